@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+// RunAdmin creates the first admin of an empty system, e.g.
+// `ewallet-ums admin create --username ops --email ops@example.com`. Flags
+// left out are asked for on stdin. The generated password is printed once
+// and must be changed at the first login.
+func RunAdmin(args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return fmt.Errorf("usage: admin create [--username <username>] [--email <email>] [--phone-number <phone>]")
+	}
+
+	fs := flag.NewFlagSet("admin create", flag.ContinueOnError)
+	username := fs.String("username", "", "username of the admin, asked for when empty")
+	email := fs.String("email", "", "email of the admin, asked for when empty")
+	phoneNumber := fs.String("phone-number", "", "optional phone number of the admin")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	stdin := bufio.NewReader(os.Stdin)
+	for _, field := range []struct {
+		prompt string
+		value  *string
+	}{{"username", username}, {"email", email}} {
+		if *field.value != "" {
+			continue
+		}
+		value, err := prompt(stdin, field.prompt)
+		if err != nil {
+			return err
+		}
+		*field.value = value
+	}
+
+	req := models.CreateAdminRequest{Username: *username, Email: *email, PhoneNumber: *phoneNumber}
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("invalid admin: %v", err)
+	}
+
+	svc := &services.UserAdminService{
+		AdminRepo:      &repository.AdminRepository{DB: helpers.DB},
+		UserRepo:       &repository.UserRepository{DB: helpers.DB},
+		SessionRepo:    &repository.SessionRepository{DB: helpers.DB},
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		PasswordHasher: helpers.NewPasswordHasher(helpers.GetEnvInt("BCRYPT_MAX_CONCURRENCY", 0)),
+	}
+	user, password, err := svc.CreateAdmin(context.Background(), constants.AuditActorSystem, req)
+	if err != nil {
+		return err
+	}
+
+	helpers.Logger.Infof("admin %s created with user id %d", user.Username, user.ID)
+	// the password is only printed, never logged
+	fmt.Printf("temporary password: %s\nlog in with it and a new_password to set your own\n", password)
+	return nil
+}
+
+func prompt(r *bufio.Reader, name string) (string, error) {
+	fmt.Printf("%s: ", name)
+	line, err := r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read %s: %v", name, err)
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+const backupUsage = "usage: backup (export [--tables users,audit] [--prefix <prefix>] | verify --key <object key>)"
+
+// RunBackup dumps tables into encrypted archives in object storage and
+// checks they can be read back, for DR runbooks, e.g.
+// `ewallet-ums backup export --tables users,audit` and
+// `ewallet-ums backup verify --key backups/20260101T000000Z-users-audit.umsbak`.
+func RunBackup(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(backupUsage)
+	}
+
+	encrypter, err := newBackupKeyEncrypter()
+	if err != nil {
+		return err
+	}
+
+	svc := &services.BackupService{
+		BackupRepo: &repository.BackupRepository{DB: helpers.DB},
+		AuditRepo:  &repository.AuditRepository{DB: helpers.DB},
+		Storage:    newObjectStorage(helpers.NewHTTPClient()),
+		Encrypter:  encrypter,
+	}
+
+	ctx := context.Background()
+	var result models.BackupResult
+	switch args[0] {
+	case "export":
+		fs := flag.NewFlagSet("backup export", flag.ContinueOnError)
+		tables := fs.String("tables", constants.BackupTableUsers+","+constants.BackupTableAudit, "comma separated tables to dump: users, audit")
+		prefix := fs.String("prefix", helpers.GetEnv("BACKUP_OBJECT_PREFIX", "backups/"), "object key prefix of the archive")
+		fs.IntVar(&svc.BatchSize, "batch-size", 1000, "rows read per query")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if svc.BatchSize <= 0 {
+			return fmt.Errorf("--batch-size must be positive, got %d", svc.BatchSize)
+		}
+
+		names := strings.Split(*tables, ",")
+		for _, name := range names {
+			if !slices.Contains([]string{constants.BackupTableUsers, constants.BackupTableAudit}, name) {
+				return fmt.Errorf("unknown table %q, want users or audit", name)
+			}
+		}
+		result, err = svc.Export(ctx, names, *prefix, time.Now())
+	case "verify":
+		fs := flag.NewFlagSet("backup verify", flag.ContinueOnError)
+		key := fs.String("key", "", "object key of the archive")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *key == "" {
+			return fmt.Errorf("--key is required")
+		}
+		result, err = svc.Verify(ctx, *key)
+	default:
+		return fmt.Errorf(backupUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// newBackupKeyEncrypter wraps backup data keys with BACKUP_MASTER_KEYS, kept
+// apart from the PII master keys so a backup can be restored where only the
+// backup keys are held. Deployments with a KMS swap in a helpers.KeyEncrypter
+// backed by it here; archives only record the key id, so older archives stay
+// readable while the old key is listed.
+func newBackupKeyEncrypter() (helpers.KeyEncrypter, error) {
+	keys := helpers.GetEnv("BACKUP_MASTER_KEYS", "")
+	if keys == "" {
+		return nil, fmt.Errorf("BACKUP_MASTER_KEYS is required")
+	}
+	return helpers.NewLocalKeyEncrypter(keys, helpers.GetEnv("BACKUP_ACTIVE_MASTER_KEY", ""))
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"ewallet-ums/helpers"
+)
+
+func TestRunBackupBatchSize(t *testing.T) {
+	helpers.Env = map[string]string{
+		"BACKUP_MASTER_KEYS":       "test:" + base64.StdEncoding.EncodeToString(make([]byte, 32)),
+		"BACKUP_ACTIVE_MASTER_KEY": "test",
+	}
+	t.Cleanup(func() { helpers.Env = map[string]string{} })
+
+	// batches of none would make an empty backup that still verifies
+	for _, batch := range []string{"--batch-size=0", "--batch-size=-1"} {
+		if err := RunBackup([]string{"export", batch}); err == nil || !strings.Contains(err.Error(), "--batch-size") {
+			t.Errorf("got err %v, want %s refused", err, batch)
+		}
+	}
+}
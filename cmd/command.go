@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// RunCommand dispatches the CLI subcommands that run instead of the servers.
+func RunCommand(name string, args []string) {
+	var err error
+
+	switch name {
+	case "seed":
+		err = RunSeed(args)
+	case "pii-rotate":
+		err = RunPIIRotate(args)
+	case "retention":
+		err = RunRetention(args)
+	case "client":
+		err = RunClient(args)
+	case "user-import":
+		err = RunUserImport(args)
+	case "token":
+		err = RunToken(args)
+	case "admin":
+		err = RunAdmin(args)
+	case "dead-letters":
+		err = RunDeadLetters(args)
+	case "backup":
+		err = RunBackup(args)
+	case "migrate":
+		err = RunMigrate(args)
+	default:
+		err = fmt.Errorf("unknown command %q", name)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
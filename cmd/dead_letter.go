@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+const deadLetterUsage = "usage: dead-letters (list [--status <status>] [--topic <topic>] | show <id> | fix <id> --payload <json> --note <note> | replay <id> | discard <id> --note <note>)"
+
+// RunDeadLetters inspects and resolves dead letters without the admin API,
+// e.g. `ewallet-ums dead-letters list --status pending` or
+// `ewallet-ums dead-letters replay 42`. Replays go through redis like the
+// API's, so they are handled by the running consumers.
+func RunDeadLetters(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(deadLetterUsage)
+	}
+
+	helpers.SetupRedis()
+
+	svc := &services.DeadLetterService{
+		DeadLetterRepo: &repository.DeadLetterRepository{DB: helpers.DB},
+		Publisher:      &external.EventPublisher{Redis: helpers.Redis},
+		Consumer:       &external.EventConsumer{Redis: helpers.Redis},
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+	}
+
+	ctx := context.Background()
+	if args[0] == "list" {
+		fs := flag.NewFlagSet("dead-letters list", flag.ContinueOnError)
+		status := fs.String("status", constants.DeadLetterStatusPending, "only dead letters of this status, empty for all")
+		topic := fs.String("topic", "", "only dead letters of this topic")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		req := models.DeadLetterListRequest{Status: *status, Topic: *topic}
+		for {
+			page, err := svc.GetDeadLetters(ctx, req)
+			if err != nil {
+				return err
+			}
+			for _, deadLetter := range page.Items {
+				fmt.Printf("%d\t%s\t%s\t%s\t%s\t%s\n", deadLetter.ID, deadLetter.CreatedAt.Format(time.RFC3339), deadLetter.Status, deadLetter.Direction, deadLetter.Topic, deadLetter.Error)
+			}
+			if page.NextCursor == "" {
+				return nil
+			}
+			req.Cursor = page.NextCursor
+		}
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf(deadLetterUsage)
+	}
+	deadLetterID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid dead letter id %q", args[1])
+	}
+
+	fs := flag.NewFlagSet("dead-letters "+args[0], flag.ContinueOnError)
+	payload := fs.String("payload", "", "the fixed JSON payload")
+	note := fs.String("note", "", "why the dead letter is fixed or discarded, kept in the audit log")
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+
+	var deadLetter models.DeadLetter
+	switch args[0] {
+	case "show":
+		deadLetter, err = svc.GetDeadLetter(ctx, deadLetterID)
+	case "fix":
+		req := models.DeadLetterUpdateRequest{Payload: json.RawMessage(*payload), Note: *note}
+		if err := req.Validate(); err != nil {
+			return fmt.Errorf("--payload and --note are required")
+		}
+		deadLetter, err = svc.UpdateDeadLetter(ctx, constants.AuditActorSystem, deadLetterID, req)
+	case "replay":
+		deadLetter, err = svc.ReplayDeadLetter(ctx, constants.AuditActorSystem, deadLetterID)
+	case "discard":
+		req := models.DeadLetterDiscardRequest{Note: *note}
+		if err := req.Validate(); err != nil {
+			return fmt.Errorf("--note is required")
+		}
+		deadLetter, err = svc.DiscardDeadLetter(ctx, constants.AuditActorSystem, deadLetterID, req)
+	default:
+		return fmt.Errorf(deadLetterUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(deadLetter)
+}
@@ -1,57 +1,465 @@
 package cmd
 
 import (
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"ewallet-ums/constants"
 	"ewallet-ums/external"
 	"ewallet-ums/helpers"
 	"ewallet-ums/internal/api"
 	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/locker"
+	"ewallet-ums/internal/models"
 	"ewallet-ums/internal/repository"
 	"ewallet-ums/internal/services"
 )
 
 type Dependency struct {
-	UserRepo interfaces.IUserRepository
+	UserRepo    interfaces.IUserReader
+	SessionRepo interfaces.ISessionRepository
+	AdminRepo   interfaces.IAdminRepository
+	TokenCache  interfaces.ITokenCacheRepository
+
+	StatelessValidation *helpers.Reloadable[bool]
+	// AllowRawToken accepts tokens sent without the Bearer scheme, for
+	// clients that predate it.
+	AllowRawToken bool
+	// TicketRefRequired refuses sensitive admin actions without a ticket
+	// reference.
+	TicketRefRequired bool
+
+	ReplayProtection ReplayProtection
+
+	SigningKeys    map[string][]byte
+	SigningMaxSkew time.Duration
+
+	OAuthClients map[string][]byte
+
+	CallerGuard interfaces.ICallerGuardRepository
+	// Quotas cap each user's calls to expensive endpoints per day.
+	Quotas            interfaces.IUserQuotaService
+	CallerGuardConfig *helpers.Reloadable[CallerGuardConfig]
+
+	// TokenValidation and AdminClientNames authenticate UserAdminService callers.
+	TokenValidation  interfaces.ITokenValidationService
+	AdminClientNames []string
+	// CallerNames are the client certificate SANs InterceptorCallerAuth
+	// accepts, besides service account tokens.
+	CallerNames        []string
+	CallerAuthEnforced bool
+
+	// RepeatedQueryThreshold is how many times one request may run the same
+	// query before it is reported as a likely N+1.
+	RepeatedQueryThreshold int
 
-	HealthcheckAPI  interfaces.IHealthcheckHandler
-	RegisterAPI     interfaces.IRegisterHandler
-	LoginAPI        interfaces.ILoginHandler
-	LogoutAPI       interfaces.ILogoutHandler
-	RefreshTokenAPI interfaces.IRefreshTokenHandler
+	RouteTimeouts    RouteTimeouts
+	RouteConcurrency RouteConcurrency
+	BodyLimits       BodyLimits
+
+	// AuthLogSampler thins out the logs of rejected requests.
+	AuthLogSampler *helpers.LogSampler
+
+	// ErrorReporter receives 5xx errors and panics, nil without SENTRY_DSN.
+	ErrorReporter interfaces.IErrorReporter
+
+	HealthcheckAPI      *api.Healthcheck
+	RegisterAPI         interfaces.IRegisterHandler
+	OTPAPI              interfaces.IOTPHandler
+	LoginAPI            interfaces.ILoginHandler
+	LogoutAPI           interfaces.ILogoutHandler
+	RefreshTokenAPI     interfaces.IRefreshTokenHandler
+	WalletStatusAPI     interfaces.IWalletStatusHandler
+	SessionAPI          interfaces.ISessionHandler
+	LoginHistoryAPI     interfaces.ILoginHistoryHandler
+	ProfileAPI          interfaces.IProfileHandler
+	ConsentAPI          interfaces.IConsentHandler
+	GuestAPI            interfaces.IGuestHandler
+	SecurityQuestionAPI interfaces.ISecurityQuestionHandler
+	AccountRecoveryAPI  interfaces.IAccountRecoveryHandler
+	AccountDeletionAPI  interfaces.IAccountDeletionHandler
+	NotificationAPI     interfaces.INotificationHandler
+
+	AdminApprovalAPI        interfaces.IAdminApprovalHandler
+	LegalHoldAPI            interfaces.ILegalHoldHandler
+	AuditAPI                interfaces.IAuditHandler
+	ServiceAccountAPI       interfaces.IServiceAccountHandler
+	UserImportAPI           interfaces.IUserImportHandler
+	UserExportAPI           interfaces.IUserExportHandler
+	LoginVelocityAPI        interfaces.ILoginVelocityHandler
+	AdminUserAPI            interfaces.IAdminUserHandler
+	TokenRevocationAPI      interfaces.ITokenRevocationHandler
+	WalletReconciliationAPI interfaces.IWalletReconciliationHandler
+	LogLevelAPI             interfaces.ILogLevelHandler
+	EmailTemplateAPI        interfaces.IEmailTemplateHandler
+	StatsAPI                interfaces.IStatsHandler
+	ReservedUsernameAPI     interfaces.IReservedUsernameHandler
+	DeadLetterAPI           interfaces.IDeadLetterHandler
+	UserQuotaAPI            interfaces.IUserQuotaHandler
+	KycAPI                  interfaces.IKycHandler
+	ScreeningAPI            interfaces.IScreeningHandler
+	AccountNoteAPI          interfaces.IAccountNoteHandler
 
 	TokenValidationAPI *api.TokenValidationHandler
+	UserAdminAPI       *api.UserAdminHandler
+	EntitlementAPI     *api.EntitlementHandler
+	AddressAPI         *api.AddressHandler
+	IntrospectionAPI   interfaces.IIntrospectionHandler
+	OAuthTokenAPI      interfaces.IOAuthTokenHandler
+
+	SessionCleanup       interfaces.ISessionCleanupService
+	Retention            interfaces.IRetentionService
+	Anonymization        interfaces.IAnonymizationService
+	AccountDeletion      interfaces.IAccountDeletionService
+	WalletProvisioning   interfaces.IWalletProvisioningService
+	UserExport           interfaces.IUserExportService
+	ActivityDigest       interfaces.IActivityDigestService
+	NotificationSummary  interfaces.INotificationSummaryService
+	WalletReconciliation interfaces.IWalletReconciliationService
+	Screening            interfaces.IScreeningService
+	UserPurge            interfaces.IUserPurgeService
+	Inbox                interfaces.IInboxService
+	Stats                interfaces.IStatsService
+	// Entitlements add the entitlements claim to the tokens issued over HTTP.
+	Entitlements interfaces.IEntitlementService
+
+	// Locker keeps each worker job on one instance, JobLockTTL is how long a
+	// crashed instance holds on to its jobs.
+	Locker     interfaces.ILocker
+	JobLockTTL time.Duration
 }
 
 func dependencyInject() Dependency {
-	healthcheckSvc := &services.Healthcheck{}
+	httpClient := helpers.NewHTTPClient()
+
+	eventBus := &external.EventPublisher{
+		Redis: helpers.Redis,
+	}
+
+	extWallet := &external.ExtWallet{
+		HTTPClient: httpClient,
+	}
+
+	healthcheckSvc := &services.Healthcheck{
+		HealthcheckRepository: &repository.HealthcheckRepository{DB: helpers.DB, Redis: helpers.Redis},
+		ExternalWallet:        extWallet,
+		Timeout:               time.Duration(helpers.GetEnvInt("HEALTHCHECK_TIMEOUT_MS", 1000)) * time.Millisecond,
+	}
 	healthcheckAPI := &api.Healthcheck{
 		HealthcheckServices: healthcheckSvc,
 	}
 
-	extWallet := &external.ExtWallet{}
-
 	userRepo := &repository.UserRepository{
 		DB: helpers.DB,
 	}
 
+	sessionRepo := &repository.SessionRepository{
+		DB: helpers.DB,
+	}
+
+	clientRepo := &repository.ClientRepository{
+		DB: helpers.DB,
+	}
+
+	refreshTokenRepo := &repository.RefreshTokenRepository{
+		DB: helpers.DB,
+	}
+
+	auditRepo := &repository.AuditRepository{
+		DB: helpers.DB,
+	}
+
+	// consumers are named after the host, so a restarted instance picks up
+	// the events it left pending
+	hostname, _ := os.Hostname()
+	inboxClaimTimeout := time.Duration(helpers.GetEnvInt("INBOX_RECLAIM_IDLE_SECONDS", 60)) * time.Second
+	eventConsumer := &external.EventConsumer{
+		Redis:         helpers.Redis,
+		Group:         helpers.GetEnv("INBOX_CONSUMER_GROUP", "ewallet-ums"),
+		Consumer:      hostname,
+		ReclaimIdle:   inboxClaimTimeout,
+		MaxDeliveries: int64(helpers.GetEnvInt("INBOX_MAX_DELIVERIES", 10)),
+	}
+
+	deadLetterSvc := &services.DeadLetterService{
+		DeadLetterRepo: &repository.DeadLetterRepository{DB: helpers.DB},
+		Publisher:      eventBus,
+		Consumer:       eventConsumer,
+		AuditRepo:      auditRepo,
+	}
+	eventConsumer.DeadLetter = deadLetterSvc.RecordConsumed
+
+	deadLetterAPI := &api.DeadLetterHandler{
+		DeadLetterService: deadLetterSvc,
+	}
+
+	// events that fail to publish are kept as dead letters
+	eventPublisher := &services.DeadLetterPublisher{
+		Publisher:   eventBus,
+		DeadLetters: deadLetterSvc,
+	}
+
+	statelessValidation := helpers.NewReloadable(func() bool {
+		return helpers.GetEnvBool("AUTH_STATELESS_VALIDATION", false)
+	})
+
+	tokenCache := repository.NewTokenCacheRepository(
+		helpers.Redis,
+		helpers.GetEnvInt("TOKEN_CACHE_SIZE", 10000),
+		time.Duration(helpers.GetEnvInt("TOKEN_CACHE_TTL_SECONDS", 30))*time.Second,
+	)
+
+	activeUsersRepo := &repository.ActiveUsersRepository{Redis: helpers.Redis}
+
+	signingKeys, err := helpers.ParseSigningKeys(helpers.GetEnv("INTERNAL_SIGNING_KEYS", ""))
+	if err != nil {
+		log.Fatal("failed to load internal signing keys: ", err)
+	}
+
+	// registered OAuth clients use the same "id:secret,..." format
+	oauthClients, err := helpers.ParseSigningKeys(helpers.GetEnv("OAUTH_CLIENTS", ""))
+	if err != nil {
+		log.Fatal("failed to load oauth clients: ", err)
+	}
+
+	tokenExchangePolicy, err := helpers.ParseTokenExchangePolicy(helpers.GetEnv("TOKEN_EXCHANGE_POLICY", ""))
+	if err != nil {
+		log.Fatal("failed to load token exchange policy: ", err)
+	}
+
+	passwordHasher := helpers.NewPasswordHasher(helpers.GetEnvInt("BCRYPT_MAX_CONCURRENCY", 0))
+
+	walletProvisioningRepo := &repository.WalletProvisioningRepository{
+		DB: helpers.DB,
+	}
+
+	userClaimsSvc := &services.UserClaimsService{
+		SessionRepo:    sessionRepo,
+		TokenCache:     tokenCache,
+		EventPublisher: eventPublisher,
+	}
+
+	reservedUsernameSvc := &services.ReservedUsernameService{
+		ReservedUsernameRepo: &repository.ReservedUsernameRepository{DB: helpers.DB},
+		UserRepo:             userRepo,
+		AuditRepo:            auditRepo,
+		UserClaims:           userClaimsSvc,
+	}
+
+	reservedUsernameAPI := &api.ReservedUsernameHandler{
+		ReservedUsernameService: reservedUsernameSvc,
+	}
+
 	registerSvc := &services.RegisterService{
-		UserRepo:       userRepo,
-		ExternalWallet: extWallet,
+		WalletProvisioningRepo: walletProvisioningRepo,
+		ClientRepo:             clientRepo,
+		PasswordHasher:         passwordHasher,
+		EventPublisher:         eventPublisher,
+		ReservedUsernames:      reservedUsernameSvc,
 	}
 
 	registerAPI := &api.RegisterHandler{
 		RegisterService: registerSvc,
 	}
 
+	// passwordless accounts need an SMS gateway, SMS_LOG_ONLY logs the codes
+	// instead for development
+	otpSvc := &services.OTPService{
+		OTPRepo:          &repository.OTPRepository{Redis: helpers.Redis},
+		UserRepo:         userRepo,
+		Register:         registerSvc,
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		TTL:              time.Duration(helpers.GetEnvInt("OTP_TTL_SECONDS", 300)) * time.Second,
+		ResendInterval:   time.Duration(helpers.GetEnvInt("OTP_RESEND_INTERVAL_SECONDS", 60)) * time.Second,
+		MaxAttempts:      helpers.GetEnvInt("OTP_MAX_ATTEMPTS", 5),
+	}
+	if url := helpers.GetEnv("SMS_GATEWAY_URL", ""); url != "" {
+		otpSvc.SMS = &external.WebhookSMSSender{
+			URL:        url,
+			APIKey:     helpers.GetEnv("SMS_GATEWAY_API_KEY", ""),
+			HTTPClient: httpClient,
+		}
+	} else if helpers.GetEnvBool("SMS_LOG_ONLY", false) {
+		otpSvc.SMS = external.LogSMSSender{}
+	}
+
+	otpAPI := &api.OTPHandler{
+		OTPService: otpSvc,
+	}
+
+	loginHistoryRepo := &repository.LoginHistoryRepository{
+		DB: helpers.DB,
+	}
+
+	// without an SMTP relay security emails are only logged
+	var mailer interfaces.IMailer = external.LogMailer{}
+	if smtpHost := helpers.GetEnv("SMTP_HOST", ""); smtpHost != "" {
+		mailer = &external.SMTPMailer{
+			Addr:     smtpHost + ":" + helpers.GetEnv("SMTP_PORT", "587"),
+			Username: helpers.GetEnv("SMTP_USERNAME", ""),
+			Password: helpers.GetEnv("SMTP_PASSWORD", ""),
+			From:     helpers.GetEnv("MAIL_FROM", ""),
+		}
+	}
+
+	mailDefaultLocale := helpers.GetEnv("MAIL_DEFAULT_LOCALE", constants.LocaleEnglish)
+
+	emailBranding := &services.EmailBranding{
+		ClientRepo: clientRepo,
+		Default: models.Brand{
+			Name:       helpers.GetEnv("MAIL_BRAND_NAME", ""),
+			SupportURL: helpers.GetEnv("MAIL_SUPPORT_URL", ""),
+		},
+	}
+	if dir := helpers.GetEnv("MAIL_BRAND_TEMPLATES_DIR", ""); dir != "" {
+		if err := emailBranding.LoadTemplates(dir); err != nil {
+			log.Fatal("failed to load brand email templates: ", err)
+		}
+	}
+
+	notificationRepo := &repository.NotificationRepository{DB: helpers.DB}
+	notificationSvc := &services.NotificationService{
+		NotificationRepo: notificationRepo,
+		UserRepo:         userRepo,
+		Mailer:           mailer,
+		Branding:         emailBranding,
+		Throttle:         &repository.NotificationThrottleRepository{Redis: helpers.Redis},
+		ThrottleWindow:   time.Duration(helpers.GetEnvInt("NOTIFICATION_THROTTLE_WINDOW_SECONDS", 900)) * time.Second,
+		BaseURL:          helpers.GetEnv("APP_BASE_URL", ""),
+		DefaultLocale:    mailDefaultLocale,
+	}
+
+	notificationSummarySvc := &services.NotificationSummaryService{
+		NotificationRepo: notificationRepo,
+		Notifications:    notificationSvc,
+		BatchSize:        helpers.GetEnvInt("NOTIFICATION_SUMMARY_BATCH_SIZE", 100),
+		Interval:         time.Duration(helpers.GetEnvInt("NOTIFICATION_SUMMARY_INTERVAL_SECONDS", 60)) * time.Second,
+	}
+
+	emailTemplateAPI := &api.EmailTemplateHandler{
+		EmailTemplateService: &services.EmailTemplateService{Branding: emailBranding, DefaultLocale: mailDefaultLocale},
+	}
+
+	activityDigestSvc := &services.ActivityDigestService{
+		DigestRepo:    &repository.ActivityDigestRepository{DB: helpers.DB},
+		Notifications: notificationSvc,
+		BatchSize:     helpers.GetEnvInt("ACTIVITY_DIGEST_BATCH_SIZE", 100),
+		Interval:      time.Duration(helpers.GetEnvInt("ACTIVITY_DIGEST_INTERVAL_SECONDS", 3600)) * time.Second,
+	}
+
+	notificationAPI := &api.NotificationHandler{
+		NotificationService: notificationSvc,
+	}
+
+	var alerter interfaces.IAlerter = external.LogAlerter{}
+	if url := helpers.GetEnv("ALERT_SLACK_WEBHOOK_URL", ""); url != "" {
+		alerter = &external.SlackAlerter{WebhookURL: url, HTTPClient: helpers.NewHTTPClient()}
+	} else if url := helpers.GetEnv("ALERT_WEBHOOK_URL", ""); url != "" {
+		alerter = &external.WebhookAlerter{URL: url, HTTPClient: helpers.NewHTTPClient()}
+	}
+
+	loginVelocitySvc := &services.LoginVelocityService{
+		VelocityRepo: &repository.LoginVelocityRepository{Redis: helpers.Redis},
+		Alerter:      alerter,
+		Window:       time.Duration(helpers.GetEnvInt("LOGIN_VELOCITY_WINDOW_SECONDS", 300)) * time.Second,
+		Thresholds: map[string]int{
+			constants.LoginSourceIP:      helpers.GetEnvInt("LOGIN_VELOCITY_IP_THRESHOLD", 20),
+			constants.LoginSourceASN:     helpers.GetEnvInt("LOGIN_VELOCITY_ASN_THRESHOLD", 200),
+			constants.LoginSourceCountry: helpers.GetEnvInt("LOGIN_VELOCITY_COUNTRY_THRESHOLD", 1000),
+		},
+	}
+
+	walletReconciliationSvc := &services.WalletReconciliationService{
+		WalletProvisioningRepo: walletProvisioningRepo,
+		AuditRepo:              auditRepo,
+		Alerter:                alerter,
+		SLA:                    time.Duration(helpers.GetEnvInt("WALLET_PROVISIONING_SLA_SECONDS", 900)) * time.Second,
+		Interval:               time.Duration(helpers.GetEnvInt("WALLET_RECONCILIATION_INTERVAL_SECONDS", 3600)) * time.Second,
+	}
+
+	walletReconciliationAPI := &api.WalletReconciliationHandler{
+		WalletReconciliationService: walletReconciliationSvc,
+	}
+
+	statsSvc := &services.StatsService{
+		StatsRepo:   &repository.StatsRepository{DB: helpers.DB},
+		SessionRepo: sessionRepo,
+		ActiveUsers: activeUsersRepo,
+		Days:        helpers.GetEnvInt("STATS_DAYS", 90),
+		Interval:    time.Duration(helpers.GetEnvInt("STATS_REFRESH_INTERVAL_SECONDS", 300)) * time.Second,
+	}
+
+	statsAPI := &api.StatsHandler{
+		StatsService: statsSvc,
+	}
+
+	loginVelocityAPI := &api.LoginVelocityHandler{
+		LoginVelocityService: loginVelocitySvc,
+	}
+
+	logLevelAPI := &api.LogLevelHandler{
+		LogLevelService: &services.LogLevelService{AuditRepo: auditRepo},
+	}
+
+	accountDeletionSvc := &services.AccountDeletionService{
+		DeletionRepo:   &repository.AccountDeletionRepository{DB: helpers.DB},
+		UserRepo:       userRepo,
+		SessionRepo:    sessionRepo,
+		AuditRepo:      auditRepo,
+		TokenCache:     tokenCache,
+		PasswordHasher: passwordHasher,
+		EventPublisher: eventPublisher,
+		Window:         time.Duration(helpers.GetEnvInt("ACCOUNT_DELETION_WINDOW_DAYS", 30)) * 24 * time.Hour,
+		BatchSize:      helpers.GetEnvInt("ACCOUNT_DELETION_BATCH_SIZE", 100),
+		Interval:       time.Duration(helpers.GetEnvInt("ACCOUNT_DELETION_INTERVAL_SECONDS", 3600)) * time.Second,
+	}
+
+	accountDeletionAPI := &api.AccountDeletionHandler{
+		AccountDeletionService: accountDeletionSvc,
+	}
+
+	trustedDeviceRepo := &repository.TrustedDeviceRepository{DB: helpers.DB}
 	loginSvc := &services.LoginService{
-		UserRepo: userRepo,
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		ClientRepo:       clientRepo,
+		LoginHistoryRepo: loginHistoryRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		PasswordHasher:   passwordHasher,
+		Notifications:    notificationSvc,
+		Velocity:         loginVelocitySvc,
+		AccountDeletion:  accountDeletionSvc,
+		ActiveUsers:      activeUsersRepo,
+		OTP:              otpSvc,
+	}
+	if ttl := helpers.GetEnvInt("TRUSTED_DEVICE_TTL_DAYS", 30); ttl > 0 {
+		loginSvc.TrustedDevices = trustedDeviceRepo
+		loginSvc.TrustedDeviceTTL = time.Duration(ttl) * 24 * time.Hour
+	}
+	if accountID := helpers.GetEnv("GEOIP_ACCOUNT_ID", ""); accountID != "" {
+		loginSvc.GeoIP = external.NewMaxMindGeoIP(
+			helpers.NewHTTPClient(),
+			helpers.GetEnv("GEOIP_BASE_URL", "https://geoip.maxmind.com"),
+			accountID,
+			helpers.GetEnv("GEOIP_LICENSE_KEY", ""),
+			helpers.GetEnvInt("GEOIP_CACHE_SIZE", 10000),
+			time.Duration(helpers.GetEnvInt("GEOIP_CACHE_TTL_SECONDS", 86400))*time.Second,
+		)
 	}
 
 	loginAPI := &api.LoginHandler{
-		LoginService: loginSvc,
+		LoginService:  loginSvc,
+		ASNHeader:     helpers.GetEnv("LOGIN_ASN_HEADER", ""),
+		CountryHeader: helpers.GetEnv("LOGIN_COUNTRY_HEADER", ""),
 	}
 
 	logoutSvc := &services.LogoutService{
-		UserRepo: userRepo,
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		TokenCache:       tokenCache,
 	}
 
 	logoutAPI := &api.LogoutHandler{
@@ -59,28 +467,582 @@ func dependencyInject() Dependency {
 	}
 
 	refreshTokenSvc := &services.RefreshTokenService{
-		UserRepo: userRepo,
+		SessionRepo:      sessionRepo,
+		ClientRepo:       clientRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		AuditRepo:        auditRepo,
+		TokenCache:       tokenCache,
+		ActiveUsers:      activeUsersRepo,
 	}
 
 	refreshTokenAPI := &api.RefreshTokenHandler{
 		RefreshTokenService: refreshTokenSvc,
 	}
 
+	entitlementsByKycStatus, err := services.ParseEntitlementsByKycStatus(helpers.GetEnv("ENTITLEMENTS_BY_KYC_STATUS", ""))
+	if err != nil {
+		log.Fatal("failed to load kyc entitlements: ", err)
+	}
+	entitlementSvc := &services.EntitlementService{
+		EntitlementRepo: &repository.EntitlementRepository{DB: helpers.DB},
+		UserRepo:        userRepo,
+		AuditRepo:       auditRepo,
+		UserClaims:      userClaimsSvc,
+		ByKycStatus:     entitlementsByKycStatus,
+	}
+
+	entitlementAPI := &api.EntitlementHandler{
+		EntitlementService: entitlementSvc,
+	}
+
+	addressRepo := &repository.AddressRepository{DB: helpers.DB}
+	addressAPI := &api.AddressHandler{
+		AddressService: &services.AddressService{
+			AddressRepo: addressRepo,
+			UserRepo:    userRepo,
+			AuditRepo:   auditRepo,
+			MaxPerUser:  helpers.GetEnvInt("ADDRESS_MAX_PER_USER", 10),
+		},
+	}
+
+	kycRequiredFields, err := services.ParseKycRequiredFields(helpers.GetEnv("KYC_REQUIRED_FIELDS", "default=occupation source_of_funds"))
+	if err != nil {
+		log.Fatal("failed to load kyc required fields: ", err)
+	}
+	kycAPI := &api.KycHandler{
+		KycService: &services.KycService{
+			KycRepo:        &repository.KycRepository{DB: helpers.DB},
+			AddressRepo:    addressRepo,
+			UserRepo:       userRepo,
+			AuditRepo:      auditRepo,
+			RequiredFields: kycRequiredFields,
+		},
+	}
+
+	screeningSvc := &services.ScreeningService{
+		ScreeningRepo: &repository.ScreeningRepository{DB: helpers.DB},
+		AddressRepo:   addressRepo,
+		AuditRepo:     auditRepo,
+		Alerter:       alerter,
+		Cadence:       time.Duration(helpers.GetEnvInt("SCREENING_CADENCE_DAYS", 30)) * 24 * time.Hour,
+		BatchSize:     helpers.GetEnvInt("SCREENING_BATCH_SIZE", 100),
+		Interval:      time.Duration(helpers.GetEnvInt("SCREENING_INTERVAL_SECONDS", 3600)) * time.Second,
+	}
+	if url := helpers.GetEnv("SCREENING_PROVIDER_URL", ""); url != "" {
+		screeningSvc.Provider = &external.WebhookScreeningProvider{
+			URL:        url,
+			APIKey:     helpers.GetEnv("SCREENING_PROVIDER_API_KEY", ""),
+			HTTPClient: helpers.NewHTTPClient(),
+		}
+	}
+
+	screeningAPI := &api.ScreeningHandler{
+		ScreeningService: screeningSvc,
+	}
+
 	tokenValidationSvc := &services.TokenValidationService{
-		UserRepo: userRepo,
+		UserRepo:     userRepo,
+		SessionRepo:  sessionRepo,
+		TokenCache:   tokenCache,
+		Entitlements: entitlementSvc,
+		Stateless:    statelessValidation,
 	}
 
 	tokenValidationAPI := &api.TokenValidationHandler{
 		TokenValidationService: tokenValidationSvc,
+		LogSampler:             newLogSampler("token_validation"),
 	}
 
-	return Dependency{
+	introspectionSvc := &services.IntrospectionService{
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		TokenCache:  tokenCache,
+	}
+
+	introspectionAPI := &api.IntrospectionHandler{
+		IntrospectionService: introspectionSvc,
+	}
+
+	walletProvisioningSvc := &services.WalletProvisioningService{
+		WalletProvisioningRepo: walletProvisioningRepo,
+		ExternalWallet:         extWallet,
+		BatchSize:              helpers.GetEnvInt("WALLET_PROVISIONING_BATCH_SIZE", 100),
+		MaxAttempts:            helpers.GetEnvInt("WALLET_PROVISIONING_MAX_ATTEMPTS", 10),
+		Interval:               time.Duration(helpers.GetEnvInt("WALLET_PROVISIONING_INTERVAL_SECONDS", 5)) * time.Second,
+	}
+
+	walletStatusAPI := &api.WalletStatusHandler{
+		WalletProvisioningService: walletProvisioningSvc,
+	}
+
+	sessionSvc := &services.SessionService{
+		SessionRepo: sessionRepo,
+	}
+
+	sessionAPI := &api.SessionHandler{
+		SessionService: sessionSvc,
+	}
+
+	loginHistorySvc := &services.LoginHistoryService{
+		LoginHistoryRepo: loginHistoryRepo,
+	}
+
+	loginHistoryAPI := &api.LoginHistoryHandler{
+		LoginHistoryService: loginHistorySvc,
+	}
+
+	profileSvc := &services.ProfileService{
+		UserRepo:          userRepo,
+		UserClaimsService: userClaimsSvc,
+		Notifications:     notificationSvc,
+		EmailChangeRepo:   &repository.EmailChangeRepository{DB: helpers.DB},
+		SessionRepo:       sessionRepo,
+		TokenCache:        tokenCache,
+		AuditRepo:         auditRepo,
+		RevertURL:         helpers.GetEnv("EMAIL_CHANGE_REVERT_URL", helpers.GetEnv("APP_BASE_URL", "")+"/revert-email-change"),
+		RevertTTL:         time.Duration(helpers.GetEnvInt("EMAIL_CHANGE_REVERT_TTL_HOURS", 72)) * time.Hour,
+		PasswordHasher:    passwordHasher,
+		OTP:               otpSvc,
+		TrustedDevices:    trustedDeviceRepo,
+	}
+
+	profileAPI := &api.ProfileHandler{
+		ProfileService: profileSvc,
+	}
+
+	consentSvc := &services.ConsentService{
+		ConsentRepo: &repository.ConsentRepository{DB: helpers.DB},
+	}
+
+	consentAPI := &api.ConsentHandler{
+		ConsentService: consentSvc,
+	}
+
+	adminRepo := &repository.AdminRepository{
+		DB: helpers.DB,
+	}
+
+	accountNoteAPI := &api.AccountNoteHandler{
+		AccountNoteService: &services.AccountNoteService{
+			NoteRepo:  &repository.AccountNoteRepository{DB: helpers.DB},
+			UserRepo:  userRepo,
+			AdminRepo: adminRepo,
+			AuditRepo: auditRepo,
+		},
+	}
+
+	tokenExchangeSvc := &services.TokenExchangeService{
+		TokenValidationService: tokenValidationSvc,
+		AuditRepo:              auditRepo,
+		Policy:                 tokenExchangePolicy,
+		TTL:                    time.Duration(helpers.GetEnvInt("TOKEN_EXCHANGE_TTL_SECONDS", 300)) * time.Second,
+	}
+
+	guestAPI := &api.GuestHandler{
+		GuestService: &services.GuestService{
+			UserRepo:               userRepo,
+			SessionRepo:            sessionRepo,
+			WalletProvisioningRepo: walletProvisioningRepo,
+			AuditRepo:              auditRepo,
+			TokenCache:             tokenCache,
+			RefreshTokenRepo:       refreshTokenRepo,
+			PasswordHasher:         passwordHasher,
+			ReservedUsernames:      reservedUsernameSvc,
+		},
+	}
+
+	securityQuestionAPI := &api.SecurityQuestionHandler{
+		SecurityQuestionService: &services.SecurityQuestionService{
+			SecurityQuestionRepo: &repository.SecurityQuestionRepository{DB: helpers.DB},
+			UserRepo:             userRepo,
+			SessionRepo:          sessionRepo,
+			AuditRepo:            auditRepo,
+			TokenCache:           tokenCache,
+			CallerGuard:          &repository.CallerGuardRepository{Redis: helpers.Redis},
+			PasswordHasher:       passwordHasher,
+			OTP:                  otpSvc,
+			Notifications:        notificationSvc,
+			TrustedDevices:       trustedDeviceRepo,
+			FailureLimit:         helpers.GetEnvInt("RECOVERY_FAILURE_LIMIT", 5),
+			NoPhoneFailureLimit:  helpers.GetEnvInt("RECOVERY_NO_PHONE_FAILURE_LIMIT", 2),
+			FailureWindow:        time.Duration(helpers.GetEnvInt("RECOVERY_FAILURE_WINDOW_SECONDS", 3600)) * time.Second,
+			LockDuration:         time.Duration(helpers.GetEnvInt("RECOVERY_LOCK_SECONDS", 86400)) * time.Second,
+		},
+	}
+
+	serviceAccountSvc := &services.ServiceAccountService{
+		ServiceAccountRepo: &repository.ServiceAccountRepository{DB: helpers.DB},
 		UserRepo:           userRepo,
-		HealthcheckAPI:     healthcheckAPI,
-		RegisterAPI:        registerAPI,
-		LoginAPI:           loginAPI,
-		LogoutAPI:          logoutAPI,
-		RefreshTokenAPI:    refreshTokenAPI,
-		TokenValidationAPI: tokenValidationAPI,
+		SessionRepo:        sessionRepo,
+		AuditRepo:          auditRepo,
+		TokenCache:         tokenCache,
+		PasswordHasher:     passwordHasher,
+		TokenTTL:           time.Duration(helpers.GetEnvInt("SERVICE_ACCOUNT_TOKEN_TTL_SECONDS", 3600)) * time.Second,
+	}
+
+	serviceAccountAPI := &api.ServiceAccountHandler{
+		ServiceAccountService: serviceAccountSvc,
+	}
+
+	oauthTokenAPI := &api.OAuthTokenHandler{
+		TokenExchangeService:  tokenExchangeSvc,
+		ServiceAccountService: serviceAccountSvc,
+	}
+
+	objectStorage := newObjectStorage(httpClient)
+
+	accountRecoverySvc := &services.AccountRecoveryService{
+		RecoveryRepo:   &repository.AccountRecoveryRepository{DB: helpers.DB},
+		UserRepo:       userRepo,
+		SessionRepo:    sessionRepo,
+		AuditRepo:      auditRepo,
+		TokenCache:     tokenCache,
+		CallerGuard:    &repository.CallerGuardRepository{Redis: helpers.Redis},
+		PasswordHasher: passwordHasher,
+		ObjectStorage:  objectStorage,
+		Notifications:  notificationSvc,
+		TrustedDevices: trustedDeviceRepo,
+		SubmitLimit:    helpers.GetEnvInt("RECOVERY_TICKET_LIMIT", 3),
+		SubmitWindow:   time.Duration(helpers.GetEnvInt("RECOVERY_TICKET_WINDOW_SECONDS", 86400)) * time.Second,
+		ResetURL:       helpers.GetEnv("RECOVERY_RESET_URL", helpers.GetEnv("APP_BASE_URL", "")+"/reset-password"),
+		ResetTTL:       time.Duration(helpers.GetEnvInt("RECOVERY_RESET_TTL_MINUTES", 60)) * time.Minute,
+		EvidenceTTL:    time.Duration(helpers.GetEnvInt("RECOVERY_EVIDENCE_URL_TTL_SECONDS", 300)) * time.Second,
+	}
+
+	accountRecoveryAPI := &api.AccountRecoveryHandler{
+		AccountRecoveryService: accountRecoverySvc,
+		MaxBytes:               int64(helpers.GetEnvInt("RECOVERY_EVIDENCE_MAX_BYTES", 20<<20)),
+	}
+
+	userPurgeSvc := &services.UserPurgeService{
+		PurgeRepo:      &repository.UserPurgeRepository{DB: helpers.DB},
+		LegalHoldRepo:  &repository.LegalHoldRepository{DB: helpers.DB},
+		SessionRepo:    sessionRepo,
+		AuditRepo:      auditRepo,
+		TokenCache:     tokenCache,
+		EventPublisher: eventPublisher,
+		BatchSize:      helpers.GetEnvInt("USER_PURGE_BATCH_SIZE", 100),
+		Interval:       time.Duration(helpers.GetEnvInt("USER_PURGE_INTERVAL_SECONDS", 300)) * time.Second,
+	}
+
+	adminApprovalSvc := &services.AdminApprovalService{
+		AdminRepo:   adminRepo,
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		AuditRepo:   auditRepo,
+		TokenCache:  tokenCache,
+		Recovery:    accountRecoverySvc,
+		Purges:      userPurgeSvc,
+	}
+
+	adminApprovalAPI := &api.AdminApprovalHandler{
+		AdminApprovalService: adminApprovalSvc,
+	}
+
+	userAdminSvc := &services.UserAdminService{
+		AdminRepo:      adminRepo,
+		UserRepo:       userRepo,
+		SessionRepo:    sessionRepo,
+		AuditRepo:      auditRepo,
+		TokenCache:     tokenCache,
+		Approvals:      adminApprovalSvc,
+		TrustedDevices: trustedDeviceRepo,
+	}
+
+	userAdminAPI := &api.UserAdminHandler{
+		UserAdminService: userAdminSvc,
+	}
+
+	adminUserAPI := &api.AdminUserHandler{
+		UserAdminService: userAdminSvc,
+	}
+
+	tokenRevocationAPI := &api.TokenRevocationHandler{
+		TokenRevocationService: &services.TokenRevocationService{
+			SessionRepo:      sessionRepo,
+			RefreshTokenRepo: refreshTokenRepo,
+			AuditRepo:        auditRepo,
+			TokenCache:       tokenCache,
+			TrustedDevices:   trustedDeviceRepo,
+		},
+	}
+
+	inboxSvc := &services.InboxService{
+		InboxRepo:              &repository.InboxRepository{DB: helpers.DB},
+		UserAdmin:              userAdminSvc,
+		WalletProvisioningRepo: walletProvisioningRepo,
+		AuditRepo:              auditRepo,
+		Consumer:               eventConsumer,
+		DeadLetters:            deadLetterSvc,
+		ClaimTimeout:           inboxClaimTimeout,
+	}
+
+	userImportAPI := &api.UserImportHandler{
+		UserImportService: newUserImportService(passwordHasher),
+		MaxBytes:          int64(helpers.GetEnvInt("USER_IMPORT_MAX_BYTES", 32<<20)),
+	}
+
+	userExportSvc := &services.UserExportService{
+		UserExportRepo: &repository.UserExportRepository{DB: helpers.DB},
+		AdminRepo:      adminRepo,
+		AuditRepo:      auditRepo,
+		ObjectStorage:  objectStorage,
+		BatchSize:      helpers.GetEnvInt("USER_EXPORT_BATCH_SIZE", 1000),
+		Interval:       time.Duration(helpers.GetEnvInt("USER_EXPORT_INTERVAL_SECONDS", 10)) * time.Second,
+		Timeout:        time.Duration(helpers.GetEnvInt("USER_EXPORT_TIMEOUT_SECONDS", 3600)) * time.Second,
+		URLTTL:         time.Duration(helpers.GetEnvInt("USER_EXPORT_URL_TTL_SECONDS", 900)) * time.Second,
+	}
+
+	userExportAPI := &api.UserExportHandler{
+		UserExportService: userExportSvc,
+	}
+
+	legalHoldSvc := &services.LegalHoldService{
+		LegalHoldRepo: &repository.LegalHoldRepository{DB: helpers.DB},
+		UserRepo:      userRepo,
+		AuditRepo:     auditRepo,
+	}
+
+	legalHoldAPI := &api.LegalHoldHandler{
+		LegalHoldService: legalHoldSvc,
+	}
+
+	auditAPI := &api.AuditHandler{
+		AuditService: &services.AuditService{
+			AuditRepo:     auditRepo,
+			ExportMaxRows: helpers.GetEnvInt("AUDIT_EXPORT_MAX_ROWS", 50000),
+		},
+	}
+
+	sessionCleanupSvc := &services.SessionCleanupService{
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		BatchSize:        helpers.GetEnvInt("SESSION_CLEANUP_BATCH_SIZE", 1000),
+		Interval:         time.Duration(helpers.GetEnvInt("SESSION_CLEANUP_INTERVAL_SECONDS", 300)) * time.Second,
+	}
+
+	retentionSvc := newRetentionService()
+
+	anonymizationSvc := &services.AnonymizationService{
+		AnonymizationRepo: &repository.AnonymizationRepository{DB: helpers.DB},
+		AuditRepo:         auditRepo,
+		EventPublisher:    eventPublisher,
+		GracePeriod:       time.Duration(helpers.GetEnvInt("ANONYMIZATION_GRACE_DAYS", 90)) * 24 * time.Hour,
+		BatchSize:         helpers.GetEnvInt("ANONYMIZATION_BATCH_SIZE", 100),
+		Interval:          time.Duration(helpers.GetEnvInt("ANONYMIZATION_INTERVAL_SECONDS", 3600)) * time.Second,
+	}
+
+	callerGuardConfig := helpers.NewReloadable(func() CallerGuardConfig {
+		return CallerGuardConfig{
+			RateLimit:     helpers.GetEnvInt("GRPC_CALLER_RATE_LIMIT", 6000),
+			RateWindow:    time.Duration(helpers.GetEnvInt("GRPC_CALLER_RATE_WINDOW_SECONDS", 60)) * time.Second,
+			FailureLimit:  helpers.GetEnvInt("GRPC_CALLER_FAILURE_LIMIT", 100),
+			FailureWindow: time.Duration(helpers.GetEnvInt("GRPC_CALLER_FAILURE_WINDOW_SECONDS", 300)) * time.Second,
+			BanDuration:   time.Duration(helpers.GetEnvInt("GRPC_CALLER_BAN_SECONDS", 900)) * time.Second,
+		}
+	})
+
+	routeTimeouts := RouteTimeouts{
+		Default:         time.Duration(helpers.GetEnvInt("HTTP_TIMEOUT_MS", 5000)) * time.Millisecond,
+		Login:           time.Duration(helpers.GetEnvInt("HTTP_LOGIN_TIMEOUT_MS", 2000)) * time.Millisecond,
+		Admin:           time.Duration(helpers.GetEnvInt("HTTP_ADMIN_TIMEOUT_MS", 10000)) * time.Millisecond,
+		OAuth:           time.Duration(helpers.GetEnvInt("HTTP_OAUTH_TIMEOUT_MS", 2000)) * time.Millisecond,
+		TokenValidation: time.Duration(helpers.GetEnvInt("HTTP_TOKEN_VALIDATION_TIMEOUT_MS", 500)) * time.Millisecond,
+	}
+
+	var errorReporter interfaces.IErrorReporter
+	if dsn := helpers.GetEnv("SENTRY_DSN", ""); dsn != "" {
+		sentry, err := external.NewSentryReporter(dsn, httpClient)
+		if err != nil {
+			log.Fatal("failed to set up error reporting: ", err)
+		}
+		sentry.Environment = helpers.GetEnv("SENTRY_ENVIRONMENT", helpers.GetEnv("APP_ENV", ""))
+		sentry.Release = helpers.GetEnv("SENTRY_RELEASE", "")
+		errorReporter = sentry
+	}
+
+	routeConcurrency := RouteConcurrency{
+		Default:         helpers.GetEnvInt("HTTP_MAX_INFLIGHT", 500),
+		Login:           helpers.GetEnvInt("HTTP_LOGIN_MAX_INFLIGHT", 100),
+		Register:        helpers.GetEnvInt("HTTP_REGISTER_MAX_INFLIGHT", 50),
+		Admin:           helpers.GetEnvInt("HTTP_ADMIN_MAX_INFLIGHT", 50),
+		OAuth:           helpers.GetEnvInt("HTTP_OAUTH_MAX_INFLIGHT", 200),
+		TokenValidation: helpers.GetEnvInt("HTTP_TOKEN_VALIDATION_MAX_INFLIGHT", 1000),
+		RetryAfter:      time.Duration(helpers.GetEnvInt("HTTP_SHED_RETRY_AFTER_SECONDS", 1)) * time.Second,
+	}
+
+	bodyLimits := BodyLimits{
+		MaxBytes:     int64(helpers.GetEnvInt("HTTP_MAX_BODY_BYTES", 1<<20)),
+		MaxJSONDepth: helpers.GetEnvInt("HTTP_MAX_JSON_DEPTH", 32),
+	}
+
+	var jobLocker interfaces.ILocker
+	switch backend := helpers.GetEnv("JOB_LOCK_BACKEND", constants.JobLockBackendRedis); backend {
+	case constants.JobLockBackendRedis:
+		jobLocker = &locker.RedisLocker{Redis: helpers.Redis}
+	case constants.JobLockBackendMySQL:
+		if helpers.IsSQLite(helpers.DB) {
+			log.Fatal("JOB_LOCK_BACKEND=mysql needs DB_DRIVER=mysql")
+		}
+		jobLocker = &locker.MySQLLocker{DB: helpers.DB}
+	default:
+		log.Fatalf("unknown JOB_LOCK_BACKEND %q, expected %s or %s", backend, constants.JobLockBackendRedis, constants.JobLockBackendMySQL)
+	}
+
+	userQuotaSvc := &services.UserQuotaService{
+		QuotaRepo: &repository.UserQuotaRepository{Redis: helpers.Redis},
+		Limits: map[string]int{
+			constants.QuotaUserExports: helpers.GetEnvInt("USER_QUOTA_USER_EXPORTS_PER_DAY", 10),
+			constants.QuotaUserImports: helpers.GetEnvInt("USER_QUOTA_USER_IMPORTS_PER_DAY", 20),
+		},
+	}
+
+	userQuotaAPI := &api.UserQuotaHandler{
+		UserQuotaService: userQuotaSvc,
+	}
+
+	return Dependency{
+		UserRepo:                userRepo,
+		SessionRepo:             sessionRepo,
+		AdminRepo:               adminRepo,
+		TokenCache:              tokenCache,
+		StatelessValidation:     statelessValidation,
+		AllowRawToken:           helpers.GetEnvBool("AUTH_ALLOW_RAW_TOKEN", true),
+		TicketRefRequired:       helpers.GetEnvBool("ADMIN_TICKET_REF_REQUIRED", false),
+		SigningKeys:             signingKeys,
+		SigningMaxSkew:          time.Duration(helpers.GetEnvInt("INTERNAL_SIGNING_MAX_SKEW_SECONDS", 300)) * time.Second,
+		OAuthClients:            oauthClients,
+		CallerGuard:             &repository.CallerGuardRepository{Redis: helpers.Redis},
+		CallerGuardConfig:       callerGuardConfig,
+		Quotas:                  userQuotaSvc,
+		TokenValidation:         tokenValidationSvc,
+		AdminClientNames:        helpers.GetEnvList("GRPC_ADMIN_CLIENT_NAMES"),
+		CallerNames:             helpers.GetEnvList("GRPC_CALLER_NAMES"),
+		CallerAuthEnforced:      helpers.GetEnvBool("GRPC_CALLER_AUTH_ENFORCE", true),
+		RepeatedQueryThreshold:  helpers.GetEnvInt("DB_REPEATED_QUERY_THRESHOLD", 10),
+		RouteTimeouts:           routeTimeouts,
+		RouteConcurrency:        routeConcurrency,
+		BodyLimits:              bodyLimits,
+		AuthLogSampler:          newLogSampler("auth"),
+		ErrorReporter:           errorReporter,
+		HealthcheckAPI:          healthcheckAPI,
+		RegisterAPI:             registerAPI,
+		LoginAPI:                loginAPI,
+		LogoutAPI:               logoutAPI,
+		RefreshTokenAPI:         refreshTokenAPI,
+		WalletStatusAPI:         walletStatusAPI,
+		SessionAPI:              sessionAPI,
+		LoginHistoryAPI:         loginHistoryAPI,
+		TokenValidationAPI:      tokenValidationAPI,
+		UserAdminAPI:            userAdminAPI,
+		EntitlementAPI:          entitlementAPI,
+		AddressAPI:              addressAPI,
+		KycAPI:                  kycAPI,
+		ScreeningAPI:            screeningAPI,
+		AccountNoteAPI:          accountNoteAPI,
+		ReservedUsernameAPI:     reservedUsernameAPI,
+		DeadLetterAPI:           deadLetterAPI,
+		UserQuotaAPI:            userQuotaAPI,
+		Entitlements:            entitlementSvc,
+		IntrospectionAPI:        introspectionAPI,
+		OAuthTokenAPI:           oauthTokenAPI,
+		SessionCleanup:          sessionCleanupSvc,
+		Retention:               retentionSvc,
+		Anonymization:           anonymizationSvc,
+		AccountDeletion:         accountDeletionSvc,
+		WalletProvisioning:      walletProvisioningSvc,
+		WalletReconciliation:    walletReconciliationSvc,
+		Screening:               screeningSvc,
+		UserPurge:               userPurgeSvc,
+		UserExport:              userExportSvc,
+		ActivityDigest:          activityDigestSvc,
+		NotificationSummary:     notificationSummarySvc,
+		Inbox:                   inboxSvc,
+		Stats:                   statsSvc,
+		Locker:                  jobLocker,
+		JobLockTTL:              time.Duration(helpers.GetEnvInt("JOB_LOCK_TTL_SECONDS", 30)) * time.Second,
+		ProfileAPI:              profileAPI,
+		OTPAPI:                  otpAPI,
+		ConsentAPI:              consentAPI,
+		GuestAPI:                guestAPI,
+		SecurityQuestionAPI:     securityQuestionAPI,
+		AccountRecoveryAPI:      accountRecoveryAPI,
+		AccountDeletionAPI:      accountDeletionAPI,
+		NotificationAPI:         notificationAPI,
+		AdminApprovalAPI:        adminApprovalAPI,
+		LegalHoldAPI:            legalHoldAPI,
+		AuditAPI:                auditAPI,
+		ServiceAccountAPI:       serviceAccountAPI,
+		UserImportAPI:           userImportAPI,
+		UserExportAPI:           userExportAPI,
+		LoginVelocityAPI:        loginVelocityAPI,
+		WalletReconciliationAPI: walletReconciliationAPI,
+		AdminUserAPI:            adminUserAPI,
+		TokenRevocationAPI:      tokenRevocationAPI,
+		LogLevelAPI:             logLevelAPI,
+		EmailTemplateAPI:        emailTemplateAPI,
+		StatsAPI:                statsAPI,
+		ReplayProtection: ReplayProtection{
+			Nonces:   &repository.ReplayNonceRepository{Redis: helpers.Redis},
+			Window:   time.Duration(helpers.GetEnvInt("REPLAY_WINDOW_SECONDS", 300)) * time.Second,
+			Required: helpers.GetEnvBool("REPLAY_PROTECTION_REQUIRED", true),
+		},
+	}
+}
+
+// newLogSampler lets through LOG_SAMPLE_FIRST entries per second on a
+// high-volume path, then one in LOG_SAMPLE_THEREAFTER.
+func newLogSampler(name string) *helpers.LogSampler {
+	return &helpers.LogSampler{
+		Name:       name,
+		First:      helpers.GetEnvInt("LOG_SAMPLE_FIRST", 100),
+		Thereafter: helpers.GetEnvInt("LOG_SAMPLE_THEREAFTER", 100),
+		Interval:   time.Second,
+	}
+}
+
+// newRetentionService is shared by the worker and the `retention` command,
+// which runs without redis and so can't go through dependencyInject.
+func newRetentionService() *services.RetentionService {
+	day := 24 * time.Hour
+
+	return &services.RetentionService{
+		RetentionRepo: &repository.RetentionRepository{DB: helpers.DB},
+		AuditRepo:     &repository.AuditRepository{DB: helpers.DB},
+		Rules: []models.RetentionRule{
+			{Name: constants.RetentionRuleLoginHistory, Retention: time.Duration(helpers.GetEnvInt("RETENTION_LOGIN_HISTORY_DAYS", 365)) * day},
+			{Name: constants.RetentionRuleUserSession, Retention: time.Duration(helpers.GetEnvInt("RETENTION_SESSION_DAYS", 30)) * day},
+		},
+		BatchSize: helpers.GetEnvInt("RETENTION_BATCH_SIZE", 1000),
+		Interval:  time.Duration(helpers.GetEnvInt("RETENTION_INTERVAL_SECONDS", 3600)) * time.Second,
+		DryRun:    helpers.GetEnvBool("RETENTION_DRY_RUN", false),
+	}
+}
+
+// newUserImportService is shared by the admin API and the `user-import`
+// command, which also runs without redis. The API passes the server's hasher
+// so an import can't starve logins of bcrypt slots.
+func newUserImportService(passwordHasher interfaces.IPasswordHasher) *services.UserImportService {
+	return &services.UserImportService{
+		UserImportRepo: &repository.UserImportRepository{DB: helpers.DB},
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		PasswordHasher: passwordHasher,
+		ProgressEvery:  helpers.GetEnvInt("USER_IMPORT_PROGRESS_EVERY", 100),
+	}
+}
+
+// newObjectStorage is the bucket of exports, recovery evidence and backups.
+// Exports can be large, so uploads get their own timeout.
+func newObjectStorage(httpClient *http.Client) *external.ObjectStorage {
+	return &external.ObjectStorage{
+		HTTPClient: &http.Client{
+			Transport: httpClient.Transport,
+			Timeout:   time.Duration(helpers.GetEnvInt("OBJECT_STORAGE_TIMEOUT_SECONDS", 300)) * time.Second,
+		},
+		Endpoint:        helpers.GetEnv("OBJECT_STORAGE_ENDPOINT", ""),
+		Region:          helpers.GetEnv("OBJECT_STORAGE_REGION", "us-east-1"),
+		Bucket:          helpers.GetEnv("OBJECT_STORAGE_BUCKET", ""),
+		AccessKeyID:     helpers.GetEnv("OBJECT_STORAGE_ACCESS_KEY_ID", ""),
+		SecretAccessKey: helpers.GetEnv("OBJECT_STORAGE_SECRET_ACCESS_KEY", ""),
 	}
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"flag"
+	"time"
+
+	"ewallet-ums/external/walletfake"
+	"ewallet-ums/helpers"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ServeOptions struct {
+	WithFakes bool
+}
+
+func ParseServeFlags(args []string) ServeOptions {
+	opts := ServeOptions{}
+
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.BoolVar(&opts.WithFakes, "with-fakes", false, "run against in-process fakes of external services")
+	fs.Parse(args)
+
+	return opts
+}
+
+// StartFakes boots the fake external services and points the config at them.
+// Must run before dependencyInject.
+func StartFakes() {
+	wallet := walletfake.New(
+		walletfake.WithLatency(time.Duration(helpers.GetEnvInt("FAKE_WALLET_LATENCY_MS", 0))*time.Millisecond),
+		walletfake.WithFailureRate(float64(helpers.GetEnvInt("FAKE_WALLET_FAILURE_PERCENT", 0))/100),
+	)
+
+	helpers.Env["WALLET_HOST"] = wallet.URL
+	helpers.Env["WALLET_ENDPOINT_CREATE"] = walletfake.CreateEndpoint
+	helpers.Env["WALLET_ENDPOINT_HEALTH"] = walletfake.HealthEndpoint
+
+	logrus.Info("fake wallet service listening on: " + wallet.URL)
+}
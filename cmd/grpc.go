@@ -1,32 +1,126 @@
 package cmd
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"log"
 	"net"
+	"os"
+	"time"
 
+	"ewallet-ums/cmd/proto/entitlement"
+	"ewallet-ums/cmd/proto/healthcheck"
 	"ewallet-ums/cmd/proto/tokenvalidation"
+	"ewallet-ums/cmd/proto/user"
+	"ewallet-ums/cmd/proto/useradmin"
 	"ewallet-ums/helpers"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/net/netutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 )
 
 func ServeGRPC() {
 	// init dependency
 	dependency := dependencyInject()
 
+	// evict revoked tokens from the local validation cache
+	go dependency.TokenCache.SubscribeRevocation(context.Background())
+
 	lis, err := net.Listen("tcp", ":"+helpers.GetEnv("GRPC_PORT", "7000"))
 	if err != nil {
 		log.Fatal("failed to listen grpc: ", err)
 	}
 
-	s := grpc.NewServer()
+	if maxConn := helpers.GetEnvInt("GRPC_MAX_CONNECTIONS", 0); maxConn > 0 {
+		lis = netutil.LimitListener(lis, maxConn)
+	}
+
+	opts := append(grpcServerOptions(), grpc.ChainUnaryInterceptor(dependency.InterceptorRequestID, dependency.InterceptorCallerAuth, dependency.InterceptorCallerGuard, dependency.InterceptorUserAdminAuth, dependency.InterceptorTicketRef, dependency.InterceptorErrorReporting))
+
+	tlsConfig, err := grpcTLSConfig()
+	if err != nil {
+		log.Fatal("failed to load grpc tls config: ", err)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	s := grpc.NewServer(opts...)
 
 	// list method
 	tokenvalidation.RegisterTokenValidationServer(s, dependency.TokenValidationAPI)
+	healthcheck.RegisterHealthcheckServer(s, dependency.HealthcheckAPI)
+	useradmin.RegisterUserAdminServiceServer(s, dependency.UserAdminAPI)
+	entitlement.RegisterEntitlementServer(s, dependency.EntitlementAPI)
+	user.RegisterUserServer(s, dependency.AddressAPI)
 
 	logrus.Info("start listening grpc on port: " + helpers.GetEnv("GRPC_PORT", "7000"))
 	if err := s.Serve(lis); err != nil {
 		log.Fatal("failed to serve grpc port: ", err)
 	}
 }
+
+func grpcServerOptions() []grpc.ServerOption {
+	seconds := func(key string, val int) time.Duration {
+		return time.Duration(helpers.GetEnvInt(key, val)) * time.Second
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(helpers.GetEnvInt("GRPC_MAX_RECV_MSG_SIZE_BYTES", 4*1024*1024)),
+		grpc.MaxSendMsgSize(helpers.GetEnvInt("GRPC_MAX_SEND_MSG_SIZE_BYTES", 4*1024*1024)),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             seconds("GRPC_KEEPALIVE_MIN_TIME_SECONDS", 10),
+			PermitWithoutStream: helpers.GetEnvBool("GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM", true),
+		}),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     seconds("GRPC_MAX_CONNECTION_IDLE_SECONDS", 300),
+			MaxConnectionAge:      seconds("GRPC_MAX_CONNECTION_AGE_SECONDS", 0),
+			MaxConnectionAgeGrace: seconds("GRPC_MAX_CONNECTION_AGE_GRACE_SECONDS", 0),
+			Time:                  seconds("GRPC_KEEPALIVE_TIME_SECONDS", 60),
+			Timeout:               seconds("GRPC_KEEPALIVE_TIMEOUT_SECONDS", 20),
+		}),
+	}
+
+	if maxStreams := helpers.GetEnvInt("GRPC_MAX_CONCURRENT_STREAMS", 0); maxStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(uint32(maxStreams)))
+	}
+
+	return opts
+}
+
+// grpcTLSConfig serves TLS when GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE are
+// set. With GRPC_TLS_CLIENT_CA_FILE, client certificates signed by that CA
+// are verified; they are optional so callers can authenticate with a service
+// account token instead.
+func grpcTLSConfig() (*tls.Config, error) {
+	certFile, keyFile := helpers.GetEnv("GRPC_TLS_CERT_FILE", ""), helpers.GetEnv("GRPC_TLS_KEY_FILE", "")
+	if certFile == "" || keyFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %v", err)
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	if caFile := helpers.GetEnv("GRPC_TLS_CLIENT_CA_FILE", ""); caFile != "" {
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client ca: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates in client ca file %s", caFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return cfg, nil
+}
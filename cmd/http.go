@@ -11,6 +11,9 @@ import (
 func ServeHTTP() {
 	dependency := dependencyInject()
 
+	// tokens are only issued over HTTP
+	helpers.RegisterClaimsEnricher(dependency.Entitlements)
+
 	r := gin.Default()
 
 	route(r, dependency)
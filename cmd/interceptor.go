@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"ewallet-ums/cmd/proto/healthcheck"
+	"ewallet-ums/cmd/proto/tokenvalidation"
+	"ewallet-ums/cmd/proto/useradmin"
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type CallerGuardConfig struct {
+	RateLimit     int
+	RateWindow    time.Duration
+	FailureLimit  int
+	FailureWindow time.Duration
+	BanDuration   time.Duration
+}
+
+// InterceptorCallerGuard rate limits gRPC callers and temporarily bans
+// callers that fail too many token validations, so a compromised internal
+// service can't be used to guess tokens. It runs after InterceptorCallerAuth
+// and identifies callers by the actor it authenticated, so services sharing
+// an egress IP don't share a limit, falling back to the peer IP for
+// unauthenticated callers. Redis errors fail open, the limits are a safeguard
+// rather than the authentication.
+func (d *Dependency) InterceptorCallerGuard(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	log := helpers.Logger
+	caller := guardedCaller(ctx)
+	cfg := d.CallerGuardConfig.Get()
+
+	banned, err := d.CallerGuard.IsBanned(ctx, caller)
+	if err != nil {
+		log.Error("failed to check caller ban: ", err)
+	}
+	if banned {
+		helpers.GRPCCallerRejections.WithLabelValues("banned").Inc()
+		return nil, status.Error(codes.PermissionDenied, "caller is temporarily banned")
+	}
+
+	allowed, err := d.CallerGuard.Allow(ctx, caller, cfg.RateLimit, cfg.RateWindow)
+	if err != nil {
+		log.Error("failed to check caller rate limit: ", err)
+	}
+	if !allowed {
+		helpers.GRPCCallerRejections.WithLabelValues("rate_limited").Inc()
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	resp, err := handler(ctx, req)
+
+	// ValidateToken reports invalid tokens in the message, not as an error
+	if tokenResp, ok := resp.(*tokenvalidation.TokenResponse); ok && tokenResp.GetMessage() != constants.SuccessMessage {
+		failures, guardErr := d.CallerGuard.RecordFailure(ctx, caller, cfg.FailureWindow)
+		if guardErr != nil {
+			log.Error("failed to record caller failure: ", guardErr)
+		} else if failures >= int64(cfg.FailureLimit) {
+			log.Warn("banning grpc caller after failed validations: ", caller)
+			if guardErr := d.CallerGuard.Ban(ctx, caller, cfg.BanDuration); guardErr != nil {
+				log.Error("failed to ban caller: ", guardErr)
+			}
+		}
+	}
+
+	return resp, err
+}
+
+func guardedCaller(ctx context.Context) string {
+	if actor, ok := helpers.ActorFromContext(ctx); ok {
+		return actor
+	}
+	return callerFromPeer(ctx)
+}
+
+func callerFromPeer(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// grpcServicesAuthenticatedElsewhere are left alone by InterceptorCallerAuth:
+// the healthcheck is public and UserAdminService has its own, stricter check.
+var grpcServicesAuthenticatedElsewhere = []string{
+	healthcheck.Healthcheck_ServiceDesc.ServiceName,
+	useradmin.UserAdminService_ServiceDesc.ServiceName,
+}
+
+// InterceptorCallerAuth authenticates the callers of every other RPC, token
+// validation included. Callers present either a client certificate verified
+// against GRPC_TLS_CLIENT_CA_FILE with a DNS or URI SAN listed in
+// GRPC_CALLER_NAMES, or a service account token in the authorization
+// metadata. The caller is passed on as the audit actor. While
+// GRPC_CALLER_AUTH_ENFORCE is off, unauthenticated calls are only logged, to
+// find the callers still to migrate.
+func (d *Dependency) InterceptorCallerAuth(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	service, _, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+	if slices.Contains(grpcServicesAuthenticatedElsewhere, service) {
+		return handler(ctx, req)
+	}
+
+	actor, err := d.authenticateCaller(ctx)
+	if err != nil {
+		helpers.GRPCCallerRejections.WithLabelValues("unauthenticated").Inc()
+		if !d.CallerAuthEnforced {
+			helpers.Logger.Warnf("unauthenticated grpc call to %s from %s: %v", info.FullMethod, callerFromPeer(ctx), err)
+			return handler(ctx, req)
+		}
+		helpers.Logger.Info("unauthenticated grpc caller: ", err)
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	return handler(helpers.ContextWithActor(ctx, actor), req)
+}
+
+func (d *Dependency) authenticateCaller(ctx context.Context) (string, error) {
+	if name, ok := verifiedClientSAN(ctx, d.CallerNames); ok {
+		return "cert:" + name, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := md.Get("authorization")
+	if len(auth) == 0 {
+		return "", errors.New("no client certificate or token")
+	}
+	token, ok := helpers.BearerToken(auth[0], d.AllowRawToken)
+	if !ok {
+		return "", errors.New("malformed authorization")
+	}
+
+	claim, err := d.TokenValidation.TokenValidation(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if !claim.Service {
+		return "", fmt.Errorf("user %d is not a service account", claim.UserID)
+	}
+	return models.UserActor(claim.UserID), nil
+}
+
+// verifiedClientSAN returns the first DNS or URI SAN of a verified client
+// certificate that is one of names.
+func verifiedClientSAN(ctx context.Context, names []string) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	sans := slices.Clone(cert.DNSNames)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	for _, san := range sans {
+		if slices.Contains(names, san) {
+			return san, true
+		}
+	}
+	return "", false
+}
+
+// InterceptorTicketRef passes the x-ticket-reference metadata of
+// UserAdminService calls on to their audit events. Every UserAdminService
+// method is a sensitive action, so while ADMIN_TICKET_REF_REQUIRED is on
+// calls without one are refused.
+func (d *Dependency) InterceptorTicketRef(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, "/"+useradmin.UserAdminService_ServiceDesc.ServiceName+"/") {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	refs := md.Get(strings.ToLower(constants.HeaderTicketRef))
+	if len(refs) == 0 || refs[0] == "" {
+		if d.TicketRefRequired {
+			return nil, status.Error(codes.InvalidArgument, constants.ErrTicketRefRequired)
+		}
+		return handler(ctx, req)
+	}
+	if !helpers.ValidTicketRef(refs[0]) {
+		return nil, status.Error(codes.InvalidArgument, constants.ErrFailedBadRequest)
+	}
+	return handler(helpers.ContextWithTicketRef(ctx, refs[0]), req)
+}
+
+// InterceptorUserAdminAuth guards the UserAdminService methods. Callers
+// present either a client certificate verified against
+// GRPC_TLS_CLIENT_CA_FILE with a DNS or URI SAN listed in
+// GRPC_ADMIN_CLIENT_NAMES, or a service account token holding the admin role
+// in the authorization metadata. The caller is passed on as the audit actor.
+func (d *Dependency) InterceptorUserAdminAuth(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, "/"+useradmin.UserAdminService_ServiceDesc.ServiceName+"/") {
+		return handler(ctx, req)
+	}
+
+	if name, ok := verifiedClientSAN(ctx, d.AdminClientNames); ok {
+		return handler(helpers.ContextWithActor(ctx, "cert:"+name), req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := md.Get("authorization")
+	if len(auth) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	token, ok := helpers.BearerToken(auth[0], d.AllowRawToken)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	claim, err := d.TokenValidation.TokenValidation(ctx, token)
+	if err != nil {
+		helpers.Logger.Info("invalid user admin token: ", err)
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	user, err := d.UserRepo.GetUserByID(ctx, claim.UserID)
+	if err != nil {
+		helpers.Logger.Error("failed to get user admin caller: ", err)
+		return nil, status.Error(codes.Internal, constants.ErrServerError)
+	}
+	roles, err := d.AdminRepo.GetUserRoles(ctx, claim.UserID)
+	if err != nil {
+		helpers.Logger.Error("failed to get user admin caller roles: ", err)
+		return nil, status.Error(codes.Internal, constants.ErrServerError)
+	}
+	if user.Type != constants.UserTypeService || !slices.Contains(roles, constants.RoleAdmin) {
+		helpers.Logger.Warn("user admin call denied for user: ", claim.UserID)
+		return nil, status.Error(codes.PermissionDenied, constants.ErrForbidden)
+	}
+
+	return handler(helpers.ContextWithActor(ctx, models.UserActor(claim.UserID)), req)
+}
+
+// InterceptorErrorReporting is the gRPC side of MiddlewareErrorReporting,
+// reporting Internal and Unknown errors. It runs last so the handler's actor
+// is known. gRPC has no recovery of its own, so a panic is also logged and
+// answered with Internal instead of taking the server down.
+func (d *Dependency) InterceptorErrorReporting(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	report := func(r external.ErrorReport) {
+		if d.ErrorReporter != nil {
+			r.Transport = "grpc"
+			r.Route = info.FullMethod
+			r.Status = status.Code(err).String()
+			d.reportError(ctx, r)
+		}
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			stack := string(debug.Stack())
+			helpers.Logger.Errorf("panic in %s: %v\n%s", info.FullMethod, recovered, stack)
+			resp, err = nil, status.Error(codes.Internal, constants.ErrServerError)
+			report(external.ErrorReport{Message: fmt.Sprint(recovered), Type: "panic", Panic: true, Stack: stack})
+		}
+	}()
+
+	resp, err = handler(ctx, req)
+	if code := status.Code(err); code == codes.Internal || code == codes.Unknown {
+		report(external.ErrorReport{Message: status.Convert(err).Message(), Type: "grpc_error"})
+	}
+	return resp, err
+}
+
+// InterceptorRequestID is the gRPC side of MiddlewareRequestID, reading the
+// x-request-id metadata.
+func (d *Dependency) InterceptorRequestID(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := ""
+	if values := md.Get("x-request-id"); len(values) > 0 {
+		id = values[0]
+	}
+
+	ctx, requestID := helpers.WithRequestID(ctx, id)
+	ctx, stats := helpers.WithQueryStats(ctx)
+	defer stats.Report(requestID, d.RepeatedQueryThreshold)
+
+	return handler(ctx, req)
+}
@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"ewallet-ums/cmd/proto/tokenvalidation"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/mocks"
+	"ewallet-ums/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptorCallerGuard(t *testing.T) {
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	cfg := helpers.StaticReloadable(CallerGuardConfig{RateLimit: 10, RateWindow: time.Minute, FailureLimit: 3, FailureWindow: time.Minute, BanDuration: time.Hour})
+	info := &grpc.UnaryServerInfo{FullMethod: "/tokenvalidation.TokenValidation/ValidateToken"}
+	invalid := func(ctx context.Context, req any) (any, error) {
+		return &tokenvalidation.TokenResponse{Message: "token invalid"}, nil
+	}
+	valid := func(ctx context.Context, req any) (any, error) {
+		return &tokenvalidation.TokenResponse{Message: constants.SuccessMessage}, nil
+	}
+
+	tests := []struct {
+		name     string
+		setup    func(guard *mocks.MockICallerGuardRepository)
+		handler  grpc.UnaryHandler
+		wantCode codes.Code
+	}{
+		{
+			name: "banned caller",
+			setup: func(guard *mocks.MockICallerGuardRepository) {
+				guard.EXPECT().IsBanned(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			handler:  valid,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "rate limited caller",
+			setup: func(guard *mocks.MockICallerGuardRepository) {
+				guard.EXPECT().IsBanned(gomock.Any(), gomock.Any()).Return(false, nil)
+				guard.EXPECT().Allow(gomock.Any(), gomock.Any(), 10, time.Minute).Return(false, nil)
+			},
+			handler:  valid,
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name: "valid token is not a failure",
+			setup: func(guard *mocks.MockICallerGuardRepository) {
+				guard.EXPECT().IsBanned(gomock.Any(), gomock.Any()).Return(false, nil)
+				guard.EXPECT().Allow(gomock.Any(), gomock.Any(), 10, time.Minute).Return(true, nil)
+			},
+			handler:  valid,
+			wantCode: codes.OK,
+		},
+		{
+			name: "failure limit bans caller",
+			setup: func(guard *mocks.MockICallerGuardRepository) {
+				guard.EXPECT().IsBanned(gomock.Any(), gomock.Any()).Return(false, nil)
+				guard.EXPECT().Allow(gomock.Any(), gomock.Any(), 10, time.Minute).Return(true, nil)
+				guard.EXPECT().RecordFailure(gomock.Any(), gomock.Any(), time.Minute).Return(int64(3), nil)
+				guard.EXPECT().Ban(gomock.Any(), gomock.Any(), time.Hour).Return(nil)
+			},
+			handler:  invalid,
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := mocks.NewMockICallerGuardRepository(gomock.NewController(t))
+			tt.setup(guard)
+			d := &Dependency{CallerGuard: guard, CallerGuardConfig: cfg}
+
+			_, err := d.InterceptorCallerGuard(context.Background(), &tokenvalidation.TokenRequest{}, info, tt.handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("got code %v, want %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestInterceptorCallerGuardKey(t *testing.T) {
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	cfg := helpers.StaticReloadable(CallerGuardConfig{RateLimit: 10, RateWindow: time.Minute, FailureLimit: 3, FailureWindow: time.Minute, BanDuration: time.Hour})
+	info := &grpc.UnaryServerInfo{FullMethod: "/tokenvalidation.TokenValidation/ValidateToken"}
+	// services behind the same egress IP
+	fromPeer := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantKey string
+	}{
+		{name: "certificate caller", ctx: helpers.ContextWithActor(fromPeer, "cert:billing.internal"), wantKey: "cert:billing.internal"},
+		{name: "service account caller", ctx: helpers.ContextWithActor(fromPeer, "user:7"), wantKey: "user:7"},
+		{name: "unauthenticated caller", ctx: fromPeer, wantKey: "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := mocks.NewMockICallerGuardRepository(gomock.NewController(t))
+			guard.EXPECT().IsBanned(gomock.Any(), tt.wantKey).Return(false, nil)
+			guard.EXPECT().Allow(gomock.Any(), tt.wantKey, 10, time.Minute).Return(true, nil)
+			guard.EXPECT().RecordFailure(gomock.Any(), tt.wantKey, time.Minute).Return(int64(1), nil)
+			d := &Dependency{CallerGuard: guard, CallerGuardConfig: cfg}
+
+			handler := func(ctx context.Context, req any) (any, error) {
+				return &tokenvalidation.TokenResponse{Message: "token invalid"}, nil
+			}
+			if _, err := d.InterceptorCallerGuard(tt.ctx, &tokenvalidation.TokenRequest{}, info, handler); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestInterceptorUserAdminAuth(t *testing.T) {
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/useradmin.UserAdminService/SuspendUser"}
+	withToken := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer service-token"))
+	withCert := func(cert *x509.Certificate) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+		})
+	}
+	serviceAccount := models.User{ID: 7, Type: constants.UserTypeService}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		info      *grpc.UnaryServerInfo
+		setup     func(validation *mocks.MockITokenValidationService, users *mocks.MockIUserReader, admin *mocks.MockIAdminRepository)
+		wantCode  codes.Code
+		wantActor string
+	}{
+		{
+			name:     "other services are not guarded",
+			ctx:      context.Background(),
+			info:     &grpc.UnaryServerInfo{FullMethod: "/tokenvalidation.TokenValidation/ValidateToken"},
+			setup:    func(*mocks.MockITokenValidationService, *mocks.MockIUserReader, *mocks.MockIAdminRepository) {},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "missing credentials",
+			ctx:      context.Background(),
+			info:     info,
+			setup:    func(*mocks.MockITokenValidationService, *mocks.MockIUserReader, *mocks.MockIAdminRepository) {},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:      "listed certificate",
+			ctx:       withCert(&x509.Certificate{DNSNames: []string{"backoffice.internal"}}),
+			info:      info,
+			setup:     func(*mocks.MockITokenValidationService, *mocks.MockIUserReader, *mocks.MockIAdminRepository) {},
+			wantCode:  codes.OK,
+			wantActor: "cert:backoffice.internal",
+		},
+		{
+			name:     "listed common name without the san",
+			ctx:      withCert(&x509.Certificate{Subject: pkix.Name{CommonName: "backoffice.internal"}, DNSNames: []string{"other.internal"}}),
+			info:     info,
+			setup:    func(*mocks.MockITokenValidationService, *mocks.MockIUserReader, *mocks.MockIAdminRepository) {},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "token without the bearer scheme",
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "service-token")),
+			info:     info,
+			setup:    func(*mocks.MockITokenValidationService, *mocks.MockIUserReader, *mocks.MockIAdminRepository) {},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "invalid token",
+			ctx:  withToken,
+			info: info,
+			setup: func(validation *mocks.MockITokenValidationService, _ *mocks.MockIUserReader, _ *mocks.MockIAdminRepository) {
+				validation.EXPECT().TokenValidation(gomock.Any(), "service-token").Return(nil, errors.New("token invalid"))
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "human admin is denied",
+			ctx:  withToken,
+			info: info,
+			setup: func(validation *mocks.MockITokenValidationService, users *mocks.MockIUserReader, admin *mocks.MockIAdminRepository) {
+				validation.EXPECT().TokenValidation(gomock.Any(), "service-token").Return(&helpers.ClaimToken{UserID: 7}, nil)
+				users.EXPECT().GetUserByID(gomock.Any(), 7).Return(models.User{ID: 7, Type: constants.UserTypeHuman}, nil)
+				admin.EXPECT().GetUserRoles(gomock.Any(), 7).Return([]string{constants.RoleAdmin}, nil)
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "service account without admin role is denied",
+			ctx:  withToken,
+			info: info,
+			setup: func(validation *mocks.MockITokenValidationService, users *mocks.MockIUserReader, admin *mocks.MockIAdminRepository) {
+				validation.EXPECT().TokenValidation(gomock.Any(), "service-token").Return(&helpers.ClaimToken{UserID: 7}, nil)
+				users.EXPECT().GetUserByID(gomock.Any(), 7).Return(serviceAccount, nil)
+				admin.EXPECT().GetUserRoles(gomock.Any(), 7).Return(nil, nil)
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "admin service account",
+			ctx:  withToken,
+			info: info,
+			setup: func(validation *mocks.MockITokenValidationService, users *mocks.MockIUserReader, admin *mocks.MockIAdminRepository) {
+				validation.EXPECT().TokenValidation(gomock.Any(), "service-token").Return(&helpers.ClaimToken{UserID: 7}, nil)
+				users.EXPECT().GetUserByID(gomock.Any(), 7).Return(serviceAccount, nil)
+				admin.EXPECT().GetUserRoles(gomock.Any(), 7).Return([]string{constants.RoleAdmin}, nil)
+			},
+			wantCode:  codes.OK,
+			wantActor: "user:7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			validation := mocks.NewMockITokenValidationService(ctrl)
+			users := mocks.NewMockIUserReader(ctrl)
+			admin := mocks.NewMockIAdminRepository(ctrl)
+			tt.setup(validation, users, admin)
+			d := &Dependency{TokenValidation: validation, UserRepo: users, AdminRepo: admin, AdminClientNames: []string{"backoffice.internal"}}
+
+			var actor string
+			handler := func(ctx context.Context, req any) (any, error) {
+				actor, _ = helpers.ActorFromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := d.InterceptorUserAdminAuth(tt.ctx, nil, tt.info, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("got code %v, want %v", code, tt.wantCode)
+			}
+			if actor != tt.wantActor {
+				t.Errorf("got actor %q, want %q", actor, tt.wantActor)
+			}
+		})
+	}
+}
+
+func TestInterceptorCallerAuth(t *testing.T) {
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/tokenvalidation.TokenValidation/ValidateToken"}
+	withToken := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer service-token"))
+	withCert := func(san string) context.Context {
+		chain := []*x509.Certificate{{DNSNames: []string{san}}}
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{chain}}},
+		})
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		info      *grpc.UnaryServerInfo
+		enforced  bool
+		setup     func(validation *mocks.MockITokenValidationService)
+		wantCode  codes.Code
+		wantActor string
+	}{
+		{
+			name:     "healthcheck is public",
+			ctx:      context.Background(),
+			info:     &grpc.UnaryServerInfo{FullMethod: "/healthcheck.Healthcheck/Check"},
+			enforced: true,
+			setup:    func(*mocks.MockITokenValidationService) {},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "missing credentials",
+			ctx:      context.Background(),
+			info:     info,
+			enforced: true,
+			setup:    func(*mocks.MockITokenValidationService) {},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "missing credentials not enforced",
+			ctx:      context.Background(),
+			info:     info,
+			setup:    func(*mocks.MockITokenValidationService) {},
+			wantCode: codes.OK,
+		},
+		{
+			name:      "listed certificate",
+			ctx:       withCert("billing.internal"),
+			info:      info,
+			enforced:  true,
+			setup:     func(*mocks.MockITokenValidationService) {},
+			wantCode:  codes.OK,
+			wantActor: "cert:billing.internal",
+		},
+		{
+			name:     "unlisted certificate",
+			ctx:      withCert("unknown.internal"),
+			info:     info,
+			enforced: true,
+			setup:    func(*mocks.MockITokenValidationService) {},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "user token is denied",
+			ctx:      withToken,
+			info:     info,
+			enforced: true,
+			setup: func(validation *mocks.MockITokenValidationService) {
+				validation.EXPECT().TokenValidation(gomock.Any(), "service-token").Return(&helpers.ClaimToken{UserID: 7}, nil)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "service account token",
+			ctx:      withToken,
+			info:     info,
+			enforced: true,
+			setup: func(validation *mocks.MockITokenValidationService) {
+				validation.EXPECT().TokenValidation(gomock.Any(), "service-token").Return(&helpers.ClaimToken{UserID: 7, Service: true}, nil)
+			},
+			wantCode:  codes.OK,
+			wantActor: "user:7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation := mocks.NewMockITokenValidationService(gomock.NewController(t))
+			tt.setup(validation)
+			d := &Dependency{TokenValidation: validation, CallerNames: []string{"billing.internal"}, CallerAuthEnforced: tt.enforced}
+
+			var actor string
+			handler := func(ctx context.Context, req any) (any, error) {
+				actor, _ = helpers.ActorFromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := d.InterceptorCallerAuth(tt.ctx, nil, tt.info, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("got code %v, want %v", code, tt.wantCode)
+			}
+			if actor != tt.wantActor {
+				t.Errorf("got actor %q, want %q", actor, tt.wantActor)
+			}
+		})
+	}
+}
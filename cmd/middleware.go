@@ -1,86 +1,679 @@
 package cmd
 
 import (
-	"log"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"runtime/debug"
+	"slices"
+	"strconv"
 	"time"
 
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
 	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/sirupsen/logrus"
 )
 
-func (d *Dependency) MiddlewareValidateAuth(c *gin.Context) {
-	auth := c.Request.Header.Get("Authorization")
+// logRejected logs why a request was turned away, sampled since a client
+// retrying with a stale token can produce lots of them.
+func (d *Dependency) logRejected(args ...any) {
+	if d.AuthLogSampler.Allow(logrus.WarnLevel) {
+		helpers.Logger.Warn(args...)
+	}
+}
 
-	if auth == "" {
-		log.Println("authorization empty")
-		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
-		c.Abort()
+// rejectBearer turns away a request without a valid bearer token, with the
+// challenge RFC 6750 asks for. A presented token is reported to the SIEM
+// with reason, claim is nil when it couldn't be parsed.
+func (d *Dependency) rejectBearer(c *gin.Context, reason string, claim *helpers.ClaimToken, args ...any) {
+	d.logRejected(args...)
+	if reason != "" {
+		helpers.EmitTokenValidationFailed(c.Request.Context(), reason, claim)
+	}
+	c.Header("WWW-Authenticate", `Bearer realm="ums"`)
+	helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
+	c.Abort()
+}
+
+func (d *Dependency) MiddlewareValidateAuth(c *gin.Context) {
+	auth, ok := helpers.BearerToken(c.GetHeader("Authorization"), d.AllowRawToken)
+	if !ok {
+		d.rejectBearer(c, "", nil, "authorization empty or malformed")
 		return
 	}
 
-	_, err := d.UserRepo.GetUserSessionByToken(c.Request.Context(), auth)
+	claim, err := helpers.ValidateToken(c.Request.Context(), auth)
 	if err != nil {
-		log.Println("failed to get user session on db: ", err)
-		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
-		c.Abort()
+		d.rejectBearer(c, helpers.TokenFailureInvalid, nil, err)
+		return
+	}
+	if claim.Type != "" && !claim.IsAccessToken() {
+		d.rejectBearer(c, helpers.TokenFailureWrongType, claim, "not an access token: ", claim.Type)
+		return
+	}
+	// delegated tokens are for the audience they were exchanged for, not us
+	if claim.Act != nil {
+		d.rejectBearer(c, helpers.TokenFailureWrongAudience, claim, "delegated token for: ", claim.Audience)
+		return
+	}
+
+	// untyped tokens predate the typ claim, only their session tells an
+	// access token from a refresh token
+	stateless := d.StatelessValidation.Get()
+	if !stateless || claim.Type == "" {
+		_, err := d.SessionRepo.GetUserSessionByToken(c.Request.Context(), auth)
+		if err != nil {
+			d.rejectBearer(c, helpers.TokenFailureNoSession, claim, "failed to get user session on db: ", err)
+			return
+		}
+	}
+
+	if stateless {
+		revoked, err := d.TokenCache.IsTokenRevoked(c.Request.Context(), auth, claim.ID)
+		if err != nil {
+			d.rejectBearer(c, "", nil, "failed to check token revocation: ", err)
+			return
+		}
+		if revoked {
+			d.rejectBearer(c, helpers.TokenFailureRevoked, claim, "token is revoked")
+			return
+		}
+	}
+
+	if time.Now().Unix() > claim.ExpiresAt.Unix() {
+		d.rejectBearer(c, helpers.TokenFailureExpired, claim, "jwt token is expired: ", claim.ExpiresAt)
+		return
+	}
+
+	// device-bound tokens, like a guest's, only work from their device
+	if claim.DeviceID != "" && c.GetHeader(constants.HeaderDeviceID) != claim.DeviceID {
+		d.rejectBearer(c, helpers.TokenFailureWrongDevice, claim, "token used from another device")
+		return
+	}
+
+	c.Set("token", claim)
+	c.Set("bearer_token", auth)
+	c.Request = c.Request.WithContext(helpers.ContextWithActor(c.Request.Context(), models.UserActor(claim.UserID)))
+	helpers.SetUserLocaleLookup(c, func() string {
+		user, err := d.UserRepo.GetUserByID(c.Request.Context(), claim.UserID)
+		if err != nil {
+			return ""
+		}
+		return user.Locale
+	})
+	c.Next()
+}
+
+func (d *Dependency) MiddlewareRefreshToken(c *gin.Context) {
+	auth, ok := helpers.BearerToken(c.GetHeader("Authorization"), d.AllowRawToken)
+	if !ok {
+		d.rejectBearer(c, "", nil, "authorization empty or malformed")
 		return
 	}
 
+	// the session is looked up by the refresh token service, which also has
+	// to see rotated-out tokens to detect their reuse
 	claim, err := helpers.ValidateToken(c.Request.Context(), auth)
 	if err != nil {
-		log.Println(err)
-		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
-		c.Abort()
+		d.rejectBearer(c, helpers.TokenFailureInvalid, nil, err)
+		return
+	}
+	if claim.IsAccessToken() {
+		d.rejectBearer(c, helpers.TokenFailureWrongType, claim, "not a refresh token: ", claim.Type)
 		return
 	}
 
 	if time.Now().Unix() > claim.ExpiresAt.Unix() {
-		log.Println("jwt token is expired: ", claim.ExpiresAt)
+		d.rejectBearer(c, helpers.TokenFailureExpired, claim, "jwt token is expired: ", claim.ExpiresAt)
+		return
+	}
+
+	// device-bound tokens, like a guest's, only work from their device
+	if claim.DeviceID != "" && c.GetHeader(constants.HeaderDeviceID) != claim.DeviceID {
+		d.rejectBearer(c, helpers.TokenFailureWrongDevice, claim, "token used from another device")
+		return
+	}
+
+	c.Set("token", claim)
+	c.Set("bearer_token", auth)
+
+	c.Next()
+}
+
+// MiddlewareVerifySignature only lets through internal callers that signed
+// the request with one of INTERNAL_SIGNING_KEYS, see helpers.VerifyRequest.
+func (d *Dependency) MiddlewareVerifySignature(c *gin.Context) {
+	caller, err := helpers.VerifyRequest(c.Request, d.SigningKeys, d.SigningMaxSkew, time.Now())
+	if err != nil {
+		d.logRejected("invalid request signature: ", err)
 		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
 		c.Abort()
 		return
 	}
 
-	c.Set("token", claim)
+	c.Set("caller", caller)
 	c.Next()
 }
 
-func (d *Dependency) MiddlewareRefreshToken(c *gin.Context) {
-	auth := c.Request.Header.Get("Authorization")
+// MiddlewareClientAuth checks HTTP basic credentials against OAUTH_CLIENTS
+// and fails with an RFC 6749 invalid_client error.
+func (d *Dependency) MiddlewareClientAuth(c *gin.Context) {
+	clientID, secret, ok := c.Request.BasicAuth()
+	expected, known := d.OAuthClients[clientID]
+	if !ok || !known || !hmac.Equal([]byte(secret), expected) {
+		d.logRejected("invalid client credentials: ", clientID)
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": constants.OAuthErrInvalidClient})
+		return
+	}
 
-	if auth == "" {
-		log.Println("authorization empty")
-		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
+	c.Set("client_id", clientID)
+	c.Next()
+}
+
+// MiddlewareTokenClientAuth authenticates callers of the token endpoint.
+// Service accounts using the client_credentials grant are authenticated by
+// the grant itself, everyone else must be one of OAUTH_CLIENTS.
+func (d *Dependency) MiddlewareTokenClientAuth(c *gin.Context) {
+	if c.PostForm("grant_type") == constants.GrantTypeClientCredentials {
+		c.Next()
+		return
+	}
+
+	d.MiddlewareClientAuth(c)
+}
+
+// MiddlewareRequireRole lets through users holding any of roles. It runs
+// after MiddlewareValidateAuth, which sets the token claim.
+func (d *Dependency) MiddlewareRequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claim, err := helpers.GetTokenClaim(c)
+		if err != nil {
+			d.logRejected(err)
+			helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
+			c.Abort()
+			return
+		}
+
+		userRoles, err := d.AdminRepo.GetUserRoles(c.Request.Context(), claim.UserID)
+		if err != nil {
+			helpers.Logger.Error("failed to get user roles on db: ", err)
+			helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+			c.Abort()
+			return
+		}
+
+		for _, role := range userRoles {
+			if slices.Contains(roles, role) {
+				c.Next()
+				return
+			}
+		}
+
+		d.logRejected("user lacks required role: ", claim.UserID)
+		helpers.SendResponseHTTP(c, http.StatusForbidden, constants.ErrForbidden, nil)
 		c.Abort()
+	}
+}
+
+// MiddlewareRequestID tags the request with the caller's X-Request-ID, or a
+// new one, so slow query logs can be tied back to it. After the request it
+// reports the queries repeated often enough to look like an N+1.
+func (d *Dependency) MiddlewareRequestID(c *gin.Context) {
+	ctx, requestID := helpers.WithRequestID(c.Request.Context(), c.GetHeader("X-Request-ID"))
+	ctx, stats := helpers.WithQueryStats(ctx)
+	c.Request = c.Request.WithContext(ctx)
+	c.Header("X-Request-ID", requestID)
+
+	c.Next()
+
+	stats.Report(requestID, d.RepeatedQueryThreshold)
+}
+
+// MiddlewareErrorReporting sends 5xx responses and panics to the error
+// tracker, tagged with the request id and the hashed actor. Shed requests
+// (503) are load rather than errors. A panic is passed on for gin's recovery
+// to answer.
+func (d *Dependency) MiddlewareErrorReporting(c *gin.Context) {
+	if d.ErrorReporter == nil {
+		c.Next()
 		return
 	}
 
-	_, err := d.UserRepo.GetUserSessionByRefreshToken(c.Request.Context(), auth)
-	if err != nil {
-		log.Println("failed to get user session on db: ", err)
-		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
+	report := func(r external.ErrorReport) {
+		r.Transport = "http"
+		r.Route = c.FullPath()
+		r.Status = strconv.Itoa(c.Writer.Status())
+		d.reportError(c.Request.Context(), r)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			c.Status(http.StatusInternalServerError)
+			report(external.ErrorReport{Message: fmt.Sprint(recovered), Type: "panic", Panic: true, Stack: string(debug.Stack())})
+			panic(recovered)
+		}
+	}()
+
+	c.Next()
+
+	status := c.Writer.Status()
+	if status < http.StatusInternalServerError || status == http.StatusServiceUnavailable {
+		return
+	}
+	if len(c.Errors) == 0 {
+		report(external.ErrorReport{Message: http.StatusText(status), Type: "http_error"})
+	}
+	for _, err := range c.Errors {
+		report(external.ErrorReport{Message: err.Error(), Type: errorType(err.Err)})
+	}
+}
+
+// reportError sends report in the background, adding the request id and
+// actor of ctx.
+func (d *Dependency) reportError(ctx context.Context, report external.ErrorReport) {
+	report.RequestID = helpers.RequestID(ctx)
+	if actor, ok := helpers.ActorFromContext(ctx); ok {
+		report.UserID = helpers.HashActor(actor)
+	}
+
+	go func() {
+		if err := d.ErrorReporter.Report(context.WithoutCancel(ctx), report); err != nil {
+			helpers.Logger.Error("failed to report error: ", err)
+		}
+	}()
+}
+
+// errorType names the type of err's root cause, the wrapping apperr.Error
+// says nothing.
+func errorType(err error) string {
+	for {
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			return fmt.Sprintf("%T", err)
+		}
+		err = inner
+	}
+}
+
+// MiddlewareLocale picks the locale of response messages from the request's
+// Accept-Language. Without a supported one, authenticated requests fall back
+// to the user's stored locale.
+func (d *Dependency) MiddlewareLocale(c *gin.Context) {
+	if locale := helpers.MatchAcceptLanguage(c.GetHeader("Accept-Language")); locale != "" {
+		helpers.SetLocale(c, locale)
+	}
+	c.Next()
+}
+
+// BodyLimits bound the request bodies of the public routes, zero disables a
+// limit. Admin routes have none, user imports take their own.
+type BodyLimits struct {
+	MaxBytes     int64
+	MaxJSONDepth int
+}
+
+// MiddlewareBodyLimit rejects bodies over limits.MaxBytes with a 413 and
+// JSON nested deeper than limits.MaxJSONDepth with a 400, before binding
+// spends memory and stack on them. The body is checked whatever its content
+// type, since JSON binding doesn't look at it, except for forms.
+func (d *Dependency) MiddlewareBodyLimit(limits BodyLimits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limits.MaxBytes > 0 {
+			if c.Request.ContentLength > limits.MaxBytes {
+				d.logRejected("request body too large: ", c.Request.ContentLength)
+				helpers.SendResponseHTTP(c, http.StatusRequestEntityTooLarge, constants.ErrFailedBadRequest, nil)
+				c.Abort()
+				return
+			}
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limits.MaxBytes)
+		}
+
+		contentType := c.ContentType()
+		if limits.MaxJSONDepth <= 0 || contentType == binding.MIMEPOSTForm || contentType == binding.MIMEMultipartPOSTForm {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			d.logRejected("failed to read request body: ", err)
+			code := http.StatusBadRequest
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				code = http.StatusRequestEntityTooLarge
+			}
+			helpers.SendResponseHTTP(c, code, constants.ErrFailedBadRequest, nil)
+			c.Abort()
+			return
+		}
+
+		if jsonDepthExceeds(body, limits.MaxJSONDepth) {
+			d.logRejected("request body nested too deep")
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Next()
+	}
+}
+
+// jsonDepthExceeds reports whether data nests objects and arrays deeper than
+// maxDepth. It only tracks brackets outside strings, whether data is valid
+// JSON is left to binding.
+func jsonDepthExceeds(data []byte, maxDepth int) bool {
+	depth := 0
+	inString, escaped := false, false
+	for _, b := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			escaped = b == '\\'
+			inString = b != '"'
+		case b == '"':
+			inString = true
+		case b == '{' || b == '[':
+			depth++
+			if depth > maxDepth {
+				return true
+			}
+		case b == '}' || b == ']':
+			depth--
+		}
+	}
+	return false
+}
+
+// RouteConcurrency caps the requests each route group handles at once, zero
+// disables a cap. Login and register hash passwords with bcrypt and get caps
+// of their own, so a burst of them can't take every worker.
+type RouteConcurrency struct {
+	Default         int
+	Login           int
+	Register        int
+	Admin           int
+	OAuth           int
+	TokenValidation int
+
+	// RetryAfter is sent with the 503s of a full group.
+	RetryAfter time.Duration
+}
+
+// MiddlewareConcurrencyLimit lets limit requests through at once and answers
+// the rest straight away with a 503 and Retry-After, rather than queueing
+// them until they all time out. Every call makes its own limiter, group
+// labels the requests it sheds.
+func (d *Dependency) MiddlewareConcurrencyLimit(group string, limit int) gin.HandlerFunc {
+	if limit <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	slots := make(chan struct{}, limit)
+	retryAfter := strconv.Itoa(max(1, int(d.RouteConcurrency.RetryAfter/time.Second)))
+	return func(c *gin.Context) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			helpers.HTTPRequestsShed.WithLabelValues(group).Inc()
+			d.logRejected("route group at its concurrency limit: ", group)
+			c.Header("Retry-After", retryAfter)
+			helpers.SendResponseHTTP(c, http.StatusServiceUnavailable, constants.ErrOverloaded, nil)
+			c.Abort()
+			return
+		}
+		defer func() { <-slots }()
+
+		c.Next()
+	}
+}
+
+// MiddlewareTicketRef passes the X-Ticket-Reference of admin requests on to
+// their audit events. A malformed reference is rejected rather than dropped,
+// so the action isn't taken untraceably.
+func (d *Dependency) MiddlewareTicketRef(c *gin.Context) {
+	ref := c.GetHeader(constants.HeaderTicketRef)
+	if ref == "" {
+		c.Next()
+		return
+	}
+	if !helpers.ValidTicketRef(ref) {
+		d.logRejected("invalid ticket reference: ", ref)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
 		c.Abort()
 		return
 	}
 
-	claim, err := helpers.ValidateToken(c.Request.Context(), auth)
+	c.Request = c.Request.WithContext(helpers.ContextWithTicketRef(c.Request.Context(), ref))
+	c.Next()
+}
+
+// MiddlewareRequireTicketRef guards sensitive admin actions: while
+// ADMIN_TICKET_REF_REQUIRED is on they are refused without a ticket
+// reference. It runs after MiddlewareTicketRef.
+func (d *Dependency) MiddlewareRequireTicketRef(c *gin.Context) {
+	if _, ok := helpers.TicketRefFromContext(c.Request.Context()); d.TicketRefRequired && !ok {
+		d.logRejected("sensitive admin action without a ticket reference: ", c.FullPath())
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrTicketRefRequired, nil)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// ReplayProtection tracks the nonces of requests to sensitive endpoints,
+// accepted within Window of their timestamp and required while Required.
+type ReplayProtection struct {
+	Nonces   interfaces.IReplayNonceRepository
+	Window   time.Duration
+	Required bool
+}
+
+// MiddlewareReplayProtection makes requests to sensitive endpoints
+// single-use: one carrying X-Request-Nonce and X-Request-Timestamp is only
+// accepted while the timestamp is within the replay window, and only once
+// per nonce and user, so a request captured on a hostile network can't be
+// sent again. X-Request-Signature, keyed with the session's request signing
+// key, binds the nonce to the method, path and body, so it is checked before
+// the nonce is spent. Requests without the headers only pass while replay
+// protection isn't required. It runs after MiddlewareValidateAuth. Redis
+// errors fail open, like the user quota.
+func (d *Dependency) MiddlewareReplayProtection(c *gin.Context) {
+	nonce := c.GetHeader(constants.HeaderRequestNonce)
+	timestamp := c.GetHeader(constants.HeaderRequestTimestamp)
+	signature := c.GetHeader(constants.HeaderRequestSignature)
+	if nonce == "" && timestamp == "" && signature == "" {
+		if d.ReplayProtection.Required {
+			d.logRejected("sensitive request without a nonce: ", c.FullPath())
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrRequestNonceRequired, nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+		return
+	}
+
+	if !helpers.ValidRequestNonce(nonce) || !helpers.RequestTimestampFresh(timestamp, time.Now(), d.ReplayProtection.Window) {
+		d.logRejected("invalid or stale request nonce: ", c.FullPath())
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		c.Abort()
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
 	if err != nil {
-		log.Println(err)
-		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
+		helpers.Logger.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
 		c.Abort()
 		return
 	}
 
-	if time.Now().Unix() > claim.ExpiresAt.Unix() {
-		log.Println("jwt token is expired: ", claim.ExpiresAt)
-		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
+	if tokenClaim.ID == "" || !helpers.VerifyNoncedRequest(c.Request, helpers.RequestSigningKey(tokenClaim.ID), nonce, timestamp, signature) {
+		d.logRejected("invalid request signature: ", c.FullPath())
+		helpers.SendResponseHTTP(c, http.StatusUnauthorized, constants.ErrRequestSignature, nil)
 		c.Abort()
 		return
 	}
 
-	c.Set("token", claim)
+	// the timestamp is accepted up to the window either side of now, so the
+	// nonce is kept for twice that
+	fresh, err := d.ReplayProtection.Nonces.ClaimRequestNonce(c.Request.Context(), tokenClaim.UserID, nonce, 2*d.ReplayProtection.Window)
+	if err != nil {
+		helpers.Logger.Error("failed to check request nonce: ", err)
+	} else if !fresh {
+		d.logRejected("replayed request nonce: ", c.FullPath())
+		helpers.SendResponseHTTP(c, http.StatusConflict, constants.ErrRequestReplayed, nil)
+		c.Abort()
+		return
+	}
 
 	c.Next()
 }
+
+// MiddlewareUserQuota counts the request against the user's daily quota of
+// name and answers a 429 with Retry-After once it is used up. The quota is
+// reported in X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset,
+// the reset as unix seconds. It runs after MiddlewareValidateAuth. Redis
+// errors fail open, like the caller guard.
+func (d *Dependency) MiddlewareUserQuota(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenClaim, err := helpers.GetTokenClaim(c)
+		if err != nil {
+			helpers.Logger.Error(err)
+			helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+			c.Abort()
+			return
+		}
+
+		now := time.Now()
+		usage, err := d.Quotas.ConsumeQuota(c.Request.Context(), tokenClaim.UserID, name, now)
+		if usage.Limit > 0 {
+			c.Header("X-RateLimit-Limit", strconv.Itoa(usage.Limit))
+			c.Header("X-RateLimit-Remaining", strconv.Itoa(usage.Remaining))
+			c.Header("X-RateLimit-Reset", strconv.FormatInt(usage.ResetAt.Unix(), 10))
+		}
+		if apperr.Is(err, apperr.RateLimited) {
+			d.logRejected("user quota used up: ", name)
+			c.Header("Retry-After", strconv.Itoa(max(1, int(usage.ResetAt.Sub(now).Seconds()))))
+			helpers.SendErrorHTTP(c, err)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			helpers.Logger.Error("failed to check user quota: ", err)
+		}
+
+		c.Next()
+	}
+}
+
+// RouteTimeouts are the request deadlines of each route group, zero disables
+// the deadline.
+type RouteTimeouts struct {
+	Default         time.Duration
+	Login           time.Duration
+	Admin           time.Duration
+	OAuth           time.Duration
+	TokenValidation time.Duration
+}
+
+// MiddlewareTimeout cancels the request context after timeout, so a slow
+// database or Redis gives up instead of holding the worker. The response is
+// buffered: when the deadline passes and the handler failed or wrote
+// nothing, it is replaced by a 504. A late success is still sent.
+func (d *Dependency) MiddlewareTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		writer := &timeoutWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && (writer.status == 0 || writer.status >= http.StatusInternalServerError) {
+			helpers.HTTPRequestTimeouts.WithLabelValues(c.FullPath()).Inc()
+			helpers.Logger.Warn("request timed out: ", c.FullPath())
+			helpers.SendResponseHTTP(c, http.StatusGatewayTimeout, constants.ErrGatewayTimeout, nil)
+			return
+		}
+
+		if writer.status != 0 {
+			c.Writer.WriteHeader(writer.status)
+		}
+		c.Writer.WriteHeaderNow()
+		if _, err := c.Writer.Write(writer.body.Bytes()); err != nil {
+			helpers.Logger.Error("failed to write response: ", err)
+		}
+	}
+}
+
+// timeoutWriter holds back the response until MiddlewareTimeout knows
+// whether the deadline passed.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	return w.body.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+	return w.body.WriteString(s)
+}
+
+func (w *timeoutWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	if w.status == 0 {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	return w.status != 0
+}
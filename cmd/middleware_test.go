@@ -0,0 +1,429 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/mocks"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMiddlewareTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	// slow waits for the deadline like a cancelled query would, then fails
+	slow := func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, "failed", nil)
+	}
+	lateSuccess := func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		helpers.SendResponseHTTP(c, http.StatusOK, "ok", nil)
+	}
+	fast := func(c *gin.Context) {
+		helpers.SendResponseHTTP(c, http.StatusCreated, "ok", nil)
+	}
+	silent := func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	}
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		handler  gin.HandlerFunc
+		wantCode int
+	}{
+		{name: "fast handler", timeout: time.Second, handler: fast, wantCode: http.StatusCreated},
+		{name: "failed after deadline", timeout: 10 * time.Millisecond, handler: slow, wantCode: http.StatusGatewayTimeout},
+		{name: "nothing written after deadline", timeout: 10 * time.Millisecond, handler: silent, wantCode: http.StatusGatewayTimeout},
+		{name: "late success is kept", timeout: 10 * time.Millisecond, handler: lateSuccess, wantCode: http.StatusOK},
+		{name: "disabled", timeout: 0, handler: fast, wantCode: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dependency{}
+			r := gin.New()
+			r.GET("/test", d.MiddlewareTimeout(tt.timeout), tt.handler)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if w.Body.Len() == 0 {
+				t.Fatal("expected a response body")
+			}
+		})
+	}
+}
+
+func TestMiddlewareBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	// bind reads the body the way JSON binding does, whatever its type
+	bind := func(c *gin.Context) {
+		req := map[string]any{}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, "failed", nil)
+			return
+		}
+		helpers.SendResponseHTTP(c, http.StatusOK, "ok", nil)
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		wantCode    int
+	}{
+		{name: "small json", body: `{"a":[{"b":1}]}`, contentType: "application/json", wantCode: http.StatusOK},
+		{name: "too large", body: `{"a":"` + strings.Repeat("x", 100) + `"}`, contentType: "application/json", wantCode: http.StatusRequestEntityTooLarge},
+		{name: "too deep", body: strings.Repeat("[", 5) + strings.Repeat("]", 5), contentType: "application/json", wantCode: http.StatusBadRequest},
+		{name: "too deep without json content type", body: strings.Repeat("[", 5) + strings.Repeat("]", 5), contentType: "text/plain", wantCode: http.StatusBadRequest},
+		{name: "brackets inside strings", body: `{"a":"[[[[[\"[[[["}`, contentType: "application/json", wantCode: http.StatusOK},
+		{name: "forms are not scanned", body: "a=[[[[[", contentType: "application/x-www-form-urlencoded", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dependency{}
+			r := gin.New()
+			r.POST("/test", d.MiddlewareBodyLimit(BodyLimits{MaxBytes: 64, MaxJSONDepth: 4}), bind)
+
+			req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMiddlewareConcurrencyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	d := &Dependency{RouteConcurrency: RouteConcurrency{RetryAfter: 2 * time.Second}}
+	r := gin.New()
+	r.GET("/test", d.MiddlewareConcurrencyLimit("test", 1), func(c *gin.Context) {
+		if c.Query("block") != "" {
+			started <- struct{}{}
+			<-release
+		}
+		helpers.SendResponseHTTP(c, http.StatusOK, "ok", nil)
+	})
+
+	blocked := make(chan int)
+	go func() {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test?block=1", nil))
+		blocked <- w.Code
+	}()
+	<-started
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "2" {
+		t.Fatalf("expected a 503 with Retry-After 2 while full, got %d %q", w.Code, w.Header().Get("Retry-After"))
+	}
+
+	close(release)
+	if code := <-blocked; code != http.StatusOK {
+		t.Fatalf("expected the admitted request to succeed, got %d", code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the slot to be released, got %d", w.Code)
+	}
+}
+
+func TestMiddlewareUserQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name          string
+		usage         models.QuotaUsage
+		err           error
+		wantCode      int
+		wantRemaining string
+	}{
+		{name: "within quota", usage: models.QuotaUsage{Limit: 5, Used: 2, Remaining: 3, ResetAt: resetAt}, wantCode: http.StatusOK, wantRemaining: "3"},
+		{name: "used up", usage: models.QuotaUsage{Limit: 5, Used: 5, ResetAt: resetAt}, err: services.ErrQuotaExceeded, wantCode: http.StatusTooManyRequests, wantRemaining: "0"},
+		{name: "redis down fails open", usage: models.QuotaUsage{Limit: 5, Remaining: 5, ResetAt: resetAt}, err: errors.New("connection refused"), wantCode: http.StatusOK, wantRemaining: "5"},
+		{name: "unlimited", wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotas := mocks.NewMockIUserQuotaService(gomock.NewController(t))
+			quotas.EXPECT().ConsumeQuota(gomock.Any(), 42, "test", gomock.Any()).Return(tt.usage, tt.err)
+
+			d := &Dependency{Quotas: quotas}
+			r := gin.New()
+			r.GET("/test", func(c *gin.Context) {
+				c.Set("token", &helpers.ClaimToken{UserID: 42})
+			}, d.MiddlewareUserQuota("test"), func(c *gin.Context) {
+				helpers.SendResponseHTTP(c, http.StatusOK, "ok", nil)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("X-RateLimit-Remaining"); got != tt.wantRemaining {
+				t.Errorf("expected X-RateLimit-Remaining %q, got %q", tt.wantRemaining, got)
+			}
+			if tt.usage.Limit > 0 && w.Header().Get("X-RateLimit-Reset") != strconv.FormatInt(resetAt.Unix(), 10) {
+				t.Errorf("expected X-RateLimit-Reset %d, got %q", resetAt.Unix(), w.Header().Get("X-RateLimit-Reset"))
+			}
+			if tt.wantCode == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+				t.Error("expected a Retry-After with the 429")
+			}
+		})
+	}
+}
+
+func TestMiddlewareTicketRef(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	tests := []struct {
+		name     string
+		required bool
+		ref      string
+		wantCode int
+		wantRef  string
+	}{
+		{name: "optional and missing", wantCode: http.StatusOK},
+		{name: "optional and given", ref: "SUP-1234", wantCode: http.StatusOK, wantRef: "SUP-1234"},
+		{name: "required and given", required: true, ref: "SUP-1234", wantCode: http.StatusOK, wantRef: "SUP-1234"},
+		{name: "required and missing", required: true, wantCode: http.StatusBadRequest},
+		{name: "malformed", ref: "SUP 1234", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dependency{TicketRefRequired: tt.required}
+			gotRef := ""
+			r := gin.New()
+			r.POST("/test", d.MiddlewareTicketRef, d.MiddlewareRequireTicketRef, func(c *gin.Context) {
+				gotRef, _ = helpers.TicketRefFromContext(c.Request.Context())
+				helpers.SendResponseHTTP(c, http.StatusOK, "ok", nil)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/test", nil)
+			if tt.ref != "" {
+				req.Header.Set("X-Ticket-Reference", tt.ref)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if gotRef != tt.wantRef {
+				t.Errorf("expected ticket reference %q, got %q", tt.wantRef, gotRef)
+			}
+		})
+	}
+}
+
+func TestMiddlewareReplayProtection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+	helpers.Env = map[string]string{"APP_SECRET": "test-secret"}
+	t.Cleanup(func() { helpers.Env = map[string]string{} })
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	nonce := "3f2c9a1e7b4d4f0e9c6a2b8d1e5f7a90"
+	body := `{"full_name":"Jane"}`
+	key := helpers.RequestSigningKey("jti-1")
+	tests := []struct {
+		name       string
+		required   bool
+		nonce      string
+		timestamp  string
+		signedBody string
+		signingKey string
+		claims     bool
+		fresh      bool
+		claimErr   error
+		wantCode   int
+	}{
+		{name: "optional and missing", wantCode: http.StatusOK},
+		{name: "required and missing", required: true, wantCode: http.StatusBadRequest},
+		{name: "first use", nonce: nonce, timestamp: now, signedBody: body, signingKey: key, claims: true, fresh: true, wantCode: http.StatusOK},
+		{name: "replayed", nonce: nonce, timestamp: now, signedBody: body, signingKey: key, claims: true, wantCode: http.StatusConflict},
+		{name: "unsigned", nonce: nonce, timestamp: now, wantCode: http.StatusUnauthorized},
+		{name: "other body", nonce: nonce, timestamp: now, signedBody: `{"full_name":"Eve"}`, signingKey: key, wantCode: http.StatusUnauthorized},
+		{name: "other session's key", nonce: nonce, timestamp: now, signedBody: body, signingKey: helpers.RequestSigningKey("jti-2"), wantCode: http.StatusUnauthorized},
+		{name: "stale timestamp", nonce: nonce, timestamp: stale, signedBody: body, signingKey: key, wantCode: http.StatusBadRequest},
+		{name: "nonce without timestamp", nonce: nonce, wantCode: http.StatusBadRequest},
+		{name: "short nonce", nonce: "abc", timestamp: now, wantCode: http.StatusBadRequest},
+		{name: "redis down fails open", nonce: nonce, timestamp: now, signedBody: body, signingKey: key, claims: true, claimErr: errors.New("connection refused"), wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nonces := mocks.NewMockIReplayNonceRepository(gomock.NewController(t))
+			if tt.claims {
+				nonces.EXPECT().ClaimRequestNonce(gomock.Any(), 42, tt.nonce, 10*time.Minute).Return(tt.fresh, tt.claimErr)
+			}
+
+			d := &Dependency{ReplayProtection: ReplayProtection{Nonces: nonces, Window: 5 * time.Minute, Required: tt.required}}
+			r := gin.New()
+			r.PUT("/test", func(c *gin.Context) {
+				c.Set("token", &helpers.ClaimToken{UserID: 42, RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1"}})
+			}, d.MiddlewareReplayProtection, func(c *gin.Context) {
+				// the handler still gets the body the signature was checked over
+				got, _ := io.ReadAll(c.Request.Body)
+				if string(got) != body {
+					t.Errorf("handler got body %q", got)
+				}
+				helpers.SendResponseHTTP(c, http.StatusOK, "ok", nil)
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/test", strings.NewReader(body))
+			if tt.nonce != "" {
+				req.Header.Set("X-Request-Nonce", tt.nonce)
+			}
+			if tt.timestamp != "" {
+				req.Header.Set("X-Request-Timestamp", tt.timestamp)
+			}
+			if tt.signingKey != "" {
+				req.Header.Set("X-Request-Signature", helpers.SignNoncedRequest(tt.signingKey, http.MethodPut, "/test", []byte(tt.signedBody), tt.nonce, tt.timestamp))
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMiddlewareValidateAuthTokenType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+	helpers.Env = map[string]string{"APP_SECRET": strings.Repeat("s", 32)}
+	t.Cleanup(func() { helpers.Env = map[string]string{} })
+
+	ctx := context.Background()
+	access, err := helpers.GenerateToken(ctx, 42, "user", "User", "token", "user@example.com", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	refresh, err := helpers.GenerateToken(ctx, 42, "user", "User", "refresh_token", "user@example.com", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := helpers.ValidateToken(ctx, access)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegated, _, err := helpers.GenerateDelegatedToken(ctx, subject, "wallet", "gateway", time.Minute, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// tokens issued before the typ claim
+	untyped, err := jwt.NewWithClaims(jwt.SigningMethodHS256, helpers.ClaimToken{
+		UserID:           42,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}).SignedString([]byte(helpers.Env["APP_SECRET"]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		refresh    bool
+		hasSession bool
+		wantCode   int
+	}{
+		{name: "access token", token: access, wantCode: http.StatusOK},
+		{name: "refresh token as access token", token: refresh, wantCode: http.StatusUnauthorized},
+		{name: "untyped token with a session", token: untyped, hasSession: true, wantCode: http.StatusOK},
+		{name: "untyped token without a session", token: untyped, wantCode: http.StatusUnauthorized},
+		{name: "refresh token", token: refresh, refresh: true, wantCode: http.StatusOK},
+		{name: "access token as refresh token", token: access, refresh: true, wantCode: http.StatusUnauthorized},
+		{name: "delegated token", token: delegated, wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			tokenCache := mocks.NewMockITokenCacheRepository(ctrl)
+			tokenCache.EXPECT().IsTokenRevoked(gomock.Any(), tt.token, gomock.Any()).Return(false, nil).AnyTimes()
+			sessions := mocks.NewMockISessionRepository(ctrl)
+			if tt.token == untyped {
+				var err error
+				if !tt.hasSession {
+					err = errors.New("record not found")
+				}
+				sessions.EXPECT().GetUserSessionByToken(gomock.Any(), tt.token).Return(models.UserSession{}, err)
+			}
+
+			d := &Dependency{
+				SessionRepo:         sessions,
+				TokenCache:          tokenCache,
+				StatelessValidation: helpers.NewReloadable(func() bool { return true }),
+			}
+			middleware := d.MiddlewareValidateAuth
+			if tt.refresh {
+				middleware = d.MiddlewareRefreshToken
+			}
+			r := gin.New()
+			r.GET("/test", middleware, func(c *gin.Context) {
+				helpers.SendResponseHTTP(c, http.StatusOK, "ok", nil)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
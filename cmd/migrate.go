@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+)
+
+const migrateUsage = "usage: migrate (status | expand | contract)"
+
+// RunMigrate migrates the schema out of band, for DB_AUTO_MIGRATE=false
+// deployments and contract migrations, e.g. `ewallet-ums migrate expand`
+// before a rolling deploy and `ewallet-ums migrate contract` once every pod
+// runs the new release. `migrate status` reports whether this binary can run
+// against the schema.
+func RunMigrate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf(migrateUsage)
+	}
+
+	switch args[0] {
+	case "status":
+		problems, err := helpers.SchemaProblems(helpers.DB)
+		if err != nil {
+			return err
+		}
+		pending, err := helpers.PendingMigrations()
+		if err != nil {
+			return err
+		}
+
+		helpers.Logger.Infof("binary schema version %d", helpers.SchemaVersion())
+		for _, problem := range append(problems, pending...) {
+			helpers.Logger.Warn("incompatible schema: ", problem)
+		}
+		if len(problems) > 0 || len(pending) > 0 {
+			return fmt.Errorf("schema is incompatible with this binary")
+		}
+		helpers.Logger.Info("schema is compatible with this binary")
+		return nil
+	case constants.MigrationPhaseExpand, constants.MigrationPhaseContract:
+		ran, err := helpers.MigrateSchema(helpers.DB, args[0])
+		for _, migration := range ran {
+			helpers.Logger.Infof("applied %s migration %d %s", migration.Phase, migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		helpers.Logger.Infof("%s migrations applied: %d", args[0], len(ran))
+		return nil
+	default:
+		return fmt.Errorf(migrateUsage)
+	}
+}
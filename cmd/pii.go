@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+// RunPIIRotate rotates the PII data key and re-encrypts existing rows, e.g.
+// `ewallet-ums pii-rotate` after adding a new master key to PII_MASTER_KEYS
+// and pointing PII_ACTIVE_MASTER_KEY at it, or
+// `ewallet-ums pii-rotate --new-data-key=false --reindex` after changing
+// PII_LOOKUP_KEY.
+func RunPIIRotate(args []string) error {
+	fs := flag.NewFlagSet("pii-rotate", flag.ContinueOnError)
+	newDataKey := fs.Bool("new-data-key", true, "create a new active data key before re-encrypting")
+	rewrap := fs.Bool("rewrap", true, "re-wrap old data keys under the active master key")
+	reindex := fs.Bool("reindex", false, "rewrite every user, not only stale ones, to recompute the email and phone lookups")
+	batch := fs.Int("batch-size", 500, "rows per re-encryption batch")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *batch <= 0 {
+		return fmt.Errorf("--batch-size must be positive, got %d", *batch)
+	}
+
+	rotationSvc := &services.PIIRotationService{
+		PIIRepo:   &repository.PIIRepository{DB: helpers.DB},
+		Keyring:   helpers.PII,
+		BatchSize: *batch,
+	}
+
+	reencrypted, err := rotationSvc.Rotate(context.Background(), *newDataKey, *rewrap, *reindex)
+	if err != nil {
+		return err
+	}
+
+	helpers.Logger.Info("users re-encrypted: ", reencrypted)
+	return nil
+}
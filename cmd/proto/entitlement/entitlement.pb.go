@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.34.0--dev
+// source: entitlement.proto
+
+package entitlement
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UserEntitlementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEntitlementsRequest) Reset() {
+	*x = UserEntitlementsRequest{}
+	mi := &file_entitlement_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEntitlementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEntitlementsRequest) ProtoMessage() {}
+
+func (x *UserEntitlementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_entitlement_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEntitlementsRequest.ProtoReflect.Descriptor instead.
+func (*UserEntitlementsRequest) Descriptor() ([]byte, []int) {
+	return file_entitlement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserEntitlementsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UserEntitlementsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Message Indicating Success
+	// Granted by an admin or following from the KYC status, e.g.
+	// international_transfer or crypto, sorted
+	Features      []string `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEntitlementsResponse) Reset() {
+	*x = UserEntitlementsResponse{}
+	mi := &file_entitlement_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEntitlementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEntitlementsResponse) ProtoMessage() {}
+
+func (x *UserEntitlementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_entitlement_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEntitlementsResponse.ProtoReflect.Descriptor instead.
+func (*UserEntitlementsResponse) Descriptor() ([]byte, []int) {
+	return file_entitlement_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserEntitlementsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UserEntitlementsResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+var File_entitlement_proto protoreflect.FileDescriptor
+
+const file_entitlement_proto_rawDesc = "" +
+	"\n" +
+	"\x11entitlement.proto\x12\ventitlement\"2\n" +
+	"\x17UserEntitlementsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"P\n" +
+	"\x18UserEntitlementsResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1a\n" +
+	"\bfeatures\x18\x02 \x03(\tR\bfeatures2q\n" +
+	"\vEntitlement\x12b\n" +
+	"\x13GetUserEntitlements\x12$.entitlement.UserEntitlementsRequest\x1a%.entitlement.UserEntitlementsResponseB\x0fZ\r./entitlementb\x06proto3"
+
+var (
+	file_entitlement_proto_rawDescOnce sync.Once
+	file_entitlement_proto_rawDescData []byte
+)
+
+func file_entitlement_proto_rawDescGZIP() []byte {
+	file_entitlement_proto_rawDescOnce.Do(func() {
+		file_entitlement_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_entitlement_proto_rawDesc), len(file_entitlement_proto_rawDesc)))
+	})
+	return file_entitlement_proto_rawDescData
+}
+
+var file_entitlement_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_entitlement_proto_goTypes = []any{
+	(*UserEntitlementsRequest)(nil),  // 0: entitlement.UserEntitlementsRequest
+	(*UserEntitlementsResponse)(nil), // 1: entitlement.UserEntitlementsResponse
+}
+var file_entitlement_proto_depIdxs = []int32{
+	0, // 0: entitlement.Entitlement.GetUserEntitlements:input_type -> entitlement.UserEntitlementsRequest
+	1, // 1: entitlement.Entitlement.GetUserEntitlements:output_type -> entitlement.UserEntitlementsResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_entitlement_proto_init() }
+func file_entitlement_proto_init() {
+	if File_entitlement_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_entitlement_proto_rawDesc), len(file_entitlement_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_entitlement_proto_goTypes,
+		DependencyIndexes: file_entitlement_proto_depIdxs,
+		MessageInfos:      file_entitlement_proto_msgTypes,
+	}.Build()
+	File_entitlement_proto = out.File
+	file_entitlement_proto_goTypes = nil
+	file_entitlement_proto_depIdxs = nil
+}
@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.34.0--dev
+// source: entitlement.proto
+
+package entitlement
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Entitlement_GetUserEntitlements_FullMethodName = "/entitlement.Entitlement/GetUserEntitlements"
+)
+
+// EntitlementClient is the client API for Entitlement service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Feature entitlements, for services gating product features. Callers
+// authenticate like token validation callers.
+type EntitlementClient interface {
+	// The features the user is entitled to
+	GetUserEntitlements(ctx context.Context, in *UserEntitlementsRequest, opts ...grpc.CallOption) (*UserEntitlementsResponse, error)
+}
+
+type entitlementClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEntitlementClient(cc grpc.ClientConnInterface) EntitlementClient {
+	return &entitlementClient{cc}
+}
+
+func (c *entitlementClient) GetUserEntitlements(ctx context.Context, in *UserEntitlementsRequest, opts ...grpc.CallOption) (*UserEntitlementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserEntitlementsResponse)
+	err := c.cc.Invoke(ctx, Entitlement_GetUserEntitlements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EntitlementServer is the server API for Entitlement service.
+// All implementations must embed UnimplementedEntitlementServer
+// for forward compatibility.
+//
+// Feature entitlements, for services gating product features. Callers
+// authenticate like token validation callers.
+type EntitlementServer interface {
+	// The features the user is entitled to
+	GetUserEntitlements(context.Context, *UserEntitlementsRequest) (*UserEntitlementsResponse, error)
+	mustEmbedUnimplementedEntitlementServer()
+}
+
+// UnimplementedEntitlementServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEntitlementServer struct{}
+
+func (UnimplementedEntitlementServer) GetUserEntitlements(context.Context, *UserEntitlementsRequest) (*UserEntitlementsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserEntitlements not implemented")
+}
+func (UnimplementedEntitlementServer) mustEmbedUnimplementedEntitlementServer() {}
+func (UnimplementedEntitlementServer) testEmbeddedByValue()                     {}
+
+// UnsafeEntitlementServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EntitlementServer will
+// result in compilation errors.
+type UnsafeEntitlementServer interface {
+	mustEmbedUnimplementedEntitlementServer()
+}
+
+func RegisterEntitlementServer(s grpc.ServiceRegistrar, srv EntitlementServer) {
+	// If the following call panics, it indicates UnimplementedEntitlementServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Entitlement_ServiceDesc, srv)
+}
+
+func _Entitlement_GetUserEntitlements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserEntitlementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntitlementServer).GetUserEntitlements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Entitlement_GetUserEntitlements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntitlementServer).GetUserEntitlements(ctx, req.(*UserEntitlementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Entitlement_ServiceDesc is the grpc.ServiceDesc for Entitlement service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Entitlement_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "entitlement.Entitlement",
+	HandlerType: (*EntitlementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserEntitlements",
+			Handler:    _Entitlement_GetUserEntitlements_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "entitlement.proto",
+}
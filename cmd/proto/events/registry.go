@@ -0,0 +1,111 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"ewallet-ums/constants"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Upcaster rewrites a decoded payload of one schema version into the next.
+type Upcaster func(payload map[string]any) error
+
+// Schema is the current schema of a topic's payloads.
+type Schema struct {
+	Version int32
+	New     func() proto.Message
+	// Upcasters[i] turns a version i+1 payload into version i+2, so there is
+	// one less than Version.
+	Upcasters []Upcaster
+}
+
+// Schemas is the registry of every published topic.
+var Schemas = map[string]Schema{
+	constants.EventUserClaimsChanged: {
+		Version: 1,
+		New:     func() proto.Message { return &UserClaimsChanged{} },
+	},
+	constants.EventUserAnonymized: {
+		Version: 1,
+		New:     func() proto.Message { return &UserAnonymized{} },
+	},
+	constants.EventUserDeleted: {
+		Version: 1,
+		New:     func() proto.Message { return &UserDeleted{} },
+	},
+	constants.EventUserRegistered: {
+		Version: 1,
+		New:     func() proto.Message { return &UserRegistered{} },
+	},
+}
+
+// payloads published before schemas were versioned have no schema_version
+const unversioned = 1
+
+const schemaVersionField = "schema_version"
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Marshal encodes event as a payload of topic, stamped with the topic's
+// current schema version.
+func Marshal(topic string, event proto.Message) ([]byte, error) {
+	schema, ok := Schemas[topic]
+	if !ok {
+		return nil, fmt.Errorf("no schema registered for topic %s", topic)
+	}
+	if want := schema.New().ProtoReflect().Descriptor().FullName(); event.ProtoReflect().Descriptor().FullName() != want {
+		return nil, fmt.Errorf("topic %s takes %s, got %s", topic, want, event.ProtoReflect().Descriptor().FullName())
+	}
+
+	event = proto.Clone(event)
+	message := event.ProtoReflect()
+	message.Set(message.Descriptor().Fields().ByName(schemaVersionField), protoreflect.ValueOfInt32(schema.Version))
+	return marshalOptions.Marshal(event)
+}
+
+// Unmarshal decodes a payload of topic published with any schema version up
+// to the current one, upcasting older versions first. Fields added after the
+// consumer was built are ignored.
+func Unmarshal(topic string, data []byte) (proto.Message, error) {
+	schema, ok := Schemas[topic]
+	if !ok {
+		return nil, fmt.Errorf("no schema registered for topic %s", topic)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %v", topic, err)
+	}
+
+	version := int32(unversioned)
+	if v, ok := payload[schemaVersionField].(float64); ok {
+		version = int32(v)
+	}
+	if version < 1 || version > schema.Version {
+		return nil, fmt.Errorf("unsupported %s schema version %d, this consumer reads up to %d", topic, version, schema.Version)
+	}
+
+	for ; version < schema.Version; version++ {
+		if err := schema.Upcasters[version-1](payload); err != nil {
+			return nil, fmt.Errorf("failed to upcast %s payload from version %d: %v", topic, version, err)
+		}
+	}
+	payload[schemaVersionField] = schema.Version
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upcast %s payload: %v", topic, err)
+	}
+	event := schema.New()
+	if err := unmarshalOptions.Unmarshal(data, event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %v", topic, err)
+	}
+	return event, nil
+}
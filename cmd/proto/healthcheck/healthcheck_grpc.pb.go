@@ -0,0 +1,127 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.34.0--dev
+// source: healthcheck.proto
+
+package healthcheck
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Healthcheck_Check_FullMethodName = "/healthcheck.Healthcheck/Check"
+)
+
+// HealthcheckClient is the client API for Healthcheck service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Dependency status for internal dashboards that only speak gRPC.
+type HealthcheckClient interface {
+	// Check every dependency and report their status
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+}
+
+type healthcheckClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHealthcheckClient(cc grpc.ClientConnInterface) HealthcheckClient {
+	return &healthcheckClient{cc}
+}
+
+func (c *healthcheckClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, Healthcheck_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HealthcheckServer is the server API for Healthcheck service.
+// All implementations must embed UnimplementedHealthcheckServer
+// for forward compatibility.
+//
+// Dependency status for internal dashboards that only speak gRPC.
+type HealthcheckServer interface {
+	// Check every dependency and report their status
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	mustEmbedUnimplementedHealthcheckServer()
+}
+
+// UnimplementedHealthcheckServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHealthcheckServer struct{}
+
+func (UnimplementedHealthcheckServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedHealthcheckServer) mustEmbedUnimplementedHealthcheckServer() {}
+func (UnimplementedHealthcheckServer) testEmbeddedByValue()                     {}
+
+// UnsafeHealthcheckServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HealthcheckServer will
+// result in compilation errors.
+type UnsafeHealthcheckServer interface {
+	mustEmbedUnimplementedHealthcheckServer()
+}
+
+func RegisterHealthcheckServer(s grpc.ServiceRegistrar, srv HealthcheckServer) {
+	// If the following call panics, it indicates UnimplementedHealthcheckServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Healthcheck_ServiceDesc, srv)
+}
+
+func _Healthcheck_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthcheckServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Healthcheck_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthcheckServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Healthcheck_ServiceDesc is the grpc.ServiceDesc for Healthcheck service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Healthcheck_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "healthcheck.Healthcheck",
+	HandlerType: (*HealthcheckServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Check",
+			Handler:    _Healthcheck_Check_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "healthcheck.proto",
+}
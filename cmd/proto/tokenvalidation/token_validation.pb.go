@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.11
+// 	protoc-gen-go v1.36.10
 // 	protoc        v6.34.0--dev
 // source: token_validation.proto
 
@@ -23,8 +23,10 @@ const (
 
 // The Request Message Containing The Token To Validate
 type TokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The Caller's Audience, Tokens Issued For Another Audience Are Rejected
+	Audience      string `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,6 +68,13 @@ func (x *TokenRequest) GetToken() string {
 	return ""
 }
 
+func (x *TokenRequest) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
 // The Response Message
 type TokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -121,10 +130,19 @@ func (x *TokenResponse) GetData() *UserData {
 
 // The User Data Returned If The Token Is Valid
 type UserData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	FullName string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	// Percentage (0-100) of the profile filled in, users may register with
+	// only an email or phone number and complete the rest later
+	ProfileCompleteness int32 `protobuf:"varint,4,opt,name=profile_completeness,json=profileCompleteness,proto3" json:"profile_completeness,omitempty"`
+	// Guests have not registered yet, their tokens are bound to device_id and
+	// callers should check it against the device making the request
+	Guest    bool   `protobuf:"varint,5,opt,name=guest,proto3" json:"guest,omitempty"`
+	DeviceId string `protobuf:"bytes,6,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Features the user is entitled to, see the entitlement service
+	Entitlements  []string `protobuf:"bytes,7,rep,name=entitlements,proto3" json:"entitlements,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -180,20 +198,53 @@ func (x *UserData) GetFullName() string {
 	return ""
 }
 
+func (x *UserData) GetProfileCompleteness() int32 {
+	if x != nil {
+		return x.ProfileCompleteness
+	}
+	return 0
+}
+
+func (x *UserData) GetGuest() bool {
+	if x != nil {
+		return x.Guest
+	}
+	return false
+}
+
+func (x *UserData) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *UserData) GetEntitlements() []string {
+	if x != nil {
+		return x.Entitlements
+	}
+	return nil
+}
+
 var File_token_validation_proto protoreflect.FileDescriptor
 
 const file_token_validation_proto_rawDesc = "" +
 	"\n" +
-	"\x16token_validation.proto\x12\x0ftokenvalidation\"$\n" +
+	"\x16token_validation.proto\x12\x0ftokenvalidation\"@\n" +
 	"\fTokenRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"X\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\baudience\x18\x02 \x01(\tR\baudience\"X\n" +
 	"\rTokenResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12-\n" +
-	"\x04data\x18\x02 \x01(\v2\x19.tokenvalidation.UserDataR\x04data\"\\\n" +
+	"\x04data\x18\x02 \x01(\v2\x19.tokenvalidation.UserDataR\x04data\"\xe6\x01\n" +
 	"\bUserData\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
-	"\tfull_name\x18\x03 \x01(\tR\bfullName2a\n" +
+	"\tfull_name\x18\x03 \x01(\tR\bfullName\x121\n" +
+	"\x14profile_completeness\x18\x04 \x01(\x05R\x13profileCompleteness\x12\x14\n" +
+	"\x05guest\x18\x05 \x01(\bR\x05guest\x12\x1b\n" +
+	"\tdevice_id\x18\x06 \x01(\tR\bdeviceId\x12\"\n" +
+	"\fentitlements\x18\a \x03(\tR\fentitlements2a\n" +
 	"\x0fTokenValidation\x12N\n" +
 	"\rValidateToken\x12\x1d.tokenvalidation.TokenRequest\x1a\x1e.tokenvalidation.TokenResponseB\x13Z\x11./tokenvalidationb\x06proto3"
 
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"ewallet-ums/helpers"
+)
+
+// RunRetention enforces the retention rules once, e.g.
+// `ewallet-ums retention --dry-run` to report what the worker would purge.
+func RunRetention(args []string) error {
+	fs := flag.NewFlagSet("retention", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "count rows past retention without purging them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	results, err := newRetentionService().Enforce(context.Background(), time.Now(), *dryRun)
+	for _, result := range results {
+		helpers.Logger.Infof("retention rule %s: %d rows before %s (dry run: %t)", result.Rule, result.Rows, result.Cutoff.Format(time.RFC3339), result.DryRun)
+	}
+	return err
+}
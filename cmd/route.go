@@ -1,13 +1,137 @@
 package cmd
 
-import "github.com/gin-gonic/gin"
+import (
+	"ewallet-ums/constants"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
 
 func route(r *gin.Engine, dependency Dependency) {
+	r.Use(dependency.MiddlewareRequestID, dependency.MiddlewareErrorReporting, dependency.MiddlewareLocale)
+
 	r.GET("/health", dependency.HealthcheckAPI.HealthcheckHandlerHTTP)
+	// OpenMetrics for scrapers that ask for it, the text format otherwise
+	r.GET("/metrics", gin.WrapH(promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}))))
+
+	timeouts := dependency.RouteTimeouts
+	concurrency := dependency.RouteConcurrency
+	bodyLimit := dependency.MiddlewareBodyLimit(dependency.BodyLimits)
+	// requests are shed before anything else is spent on them
+	adminLimit := dependency.MiddlewareConcurrencyLimit("admin", concurrency.Admin)
 
-	userV1 := r.Group("/user/v1")
-	userV1.POST("/register", dependency.RegisterAPI.Register)
-	userV1.POST("/login", dependency.LoginAPI.Login)
+	userLimit := dependency.MiddlewareConcurrencyLimit("user", concurrency.Default)
+
+	userV1 := r.Group("/user/v1", userLimit, bodyLimit, dependency.MiddlewareTimeout(timeouts.Default))
+	userV1.POST("/register", dependency.MiddlewareConcurrencyLimit("register", concurrency.Register), dependency.RegisterAPI.Register)
+	userV1.POST("/register/phone", dependency.MiddlewareConcurrencyLimit("register", concurrency.Register), dependency.OTPAPI.RegisterWithPhone)
+	userV1.POST("/otp", dependency.OTPAPI.RequestOTP)
+	userV1.POST("/login", dependency.MiddlewareConcurrencyLimit("login", concurrency.Login), dependency.MiddlewareTimeout(timeouts.Login), dependency.LoginAPI.Login)
+	userV1.POST("/guest", dependency.GuestAPI.CreateGuest)
+	userV1.POST("/guest/upgrade", dependency.MiddlewareValidateAuth, dependency.GuestAPI.UpgradeGuest)
 	userV1.DELETE("/logout", dependency.MiddlewareValidateAuth, dependency.LogoutAPI.Logout)
 	userV1.PUT("/refresh-token", dependency.MiddlewareRefreshToken, dependency.RefreshTokenAPI.RefreshToken)
+	userV1.GET("/wallet-status", dependency.MiddlewareValidateAuth, dependency.WalletStatusAPI.GetWalletStatus)
+	userV1.GET("/profile", dependency.MiddlewareValidateAuth, dependency.ProfileAPI.GetProfile)
+	userV1.PUT("/profile", dependency.MiddlewareValidateAuth, dependency.MiddlewareReplayProtection, dependency.ProfileAPI.UpdateProfile)
+	userV1.PUT("/password", dependency.MiddlewareValidateAuth, dependency.MiddlewareReplayProtection, dependency.ProfileAPI.SetPassword)
+	userV1.POST("/email-change/revert", dependency.ProfileAPI.RevertEmailChange)
+	userV1.DELETE("/account", dependency.MiddlewareValidateAuth, dependency.AccountDeletionAPI.RequestDeletion)
+	userV1.GET("/sessions", dependency.MiddlewareValidateAuth, dependency.SessionAPI.GetSessions)
+	userV1.GET("/login-history", dependency.MiddlewareValidateAuth, dependency.LoginHistoryAPI.GetLoginHistories)
+	userV1.GET("/quotas", dependency.MiddlewareValidateAuth, dependency.UserQuotaAPI.GetQuotaUsage)
+	userV1.GET("/consents", dependency.MiddlewareValidateAuth, dependency.ConsentAPI.GetConsents)
+	userV1.PUT("/consents", dependency.MiddlewareValidateAuth, dependency.ConsentAPI.UpdateConsents)
+	userV1.GET("/notification-preferences", dependency.MiddlewareValidateAuth, dependency.NotificationAPI.GetPreferences)
+	userV1.PUT("/notification-preferences", dependency.MiddlewareValidateAuth, dependency.NotificationAPI.UpdatePreferences)
+	userV1.GET("/notification-preferences/quiet-hours", dependency.MiddlewareValidateAuth, dependency.NotificationAPI.GetQuietHours)
+	userV1.PUT("/notification-preferences/quiet-hours", dependency.MiddlewareValidateAuth, dependency.NotificationAPI.UpdateQuietHours)
+	userV1.DELETE("/notification-preferences/quiet-hours", dependency.MiddlewareValidateAuth, dependency.NotificationAPI.DeleteQuietHours)
+	userV1.GET("/notification-preferences/unsubscribe", dependency.NotificationAPI.Unsubscribe)
+	userV1.POST("/notification-preferences/unsubscribe", dependency.NotificationAPI.Unsubscribe)
+	userV1.GET("/addresses", dependency.MiddlewareValidateAuth, dependency.AddressAPI.GetAddresses)
+	userV1.POST("/addresses", dependency.MiddlewareValidateAuth, dependency.AddressAPI.AddAddress)
+	userV1.PUT("/addresses/:id", dependency.MiddlewareValidateAuth, dependency.AddressAPI.UpdateAddress)
+	userV1.PUT("/addresses/:id/primary", dependency.MiddlewareValidateAuth, dependency.AddressAPI.SetPrimaryAddress)
+	userV1.DELETE("/addresses/:id", dependency.MiddlewareValidateAuth, dependency.AddressAPI.DeleteAddress)
+	userV1.GET("/kyc/profile", dependency.MiddlewareValidateAuth, dependency.KycAPI.GetKycProfile)
+	userV1.PUT("/kyc/profile", dependency.MiddlewareValidateAuth, dependency.KycAPI.UpdateKycProfile)
+	userV1.GET("/security-questions", dependency.MiddlewareValidateAuth, dependency.SecurityQuestionAPI.GetSecurityQuestions)
+	userV1.PUT("/security-questions", dependency.MiddlewareValidateAuth, dependency.SecurityQuestionAPI.SetSecurityQuestions)
+	userV1.POST("/recovery/security-questions", dependency.SecurityQuestionAPI.GetRecoveryQuestions)
+	userV1.POST("/recovery/security-questions/verify", dependency.SecurityQuestionAPI.RecoverWithSecurityQuestions)
+	userV1.POST("/recovery/manual/reset", dependency.AccountRecoveryAPI.CompleteReset)
+
+	// evidence uploads are larger than the body limit, the handler has its own
+	r.POST("/user/v1/recovery/manual", userLimit, dependency.MiddlewareTimeout(timeouts.Default), dependency.AccountRecoveryAPI.SubmitTicket)
+
+	adminV1 := r.Group("/admin/v1", adminLimit, dependency.MiddlewareTimeout(timeouts.Admin), dependency.MiddlewareValidateAuth, dependency.MiddlewareRequireRole(constants.RoleAdmin), dependency.MiddlewareTicketRef)
+	adminV1.POST("/approvals", dependency.MiddlewareRequireTicketRef, dependency.AdminApprovalAPI.RequestApproval)
+	adminV1.GET("/approvals", dependency.AdminApprovalAPI.GetApprovals)
+	adminV1.POST("/approvals/:id/approve", dependency.MiddlewareRequireTicketRef, dependency.AdminApprovalAPI.Approve)
+	adminV1.POST("/approvals/:id/reject", dependency.MiddlewareRequireTicketRef, dependency.AdminApprovalAPI.Reject)
+	adminV1.GET("/recovery-tickets", dependency.AccountRecoveryAPI.GetTickets)
+	adminV1.GET("/recovery-tickets/:id", dependency.AccountRecoveryAPI.GetTicket)
+	adminV1.POST("/recovery-tickets/:id/reject", dependency.AccountRecoveryAPI.RejectTicket)
+	adminV1.POST("/service-accounts", dependency.ServiceAccountAPI.CreateServiceAccount)
+	adminV1.GET("/service-accounts", dependency.ServiceAccountAPI.GetServiceAccounts)
+	adminV1.GET("/service-accounts/:id", dependency.ServiceAccountAPI.GetServiceAccount)
+	adminV1.PUT("/service-accounts/:id", dependency.ServiceAccountAPI.UpdateServiceAccount)
+	adminV1.DELETE("/service-accounts/:id", dependency.ServiceAccountAPI.DeleteServiceAccount)
+	adminV1.POST("/service-accounts/:id/rotate-secret", dependency.MiddlewareRequireTicketRef, dependency.ServiceAccountAPI.RotateSecret)
+	adminV1.POST("/user-imports", dependency.MiddlewareRequireTicketRef, dependency.MiddlewareUserQuota(constants.QuotaUserImports), dependency.UserImportAPI.ImportUsers)
+	adminV1.GET("/user-imports/:id", dependency.UserImportAPI.GetImport)
+	adminV1.POST("/user-exports", dependency.MiddlewareRequireTicketRef, dependency.MiddlewareUserQuota(constants.QuotaUserExports), dependency.UserExportAPI.RequestExport)
+	adminV1.GET("/user-exports/:id", dependency.UserExportAPI.GetExport)
+	adminV1.GET("/login-velocity/hot-sources", dependency.LoginVelocityAPI.GetHotSources)
+	adminV1.GET("/users", dependency.AdminUserAPI.GetUsers)
+	adminV1.POST("/users/:id/force-logout", dependency.MiddlewareRequireTicketRef, dependency.AdminUserAPI.ForceLogout)
+	adminV1.GET("/users/:id/kyc/profile", dependency.KycAPI.GetUserKycProfile)
+	adminV1.GET("/users/:id/notes", dependency.AccountNoteAPI.GetNotes)
+	adminV1.POST("/users/:id/notes", dependency.AccountNoteAPI.AddNote)
+	adminV1.PUT("/notes/:id", dependency.AccountNoteAPI.UpdateNote)
+	adminV1.DELETE("/notes/:id", dependency.AccountNoteAPI.DeleteNote)
+	adminV1.GET("/users/:id/entitlements", dependency.EntitlementAPI.GetEntitlements)
+	adminV1.PUT("/users/:id/entitlements/:feature", dependency.MiddlewareRequireTicketRef, dependency.EntitlementAPI.GrantEntitlement)
+	adminV1.DELETE("/users/:id/entitlements/:feature", dependency.MiddlewareRequireTicketRef, dependency.EntitlementAPI.RevokeEntitlement)
+	adminV1.GET("/reserved-usernames", dependency.ReservedUsernameAPI.GetReservedUsernames)
+	adminV1.POST("/reserved-usernames", dependency.ReservedUsernameAPI.ReserveUsername)
+	adminV1.DELETE("/reserved-usernames/:username", dependency.ReservedUsernameAPI.DeleteReservedUsername)
+	adminV1.POST("/reserved-usernames/:username/claim", dependency.MiddlewareRequireTicketRef, dependency.ReservedUsernameAPI.ClaimReservedUsername)
+	adminV1.GET("/dead-letters", dependency.DeadLetterAPI.GetDeadLetters)
+	adminV1.GET("/dead-letters/:id", dependency.DeadLetterAPI.GetDeadLetter)
+	adminV1.PUT("/dead-letters/:id", dependency.DeadLetterAPI.UpdateDeadLetter)
+	adminV1.POST("/dead-letters/:id/replay", dependency.DeadLetterAPI.ReplayDeadLetter)
+	adminV1.POST("/dead-letters/:id/discard", dependency.DeadLetterAPI.DiscardDeadLetter)
+	adminV1.GET("/screening-reviews", dependency.ScreeningAPI.GetScreeningReviews)
+	adminV1.GET("/screening-reviews/:id", dependency.ScreeningAPI.GetScreeningReview)
+	adminV1.POST("/screening-reviews/:id/resolve", dependency.MiddlewareRequireTicketRef, dependency.ScreeningAPI.ResolveScreeningReview)
+	adminV1.GET("/revoked-tokens", dependency.TokenRevocationAPI.GetRevokedTokens)
+	adminV1.POST("/revoked-tokens", dependency.MiddlewareRequireTicketRef, dependency.TokenRevocationAPI.RevokeTokens)
+	adminV1.DELETE("/revoked-tokens/:key", dependency.MiddlewareRequireTicketRef, dependency.TokenRevocationAPI.DeleteRevokedToken)
+	adminV1.GET("/wallet-provisionings/reconciliation", dependency.WalletReconciliationAPI.GetReport)
+	adminV1.POST("/users/:id/wallet-provisioning/retry", dependency.WalletReconciliationAPI.RetryProvisioning)
+	adminV1.GET("/log-level", dependency.LogLevelAPI.GetLogLevel)
+	adminV1.PUT("/log-level", dependency.LogLevelAPI.SetLogLevel)
+	adminV1.GET("/email-templates", dependency.EmailTemplateAPI.GetTemplates)
+	adminV1.GET("/email-templates/:name/preview", dependency.EmailTemplateAPI.Preview)
+	adminV1.GET("/stats", dependency.StatsAPI.GetStats)
+
+	complianceV1 := r.Group("/admin/v1", adminLimit, dependency.MiddlewareTimeout(timeouts.Admin), dependency.MiddlewareValidateAuth, dependency.MiddlewareRequireRole(constants.RoleCompliance), dependency.MiddlewareTicketRef)
+	complianceV1.GET("/users/:id/legal-holds", dependency.LegalHoldAPI.GetHolds)
+	complianceV1.POST("/users/:id/legal-holds", dependency.MiddlewareRequireTicketRef, dependency.LegalHoldAPI.PlaceHold)
+	complianceV1.POST("/legal-holds/:id/lift", dependency.MiddlewareRequireTicketRef, dependency.LegalHoldAPI.LiftHold)
+	complianceV1.GET("/audit", dependency.AuditAPI.GetAuditEvents)
+
+	// RFC 7662 introspection for registered clients, and the token endpoint
+	// for token exchange and service accounts, authenticated with basic auth
+	oauth := r.Group("/oauth", dependency.MiddlewareConcurrencyLimit("oauth", concurrency.OAuth), bodyLimit, dependency.MiddlewareTimeout(timeouts.OAuth))
+	oauth.POST("/introspect", dependency.MiddlewareClientAuth, dependency.IntrospectionAPI.Introspect)
+	oauth.POST("/token", dependency.MiddlewareTokenClientAuth, dependency.OAuthTokenAPI.Token)
+
+	// service-to-service endpoints, callers must sign requests
+	internalV1 := r.Group("/internal/v1", dependency.MiddlewareConcurrencyLimit("token_validation", concurrency.TokenValidation), bodyLimit, dependency.MiddlewareTimeout(timeouts.TokenValidation), dependency.MiddlewareVerifySignature)
+	internalV1.POST("/token/validate", dependency.TokenValidationAPI.ValidateTokenHTTP)
 }
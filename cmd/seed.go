@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func RunSeed(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	users := fs.Int("users", 100, "number of users to create")
+	sessions := fs.Int("sessions-per-user", 2, "number of sessions per user")
+	histories := fs.Int("login-histories-per-user", 5, "number of login history rows per user")
+	kycPercent := fs.Int("kyc-profiles-percent", 70, "percentage of users with a primary address and a kyc profile")
+	password := fs.String("password", "password123", "password set on every seeded user")
+	seed := fs.Uint64("seed", 0, "faker seed, 0 for random")
+	batch := fs.Int("batch-size", 500, "rows per insert batch")
+	truncate := fs.Bool("truncate", false, "delete all existing users, sessions, login histories and kyc data first")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *batch <= 0 {
+		return fmt.Errorf("--batch-size must be positive, got %d", *batch)
+	}
+
+	ctx := context.Background()
+	log := helpers.Logger
+	db := helpers.DB
+
+	if *truncate {
+		if helpers.GetEnv("APP_ENV", "") == "production" {
+			return fmt.Errorf("refusing to truncate when APP_ENV is production")
+		}
+		for _, table := range []string{"kyc_profiles", "user_addresses", "login_histories", "wallet_provisionings", "user_sessions", "users"} {
+			if err := db.Exec("DELETE FROM " + table).Error; err != nil {
+				return fmt.Errorf("failed to truncate %s: %v", table, err)
+			}
+		}
+		log.Info("existing seed tables truncated")
+	}
+
+	// hash once, bcrypt per row would dominate the run time
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %v", err)
+	}
+
+	faker := gofakeit.New(*seed)
+	suffix := time.Now().Unix() % 100000
+	now := time.Now()
+
+	for start := 0; start < *users; start += *batch {
+		size := min(*batch, *users-start)
+
+		userRows := make([]models.User, 0, size)
+		kycStates := make([]seedKycState, 0, size)
+		for i := range size {
+			// the email and phone lookups are unique, so are the seeded values
+			username := fmt.Sprintf("%.6s%d_%d", strings.ToLower(faker.Username()), suffix, start+i)
+			email := username + "@" + faker.DomainName()
+			phoneNumber := fmt.Sprintf("+628%05d%06d", suffix, start+i)
+			emailLookup, phoneLookup := helpers.PIILookup(email), helpers.PIILookup(phoneNumber)
+
+			state := pickKycState(faker, *kycPercent)
+			kycStates = append(kycStates, state)
+			userRows = append(userRows, models.User{
+				Username:    username,
+				Email:       email,
+				PhoneNumber: phoneNumber,
+				EmailLookup: &emailLookup,
+				PhoneLookup: &phoneLookup,
+				FullName:    faker.Name(),
+				Address:     faker.Address().Address,
+				Dob:         faker.DateRange(now.AddDate(-70, 0, 0), now.AddDate(-17, 0, 0)).Format(time.DateOnly),
+				Password:    string(hashPassword),
+				KycStatus:   state.status,
+			})
+		}
+		if err := db.CreateInBatches(&userRows, *batch).Error; err != nil {
+			return fmt.Errorf("failed to insert users: %v", err)
+		}
+
+		sessionRows := []models.UserSession{}
+		historyRows := []models.LoginHistory{}
+		walletRows := []models.WalletProvisioning{}
+		addressRows := []models.UserAddress{}
+		kycRows := []models.KycProfile{}
+		for i, user := range userRows {
+			for range *sessions {
+				issuedAt := faker.DateRange(now.AddDate(0, 0, -7), now)
+				token, err := helpers.GenerateToken(ctx, user.ID, user.Username, user.FullName, "token", user.Email, issuedAt)
+				if err != nil {
+					return err
+				}
+				refreshToken, err := helpers.GenerateToken(ctx, user.ID, user.Username, user.FullName, "refresh_token", user.Email, issuedAt)
+				if err != nil {
+					return err
+				}
+				sessionRows = append(sessionRows, models.UserSession{
+					UserID:              user.ID,
+					Token:               token,
+					RefreshToken:        refreshToken,
+					TokenExpired:        issuedAt.Add(helpers.MapTypeToken["token"]),
+					RefreshTokenExpired: issuedAt.Add(helpers.MapTypeToken["refresh_token"]),
+				})
+			}
+
+			for range *histories {
+				historyRows = append(historyRows, models.LoginHistory{
+					UserID:    user.ID,
+					Username:  user.Username,
+					IPAddress: faker.IPv4Address(),
+					UserAgent: faker.UserAgent(),
+					Success:   faker.Float32() > 0.1,
+					CreatedAt: faker.DateRange(now.AddDate(0, -6, 0), now),
+				})
+			}
+
+			// mark wallets as created so the provisioning worker leaves seeded users alone
+			walletRows = append(walletRows, models.WalletProvisioning{
+				UserID:        user.ID,
+				Status:        constants.WalletStatusCreated,
+				WalletID:      user.ID,
+				Attempts:      1,
+				NextAttemptAt: now,
+			})
+
+			if state := kycStates[i]; state.profile != "" {
+				address := faker.Address()
+				addressRows = append(addressRows, models.UserAddress{
+					UserID:     user.ID,
+					Type:       "home",
+					Line1:      address.Street,
+					City:       address.City,
+					PostalCode: address.Zip,
+					Country:    seedKycCountries[faker.IntN(len(seedKycCountries))],
+					IsPrimary:  true,
+				})
+				kycRows = append(kycRows, seedKycProfile(faker, user.ID, state.profile))
+			}
+		}
+
+		if len(sessionRows) > 0 {
+			if err := db.CreateInBatches(&sessionRows, *batch).Error; err != nil {
+				return fmt.Errorf("failed to insert sessions: %v", err)
+			}
+		}
+		if len(historyRows) > 0 {
+			if err := db.CreateInBatches(&historyRows, *batch).Error; err != nil {
+				return fmt.Errorf("failed to insert login histories: %v", err)
+			}
+		}
+		if err := db.CreateInBatches(&walletRows, *batch).Error; err != nil {
+			return fmt.Errorf("failed to insert wallet provisionings: %v", err)
+		}
+		if len(kycRows) > 0 {
+			if err := db.CreateInBatches(&addressRows, *batch).Error; err != nil {
+				return fmt.Errorf("failed to insert addresses: %v", err)
+			}
+			if err := db.CreateInBatches(&kycRows, *batch).Error; err != nil {
+				return fmt.Errorf("failed to insert kyc profiles: %v", err)
+			}
+		}
+
+		log.Infof("seeded %d/%d users", start+size, *users)
+	}
+
+	return nil
+}
+
+// seedKycCountries are the primary address countries of seeded users, ID
+// more often as it is the main market. Whether one is a jurisdiction of its
+// own depends on KYC_REQUIRED_FIELDS.
+var seedKycCountries = []string{"ID", "ID", "ID", "SG", "MY", "US"}
+
+// seedKycState is a user's KYC status and the kind of profile they filled
+// in: "complete" has every field any jurisdiction may require, "partial" only
+// the occupation and source of funds the default rules ask for, so it shows
+// fields missing under stricter jurisdictions. Users without a profile are
+// unverified.
+type seedKycState struct {
+	status  string
+	profile string
+}
+
+func pickKycState(faker *gofakeit.Faker, percent int) seedKycState {
+	if faker.IntN(100) >= percent {
+		return seedKycState{status: constants.KycStatusUnverified}
+	}
+	switch roll := faker.IntN(10); {
+	case roll < 3:
+		return seedKycState{status: constants.KycStatusPending, profile: "partial"}
+	case roll < 5:
+		return seedKycState{status: constants.KycStatusPending, profile: "complete"}
+	case roll < 9:
+		return seedKycState{status: constants.KycStatusVerified, profile: "complete"}
+	default:
+		return seedKycState{status: constants.KycStatusRejected, profile: "complete"}
+	}
+}
+
+func seedKycProfile(faker *gofakeit.Faker, userID int, kind string) models.KycProfile {
+	profile := models.KycProfile{
+		UserID:           userID,
+		EmploymentStatus: constants.EmploymentStatusEmployed,
+		Occupation:       faker.JobTitle(),
+		SourceOfFunds:    "salary",
+	}
+	if kind == "partial" {
+		return profile
+	}
+
+	profile.EmployerName = faker.Company()
+	profile.NextOfKin = models.NextOfKin{
+		FullName:     faker.Name(),
+		Relationship: faker.RandomString([]string{"spouse", "parent", "sibling"}),
+		PhoneNumber:  fmt.Sprintf("+62812%08d", faker.IntN(100000000)),
+	}
+	return profile
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRunSeed(t *testing.T) {
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+	helpers.Env = map[string]string{
+		"DB_DRIVER":      helpers.DBDriverSQLite,
+		"DB_SQLITE_PATH": helpers.SQLiteInMemory,
+		"APP_SECRET":     "app-secret",
+	}
+	t.Cleanup(func() { helpers.Env = map[string]string{} })
+	helpers.SetupDatabase()
+
+	if err := RunSeed([]string{"--batch-size=0"}); err == nil {
+		t.Error("expected a batch size of 0 to be refused")
+	}
+	args := []string{"--users=60", "--sessions-per-user=1", "--login-histories-per-user=1", "--batch-size=25", "--seed=7"}
+	if err := RunSeed(args); err != nil {
+		t.Fatal(err)
+	}
+	// a second run must not collide on the unique lookups
+	if err := RunSeed(append(args, "--truncate")); err != nil {
+		t.Fatal(err)
+	}
+
+	users := []models.User{}
+	if err := helpers.DB.Find(&users).Error; err != nil || len(users) != 60 {
+		t.Fatalf("got %d users, err %v, want 60", len(users), err)
+	}
+	profiles := []models.KycProfile{}
+	if err := helpers.DB.Find(&profiles).Error; err != nil {
+		t.Fatal(err)
+	}
+	withProfile := map[int]models.KycProfile{}
+	for _, profile := range profiles {
+		withProfile[profile.UserID] = profile
+	}
+
+	statuses := map[string]int{}
+	partial := 0
+	for _, user := range users {
+		if user.EmailLookup == nil || *user.EmailLookup != helpers.PIILookup(user.Email) || user.PhoneLookup == nil {
+			t.Errorf("user %d has no lookups", user.ID)
+		}
+		statuses[user.KycStatus]++
+
+		profile, ok := withProfile[user.ID]
+		if ok == (user.KycStatus == constants.KycStatusUnverified) {
+			t.Errorf("user %d is %s, has a profile %v", user.ID, user.KycStatus, ok)
+		}
+		if ok && profile.NextOfKin.FullName == "" {
+			partial++
+		}
+	}
+	for _, status := range []string{constants.KycStatusUnverified, constants.KycStatusPending, constants.KycStatusVerified} {
+		if statuses[status] == 0 {
+			t.Errorf("got statuses %v, want some %s", statuses, status)
+		}
+	}
+	if partial == 0 {
+		t.Error("expected some partial kyc profiles")
+	}
+
+	var addresses int64
+	if err := helpers.DB.Model(&models.UserAddress{}).Where("is_primary = ?", true).Count(&addresses).Error; err != nil || int(addresses) != len(profiles) {
+		t.Errorf("got %d primary addresses, err %v, want one per kyc profile (%d)", addresses, err, len(profiles))
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+// SelfTest checks the dependencies and config the servers need and exits
+// with every problem found, instead of serving traffic that would only 500.
+// The wallet service is optional unless STARTUP_REQUIRE_WALLET is set, its
+// outage only delays wallet provisioning.
+func SelfTest() {
+	problems := []string{}
+
+	healthcheck := &services.Healthcheck{
+		HealthcheckRepository: &repository.HealthcheckRepository{DB: helpers.DB, Redis: helpers.Redis},
+		ExternalWallet:        &external.ExtWallet{HTTPClient: helpers.NewHTTPClient()},
+		Timeout:               time.Duration(helpers.GetEnvInt("HEALTHCHECK_TIMEOUT_MS", 1000)) * time.Millisecond,
+	}
+	databaseUp := false
+	report := healthcheck.Check(context.Background())
+	for _, dependency := range report.Dependencies {
+		if dependency.Status == constants.HealthStatusUp {
+			databaseUp = databaseUp || dependency.Name == constants.DependencyDatabase
+			continue
+		}
+		if dependency.Name == constants.DependencyWallet && !helpers.GetEnvBool("STARTUP_REQUIRE_WALLET", false) {
+			helpers.Logger.Warn("wallet service unreachable at startup: ", dependency.Error)
+			continue
+		}
+		problems = append(problems, dependency.Name+" unreachable: "+dependency.Error)
+	}
+
+	// the schema can only be inspected with a working database
+	if databaseUp {
+		pending, err := helpers.PendingMigrations()
+		if err != nil {
+			problems = append(problems, "failed to check migrations: "+err.Error())
+		} else if len(pending) > 0 {
+			problems = append(problems, "pending migrations: "+strings.Join(pending, ", "))
+		}
+
+		// during a rolling deploy old and new pods share the schema, each
+		// only boots against one it is compatible with
+		incompatible, err := helpers.SchemaProblems(helpers.DB)
+		if err != nil {
+			problems = append(problems, "failed to check schema version: "+err.Error())
+		} else if len(incompatible) > 0 {
+			problems = append(problems, "incompatible schema: "+strings.Join(incompatible, ", "))
+		}
+	}
+
+	if err := helpers.ValidateJWTKeys(); err != nil {
+		problems = append(problems, "invalid jwt keys: "+err.Error())
+	}
+	if err := helpers.ValidatePIIKeys(); err != nil {
+		problems = append(problems, "invalid pii keys: "+err.Error())
+	}
+
+	if len(problems) > 0 {
+		log.Fatal("startup self-test failed: ", strings.Join(problems, "; "))
+	}
+	helpers.Logger.Info("startup self-test passed")
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+)
+
+// StartSIEM streams token issuance, validation failures and revocations to
+// SIEM_URL, when set, for every server of the process. Must run before any
+// token is issued.
+func StartSIEM() {
+	url := helpers.GetEnv("SIEM_URL", "")
+	if url == "" {
+		return
+	}
+
+	sink, err := external.NewSIEMSink(
+		helpers.NewHTTPClient(),
+		url,
+		helpers.GetEnv("SIEM_API_KEY", ""),
+		helpers.GetEnv("SIEM_FORMAT", external.SIEMFormatJSON),
+		helpers.GetEnvInt("SIEM_QUEUE_SIZE", 10000),
+		helpers.GetEnvInt("SIEM_BATCH_SIZE", 100),
+		time.Duration(helpers.GetEnvInt("SIEM_FLUSH_INTERVAL_MS", 1000))*time.Millisecond,
+		helpers.GetEnvInt("SIEM_MAX_RETRIES", 3),
+	)
+	if err != nil {
+		log.Fatal("failed to load siem sink: ", err)
+	}
+
+	helpers.SetSecurityEventSink(sink)
+	go sink.Run(context.Background())
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+// RunToken revokes tokens straight in the database and the revocation list,
+// for incident response when the admin API is down, e.g.
+// `ewallet-ums token revoke --user 42 --reason "stolen laptop"` or
+// `ewallet-ums token revoke --token <jti> --reason "leaked in logs"`.
+func RunToken(args []string) error {
+	if len(args) == 0 || args[0] != "revoke" {
+		return fmt.Errorf("usage: token revoke (--user <id> | --token <jti>) --reason <reason>")
+	}
+
+	fs := flag.NewFlagSet("token revoke", flag.ContinueOnError)
+	userID := fs.Int("user", 0, "revoke every session and refresh token of this user id")
+	tokenID := fs.String("token", "", "revoke the session and refresh token family of this access or refresh token jti")
+	reason := fs.String("reason", "", "why the tokens are revoked, kept in the audit log")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if (*userID == 0) == (*tokenID == "") {
+		return fmt.Errorf("exactly one of --user or --token is required")
+	}
+	if *reason == "" {
+		return fmt.Errorf("--reason is required")
+	}
+
+	helpers.SetupRedis()
+
+	svc := &services.TokenRevocationService{
+		SessionRepo:      &repository.SessionRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		AuditRepo:        &repository.AuditRepository{DB: helpers.DB},
+		// nothing is cached locally by a one-off command
+		TokenCache:     repository.NewTokenCacheRepository(helpers.Redis, 1, time.Second),
+		TrustedDevices: &repository.TrustedDeviceRepository{DB: helpers.DB},
+	}
+
+	ctx := context.Background()
+	if *userID != 0 {
+		revoked, err := svc.RevokeUserTokens(ctx, constants.AuditActorSystem, *userID, *reason)
+		if err != nil {
+			return err
+		}
+		helpers.Logger.Infof("user %d: %d sessions revoked", *userID, revoked)
+		return nil
+	}
+
+	revoked, err := svc.RevokeTokenID(ctx, constants.AuditActorSystem, *tokenID, *reason)
+	if err != nil {
+		return err
+	}
+	helpers.Logger.Infof("token %s: %d sessions revoked", *tokenID, revoked)
+	return nil
+}
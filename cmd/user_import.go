@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+)
+
+// RunUserImport imports users from a legacy system export, e.g.
+// `ewallet-ums user-import --file users.csv --dry-run`. Re-running a file
+// skips the users an earlier run already created.
+func RunUserImport(args []string) error {
+	fs := flag.NewFlagSet("user-import", flag.ContinueOnError)
+	file := fs.String("file", "", "csv or ndjson file to import")
+	format := fs.String("format", "", "csv or ndjson, defaults from the file extension")
+	dryRun := fs.Bool("dry-run", false, "validate every row without creating users")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("--file is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+	if *format != constants.UserImportFormatCSV && *format != constants.UserImportFormatNDJSON {
+		return fmt.Errorf("unknown import format %q", *format)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %v", err)
+	}
+	defer f.Close()
+
+	ctx := context.Background()
+	svc := newUserImportService(helpers.NewPasswordHasher(helpers.GetEnvInt("BCRYPT_MAX_CONCURRENCY", 0)))
+
+	job, err := svc.CreateImport(ctx, constants.AuditActorSystem, *format, *dryRun)
+	if err != nil {
+		return err
+	}
+	if err := svc.RunImport(ctx, &job, f); err != nil {
+		return err
+	}
+
+	for _, rowErr := range job.Errors {
+		helpers.Logger.Warnf("row %d (external_id %q): %s", rowErr.Row, rowErr.ExternalID, rowErr.Error)
+	}
+	if job.FailedRows > 0 {
+		return fmt.Errorf("%d of %d rows failed", job.FailedRows, job.ProcessedRows)
+	}
+	return nil
+}
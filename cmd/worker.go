@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/locker"
+)
+
+func ServeWorker() {
+	dependency := dependencyInject()
+	ctx := context.Background()
+
+	if interval := helpers.GetEnvInt("CONFIG_RELOAD_INTERVAL_SECONDS", 10); interval > 0 {
+		go helpers.WatchConfig(ctx, time.Duration(interval)*time.Second)
+	}
+
+	// the inbox consumers of all replicas share the events between them
+	go dependency.Inbox.Run(ctx)
+
+	// every replica runs the worker, each job runs on whichever holds its lock
+	runSingleton := func(job string, run func(ctx context.Context)) {
+		locker.RunSingleton(ctx, dependency.Locker, job, dependency.JobLockTTL, run)
+	}
+
+	go runSingleton(constants.JobSessionCleanup, dependency.SessionCleanup.Run)
+
+	go runSingleton(constants.JobRetention, dependency.Retention.Run)
+
+	go runSingleton(constants.JobAnonymization, dependency.Anonymization.Run)
+
+	go runSingleton(constants.JobAccountDeletion, dependency.AccountDeletion.Run)
+
+	go runSingleton(constants.JobUserExport, dependency.UserExport.Run)
+
+	go runSingleton(constants.JobActivityDigest, dependency.ActivityDigest.Run)
+
+	go runSingleton(constants.JobNotificationSummary, dependency.NotificationSummary.Run)
+
+	go runSingleton(constants.JobWalletReconciliation, dependency.WalletReconciliation.Run)
+
+	go runSingleton(constants.JobStats, dependency.Stats.Run)
+
+	go runSingleton(constants.JobScreening, dependency.Screening.Run)
+
+	go runSingleton(constants.JobUserPurge, dependency.UserPurge.Run)
+
+	runSingleton(constants.JobWalletProvisioning, dependency.WalletProvisioning.Run)
+}
@@ -0,0 +1,9 @@
+package constants
+
+// Account note visibilities. Support notes are readable by every admin,
+// compliance notes only by admins who also hold the compliance role. Must
+// match the oneof of models.AccountNoteRequest.
+const (
+	AccountNoteVisibilitySupport    = "support"
+	AccountNoteVisibilityCompliance = "compliance"
+)
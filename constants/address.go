@@ -0,0 +1,9 @@
+package constants
+
+// Address types, a user may have several of each. Must match the oneof of
+// models.AddressRequest.
+const (
+	AddressTypeHome    = "home"
+	AddressTypeMailing = "mailing"
+	AddressTypeBilling = "billing"
+)
@@ -0,0 +1,111 @@
+package constants
+
+const (
+	RoleAdmin      = "admin"
+	RoleCompliance = "compliance"
+	// RolePIIReader lets an admin's user exports include PII columns.
+	RolePIIReader = "pii_reader"
+)
+
+const (
+	UserStatusActive = "active"
+	UserStatusBanned = "banned"
+	// UserStatusPendingDeletion users asked for their account to be deleted
+	// and can still cancel by logging in.
+	UserStatusPendingDeletion = "pending_deletion"
+)
+
+const (
+	KycStatusUnverified = "unverified"
+	KycStatusPending    = "pending"
+	KycStatusVerified   = "verified"
+	KycStatusRejected   = "rejected"
+)
+
+// Service accounts are users of type service, see models.ServiceAccount.
+// Guests have no credentials until they upgrade to a human account.
+const (
+	UserTypeHuman   = "human"
+	UserTypeService = "service"
+	UserTypeGuest   = "guest"
+)
+
+// ClientPlatform is the platform a user registered from.
+const (
+	ClientPlatformIOS     = "ios"
+	ClientPlatformAndroid = "android"
+	ClientPlatformWeb     = "web"
+)
+
+// HeaderDeviceID must match the device_id claim of device-bound tokens.
+const HeaderDeviceID = "X-Device-ID"
+
+// HeaderRequestNonce and HeaderRequestTimestamp (unix seconds) make a request
+// to a sensitive endpoint single-use, and HeaderRequestSignature binds them
+// to the request, see MiddlewareReplayProtection.
+const (
+	HeaderRequestNonce     = "X-Request-Nonce"
+	HeaderRequestTimestamp = "X-Request-Timestamp"
+	HeaderRequestSignature = "X-Request-Signature"
+)
+
+// HeaderTicketRef names the external ticket an admin action is taken for,
+// gRPC callers send it as x-ticket-reference metadata.
+const HeaderTicketRef = "X-Ticket-Reference"
+
+// Sensitive admin actions only take effect once a second admin approves them.
+const (
+	AdminActionBanUser        = "ban_user"
+	AdminActionGrantRole      = "grant_role"
+	AdminActionRecoverAccount = "recover_account"
+	// AdminActionPurgeUser hard deletes the user, a compliance officer must
+	// approve it.
+	AdminActionPurgeUser = "purge_user"
+)
+
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+	ApprovalStatusFailed   = "failed"
+)
+
+const (
+	UserImportFormatCSV    = "csv"
+	UserImportFormatNDJSON = "ndjson"
+)
+
+const (
+	UserImportStatusRunning   = "running"
+	UserImportStatusCompleted = "completed"
+	UserImportStatusFailed    = "failed"
+)
+
+const (
+	UserExportFormatCSV     = "csv"
+	UserExportFormatParquet = "parquet"
+)
+
+const (
+	UserExportStatusPending   = "pending"
+	UserExportStatusRunning   = "running"
+	UserExportStatusCompleted = "completed"
+	UserExportStatusFailed    = "failed"
+)
+
+// A recovery ticket is pending until support rejects it or an approved
+// recover_account action sends the reset link, and completed once the link
+// is used.
+const (
+	RecoveryTicketStatusPending   = "pending"
+	RecoveryTicketStatusApproved  = "approved"
+	RecoveryTicketStatusRejected  = "rejected"
+	RecoveryTicketStatusCompleted = "completed"
+)
+
+// An approved purge_user action queues a purge, which stays pending until
+// the user_purge job has deleted the user.
+const (
+	UserPurgeStatusPending   = "pending"
+	UserPurgeStatusCompleted = "completed"
+)
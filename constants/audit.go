@@ -0,0 +1,80 @@
+package constants
+
+const (
+	AuditActorSystem = "system"
+
+	AuditActionRetentionPurge    = "retention.purge"
+	AuditActionApprovalRequested = "approval.requested"
+	AuditActionApprovalApproved  = "approval.approved"
+	AuditActionApprovalRejected  = "approval.rejected"
+	AuditActionApprovalFailed    = "approval.failed"
+	AuditActionLegalHoldPlaced   = "legal_hold.placed"
+	AuditActionLegalHoldLifted   = "legal_hold.lifted"
+	AuditActionUserAnonymized    = "user.anonymized"
+	AuditActionTokenExchanged    = "token.exchanged"
+	AuditActionRefreshTokenReuse = "token.refresh_reused"
+	AuditActionTokenRevoked      = "token.revoked"
+	AuditActionTokenUnrevoked    = "token.unrevoked"
+
+	AuditActionServiceAccountCreated       = "service_account.created"
+	AuditActionServiceAccountUpdated       = "service_account.updated"
+	AuditActionServiceAccountDeleted       = "service_account.deleted"
+	AuditActionServiceAccountSecretRotated = "service_account.secret_rotated"
+
+	AuditActionUserSuspended    = "user.suspended"
+	AuditActionUserRoleAssigned = "user.role_assigned"
+	AuditActionUserForceLogout  = "user.force_logout"
+	AuditActionUserImported     = "user.imported"
+	AuditActionGuestUpgraded    = "user.guest_upgraded"
+	AuditActionUserRecovered    = "user.recovered"
+	AuditActionPasswordSet      = "user.password_set"
+
+	AuditActionEmailChangeReverted = "user.email_change_reverted"
+
+	AuditActionEntitlementGranted = "entitlement.granted"
+	AuditActionEntitlementRevoked = "entitlement.revoked"
+
+	AuditActionUsernameReserved = "reserved_username.created"
+	AuditActionUsernameReleased = "reserved_username.deleted"
+	AuditActionUsernameClaimed  = "reserved_username.claimed"
+
+	AuditActionUserDeletionRequested = "user.deletion_requested"
+	AuditActionUserDeletionCancelled = "user.deletion_cancelled"
+	AuditActionUserDeleted           = "user.deleted"
+	AuditActionUserPurged            = "user.purged"
+
+	AuditActionSecurityQuestionsSet = "security_questions.set"
+
+	AuditActionAccountNoteAdded   = "account_note.added"
+	AuditActionAccountNoteUpdated = "account_note.updated"
+	AuditActionAccountNoteDeleted = "account_note.deleted"
+
+	AuditActionAddressAdded   = "address.added"
+	AuditActionAddressUpdated = "address.updated"
+	AuditActionAddressRemoved = "address.removed"
+	AuditActionAddressPrimary = "address.primary_set"
+
+	AuditActionKycProfileUpdated = "kyc.profile_updated"
+
+	AuditActionScreeningReviewResolved = "screening_review.resolved"
+
+	AuditActionRecoveryRequested   = "recovery.requested"
+	AuditActionRecoveryRejected    = "recovery.rejected"
+	AuditActionRecoveryResetIssued = "recovery.reset_issued"
+
+	AuditActionUserExportRequested = "user_export.requested"
+	AuditActionUserExportCompleted = "user_export.completed"
+
+	AuditActionWalletProvisioningRetried = "wallet_provisioning.retried"
+	AuditActionWalletClosed              = "wallet.closed"
+
+	AuditActionLogLevelChanged = "log_level.changed"
+
+	AuditActionDeadLetterUpdated   = "dead_letter.updated"
+	AuditActionDeadLetterReplayed  = "dead_letter.replayed"
+	AuditActionDeadLetterDiscarded = "dead_letter.discarded"
+
+	AuditActionAuditExported = "audit.exported"
+
+	AuditActionBackupExported = "backup.exported"
+)
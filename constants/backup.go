@@ -0,0 +1,10 @@
+package constants
+
+// Tables `backup export` can dump, by the name given on the command line.
+const (
+	BackupTableUsers = "users"
+	BackupTableAudit = "audit"
+)
+
+// BackupObjectSuffix ends the object key of every backup archive.
+const BackupObjectSuffix = ".umsbak"
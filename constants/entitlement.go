@@ -0,0 +1,18 @@
+package constants
+
+// Features are the product features gated by entitlements. Adding one here
+// is all it takes to grant it.
+const (
+	FeatureInternationalTransfer = "international_transfer"
+	FeatureCrypto                = "crypto"
+)
+
+// An entitlement is granted by an admin or follows from the user's KYC
+// status, see ENTITLEMENTS_BY_KYC_STATUS.
+const (
+	EntitlementSourceGrant = "grant"
+	EntitlementSourceKyc   = "kyc"
+)
+
+// ClaimEntitlements is the "ext" claim listing a token's entitlements.
+const ClaimEntitlements = "entitlements"
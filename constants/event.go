@@ -0,0 +1,24 @@
+package constants
+
+const (
+	EventUserClaimsChanged = "user.claims_changed"
+	EventUserAnonymized    = "user.anonymized"
+	EventUserDeleted       = "user.deleted"
+	EventUserRegistered    = "user.registered"
+)
+
+// Events consumed from other services.
+const (
+	EventWalletClosed = "wallet.closed"
+	EventFraudFlagged = "fraud.flagged"
+)
+
+// Dead letters are the events that failed for good, kept for replay.
+const (
+	DeadLetterDirectionPublish = "publish"
+	DeadLetterDirectionConsume = "consume"
+
+	DeadLetterStatusPending   = "pending"
+	DeadLetterStatusReplayed  = "replayed"
+	DeadLetterStatusDiscarded = "discarded"
+)
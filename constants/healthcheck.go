@@ -0,0 +1,11 @@
+package constants
+
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+
+	DependencyDatabase = "database"
+	DependencyRedis    = "redis"
+	DependencyWallet   = "wallet"
+)
@@ -0,0 +1,23 @@
+package constants
+
+// Worker jobs, each runs on one instance at a time under a lock of its name.
+const (
+	JobSessionCleanup       = "session_cleanup"
+	JobRetention            = "retention"
+	JobAnonymization        = "anonymization"
+	JobUserExport           = "user_export"
+	JobActivityDigest       = "activity_digest"
+	JobWalletReconciliation = "wallet_reconciliation"
+	JobWalletProvisioning   = "wallet_provisioning"
+	JobStats                = "stats"
+	JobAccountDeletion      = "account_deletion"
+	JobNotificationSummary  = "notification_summary"
+	JobScreening            = "screening"
+	JobUserPurge            = "user_purge"
+)
+
+// Backends selectable with JOB_LOCK_BACKEND.
+const (
+	JobLockBackendRedis = "redis"
+	JobLockBackendMySQL = "mysql"
+)
@@ -0,0 +1,20 @@
+package constants
+
+// Extended KYC fields a jurisdiction may require, see KYC_REQUIRED_FIELDS.
+const (
+	KycFieldOccupation    = "occupation"
+	KycFieldEmployer      = "employer"
+	KycFieldSourceOfFunds = "source_of_funds"
+	KycFieldNextOfKin     = "next_of_kin"
+)
+
+// KycJurisdictionDefault is the rule for users whose primary address is in a
+// country without one of its own, or who have no address.
+const KycJurisdictionDefault = "default"
+
+// Employment statuses with an employer, who are asked for its name where
+// employer is required.
+const (
+	EmploymentStatusEmployed     = "employed"
+	EmploymentStatusSelfEmployed = "self_employed"
+)
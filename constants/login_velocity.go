@@ -0,0 +1,9 @@
+package constants
+
+// Login failures are counted per source, a source being one of these kinds
+// plus its value, e.g. "ip:203.0.113.7".
+const (
+	LoginSourceIP      = "ip"
+	LoginSourceASN     = "asn"
+	LoginSourceCountry = "country"
+)
@@ -4,4 +4,19 @@ const (
 	SuccessMessage      = "Success"
 	ErrFailedBadRequest = "Request Data Not Valid"
 	ErrServerError      = "Something Went Wrong In The Server"
+	ErrForbidden        = "Forbidden"
+	ErrNotFound         = "Not Found"
+	ErrConflict         = "Conflict"
+	ErrTooManyRequests  = "Too Many Requests"
+	ErrGatewayTimeout   = "Request Timed Out"
+	ErrUpstream         = "Upstream Service Failed"
+	ErrOverloaded       = "Service Busy, Try Again Later"
+	ErrUnauthorized     = "unauthorized"
+
+	ErrPasswordChangeRequired = "Password Change Required"
+	ErrAccountPendingDeletion = "Account Pending Deletion"
+	ErrTicketRefRequired      = "Ticket Reference Required"
+	ErrRequestNonceRequired   = "Request Nonce Required"
+	ErrRequestReplayed        = "Request Replayed"
+	ErrRequestSignature       = "Request Signature Invalid"
 )
@@ -0,0 +1,10 @@
+package constants
+
+// Phases of a schema migration. Expand migrations only add to the schema,
+// so binaries from before them keep working, and run at startup. Contract
+// migrations remove what older binaries still use, and run with
+// `migrate contract` once no older binary is left.
+const (
+	MigrationPhaseExpand   = "expand"
+	MigrationPhaseContract = "contract"
+)
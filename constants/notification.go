@@ -0,0 +1,33 @@
+package constants
+
+// Security events users are emailed about. Each can be turned off in the
+// user's notification preferences, they are on by default.
+const (
+	NotificationEventPasswordChanged  = "password_changed"
+	NotificationEventNewDeviceLogin   = "new_device_login"
+	NotificationEventNewCountryLogin  = "new_country_login"
+	NotificationEventTwoFactorChanged = "two_factor_changed"
+	NotificationEventEmailChanged     = "email_changed"
+)
+
+// NotificationEventActivityDigest is the monthly account activity email,
+// users have to opt in to it.
+const NotificationEventActivityDigest = "activity_digest"
+
+// Email templates that aren't notification events, they are sent whatever
+// the user's preferences.
+const (
+	EmailTemplateVerification  = "verification"
+	EmailTemplatePasswordReset = "password_reset"
+)
+
+// EmailTemplateSecuritySummary collects the security emails held back by
+// quiet hours or the throttle, it only reports events the user still wants.
+const EmailTemplateSecuritySummary = "security_summary"
+
+// Locales emails are translated to. Every template exists in LocaleEnglish,
+// which is used when a translation is missing.
+const (
+	LocaleEnglish    = "en"
+	LocaleIndonesian = "id"
+)
@@ -0,0 +1,23 @@
+package constants
+
+// Token type hints and token_type values used by the OAuth endpoints.
+const (
+	TokenTypeAccess  = "access_token"
+	TokenTypeRefresh = "refresh_token"
+)
+
+// Grant types and RFC 8693 token type identifiers.
+const (
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+	TokenTypeURIAccess         = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// Error codes from RFC 6749 section 5.2 and RFC 8693 section 2.2.2.
+const (
+	OAuthErrInvalidRequest       = "invalid_request"
+	OAuthErrInvalidClient        = "invalid_client"
+	OAuthErrInvalidTarget        = "invalid_target"
+	OAuthErrUnsupportedGrantType = "unsupported_grant_type"
+	OAuthErrServerError          = "server_error"
+)
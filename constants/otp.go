@@ -0,0 +1,13 @@
+package constants
+
+// One-time codes are texted to a phone number for one purpose and only
+// verify for it.
+const (
+	OTPPurposeRegister = "register"
+	OTPPurposeLogin    = "login"
+	OTPPurposeRecovery = "recovery"
+)
+
+// TrustedDeviceCookie remembers a browser that logged in with a code, so the
+// next logins from it skip the code.
+const TrustedDeviceCookie = "trusted_device"
@@ -0,0 +1,8 @@
+package constants
+
+// Quotas cap how often each user calls an expensive endpoint per UTC day,
+// see MiddlewareUserQuota.
+const (
+	QuotaUserExports = "user_exports"
+	QuotaUserImports = "user_imports"
+)
@@ -0,0 +1,27 @@
+package constants
+
+const (
+	TokenRevocationChannel = "token_revocation"
+	TokenCacheKeyPrefix    = "token_validation:"
+	RevokedTokenKeyPrefix  = "revoked_token:"
+	CallerRateKeyPrefix    = "caller_rate:"
+	CallerFailureKeyPrefix = "caller_failures:"
+	CallerBanKeyPrefix     = "caller_ban:"
+
+	LoginFailuresKeyPrefix      = "login_failures:"
+	LoginFailureSourcesKey      = "login_failure_sources"
+	LoginVelocityAlertKeyPrefix = "login_velocity_alert:"
+
+	NotificationThrottleKeyPrefix = "notification_throttle:"
+
+	ActiveUsersKeyPrefix = "active_users:"
+
+	UserQuotaKeyPrefix = "user_quota:"
+
+	OTPKeyPrefix       = "otp:"
+	OTPResendKeyPrefix = "otp_resend:"
+
+	JobLockKeyPrefix = "job_lock:"
+
+	ReplayNonceKeyPrefix = "replay_nonce:"
+)
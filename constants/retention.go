@@ -0,0 +1,6 @@
+package constants
+
+const (
+	RetentionRuleLoginHistory = "login_history"
+	RetentionRuleUserSession  = "user_session"
+)
@@ -0,0 +1,9 @@
+package constants
+
+// A screening review is pending until compliance clears the hit as a false
+// positive or confirms it.
+const (
+	ScreeningReviewStatusPending   = "pending"
+	ScreeningReviewStatusCleared   = "cleared"
+	ScreeningReviewStatusConfirmed = "confirmed"
+)
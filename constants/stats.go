@@ -0,0 +1,16 @@
+package constants
+
+const (
+	StatsMetricRegistrations = "registrations"
+	StatsMetricFailedLogins  = "failed_logins"
+)
+
+// registrations_total channels besides the app platforms.
+const (
+	RegistrationChannelUnknown      = "unknown"
+	RegistrationChannelGuestUpgrade = "guest_upgrade"
+	RegistrationChannelImport       = "import"
+)
+
+// StatsDayFormat is the format of models.DailyStat days.
+const StatsDayFormat = "2006-01-02"
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	WalletStatusProvisioning = "provisioning"
+	WalletStatusCreated      = "created"
+	WalletStatusFailed       = "failed"
+	WalletStatusClosed       = "closed"
+)
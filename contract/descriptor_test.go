@@ -0,0 +1,77 @@
+package contract
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"ewallet-ums/cmd/proto/tokenvalidation"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// describe flattens a proto file into one line per service, RPC, message and
+// field, keyed on everything a consumer's generated code relies on.
+func describe(fd protoreflect.FileDescriptor) []string {
+	var lines []string
+
+	services := fd.Services()
+	for i := 0; i < services.Len(); i++ {
+		svc := services.Get(i)
+		lines = append(lines, fmt.Sprintf("service %s", svc.FullName()))
+		methods := svc.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			m := methods.Get(j)
+			lines = append(lines, fmt.Sprintf("rpc %s.%s(%s) returns (%s) client_streaming=%t server_streaming=%t",
+				svc.FullName(), m.Name(), m.Input().FullName(), m.Output().FullName(), m.IsStreamingClient(), m.IsStreamingServer()))
+		}
+	}
+
+	messages := fd.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		msg := messages.Get(i)
+		lines = append(lines, fmt.Sprintf("message %s", msg.FullName()))
+		fields := msg.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			f := fields.Get(j)
+			kind := f.Kind().String()
+			if f.Message() != nil {
+				kind = string(f.Message().FullName())
+			}
+			lines = append(lines, fmt.Sprintf("field %s %d %s %s json=%s",
+				msg.FullName(), f.Number(), f.Name(), kind, f.JSONName()))
+		}
+	}
+
+	return lines
+}
+
+// TestDescriptorContract fails when anything recorded in the snapshot has
+// disappeared or changed. New messages, fields and RPCs are additive and
+// only need the snapshot refreshed.
+func TestDescriptorContract(t *testing.T) {
+	checkDescriptor(t, tokenvalidation.File_token_validation_proto, "token_validation.descriptor.golden")
+}
+
+func checkDescriptor(t *testing.T, fd protoreflect.FileDescriptor, goldenName string) {
+	t.Helper()
+
+	current := describe(fd)
+	want := golden(t, goldenName, []byte(strings.Join(current, "\n")+"\n"))
+
+	have := make(map[string]bool, len(current))
+	for _, line := range current {
+		have[line] = true
+	}
+
+	recorded := strings.Split(strings.TrimSpace(string(want)), "\n")
+	for _, line := range recorded {
+		if !have[line] {
+			t.Errorf("breaking change, consumers rely on: %s", line)
+		}
+	}
+
+	if len(current) != len(recorded) && !t.Failed() {
+		t.Errorf("proto has additive changes, refresh the snapshot with -update")
+	}
+}
@@ -0,0 +1,110 @@
+package contract
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/constants"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEventsDescriptorContract(t *testing.T) {
+	checkDescriptor(t, events.File_user_events_proto, "user_events.descriptor.golden")
+}
+
+var occurredAt = timestamppb.New(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC))
+
+// eventSamples are published in the current schema of each topic.
+var eventSamples = map[string]proto.Message{
+	constants.EventUserClaimsChanged: &events.UserClaimsChanged{UserId: 42, ChangedFields: []string{"email"}, OccurredAt: occurredAt},
+	constants.EventUserAnonymized:    &events.UserAnonymized{UserId: 42, OccurredAt: occurredAt},
+	constants.EventUserDeleted:       &events.UserDeleted{UserId: 42, RequestedAt: timestamppb.New(occurredAt.AsTime().AddDate(0, 0, -14)), OccurredAt: occurredAt},
+	constants.EventUserRegistered: &events.UserRegistered{
+		UserId:      42,
+		UtmSource:   "newsletter",
+		UtmMedium:   "email",
+		UtmCampaign: "spring-promo",
+		Platform:    constants.ClientPlatformIOS,
+		OccurredAt:  occurredAt,
+	},
+}
+
+// TestEventPayloads pins the current payload of every topic in
+// testdata/events/<topic>.v<version>.json. Payloads of earlier versions, and
+// the unversioned ones published before the registry, are kept next to it
+// and must still decode to the sample.
+func TestEventPayloads(t *testing.T) {
+	for topic, schema := range events.Schemas {
+		t.Run(topic, func(t *testing.T) {
+			if len(schema.Upcasters) != int(schema.Version)-1 {
+				t.Fatalf("version %d needs %d upcasters, has %d", schema.Version, schema.Version-1, len(schema.Upcasters))
+			}
+			sample, ok := eventSamples[topic]
+			if !ok {
+				t.Fatal("no sample event, add one to eventSamples")
+			}
+			want := proto.Clone(sample)
+			want.ProtoReflect().Set(want.ProtoReflect().Descriptor().Fields().ByName("schema_version"), protoreflect.ValueOfInt32(schema.Version))
+
+			got, err := events.Marshal(topic, sample)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden(t, filepath.Join("events", topic+".v"+strconv.Itoa(int(schema.Version))+".json"), append(got, '\n'))
+
+			paths, err := filepath.Glob(filepath.Join("testdata", "events", topic+".*.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, path := range paths {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				decoded, err := events.Unmarshal(topic, data)
+				if err != nil {
+					t.Errorf("%s no longer decodes: %v", filepath.Base(path), err)
+					continue
+				}
+				if !proto.Equal(decoded, want) {
+					t.Errorf("%s decodes to %v, want %v", filepath.Base(path), decoded, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEventUpcasting(t *testing.T) {
+	// a version 2 that renamed id to user_id
+	const topic = "test.upcasting"
+	events.Schemas[topic] = events.Schema{
+		Version: 2,
+		New:     func() proto.Message { return &events.UserAnonymized{} },
+		Upcasters: []events.Upcaster{func(payload map[string]any) error {
+			payload["user_id"] = payload["id"]
+			delete(payload, "id")
+			return nil
+		}},
+	}
+	defer delete(events.Schemas, topic)
+
+	got, err := events.Unmarshal(topic, []byte(`{"schema_version":1,"id":42,"occurred_at":"2026-01-02T15:04:05Z"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &events.UserAnonymized{SchemaVersion: 2, UserId: 42, OccurredAt: occurredAt}
+	if !proto.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := events.Unmarshal(topic, []byte(`{"schema_version":3,"user_id":42}`)); err == nil {
+		t.Error("expected an error for a version newer than the consumer")
+	}
+}
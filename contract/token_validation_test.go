@@ -0,0 +1,79 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ewallet-ums/cmd/proto/tokenvalidation"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/api"
+	"ewallet-ums/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var marshal = protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}
+
+func TestValidateTokenGolden(t *testing.T) {
+	tests := []struct {
+		name  string
+		claim *helpers.ClaimToken
+		err   error
+	}{
+		{
+			name: "valid_token",
+			claim: &helpers.ClaimToken{
+				UserID:   42,
+				Username: "johndoe",
+				FullName: "John Doe",
+				Email:    "john@example.com",
+
+				ProfileCompleteness: 60,
+				Entitlements:        []string{"international_transfer"},
+			},
+		},
+		{
+			name: "empty_token",
+		},
+		{
+			name: "invalid_token",
+			err:  fmt.Errorf("failed to validate token: token is malformed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc := mocks.NewMockITokenValidationService(ctrl)
+			svc.EXPECT().TokenValidationForAudience(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.claim, tt.err).AnyTimes()
+
+			client := dial(t, &api.TokenValidationHandler{TokenValidationService: svc})
+
+			req := &tokenvalidation.TokenRequest{}
+			if err := protojson.Unmarshal(testdata(t, tt.name+".request.json"), req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+
+			resp, err := client.ValidateToken(context.Background(), req)
+			if err != nil {
+				t.Fatalf("ValidateToken: %v", err)
+			}
+
+			got, err := marshal.Marshal(resp)
+			if err != nil {
+				t.Fatalf("encode response: %v", err)
+			}
+
+			want := &tokenvalidation.TokenResponse{}
+			if err := protojson.Unmarshal(golden(t, tt.name+".response.json", got), want); err != nil {
+				t.Fatalf("decode golden response: %v", err)
+			}
+			if !proto.Equal(resp, want) {
+				t.Errorf("response drifted from contract\ngot:  %s\nwant: %s", got, marshal.Format(want))
+			}
+		})
+	}
+}
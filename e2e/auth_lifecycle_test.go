@@ -0,0 +1,216 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"ewallet-ums/cmd/proto/tokenvalidation"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+type envelope struct {
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func call(t *testing.T, method, path, token string, body any) (int, envelope) {
+	t.Helper()
+
+	payload := &bytes.Buffer{}
+	if body != nil {
+		json.NewEncoder(payload).Encode(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, httpURL+path, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	result := envelope{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("%s %s: failed to decode response: %v", method, path, err)
+	}
+	return resp.StatusCode, result
+}
+
+func decode[T any](t *testing.T, raw json.RawMessage) T {
+	t.Helper()
+
+	var result T
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("failed to decode data %s: %v", raw, err)
+	}
+	return result
+}
+
+// serviceToken creates a service account and returns an access token for it,
+// which the gRPC API requires of its callers.
+func serviceToken(t *testing.T) string {
+	t.Helper()
+
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	svc := &services.ServiceAccountService{
+		ServiceAccountRepo: &repository.ServiceAccountRepository{DB: helpers.DB},
+		UserRepo:           userRepo,
+		SessionRepo:        &repository.SessionRepository{DB: helpers.DB},
+		AuditRepo:          &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:         repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+		PasswordHasher:     helpers.NewPasswordHasher(2),
+		TokenTTL:           time.Hour,
+	}
+
+	creds, err := svc.CreateServiceAccount(ctx, 0, models.ServiceAccountRequest{Name: "E2E caller"})
+	if err != nil {
+		t.Fatal("failed to create service account: ", err)
+	}
+	token, err := svc.IssueToken(ctx, creds.ClientID, creds.ClientSecret, "")
+	if err != nil {
+		t.Fatal("failed to issue service account token: ", err)
+	}
+	return token.AccessToken
+}
+
+func TestAuthLifecycle(t *testing.T) {
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	validator := tokenvalidation.NewTokenValidationClient(conn)
+	callerCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+serviceToken(t))
+
+	username := fmt.Sprintf("e2e%d", time.Now().UnixNano()%1e12)
+	password := "e2e-password"
+
+	// register
+	status, resp := call(t, http.MethodPost, "/user/v1/register", "", map[string]string{
+		"username":     username,
+		"email":        username + "@example.com",
+		"phone_number": "08123456789",
+		"full_name":    "E2E User",
+		"password":     password,
+	})
+	if status != http.StatusOK || resp.Message != constants.SuccessMessage {
+		t.Fatalf("register: got %d %q", status, resp.Message)
+	}
+	registered := decode[map[string]any](t, resp.Data)
+	if _, ok := registered["password"]; ok {
+		t.Error("register response must not contain the password")
+	}
+	if registered["wallet_status"] != constants.WalletStatusProvisioning {
+		t.Errorf("register: got wallet_status %v", registered["wallet_status"])
+	}
+
+	user := models.User{}
+	if err := helpers.DB.Where("username = ?", username).First(&user).Error; err != nil {
+		t.Fatal("registered user not in database: ", err)
+	}
+	if user.Password == password {
+		t.Error("password stored in plain text")
+	}
+
+	// there is no account verification step yet, so the next gate is the
+	// wallet being provisioned by the worker
+	deadline := time.Now().Add(15 * time.Second)
+	for {
+		provisioning := models.WalletProvisioning{}
+		helpers.DB.Where("user_id = ?", user.ID).First(&provisioning)
+		if provisioning.Status == constants.WalletStatusCreated {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("wallet not provisioned, status %q", provisioning.Status)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	// login
+	status, resp = call(t, http.MethodPost, "/user/v1/login", "", models.LoginRequest{Username: username, Password: password})
+	if status != http.StatusOK {
+		t.Fatalf("login: got %d %q", status, resp.Message)
+	}
+	login := decode[models.LoginResponse](t, resp.Data)
+	if login.UserID != user.ID || login.Token == "" || login.RefreshToken == "" || login.RequestSigningKey == "" {
+		t.Fatalf("login: unexpected response %+v", login)
+	}
+
+	var sessions int64
+	helpers.DB.Model(&models.UserSession{}).Where("user_id = ?", user.ID).Count(&sessions)
+	if sessions != 1 {
+		t.Errorf("got %d sessions after login, want 1", sessions)
+	}
+
+	// validate over grpc, which takes an authenticated caller
+	if _, err := validator.ValidateToken(ctx, &tokenvalidation.TokenRequest{Token: login.Token}); grpcstatus.Code(err) != codes.Unauthenticated {
+		t.Errorf("validate without caller credentials: got %v, want unauthenticated", err)
+	}
+	validation, err := validator.ValidateToken(callerCtx, &tokenvalidation.TokenRequest{Token: login.Token})
+	if err != nil {
+		t.Fatal("validate: ", err)
+	}
+	if validation.GetMessage() != constants.SuccessMessage || validation.GetData().GetUserId() != int64(user.ID) {
+		t.Fatalf("validate: unexpected response %v", validation)
+	}
+
+	// refresh
+	status, resp = call(t, http.MethodPut, "/user/v1/refresh-token", login.RefreshToken, nil)
+	if status != http.StatusOK {
+		t.Fatalf("refresh: got %d %q", status, resp.Message)
+	}
+	refreshed := decode[models.RefreshTokenResponse](t, resp.Data)
+	if refreshed.Token == "" || refreshed.RefreshToken == "" || refreshed.RefreshToken == login.RefreshToken || refreshed.TokenExpiresAt.IsZero() || refreshed.RefreshTokenExpiresAt.IsZero() {
+		t.Fatalf("refresh: unexpected response %+v", refreshed)
+	}
+
+	validation, _ = validator.ValidateToken(callerCtx, &tokenvalidation.TokenRequest{Token: login.Token})
+	if validation.GetMessage() == constants.SuccessMessage {
+		t.Error("old access token still valid after refresh")
+	}
+
+	// logout
+	status, resp = call(t, http.MethodDelete, "/user/v1/logout", refreshed.Token, nil)
+	if status != http.StatusOK {
+		t.Fatalf("logout: got %d %q", status, resp.Message)
+	}
+
+	helpers.DB.Model(&models.UserSession{}).Where("user_id = ?", user.ID).Count(&sessions)
+	if sessions != 0 {
+		t.Errorf("got %d sessions after logout, want 0", sessions)
+	}
+
+	validation, _ = validator.ValidateToken(callerCtx, &tokenvalidation.TokenRequest{Token: refreshed.Token})
+	if validation.GetMessage() == constants.SuccessMessage {
+		t.Error("token still valid over grpc after logout")
+	}
+
+	status, _ = call(t, http.MethodGet, "/user/v1/profile", refreshed.Token, nil)
+	if status != http.StatusUnauthorized {
+		t.Errorf("profile after logout: got %d, want 401", status)
+	}
+}
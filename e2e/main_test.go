@@ -0,0 +1,82 @@
+//go:build e2e
+
+// Package e2e boots the real HTTP, gRPC and worker processes against
+// containers and drives them as a client would. Run with:
+//
+//	go test -tags e2e ./e2e
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"ewallet-ums/cmd"
+	"ewallet-ums/internal/testenv"
+)
+
+var (
+	ctx      = context.Background()
+	httpURL  string
+	grpcAddr string
+)
+
+func TestMain(m *testing.M) {
+	httpPort, grpcPort := freePort(), freePort()
+	httpURL = "http://127.0.0.1:" + strconv.Itoa(httpPort)
+	grpcAddr = "127.0.0.1:" + strconv.Itoa(grpcPort)
+
+	env, err := testenv.Start(ctx, map[string]string{
+		"PORT":                                 strconv.Itoa(httpPort),
+		"GRPC_PORT":                            strconv.Itoa(grpcPort),
+		"WALLET_PROVISIONING_INTERVAL_SECONDS": "1",
+	})
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+
+	go cmd.ServeWorker()
+	go cmd.ServeGRPC()
+	go cmd.ServeHTTP()
+
+	if err := waitHealthy(30 * time.Second); err != nil {
+		log.Println(err)
+		env.Stop()
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	env.Stop()
+	os.Exit(code)
+}
+
+func freePort() int {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatal("failed to find free port: ", err)
+	}
+	defer lis.Close()
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+func waitHealthy(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(httpURL + "/health")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return fmt.Errorf("service not healthy after %s", timeout)
+}
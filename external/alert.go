@@ -0,0 +1,83 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Alert is an operator notification.
+type Alert struct {
+	Title  string            `json:"title"`
+	Text   string            `json:"text"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// WebhookAlerter posts alerts as JSON to URL.
+type WebhookAlerter struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+func (a *WebhookAlerter) Alert(ctx context.Context, alert Alert) error {
+	return postJSON(ctx, a.HTTPClient, a.URL, alert)
+}
+
+// SlackAlerter posts alerts to a Slack incoming webhook.
+type SlackAlerter struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+func (a *SlackAlerter) Alert(ctx context.Context, alert Alert) error {
+	lines := []string{"*" + alert.Title + "*", alert.Text}
+
+	keys := make([]string, 0, len(alert.Fields))
+	for key := range alert.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %s", key, alert.Fields[key]))
+	}
+
+	return postJSON(ctx, a.HTTPClient, a.WebhookURL, map[string]string{"text": strings.Join(lines, "\n")})
+}
+
+// LogAlerter only logs alerts, for deployments without an alert channel.
+type LogAlerter struct{}
+
+func (LogAlerter) Alert(ctx context.Context, alert Alert) error {
+	logrus.WithField("fields", alert.Fields).Warnf("alert: %s: %s", alert.Title, alert.Text)
+	return nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal json: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create alert http request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("got error response from alert webhook %d", resp.StatusCode)
+	}
+	return nil
+}
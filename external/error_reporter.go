@@ -0,0 +1,175 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrorReport is an error or panic sent to the error tracker. It carries no
+// raw PII: UserID is a keyed hash and Route is the route pattern, never the
+// URL with its query.
+type ErrorReport struct {
+	Message string
+	// Type is the Go type of the error, or "panic".
+	Type  string
+	Panic bool
+	Stack string
+
+	RequestID string
+	UserID    string
+	// Transport is http or grpc, Route the HTTP route or gRPC method.
+	Transport string
+	Route     string
+	Status    string
+}
+
+// piiPatterns match emails and phone or card numbers that may end up in an
+// error message, e.g. from a failed lookup.
+var piiPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	regexp.MustCompile(`\+?\d[\d \-]{7,}\d`),
+}
+
+// ScrubPII replaces the emails and long numbers in s.
+func ScrubPII(s string) string {
+	for _, pattern := range piiPatterns {
+		s = pattern.ReplaceAllString(s, "[redacted]")
+	}
+	return s
+}
+
+// SentryReporter sends reports to Sentry's envelope endpoint.
+type SentryReporter struct {
+	HTTPClient  *http.Client
+	Environment string
+	Release     string
+
+	endpoint  string
+	publicKey string
+	dsn       string
+}
+
+// NewSentryReporter parses a DSN of the form
+// https://<public key>@<host>/<project id>.
+func NewSentryReporter(dsn string, httpClient *http.Client) (*SentryReporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentry dsn: %v", err)
+	}
+	projectID := path.Base(u.Path)
+	if u.User == nil || u.User.Username() == "" || u.Host == "" || projectID == "." || projectID == "/" {
+		return nil, fmt.Errorf("invalid sentry dsn: want https://<key>@<host>/<project>")
+	}
+
+	return &SentryReporter{
+		HTTPClient: httpClient,
+		endpoint:   fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, strings.TrimSuffix(path.Dir(u.Path), "/"), projectID),
+		publicKey:  u.User.Username(),
+		dsn:        dsn,
+	}, nil
+}
+
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	User        *sentryUser       `json:"user,omitempty"`
+	Exception   struct {
+		Values []sentryException `json:"values"`
+	} `json:"exception"`
+	Extra map[string]string `json:"extra,omitempty"`
+}
+
+type sentryUser struct {
+	ID string `json:"id"`
+}
+
+type sentryException struct {
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	Mechanism struct {
+		Type    string `json:"type"`
+		Handled bool   `json:"handled"`
+	} `json:"mechanism"`
+}
+
+func (r *SentryReporter) Report(ctx context.Context, report ErrorReport) error {
+	eventID := make([]byte, 16)
+	if _, err := rand.Read(eventID); err != nil {
+		return fmt.Errorf("failed to generate event id: %v", err)
+	}
+
+	event := sentryEvent{
+		EventID:     hex.EncodeToString(eventID),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "error",
+		Environment: r.Environment,
+		Release:     r.Release,
+		Tags:        map[string]string{},
+	}
+	for key, value := range map[string]string{
+		"request_id": report.RequestID,
+		"transport":  report.Transport,
+		"route":      report.Route,
+		"status":     report.Status,
+	} {
+		if value != "" {
+			event.Tags[key] = value
+		}
+	}
+	if report.UserID != "" {
+		event.User = &sentryUser{ID: report.UserID}
+	}
+
+	exception := sentryException{Type: report.Type, Value: ScrubPII(report.Message)}
+	exception.Mechanism.Type = "generic"
+	exception.Mechanism.Handled = !report.Panic
+	if report.Panic {
+		event.Level = "fatal"
+		exception.Mechanism.Type = "panic"
+	}
+	event.Exception.Values = []sentryException{exception}
+	if report.Stack != "" {
+		event.Extra = map[string]string{"stack": ScrubPII(report.Stack)}
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sentry event: %v", err)
+	}
+	header, _ := json.Marshal(map[string]string{"event_id": event.EventID, "dsn": r.dsn, "sent_at": event.Timestamp})
+	body := bytes.Join([][]byte{header, []byte(`{"type":"event"}`), payload}, []byte("\n"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create sentry http request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", "Sentry sentry_version=7, sentry_client=ewallet-ums/1.0, sentry_key="+r.publicKey)
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send sentry event: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("got error response from sentry %d", resp.StatusCode)
+	}
+	return nil
+}
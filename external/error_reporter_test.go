@@ -0,0 +1,60 @@
+package external
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScrubPII(t *testing.T) {
+	got := ScrubPII("user jane.doe@example.com with phone +62 812-3456-7890 not found after 3 attempts")
+	want := "user [redacted] with phone [redacted] not found after 3 attempts"
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestSentryReporter(t *testing.T) {
+	var gotPath, gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotAuth, gotBody = r.URL.Path, r.Header.Get("X-Sentry-Auth"), string(body)
+	}))
+	defer server.Close()
+
+	reporter, err := NewSentryReporter(strings.Replace(server.URL, "://", "://publickey@", 1)+"/42", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reporter.Report(context.Background(), ErrorReport{
+		Message:   "failed to find user jane.doe@example.com",
+		Type:      "*errors.errorString",
+		RequestID: "req-1",
+		UserID:    "abcdef0123456789",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotPath != "/api/42/envelope/" {
+		t.Errorf("expected the envelope endpoint, got %s", gotPath)
+	}
+	if !strings.Contains(gotAuth, "sentry_key=publickey") {
+		t.Errorf("expected the public key in the auth header, got %s", gotAuth)
+	}
+	for _, want := range []string{`"request_id":"req-1"`, `"user":{"id":"abcdef0123456789"}`, `failed to find user [redacted]`} {
+		if !strings.Contains(gotBody, want) {
+			t.Errorf("expected %s in the envelope, got %s", want, gotBody)
+		}
+	}
+	if strings.Contains(gotBody, "jane.doe") {
+		t.Errorf("expected the email to be scrubbed, got %s", gotBody)
+	}
+
+	if _, err := NewSentryReporter("https://sentry.example.com/42", nil); err == nil {
+		t.Error("expected an error for a dsn without a key")
+	}
+}
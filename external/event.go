@@ -0,0 +1,177 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/helpers"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+type EventPublisher struct {
+	Redis *redis.Client
+}
+
+// Publish sends event as JSON in the schema registered for topic, see
+// cmd/proto/events.
+func (e *EventPublisher) Publish(ctx context.Context, topic string, event proto.Message) error {
+	message, err := events.Marshal(topic, event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %v", err)
+	}
+	return e.PublishMessage(ctx, topic, message)
+}
+
+// PublishMessage sends an event already marshaled, e.g. a dead letter.
+func (e *EventPublisher) PublishMessage(ctx context.Context, topic string, message []byte) error {
+	if err := e.Redis.Publish(ctx, topic, message).Err(); err != nil {
+		return fmt.Errorf("failed to publish event %s: %v", topic, err)
+	}
+	return nil
+}
+
+// EventConsumer reads the events other services add to redis streams, one
+// stream per topic with the JSON event in its payload field. Instances read
+// as one consumer group, so each event goes to one of them and stays pending
+// until handled. Events left pending longer than ReclaimIdle, by a failed
+// handler or a consumer that stopped, are taken over and handled again, and
+// given up on after MaxDeliveries.
+type EventConsumer struct {
+	Redis         *redis.Client
+	Group         string
+	Consumer      string
+	ReclaimIdle   time.Duration
+	MaxDeliveries int64
+	// DeadLetter keeps the events given up on. Without it they are dropped
+	// with an error log, and when it fails they stay pending.
+	DeadLetter func(ctx context.Context, topic, messageID string, payload []byte, reason string) error
+}
+
+// Redeliver adds payload to topic's stream again, so the consumer group
+// handles it as a new event.
+func (c *EventConsumer) Redeliver(ctx context.Context, topic string, payload []byte) error {
+	err := c.Redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: topic,
+		Values: map[string]any{"payload": string(payload)},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to redeliver event %s: %v", topic, err)
+	}
+	return nil
+}
+
+func (c *EventConsumer) Consume(ctx context.Context, topics []string, handle func(ctx context.Context, topic string, payload []byte) error) error {
+	for _, topic := range topics {
+		err := c.Redis.XGroupCreateMkStream(ctx, topic, c.Group, "0").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return fmt.Errorf("failed to create consumer group for %s: %v", topic, err)
+		}
+	}
+
+	streams := make([]string, 0, 2*len(topics))
+	streams = append(streams, topics...)
+	for range topics {
+		streams = append(streams, ">")
+	}
+
+	reclaimed := time.Now()
+	for ctx.Err() == nil {
+		if time.Since(reclaimed) >= c.ReclaimIdle {
+			for _, topic := range topics {
+				c.reclaim(ctx, topic, handle)
+			}
+			reclaimed = time.Now()
+		}
+
+		// block for less than ReclaimIdle, so reclaiming isn't held up
+		// while nothing new arrives
+		result, err := c.Redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.Group,
+			Consumer: c.Consumer,
+			Streams:  streams,
+			Count:    10,
+			Block:    min(c.ReclaimIdle, 5*time.Second),
+		}).Result()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) && ctx.Err() == nil {
+				helpers.Logger.Error("failed to read events: ", err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+
+		for _, stream := range result {
+			for _, message := range stream.Messages {
+				c.handle(ctx, stream.Stream, message, handle)
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+func (c *EventConsumer) reclaim(ctx context.Context, topic string, handle func(ctx context.Context, topic string, payload []byte) error) {
+	messages, _, err := c.Redis.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   topic,
+		Group:    c.Group,
+		Consumer: c.Consumer,
+		MinIdle:  c.ReclaimIdle,
+		Start:    "0-0",
+		Count:    100,
+	}).Result()
+	if err != nil {
+		helpers.Logger.Errorf("failed to reclaim %s events: %v", topic, err)
+		return
+	}
+
+	for _, message := range messages {
+		pending, err := c.Redis.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: topic,
+			Group:  c.Group,
+			Start:  message.ID,
+			End:    message.ID,
+			Count:  1,
+		}).Result()
+		if err == nil && len(pending) == 1 && pending[0].RetryCount > c.MaxDeliveries {
+			c.giveUp(ctx, topic, message, pending[0].RetryCount)
+			continue
+		}
+		c.handle(ctx, topic, message, handle)
+	}
+}
+
+func (c *EventConsumer) giveUp(ctx context.Context, topic string, message redis.XMessage, deliveries int64) {
+	if c.DeadLetter == nil {
+		helpers.Logger.Errorf("dropping %s event %s after %d deliveries: %v", topic, message.ID, deliveries, message.Values)
+		c.ack(ctx, topic, message.ID)
+		return
+	}
+
+	payload, _ := message.Values["payload"].(string)
+	reason := fmt.Sprintf("failed to handle after %d deliveries", deliveries)
+	if err := c.DeadLetter(ctx, topic, message.ID, []byte(payload), reason); err != nil {
+		helpers.Logger.Errorf("failed to dead letter %s event %s, it stays pending: %v", topic, message.ID, err)
+		return
+	}
+	c.ack(ctx, topic, message.ID)
+}
+
+func (c *EventConsumer) handle(ctx context.Context, topic string, message redis.XMessage, handle func(ctx context.Context, topic string, payload []byte) error) {
+	payload, _ := message.Values["payload"].(string)
+	if err := handle(ctx, topic, []byte(payload)); err != nil {
+		helpers.Logger.Errorf("failed to handle %s event %s, it will be retried: %v", topic, message.ID, err)
+		return
+	}
+	c.ack(ctx, topic, message.ID)
+}
+
+func (c *EventConsumer) ack(ctx context.Context, topic, id string) {
+	if err := c.Redis.XAck(ctx, topic, c.Group, id).Err(); err != nil {
+		helpers.Logger.Errorf("failed to ack %s event %s: %v", topic, id, err)
+	}
+}
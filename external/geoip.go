@@ -0,0 +1,97 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+// GeoLocation is where an IP address is, empty when it isn't known.
+type GeoLocation struct {
+	// Country is the ISO 3166-1 alpha-2 code.
+	Country string
+	City    string
+}
+
+// MaxMindGeoIP looks IP addresses up with the MaxMind GeoIP2 City web
+// service, or GeoLite2 with BaseURL https://geolite.info. Results are cached,
+// a login shouldn't cost a lookup each time.
+type MaxMindGeoIP struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	AccountID  string
+	LicenseKey string
+
+	cache *expirable.LRU[string, GeoLocation]
+}
+
+func NewMaxMindGeoIP(httpClient *http.Client, baseURL, accountID, licenseKey string, cacheSize int, cacheTTL time.Duration) *MaxMindGeoIP {
+	return &MaxMindGeoIP{
+		HTTPClient: httpClient,
+		BaseURL:    baseURL,
+		AccountID:  accountID,
+		LicenseKey: licenseKey,
+		cache:      expirable.NewLRU[string, GeoLocation](cacheSize, nil, cacheTTL),
+	}
+}
+
+type maxMindCity struct {
+	Country struct {
+		ISOCode string `json:"iso_code"`
+	} `json:"country"`
+	City struct {
+		Names map[string]string `json:"names"`
+	} `json:"city"`
+}
+
+type maxMindError struct {
+	Code string `json:"code"`
+}
+
+func (m *MaxMindGeoIP) Lookup(ctx context.Context, ip string) (GeoLocation, error) {
+	if location, ok := m.cache.Get(ip); ok {
+		return location, nil
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL+"/geoip/v2.1/city/"+url.PathEscape(ip), nil)
+	if err != nil {
+		return GeoLocation{}, fmt.Errorf("failed to create geoip http request: %v", err)
+	}
+	httpReq.SetBasicAuth(m.AccountID, m.LicenseKey)
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := m.HTTPClient.Do(httpReq)
+	if err != nil {
+		return GeoLocation{}, fmt.Errorf("failed to connect geoip service: %v", err)
+	}
+	defer resp.Body.Close()
+
+	location := GeoLocation{}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		city := maxMindCity{}
+		if err := json.NewDecoder(resp.Body).Decode(&city); err != nil {
+			return GeoLocation{}, fmt.Errorf("failed to read geoip response body: %v", err)
+		}
+		location = GeoLocation{Country: city.Country.ISOCode, City: city.City.Names["en"]}
+	case http.StatusNotFound, http.StatusBadRequest:
+		// private, reserved and unknown addresses have no location
+		body := maxMindError{}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return GeoLocation{}, fmt.Errorf("got error response from geoip service %d", resp.StatusCode)
+		}
+		if body.Code != "IP_ADDRESS_NOT_FOUND" && body.Code != "IP_ADDRESS_RESERVED" {
+			return GeoLocation{}, fmt.Errorf("got error response from geoip service %d %s", resp.StatusCode, body.Code)
+		}
+	default:
+		return GeoLocation{}, fmt.Errorf("got error response from geoip service %d", resp.StatusCode)
+	}
+
+	m.cache.Add(ip, location)
+	return location, nil
+}
@@ -0,0 +1,48 @@
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMaxMindGeoIP(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if user, key, _ := r.BasicAuth(); user != "42" || key != "license" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/geoip/v2.1/city/203.0.113.7":
+			w.Write([]byte(`{"country":{"iso_code":"ID","names":{"en":"Indonesia"}},"city":{"names":{"en":"Jakarta","id":"Jakarta"}}}`))
+		case "/geoip/v2.1/city/10.0.0.1":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"IP_ADDRESS_RESERVED","error":"The value 10.0.0.1 belongs to a reserved or private range."}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	geoIP := NewMaxMindGeoIP(server.Client(), server.URL, "42", "license", 10, time.Minute)
+	for range 2 {
+		location, err := geoIP.Lookup(context.Background(), "203.0.113.7")
+		if err != nil || location != (GeoLocation{Country: "ID", City: "Jakarta"}) {
+			t.Fatalf("got %+v, err %v, want Jakarta, ID", location, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want the second lookup cached", requests)
+	}
+
+	if location, err := geoIP.Lookup(context.Background(), "10.0.0.1"); err != nil || location != (GeoLocation{}) {
+		t.Errorf("got %+v, err %v, want no location for a private address", location, err)
+	}
+	if _, err := geoIP.Lookup(context.Background(), "198.51.100.1"); err == nil {
+		t.Error("expected an error for a failed lookup")
+	}
+}
@@ -0,0 +1,115 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Mail struct {
+	// From overrides the mailer's sender, e.g. with a client's brand.
+	From    string
+	To      string
+	Subject string
+	Body    string
+	// HTMLBody is sent as an alternative to Body when set.
+	HTMLBody string
+	// Template names the template, locale and version the mail was rendered
+	// from, so support can tell which copy a user got.
+	Template string
+	// UnsubscribeURL is sent as a one-click List-Unsubscribe header.
+	UnsubscribeURL string
+}
+
+// SMTPMailer sends plain text mail, with an HTML alternative when there is
+// one, through an SMTP relay, authenticating when Username is set.
+type SMTPMailer struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, mail Mail) error {
+	if strings.ContainsAny(mail.To, "\r\n") {
+		return fmt.Errorf("invalid mail recipient %q", mail.To)
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, _ := strings.Cut(m.Addr, ":")
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+
+	from := m.From
+	if mail.From != "" {
+		from = mail.From
+	}
+	if strings.ContainsAny(from, "\r\n") {
+		return fmt.Errorf("invalid mail sender %q", from)
+	}
+
+	headers := []string{
+		"From: " + from,
+		"To: " + mail.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", mail.Subject),
+		"MIME-Version: 1.0",
+	}
+	if mail.Template != "" {
+		headers = append(headers, "X-Template: "+mail.Template)
+	}
+	if mail.UnsubscribeURL != "" {
+		headers = append(headers, "List-Unsubscribe: <"+mail.UnsubscribeURL+">", "List-Unsubscribe-Post: List-Unsubscribe=One-Click")
+	}
+
+	contentType, body := "text/plain; charset=utf-8", mail.Body
+	if mail.HTMLBody != "" {
+		contentType, body = multipartAlternative(mail.Body, mail.HTMLBody)
+	}
+	headers = append(headers, "Content-Type: "+contentType)
+	message := strings.Join(append(headers, "", body), "\r\n")
+
+	if err := smtp.SendMail(m.Addr, auth, from, []string{mail.To}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send mail: %v", err)
+	}
+	return nil
+}
+
+// multipartAlternative returns the content type and body of a mail offering
+// both text and HTML, quoted-printable so long HTML lines survive relays.
+func multipartAlternative(text, html string) (string, string) {
+	body := bytes.Buffer{}
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		// writes to a bytes.Buffer don't fail
+		partWriter, _ := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		encoder := quotedprintable.NewWriter(partWriter)
+		encoder.Write([]byte(part.content))
+		encoder.Close()
+	}
+	writer.Close()
+
+	return "multipart/alternative; boundary=" + writer.Boundary(), body.String()
+}
+
+// LogMailer only logs mail, for local runs without an SMTP relay.
+type LogMailer struct{}
+
+func (LogMailer) Send(ctx context.Context, mail Mail) error {
+	logrus.Infof("mail to %s: %s (%s)", mail.To, mail.Subject, mail.Template)
+	return nil
+}
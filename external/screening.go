@@ -0,0 +1,72 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ScreeningSubject is a person to screen against PEP and sanctions lists.
+type ScreeningSubject struct {
+	// Reference identifies the subject to the provider, the user id.
+	Reference string `json:"reference"`
+	FullName  string `json:"full_name"`
+	Dob       string `json:"dob,omitempty"`
+	Country   string `json:"country,omitempty"`
+}
+
+// ScreeningMatch is a list entry the subject may be.
+type ScreeningMatch struct {
+	ID string `json:"id"`
+	// List is the kind of list, e.g. pep, sanctions or adverse_media.
+	List  string  `json:"list"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+type screeningResponse struct {
+	Matches []ScreeningMatch `json:"matches"`
+}
+
+// WebhookScreeningProvider screens subjects by posting them as JSON to the
+// screening provider at URL, with APIKey as the bearer token when set. The
+// provider answers with the matches, none for a clear subject.
+type WebhookScreeningProvider struct {
+	URL        string
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+func (p *WebhookScreeningProvider) Screen(ctx context.Context, subject ScreeningSubject) ([]ScreeningMatch, error) {
+	payload, err := json.Marshal(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal screening subject: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create screening http request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect screening provider: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got error response from screening provider %d", resp.StatusCode)
+	}
+
+	body := screeningResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to read screening response body: %v", err)
+	}
+	return body.Matches, nil
+}
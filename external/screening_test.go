@@ -0,0 +1,45 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookScreeningProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		subject := ScreeningSubject{}
+		if err := json.NewDecoder(r.Body).Decode(&subject); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch subject.FullName {
+		case "Jane Doe":
+			w.Write([]byte(`{"matches":[{"id":"ofac-123","list":"sanctions","name":"Jane DOE","score":0.97}]}`))
+		case "John Smith":
+			w.Write([]byte(`{"matches":[]}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	provider := &WebhookScreeningProvider{URL: server.URL, APIKey: "key", HTTPClient: server.Client()}
+
+	matches, err := provider.Screen(context.Background(), ScreeningSubject{Reference: "1", FullName: "Jane Doe"})
+	if err != nil || len(matches) != 1 || matches[0] != (ScreeningMatch{ID: "ofac-123", List: "sanctions", Name: "Jane DOE", Score: 0.97}) {
+		t.Fatalf("got %+v, err %v, want the sanctions match", matches, err)
+	}
+	if matches, err := provider.Screen(context.Background(), ScreeningSubject{Reference: "2", FullName: "John Smith"}); err != nil || len(matches) != 0 {
+		t.Errorf("got %+v, err %v, want no matches", matches, err)
+	}
+	if _, err := provider.Screen(context.Background(), ScreeningSubject{Reference: "3", FullName: "Down Time"}); err == nil {
+		t.Error("expected an error for a failed screening")
+	}
+}
@@ -0,0 +1,175 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ewallet-ums/helpers"
+)
+
+const (
+	// SIEMFormatJSON posts each batch as a JSON array of events, for HTTP
+	// collectors like Splunk HEC raw or a Logstash http input.
+	SIEMFormatJSON = "json"
+	// SIEMFormatKafkaREST posts each batch to a Kafka topic through a Kafka
+	// REST proxy (v2 API), keyed by user id so a user's events stay in order.
+	SIEMFormatKafkaREST = "kafka_rest"
+)
+
+// SIEMSink batches security events and posts them to a SIEM. Events wait in
+// a bounded in-memory queue: when it is full, e.g. while the SIEM is down,
+// new events are dropped and counted rather than holding up the request that
+// emitted them. A failed batch is retried with backoff up to MaxRetries times
+// and then dropped. Events still queued when the process stops are lost.
+type SIEMSink struct {
+	URL        string
+	APIKey     string
+	Format     string
+	HTTPClient *http.Client
+
+	BatchSize     int
+	FlushInterval time.Duration
+	MaxRetries    int
+	// RetryBackoff is the wait before the first retry, doubled on each one.
+	RetryBackoff time.Duration
+
+	queue chan helpers.SecurityEvent
+}
+
+func NewSIEMSink(httpClient *http.Client, url, apiKey, format string, queueSize, batchSize int, flushInterval time.Duration, maxRetries int) (*SIEMSink, error) {
+	if format != SIEMFormatJSON && format != SIEMFormatKafkaREST {
+		return nil, fmt.Errorf("invalid siem format %q, want %s or %s", format, SIEMFormatJSON, SIEMFormatKafkaREST)
+	}
+
+	return &SIEMSink{
+		URL:           url,
+		APIKey:        apiKey,
+		Format:        format,
+		HTTPClient:    httpClient,
+		BatchSize:     batchSize,
+		FlushInterval: flushInterval,
+		MaxRetries:    maxRetries,
+		RetryBackoff:  time.Second,
+		queue:         make(chan helpers.SecurityEvent, queueSize),
+	}, nil
+}
+
+// Emit queues event without blocking, dropping it when the queue is full.
+func (s *SIEMSink) Emit(event helpers.SecurityEvent) {
+	select {
+	case s.queue <- event:
+		helpers.SIEMQueueDepth.Set(float64(len(s.queue)))
+	default:
+		helpers.SIEMEvents.WithLabelValues("dropped").Inc()
+	}
+}
+
+// Run sends the queued events until ctx is done, a batch once BatchSize
+// events are queued or FlushInterval has passed. What is batched when ctx
+// is done is sent once more without retries.
+func (s *SIEMSink) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]helpers.SecurityEvent, 0, s.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			if len(batch) > 0 {
+				s.send(context.WithoutCancel(ctx), batch, 0)
+			}
+			return
+		case event := <-s.queue:
+			batch = append(batch, event)
+			if len(batch) < s.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		helpers.SIEMQueueDepth.Set(float64(len(s.queue)))
+		s.send(ctx, batch, s.MaxRetries)
+		batch = batch[:0]
+	}
+}
+
+// send posts batch, retrying up to retries times. The queue fills up while it
+// waits, which is what sheds load when the SIEM can't keep up.
+func (s *SIEMSink) send(ctx context.Context, batch []helpers.SecurityEvent, retries int) {
+	backoff := s.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := s.post(ctx, batch)
+		if err == nil {
+			helpers.SIEMEvents.WithLabelValues("sent").Add(float64(len(batch)))
+			return
+		}
+		if attempt >= retries {
+			helpers.Logger.Errorf("dropping %d security events after %d attempts: %v", len(batch), attempt+1, err)
+			helpers.SIEMEvents.WithLabelValues("failed").Add(float64(len(batch)))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			helpers.SIEMEvents.WithLabelValues("failed").Add(float64(len(batch)))
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+type kafkaRecord struct {
+	Key   string                `json:"key,omitempty"`
+	Value helpers.SecurityEvent `json:"value"`
+}
+
+func (s *SIEMSink) post(ctx context.Context, batch []helpers.SecurityEvent) error {
+	var body any = batch
+	contentType := "application/json"
+	if s.Format == SIEMFormatKafkaREST {
+		records := make([]kafkaRecord, 0, len(batch))
+		for _, event := range batch {
+			record := kafkaRecord{Value: event}
+			if event.UserID != 0 {
+				record.Key = strconv.Itoa(event.UserID)
+			}
+			records = append(records, record)
+		}
+		body = map[string][]kafkaRecord{"records": records}
+		contentType = "application/vnd.kafka.json.v2+json"
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal security events: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create siem http request: %v", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	}
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send security events: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("got error response from siem %d", resp.StatusCode)
+	}
+	return nil
+}
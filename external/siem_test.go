@@ -0,0 +1,96 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+)
+
+func TestSIEMSinkBatchesKafkaRecords(t *testing.T) {
+	var failures atomic.Int32
+	batches := make(chan []kafkaRecord, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" || r.Header.Get("Content-Type") != "application/vnd.kafka.json.v2+json" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// the first attempt fails, to be retried
+		if failures.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body := map[string][]kafkaRecord{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batches <- body["records"]
+	}))
+	defer server.Close()
+
+	sink, err := NewSIEMSink(server.Client(), server.URL, "key", SIEMFormatKafkaREST, 10, 2, 20*time.Millisecond, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.RetryBackoff = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sink.Run(ctx)
+
+	sink.Emit(helpers.SecurityEvent{Type: helpers.SecurityEventTokenIssued, UserID: 7, TokenID: "a"})
+	sink.Emit(helpers.SecurityEvent{Type: helpers.SecurityEventTokenRevoked, UserID: 7, TokenID: "a"})
+	sink.Emit(helpers.SecurityEvent{Type: helpers.SecurityEventTokenValidationFailed, Reason: helpers.TokenFailureInvalid})
+
+	got := []kafkaRecord{}
+	for len(got) < 3 {
+		select {
+		case batch := <-batches:
+			if len(batch) > 2 {
+				t.Errorf("got a batch of %d, want at most 2", len(batch))
+			}
+			got = append(got, batch...)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("got %d events, want 3", len(got))
+		}
+	}
+
+	if got[0].Key != "7" || got[0].Value.TokenID != "a" || got[0].Value.Type != helpers.SecurityEventTokenIssued {
+		t.Errorf("got %+v, want the issued event keyed by user", got[0])
+	}
+	if got[2].Key != "" || got[2].Value.Reason != helpers.TokenFailureInvalid {
+		t.Errorf("got %+v, want the unkeyed validation failure", got[2])
+	}
+}
+
+func TestSIEMSinkDropsWhenFull(t *testing.T) {
+	sink, err := NewSIEMSink(http.DefaultClient, "http://siem.invalid", "", SIEMFormatJSON, 1, 10, time.Second, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		sink.Emit(helpers.SecurityEvent{Type: helpers.SecurityEventTokenIssued})
+		sink.Emit(helpers.SecurityEvent{Type: helpers.SecurityEventTokenIssued})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Emit not to block on a full queue")
+	}
+	if len(sink.queue) != 1 {
+		t.Errorf("got %d queued, want 1", len(sink.queue))
+	}
+
+	if _, err := NewSIEMSink(http.DefaultClient, "http://siem.invalid", "", "syslog", 1, 10, time.Second, 0); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,61 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SMS is a text message to a phone number in E.164.
+type SMS struct {
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+// WebhookSMSSender posts text messages as JSON to the SMS gateway at URL,
+// with APIKey as the bearer token when set.
+type WebhookSMSSender struct {
+	URL        string
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+func (s *WebhookSMSSender) SendSMS(ctx context.Context, sms SMS) error {
+	payload, err := json.Marshal(sms)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sms: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create sms http request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	}
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect sms gateway: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("got error response from sms gateway %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// LogSMSSender only logs text messages, codes included, for development
+// without an SMS gateway.
+type LogSMSSender struct{}
+
+func (LogSMSSender) SendSMS(ctx context.Context, sms SMS) error {
+	logrus.Warnf("sms to %s: %s", sms.To, sms.Text)
+	return nil
+}
@@ -16,7 +16,9 @@ type Wallet struct {
 	Balance float64 `json:"balance"`
 }
 
-type ExtWallet struct{}
+type ExtWallet struct {
+	HTTPClient *http.Client
+}
 
 func (e *ExtWallet) CreateWallet(ctx context.Context, userID int) (*Wallet, error) {
 	req := Wallet{UserID: userID}
@@ -28,16 +30,17 @@ func (e *ExtWallet) CreateWallet(ctx context.Context, userID int) (*Wallet, erro
 
 	url := helpers.GetEnv("WALLET_HOST", "") + helpers.GetEnv("WALLET_ENDPOINT_CREATE", "")
 
-	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(payload))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create wallet http request: %v", err)
 	}
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
+	resp, err := e.HTTPClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect wallet service: %v", err)
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("got error response from wallet service %d", resp.StatusCode)
@@ -48,7 +51,27 @@ func (e *ExtWallet) CreateWallet(ctx context.Context, userID int) (*Wallet, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %v", err)
 	}
-	defer resp.Body.Close()
 
 	return result, nil
 }
+
+// Ping checks the wallet service is reachable and answering.
+func (e *ExtWallet) Ping(ctx context.Context) error {
+	url := helpers.GetEnv("WALLET_HOST", "") + helpers.GetEnv("WALLET_ENDPOINT_HEALTH", "/health")
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create wallet http request: %v", err)
+	}
+
+	resp, err := e.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to connect wallet service: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("got error response from wallet service %d", resp.StatusCode)
+	}
+	return nil
+}
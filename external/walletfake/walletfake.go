@@ -0,0 +1,137 @@
+// Package walletfake provides an in-process stand-in for the wallet service,
+// used by integration tests and `ewallet-ums serve --with-fakes`.
+package walletfake
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"ewallet-ums/external"
+)
+
+const (
+	CreateEndpoint = "/wallet/v1/create"
+	HealthEndpoint = "/health"
+)
+
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	latency     time.Duration
+	failureRate float64
+	failNext    int
+	failStatus  int
+	wallets     map[int]external.Wallet
+	calls       int
+}
+
+type Option func(*Server)
+
+func WithLatency(latency time.Duration) Option {
+	return func(s *Server) { s.latency = latency }
+}
+
+// WithFailureRate makes a random share (0 to 1) of requests fail.
+func WithFailureRate(rate float64) Option {
+	return func(s *Server) { s.failureRate = rate }
+}
+
+// WithFailureStatus sets the status code returned by failed requests.
+func WithFailureStatus(code int) Option {
+	return func(s *Server) { s.failStatus = code }
+}
+
+func New(opts ...Option) *Server {
+	s := &Server{
+		failStatus: http.StatusInternalServerError,
+		wallets:    map[int]external.Wallet{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+CreateEndpoint, s.createWallet)
+	mux.HandleFunc("GET "+HealthEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+func (s *Server) SetFailureRate(rate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failureRate = rate
+}
+
+// FailNext makes the next n requests fail regardless of the failure rate.
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failNext = n
+}
+
+func (s *Server) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func (s *Server) Wallet(userID int) (external.Wallet, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wallet, ok := s.wallets[userID]
+	return wallet, ok
+}
+
+func (s *Server) createWallet(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.calls++
+	latency := s.latency
+	fail := s.failNext > 0 || (s.failureRate > 0 && rand.Float64() < s.failureRate)
+	if s.failNext > 0 {
+		s.failNext--
+	}
+	failStatus := s.failStatus
+	s.mu.Unlock()
+
+	select {
+	case <-time.After(latency):
+	case <-r.Context().Done():
+		return
+	}
+
+	if fail {
+		w.WriteHeader(failStatus)
+		return
+	}
+
+	req := external.Wallet{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	wallet, ok := s.wallets[req.UserID]
+	if !ok {
+		wallet = external.Wallet{ID: len(s.wallets) + 1, UserID: req.UserID}
+		s.wallets[req.UserID] = wallet
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(wallet)
+}
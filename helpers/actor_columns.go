@@ -0,0 +1,40 @@
+package helpers
+
+import "gorm.io/gorm"
+
+// ActorColumns is a GORM plugin filling the created_by and updated_by
+// columns of models embedding models.AuditColumns with the actor of the
+// statement's context, see ContextWithActor. Statements without an actor,
+// e.g. from background jobs, leave them as they are. Raw Exec statements
+// aren't covered.
+type ActorColumns struct{}
+
+func (p *ActorColumns) Name() string {
+	return "actor_columns"
+}
+
+func (p *ActorColumns) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register("actor_columns:create", p.setActor("CreatedBy", "UpdatedBy")); err != nil {
+		return err
+	}
+	return callback.Update().Before("gorm:update").Register("actor_columns:update", p.setActor("UpdatedBy"))
+}
+
+func (p *ActorColumns) setActor(fields ...string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.Statement.Schema == nil {
+			return
+		}
+		actor, ok := ActorFromContext(db.Statement.Context)
+		if !ok {
+			return
+		}
+
+		for _, name := range fields {
+			if db.Statement.Schema.LookUpField(name) != nil {
+				db.Statement.SetColumn(name, actor, true)
+			}
+		}
+	}
+}
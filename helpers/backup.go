@@ -0,0 +1,222 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// backupMagic starts every encrypted backup, the format version in its last
+// byte.
+var backupMagic = []byte("UMSBAK\x00\x01")
+
+const (
+	backupChunkSize   = 64 << 10
+	backupNoncePrefix = 7
+	backupMaxHeader   = 4 << 10
+)
+
+var ErrBackupCorrupt = errors.New("backup is corrupt, truncated or encrypted with another key")
+
+// BackupHeader is the plaintext head of an encrypted backup. The data key
+// the backup is encrypted with is stored wrapped by the master key KeyID, so
+// any process holding that master key, or a KMS in front of it, can read it.
+type BackupHeader struct {
+	KeyID       string    `json:"key_id"`
+	WrappedKey  []byte    `json:"wrapped_key"`
+	NoncePrefix []byte    `json:"nonce_prefix"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// backupWriter encrypts a stream in chunks of backupChunkSize with AES-GCM,
+// each sealed with the header as additional data and a nonce counting the
+// chunks and marking the last, so reordered, dropped or truncated chunks
+// and a swapped header fail to decrypt.
+type backupWriter struct {
+	w       io.Writer
+	gcm     cipher.AEAD
+	prefix  []byte
+	header  []byte
+	counter uint32
+	buf     []byte
+	closed  bool
+}
+
+// NewBackupWriter encrypts what is written to it into w, under a new data
+// key wrapped by encrypter. Close writes the last chunk, a backup without
+// it doesn't decrypt.
+func NewBackupWriter(ctx context.Context, w io.Writer, encrypter KeyEncrypter, now time.Time) (io.WriteCloser, error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	wrapped, err := encrypter.Wrap(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap backup key: %v", err)
+	}
+
+	header := BackupHeader{KeyID: encrypter.KeyID(), WrappedKey: wrapped, NoncePrefix: make([]byte, backupNoncePrefix), CreatedAt: now.UTC()}
+	if _, err := rand.Read(header.NoncePrefix); err != nil {
+		return nil, err
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	head := append([]byte{}, backupMagic...)
+	head = binary.BigEndian.AppendUint32(head, uint32(len(headerJSON)))
+	head = append(head, headerJSON...)
+	if _, err := w.Write(head); err != nil {
+		return nil, err
+	}
+
+	return &backupWriter{w: w, gcm: gcm, prefix: header.NoncePrefix, header: headerJSON}, nil
+}
+
+func (b *backupWriter) Write(p []byte) (int, error) {
+	if b.closed {
+		return 0, errors.New("write to closed backup")
+	}
+
+	b.buf = append(b.buf, p...)
+	// keep a full chunk back, it may turn out to be the last one
+	for len(b.buf) > backupChunkSize {
+		if err := b.seal(b.buf[:backupChunkSize], false); err != nil {
+			return 0, err
+		}
+		b.buf = append(b.buf[:0], b.buf[backupChunkSize:]...)
+	}
+	return len(p), nil
+}
+
+func (b *backupWriter) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	return b.seal(b.buf, true)
+}
+
+func (b *backupWriter) seal(chunk []byte, last bool) error {
+	if b.counter == ^uint32(0) {
+		return errors.New("backup too large")
+	}
+
+	sealed := b.gcm.Seal(nil, backupNonce(b.prefix, b.counter, last), chunk, b.header)
+	b.counter++
+
+	out := binary.BigEndian.AppendUint32(nil, uint32(len(sealed)))
+	_, err := b.w.Write(append(out, sealed...))
+	return err
+}
+
+func backupNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := append([]byte{}, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+type backupReader struct {
+	r       io.Reader
+	gcm     cipher.AEAD
+	prefix  []byte
+	header  []byte
+	counter uint32
+	buf     []byte
+	done    bool
+}
+
+// NewBackupReader decrypts a backup written by NewBackupWriter, unwrapping
+// its data key with encrypter. Reads fail with ErrBackupCorrupt when the
+// backup was tampered with or cut short.
+func NewBackupReader(ctx context.Context, r io.Reader, encrypter KeyEncrypter) (io.Reader, BackupHeader, error) {
+	header := BackupHeader{}
+
+	head := make([]byte, len(backupMagic)+4)
+	if _, err := io.ReadFull(r, head); err != nil || !bytes.Equal(head[:len(backupMagic)], backupMagic) {
+		return nil, header, ErrBackupCorrupt
+	}
+	size := binary.BigEndian.Uint32(head[len(backupMagic):])
+	if size > backupMaxHeader {
+		return nil, header, ErrBackupCorrupt
+	}
+	headerJSON := make([]byte, size)
+	if _, err := io.ReadFull(r, headerJSON); err != nil {
+		return nil, header, ErrBackupCorrupt
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil || len(header.NoncePrefix) != backupNoncePrefix {
+		return nil, header, ErrBackupCorrupt
+	}
+
+	dataKey, err := encrypter.Unwrap(ctx, header.KeyID, header.WrappedKey)
+	if err != nil {
+		return nil, header, fmt.Errorf("failed to unwrap backup key %q: %v", header.KeyID, err)
+	}
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, header, ErrBackupCorrupt
+	}
+
+	return &backupReader{r: r, gcm: gcm, prefix: header.NoncePrefix, header: headerJSON}, header, nil
+}
+
+func (b *backupReader) Read(p []byte) (int, error) {
+	for len(b.buf) == 0 {
+		if b.done {
+			return 0, io.EOF
+		}
+		if err := b.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, b.buf)
+	b.buf = b.buf[n:]
+	return n, nil
+}
+
+func (b *backupReader) open() error {
+	length := make([]byte, 4)
+	if _, err := io.ReadFull(b.r, length); err != nil {
+		return ErrBackupCorrupt
+	}
+	size := binary.BigEndian.Uint32(length)
+	if size > backupChunkSize+uint32(b.gcm.Overhead()) {
+		return ErrBackupCorrupt
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(b.r, sealed); err != nil {
+		return ErrBackupCorrupt
+	}
+
+	chunk, err := b.gcm.Open(nil, backupNonce(b.prefix, b.counter, false), sealed, b.header)
+	if err != nil {
+		if chunk, err = b.gcm.Open(nil, backupNonce(b.prefix, b.counter, true), sealed, b.header); err != nil {
+			return ErrBackupCorrupt
+		}
+		// nothing may follow the last chunk
+		if n, _ := b.r.Read(make([]byte, 1)); n > 0 {
+			return ErrBackupCorrupt
+		}
+		b.done = true
+	}
+	b.counter++
+	b.buf = chunk
+	return nil
+}
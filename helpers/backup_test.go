@@ -0,0 +1,96 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func newTestKeyEncrypter(t *testing.T, id string) *LocalKeyEncrypter {
+	t.Helper()
+	key := make([]byte, 32)
+	rand.Read(key)
+	encrypter, err := NewLocalKeyEncrypter(id+":"+base64.StdEncoding.EncodeToString(key), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encrypter
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	encrypter := newTestKeyEncrypter(t, "backup-1")
+
+	for _, size := range []int{0, 10, backupChunkSize, 3*backupChunkSize + 17} {
+		plaintext := make([]byte, size)
+		rand.Read(plaintext)
+
+		encrypted := bytes.Buffer{}
+		w, err := NewBackupWriter(ctx, &encrypted, encrypter, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(plaintext); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r, header, err := NewBackupReader(ctx, bytes.NewReader(encrypted.Bytes()), encrypter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.KeyID != "backup-1" {
+			t.Errorf("got key id %q, want backup-1", header.KeyID)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("size %d: got different plaintext back", size)
+		}
+	}
+}
+
+func TestBackupDetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	encrypter := newTestKeyEncrypter(t, "backup-1")
+
+	encrypted := bytes.Buffer{}
+	w, err := NewBackupWriter(ctx, &encrypted, encrypter, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(bytes.Repeat([]byte("row\n"), backupChunkSize))
+	w.Close()
+	backup := encrypted.Bytes()
+
+	flipped := append([]byte{}, backup...)
+	flipped[len(flipped)-1] ^= 1
+
+	tests := map[string][]byte{
+		"flipped bit":   flipped,
+		"truncated":     backup[:len(backup)-backupChunkSize/2],
+		"trailing data": append(append([]byte{}, backup...), 0),
+	}
+	for name, data := range tests {
+		r, _, err := NewBackupReader(ctx, bytes.NewReader(data), encrypter)
+		if err == nil {
+			_, err = io.ReadAll(r)
+		}
+		if !errors.Is(err, ErrBackupCorrupt) {
+			t.Errorf("%s: got %v, want ErrBackupCorrupt", name, err)
+		}
+	}
+
+	if _, _, err := NewBackupReader(ctx, bytes.NewReader(backup), newTestKeyEncrypter(t, "other")); err == nil {
+		t.Error("expected an error without the master key")
+	}
+}
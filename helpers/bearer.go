@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BearerToken returns the token of an Authorization header or metadata value
+// in the Bearer scheme of RFC 6750, whose name is case-insensitive. With
+// allowRaw a value without any scheme is taken as the token itself, as sent
+// by clients that predate the scheme.
+func BearerToken(authorization string, allowRaw bool) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !found {
+		if !allowRaw || scheme == "" || strings.EqualFold(scheme, "Bearer") {
+			return "", false
+		}
+		return scheme, true
+	}
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimLeft(token, " ")
+	if token == "" || strings.ContainsAny(token, " \t") {
+		return "", false
+	}
+	return token, true
+}
+
+// GetBearerToken returns the token the auth middleware accepted.
+func GetBearerToken(c *gin.Context) (string, error) {
+	token := c.GetString("bearer_token")
+	if token == "" {
+		return "", fmt.Errorf("failed to get bearer token in context")
+	}
+	return token, nil
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import "testing"
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		allowRaw      bool
+		want          string
+		wantOK        bool
+	}{
+		{name: "bearer", authorization: "Bearer abc.def.ghi", want: "abc.def.ghi", wantOK: true},
+		{name: "scheme is case-insensitive", authorization: "bearer abc", want: "abc", wantOK: true},
+		{name: "extra spaces", authorization: "  Bearer   abc ", want: "abc", wantOK: true},
+		{name: "raw token allowed", authorization: "abc.def.ghi", allowRaw: true, want: "abc.def.ghi", wantOK: true},
+		{name: "raw token refused", authorization: "abc.def.ghi"},
+		{name: "bearer with raw allowed", authorization: "Bearer abc", allowRaw: true, want: "abc", wantOK: true},
+		{name: "other scheme", authorization: "Basic dXNlcjpwYXNz", allowRaw: true},
+		{name: "no token", authorization: "Bearer ", allowRaw: true},
+		{name: "two tokens", authorization: "Bearer abc def"},
+		{name: "empty", authorization: "", allowRaw: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := BearerToken(tt.authorization, tt.allowRaw)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("got %q, %t, want %q, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ClaimsEnricher adds deployment-specific claims (tenant, KYC tier, feature
+// entitlements) to tokens as they are issued. The returned claims are merged
+// into the token's "ext" claim, so they can't clash with the standard ones.
+type ClaimsEnricher interface {
+	EnrichClaims(ctx context.Context, claims *ClaimToken) (map[string]any, error)
+}
+
+// ClaimsEnricherFunc adapts a plain function to ClaimsEnricher.
+type ClaimsEnricherFunc func(ctx context.Context, claims *ClaimToken) (map[string]any, error)
+
+func (f ClaimsEnricherFunc) EnrichClaims(ctx context.Context, claims *ClaimToken) (map[string]any, error) {
+	return f(ctx, claims)
+}
+
+var claimsEnrichers []ClaimsEnricher
+
+// RegisterClaimsEnricher adds e to the enrichers run, in registration order,
+// on every token issued at login or refresh. Register at startup, before any
+// token is generated; a later enricher overrides earlier ones on the same key.
+func RegisterClaimsEnricher(e ClaimsEnricher) {
+	claimsEnrichers = append(claimsEnrichers, e)
+}
+
+// SetupClaimsEnrichers registers the enrichers that only need config, e.g.
+// TOKEN_STATIC_CLAIMS="tenant=acme,region=id".
+func SetupClaimsEnrichers() {
+	static, err := ParseStaticClaims(GetEnv("TOKEN_STATIC_CLAIMS", ""))
+	if err != nil {
+		log.Fatal("failed to load static token claims: ", err)
+	}
+	if len(static) > 0 {
+		RegisterClaimsEnricher(StaticClaimsEnricher(static))
+	}
+}
+
+// StaticClaimsEnricher adds the same claims to every token.
+type StaticClaimsEnricher map[string]any
+
+func (e StaticClaimsEnricher) EnrichClaims(ctx context.Context, claims *ClaimToken) (map[string]any, error) {
+	return e, nil
+}
+
+// ParseStaticClaims parses "key=value,key=value" into string claims.
+func ParseStaticClaims(value string) (map[string]any, error) {
+	claims := map[string]any{}
+	if strings.TrimSpace(value) == "" {
+		return claims, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid static claim entry %q", entry)
+		}
+		claims[key] = val
+	}
+	return claims, nil
+}
+
+func enrichClaims(ctx context.Context, claimToken *ClaimToken) error {
+	for _, enricher := range claimsEnrichers {
+		extra, err := enricher.EnrichClaims(ctx, claimToken)
+		if err != nil {
+			return fmt.Errorf("failed to enrich claims: %v", err)
+		}
+		if len(extra) > 0 && claimToken.Ext == nil {
+			claimToken.Ext = map[string]any{}
+		}
+		for key, val := range extra {
+			claimToken.Ext[key] = val
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClaimsEnricher(t *testing.T) {
+	ctx := context.Background()
+	t.Cleanup(func() { claimsEnrichers = nil })
+
+	RegisterClaimsEnricher(StaticClaimsEnricher{"tenant": "acme", "tier": "basic"})
+	RegisterClaimsEnricher(ClaimsEnricherFunc(func(ctx context.Context, claims *ClaimToken) (map[string]any, error) {
+		if claims.UserID == 2 {
+			return map[string]any{"tier": "premium"}, nil
+		}
+		return nil, nil
+	}))
+
+	tests := []struct {
+		userID int
+		tier   string
+	}{
+		{userID: 1, tier: "basic"},
+		{userID: 2, tier: "premium"},
+	}
+
+	for _, tt := range tests {
+		token, err := GenerateToken(ctx, tt.userID, "user", "User", "token", "user@example.com", time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		claim, err := ValidateToken(ctx, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if claim.Ext["tenant"] != "acme" || claim.Ext["tier"] != tt.tier {
+			t.Errorf("user %d: unexpected ext claims %v", tt.userID, claim.Ext)
+		}
+	}
+
+	RegisterClaimsEnricher(ClaimsEnricherFunc(func(ctx context.Context, claims *ClaimToken) (map[string]any, error) {
+		return nil, errors.New("entitlements unavailable")
+	}))
+	if _, err := GenerateToken(ctx, 1, "user", "User", "token", "user@example.com", time.Now()); err == nil {
+		t.Error("expected token generation to fail when an enricher fails")
+	}
+}
+
+func TestParseStaticClaims(t *testing.T) {
+	claims, err := ParseStaticClaims("tenant=acme, region=id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims["tenant"] != "acme" || claims["region"] != "id" {
+		t.Errorf("unexpected claims %v", claims)
+	}
+
+	for _, value := range []string{"tenant", "=acme"} {
+		if _, err := ParseStaticClaims(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
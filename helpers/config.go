@@ -1,25 +1,153 @@
 package helpers
 
 import (
+	"fmt"
 	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/joho/godotenv"
 )
 
-var Env = map[string]string{}
+// Env is written directly only before the servers start, afterwards it is
+// swapped by ReloadConfig under envMu.
+var (
+	Env   = map[string]string{}
+	envMu sync.RWMutex
+)
+
+const (
+	// ConfigFile holds the defaults every environment starts from.
+	ConfigFile = ".env"
+	// ConfigProfileDir holds the per-environment profiles, e.g.
+	// config/production.env, picked by APP_ENV unless --config names one.
+	ConfigProfileDir = "config"
+)
 
-func SetupConfig() {
-	var err error
-	Env, err = godotenv.Read(".env")
+// configLayers are the files loadConfig merges, later ones win.
+var configLayers = []string{ConfigFile}
+
+// SetupConfig layers the defaults, an environment profile and the
+// environment variables, later layers winning. profile is the --config file,
+// empty picks config/$APP_ENV.env when it exists.
+func SetupConfig(profile string) {
+	defaults, err := godotenv.Read(ConfigFile)
 	if err != nil {
 		log.Fatal("failed to read env file: ", err)
 	}
+
+	if profile == "" {
+		appEnv, ok := os.LookupEnv("APP_ENV")
+		if !ok {
+			appEnv = defaults["APP_ENV"]
+		}
+		if appEnv != "" {
+			candidate := filepath.Join(ConfigProfileDir, appEnv+".env")
+			if _, err := os.Stat(candidate); err == nil {
+				profile = candidate
+			}
+		}
+	}
+
+	configLayers = []string{ConfigFile}
+	if profile != "" {
+		configLayers = append(configLayers, profile)
+	}
+
+	env, err := loadConfig()
+	if err != nil {
+		log.Fatal("failed to load config: ", err)
+	}
+	if err := ValidateConfig(env); err != nil {
+		log.Fatal("invalid config: ", err)
+	}
+	Env = env
+}
+
+// ParseConfigFlag takes --config <file> or --config=<file> out of args, it
+// applies to every command.
+func ParseConfigFlag(args []string) (string, []string) {
+	profile := ""
+	rest := []string{}
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--config" && i+1 < len(args):
+			profile = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--config="):
+			profile = strings.TrimPrefix(args[i], "--config=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return profile, rest
+}
+
+func loadConfig() (map[string]string, error) {
+	env := map[string]string{}
+	for _, file := range configLayers {
+		layer, err := godotenv.Read(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", file, err)
+		}
+		maps.Copy(env, layer)
+	}
+
+	// only known keys, the environment holds plenty of unrelated variables
+	for key := range ConfigSchema {
+		if val, ok := os.LookupEnv(key); ok {
+			env[key] = val
+		}
+	}
+	return env, nil
+}
+
+func sortedKeys(env map[string]string) []string {
+	return slices.Sorted(maps.Keys(env))
+}
+
+func envValue(key string) string {
+	envMu.RLock()
+	defer envMu.RUnlock()
+	return Env[key]
 }
 
 func GetEnv(key string, val string) string {
-	result := Env[key]
+	result := envValue(key)
 	if result == "" {
 		result = val
 	}
 	return result
 }
+
+func GetEnvInt(key string, val int) int {
+	result, err := strconv.Atoi(envValue(key))
+	if err != nil {
+		return val
+	}
+	return result
+}
+
+func GetEnvBool(key string, val bool) bool {
+	result, err := strconv.ParseBool(envValue(key))
+	if err != nil {
+		return val
+	}
+	return result
+}
+
+// GetEnvList splits a comma separated value, dropping empty entries.
+func GetEnvList(key string) []string {
+	list := []string{}
+	for _, item := range strings.Split(envValue(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,178 @@
+package helpers
+
+import (
+	"context"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ReloadableConfig lists the settings ReloadConfig applies without a
+// restart. They are non-secret tunables read on use or through a
+// Reloadable. Changes to any other key are only reported.
+var ReloadableConfig = []string{
+	"AUTH_STATELESS_VALIDATION",
+	"ACCESS_TOKEN_TTL_SECONDS",
+	"REFRESH_TOKEN_TTL_SECONDS",
+	"GRPC_CALLER_RATE_LIMIT",
+	"GRPC_CALLER_RATE_WINDOW_SECONDS",
+	"GRPC_CALLER_FAILURE_LIMIT",
+	"GRPC_CALLER_FAILURE_WINDOW_SECONDS",
+	"GRPC_CALLER_BAN_SECONDS",
+	"EXPERIMENTS",
+}
+
+var (
+	reloadMu  sync.Mutex
+	onReloads []func()
+)
+
+// ConfigChange is a reloadable setting changed by ReloadConfig.
+type ConfigChange struct {
+	Key string `json:"key"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// ReloadConfig re-reads the config layers and applies the changed
+// reloadable settings, then rebuilds every Reloadable. It returns the
+// applied changes and the keys that changed but need a restart. An invalid
+// config is rejected as a whole.
+func ReloadConfig() ([]ConfigChange, []string, error) {
+	fresh, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ValidateConfig(fresh); err != nil {
+		return nil, nil, err
+	}
+
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	envMu.Lock()
+	changes := []ConfigChange{}
+	ignored := []string{}
+	next := maps.Clone(Env)
+	for _, key := range unionKeys(Env, fresh) {
+		if Env[key] == fresh[key] {
+			continue
+		}
+		if !slices.Contains(ReloadableConfig, key) {
+			ignored = append(ignored, key)
+			continue
+		}
+		changes = append(changes, ConfigChange{Key: key, Old: Env[key], New: fresh[key]})
+		if val, ok := fresh[key]; ok {
+			next[key] = val
+		} else {
+			delete(next, key)
+		}
+	}
+	Env = next
+	envMu.Unlock()
+
+	if len(changes) > 0 {
+		for _, fn := range onReloads {
+			fn()
+		}
+	}
+	return changes, ignored, nil
+}
+
+func unionKeys(a, b map[string]string) []string {
+	union := maps.Clone(a)
+	maps.Copy(union, b)
+	return sortedKeys(union)
+}
+
+// Reloadable holds a value built from the config, e.g. a struct of rate
+// limits copied into a handler, and rebuilds it on every reload that
+// changes a setting.
+type Reloadable[T any] struct {
+	value atomic.Pointer[T]
+}
+
+func NewReloadable[T any](load func() T) *Reloadable[T] {
+	r := &Reloadable[T]{}
+	value := load()
+	r.value.Store(&value)
+
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	onReloads = append(onReloads, func() {
+		value := load()
+		r.value.Store(&value)
+	})
+	return r
+}
+
+// StaticReloadable never changes, for tests and callers without a config.
+func StaticReloadable[T any](value T) *Reloadable[T] {
+	r := &Reloadable[T]{}
+	r.value.Store(&value)
+	return r
+}
+
+func (r *Reloadable[T]) Get() T {
+	return *r.value.Load()
+}
+
+// WatchConfig reloads the config when a config file changes, checked every
+// interval, and on SIGHUP. Every reload that applies a change logs a config
+// changed entry and counts in the config_reloads_total metric.
+func WatchConfig(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	modTime := configModTime()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		case <-ticker.C:
+			latest := configModTime()
+			if latest.Equal(modTime) {
+				continue
+			}
+			modTime = latest
+		}
+
+		changes, ignored, err := ReloadConfig()
+		if err != nil {
+			Logger.Error("failed to reload config: ", err)
+			ConfigReloads.WithLabelValues("failed").Inc()
+			continue
+		}
+		if len(ignored) > 0 {
+			Logger.WithField("keys", ignored).Warn("config changed for settings that need a restart")
+		}
+		if len(changes) > 0 {
+			Logger.WithFields(logrus.Fields{"event": "config_changed", "changes": changes}).Info("config changed")
+			ConfigReloads.WithLabelValues("applied").Inc()
+		}
+	}
+}
+
+// configModTime is the latest change to any config layer.
+func configModTime() time.Time {
+	latest := time.Time{}
+	for _, file := range configLayers {
+		if info, err := os.Stat(file); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
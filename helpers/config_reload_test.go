@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReloadConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	saved := Env
+	t.Cleanup(func() { Env = saved })
+
+	Env = map[string]string{"APP_SECRET": "old", "GRPC_CALLER_RATE_LIMIT": "10"}
+	limit := NewReloadable(func() int { return GetEnvInt("GRPC_CALLER_RATE_LIMIT", 0) })
+
+	config := "APP_SECRET=new\nGRPC_CALLER_RATE_LIMIT=20\nACCESS_TOKEN_TTL_SECONDS=600\n"
+	if err := os.WriteFile(ConfigFile, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, ignored, err := ReloadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Key != "ACCESS_TOKEN_TTL_SECONDS" || changes[1] != (ConfigChange{Key: "GRPC_CALLER_RATE_LIMIT", Old: "10", New: "20"}) {
+		t.Errorf("got changes %v", changes)
+	}
+	if len(ignored) != 1 || ignored[0] != "APP_SECRET" {
+		t.Errorf("got ignored %v, want APP_SECRET", ignored)
+	}
+
+	if got := GetEnv("APP_SECRET", ""); got != "old" {
+		t.Errorf("got APP_SECRET %q, secrets need a restart", got)
+	}
+	if got := limit.Get(); got != 20 {
+		t.Errorf("got reloaded limit %d, want 20", got)
+	}
+	if got := (TokenPolicy{}).TTLFor("token").Seconds(); got != 600 {
+		t.Errorf("got token ttl %v, want 600", got)
+	}
+}
@@ -0,0 +1,268 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type ConfigKind int
+
+const (
+	ConfigString ConfigKind = iota
+	ConfigInt
+	ConfigBool
+	// ConfigList is a comma separated list, see GetEnvList.
+	ConfigList
+)
+
+// ConfigSchema lists every setting the service reads. Config files may only
+// set these keys, and they are the only environment variables read.
+var ConfigSchema = map[string]ConfigKind{
+	"ACCESS_TOKEN_TTL_SECONDS":                    ConfigInt,
+	"ACCOUNT_DELETION_BATCH_SIZE":                 ConfigInt,
+	"ACCOUNT_DELETION_INTERVAL_SECONDS":           ConfigInt,
+	"ACCOUNT_DELETION_WINDOW_DAYS":                ConfigInt,
+	"ACTIVITY_DIGEST_BATCH_SIZE":                  ConfigInt,
+	"ACTIVITY_DIGEST_INTERVAL_SECONDS":            ConfigInt,
+	"ADDRESS_MAX_PER_USER":                        ConfigInt,
+	"ADMIN_TICKET_REF_REQUIRED":                   ConfigBool,
+	"ALERT_SLACK_WEBHOOK_URL":                     ConfigString,
+	"ALERT_WEBHOOK_URL":                           ConfigString,
+	"ANONYMIZATION_BATCH_SIZE":                    ConfigInt,
+	"ANONYMIZATION_GRACE_DAYS":                    ConfigInt,
+	"ANONYMIZATION_INTERVAL_SECONDS":              ConfigInt,
+	"APP_BASE_URL":                                ConfigString,
+	"APP_ENV":                                     ConfigString,
+	"APP_NAME":                                    ConfigString,
+	"APP_SECRET":                                  ConfigString,
+	"AUDIT_EXPORT_MAX_ROWS":                       ConfigInt,
+	"AUTH_ALLOW_RAW_TOKEN":                        ConfigBool,
+	"AUTH_STATELESS_VALIDATION":                   ConfigBool,
+	"BACKUP_ACTIVE_MASTER_KEY":                    ConfigString,
+	"BACKUP_MASTER_KEYS":                          ConfigString,
+	"BACKUP_OBJECT_PREFIX":                        ConfigString,
+	"BCRYPT_MAX_CONCURRENCY":                      ConfigInt,
+	"CONFIG_RELOAD_INTERVAL_SECONDS":              ConfigInt,
+	"DB_AUTO_MIGRATE":                             ConfigBool,
+	"DB_CONNECT_ATTEMPTS":                         ConfigInt,
+	"DB_CONNECT_BACKOFF_MS":                       ConfigInt,
+	"DB_DRIVER":                                   ConfigString,
+	"DB_HOST":                                     ConfigString,
+	"DB_NAME":                                     ConfigString,
+	"DB_PASSWORD":                                 ConfigString,
+	"DB_PORT":                                     ConfigString,
+	"DB_QUERY_RETRY_ATTEMPTS":                     ConfigInt,
+	"DB_QUERY_RETRY_BACKOFF_MS":                   ConfigInt,
+	"DB_REPEATED_QUERY_THRESHOLD":                 ConfigInt,
+	"DB_SLOW_QUERY_MS":                            ConfigInt,
+	"DB_SQLITE_PATH":                              ConfigString,
+	"DB_USER":                                     ConfigString,
+	"EMAIL_CHANGE_REVERT_TTL_HOURS":               ConfigInt,
+	"EMAIL_CHANGE_REVERT_URL":                     ConfigString,
+	"ENTITLEMENTS_BY_KYC_STATUS":                  ConfigString,
+	"EXPERIMENTS":                                 ConfigString,
+	"FAKE_WALLET_FAILURE_PERCENT":                 ConfigInt,
+	"FAKE_WALLET_LATENCY_MS":                      ConfigInt,
+	"GEOIP_ACCOUNT_ID":                            ConfigString,
+	"GEOIP_BASE_URL":                              ConfigString,
+	"GEOIP_CACHE_SIZE":                            ConfigInt,
+	"GEOIP_CACHE_TTL_SECONDS":                     ConfigInt,
+	"GEOIP_LICENSE_KEY":                           ConfigString,
+	"GRPC_ADMIN_CLIENT_NAMES":                     ConfigList,
+	"GRPC_CALLER_AUTH_ENFORCE":                    ConfigBool,
+	"GRPC_CALLER_BAN_SECONDS":                     ConfigInt,
+	"GRPC_CALLER_FAILURE_LIMIT":                   ConfigInt,
+	"GRPC_CALLER_FAILURE_WINDOW_SECONDS":          ConfigInt,
+	"GRPC_CALLER_NAMES":                           ConfigList,
+	"GRPC_CALLER_RATE_LIMIT":                      ConfigInt,
+	"GRPC_CALLER_RATE_WINDOW_SECONDS":             ConfigInt,
+	"GRPC_KEEPALIVE_MIN_TIME_SECONDS":             ConfigInt,
+	"GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM":        ConfigBool,
+	"GRPC_KEEPALIVE_TIMEOUT_SECONDS":              ConfigInt,
+	"GRPC_KEEPALIVE_TIME_SECONDS":                 ConfigInt,
+	"GRPC_MAX_CONCURRENT_STREAMS":                 ConfigInt,
+	"GRPC_MAX_CONNECTIONS":                        ConfigInt,
+	"GRPC_MAX_CONNECTION_AGE_GRACE_SECONDS":       ConfigInt,
+	"GRPC_MAX_CONNECTION_AGE_SECONDS":             ConfigInt,
+	"GRPC_MAX_CONNECTION_IDLE_SECONDS":            ConfigInt,
+	"GRPC_MAX_RECV_MSG_SIZE_BYTES":                ConfigInt,
+	"GRPC_MAX_SEND_MSG_SIZE_BYTES":                ConfigInt,
+	"GRPC_PORT":                                   ConfigString,
+	"GRPC_TLS_CERT_FILE":                          ConfigString,
+	"GRPC_TLS_CLIENT_CA_FILE":                     ConfigString,
+	"GRPC_TLS_KEY_FILE":                           ConfigString,
+	"HEALTHCHECK_TIMEOUT_MS":                      ConfigInt,
+	"HTTP_ADMIN_MAX_INFLIGHT":                     ConfigInt,
+	"HTTP_ADMIN_TIMEOUT_MS":                       ConfigInt,
+	"HTTP_CLIENT_DIAL_TIMEOUT_SECONDS":            ConfigInt,
+	"HTTP_CLIENT_IDLE_CONN_TIMEOUT_SECONDS":       ConfigInt,
+	"HTTP_CLIENT_KEEP_ALIVE_SECONDS":              ConfigInt,
+	"HTTP_CLIENT_MAX_CONNS_PER_HOST":              ConfigInt,
+	"HTTP_CLIENT_MAX_IDLE_CONNS":                  ConfigInt,
+	"HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST":         ConfigInt,
+	"HTTP_CLIENT_PROXY_URL":                       ConfigString,
+	"HTTP_CLIENT_RESPONSE_HEADER_TIMEOUT_SECONDS": ConfigInt,
+	"HTTP_CLIENT_TIMEOUT_SECONDS":                 ConfigInt,
+	"HTTP_CLIENT_TLS_HANDSHAKE_TIMEOUT_SECONDS":   ConfigInt,
+	"HTTP_LOGIN_MAX_INFLIGHT":                     ConfigInt,
+	"HTTP_LOGIN_TIMEOUT_MS":                       ConfigInt,
+	"HTTP_MAX_BODY_BYTES":                         ConfigInt,
+	"HTTP_MAX_INFLIGHT":                           ConfigInt,
+	"HTTP_MAX_JSON_DEPTH":                         ConfigInt,
+	"HTTP_OAUTH_MAX_INFLIGHT":                     ConfigInt,
+	"HTTP_OAUTH_TIMEOUT_MS":                       ConfigInt,
+	"HTTP_REGISTER_MAX_INFLIGHT":                  ConfigInt,
+	"HTTP_SHED_RETRY_AFTER_SECONDS":               ConfigInt,
+	"HTTP_SIGNING_KEY_ID":                         ConfigString,
+	"HTTP_SIGNING_SECRET":                         ConfigString,
+	"HTTP_TIMEOUT_MS":                             ConfigInt,
+	"HTTP_TOKEN_VALIDATION_MAX_INFLIGHT":          ConfigInt,
+	"HTTP_TOKEN_VALIDATION_TIMEOUT_MS":            ConfigInt,
+	"INBOX_CONSUMER_GROUP":                        ConfigString,
+	"INBOX_MAX_DELIVERIES":                        ConfigInt,
+	"INBOX_RECLAIM_IDLE_SECONDS":                  ConfigInt,
+	"INTERNAL_SIGNING_KEYS":                       ConfigString,
+	"INTERNAL_SIGNING_MAX_SKEW_SECONDS":           ConfigInt,
+	"JOB_LOCK_BACKEND":                            ConfigString,
+	"JOB_LOCK_TTL_SECONDS":                        ConfigInt,
+	"JWE_AUDIENCES":                               ConfigString,
+	"JWE_KEY":                                     ConfigString,
+	"KYC_REQUIRED_FIELDS":                         ConfigString,
+	"LOGIN_ASN_HEADER":                            ConfigString,
+	"LOGIN_COUNTRY_HEADER":                        ConfigString,
+	"LOGIN_VELOCITY_ASN_THRESHOLD":                ConfigInt,
+	"LOGIN_VELOCITY_COUNTRY_THRESHOLD":            ConfigInt,
+	"LOGIN_VELOCITY_IP_THRESHOLD":                 ConfigInt,
+	"LOGIN_VELOCITY_WINDOW_SECONDS":               ConfigInt,
+	"LOG_LEVEL":                                   ConfigString,
+	"LOG_SAMPLE_FIRST":                            ConfigInt,
+	"LOG_SAMPLE_THEREAFTER":                       ConfigInt,
+	"MAIL_BRAND_NAME":                             ConfigString,
+	"MAIL_BRAND_TEMPLATES_DIR":                    ConfigString,
+	"MAIL_DEFAULT_LOCALE":                         ConfigString,
+	"MAIL_FROM":                                   ConfigString,
+	"MAIL_SUPPORT_URL":                            ConfigString,
+	"NOTIFICATION_SUMMARY_BATCH_SIZE":             ConfigInt,
+	"NOTIFICATION_SUMMARY_INTERVAL_SECONDS":       ConfigInt,
+	"NOTIFICATION_THROTTLE_WINDOW_SECONDS":        ConfigInt,
+	"OAUTH_CLIENTS":                               ConfigString,
+	"OBJECT_STORAGE_ACCESS_KEY_ID":                ConfigString,
+	"OBJECT_STORAGE_BUCKET":                       ConfigString,
+	"OBJECT_STORAGE_ENDPOINT":                     ConfigString,
+	"OBJECT_STORAGE_REGION":                       ConfigString,
+	"OBJECT_STORAGE_SECRET_ACCESS_KEY":            ConfigString,
+	"OBJECT_STORAGE_TIMEOUT_SECONDS":              ConfigInt,
+	"OTP_MAX_ATTEMPTS":                            ConfigInt,
+	"OTP_RESEND_INTERVAL_SECONDS":                 ConfigInt,
+	"OTP_TTL_SECONDS":                             ConfigInt,
+	"TRUSTED_DEVICE_TTL_DAYS":                     ConfigInt,
+	"PHONE_DEFAULT_COUNTRY_CODE":                  ConfigString,
+	"PII_ACTIVE_MASTER_KEY":                       ConfigString,
+	"PII_LOOKUP_KEY":                              ConfigString,
+	"PII_MASTER_KEYS":                             ConfigString,
+	"PORT":                                        ConfigString,
+	"RECOVERY_EVIDENCE_MAX_BYTES":                 ConfigInt,
+	"RECOVERY_EVIDENCE_URL_TTL_SECONDS":           ConfigInt,
+	"RECOVERY_FAILURE_LIMIT":                      ConfigInt,
+	"RECOVERY_FAILURE_WINDOW_SECONDS":             ConfigInt,
+	"RECOVERY_LOCK_SECONDS":                       ConfigInt,
+	"RECOVERY_NO_PHONE_FAILURE_LIMIT":             ConfigInt,
+	"RECOVERY_RESET_TTL_MINUTES":                  ConfigInt,
+	"RECOVERY_RESET_URL":                          ConfigString,
+	"RECOVERY_TICKET_LIMIT":                       ConfigInt,
+	"RECOVERY_TICKET_WINDOW_SECONDS":              ConfigInt,
+	"REDIS_DB":                                    ConfigInt,
+	"REDIS_HOST":                                  ConfigString,
+	"REDIS_PASSWORD":                              ConfigString,
+	"REDIS_PORT":                                  ConfigString,
+	"REFRESH_TOKEN_TTL_SECONDS":                   ConfigInt,
+	"REPLAY_PROTECTION_REQUIRED":                  ConfigBool,
+	"REPLAY_WINDOW_SECONDS":                       ConfigInt,
+	"REQUEST_SIGNING_SECRET":                      ConfigString,
+	"RETENTION_BATCH_SIZE":                        ConfigInt,
+	"RETENTION_DRY_RUN":                           ConfigBool,
+	"RETENTION_INTERVAL_SECONDS":                  ConfigInt,
+	"RETENTION_LOGIN_HISTORY_DAYS":                ConfigInt,
+	"RETENTION_SESSION_DAYS":                      ConfigInt,
+	"SCREENING_BATCH_SIZE":                        ConfigInt,
+	"SCREENING_CADENCE_DAYS":                      ConfigInt,
+	"SCREENING_INTERVAL_SECONDS":                  ConfigInt,
+	"SCREENING_PROVIDER_API_KEY":                  ConfigString,
+	"SCREENING_PROVIDER_URL":                      ConfigString,
+	"SENTRY_DSN":                                  ConfigString,
+	"SENTRY_ENVIRONMENT":                          ConfigString,
+	"SENTRY_RELEASE":                              ConfigString,
+	"SERVICE_ACCOUNT_TOKEN_TTL_SECONDS":           ConfigInt,
+	"SESSION_CLEANUP_BATCH_SIZE":                  ConfigInt,
+	"SESSION_CLEANUP_INTERVAL_SECONDS":            ConfigInt,
+	"SIEM_API_KEY":                                ConfigString,
+	"SIEM_BATCH_SIZE":                             ConfigInt,
+	"SIEM_FLUSH_INTERVAL_MS":                      ConfigInt,
+	"SIEM_FORMAT":                                 ConfigString,
+	"SIEM_MAX_RETRIES":                            ConfigInt,
+	"SIEM_QUEUE_SIZE":                             ConfigInt,
+	"SIEM_URL":                                    ConfigString,
+	"SMS_GATEWAY_API_KEY":                         ConfigString,
+	"SMS_GATEWAY_URL":                             ConfigString,
+	"SMS_LOG_ONLY":                                ConfigBool,
+	"SMTP_HOST":                                   ConfigString,
+	"SMTP_PASSWORD":                               ConfigString,
+	"SMTP_PORT":                                   ConfigString,
+	"SMTP_USERNAME":                               ConfigString,
+	"STARTUP_REQUIRE_WALLET":                      ConfigBool,
+	"STATS_DAYS":                                  ConfigInt,
+	"STATS_REFRESH_INTERVAL_SECONDS":              ConfigInt,
+	"TOKEN_CACHE_SIZE":                            ConfigInt,
+	"TOKEN_CACHE_TTL_SECONDS":                     ConfigInt,
+	"TOKEN_EXCHANGE_POLICY":                       ConfigString,
+	"TOKEN_EXCHANGE_TTL_SECONDS":                  ConfigInt,
+	"TOKEN_STATIC_CLAIMS":                         ConfigString,
+	"USER_EXPORT_BATCH_SIZE":                      ConfigInt,
+	"USER_EXPORT_INTERVAL_SECONDS":                ConfigInt,
+	"USER_EXPORT_TIMEOUT_SECONDS":                 ConfigInt,
+	"USER_EXPORT_URL_TTL_SECONDS":                 ConfigInt,
+	"USER_IMPORT_MAX_BYTES":                       ConfigInt,
+	"USER_IMPORT_PROGRESS_EVERY":                  ConfigInt,
+	"USER_PURGE_BATCH_SIZE":                       ConfigInt,
+	"USER_PURGE_INTERVAL_SECONDS":                 ConfigInt,
+	"USER_QUOTA_USER_EXPORTS_PER_DAY":             ConfigInt,
+	"USER_QUOTA_USER_IMPORTS_PER_DAY":             ConfigInt,
+	"WALLET_ENDPOINT_CREATE":                      ConfigString,
+	"WALLET_ENDPOINT_HEALTH":                      ConfigString,
+	"WALLET_HOST":                                 ConfigString,
+	"WALLET_PROVISIONING_BATCH_SIZE":              ConfigInt,
+	"WALLET_PROVISIONING_INTERVAL_SECONDS":        ConfigInt,
+	"WALLET_PROVISIONING_MAX_ATTEMPTS":            ConfigInt,
+	"WALLET_PROVISIONING_SLA_SECONDS":             ConfigInt,
+	"WALLET_RECONCILIATION_INTERVAL_SECONDS":      ConfigInt,
+}
+
+// ValidateConfig reports unknown keys and values that don't parse as their
+// kind. Empty values are allowed, they fall back to the default.
+func ValidateConfig(env map[string]string) error {
+	errs := []error{}
+	for _, key := range sortedKeys(env) {
+		val := env[key]
+		kind, ok := ConfigSchema[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown config key %s", key))
+			continue
+		}
+		if val == "" {
+			continue
+		}
+
+		var err error
+		switch kind {
+		case ConfigInt:
+			_, err = strconv.Atoi(val)
+		case ConfigBool:
+			_, err = strconv.ParseBool(val)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q: %v", key, val, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetupConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	saved := Env
+	t.Cleanup(func() { Env = saved })
+
+	files := map[string]string{
+		ConfigFile: "APP_ENV=staging\nPORT=8080\nGRPC_PORT=7000\nTOKEN_CACHE_SIZE=10\n",
+		filepath.Join(ConfigProfileDir, "staging.env"): "PORT=9090\nTOKEN_CACHE_SIZE=20\n",
+	}
+	if err := os.Mkdir(ConfigProfileDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("TOKEN_CACHE_SIZE", "30")
+	t.Setenv("NOT_A_CONFIG_KEY", "ignored")
+
+	SetupConfig("")
+
+	want := map[string]string{"GRPC_PORT": "7000", "PORT": "9090", "TOKEN_CACHE_SIZE": "30", "NOT_A_CONFIG_KEY": ""}
+	for key, val := range want {
+		if got := Env[key]; got != val {
+			t.Errorf("got %s %q, want %q", key, got, val)
+		}
+	}
+}
+
+func TestParseConfigFlag(t *testing.T) {
+	profile, rest := ParseConfigFlag([]string{"serve", "--config", "config/production.env", "--with-fakes"})
+	if profile != "config/production.env" || len(rest) != 2 || rest[0] != "serve" || rest[1] != "--with-fakes" {
+		t.Errorf("got profile %q, rest %v", profile, rest)
+	}
+
+	profile, rest = ParseConfigFlag([]string{"seed", "--config=staging.env"})
+	if profile != "staging.env" || len(rest) != 1 {
+		t.Errorf("got profile %q, rest %v", profile, rest)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "valid", env: map[string]string{"PORT": "8080", "TOKEN_CACHE_SIZE": "100", "AUTH_STATELESS_VALIDATION": "true"}},
+		{name: "empty falls back to the default", env: map[string]string{"TOKEN_CACHE_SIZE": ""}},
+		{name: "unknown key", env: map[string]string{"TOKEN_CACHE_SIZ": "100"}, wantErr: true},
+		{name: "not an int", env: map[string]string{"TOKEN_CACHE_SIZE": "lots"}, wantErr: true},
+		{name: "not a bool", env: map[string]string{"AUTH_STATELESS_VALIDATION": "maybe"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateConfig(tt.env); (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
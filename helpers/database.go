@@ -1,29 +1,156 @@
 package helpers
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
+	"ewallet-ums/constants"
 	"ewallet-ums/internal/models"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
-func SetupMySQL() {
-	var err error
+// migratedModels are the tables AutoMigrate creates, before the expand
+// migrations run, and PendingMigrations checks.
+var migratedModels = []any{&models.User{}, &models.UserSession{}, &models.WalletProvisioning{}, &models.LoginHistory{}, &models.PIIDataKey{}, &models.AuditEvent{}, &models.UserRole{}, &models.AdminApproval{}, &models.LegalHold{}, &models.UserConsent{}, &models.Client{}, &models.ServiceAccount{}, &models.UserImport{}, &models.UserExport{}, &models.SecurityQuestion{}, &models.NotificationPreference{}, &models.ActivityDigest{}, &models.RefreshToken{}, &models.InboxEvent{}, &models.DailyStat{}, &models.RecoveryTicket{}, &models.NotificationQuietHours{}, &models.PendingNotification{}, &models.UserEntitlement{}, &models.EmailChangeRevert{}, &models.ReservedUsername{}, &models.DeadLetter{}, &models.UserAddress{}, &models.KycProfile{}, &models.UserScreening{}, &models.ScreeningReview{}, &models.UserPurge{}, &models.AccountNote{}, &models.TrustedDevice{}}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local", GetEnv("DB_USER", ""), GetEnv("DB_PASSWORD", ""), GetEnv("DB_HOST", "127.0.0.1"), GetEnv("DB_PORT", "3306"), GetEnv("DB_NAME", ""))
+// Database drivers selectable with DB_DRIVER.
+const (
+	DBDriverMySQL  = "mysql"
+	DBDriverSQLite = "sqlite"
+)
+
+// SQLiteInMemory as DB_SQLITE_PATH keeps the SQLite database in memory, for
+// unit tests and demos that need the repositories without a database file.
+// It lasts as long as the process.
+const SQLiteInMemory = ":memory:"
 
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+// SetupDatabase connects to the database picked by DB_DRIVER: MySQL, the
+// default and what production runs, or SQLite for local development without
+// a database server.
+func SetupDatabase() {
+	driver := GetEnv("DB_DRIVER", DBDriverMySQL)
+	sqlDB, err := openDatabase(driver)
 	if err != nil {
 		log.Fatal("failed to connect to database: ", err)
 	}
+	pool := &retryConnPool{
+		DB:       sqlDB,
+		Attempts: GetEnvInt("DB_QUERY_RETRY_ATTEMPTS", 3),
+		Backoff:  time.Duration(GetEnvInt("DB_QUERY_RETRY_BACKOFF_MS", 50)) * time.Millisecond,
+	}
+
+	// the database may still be starting or failing over, retry with backoff
+	// instead of crash looping
+	attempts := GetEnvInt("DB_CONNECT_ATTEMPTS", 10)
+	backoff := time.Duration(GetEnvInt("DB_CONNECT_BACKOFF_MS", 500)) * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		DB, err = gorm.Open(newDialector(driver, pool), &gorm.Config{})
+		if err == nil {
+			break
+		}
+		if attempt >= attempts {
+			log.Fatal("failed to connect to database: ", err)
+		}
+
+		logrus.Warnf("failed to connect to database (attempt %d of %d), retrying in %s: %v", attempt, attempts, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, 30*time.Second)
+	}
 
 	logrus.Info("Successfully connect to database")
 
-	DB.AutoMigrate(&models.User{}, &models.UserSession{})
+	queryMetrics := &QueryMetrics{SlowThreshold: time.Duration(GetEnvInt("DB_SLOW_QUERY_MS", 200)) * time.Millisecond}
+	if err := DB.Use(queryMetrics); err != nil {
+		log.Fatal("failed to register query metrics: ", err)
+	}
+	if err := DB.Use(&ActorColumns{}); err != nil {
+		log.Fatal("failed to register actor columns: ", err)
+	}
+
+	// with DB_AUTO_MIGRATE=false the schema is migrated out of band, the
+	// startup self-test then refuses to serve a schema that is behind.
+	// Contract migrations never run here, old pods may still be serving.
+	if GetEnvBool("DB_AUTO_MIGRATE", true) {
+		ran, err := MigrateSchema(DB, constants.MigrationPhaseExpand)
+		if err != nil {
+			logrus.Error("failed to migrate database: ", err)
+		}
+		for _, migration := range ran {
+			logrus.Infof("applied migration %d %s", migration.Version, migration.Name)
+		}
+	}
+}
+
+func openDatabase(driver string) (*sql.DB, error) {
+	switch driver {
+	case DBDriverMySQL:
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local", GetEnv("DB_USER", ""), GetEnv("DB_PASSWORD", ""), GetEnv("DB_HOST", "127.0.0.1"), GetEnv("DB_PORT", "3306"), GetEnv("DB_NAME", ""))
+		return sql.Open("mysql", dsn)
+	case DBDriverSQLite:
+		path := GetEnv("DB_SQLITE_PATH", "ewallet-ums.db")
+		if path == SQLiteInMemory {
+			// every connection would open an empty database of its own, so
+			// all queries share one connection that is never closed
+			sqlDB, err := sql.Open(sqlite.DriverName, "file::memory:?_foreign_keys=on")
+			if err != nil {
+				return nil, err
+			}
+			sqlDB.SetMaxOpenConns(1)
+			return sqlDB, nil
+		}
+
+		// WAL and a busy timeout let the workers write while requests are
+		// served, instead of failing with "database is locked"
+		dsn := path + "?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on"
+		return sql.Open(sqlite.DriverName, dsn)
+	default:
+		return nil, fmt.Errorf("unknown DB_DRIVER %q, expected %s or %s", driver, DBDriverMySQL, DBDriverSQLite)
+	}
+}
+
+func newDialector(driver string, pool gorm.ConnPool) gorm.Dialector {
+	if driver == DBDriverSQLite {
+		return sqlite.New(sqlite.Config{Conn: pool})
+	}
+	return mysql.New(mysql.Config{Conn: pool})
+}
+
+// IsSQLite reports whether db runs on SQLite, for the few statements whose
+// syntax differs from MySQL.
+func IsSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == DBDriverSQLite
+}
+
+// PendingMigrations lists the tables and columns the models expect but the
+// database lacks.
+func PendingMigrations() ([]string, error) {
+	migrator := DB.Migrator()
+	pending := []string{}
+
+	for _, model := range migratedModels {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model schema: %v", err)
+		}
+
+		if !migrator.HasTable(model) {
+			pending = append(pending, "table "+stmt.Schema.Table)
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !migrator.HasColumn(model, field.DBName) {
+				pending = append(pending, "column "+stmt.Schema.Table+"."+field.DBName)
+			}
+		}
+	}
+
+	return pending, nil
 }
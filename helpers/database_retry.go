@@ -0,0 +1,91 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var DBQueryRetries = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "db_query_retries_total",
+	Help: "Reads retried after a transient database error",
+})
+
+// mysql server errors seen while a server shuts down or fails over
+const (
+	mysqlErrServerShutdown   = 1053
+	mysqlErrConnectionKilled = 1927
+)
+
+// retryConnPool is the GORM connection pool. Reads outside a transaction
+// that fail on a transient error, e.g. a connection reset by a failover, are
+// retried on a fresh connection with backoff. Writes are never retried, they
+// may have been applied before the connection dropped. Transactions begin on
+// the embedded *sql.DB, so nothing inside one is retried either.
+type retryConnPool struct {
+	*sql.DB
+
+	Attempts int
+	Backoff  time.Duration
+}
+
+func (p *retryConnPool) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
+
+func (p *retryConnPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	rows, err := p.DB.QueryContext(ctx, query, args...)
+	if !isReadQuery(query) {
+		return rows, err
+	}
+
+	for attempt := 1; attempt < p.Attempts && isTransientDBError(err); attempt++ {
+		select {
+		case <-ctx.Done():
+			return rows, err
+		case <-time.After(p.Backoff << (attempt - 1)):
+		}
+
+		DBQueryRetries.Inc()
+		Logger.Warnf("retrying read after transient database error (attempt %d): %v", attempt+1, err)
+		rows, err = p.DB.QueryContext(ctx, query, args...)
+	}
+	return rows, err
+}
+
+func isReadQuery(query string) bool {
+	query = strings.TrimSpace(query)
+	return len(query) >= 6 && strings.EqualFold(query[:6], "SELECT") && !strings.Contains(strings.ToUpper(query), "FOR UPDATE")
+}
+
+// isTransientDBError reports whether err is a lost or refused connection
+// rather than a problem with the query itself.
+func isTransientDBError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrServerShutdown || mysqlErr.Number == mysqlErrConnectionKilled
+	}
+
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.As(err, &netErr)
+}
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestIsTransientDBError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: driver.ErrBadConn, want: true},
+		{err: mysql.ErrInvalidConn, want: true},
+		{err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{err: &mysql.MySQLError{Number: 1053, Message: "Server shutdown in progress"}, want: true},
+		{err: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, want: false},
+		{err: context.DeadlineExceeded, want: false},
+		{err: errors.New("record not found"), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isTransientDBError(tt.err); got != tt.want {
+			t.Errorf("isTransientDBError(%v) = %t, want %t", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestIsReadQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "SELECT * FROM `users` WHERE id = ?", want: true},
+		{query: "  select count(*) from `user_sessions`", want: true},
+		{query: "SELECT * FROM `wallet_provisionings` WHERE id = ? FOR UPDATE", want: false},
+		{query: "UPDATE `users` SET status = ?", want: false},
+		{query: "INSERT INTO `audit_events` (`action`) VALUES (?)", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isReadQuery(tt.query); got != tt.want {
+			t.Errorf("isReadQuery(%q) = %t, want %t", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package helpers
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func TestSetupDatabaseSQLite(t *testing.T) {
+	Logger = logrus.New()
+	Env = map[string]string{
+		"DB_DRIVER":      DBDriverSQLite,
+		"DB_SQLITE_PATH": filepath.Join(t.TempDir(), "test.db"),
+	}
+	t.Cleanup(func() { Env = map[string]string{} })
+
+	SetupDatabase()
+	if !IsSQLite(DB) {
+		t.Fatalf("expected the sqlite dialect, got %s", DB.Dialector.Name())
+	}
+
+	pending, err := PendingMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) > 0 {
+		t.Fatalf("expected every model migrated, still pending: %v", pending)
+	}
+}
+
+func TestSetupDatabaseInMemory(t *testing.T) {
+	Logger = logrus.New()
+	Env = map[string]string{
+		"DB_DRIVER":      DBDriverSQLite,
+		"DB_SQLITE_PATH": SQLiteInMemory,
+	}
+	t.Cleanup(func() { Env = map[string]string{} })
+
+	SetupDatabase()
+	if pending, err := PendingMigrations(); err != nil || len(pending) > 0 {
+		t.Fatalf("got pending %v, err %v, want every model migrated", pending, err)
+	}
+
+	// rows outlive the statement, and the transaction, that wrote them
+	session := models.UserSession{UserID: 1, Token: "token", RefreshToken: "refresh"}
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&session).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := models.UserSession{}
+	if err := DB.First(&got, session.ID).Error; err != nil || got.Token != "token" {
+		t.Fatalf("got session %+v, err %v, want the one created", got, err)
+	}
+}
+
+func TestActorColumns(t *testing.T) {
+	Logger = logrus.New()
+	Env = map[string]string{
+		"DB_DRIVER":      DBDriverSQLite,
+		"DB_SQLITE_PATH": filepath.Join(t.TempDir(), "test.db"),
+	}
+	t.Cleanup(func() { Env = map[string]string{} })
+	SetupDatabase()
+
+	ctx := ContextWithActor(context.Background(), "user:1")
+	session := models.UserSession{UserID: 1, Token: "token", RefreshToken: "refresh"}
+	if err := DB.WithContext(ctx).Create(&session).Error; err != nil {
+		t.Fatal(err)
+	}
+	if session.CreatedBy != "user:1" || session.UpdatedBy != "user:1" {
+		t.Fatalf("expected the actor on create, got created_by %q updated_by %q", session.CreatedBy, session.UpdatedBy)
+	}
+
+	ctx = ContextWithActor(context.Background(), "admin:2")
+	if err := DB.WithContext(ctx).Model(&models.UserSession{}).Where("id = ?", session.ID).Update("token", "rotated").Error; err != nil {
+		t.Fatal(err)
+	}
+	got := models.UserSession{}
+	if err := DB.First(&got, session.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got.CreatedBy != "user:1" || got.UpdatedBy != "admin:2" {
+		t.Fatalf("expected only updated_by to change, got created_by %q updated_by %q", got.CreatedBy, got.UpdatedBy)
+	}
+
+	// without an actor, e.g. a background job, the columns are left alone
+	if err := DB.Model(&models.UserSession{}).Where("id = ?", session.ID).Update("token", "cleaned").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := DB.First(&got, session.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got.UpdatedBy != "admin:2" {
+		t.Fatalf("expected updated_by kept without an actor, got %q", got.UpdatedBy)
+	}
+}
@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// SignDeviceToken returns the trusted device cookie value of token. The
+// signature only keeps forged cookies from reaching the database, the token
+// is still checked against trusted_devices.
+func SignDeviceToken(token string) string {
+	return token + "." + deviceTokenSignature(token)
+}
+
+func VerifyDeviceToken(value string) (string, error) {
+	token, signature, ok := strings.Cut(value, ".")
+	if !ok || token == "" || !hmac.Equal([]byte(signature), []byte(deviceTokenSignature(token))) {
+		return "", errors.New("invalid device token")
+	}
+	return token, nil
+}
+
+func deviceTokenSignature(token string) string {
+	mac := hmac.New(sha256.New, jwtSecret())
+	mac.Write([]byte("device:" + token))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
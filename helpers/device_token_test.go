@@ -0,0 +1,32 @@
+package helpers
+
+import "testing"
+
+func TestVerifyDeviceToken(t *testing.T) {
+	value := SignDeviceToken("device-token")
+	other := SignDeviceToken("other-token")
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid", value: value},
+		{name: "swapped token", value: "other-token" + value[len("device-token"):], wantErr: true},
+		{name: "swapped signature", value: "device-token" + other[len("other-token"):], wantErr: true},
+		{name: "no signature", value: "device-token", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := VerifyDeviceToken(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil && token != "device-token" {
+				t.Errorf("got token %q, want device-token", token)
+			}
+		})
+	}
+}
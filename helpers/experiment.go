@@ -0,0 +1,139 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+// Experiment variants, the label new flows compare their metrics by.
+const (
+	ExperimentControl   = "control"
+	ExperimentTreatment = "treatment"
+)
+
+// experimentBuckets is how finely users are split, 0.01% each.
+const experimentBuckets = 10000
+
+var (
+	ExperimentAssignments = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "experiment_assignments_total",
+		Help: "Number of times a user was put in a variant of an experiment",
+	}, []string{"experiment", "variant"})
+	ExperimentOutcomes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "experiment_outcomes_total",
+		Help: "Number of outcomes, e.g. success or failure, of experiment flows by variant",
+	}, []string{"experiment", "variant", "outcome"})
+	ExperimentDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "experiment_duration_seconds",
+		Help:    "Duration of experiment flows by variant",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"experiment", "variant"})
+)
+
+// Experiment rolls a new flow, e.g. Argon2 hashing or a new risk engine, out
+// to Percent of users. A user's bucket only depends on the salt and their id,
+// so they stay in their variant on every instance and raising Percent only
+// adds users to the treatment. A new salt reshuffles everyone.
+type Experiment struct {
+	Name    string
+	Salt    string
+	Percent float64
+}
+
+// Bucket is the user's place in [0, 10000), the users below Percent*100 get
+// the treatment.
+func (e Experiment) Bucket(userID int) int {
+	sum := sha256.Sum256([]byte(e.Salt + ":" + strconv.Itoa(userID)))
+	return int(binary.BigEndian.Uint64(sum[:8]) % experimentBuckets)
+}
+
+func (e Experiment) Variant(userID int) string {
+	if float64(e.Bucket(userID)) < e.Percent*experimentBuckets/100 {
+		return ExperimentTreatment
+	}
+	return ExperimentControl
+}
+
+var experiments = StaticReloadable(map[string]Experiment{})
+
+// SetupExperiments loads EXPERIMENTS, e.g. "argon2=5,risk_engine=20/2024-10"
+// for 5% and 20% of users, the salt after the slash defaulting to the name.
+// It is reloadable: an invalid value on reload is logged and the previous
+// experiments are kept.
+func SetupExperiments() {
+	last, err := ParseExperiments(GetEnv("EXPERIMENTS", ""))
+	if err != nil {
+		log.Fatal("failed to load experiments: ", err)
+	}
+	experiments = NewReloadable(func() map[string]Experiment {
+		parsed, err := ParseExperiments(GetEnv("EXPERIMENTS", ""))
+		if err != nil {
+			logrus.Error("invalid EXPERIMENTS, keeping the previous experiments: ", err)
+			return last
+		}
+		last = parsed
+		return parsed
+	})
+}
+
+// ParseExperiments parses "name=percent[/salt],..." into experiments by name.
+func ParseExperiments(value string) (map[string]Experiment, error) {
+	parsed := map[string]Experiment{}
+	if strings.TrimSpace(value) == "" {
+		return parsed, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, rest, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid experiment entry %q", entry)
+		}
+		percent, salt, _ := strings.Cut(rest, "/")
+		if salt == "" {
+			salt = name
+		}
+		p, err := strconv.ParseFloat(percent, 64)
+		if err != nil || p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid percent of experiment %s: %q", name, percent)
+		}
+		if _, ok := parsed[name]; ok {
+			return nil, fmt.Errorf("duplicate experiment %s", name)
+		}
+		parsed[name] = Experiment{Name: name, Salt: salt, Percent: p}
+	}
+	return parsed, nil
+}
+
+// ExperimentVariant puts the user in a variant of the named experiment and
+// counts the assignment. Unknown experiments are off, every user gets the
+// control.
+func ExperimentVariant(name string, userID int) string {
+	variant := ExperimentControl
+	if experiment, ok := experiments.Get()[name]; ok {
+		variant = experiment.Variant(userID)
+	}
+	ExperimentAssignments.WithLabelValues(name, variant).Inc()
+	return variant
+}
+
+// InExperiment reports whether the user gets the new flow of the named
+// experiment.
+func InExperiment(name string, userID int) bool {
+	return ExperimentVariant(name, userID) == ExperimentTreatment
+}
+
+// ObserveExperiment records how a flow run in variant ended and how long it
+// took since start, so the variants can be compared before a full rollout.
+func ObserveExperiment(name, variant, outcome string, start time.Time) {
+	ExperimentOutcomes.WithLabelValues(name, variant, outcome).Inc()
+	ExperimentDuration.WithLabelValues(name, variant).Observe(time.Since(start).Seconds())
+}
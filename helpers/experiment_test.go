@@ -0,0 +1,65 @@
+package helpers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExperimentVariant(t *testing.T) {
+	experiment := Experiment{Name: "argon2", Salt: "argon2", Percent: 20}
+	ramped := Experiment{Name: "argon2", Salt: "argon2", Percent: 50}
+	resalted := Experiment{Name: "argon2", Salt: "2024-10", Percent: 20}
+
+	const users = 20000
+	treated, moved := 0, 0
+	for userID := 1; userID <= users; userID++ {
+		variant := experiment.Variant(userID)
+		if variant != experiment.Variant(userID) {
+			t.Fatalf("user %d changed variant between calls", userID)
+		}
+		if variant == ExperimentTreatment {
+			treated++
+			if ramped.Variant(userID) != ExperimentTreatment {
+				t.Fatalf("user %d left the treatment when the rollout grew", userID)
+			}
+		}
+		if variant != resalted.Variant(userID) {
+			moved++
+		}
+	}
+
+	if share := float64(treated) / users; math.Abs(share-0.2) > 0.02 {
+		t.Errorf("got %.3f of users treated, want about 0.2", share)
+	}
+	if moved == 0 {
+		t.Error("expected a new salt to reshuffle users")
+	}
+	if (Experiment{Percent: 0}).Variant(1) != ExperimentControl || (Experiment{Percent: 100}).Variant(1) != ExperimentTreatment {
+		t.Error("expected 0% to treat nobody and 100% everybody")
+	}
+}
+
+func TestParseExperiments(t *testing.T) {
+	parsed, err := ParseExperiments("argon2=5, risk_engine=12.5/2024-10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed["argon2"]; got != (Experiment{Name: "argon2", Salt: "argon2", Percent: 5}) {
+		t.Errorf("got %+v for argon2", got)
+	}
+	if got := parsed["risk_engine"]; got != (Experiment{Name: "risk_engine", Salt: "2024-10", Percent: 12.5}) {
+		t.Errorf("got %+v for risk_engine", got)
+	}
+
+	for _, value := range []string{"argon2", "=5", "argon2=abc", "argon2=101", "argon2=-1", "argon2=5,argon2=10"} {
+		if _, err := ParseExperiments(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestExperimentVariantUnknown(t *testing.T) {
+	if ExperimentVariant("unknown", 1) != ExperimentControl {
+		t.Error("expected unknown experiments to be off")
+	}
+}
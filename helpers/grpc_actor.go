@@ -0,0 +1,17 @@
+package helpers
+
+import "context"
+
+type actorKey struct{}
+
+// ContextWithActor records who is calling, as an audit actor: set by the
+// gRPC admin auth interceptor and the HTTP auth middleware, and written to
+// the created_by and updated_by columns by ActorColumns.
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	return actor, ok && actor != ""
+}
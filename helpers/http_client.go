@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func NewHTTPClient() *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   time.Duration(GetEnvInt("HTTP_CLIENT_DIAL_TIMEOUT_SECONDS", 5)) * time.Second,
+			KeepAlive: time.Duration(GetEnvInt("HTTP_CLIENT_KEEP_ALIVE_SECONDS", 30)) * time.Second,
+		}).DialContext,
+		MaxIdleConns:          GetEnvInt("HTTP_CLIENT_MAX_IDLE_CONNS", 100),
+		MaxIdleConnsPerHost:   GetEnvInt("HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST", 20),
+		MaxConnsPerHost:       GetEnvInt("HTTP_CLIENT_MAX_CONNS_PER_HOST", 0),
+		IdleConnTimeout:       time.Duration(GetEnvInt("HTTP_CLIENT_IDLE_CONN_TIMEOUT_SECONDS", 90)) * time.Second,
+		TLSHandshakeTimeout:   time.Duration(GetEnvInt("HTTP_CLIENT_TLS_HANDSHAKE_TIMEOUT_SECONDS", 5)) * time.Second,
+		ResponseHeaderTimeout: time.Duration(GetEnvInt("HTTP_CLIENT_RESPONSE_HEADER_TIMEOUT_SECONDS", 10)) * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	if proxy := GetEnv("HTTP_CLIENT_PROXY_URL", ""); proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			logrus.Warn("invalid http client proxy url, falling back to environment proxy: ", err)
+		} else {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(GetEnvInt("HTTP_CLIENT_TIMEOUT_SECONDS", 15)) * time.Second,
+	}
+
+	// sign outbound calls (wallet, webhooks) the way internal callers sign ours
+	if keyID := GetEnv("HTTP_SIGNING_KEY_ID", ""); keyID != "" {
+		client.Transport = &signingTransport{
+			base:   transport,
+			keyID:  keyID,
+			secret: []byte(GetEnv("HTTP_SIGNING_SECRET", "")),
+		}
+	}
+
+	return client
+}
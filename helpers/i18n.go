@@ -0,0 +1,122 @@
+package helpers
+
+import (
+	"ewallet-ums/constants"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// errorMessages is the catalog of response messages with a stable code for
+// clients to match on and their translations. The English message is the
+// constant itself, so responses without a translation don't change.
+var errorMessages = map[string]struct {
+	Code         string
+	Translations map[string]string
+}{
+	constants.ErrFailedBadRequest: {"bad_request", map[string]string{
+		constants.LocaleIndonesian: "Data permintaan tidak valid",
+	}},
+	constants.ErrServerError: {"server_error", map[string]string{
+		constants.LocaleIndonesian: "Terjadi kesalahan pada server",
+	}},
+	constants.ErrForbidden: {"forbidden", map[string]string{
+		constants.LocaleIndonesian: "Akses ditolak",
+	}},
+	constants.ErrNotFound: {"not_found", map[string]string{
+		constants.LocaleIndonesian: "Data tidak ditemukan",
+	}},
+	constants.ErrConflict: {"conflict", map[string]string{
+		constants.LocaleIndonesian: "Data bertentangan dengan perubahan lain",
+	}},
+	constants.ErrTooManyRequests: {"too_many_requests", map[string]string{
+		constants.LocaleIndonesian: "Terlalu banyak permintaan, coba lagi nanti",
+	}},
+	constants.ErrGatewayTimeout: {"timeout", map[string]string{
+		constants.LocaleIndonesian: "Waktu permintaan habis",
+	}},
+	constants.ErrUpstream: {"upstream_error", map[string]string{
+		constants.LocaleIndonesian: "Layanan pendukung sedang bermasalah",
+	}},
+	constants.ErrOverloaded: {"overloaded", map[string]string{
+		constants.LocaleIndonesian: "Layanan sedang sibuk, coba lagi nanti",
+	}},
+	constants.ErrUnauthorized: {"unauthorized", map[string]string{
+		constants.LocaleIndonesian: "Tidak terautentikasi",
+	}},
+	constants.ErrPasswordChangeRequired: {"password_change_required", map[string]string{
+		constants.LocaleIndonesian: "Kata sandi harus diganti",
+	}},
+	constants.ErrAccountPendingDeletion: {"account_pending_deletion", map[string]string{
+		constants.LocaleIndonesian: "Akun dijadwalkan untuk dihapus",
+	}},
+}
+
+// supportedLocales are matched against Accept-Language, the first one wins
+// ties.
+var supportedLocales = []string{constants.LocaleEnglish, constants.LocaleIndonesian}
+
+var localeMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(supportedLocales))
+	for i, locale := range supportedLocales {
+		tags[i] = language.Make(locale)
+	}
+	return language.NewMatcher(tags)
+}()
+
+// MatchAcceptLanguage returns the supported locale an Accept-Language header
+// prefers, or "" when it names none of them.
+func MatchAcceptLanguage(header string) string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return ""
+	}
+	_, index, confidence := localeMatcher.Match(tags...)
+	if confidence == language.No {
+		return ""
+	}
+	return supportedLocales[index]
+}
+
+// SetLocale sets the locale of the request's messages.
+func SetLocale(c *gin.Context, locale string) {
+	c.Set("locale", locale)
+}
+
+// SetUserLocaleLookup sets how to load the authenticated user's stored
+// locale. It is only called when a message is translated, so requests that
+// succeed don't pay for the lookup.
+func SetUserLocaleLookup(c *gin.Context, lookup func() string) {
+	c.Set("user_locale", lookup)
+}
+
+// Locale returns the locale of the request's messages: the one set from
+// Accept-Language, else the user's stored locale, else English.
+func Locale(c *gin.Context) string {
+	if locale := c.GetString("locale"); locale != "" {
+		return locale
+	}
+	if lookup, ok := c.Get("user_locale"); ok {
+		if locale := lookup.(func() string)(); locale != "" {
+			SetLocale(c, locale)
+			return locale
+		}
+	}
+	return constants.LocaleEnglish
+}
+
+// localizeMessage returns the code of message and its translation for the
+// request, message is returned as is when it isn't in the catalog.
+func localizeMessage(c *gin.Context, message string) (string, string) {
+	entry, ok := errorMessages[message]
+	if !ok {
+		return "", message
+	}
+
+	locale := Locale(c)
+	c.Header("Content-Language", locale)
+	if translated, ok := entry.Translations[locale]; ok {
+		return entry.Code, translated
+	}
+	return entry.Code, message
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ewallet-ums/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                         "",
+		"id-ID,id;q=0.9,en;q=0.8":  constants.LocaleIndonesian,
+		"en-US,en;q=0.9,id;q=0.8":  constants.LocaleEnglish,
+		"fr-FR, id;q=0.5":          constants.LocaleIndonesian,
+		"fr-FR,de;q=0.5":           "",
+		"en;q=0.1, id;q=0.9":       constants.LocaleIndonesian,
+		"not a language header;;;": "",
+	}
+	for header, want := range tests {
+		if got := MatchAcceptLanguage(header); got != want {
+			t.Errorf("MatchAcceptLanguage(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestSendResponseHTTPLocalized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	send := func(setup func(c *gin.Context), message string) Response {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		setup(c)
+		SendResponseHTTP(c, http.StatusBadRequest, message, nil)
+
+		resp := Response{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatal("failed to decode response: ", err)
+		}
+		return resp
+	}
+
+	resp := send(func(c *gin.Context) {}, constants.ErrFailedBadRequest)
+	if resp.Code != "bad_request" || resp.Message != constants.ErrFailedBadRequest {
+		t.Errorf("got %+v, want the English message by default", resp)
+	}
+
+	resp = send(func(c *gin.Context) { SetLocale(c, constants.LocaleIndonesian) }, constants.ErrFailedBadRequest)
+	if resp.Code != "bad_request" || resp.Message != "Data permintaan tidak valid" {
+		t.Errorf("got %+v, want the Indonesian message with the same code", resp)
+	}
+
+	// the stored locale is only used without an Accept-Language match
+	resp = send(func(c *gin.Context) {
+		SetUserLocaleLookup(c, func() string { return constants.LocaleIndonesian })
+	}, constants.ErrNotFound)
+	if resp.Code != "not_found" || resp.Message != "Data tidak ditemukan" {
+		t.Errorf("got %+v, want the user's stored locale", resp)
+	}
+	resp = send(func(c *gin.Context) {
+		SetLocale(c, constants.LocaleEnglish)
+		SetUserLocaleLookup(c, func() string { return constants.LocaleIndonesian })
+	}, constants.ErrNotFound)
+	if resp.Message != constants.ErrNotFound {
+		t.Errorf("got %+v, want Accept-Language to win over the stored locale", resp)
+	}
+
+	resp = send(func(c *gin.Context) { SetLocale(c, constants.LocaleIndonesian) }, "custom message")
+	if resp.Code != "" || resp.Message != "custom message" {
+		t.Errorf("got %+v, want messages outside the catalog unchanged", resp)
+	}
+}
@@ -0,0 +1,71 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// Tokens issued for an audience listed in JWE_AUDIENCES are signed as usual
+// and then encrypted (nested JWT, dir + A256GCM) with JWE_KEY, so end-user
+// clients can't read the claims while internal services holding the key can.
+
+func shouldEncryptToken(audience string) bool {
+	if audience == "" {
+		return false
+	}
+	return slices.Contains(strings.Split(GetEnv("JWE_AUDIENCES", ""), ","), audience)
+}
+
+func jweKey() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(GetEnv("JWE_KEY", ""))
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("JWE_KEY must be 32 base64-encoded bytes")
+	}
+	return key, nil
+}
+
+func isEncryptedToken(token string) bool {
+	// compact JWE has five segments, compact JWS three
+	return strings.Count(token, ".") == 4
+}
+
+func encryptToken(signed string) (string, error) {
+	key, err := jweKey()
+	if err != nil {
+		return "", err
+	}
+
+	encrypter, err := jose.NewEncrypter(jose.A256GCM, jose.Recipient{Algorithm: jose.DIRECT, Key: key},
+		(&jose.EncrypterOptions{Compression: jose.DEFLATE}).WithContentType("JWT"))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token encrypter: %v", err)
+	}
+
+	object, err := encrypter.Encrypt([]byte(signed))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt token: %v", err)
+	}
+	return object.CompactSerialize()
+}
+
+func decryptToken(token string) (string, error) {
+	key, err := jweKey()
+	if err != nil {
+		return "", err
+	}
+
+	object, err := jose.ParseEncrypted(token, []jose.KeyAlgorithm{jose.DIRECT}, []jose.ContentEncryption{jose.A256GCM})
+	if err != nil {
+		return "", fmt.Errorf("failed to parse encrypted token: %v", err)
+	}
+
+	signed, err := object.Decrypt(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token: %v", err)
+	}
+	return string(signed), nil
+}
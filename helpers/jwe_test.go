@@ -0,0 +1,109 @@
+package helpers
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+)
+
+func TestEncryptedToken(t *testing.T) {
+	ctx := context.Background()
+	Env = map[string]string{
+		"JWE_AUDIENCES": "partner",
+		"JWE_KEY":       base64.StdEncoding.EncodeToString(make([]byte, 32)),
+	}
+	t.Cleanup(func() { Env = map[string]string{} })
+
+	tests := []struct {
+		audience  string
+		encrypted bool
+	}{
+		{audience: "", encrypted: false},
+		{audience: "mobile", encrypted: false},
+		{audience: "partner", encrypted: true},
+	}
+
+	for _, tt := range tests {
+		token, err := GenerateTokenForAudience(ctx, 1, "user", "User", "token", "user@example.com", tt.audience, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Count(token, ".") == 4; got != tt.encrypted {
+			t.Errorf("audience %q: got encrypted %t, want %t", tt.audience, got, tt.encrypted)
+		}
+
+		claim, err := ValidateToken(ctx, token)
+		if err != nil {
+			t.Fatalf("audience %q: failed to validate token: %v", tt.audience, err)
+		}
+		if claim.Email != "user@example.com" {
+			t.Errorf("audience %q: got email %q", tt.audience, claim.Email)
+		}
+	}
+}
+
+func TestEncryptedTokenAudience(t *testing.T) {
+	ctx := context.Background()
+	Env = map[string]string{
+		"APP_SECRET":    "test-secret",
+		"JWE_AUDIENCES": "partner",
+		"JWE_KEY":       base64.StdEncoding.EncodeToString(make([]byte, 32)),
+	}
+	t.Cleanup(func() { Env = map[string]string{} })
+
+	claim := ClaimToken{UserID: 1, RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}}
+
+	// signed for the encrypted audience but left readable
+	claim.Audience = jwt.ClaimStrings{"partner"}
+	plain, err := signClaims(claim, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateToken(ctx, plain); err == nil {
+		t.Error("expected a plain token for an encrypted audience to be rejected")
+	}
+
+	// encrypted for a readable audience
+	claim.Audience = jwt.ClaimStrings{"mobile"}
+	signed, err := signClaims(claim, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := encryptToken(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateToken(ctx, encrypted); err == nil {
+		t.Error("expected an encrypted token for a readable audience to be rejected")
+	}
+}
+
+func TestValidateJWTKeys(t *testing.T) {
+	Logger = logrus.New()
+	t.Cleanup(func() { Env = map[string]string{} })
+
+	secret := strings.Repeat("s", 32)
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "missing secret", env: map[string]string{}, wantErr: true},
+		{name: "short secret", env: map[string]string{"APP_SECRET": "short"}},
+		{name: "short secret in production", env: map[string]string{"APP_SECRET": "short", "APP_ENV": "production"}, wantErr: true},
+		{name: "jwe without key", env: map[string]string{"APP_SECRET": secret, "JWE_AUDIENCES": "partner"}, wantErr: true},
+		{name: "jwe with key", env: map[string]string{"APP_SECRET": secret, "JWE_AUDIENCES": "partner", "JWE_KEY": base64.StdEncoding.EncodeToString(make([]byte, 32))}},
+	}
+
+	for _, tt := range tests {
+		Env = tt.env
+		if err := ValidateJWTKeys(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
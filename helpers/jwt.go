@@ -2,45 +2,225 @@ package helpers
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"slices"
 	"time"
 
+	"ewallet-ums/constants"
+
+	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type ClaimToken struct {
 	UserID   int    `json:"user_id"`
-	Username string `json:"username"`
-	FullName string `json:"full_name"`
-	Email    string `json:"email"`
+	Username string `json:"username,omitempty"`
+	FullName string `json:"full_name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	// ProfileCompleteness is not signed into tokens, token validation fills
+	// it from the current profile.
+	ProfileCompleteness int `json:"profile_completeness,omitempty"`
+	// Guest and Service are signed at issue, stateful token validation
+	// refreshes them from the current profile. Service is for service
+	// account tokens.
+	Guest   bool `json:"guest,omitempty"`
+	Service bool `json:"service,omitempty"`
+	// Entitlements are filled by token validation too, tokens carry the
+	// ones at issue in Ext.
+	Entitlements []string `json:"entitlements,omitempty"`
+	DeviceID     string   `json:"device_id,omitempty"`
+	ClientID     string   `json:"client_id,omitempty"`
+	Scope        string   `json:"scope,omitempty"`
+	Act          *Actor   `json:"act,omitempty"`
+	// SubjectTokenID is the jti of the access token a delegated token was
+	// exchanged for, it is only valid while that token's session is.
+	SubjectTokenID string `json:"sub_jti,omitempty"`
+	// Type is constants.TokenTypeAccess or TokenTypeRefresh, so a refresh
+	// token can't be used as an access token where only the signature is
+	// checked. Tokens issued before it was added have none.
+	Type string `json:"typ,omitempty"`
+	// Ext holds the claims added by registered ClaimsEnrichers.
+	Ext map[string]any `json:"ext,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Actor is the RFC 8693 act claim, naming who a delegated token was issued to.
+type Actor struct {
+	Sub string `json:"sub"`
+}
+
 var MapTypeToken = map[string]time.Duration{
 	"token":         time.Hour * 3,
 	"refresh_token": time.Hour * 24 * 3,
 }
 
-var jwtSecret = []byte(GetEnv("APP_SECRET", ""))
+// tokenTTLConfig names the settings overriding MapTypeToken, they are read
+// on every token so a config reload applies to the next one issued.
+var tokenTTLConfig = map[string]string{
+	"token":         "ACCESS_TOKEN_TTL_SECONDS",
+	"refresh_token": "REFRESH_TOKEN_TTL_SECONDS",
+}
+
+// minJWTSecretLength is the HS256 key size, shorter secrets are refused in
+// production.
+const minJWTSecretLength = 32
+
+// jwtSecret is read when used, a package level var would be initialized
+// before SetupConfig loaded APP_SECRET.
+func jwtSecret() []byte {
+	return []byte(GetEnv("APP_SECRET", ""))
+}
+
+// ValidateJWTKeys checks the signing secret, and the JWE key when an audience
+// gets encrypted tokens, so a bad config fails at startup rather than on
+// the first login.
+func ValidateJWTKeys() error {
+	secret := jwtSecret()
+	if len(secret) == 0 {
+		return fmt.Errorf("APP_SECRET is not set")
+	}
+	if len(secret) < minJWTSecretLength {
+		if GetEnv("APP_ENV", "") == "production" {
+			return fmt.Errorf("APP_SECRET must be at least %d bytes in production", minJWTSecretLength)
+		}
+		Logger.Warnf("APP_SECRET is shorter than %d bytes", minJWTSecretLength)
+	}
+
+	if GetEnv("JWE_AUDIENCES", "") != "" {
+		if _, err := jweKey(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
 func GenerateToken(ctx context.Context, userID int, username, fullname string, tokenType string, email string, now time.Time) (string, error) {
+	return GenerateTokenForAudience(ctx, userID, username, fullname, tokenType, email, "", now)
+}
+
+// GenerateTokenForAudience sets the aud claim when audience is not empty and
+// encrypts the token if the audience is configured for JWE.
+func GenerateTokenForAudience(ctx context.Context, userID int, username, fullname string, tokenType string, email string, audience string, now time.Time) (string, error) {
+	return GenerateTokenWithPolicy(ctx, userID, username, fullname, tokenType, email, TokenPolicy{Audience: audience}, now)
+}
+
+// TokenPolicy is what a registered client changes about the tokens issued to
+// it. The zero value keeps the defaults: MapTypeToken TTLs and every profile
+// claim.
+type TokenPolicy struct {
+	ClientID string
+	Scope    string
+	// Audience is the registered client's, never the caller's say, as it
+	// decides whether the token is encrypted.
+	Audience string
+	TTL      map[string]time.Duration
+	// Claims lists the profile claims (username, full_name, email) to sign
+	// into the token, nil means all of them.
+	Claims []string
+	// DeviceID binds the token to one device, see constants.HeaderDeviceID.
+	DeviceID string
+	// Guest and Service sign the user's type into the token, for stateless
+	// validation.
+	Guest   bool
+	Service bool
+}
+
+// TTLFor returns the policy's lifetime for tokenType, falling back to the
+// configured TTL and then MapTypeToken.
+func (p TokenPolicy) TTLFor(tokenType string) time.Duration {
+	if ttl := p.TTL[tokenType]; ttl > 0 {
+		return ttl
+	}
+	if seconds := GetEnvInt(tokenTTLConfig[tokenType], 0); seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return MapTypeToken[tokenType]
+}
+
+func (p TokenPolicy) includesClaim(claim string) bool {
+	return p.Claims == nil || slices.Contains(p.Claims, claim)
+}
+
+func GenerateTokenWithPolicy(ctx context.Context, userID int, username, fullname string, tokenType string, email string, policy TokenPolicy, now time.Time) (string, error) {
 	claimToken := ClaimToken{
 		UserID:   userID,
-		Username: username,
-		FullName: fullname,
-		Email:    email,
+		Type:     claimType(tokenType),
+		DeviceID: policy.DeviceID,
+		ClientID: policy.ClientID,
+		Scope:    policy.Scope,
+		Guest:    policy.Guest,
+		Service:  policy.Service,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// the jti keeps tokens minted in the same second for the same
+			// user distinct, refresh tokens are rotated that fast
+			ID:        rand.Text(),
 			Issuer:    GetEnv("APP_NAME", ""),
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(MapTypeToken[tokenType])),
+			ExpiresAt: jwt.NewNumericDate(now.Add(policy.TTLFor(tokenType))),
 		},
 	}
+	if policy.includesClaim("username") {
+		claimToken.Username = username
+	}
+	if policy.includesClaim("full_name") {
+		claimToken.FullName = fullname
+	}
+	if policy.includesClaim("email") {
+		claimToken.Email = email
+	}
+	if policy.Audience != "" {
+		claimToken.Audience = jwt.ClaimStrings{policy.Audience}
+	}
+
+	if err := enrichClaims(ctx, &claimToken); err != nil {
+		return "", err
+	}
 
+	token, err := signClaims(claimToken, policy.Audience)
+	if err != nil {
+		return "", err
+	}
+
+	EmitSecurityEvent(ctx, SecurityEvent{
+		Type:       SecurityEventTokenIssued,
+		OccurredAt: now,
+		UserID:     userID,
+		TokenID:    claimToken.ID,
+		TokenType:  tokenType,
+		ClientID:   policy.ClientID,
+		Audience:   policy.Audience,
+		ExpiresAt:  claimToken.ExpiresAt.Time,
+	})
+	return token, nil
+}
+
+// claimType is the typ claim of a MapTypeToken token type.
+func claimType(tokenType string) string {
+	if tokenType == "refresh_token" {
+		return constants.TokenTypeRefresh
+	}
+	return constants.TokenTypeAccess
+}
+
+// IsAccessToken reports whether the token is an access token. Untyped
+// tokens, issued before the typ claim, are only told apart by their session.
+func (c *ClaimToken) IsAccessToken() bool {
+	return c.Type == constants.TokenTypeAccess
+}
+
+func signClaims(claimToken ClaimToken, audience string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimToken)
-	resultToken, err := token.SignedString(jwtSecret)
+	resultToken, err := token.SignedString(jwtSecret())
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %v", err)
 	}
+
+	if shouldEncryptToken(audience) {
+		return encryptToken(resultToken)
+	}
 	return resultToken, nil
 }
 
@@ -50,11 +230,20 @@ func ValidateToken(ctx context.Context, token string) (*ClaimToken, error) {
 		ok         bool
 	)
 
+	encrypted := isEncryptedToken(token)
+	if encrypted {
+		signed, err := decryptToken(token)
+		if err != nil {
+			return nil, err
+		}
+		token = signed
+	}
+
 	jwtToken, err := jwt.ParseWithClaims(token, &ClaimToken{}, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("failed to validate method jwt: %v", t.Header["alg"])
 		}
-		return jwtSecret, nil
+		return jwtSecret(), nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse jwt: %v", err)
@@ -64,5 +253,44 @@ func ValidateToken(ctx context.Context, token string) (*ClaimToken, error) {
 		return nil, fmt.Errorf("token invalid")
 	}
 
+	// a token for an encrypted audience must have been encrypted, and only
+	// those are, so the aud can't be swapped for one the caller can read
+	for _, audience := range claimToken.Audience {
+		if shouldEncryptToken(audience) != encrypted {
+			return nil, fmt.Errorf("token for audience %q has the wrong encryption", audience)
+		}
+	}
+	if encrypted && len(claimToken.Audience) == 0 {
+		return nil, fmt.Errorf("encrypted token without audience")
+	}
+
 	return claimToken, nil
 }
+
+// TokenID returns the jti of a token, empty when it can't be read.
+func TokenID(ctx context.Context, token string) string {
+	claim, err := ValidateToken(ctx, token)
+	if err != nil {
+		return ""
+	}
+	return claim.ID
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func GetTokenClaim(c *gin.Context) (*ClaimToken, error) {
+	claim, ok := c.Get("token")
+	if !ok {
+		return nil, fmt.Errorf("failed to get claim in context")
+	}
+
+	tokenClaim, ok := claim.(*ClaimToken)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse claim to claim token")
+	}
+
+	return tokenClaim, nil
+}
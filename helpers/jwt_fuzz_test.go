@@ -0,0 +1,48 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func FuzzValidateToken(f *testing.F) {
+	ctx := context.Background()
+	now := time.Now()
+
+	valid, err := GenerateToken(ctx, 1, "fuzz", "Fuzz User", "token", "fuzz@example.com", now)
+	if err != nil {
+		f.Fatal(err)
+	}
+	expired, err := GenerateToken(ctx, 1, "fuzz", "Fuzz User", "token", "fuzz@example.com", now.Add(-24*time.Hour))
+	if err != nil {
+		f.Fatal(err)
+	}
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, ClaimToken{UserID: 1}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(valid)
+	f.Add(expired)
+	f.Add(unsigned)
+	f.Add(valid[:len(valid)-2])
+	f.Add("")
+	f.Add("a.b.c")
+	f.Add("Bearer " + valid)
+
+	f.Fuzz(func(t *testing.T, token string) {
+		claim, err := ValidateToken(ctx, token)
+		if err != nil {
+			return
+		}
+		if claim == nil {
+			t.Fatal("nil claim without error")
+		}
+		if claim.ExpiresAt == nil || claim.ExpiresAt.Before(time.Now().Add(-time.Minute)) {
+			t.Fatalf("accepted expired or non-expiring token %q", token)
+		}
+	})
+}
@@ -1,17 +1,89 @@
 package helpers
 
 import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sirupsen/logrus"
 )
 
 var Logger *logrus.Logger
 
+var LogEntriesSampledOut = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "log_entries_sampled_out_total",
+	Help: "Log entries dropped by sampling on high-volume paths, by sampler",
+}, []string{"sampler"})
+
 func SetupLogger() {
 	log := logrus.New()
 	log.SetFormatter(&logrus.JSONFormatter{
 		PrettyPrint: true,
 	})
 
+	level, err := logrus.ParseLevel(GetEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		log.Warn("invalid LOG_LEVEL, using info: ", err)
+		level = logrus.InfoLevel
+	}
+	log.SetLevel(level)
+
 	log.Info("Logger initiated using logrus")
 	Logger = log
 }
+
+// SetLogLevel changes the level of Logger at runtime, e.g. to debug a live
+// instance. It only affects this process.
+func SetLogLevel(level string) error {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	Logger.SetLevel(parsed)
+	return nil
+}
+
+func LogLevel() string {
+	return Logger.GetLevel().String()
+}
+
+// LogSampler thins out the logs of a high-volume path: it lets through the
+// first First entries of every Interval, then one in Thereafter. Entries
+// below Logger's level are not counted. A nil sampler lets everything
+// through.
+type LogSampler struct {
+	Name       string
+	First      int
+	Thereafter int
+	Interval   time.Duration
+
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+}
+
+func (s *LogSampler) Allow(level logrus.Level) bool {
+	if !Logger.IsLevelEnabled(level) {
+		return false
+	}
+	if s == nil {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.windowStart) >= s.Interval {
+		s.windowStart = now
+		s.count = 0
+	}
+	s.count++
+
+	if s.count <= s.First || (s.Thereafter > 0 && (s.count-s.First)%s.Thereafter == 0) {
+		return true
+	}
+	LogEntriesSampledOut.WithLabelValues(s.Name).Inc()
+	return false
+}
@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLogSampler(t *testing.T) {
+	Logger = logrus.New()
+	Logger.SetLevel(logrus.InfoLevel)
+
+	sampler := &LogSampler{Name: "test", First: 3, Thereafter: 5, Interval: time.Hour}
+	allowed := 0
+	for range 23 {
+		if sampler.Allow(logrus.WarnLevel) {
+			allowed++
+		}
+	}
+	// the first 3, then the 5th, 10th, 15th and 20th after them
+	if allowed != 7 {
+		t.Fatalf("expected 7 entries through, got %d", allowed)
+	}
+
+	if sampler.Allow(logrus.DebugLevel) {
+		t.Fatal("expected entries below the log level to be dropped")
+	}
+
+	var unsampled *LogSampler
+	if !unsampled.Allow(logrus.WarnLevel) {
+		t.Fatal("expected a nil sampler to let everything through")
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	Logger = logrus.New()
+
+	if err := SetLogLevel("debug"); err != nil || LogLevel() != "debug" {
+		t.Fatalf("expected debug, got %q (%v)", LogLevel(), err)
+	}
+	if err := SetLogLevel("loud"); err == nil || LogLevel() != "debug" {
+		t.Fatal("expected an unknown level to be rejected and the level kept")
+	}
+}
@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var SessionsActive = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "sessions_active",
+	Help: "Number of user sessions whose refresh token has not expired",
+})
+
+var RetentionPurgedRows = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "retention_purged_rows_total",
+	Help: "Rows purged (or found, on dry runs) past their retention period",
+}, []string{"rule", "dry_run"})
+
+var GRPCCallerRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "grpc_caller_rejections_total",
+	Help: "gRPC requests rejected by the caller guard, by reason",
+}, []string{"reason"})
+
+var ConfigReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "config_reloads_total",
+	Help: "Config reloads that applied a change, or failed to read the config",
+}, []string{"result"})
+
+var WalletProvisioningBacklog = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "wallet_provisioning_backlog",
+	Help: "Wallet provisionings that failed or are pending past their SLA, by status",
+}, []string{"status"})
+
+var HTTPRequestTimeouts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "http_request_timeouts_total",
+	Help: "HTTP requests answered with 504 after their route deadline, by route",
+}, []string{"route"})
+
+var HTTPRequestsShed = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "http_requests_shed_total",
+	Help: "HTTP requests answered with 503 because their route group was at its concurrency limit, by group",
+}, []string{"group"})
+
+var RegistrationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "registrations_total",
+	Help: "Users registered, by channel: the app platform, guest_upgrade or import",
+}, []string{"channel"})
+
+var ActiveUsers24h = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "active_users_24h",
+	Help: "Distinct users who logged in or refreshed a token in the last 24 hours, estimated",
+})
+
+var KycPending = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "kyc_pending_total",
+	Help: "Users whose KYC verification is pending",
+})
+
+var DeadLetters = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "dead_letters_total",
+	Help: "Events that failed for good and were kept as dead letters, by direction and topic",
+}, []string{"direction", "topic"})
+
+var JobLocksHeld = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "job_locks_held",
+	Help: "Whether this instance holds the lock of a worker job and runs it, by job",
+}, []string{"job"})
+
+var SIEMEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "siem_events_total",
+	Help: "Security events handed to the SIEM sink, by result: sent, dropped when the queue was full, or failed after retries",
+}, []string{"result"})
+
+var SIEMQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "siem_queue_depth",
+	Help: "Security events waiting to be sent to the SIEM",
+})
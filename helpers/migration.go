@@ -0,0 +1,208 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Migration is a versioned schema change, applied once and recorded in
+// schema_migrations. Versions only grow. Up must be safe to run twice, pods
+// starting together may both apply the same expand migration.
+//
+// A change old binaries can't live with is split in two: an expand
+// migration adding the new shape (ExpandColumn, BackfillColumn) shipped with
+// the binary that writes both, then a contract migration removing the old
+// shape (ContractColumn, ContractTable) in a later release, run once every
+// pod is on it.
+type Migration struct {
+	Version int
+	Name    string
+	Phase   string
+	Up      func(db *gorm.DB) error
+}
+
+// migrations are the schema changes this binary knows, by version. New
+// tables and columns only need adding to migratedModels, AutoMigrate creates
+// them before the expand migrations run.
+var migrations = []Migration{
+	// marks schemas created by AutoMigrate alone, before versions were recorded
+	{Version: 1, Name: "baseline", Phase: constants.MigrationPhaseExpand, Up: func(db *gorm.DB) error { return nil }},
+	// older binaries write '' lookups for an empty email or phone number,
+	// which the unique index would refuse
+	{Version: 2, Name: "unique_pii_lookups", Phase: constants.MigrationPhaseContract, Up: uniquePIILookups},
+}
+
+// SchemaVersion is the schema version this binary expects, its last
+// migration's.
+func SchemaVersion() int {
+	return schemaVersion(migrations)
+}
+
+func schemaVersion(list []Migration) int {
+	version := 0
+	for _, migration := range list {
+		version = max(version, migration.Version)
+	}
+	return version
+}
+
+// MigrateSchema applies this binary's migrations of phase that weren't yet
+// and returns them. The expand phase first creates the tables and columns of
+// migratedModels. Contract migrations refuse to run while expand ones are
+// pending.
+func MigrateSchema(db *gorm.DB, phase string) ([]Migration, error) {
+	return runMigrations(db, migrations, phase)
+}
+
+func runMigrations(db *gorm.DB, list []Migration, phase string) ([]Migration, error) {
+	if err := db.AutoMigrate(&models.SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %v", err)
+	}
+	if phase == constants.MigrationPhaseExpand {
+		if err := db.AutoMigrate(migratedModels...); err != nil {
+			return nil, fmt.Errorf("failed to migrate models: %v", err)
+		}
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	ran := []Migration{}
+	for _, migration := range list {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if migration.Phase != phase {
+			if phase == constants.MigrationPhaseContract && migration.Phase == constants.MigrationPhaseExpand {
+				return ran, fmt.Errorf("expand migration %d %s is pending, run it first", migration.Version, migration.Name)
+			}
+			continue
+		}
+
+		if err := migration.Up(db); err != nil {
+			return ran, fmt.Errorf("migration %d %s failed: %v", migration.Version, migration.Name, err)
+		}
+		record := models.SchemaMigration{Version: migration.Version, Name: migration.Name, Phase: migration.Phase, AppliedAt: time.Now()}
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error; err != nil {
+			return ran, fmt.Errorf("failed to record migration %d %s: %v", migration.Version, migration.Name, err)
+		}
+		ran = append(ran, migration)
+	}
+	return ran, nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[int]models.SchemaMigration, error) {
+	records := []models.SchemaMigration{}
+	if err := db.Order("version").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %v", err)
+	}
+
+	applied := map[int]models.SchemaMigration{}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// SchemaProblems lists why this binary can't run against the database:
+// expand migrations it needs that weren't applied, e.g. a new pod booting
+// before the schema was migrated, and contract migrations of a newer binary
+// that removed what it uses, e.g. a rollback after the contract ran. Its own
+// pending contract migrations are fine, they wait for `migrate contract`.
+func SchemaProblems(db *gorm.DB) ([]string, error) {
+	if !db.Migrator().HasTable(&models.SchemaMigration{}) {
+		return []string{"no schema version recorded, run `migrate expand`"}, nil
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	return schemaProblems(applied, migrations), nil
+}
+
+func schemaProblems(applied map[int]models.SchemaMigration, list []Migration) []string {
+	version := schemaVersion(list)
+	problems := []string{}
+
+	for _, migration := range list {
+		if _, ok := applied[migration.Version]; !ok && migration.Phase == constants.MigrationPhaseExpand {
+			problems = append(problems, fmt.Sprintf("migration %d %s not applied", migration.Version, migration.Name))
+		}
+	}
+	for _, record := range applied {
+		if record.Phase == constants.MigrationPhaseContract && record.Version > version {
+			problems = append(problems, fmt.Sprintf("schema contracted by migration %d %s, newer than this binary's schema version %d", record.Version, record.Name, version))
+		}
+	}
+	return problems
+}
+
+// uniquePIILookups turns the empty lookups into NULLs and replaces the
+// plain email and phone number lookup indexes with the unique ones of the
+// model. Fresh databases already have them from AutoMigrate.
+func uniquePIILookups(db *gorm.DB) error {
+	for _, column := range []string{"email_lookup", "phone_lookup"} {
+		if err := db.Exec("UPDATE users SET ? = NULL WHERE ? = ''", clause.Column{Name: column}, clause.Column{Name: column}).Error; err != nil {
+			return err
+		}
+
+		index := "idx_users_" + column
+		if db.Migrator().HasIndex(&models.User{}, index) {
+			if err := db.Migrator().DropIndex(&models.User{}, index); err != nil {
+				return err
+			}
+		}
+		if err := db.Migrator().CreateIndex(&models.User{}, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExpandColumn adds the column of model's field unless it exists, e.g. the
+// new column of a rename.
+func ExpandColumn(db *gorm.DB, model any, field string) error {
+	if db.Migrator().HasColumn(model, field) {
+		return nil
+	}
+	return db.Migrator().AddColumn(model, field)
+}
+
+// BackfillColumn copies from into column on the rows where column is still
+// NULL, batchSize rows per statement so a large table isn't locked at once.
+func BackfillColumn(db *gorm.DB, table, column, from string, batchSize int) error {
+	query := "UPDATE " + table + " SET " + column + " = " + from +
+		" WHERE id IN (SELECT id FROM (SELECT id FROM " + table + " WHERE " + column + " IS NULL AND " + from + " IS NOT NULL LIMIT ?) AS batch)"
+	for {
+		result := db.Exec(query, batchSize)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < int64(batchSize) {
+			return nil
+		}
+	}
+}
+
+// ContractColumn drops a column only older binaries use.
+func ContractColumn(db *gorm.DB, table, column string) error {
+	if !db.Migrator().HasColumn(table, column) {
+		return nil
+	}
+	// the migrator's DropColumn needs a model, the old field is long gone
+	return db.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: table}, clause.Column{Name: column}).Error
+}
+
+// ContractTable drops a table only older binaries use.
+func ContractTable(db *gorm.DB, table string) error {
+	return db.Migrator().DropTable(table)
+}
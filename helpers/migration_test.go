@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// renamedRow is a table mid-rename from nickname to display_name.
+type renamedRow struct {
+	ID          int
+	Nickname    *string
+	DisplayName *string
+}
+
+func (*renamedRow) TableName() string {
+	return "renamed_rows"
+}
+
+func TestMigrationsExpandThenContract(t *testing.T) {
+	Logger = logrus.New()
+	Env = map[string]string{
+		"DB_DRIVER":      DBDriverSQLite,
+		"DB_SQLITE_PATH": filepath.Join(t.TempDir(), "test.db"),
+	}
+	t.Cleanup(func() { Env = map[string]string{} })
+	SetupDatabase()
+
+	if problems, err := SchemaProblems(DB); err != nil || len(problems) > 0 {
+		t.Fatalf("expected the startup migration to leave a compatible schema, got %v, err %v", problems, err)
+	}
+	// this binary's own contract migrations, twice as pods may
+	if _, err := MigrateSchema(DB, constants.MigrationPhaseContract); err != nil {
+		t.Fatal(err)
+	}
+	if err := uniquePIILookups(DB); err != nil {
+		t.Fatal(err)
+	}
+	base := SchemaVersion()
+
+	if err := DB.Exec("CREATE TABLE renamed_rows (id integer PRIMARY KEY, nickname text)").Error; err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 5; i++ {
+		if err := DB.Exec("INSERT INTO renamed_rows (id, nickname) VALUES (?, ?)", i, "nick").Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v1 := migrations
+	v2 := append(slices.Clone(v1), Migration{Version: base + 1, Name: "add display_name", Phase: constants.MigrationPhaseExpand, Up: func(db *gorm.DB) error {
+		if err := ExpandColumn(db, &renamedRow{}, "DisplayName"); err != nil {
+			return err
+		}
+		return BackfillColumn(db, "renamed_rows", "display_name", "nickname", 2)
+	}})
+	v3 := append(slices.Clone(v2), Migration{Version: base + 2, Name: "drop nickname", Phase: constants.MigrationPhaseContract, Up: func(db *gorm.DB) error {
+		return ContractColumn(db, "renamed_rows", "nickname")
+	}})
+
+	// a v2 pod can't boot before the expand ran, a v1 pod still can after
+	applied, _ := appliedMigrations(DB)
+	if problems := schemaProblems(applied, v2); len(problems) != 1 {
+		t.Errorf("expected v2 refused before its expand, got %v", problems)
+	}
+	if ran, err := runMigrations(DB, v3, constants.MigrationPhaseContract); err == nil || len(ran) > 0 {
+		t.Errorf("expected the contract refused while the expand is pending, ran %v, err %v", ran, err)
+	}
+	if ran, err := runMigrations(DB, v3, constants.MigrationPhaseExpand); err != nil || len(ran) != 1 || ran[0].Version != base+1 {
+		t.Fatalf("expected only the expand migration, ran %v, err %v", ran, err)
+	}
+	var backfilled int64
+	DB.Table("renamed_rows").Where("display_name = ?", "nick").Count(&backfilled)
+	if backfilled != 5 {
+		t.Errorf("expected every row backfilled, got %d", backfilled)
+	}
+	applied, _ = appliedMigrations(DB)
+	if problems := schemaProblems(applied, v1); len(problems) > 0 {
+		t.Errorf("expected v1 to run against the expanded schema, got %v", problems)
+	}
+	if problems := schemaProblems(applied, v3); len(problems) > 0 {
+		t.Errorf("expected v3 to run with its contract pending, got %v", problems)
+	}
+
+	// once contracted, a rollback to v2 is refused
+	if ran, err := runMigrations(DB, v3, constants.MigrationPhaseContract); err != nil || len(ran) != 1 {
+		t.Fatalf("expected the contract migration, ran %v, err %v", ran, err)
+	}
+	if DB.Migrator().HasColumn("renamed_rows", "nickname") {
+		t.Error("expected nickname dropped")
+	}
+	applied, _ = appliedMigrations(DB)
+	if problems := schemaProblems(applied, v2); len(problems) != 1 {
+		t.Errorf("expected v2 refused after the contract, got %v", problems)
+	}
+	if problems := schemaProblems(applied, v3); len(problems) > 0 {
+		t.Errorf("expected v3 to run after its contract, got %v", problems)
+	}
+
+	if ran, err := runMigrations(DB, v3, constants.MigrationPhaseExpand); err != nil || len(ran) > 0 {
+		t.Errorf("expected nothing left to run, ran %v, err %v", ran, err)
+	}
+	var records int64
+	DB.Model(&models.SchemaMigration{}).Count(&records)
+	if records != int64(base+2) {
+		t.Errorf("expected %d recorded migrations, got %d", base+2, records)
+	}
+}
@@ -0,0 +1,122 @@
+package helpers
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+func NormalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", fmt.Errorf("invalid email: %v", err)
+	}
+
+	// reject display names and comments, only a bare address is accepted
+	if addr.Name != "" || addr.Address != email {
+		return "", fmt.Errorf("invalid email: %q", email)
+	}
+
+	return email, nil
+}
+
+// NormalizePhoneNumber converts a phone number to E.164 (+<country><number>).
+// Local numbers starting with 0 get PHONE_DEFAULT_COUNTRY_CODE.
+func NormalizePhoneNumber(phone string) (string, error) {
+	digits := strings.Builder{}
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("invalid phone number: %q", phone)
+		}
+	}
+
+	number := digits.String()
+	hasPlus := strings.HasPrefix(strings.TrimSpace(phone), "+")
+	if !hasPlus && strings.HasPrefix(number, "0") {
+		number = GetEnv("PHONE_DEFAULT_COUNTRY_CODE", "62") + strings.TrimPrefix(number, "0")
+	}
+
+	if len(number) < 8 || len(number) > 15 || strings.HasPrefix(number, "0") {
+		return "", fmt.Errorf("invalid phone number: %q", phone)
+	}
+
+	return "+" + number, nil
+}
+
+// postalCodeFormat is the canonical form of a country's postal codes. With a
+// sep, codes are compared without spaces and hyphens and sep is put back
+// before the last tail characters, so "sw1a1aa" becomes "SW1A 1AA".
+type postalCodeFormat struct {
+	pattern *regexp.Regexp
+	sep     string
+	tail    int
+}
+
+// postalCodeFormats by ISO 3166-1 alpha-2 country, nil for countries without
+// postal codes. Other countries take any short alphanumeric code.
+var postalCodeFormats = map[string]*postalCodeFormat{
+	"AE": nil,
+	"AU": {pattern: regexp.MustCompile(`^[0-9]{4}$`)},
+	"CA": {pattern: regexp.MustCompile(`^[A-Z][0-9][A-Z] [0-9][A-Z][0-9]$`), sep: " ", tail: 3},
+	"DE": {pattern: regexp.MustCompile(`^[0-9]{5}$`)},
+	"FR": {pattern: regexp.MustCompile(`^[0-9]{5}$`)},
+	"GB": {pattern: regexp.MustCompile(`^[A-Z]{1,2}[0-9][A-Z0-9]? [0-9][A-Z]{2}$`), sep: " ", tail: 3},
+	"HK": nil,
+	"ID": {pattern: regexp.MustCompile(`^[0-9]{5}$`)},
+	"IN": {pattern: regexp.MustCompile(`^[0-9]{6}$`)},
+	"JP": {pattern: regexp.MustCompile(`^[0-9]{3}-[0-9]{4}$`), sep: "-", tail: 4},
+	"MY": {pattern: regexp.MustCompile(`^[0-9]{5}$`)},
+	"NL": {pattern: regexp.MustCompile(`^[0-9]{4} [A-Z]{2}$`), sep: " ", tail: 2},
+	"PH": {pattern: regexp.MustCompile(`^[0-9]{4}$`)},
+	"SG": {pattern: regexp.MustCompile(`^[0-9]{6}$`)},
+	"TH": {pattern: regexp.MustCompile(`^[0-9]{5}$`)},
+	"US": {pattern: regexp.MustCompile(`^[0-9]{5}(-[0-9]{4})?$`)},
+	"VN": {pattern: regexp.MustCompile(`^[0-9]{6}$`)},
+}
+
+var genericPostalCode = regexp.MustCompile(`^[A-Z0-9]([A-Z0-9 -]{0,8}[A-Z0-9])?$`)
+
+// NormalizePostalCode checks a postal code against the format of country and
+// returns it in canonical form. Countries with a known format require one,
+// countries without postal codes require none.
+func NormalizePostalCode(country, code string) (string, error) {
+	code = strings.Join(strings.Fields(strings.ToUpper(code)), " ")
+
+	format, known := postalCodeFormats[country]
+	switch {
+	case !known:
+		if code == "" || genericPostalCode.MatchString(code) {
+			return code, nil
+		}
+	case format == nil:
+		if code == "" {
+			return "", nil
+		}
+	default:
+		if format.sep != "" {
+			code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+			if len(code) > format.tail {
+				code = code[:len(code)-format.tail] + format.sep + code[len(code)-format.tail:]
+			}
+		}
+		if format.pattern.MatchString(code) {
+			return code, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid postal code for %s: %q", country, code)
+}
+
+// NormalizeSecurityAnswer makes security answers match regardless of case
+// and spacing, "New  York" and "new york" are the same answer.
+func NormalizeSecurityAnswer(answer string) string {
+	return strings.Join(strings.Fields(strings.ToLower(answer)), " ")
+}
@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func FuzzNormalizeEmail(f *testing.F) {
+	for _, seed := range []string{
+		"user@example.com",
+		"  User@Example.COM ",
+		"User <user@example.com>",
+		"user@",
+		"@example.com",
+		"a\"b@example.com",
+		"user@exa\x00mple.com",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, email string) {
+		normalized, err := NormalizeEmail(email)
+		if err != nil {
+			return
+		}
+		if normalized != strings.ToLower(normalized) || strings.TrimSpace(normalized) != normalized {
+			t.Fatalf("normalized email %q is not lowercase and trimmed", normalized)
+		}
+		again, err := NormalizeEmail(normalized)
+		if err != nil || again != normalized {
+			t.Fatalf("normalization not idempotent: %q -> %q -> %q (%v)", email, normalized, again, err)
+		}
+	})
+}
+
+func FuzzNormalizePhoneNumber(f *testing.F) {
+	e164 := regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+	for _, seed := range []string{
+		"081234567890",
+		"+62 812-3456-7890",
+		"(021) 555.1234",
+		"62812345678",
+		"+0000",
+		"++62812345678",
+		"0812abc",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, phone string) {
+		normalized, err := NormalizePhoneNumber(phone)
+		if err != nil {
+			return
+		}
+		if !e164.MatchString(normalized) {
+			t.Fatalf("normalized phone %q from %q is not E.164", normalized, phone)
+		}
+		again, err := NormalizePhoneNumber(normalized)
+		if err != nil || again != normalized {
+			t.Fatalf("normalization not idempotent: %q -> %q -> %q (%v)", phone, normalized, again, err)
+		}
+	})
+}
+
+func FuzzNormalizePostalCode(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"ID", "12190"},
+		{"GB", "sw1a1aa"},
+		{"CA", "k1a 0b1"},
+		{"JP", "1000001"},
+		{"NL", "1012-ab"},
+		{"US", "12345-6789"},
+		{"HK", ""},
+		{"HK", "999077"},
+		{"BR", "01310-100"},
+		{"ID", "1219"},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+
+	f.Fuzz(func(t *testing.T, country, code string) {
+		normalized, err := NormalizePostalCode(country, code)
+		if err != nil {
+			return
+		}
+		if format := postalCodeFormats[country]; format != nil && !format.pattern.MatchString(normalized) {
+			t.Fatalf("normalized postal code %q from %q doesn't match the format of %s", normalized, code, country)
+		}
+		again, err := NormalizePostalCode(country, normalized)
+		if err != nil || again != normalized {
+			t.Fatalf("normalization not idempotent: %q -> %q -> %q (%v)", code, normalized, again, err)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	BcryptQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "bcrypt_queue_depth",
+		Help: "Number of bcrypt operations waiting for a worker slot",
+	})
+	BcryptInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "bcrypt_in_flight",
+		Help: "Number of bcrypt operations currently running",
+	})
+)
+
+// PasswordHasher bounds how many bcrypt operations run at once so a login
+// spike can't saturate every core.
+type PasswordHasher struct {
+	slots chan struct{}
+}
+
+func NewPasswordHasher(concurrency int) *PasswordHasher {
+	if concurrency <= 0 {
+		concurrency = max(runtime.NumCPU()/2, 1)
+	}
+	return &PasswordHasher{
+		slots: make(chan struct{}, concurrency),
+	}
+}
+
+func (h *PasswordHasher) acquire(ctx context.Context) error {
+	BcryptQueueDepth.Inc()
+	defer BcryptQueueDepth.Dec()
+
+	select {
+	case h.slots <- struct{}{}:
+		BcryptInFlight.Inc()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *PasswordHasher) release() {
+	BcryptInFlight.Dec()
+	<-h.slots
+}
+
+func (h *PasswordHasher) HashPassword(ctx context.Context, password string) (string, error) {
+	if err := h.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer h.release()
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashPassword), nil
+}
+
+func (h *PasswordHasher) ComparePassword(ctx context.Context, hashPassword, password string) error {
+	if err := h.acquire(ctx); err != nil {
+		return err
+	}
+	defer h.release()
+
+	return bcrypt.CompareHashAndPassword([]byte(hashPassword), []byte(password))
+}
@@ -0,0 +1,404 @@
+package helpers
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// piiPrefix marks an encrypted column value: pii:<data key id>:<base64 nonce+ciphertext>.
+// Values without it are legacy plaintext and are read as-is until re-encrypted.
+const piiPrefix = "pii:"
+
+// PII is the keyring used by the `pii` GORM serializer. When nil, PII
+// columns are stored in plaintext (local development and tests).
+var PII *PIIKeyring
+
+// piiLookupKey is read when used, a package level var would be initialized
+// before SetupConfig loaded the key and hash with an empty one.
+func piiLookupKey() []byte {
+	return []byte(GetEnv("PII_LOOKUP_KEY", GetEnv("APP_SECRET", "")))
+}
+
+// ValidatePIIKeys checks the blind index key is set, without it PIILookup is
+// a plain hash anyone can recompute from a phone number or an OTP.
+func ValidatePIIKeys() error {
+	if len(piiLookupKey()) == 0 {
+		return fmt.Errorf("PII_LOOKUP_KEY is not set and there is no APP_SECRET to default to")
+	}
+	return nil
+}
+
+func init() {
+	schema.RegisterSerializer("pii", PIISerializer{})
+}
+
+// PIILookup is the blind index of an encrypted column value: ciphertexts use
+// a random nonce, so equality lookups go through this keyed hash instead.
+// Values are expected to be normalized already.
+func PIILookup(value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, piiLookupKey())
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HashActor is the audit actor as sent to the error tracker, a keyed hash
+// that support can compute for a known user but the tracker can't reverse.
+func HashActor(actor string) string {
+	return PIILookup("actor:" + actor)[:16]
+}
+
+// KeyEncrypter wraps data keys with a master key held in a KMS.
+type KeyEncrypter interface {
+	// KeyID identifies the master key new data keys are wrapped with.
+	KeyID() string
+	Wrap(ctx context.Context, dataKey []byte) ([]byte, error)
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyEncrypter is a KeyEncrypter backed by AES-256 master keys from the
+// environment, for deployments without a KMS and for local runs.
+type LocalKeyEncrypter struct {
+	ActiveKeyID string
+	MasterKeys  map[string][]byte
+}
+
+// NewLocalKeyEncrypter parses PII_MASTER_KEYS ("id:base64key,id:base64key").
+func NewLocalKeyEncrypter(keys, activeKeyID string) (*LocalKeyEncrypter, error) {
+	e := &LocalKeyEncrypter{ActiveKeyID: activeKeyID, MasterKeys: map[string][]byte{}}
+	for _, entry := range strings.Split(keys, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid master key entry %q", entry)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("master key %q must be 32 base64-encoded bytes", id)
+		}
+		e.MasterKeys[id] = key
+	}
+	if _, ok := e.MasterKeys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active master key %q not found", activeKeyID)
+	}
+	return e, nil
+}
+
+func (e *LocalKeyEncrypter) KeyID() string {
+	return e.ActiveKeyID
+}
+
+func (e *LocalKeyEncrypter) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	return seal(e.MasterKeys[e.ActiveKeyID], dataKey)
+}
+
+func (e *LocalKeyEncrypter) Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := e.MasterKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown master key %q", keyID)
+	}
+	return open(key, wrapped)
+}
+
+// PIIKeyring holds the unwrapped data keys and encrypts PII columns with the
+// active one. Keys rotated by another instance are picked up lazily.
+type PIIKeyring struct {
+	DB        *gorm.DB
+	Encrypter KeyEncrypter
+
+	mu          sync.RWMutex
+	keys        map[int][]byte
+	active      int
+	refreshedAt time.Time
+}
+
+func SetupPII() {
+	keys := GetEnv("PII_MASTER_KEYS", "")
+	if keys == "" {
+		if GetEnv("APP_ENV", "") == "production" {
+			log.Fatal("PII_MASTER_KEYS is required when APP_ENV is production")
+		}
+		logrus.Warn("PII_MASTER_KEYS is not set, PII columns are stored in plaintext")
+		return
+	}
+
+	encrypter, err := NewLocalKeyEncrypter(keys, GetEnv("PII_ACTIVE_MASTER_KEY", ""))
+	if err != nil {
+		log.Fatal("failed to load pii master keys: ", err)
+	}
+
+	PII = &PIIKeyring{DB: DB, Encrypter: encrypter, keys: map[int][]byte{}}
+	if err := PII.refresh(context.Background()); err != nil {
+		log.Fatal("failed to load pii data keys: ", err)
+	}
+	if PII.active == 0 {
+		if _, err := PII.RotateDataKey(context.Background()); err != nil {
+			log.Fatal("failed to create pii data key: ", err)
+		}
+	}
+
+	logrus.Info("Successfully load pii keyring")
+}
+
+// ActivePrefix is the prefix of values encrypted with the active data key;
+// anything else is due for re-encryption.
+func (k *PIIKeyring) ActivePrefix(ctx context.Context) (string, error) {
+	active, _, err := k.activeKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return piiPrefix + strconv.Itoa(active) + ":", nil
+}
+
+func (k *PIIKeyring) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	id, key, err := k.activeKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(key, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return piiPrefix + strconv.Itoa(id) + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (k *PIIKeyring) Decrypt(ctx context.Context, value string) (string, error) {
+	if !strings.HasPrefix(value, piiPrefix) {
+		return value, nil
+	}
+
+	idPart, encoded, ok := strings.Cut(strings.TrimPrefix(value, piiPrefix), ":")
+	id, err := strconv.Atoi(idPart)
+	if !ok || err != nil {
+		return "", fmt.Errorf("malformed pii value")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed pii value: %v", err)
+	}
+
+	key, err := k.dataKey(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(key, sealed)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt pii value with data key %d: %v", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// RotateDataKey creates a new data key and makes it the active one. Values
+// under older keys stay readable until re-encrypted.
+func (k *PIIKeyring) RotateDataKey(ctx context.Context) (int, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return 0, err
+	}
+	wrapped, err := k.Encrypter.Wrap(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to wrap data key: %v", err)
+	}
+
+	dataKey := models.PIIDataKey{MasterKeyID: k.Encrypter.KeyID(), WrappedKey: wrapped, Active: true}
+	err = k.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PIIDataKey{}).Where("active = ?", true).Update("active", false).Error; err != nil {
+			return err
+		}
+		return tx.Create(&dataKey).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to store data key: %v", err)
+	}
+
+	k.mu.Lock()
+	k.keys[dataKey.ID] = key
+	k.active = dataKey.ID
+	k.refreshedAt = time.Now()
+	k.mu.Unlock()
+
+	return dataKey.ID, nil
+}
+
+// RewrapDataKeys re-wraps every data key not yet under the active master
+// key, so a retired master key can be deleted from the KMS.
+func (k *PIIKeyring) RewrapDataKeys(ctx context.Context) (int, error) {
+	var dataKeys []models.PIIDataKey
+	err := k.DB.WithContext(ctx).Where("master_key_id <> ?", k.Encrypter.KeyID()).Find(&dataKeys).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, dataKey := range dataKeys {
+		key, err := k.Encrypter.Unwrap(ctx, dataKey.MasterKeyID, dataKey.WrappedKey)
+		if err != nil {
+			return 0, fmt.Errorf("failed to unwrap data key %d: %v", dataKey.ID, err)
+		}
+		wrapped, err := k.Encrypter.Wrap(ctx, key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to wrap data key %d: %v", dataKey.ID, err)
+		}
+		err = k.DB.WithContext(ctx).Model(&dataKey).Updates(map[string]any{
+			"master_key_id": k.Encrypter.KeyID(),
+			"wrapped_key":   wrapped,
+		}).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(dataKeys), nil
+}
+
+func (k *PIIKeyring) activeKey(ctx context.Context) (int, []byte, error) {
+	k.mu.RLock()
+	id, key, stale := k.active, k.keys[k.active], time.Since(k.refreshedAt) > time.Minute
+	k.mu.RUnlock()
+
+	if id != 0 && !stale {
+		return id, key, nil
+	}
+	if err := k.refresh(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.active == 0 {
+		return 0, nil, fmt.Errorf("no active pii data key")
+	}
+	return k.active, k.keys[k.active], nil
+}
+
+func (k *PIIKeyring) dataKey(ctx context.Context, id int) ([]byte, error) {
+	k.mu.RLock()
+	key, ok := k.keys[id]
+	k.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if key, ok = k.keys[id]; !ok {
+		return nil, fmt.Errorf("unknown pii data key %d", id)
+	}
+	return key, nil
+}
+
+// refresh unwraps any data keys not yet cached and re-reads the active one.
+func (k *PIIKeyring) refresh(ctx context.Context) error {
+	var dataKeys []models.PIIDataKey
+	if err := k.DB.WithContext(ctx).Order("id").Find(&dataKeys).Error; err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, dataKey := range dataKeys {
+		if _, ok := k.keys[dataKey.ID]; !ok {
+			key, err := k.Encrypter.Unwrap(ctx, dataKey.MasterKeyID, dataKey.WrappedKey)
+			if err != nil {
+				return fmt.Errorf("failed to unwrap data key %d: %v", dataKey.ID, err)
+			}
+			k.keys[dataKey.ID] = key
+		}
+		if dataKey.Active {
+			k.active = dataKey.ID
+		}
+	}
+	k.refreshedAt = time.Now()
+	return nil
+}
+
+// PIISerializer encrypts string columns tagged `serializer:pii` with the
+// PII keyring.
+type PIISerializer struct{}
+
+func (PIISerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	default:
+		return fmt.Errorf("unsupported pii column type %T", dbValue)
+	}
+
+	if PII != nil {
+		var err error
+		if value, err = PII.Decrypt(ctx, value); err != nil {
+			return err
+		}
+	}
+	return field.Set(ctx, dst, value)
+}
+
+func (PIISerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("unsupported pii field type %T", fieldValue)
+	}
+	if PII == nil || value == "" {
+		return value, nil
+	}
+	return PII.Encrypt(ctx, value)
+}
+
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,254 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestPIILookupKey(t *testing.T) {
+	t.Cleanup(func() { Env = map[string]string{} })
+
+	hash := func(key, value string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(value))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	// the key set by SetupConfig is used, not the one at package init
+	Env = map[string]string{"PII_LOOKUP_KEY": "lookup-key", "APP_SECRET": "app-secret"}
+	if got := PIILookup("+628123456789"); got != hash("lookup-key", "+628123456789") {
+		t.Errorf("got %s, want the lookup keyed with PII_LOOKUP_KEY", got)
+	}
+	if PIILookup("+628123456789") == hash("", "+628123456789") {
+		t.Error("expected the lookup to differ from an unkeyed hash")
+	}
+	if err := ValidatePIIKeys(); err != nil {
+		t.Errorf("got %v, want no error", err)
+	}
+
+	Env = map[string]string{"APP_SECRET": "app-secret"}
+	if got := PIILookup("user@example.com"); got != hash("app-secret", "user@example.com") {
+		t.Errorf("got %s, want the lookup keyed with APP_SECRET", got)
+	}
+
+	Env = map[string]string{}
+	if err := ValidatePIIKeys(); err == nil {
+		t.Error("expected an error without a lookup key")
+	}
+}
+
+// setupPIIKeyring opens a fresh SQLite database and a keyring whose master
+// keys are the listed ids, each 32 bytes of its first letter.
+func setupPIIKeyring(t *testing.T, masterKeyIDs []string, active string) *PIIKeyring {
+	t.Helper()
+	Logger = logrus.New()
+	Logger.SetLevel(logrus.PanicLevel)
+	Env = map[string]string{
+		"DB_DRIVER":      DBDriverSQLite,
+		"DB_SQLITE_PATH": filepath.Join(t.TempDir(), "test.db"),
+	}
+	t.Cleanup(func() { Env = map[string]string{}; PII = nil })
+	SetupDatabase()
+
+	return newPIIKeyring(t, masterKeyIDs, active)
+}
+
+// newPIIKeyring is another instance on the same database, with its own
+// cache of unwrapped data keys.
+func newPIIKeyring(t *testing.T, masterKeyIDs []string, active string) *PIIKeyring {
+	t.Helper()
+	entries := []string{}
+	for _, id := range masterKeyIDs {
+		entries = append(entries, id+":"+base64.StdEncoding.EncodeToString(bytes.Repeat([]byte(id[:1]), 32)))
+	}
+	encrypter, err := NewLocalKeyEncrypter(strings.Join(entries, ","), active)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyring := &PIIKeyring{DB: DB, Encrypter: encrypter, keys: map[int][]byte{}}
+	if err := keyring.refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if keyring.active == 0 {
+		if _, err := keyring.RotateDataKey(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return keyring
+}
+
+func TestPIIEncryptRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	keyring := setupPIIKeyring(t, []string{"master"}, "master")
+
+	prefix, err := keyring.ActivePrefix(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := keyring.Encrypt(ctx, "user@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := keyring.Encrypt(ctx, "user@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(first, prefix) || strings.Contains(first, "user@example.com") {
+		t.Errorf("got %q, want a ciphertext under %q", first, prefix)
+	}
+	if first == second {
+		t.Error("expected a fresh nonce per encryption")
+	}
+
+	for _, value := range []string{first, second} {
+		if got, err := keyring.Decrypt(ctx, value); err != nil || got != "user@example.com" {
+			t.Errorf("got %q, err %v, want the plaintext back", got, err)
+		}
+	}
+	// rows written before encryption are read as they are
+	if got, err := keyring.Decrypt(ctx, "legacy@example.com"); err != nil || got != "legacy@example.com" {
+		t.Errorf("got %q, err %v, want the legacy plaintext", got, err)
+	}
+}
+
+func TestPIITamperedCiphertext(t *testing.T) {
+	ctx := context.Background()
+	keyring := setupPIIKeyring(t, []string{"master"}, "master")
+
+	value, err := keyring.Encrypt(ctx, "+628123456789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cut := strings.LastIndex(value, ":") + 1
+	sealed, err := base64.RawStdEncoding.DecodeString(value[cut:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flipped := bytes.Clone(sealed)
+	flipped[len(flipped)-1] ^= 1
+	tampered := map[string]string{
+		"flipped bit":   value[:cut] + base64.RawStdEncoding.EncodeToString(flipped),
+		"truncated":     value[:cut] + base64.RawStdEncoding.EncodeToString(sealed[:8]),
+		"other key id":  "pii:99:" + value[cut:],
+		"bad key id":    "pii:x:" + value[cut:],
+		"not base64":    value[:cut] + "!!!",
+		"missing colon": "pii:" + value[cut:],
+	}
+	for name, value := range tampered {
+		if got, err := keyring.Decrypt(ctx, value); err == nil {
+			t.Errorf("%s: got %q, want an error", name, got)
+		}
+	}
+}
+
+func TestPIIRotatedKeys(t *testing.T) {
+	ctx := context.Background()
+	keyring := setupPIIKeyring(t, []string{"old"}, "old")
+
+	before, err := keyring.Encrypt(ctx, "before@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keyring.RotateDataKey(ctx); err != nil {
+		t.Fatal(err)
+	}
+	after, err := keyring.Encrypt(ctx, "after@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix, _ := keyring.ActivePrefix(ctx)
+	if strings.HasPrefix(before, prefix) || !strings.HasPrefix(after, prefix) {
+		t.Fatalf("got %q and %q, want only the second under the active %q", before, after, prefix)
+	}
+
+	// another instance unwraps the rotated-out data key on first use
+	other := newPIIKeyring(t, []string{"old"}, "old")
+	if got, err := other.Decrypt(ctx, before); err != nil || got != "before@example.com" {
+		t.Errorf("got %q, err %v, want a value under the rotated-out data key readable", got, err)
+	}
+
+	// once re-wrapped, the old master key can be dropped
+	rewrapping := newPIIKeyring(t, []string{"old", "new"}, "new")
+	if rewrapped, err := rewrapping.RewrapDataKeys(ctx); err != nil || rewrapped != 2 {
+		t.Fatalf("got %d re-wrapped, err %v, want both data keys", rewrapped, err)
+	}
+	retired := newPIIKeyring(t, []string{"new"}, "new")
+	for value, want := range map[string]string{before: "before@example.com", after: "after@example.com"} {
+		if got, err := retired.Decrypt(ctx, value); err != nil || got != want {
+			t.Errorf("got %q, err %v, want %q without the old master key", got, err, want)
+		}
+	}
+}
+
+// piiRow is a table with a PII column, written through the serializer.
+type piiRow struct {
+	ID    int
+	Email string `gorm:"serializer:pii"`
+}
+
+func (*piiRow) TableName() string {
+	return "pii_rows"
+}
+
+func TestPIISerializer(t *testing.T) {
+	keyring := setupPIIKeyring(t, []string{"master"}, "master")
+	if err := DB.AutoMigrate(&piiRow{}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := func(id int) string {
+		var value string
+		DB.Raw("SELECT email FROM pii_rows WHERE id = ?", id).Scan(&value)
+		return value
+	}
+
+	// without a keyring the column is plaintext
+	plain := piiRow{Email: "plain@example.com"}
+	if err := DB.Create(&plain).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got := raw(plain.ID); got != "plain@example.com" {
+		t.Errorf("got %q stored without a keyring, want plaintext", got)
+	}
+
+	PII = keyring
+	encrypted := piiRow{Email: "secret@example.com"}
+	empty := piiRow{}
+	if err := DB.Create(&encrypted).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := DB.Create(&empty).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got := raw(encrypted.ID); !strings.HasPrefix(got, piiPrefix) || strings.Contains(got, "secret") {
+		t.Errorf("got %q stored, want a ciphertext", got)
+	}
+	if got := raw(empty.ID); got != "" {
+		t.Errorf("got %q stored for an empty value, want it left empty", got)
+	}
+
+	rows := []piiRow{}
+	if err := DB.Order("id").Find(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"plain@example.com", "secret@example.com", ""}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		if row.Email != want[i] {
+			t.Errorf("row %d: got %q, want %q", row.ID, row.Email, want[i])
+		}
+	}
+}
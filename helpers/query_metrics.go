@@ -0,0 +1,155 @@
+package helpers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+var DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "db_query_duration_seconds",
+	Help:    "Database query durations by table and operation",
+	Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"table", "operation"})
+
+var DBSlowQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "db_slow_queries_total",
+	Help: "Database queries slower than DB_SLOW_QUERY_MS, by table and operation",
+}, []string{"table", "operation"})
+
+var DBRepeatedQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "db_repeated_queries_total",
+	Help: "Requests that ran the same query at least DB_REPEATED_QUERY_THRESHOLD times, a likely N+1, by table",
+}, []string{"table"})
+
+const queryStartKey = "query_metrics:start"
+
+// QueryMetrics is a GORM plugin timing every query. Queries slower than
+// SlowThreshold are logged with their request id; the SQL is logged with
+// its placeholders, never the values, so no PII ends up in the logs.
+type QueryMetrics struct {
+	SlowThreshold time.Duration
+}
+
+func (p *QueryMetrics) Name() string {
+	return "query_metrics"
+}
+
+func (p *QueryMetrics) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register("query_metrics:before_create", p.before),
+		callback.Create().After("gorm:create").Register("query_metrics:after_create", p.after("create")),
+		callback.Query().Before("gorm:query").Register("query_metrics:before_query", p.before),
+		callback.Query().After("gorm:query").Register("query_metrics:after_query", p.after("query")),
+		callback.Update().Before("gorm:update").Register("query_metrics:before_update", p.before),
+		callback.Update().After("gorm:update").Register("query_metrics:after_update", p.after("update")),
+		callback.Delete().Before("gorm:delete").Register("query_metrics:before_delete", p.before),
+		callback.Delete().After("gorm:delete").Register("query_metrics:after_delete", p.after("delete")),
+		callback.Row().Before("gorm:row").Register("query_metrics:before_row", p.before),
+		callback.Row().After("gorm:row").Register("query_metrics:after_row", p.after("row")),
+		callback.Raw().Before("gorm:raw").Register("query_metrics:before_raw", p.before),
+		callback.Raw().After("gorm:raw").Register("query_metrics:after_raw", p.after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *QueryMetrics) before(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func (p *QueryMetrics) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		elapsed := time.Since(value.(time.Time))
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		DBQueryDuration.WithLabelValues(table, operation).Observe(elapsed.Seconds())
+
+		ctx := db.Statement.Context
+		sql := db.Statement.SQL.String()
+		if stats := queryStatsFrom(ctx); stats != nil {
+			stats.record(table, sql)
+		}
+
+		if p.SlowThreshold > 0 && elapsed >= p.SlowThreshold {
+			DBSlowQueries.WithLabelValues(table, operation).Inc()
+			Logger.WithFields(map[string]any{
+				"request_id":  RequestID(ctx),
+				"table":       table,
+				"operation":   operation,
+				"duration_ms": elapsed.Milliseconds(),
+				"rows":        db.RowsAffected,
+				"sql":         sql,
+			}).Warn("slow query")
+		}
+	}
+}
+
+type queryStatsKey struct{}
+
+// QueryStats counts the queries run for one request, to spot N+1 loops
+// running the same statement over and over.
+type QueryStats struct {
+	mu     sync.Mutex
+	counts map[string]int
+	tables map[string]string
+}
+
+// WithQueryStats starts counting the queries run with ctx.
+func WithQueryStats(ctx context.Context) (context.Context, *QueryStats) {
+	stats := &QueryStats{counts: map[string]int{}, tables: map[string]string{}}
+	return context.WithValue(ctx, queryStatsKey{}, stats), stats
+}
+
+func queryStatsFrom(ctx context.Context) *QueryStats {
+	if ctx == nil {
+		return nil
+	}
+	stats, _ := ctx.Value(queryStatsKey{}).(*QueryStats)
+	return stats
+}
+
+func (s *QueryStats) record(table, sql string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[sql]++
+	s.tables[sql] = table
+}
+
+// Report logs every statement run at least threshold times, e.g. a session
+// lookup per item of a list.
+func (s *QueryStats) Report(requestID string, threshold int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if threshold <= 0 {
+		return
+	}
+	for sql, count := range s.counts {
+		if count < threshold {
+			continue
+		}
+		DBRepeatedQueries.WithLabelValues(s.tables[sql]).Inc()
+		Logger.WithFields(map[string]any{
+			"request_id": requestID,
+			"table":      s.tables[sql],
+			"count":      count,
+			"sql":        sql,
+		}).Warn("query repeated within one request, likely n+1")
+	}
+}
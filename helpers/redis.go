@@ -0,0 +1,25 @@
+package helpers
+
+import (
+	"context"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+var Redis *redis.Client
+
+func SetupRedis() {
+	Redis = redis.NewClient(&redis.Options{
+		Addr:     GetEnv("REDIS_HOST", "127.0.0.1") + ":" + GetEnv("REDIS_PORT", "6379"),
+		Password: GetEnv("REDIS_PASSWORD", ""),
+		DB:       GetEnvInt("REDIS_DB", 0),
+	})
+
+	if err := Redis.Ping(context.Background()).Err(); err != nil {
+		log.Fatal("failed to connect to redis: ", err)
+	}
+
+	logrus.Info("Successfully connect to redis")
+}
@@ -0,0 +1,23 @@
+package helpers
+
+import (
+	"context"
+	"crypto/rand"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID tags ctx with the request id, or a new one when id is empty.
+func WithRequestID(ctx context.Context, id string) (context.Context, string) {
+	if id == "" || len(id) > 128 {
+		id = rand.Text()
+	}
+	return context.WithValue(ctx, requestIDKey{}, id), id
+}
+
+// RequestID returns the request id ctx was tagged with, empty outside a
+// request.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
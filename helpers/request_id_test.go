@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWithRequestID(t *testing.T) {
+	ctx, id := WithRequestID(context.Background(), "abc-123")
+	if id != "abc-123" || RequestID(ctx) != "abc-123" {
+		t.Fatalf("expected caller's request id, got %q", RequestID(ctx))
+	}
+
+	ctx, id = WithRequestID(context.Background(), "")
+	if id == "" || RequestID(ctx) != id {
+		t.Fatalf("expected a generated request id, got %q", RequestID(ctx))
+	}
+
+	_, id = WithRequestID(context.Background(), strings.Repeat("x", 200))
+	if len(id) > 128 {
+		t.Fatal("expected an oversized request id to be replaced")
+	}
+
+	if RequestID(context.Background()) != "" {
+		t.Fatal("expected no request id outside a request")
+	}
+}
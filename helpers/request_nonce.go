@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// requestNoncePattern wants at least 128 bits of a random id, e.g. a UUID or
+// base64url bytes, and nothing that could mangle a redis key or a log line.
+var requestNoncePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{16,128}$`)
+
+func ValidRequestNonce(nonce string) bool {
+	return requestNoncePattern.MatchString(nonce)
+}
+
+// RequestTimestampFresh reports whether value, in unix seconds, is within
+// window of now either way, so clients with a slightly fast clock aren't
+// turned away.
+func RequestTimestampFresh(value string, now time.Time, window time.Duration) bool {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	skew := now.Sub(time.Unix(seconds, 0))
+	return skew <= window && skew >= -window
+}
+
+// RequestSigningKey is the key a session signs its sensitive requests with,
+// handed out with the access token. It is derived from the token's jti so it
+// needn't be stored and changes with every refresh.
+func RequestSigningKey(tokenID string) string {
+	mac := hmac.New(sha256.New, []byte(GetEnv("REQUEST_SIGNING_SECRET", GetEnv("APP_SECRET", ""))))
+	mac.Write([]byte("request-signing:" + tokenID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignNoncedRequest is the hex HMAC-SHA256, keyed with the session's request
+// signing key, over
+// "<METHOD>\n<request uri>\n<hex sha256 of body>\n<nonce>\n<timestamp>".
+func SignNoncedRequest(key, method, uri string, body []byte, nonce, timestamp string) string {
+	bodyHash := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(method + "\n" + uri + "\n" + hex.EncodeToString(bodyHash[:]) + "\n" + nonce + "\n" + timestamp))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyNoncedRequest checks signature against req, its nonce and timestamp.
+// The body is read and replaced for the handler.
+func VerifyNoncedRequest(req *http.Request, key, nonce, timestamp, signature string) bool {
+	body, err := readBody(req)
+	if err != nil {
+		return false
+	}
+	expected := SignNoncedRequest(key, req.Method, req.URL.RequestURI(), body, nonce, timestamp)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -1,17 +1,33 @@
 package helpers
 
-import "github.com/gin-gonic/gin"
+import (
+	"ewallet-ums/internal/apperr"
+
+	"github.com/gin-gonic/gin"
+)
 
 type Response struct {
+	// Code identifies catalogued messages whatever language Message is in.
+	Code    string `json:"code,omitempty"`
 	Message string `json:"message"`
 	Data    any    `json:"data,omitempty"`
 }
 
+// SendResponseHTTP responds with message translated to the request's locale
+// when it is in the message catalog.
 func SendResponseHTTP(c *gin.Context, code int, message string, data any) {
 	resp := Response{
-		Message: message,
-		Data:    data,
+		Data: data,
 	}
+	resp.Code, resp.Message = localizeMessage(c, message)
 
 	c.JSON(code, resp)
 }
+
+// SendErrorHTTP responds with the status and generic message of err's kind,
+// see apperr.HTTPStatus. err is kept on the context for error reporting.
+func SendErrorHTTP(c *gin.Context, err error) {
+	_ = c.Error(err)
+	code, message := apperr.HTTPStatus(err)
+	SendResponseHTTP(c, code, message, nil)
+}
@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	SecurityEventTokenIssued           = "token.issued"
+	SecurityEventTokenValidationFailed = "token.validation_failed"
+	SecurityEventTokenRevoked          = "token.revoked"
+)
+
+// Reasons a token fails validation, in SecurityEvent.Reason.
+const (
+	TokenFailureInvalid       = "invalid"
+	TokenFailureExpired       = "expired"
+	TokenFailureRevoked       = "revoked"
+	TokenFailureNoSession     = "no_session"
+	TokenFailureWrongDevice   = "wrong_device"
+	TokenFailureWrongType     = "wrong_type"
+	TokenFailureWrongAudience = "wrong_audience"
+)
+
+// SecurityEvent is a token lifecycle event for a SIEM. It never carries the
+// token itself, only its jti.
+type SecurityEvent struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	UserID     int       `json:"user_id,omitempty"`
+	TokenID    string    `json:"token_id,omitempty"`
+	TokenType  string    `json:"token_type,omitempty"`
+	ClientID   string    `json:"client_id,omitempty"`
+	Audience   string    `json:"audience,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at,omitzero"`
+	Reason     string    `json:"reason,omitempty"`
+	Actor      string    `json:"actor,omitempty"`
+	RequestID  string    `json:"request_id,omitempty"`
+}
+
+// SecurityEventSink takes security events off the request path. Emit must
+// not block; a sink that can't keep up drops events rather than slowing down
+// logins.
+type SecurityEventSink interface {
+	Emit(event SecurityEvent)
+}
+
+var securityEventSink SecurityEventSink
+
+// SetSecurityEventSink sets where EmitSecurityEvent sends events. Set it at
+// startup, before any token is issued; nil turns events off.
+func SetSecurityEventSink(sink SecurityEventSink) {
+	securityEventSink = sink
+}
+
+// EmitSecurityEvent fills in the time, request id and actor of event from
+// ctx and hands it to the sink, if there is one.
+func EmitSecurityEvent(ctx context.Context, event SecurityEvent) {
+	if securityEventSink == nil {
+		return
+	}
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if event.RequestID == "" {
+		event.RequestID = RequestID(ctx)
+	}
+	if event.Actor == "" {
+		event.Actor, _ = ActorFromContext(ctx)
+	}
+	securityEventSink.Emit(event)
+}
+
+// EmitTokenValidationFailed reports a token turned away for reason. claim is
+// nil when the token couldn't be parsed.
+func EmitTokenValidationFailed(ctx context.Context, reason string, claim *ClaimToken) {
+	event := SecurityEvent{Type: SecurityEventTokenValidationFailed, Reason: reason}
+	if claim != nil {
+		event.UserID, event.TokenID, event.ClientID = claim.UserID, claim.ID, claim.ClientID
+		if claim.ExpiresAt != nil {
+			event.ExpiresAt = claim.ExpiresAt.Time
+		}
+	}
+	EmitSecurityEvent(ctx, event)
+}
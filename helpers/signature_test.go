@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyRequest(t *testing.T) {
+	now := time.Now()
+	keys := map[string][]byte{"wallet": []byte("secret")}
+
+	tests := []struct {
+		name    string
+		tamper  func(req *http.Request)
+		wantErr bool
+	}{
+		{name: "valid", tamper: func(req *http.Request) {}},
+		{name: "unknown key", tamper: func(req *http.Request) { req.Header.Set(HeaderSignatureKeyID, "other") }, wantErr: true},
+		{name: "tampered body", tamper: func(req *http.Request) { req.Body = io.NopCloser(strings.NewReader(`{"token":"forged"}`)) }, wantErr: true},
+		{name: "tampered path", tamper: func(req *http.Request) { req.URL.Path = "/internal/v1/other" }, wantErr: true},
+		{name: "stale timestamp", tamper: func(req *http.Request) { req.Header.Set(HeaderSignatureTimestamp, "1") }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/internal/v1/token/validate", strings.NewReader(`{"token":"abc"}`))
+			if err := SignRequest(req, "wallet", keys["wallet"], now); err != nil {
+				t.Fatal(err)
+			}
+			tt.tamper(req)
+
+			caller, err := VerifyRequest(req, keys, 5*time.Minute, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil && caller != "wallet" {
+				t.Errorf("got caller %q, want wallet", caller)
+			}
+		})
+	}
+}
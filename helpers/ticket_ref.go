@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"context"
+	"regexp"
+)
+
+type ticketRefKey struct{}
+
+// ticketRefPattern accepts the references of the usual trackers, e.g.
+// SUP-1234, INC#5678 or a ticket URL path, and nothing that could mangle a
+// log line or a CSV cell.
+var ticketRefPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:#/-]{0,99}$`)
+
+func ValidTicketRef(ref string) bool {
+	return ticketRefPattern.MatchString(ref)
+}
+
+// ContextWithTicketRef records the external ticket an admin action was taken
+// for, AuditRepository stores it on the audit events of the request.
+func ContextWithTicketRef(ctx context.Context, ref string) context.Context {
+	return context.WithValue(ctx, ticketRefKey{}, ref)
+}
+
+func TicketRefFromContext(ctx context.Context) (string, bool) {
+	ref, ok := ctx.Value(ticketRefKey{}).(string)
+	return ref, ok && ref != ""
+}
@@ -0,0 +1,26 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidTicketRef(t *testing.T) {
+	for ref, valid := range map[string]bool{
+		"SUP-1234":               true,
+		"INC#5678":               true,
+		"jira:OPS-9":             true,
+		"browse/SUP-1":           true,
+		"":                       false,
+		"-1":                     false,
+		"SUP 1234":               false,
+		"SUP-1\nforged":          false,
+		`=HYPERLINK("x")`:        false,
+		strings.Repeat("a", 100): true,
+		strings.Repeat("a", 101): false,
+	} {
+		if got := ValidTicketRef(ref); got != valid {
+			t.Errorf("ValidTicketRef(%q) = %v, want %v", ref, got, valid)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package helpers
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseTokenExchangePolicy(t *testing.T) {
+	policy, err := ParseTokenExchangePolicy("gateway:wallet|ledger, reports:ledger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(policy["gateway"], []string{"wallet", "ledger"}) || !slices.Equal(policy["reports"], []string{"ledger"}) {
+		t.Errorf("unexpected policy %v", policy)
+	}
+
+	for _, value := range []string{"gateway", "gateway:", ":wallet", "gateway:wallet||ledger"} {
+		if _, err := ParseTokenExchangePolicy(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestGenerateDelegatedToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	subjectToken, err := GenerateToken(ctx, 1, "user", "User", "token", "user@example.com", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := ValidateToken(ctx, subjectToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, expiresAt, err := GenerateDelegatedToken(ctx, subject, "wallet", "gateway", 5*time.Minute, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := ValidateToken(ctx, token)
+	if err != nil {
+		t.Fatal("failed to validate delegated token: ", err)
+	}
+	if claim.UserID != 1 || claim.Act == nil || claim.Act.Sub != "gateway" || !slices.Equal(claim.Audience, []string{"wallet"}) || claim.SubjectTokenID != subject.ID {
+		t.Errorf("unexpected delegated claims %+v", claim)
+	}
+	if claim.ID == "" || claim.ID == subject.ID {
+		t.Errorf("got jti %q, want one of its own", claim.ID)
+	}
+	if !expiresAt.Equal(now.Add(5 * time.Minute)) {
+		t.Errorf("got expiry %v, want ttl from now", expiresAt)
+	}
+
+	// never outlives the subject token
+	_, expiresAt, err = GenerateDelegatedToken(ctx, subject, "wallet", "gateway", 24*time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expiresAt.Equal(subject.ExpiresAt.Time) {
+		t.Errorf("got expiry %v, want subject expiry %v", expiresAt, subject.ExpiresAt.Time)
+	}
+}
@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// SignUnsubscribeToken returns the token of an email unsubscribe link. It
+// names the user and the event to turn off and never expires, old emails
+// must keep working.
+func SignUnsubscribeToken(userID int, event string) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(userID) + ":" + event))
+	return payload + "." + unsubscribeSignature(payload)
+}
+
+func VerifyUnsubscribeToken(token string) (int, string, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(unsubscribeSignature(payload))) {
+		return 0, "", errors.New("invalid unsubscribe token")
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return 0, "", errors.New("invalid unsubscribe token")
+	}
+	id, event, _ := strings.Cut(string(decoded), ":")
+	userID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, "", errors.New("invalid unsubscribe token")
+	}
+	return userID, event, nil
+}
+
+func unsubscribeSignature(payload string) string {
+	mac := hmac.New(sha256.New, jwtSecret())
+	mac.Write([]byte("unsubscribe:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,31 @@
+package helpers
+
+import "testing"
+
+func TestVerifyUnsubscribeToken(t *testing.T) {
+	token := SignUnsubscribeToken(42, "activity_digest")
+	other := SignUnsubscribeToken(43, "activity_digest")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid", token: token},
+		{name: "swapped payload", token: other[:len(other)-43] + token[len(token)-43:], wantErr: true},
+		{name: "no signature", token: "NDI6YWN0aXZpdHlfZGlnZXN0", wantErr: true},
+		{name: "empty", token: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, event, err := VerifyUnsubscribeToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil && (userID != 42 || event != "activity_digest") {
+				t.Errorf("got user %d event %q, want 42 activity_digest", userID, event)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountDeletionHandler struct {
+	AccountDeletionService interfaces.IAccountDeletionService
+}
+
+func (api *AccountDeletionHandler) RequestDeletion(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AccountDeletionRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.AccountDeletionService.RequestDeletion(c.Request.Context(), tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on account deletion service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusAccepted, constants.SuccessMessage, resp)
+}
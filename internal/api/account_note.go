@@ -0,0 +1,163 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountNoteHandler struct {
+	AccountNoteService interfaces.IAccountNoteService
+}
+
+func (api *AccountNoteHandler) GetNotes(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AccountNoteListRequest{}
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.AccountNoteService.GetNotes(c.Request.Context(), tokenClaim.UserID, userID, req)
+	if err != nil {
+		log.Error("failed on account note service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *AccountNoteHandler) AddNote(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AccountNoteRequest{}
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.AccountNoteService.AddNote(c.Request.Context(), tokenClaim.UserID, userID, req)
+	if err != nil {
+		log.Error("failed on account note service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusCreated, constants.SuccessMessage, resp)
+}
+
+func (api *AccountNoteHandler) UpdateNote(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AccountNoteRequest{}
+
+	noteID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse account note id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.AccountNoteService.UpdateNote(c.Request.Context(), tokenClaim.UserID, noteID, req)
+	if err != nil {
+		log.Error("failed on account note service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *AccountNoteHandler) DeleteNote(c *gin.Context) {
+	log := helpers.Logger
+
+	noteID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse account note id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	if err := api.AccountNoteService.DeleteNote(c.Request.Context(), tokenClaim.UserID, noteID); err != nil {
+		log.Error("failed on account note service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
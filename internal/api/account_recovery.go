@@ -0,0 +1,175 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountRecoveryHandler struct {
+	AccountRecoveryService interfaces.IAccountRecoveryService
+	// MaxBytes limits the whole evidence upload.
+	MaxBytes int64
+}
+
+func (api *AccountRecoveryHandler) SubmitTicket(c *gin.Context) {
+	log := helpers.Logger
+	req := models.RecoveryTicketRequest{}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, api.MaxBytes)
+	if err := c.ShouldBind(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			helpers.SendResponseHTTP(c, http.StatusRequestEntityTooLarge, constants.ErrFailedBadRequest, nil)
+			return
+		}
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	evidence := make([]models.RecoveryEvidence, 0, len(req.Evidence))
+	for _, header := range req.Evidence {
+		file, err := header.Open()
+		if err != nil {
+			log.Error("failed to open evidence file: ", err)
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			return
+		}
+		defer file.Close()
+		evidence = append(evidence, models.RecoveryEvidence{Body: file, Size: header.Size})
+	}
+
+	resp, err := api.AccountRecoveryService.SubmitTicket(c.Request.Context(), c.ClientIP(), req, evidence)
+	if err != nil {
+		log.Error("failed on account recovery service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusAccepted, constants.SuccessMessage, resp)
+}
+
+func (api *AccountRecoveryHandler) CompleteReset(c *gin.Context) {
+	log := helpers.Logger
+	req := models.ManualRecoveryResetRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := api.AccountRecoveryService.CompleteReset(c.Request.Context(), req); err != nil {
+		log.Error("failed on account recovery service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
+
+func (api *AccountRecoveryHandler) GetTickets(c *gin.Context) {
+	log := helpers.Logger
+	req := models.RecoveryTicketListRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.AccountRecoveryService.GetTickets(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on account recovery service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *AccountRecoveryHandler) GetTicket(c *gin.Context) {
+	log := helpers.Logger
+
+	ticketID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse recovery ticket id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.AccountRecoveryService.GetTicket(c.Request.Context(), ticketID)
+	if err != nil {
+		log.Error("failed on account recovery service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *AccountRecoveryHandler) RejectTicket(c *gin.Context) {
+	log := helpers.Logger
+	req := models.RecoveryTicketRejectRequest{}
+
+	ticketID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse recovery ticket id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.AccountRecoveryService.RejectTicket(c.Request.Context(), ticketID, tokenClaim.UserID, req.Note)
+	if err != nil {
+		log.Error("failed on account recovery service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/cmd/proto/user"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddressHandler lets users manage their addresses over HTTP and other
+// services read them over gRPC.
+type AddressHandler struct {
+	user.UnimplementedUserServer
+	AddressService interfaces.IAddressService
+}
+
+func (h *AddressHandler) GetUserAddresses(ctx context.Context, req *user.UserAddressesRequest) (*user.UserAddressesResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	addresses, err := h.AddressService.GetUserAddresses(ctx, int(req.GetUserId()))
+	if err != nil {
+		helpers.Logger.Error("failed on address service: ", err)
+		return nil, apperr.GRPCError(err)
+	}
+
+	resp := &user.UserAddressesResponse{Message: constants.SuccessMessage}
+	for _, address := range addresses {
+		resp.Addresses = append(resp.Addresses, &user.Address{
+			Id:         int64(address.ID),
+			Type:       address.Type,
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			IsPrimary:  address.IsPrimary,
+		})
+	}
+	return resp, nil
+}
+
+func (h *AddressHandler) GetAddresses(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := h.AddressService.GetAddresses(c.Request.Context(), tokenClaim.UserID)
+	if err != nil {
+		log.Error("failed on address service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (h *AddressHandler) AddAddress(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AddressRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := h.AddressService.AddAddress(c.Request.Context(), tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on address service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusCreated, constants.SuccessMessage, resp)
+}
+
+func (h *AddressHandler) UpdateAddress(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AddressRequest{}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse address id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := h.AddressService.UpdateAddress(c.Request.Context(), tokenClaim.UserID, id, req)
+	if err != nil {
+		log.Error("failed on address service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (h *AddressHandler) SetPrimaryAddress(c *gin.Context) {
+	h.changeAddress(c, h.AddressService.SetPrimaryAddress)
+}
+
+func (h *AddressHandler) DeleteAddress(c *gin.Context) {
+	h.changeAddress(c, h.AddressService.DeleteAddress)
+}
+
+func (h *AddressHandler) changeAddress(c *gin.Context, change func(ctx context.Context, userID, id int) error) {
+	log := helpers.Logger
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse address id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	if err := change(c.Request.Context(), tokenClaim.UserID, id); err != nil {
+		log.Error("failed on address service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminApprovalHandler struct {
+	AdminApprovalService interfaces.IAdminApprovalService
+}
+
+func (api *AdminApprovalHandler) RequestApproval(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AdminApprovalRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.AdminApprovalService.RequestApproval(c.Request.Context(), tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on admin approval service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusCreated, constants.SuccessMessage, resp)
+}
+
+func (api *AdminApprovalHandler) GetApprovals(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AdminApprovalListRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.AdminApprovalService.GetApprovals(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on admin approval service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *AdminApprovalHandler) Approve(c *gin.Context) {
+	api.review(c, api.AdminApprovalService.Approve)
+}
+
+func (api *AdminApprovalHandler) Reject(c *gin.Context) {
+	api.review(c, api.AdminApprovalService.Reject)
+}
+
+type reviewFunc func(ctx context.Context, approvalID, reviewedBy int, note string) (models.AdminApproval, error)
+
+func (api *AdminApprovalHandler) review(c *gin.Context, review reviewFunc) {
+	log := helpers.Logger
+	req := models.AdminApprovalReviewRequest{}
+
+	approvalID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse approval id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	// the note is optional, an empty body is fine
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Error("failed to parse request: ", err)
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			return
+		}
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := review(c.Request.Context(), approvalID, tokenClaim.UserID, req.Note)
+	switch {
+	case errors.Is(err, services.ErrApprovalNotPending):
+		helpers.SendResponseHTTP(c, http.StatusConflict, constants.ErrConflict, resp)
+		return
+	case err != nil:
+		log.Error("failed on admin approval service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminUserHandler lets support act on a user directly over HTTP, without
+// the maker-checker flow of approvals.
+type AdminUserHandler struct {
+	UserAdminService interfaces.IUserAdminService
+}
+
+func (api *AdminUserHandler) GetUsers(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AdminUserListRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.UserAdminService.GetUsers(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on user admin service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *AdminUserHandler) ForceLogout(c *gin.Context) {
+	log := helpers.Logger
+	req := models.ForceLogoutRequest{}
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	revoked, err := api.UserAdminService.ForceLogout(c.Request.Context(), models.UserActor(tokenClaim.UserID), userID, req.Reason)
+	if err != nil {
+		log.Error("failed on user admin service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, models.ForceLogoutResponse{SessionsRevoked: revoked})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	AuditService interfaces.IAuditService
+}
+
+func (api *AuditHandler) GetAuditEvents(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AuditEventListRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if req.Format == "csv" {
+		api.export(c, req.AuditEventFilter)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.AuditService.GetAuditEvents(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on audit service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *AuditHandler) export(c *gin.Context, filter models.AuditEventFilter) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	body, err := api.AuditService.ExportAuditEvents(c.Request.Context(), tokenClaim.UserID, filter)
+	if err != nil {
+		log.Error("failed on audit service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="audit-events.csv"`)
+	c.Data(http.StatusOK, "text/csv", body)
+}
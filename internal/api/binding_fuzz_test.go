@@ -0,0 +1,92 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/mocks"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+}
+
+func serve(handler gin.HandlerFunc, body []byte) int {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler(c)
+	return w.Code
+}
+
+func FuzzLoginBinding(f *testing.F) {
+	f.Add([]byte(`{"username":"user","password":"secret"}`))
+	f.Add([]byte(`{"username":"","password":""}`))
+	f.Add([]byte(`{"identifier":"User@Example.com","password":"secret"}`))
+	f.Add([]byte(`{"identifier":"0812 3456 7890","password":"secret"}`))
+	f.Add([]byte(`{"username":1,"password":["a"]}`))
+	f.Add([]byte(`{"username":"user"`))
+	f.Add([]byte(`[[[[[[[[[[[[[[[[[[[[]]]]]]]]]]]]]]]]]]]]`))
+	f.Add([]byte(``))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		ctrl := gomock.NewController(t)
+		svc := mocks.NewMockILoginService(ctrl)
+		svc.EXPECT().Login(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, req models.LoginRequest) (models.LoginResponse, error) {
+				if req.GetIdentifier() == "" || req.Password == "" {
+					t.Fatalf("service called with invalid request %+v", req)
+				}
+				return models.LoginResponse{}, nil
+			}).AnyTimes()
+
+		code := serve((&LoginHandler{LoginService: svc}).Login, body)
+		if code != http.StatusOK && code != http.StatusBadRequest {
+			t.Fatalf("unexpected status %d for body %q", code, body)
+		}
+	})
+}
+
+func FuzzRegisterBinding(f *testing.F) {
+	f.Add([]byte(`{"username":"user","email":"User@Example.com","phone_number":"0812 3456 7890","full_name":"User","password":"secret"}`))
+	f.Add([]byte(`{"username":"user","email":"not-an-email","phone_number":"0812","full_name":"User","password":"secret"}`))
+	f.Add([]byte(`{"username":"user","email":"a@b.c","phone_number":"+62abc","full_name":"User","password":"secret","id":99}`))
+	f.Add([]byte(`{"username":"user","phone_number":"0812 3456 7890","password":"secret"}`))
+	f.Add([]byte(`{"username":"user","password":"secret"}`))
+	f.Add([]byte(`{"dob":{"nested":true}}`))
+	f.Add([]byte(`null`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		ctrl := gomock.NewController(t)
+		svc := mocks.NewMockIRegisterService(ctrl)
+		svc.EXPECT().Register(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, req *models.User) (any, error) {
+				if err := req.Validate(); err != nil {
+					t.Fatalf("service called with invalid request %+v", req)
+				}
+				if _, err := helpers.NormalizeEmail(req.Email); req.Email != "" && err != nil {
+					t.Fatalf("service called with unnormalized email %q", req.Email)
+				}
+				if _, err := helpers.NormalizePhoneNumber(req.PhoneNumber); req.PhoneNumber != "" && err != nil {
+					t.Fatalf("service called with unnormalized phone %q", req.PhoneNumber)
+				}
+				return req, nil
+			}).AnyTimes()
+
+		code := serve((&RegisterHandler{RegisterService: svc}).Register, body)
+		if code != http.StatusOK && code != http.StatusBadRequest {
+			t.Fatalf("unexpected status %d for body %q", code, body)
+		}
+	})
+}
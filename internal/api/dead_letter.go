@@ -0,0 +1,170 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeadLetterHandler struct {
+	DeadLetterService interfaces.IDeadLetterService
+}
+
+func (api *DeadLetterHandler) GetDeadLetters(c *gin.Context) {
+	log := helpers.Logger
+	req := models.DeadLetterListRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.DeadLetterService.GetDeadLetters(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on dead letter service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *DeadLetterHandler) GetDeadLetter(c *gin.Context) {
+	log := helpers.Logger
+
+	deadLetterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse dead letter id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.DeadLetterService.GetDeadLetter(c.Request.Context(), deadLetterID)
+	if err != nil {
+		log.Error("failed on dead letter service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *DeadLetterHandler) UpdateDeadLetter(c *gin.Context) {
+	log := helpers.Logger
+	req := models.DeadLetterUpdateRequest{}
+
+	deadLetterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse dead letter id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.DeadLetterService.UpdateDeadLetter(c.Request.Context(), models.UserActor(tokenClaim.UserID), deadLetterID, req)
+	if err != nil {
+		log.Error("failed on dead letter service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *DeadLetterHandler) ReplayDeadLetter(c *gin.Context) {
+	log := helpers.Logger
+
+	deadLetterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse dead letter id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.DeadLetterService.ReplayDeadLetter(c.Request.Context(), models.UserActor(tokenClaim.UserID), deadLetterID)
+	if err != nil {
+		log.Error("failed on dead letter service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *DeadLetterHandler) DiscardDeadLetter(c *gin.Context) {
+	log := helpers.Logger
+	req := models.DeadLetterDiscardRequest{}
+
+	deadLetterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse dead letter id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.DeadLetterService.DiscardDeadLetter(c.Request.Context(), models.UserActor(tokenClaim.UserID), deadLetterID, req)
+	if err != nil {
+		log.Error("failed on dead letter service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
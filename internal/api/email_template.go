@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailTemplateHandler struct {
+	EmailTemplateService interfaces.IEmailTemplateService
+}
+
+func (api *EmailTemplateHandler) GetTemplates(c *gin.Context) {
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, api.EmailTemplateService.GetTemplates(c.Request.Context()))
+}
+
+func (api *EmailTemplateHandler) Preview(c *gin.Context) {
+	log := helpers.Logger
+	req := models.EmailPreviewRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.EmailTemplateService.Preview(c.Request.Context(), c.Param("name"), req.Locale, req.ClientID)
+	if err != nil {
+		log.Error("failed on email template service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	switch req.Format {
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(resp.HTML))
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(resp.Text))
+	default:
+		helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/cmd/proto/entitlement"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EntitlementHandler lets admins manage a user's feature entitlements over
+// HTTP and other services look them up over gRPC.
+type EntitlementHandler struct {
+	entitlement.UnimplementedEntitlementServer
+	EntitlementService interfaces.IEntitlementService
+}
+
+func (h *EntitlementHandler) GetUserEntitlements(ctx context.Context, req *entitlement.UserEntitlementsRequest) (*entitlement.UserEntitlementsResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	features, err := h.EntitlementService.GetUserFeatures(ctx, int(req.GetUserId()))
+	if err != nil {
+		helpers.Logger.Error("failed on entitlement service: ", err)
+		return nil, apperr.GRPCError(err)
+	}
+
+	return &entitlement.UserEntitlementsResponse{Message: constants.SuccessMessage, Features: features}, nil
+}
+
+func (h *EntitlementHandler) GetEntitlements(c *gin.Context) {
+	log := helpers.Logger
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := h.EntitlementService.GetEntitlements(c.Request.Context(), userID)
+	if err != nil {
+		log.Error("failed on entitlement service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (h *EntitlementHandler) GrantEntitlement(c *gin.Context) {
+	h.changeEntitlement(c, h.EntitlementService.GrantEntitlement)
+}
+
+func (h *EntitlementHandler) RevokeEntitlement(c *gin.Context) {
+	h.changeEntitlement(c, h.EntitlementService.RevokeEntitlement)
+}
+
+func (h *EntitlementHandler) changeEntitlement(c *gin.Context, change func(ctx context.Context, actor string, userID int, req models.EntitlementRequest) error) {
+	log := helpers.Logger
+	req := models.EntitlementRequest{}
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	req.Feature = c.Param("feature")
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	if err := change(c.Request.Context(), models.UserActor(tokenClaim.UserID), userID, req); err != nil {
+		log.Error("failed on entitlement service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
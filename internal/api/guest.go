@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GuestHandler struct {
+	GuestService interfaces.IGuestService
+}
+
+func (api *GuestHandler) CreateGuest(c *gin.Context) {
+	log := helpers.Logger
+	req := models.GuestRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	resp, err := api.GuestService.CreateGuest(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on guest service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusCreated, constants.SuccessMessage, resp)
+}
+
+func (api *GuestHandler) UpgradeGuest(c *gin.Context) {
+	log := helpers.Logger
+	req := models.UpgradeGuestRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	var err error
+	if req.Email != "" {
+		if req.Email, err = helpers.NormalizeEmail(req.Email); err != nil {
+			log.Error("failed to normalize request: ", err)
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			return
+		}
+	}
+
+	if req.PhoneNumber != "" {
+		if req.PhoneNumber, err = helpers.NormalizePhoneNumber(req.PhoneNumber); err != nil {
+			log.Error("failed to normalize request: ", err)
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			return
+		}
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	resp, err := api.GuestService.UpgradeGuest(c.Request.Context(), tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on guest service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
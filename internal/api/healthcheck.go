@@ -1,8 +1,10 @@
 package api
 
 import (
+	"context"
 	"net/http"
 
+	"ewallet-ums/cmd/proto/healthcheck"
 	"ewallet-ums/helpers"
 	"ewallet-ums/internal/interfaces"
 
@@ -10,6 +12,7 @@ import (
 )
 
 type Healthcheck struct {
+	healthcheck.UnimplementedHealthcheckServer
 	HealthcheckServices interfaces.IHealthcheckServices
 }
 
@@ -21,3 +24,20 @@ func (api *Healthcheck) HealthcheckHandlerHTTP(c *gin.Context) {
 	}
 	helpers.SendResponseHTTP(c, http.StatusOK, msg, nil)
 }
+
+// Check reports the status of every dependency for gRPC-only dashboards. It
+// always succeeds, a dependency being down is part of the response.
+func (api *Healthcheck) Check(ctx context.Context, req *healthcheck.CheckRequest) (*healthcheck.CheckResponse, error) {
+	report := api.HealthcheckServices.Check(ctx)
+
+	resp := &healthcheck.CheckResponse{Status: report.Status}
+	for _, dependency := range report.Dependencies {
+		resp.Dependencies = append(resp.Dependencies, &healthcheck.DependencyStatus{
+			Name:      dependency.Name,
+			Status:    dependency.Status,
+			Error:     dependency.Error,
+			LatencyMs: dependency.LatencyMS,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IntrospectionHandler struct {
+	IntrospectionService interfaces.IIntrospectionService
+}
+
+// Introspect answers in the plain RFC 7662 shape instead of going through
+// helpers.SendResponseHTTP, so standard OAuth client libraries can read it.
+func (h *IntrospectionHandler) Introspect(c *gin.Context) {
+	log := helpers.Logger
+	req := models.IntrospectionRequest{}
+
+	if err := c.ShouldBind(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrInvalidRequest})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrInvalidRequest})
+		return
+	}
+
+	resp, err := h.IntrospectionService.Introspect(c.Request.Context(), req.Token, req.TokenTypeHint)
+	if err != nil {
+		log.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.OAuthErrServerError})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
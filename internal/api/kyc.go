@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KycHandler struct {
+	KycService interfaces.IKycService
+}
+
+func (api *KycHandler) GetKycProfile(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.KycService.GetKycProfile(c.Request.Context(), tokenClaim.UserID)
+	if err != nil {
+		log.Error("failed on kyc service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *KycHandler) UpdateKycProfile(c *gin.Context) {
+	log := helpers.Logger
+	req := models.KycProfileRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.KycService.UpdateKycProfile(c.Request.Context(), tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on kyc service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *KycHandler) GetUserKycProfile(c *gin.Context) {
+	log := helpers.Logger
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.KycService.GetUserKycProfile(c.Request.Context(), userID)
+	if err != nil {
+		log.Error("failed on kyc service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LegalHoldHandler struct {
+	LegalHoldService interfaces.ILegalHoldService
+}
+
+func (api *LegalHoldHandler) PlaceHold(c *gin.Context) {
+	log := helpers.Logger
+	req := models.LegalHoldRequest{}
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.LegalHoldService.PlaceHold(c.Request.Context(), userID, tokenClaim.UserID, req.Reason)
+	if err != nil {
+		log.Error("failed on legal hold service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusCreated, constants.SuccessMessage, resp)
+}
+
+func (api *LegalHoldHandler) LiftHold(c *gin.Context) {
+	log := helpers.Logger
+	req := models.LegalHoldRequest{}
+
+	holdID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse legal hold id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.LegalHoldService.LiftHold(c.Request.Context(), holdID, tokenClaim.UserID, req.Reason)
+	if errors.Is(err, services.ErrLegalHoldLifted) {
+		helpers.SendResponseHTTP(c, http.StatusConflict, constants.ErrConflict, resp)
+		return
+	}
+	if err != nil {
+		log.Error("failed on legal hold service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *LegalHoldHandler) GetHolds(c *gin.Context) {
+	log := helpers.Logger
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.LegalHoldService.GetHolds(c.Request.Context(), userID)
+	if err != nil {
+		log.Error("failed on legal hold service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LogLevelHandler struct {
+	LogLevelService interfaces.ILogLevelService
+}
+
+func (api *LogLevelHandler) GetLogLevel(c *gin.Context) {
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, api.LogLevelService.GetLogLevel(c.Request.Context()))
+}
+
+func (api *LogLevelHandler) SetLogLevel(c *gin.Context) {
+	log := helpers.Logger
+	req := models.LogLevelRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.LogLevelService.SetLogLevel(c.Request.Context(), models.UserActor(tokenClaim.UserID), req.Level)
+	if err != nil {
+		log.Error("failed on log level service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
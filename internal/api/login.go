@@ -1,18 +1,25 @@
 package api
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"ewallet-ums/constants"
 	"ewallet-ums/helpers"
 	"ewallet-ums/internal/interfaces"
 	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type LoginHandler struct {
 	LoginService interfaces.ILoginService
+	// ASNHeader and CountryHeader name the headers the edge proxy sets with
+	// the caller's ASN and country, empty when it sets none.
+	ASNHeader     string
+	CountryHeader string
 }
 
 func (api *LoginHandler) Login(c *gin.Context) {
@@ -24,6 +31,11 @@ func (api *LoginHandler) Login(c *gin.Context) {
 		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
 		return
 	}
+	// browsers send the cookie of a trusted device in place of a code,
+	// mobile clients never get one
+	if cookie, err := c.Cookie(constants.TrustedDeviceCookie); err == nil {
+		req.DeviceToken, _ = helpers.VerifyDeviceToken(cookie)
+	}
 
 	if err := req.Validate(); err != nil {
 		log.Error("failed to validate request: ", err)
@@ -31,12 +43,34 @@ func (api *LoginHandler) Login(c *gin.Context) {
 		return
 	}
 
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+	if api.ASNHeader != "" {
+		req.ASN = c.GetHeader(api.ASNHeader)
+	}
+	if api.CountryHeader != "" {
+		req.Country = c.GetHeader(api.CountryHeader)
+	}
+
 	resp, err := api.LoginService.Login(c.Request.Context(), req)
+	if errors.Is(err, services.ErrPasswordChangeRequired) {
+		helpers.SendResponseHTTP(c, http.StatusForbidden, constants.ErrPasswordChangeRequired, nil)
+		return
+	}
+	if errors.Is(err, services.ErrAccountPendingDeletion) {
+		helpers.SendResponseHTTP(c, http.StatusForbidden, constants.ErrAccountPendingDeletion, nil)
+		return
+	}
 	if err != nil {
 		log.Error("failed on login service: ", err)
-		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		helpers.SendErrorHTTP(c, err)
 		return
 	}
 
+	if resp.DeviceToken != "" {
+		c.SetSameSite(http.SameSiteStrictMode)
+		c.SetCookie(constants.TrustedDeviceCookie, helpers.SignDeviceToken(resp.DeviceToken), int(time.Until(resp.DeviceTokenExpiresAt).Seconds()), c.FullPath(), "", true, true)
+	}
+
 	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
 }
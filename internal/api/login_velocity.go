@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoginVelocityHandler struct {
+	LoginVelocityService interfaces.ILoginVelocityService
+}
+
+func (api *LoginVelocityHandler) GetHotSources(c *gin.Context) {
+	log := helpers.Logger
+
+	resp, err := api.LoginVelocityService.GetHotSources(c.Request.Context())
+	if err != nil {
+		log.Error("failed on login velocity service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
@@ -17,13 +17,19 @@ type LogoutHandler struct {
 func (api *LogoutHandler) Logout(c *gin.Context) {
 	log := helpers.Logger
 
-	token := c.Request.Header.Get("Authorization")
-	err := api.LogoutService.Logout(c.Request.Context(), token)
+	token, err := helpers.GetBearerToken(c)
 	if err != nil {
-		log.Error("failed on logout service: ", err)
+		log.Error(err)
 		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
 		return
 	}
 
+	err = api.LogoutService.Logout(c.Request.Context(), token)
+	if err != nil {
+		log.Error("failed on logout service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
 	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
 }
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	NotificationService interfaces.INotificationService
+}
+
+func (api *NotificationHandler) GetPreferences(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.NotificationService.GetPreferences(c.Request.Context(), tokenClaim.UserID)
+	if err != nil {
+		log.Error("failed on notification service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	log := helpers.Logger
+	req := models.UpdateNotificationPreferencesRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.NotificationService.UpdatePreferences(c.Request.Context(), tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on notification service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+// Unsubscribe serves the link in notification emails and the one-click
+// List-Unsubscribe POST, so it takes the signed token instead of a login.
+func (api *NotificationHandler) Unsubscribe(c *gin.Context) {
+	log := helpers.Logger
+
+	err := api.NotificationService.Unsubscribe(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		log.Error("failed on notification service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
+
+func (api *NotificationHandler) GetQuietHours(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.NotificationService.GetQuietHours(c.Request.Context(), tokenClaim.UserID)
+	if err != nil {
+		log.Error("failed on notification service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *NotificationHandler) UpdateQuietHours(c *gin.Context) {
+	log := helpers.Logger
+	req := models.UpdateQuietHoursRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.NotificationService.UpdateQuietHours(c.Request.Context(), tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on notification service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *NotificationHandler) DeleteQuietHours(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	if err := api.NotificationService.DeleteQuietHours(c.Request.Context(), tokenClaim.UserID); err != nil {
+		log.Error("failed on notification service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
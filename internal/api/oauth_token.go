@@ -0,0 +1,106 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OAuthTokenHandler struct {
+	TokenExchangeService  interfaces.ITokenExchangeService
+	ServiceAccountService interfaces.IServiceAccountService
+}
+
+// Token is the OAuth token endpoint. It supports the client_credentials
+// grant for service accounts and the RFC 8693 token exchange grant for
+// registered clients, and answers in the plain OAuth shape.
+func (h *OAuthTokenHandler) Token(c *gin.Context) {
+	switch c.PostForm("grant_type") {
+	case constants.GrantTypeClientCredentials:
+		h.clientCredentials(c)
+	case constants.GrantTypeTokenExchange:
+		h.tokenExchange(c)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrUnsupportedGrantType})
+	}
+}
+
+func (h *OAuthTokenHandler) clientCredentials(c *gin.Context) {
+	log := helpers.Logger
+	req := models.ClientCredentialsRequest{}
+
+	if err := c.ShouldBind(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrInvalidRequest})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrInvalidRequest})
+		return
+	}
+
+	clientID, clientSecret, ok := c.Request.BasicAuth()
+	if !ok {
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.OAuthErrInvalidClient})
+		return
+	}
+
+	resp, err := h.ServiceAccountService.IssueToken(c.Request.Context(), clientID, clientSecret, req.Scope)
+	if err != nil {
+		log.Error(err)
+		if errors.Is(err, services.ErrInvalidClientCredentials) {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": constants.OAuthErrInvalidClient})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.OAuthErrServerError})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *OAuthTokenHandler) tokenExchange(c *gin.Context) {
+	log := helpers.Logger
+	req := models.TokenExchangeRequest{}
+
+	if err := c.ShouldBind(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrInvalidRequest})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrInvalidRequest})
+		return
+	}
+
+	resp, err := h.TokenExchangeService.Exchange(c.Request.Context(), c.GetString("client_id"), req)
+	if err != nil {
+		log.Error(err)
+		switch {
+		case errors.Is(err, services.ErrExchangeNotAllowed):
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrInvalidTarget})
+		case errors.Is(err, services.ErrInvalidSubjectToken):
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.OAuthErrInvalidRequest})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": constants.OAuthErrServerError})
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, resp)
+}
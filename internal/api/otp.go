@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OTPHandler struct {
+	OTPService interfaces.IOTPService
+}
+
+func (api *OTPHandler) RequestOTP(c *gin.Context) {
+	log := helpers.Logger
+	req := models.OTPRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	var err error
+	if req.PhoneNumber, err = helpers.NormalizePhoneNumber(req.PhoneNumber); err != nil {
+		log.Error("failed to normalize request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := api.OTPService.RequestOTP(c.Request.Context(), req); err != nil {
+		log.Error("failed on otp service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	// sent or not, the answer is the same
+	helpers.SendResponseHTTP(c, http.StatusAccepted, constants.SuccessMessage, nil)
+}
+
+func (api *OTPHandler) RegisterWithPhone(c *gin.Context) {
+	log := helpers.Logger
+	req := models.PhoneRegisterRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	var err error
+	if req.PhoneNumber, err = helpers.NormalizePhoneNumber(req.PhoneNumber); err != nil {
+		log.Error("failed to normalize request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	resp, err := api.OTPService.RegisterWithPhone(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on otp service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
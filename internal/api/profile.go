@@ -0,0 +1,150 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileHandler struct {
+	ProfileService interfaces.IProfileService
+}
+
+func (api *ProfileHandler) GetProfile(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.ProfileService.GetProfile(c.Request.Context(), tokenClaim.UserID)
+	if err != nil {
+		log.Error("failed on profile service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *ProfileHandler) UpdateProfile(c *gin.Context) {
+	log := helpers.Logger
+	req := models.UpdateProfileRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	var err error
+	if req.Email != "" {
+		if req.Email, err = helpers.NormalizeEmail(req.Email); err != nil {
+			log.Error("failed to normalize request: ", err)
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			return
+		}
+	}
+
+	if req.PhoneNumber != "" {
+		if req.PhoneNumber, err = helpers.NormalizePhoneNumber(req.PhoneNumber); err != nil {
+			log.Error("failed to normalize request: ", err)
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			return
+		}
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.ProfileService.UpdateProfile(c.Request.Context(), tokenClaim.UserID, req)
+	switch {
+	case errors.Is(err, services.ErrUserVersionConflict):
+		helpers.SendResponseHTTP(c, http.StatusConflict, constants.ErrConflict, resp)
+		return
+	case err != nil:
+		log.Error("failed on profile service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *ProfileHandler) RevertEmailChange(c *gin.Context) {
+	log := helpers.Logger
+	req := models.EmailChangeRevertRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.ProfileService.RevertEmailChange(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on profile service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *ProfileHandler) SetPassword(c *gin.Context) {
+	log := helpers.Logger
+	req := models.SetPasswordRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	if err := api.ProfileService.SetPassword(c.Request.Context(), tokenClaim.UserID, req); err != nil {
+		log.Error("failed on profile service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
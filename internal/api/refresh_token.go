@@ -6,6 +6,7 @@ import (
 	"ewallet-ums/constants"
 	"ewallet-ums/helpers"
 	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,7 +18,13 @@ type RefreshTokenHandler struct {
 func (api *RefreshTokenHandler) RefreshToken(c *gin.Context) {
 	log := helpers.Logger
 
-	refreshToken := c.Request.Header.Get("Authorization")
+	refreshToken, err := helpers.GetBearerToken(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
 	claim, ok := c.Get("token")
 	if !ok {
 		log.Error("failed to get claim in context")
@@ -32,10 +39,11 @@ func (api *RefreshTokenHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	resp, err := api.RefreshTokenService.RefreshToken(c.Request.Context(), refreshToken, *tokenClaim)
+	origin := models.TokenOrigin{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	resp, err := api.RefreshTokenService.RefreshToken(c.Request.Context(), refreshToken, *tokenClaim, origin)
 	if err != nil {
-		log.Error("failed on login service: ", err)
-		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		log.Error("failed on refresh token service: ", err)
+		helpers.SendErrorHTTP(c, err)
 		return
 	}
 
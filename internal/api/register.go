@@ -18,7 +18,7 @@ type RegisterHandler struct {
 func (api *RegisterHandler) Register(c *gin.Context) {
 	log := helpers.Logger
 
-	req := models.User{}
+	req := models.RegisterRequest{}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error("failed to parse request: ", err)
@@ -32,10 +32,29 @@ func (api *RegisterHandler) Register(c *gin.Context) {
 		return
 	}
 
-	resp, err := api.RegisterService.Register(c.Request.Context(), &req)
+	// either contact may be left out and added to the profile later
+	var err error
+	if req.Email != "" {
+		if req.Email, err = helpers.NormalizeEmail(req.Email); err != nil {
+			log.Error("failed to normalize request: ", err)
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			return
+		}
+	}
+
+	if req.PhoneNumber != "" {
+		if req.PhoneNumber, err = helpers.NormalizePhoneNumber(req.PhoneNumber); err != nil {
+			log.Error("failed to normalize request: ", err)
+			helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+			return
+		}
+	}
+
+	req.User.Attribution = req.Attribution
+	resp, err := api.RegisterService.Register(c.Request.Context(), &req.User)
 	if err != nil {
 		log.Error("failed to register new user: ", err)
-		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		helpers.SendErrorHTTP(c, err)
 		return
 	}
 
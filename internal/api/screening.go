@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScreeningHandler struct {
+	ScreeningService interfaces.IScreeningService
+}
+
+func (api *ScreeningHandler) GetScreeningReviews(c *gin.Context) {
+	log := helpers.Logger
+	req := models.ScreeningReviewListRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.ScreeningService.GetScreeningReviews(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on screening service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *ScreeningHandler) GetScreeningReview(c *gin.Context) {
+	log := helpers.Logger
+
+	reviewID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse screening review id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.ScreeningService.GetScreeningReview(c.Request.Context(), reviewID)
+	if err != nil {
+		log.Error("failed on screening service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *ScreeningHandler) ResolveScreeningReview(c *gin.Context) {
+	log := helpers.Logger
+	req := models.ScreeningReviewResolveRequest{}
+
+	reviewID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse screening review id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.ScreeningService.ResolveScreeningReview(c.Request.Context(), models.UserActor(tokenClaim.UserID), reviewID, req)
+	if err != nil {
+		log.Error("failed on screening service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
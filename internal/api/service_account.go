@@ -0,0 +1,189 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceAccountHandler struct {
+	ServiceAccountService interfaces.IServiceAccountService
+}
+
+func (api *ServiceAccountHandler) CreateServiceAccount(c *gin.Context) {
+	log := helpers.Logger
+	req := models.ServiceAccountRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.ServiceAccountService.CreateServiceAccount(c.Request.Context(), tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on service account service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusCreated, constants.SuccessMessage, resp)
+}
+
+func (api *ServiceAccountHandler) GetServiceAccounts(c *gin.Context) {
+	log := helpers.Logger
+	req := models.ServiceAccountListRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.ServiceAccountService.GetServiceAccounts(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on service account service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *ServiceAccountHandler) GetServiceAccount(c *gin.Context) {
+	log := helpers.Logger
+
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse service account id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.ServiceAccountService.GetServiceAccount(c.Request.Context(), accountID)
+	if err != nil {
+		log.Error("failed on service account service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *ServiceAccountHandler) UpdateServiceAccount(c *gin.Context) {
+	log := helpers.Logger
+	req := models.ServiceAccountRequest{}
+
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse service account id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.ServiceAccountService.UpdateServiceAccount(c.Request.Context(), accountID, tokenClaim.UserID, req)
+	if err != nil {
+		log.Error("failed on service account service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *ServiceAccountHandler) DeleteServiceAccount(c *gin.Context) {
+	log := helpers.Logger
+
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse service account id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	if err := api.ServiceAccountService.DeleteServiceAccount(c.Request.Context(), accountID, tokenClaim.UserID); err != nil {
+		log.Error("failed on service account service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
+
+func (api *ServiceAccountHandler) RotateSecret(c *gin.Context) {
+	log := helpers.Logger
+
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse service account id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.ServiceAccountService.RotateSecret(c.Request.Context(), accountID, tokenClaim.UserID)
+	if err != nil {
+		log.Error("failed on service account service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatsHandler struct {
+	StatsService interfaces.IStatsService
+}
+
+func (api *StatsHandler) GetStats(c *gin.Context) {
+	log := helpers.Logger
+	req := models.AdminStatsRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+	if req.Days == 0 {
+		req.Days = 30
+	}
+
+	resp, err := api.StatsService.GetStats(c.Request.Context(), req.Days)
+	if err != nil {
+		log.Error("failed on stats service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
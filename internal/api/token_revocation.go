@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TokenRevocationHandler lets security responders look through the
+// revocation list and revoke or un-revoke tokens during an incident.
+type TokenRevocationHandler struct {
+	TokenRevocationService interfaces.ITokenRevocationService
+}
+
+func (api *TokenRevocationHandler) GetRevokedTokens(c *gin.Context) {
+	log := helpers.Logger
+	req := models.RevokedTokenListRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.TokenRevocationService.GetRevokedTokens(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on token revocation service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *TokenRevocationHandler) RevokeTokens(c *gin.Context) {
+	log := helpers.Logger
+	req := models.RevokeTokensRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	revoked, err := api.TokenRevocationService.RevokeTokens(c.Request.Context(), models.UserActor(tokenClaim.UserID), req)
+	if err != nil {
+		log.Error("failed on token revocation service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, models.ForceLogoutResponse{SessionsRevoked: revoked})
+}
+
+func (api *TokenRevocationHandler) DeleteRevokedToken(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	if err := api.TokenRevocationService.DeleteRevokedToken(c.Request.Context(), models.UserActor(tokenClaim.UserID), c.Param("key")); err != nil {
+		log.Error("failed on token revocation service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
@@ -2,17 +2,25 @@ package api
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"net/http"
 
 	"ewallet-ums/cmd/proto/tokenvalidation"
 	"ewallet-ums/constants"
 	"ewallet-ums/helpers"
 	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 )
 
 type TokenValidationHandler struct {
 	tokenvalidation.UnimplementedTokenValidationServer
 	TokenValidationService interfaces.ITokenValidationService
+	// LogSampler thins out the per-call logs, every service validates its
+	// tokens here.
+	LogSampler *helpers.LogSampler
 }
 
 func (s *TokenValidationHandler) ValidateToken(ctx context.Context, req *tokenvalidation.TokenRequest) (*tokenvalidation.TokenResponse, error) {
@@ -22,22 +30,28 @@ func (s *TokenValidationHandler) ValidateToken(ctx context.Context, req *tokenva
 	)
 
 	if token == "" {
-		err := fmt.Errorf("token is empty")
-		log.Error(err)
+		err := errors.New("token is empty")
+		if s.LogSampler.Allow(logrus.WarnLevel) {
+			log.Warn(err)
+		}
 		return &tokenvalidation.TokenResponse{
 			Message: err.Error(),
 		}, nil
 	}
 
-	claimToken, err := s.TokenValidationService.TokenValidation(ctx, token)
+	claimToken, err := s.TokenValidationService.TokenValidationForAudience(ctx, token, req.GetAudience())
 	if err != nil {
-		log.Error(err)
+		if s.LogSampler.Allow(logrus.WarnLevel) {
+			log.Warn("token rejected: ", err)
+		}
 		return &tokenvalidation.TokenResponse{
 			Message: err.Error(),
 		}, nil
 	}
 
-	fmt.Println("user id: ", claimToken.UserID)
+	if s.LogSampler.Allow(logrus.DebugLevel) {
+		log.WithField("user_id", claimToken.UserID).Debug("token validated")
+	}
 
 	return &tokenvalidation.TokenResponse{
 		Message: constants.SuccessMessage,
@@ -45,6 +59,50 @@ func (s *TokenValidationHandler) ValidateToken(ctx context.Context, req *tokenva
 			UserId:   int64(claimToken.UserID),
 			Username: claimToken.Username,
 			FullName: claimToken.FullName,
+
+			ProfileCompleteness: int32(claimToken.ProfileCompleteness),
+			Guest:               claimToken.Guest,
+			DeviceId:            claimToken.DeviceID,
+			Entitlements:        claimToken.Entitlements,
 		},
 	}, nil
 }
+
+// ValidateTokenHTTP serves the same check as the gRPC ValidateToken for
+// internal callers that only speak HTTP.
+func (s *TokenValidationHandler) ValidateTokenHTTP(c *gin.Context) {
+	log := helpers.Logger
+	req := models.TokenValidationRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	claimToken, err := s.TokenValidationService.TokenValidationForAudience(c.Request.Context(), req.Token, req.Audience)
+	if err != nil {
+		if s.LogSampler.Allow(logrus.WarnLevel) {
+			log.Warn("token rejected: ", err)
+		}
+		helpers.SendResponseHTTP(c, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, models.TokenValidationResponse{
+		UserID:   claimToken.UserID,
+		Username: claimToken.Username,
+		FullName: claimToken.FullName,
+
+		ProfileCompleteness: claimToken.ProfileCompleteness,
+		Guest:               claimToken.Guest,
+		DeviceID:            claimToken.DeviceID,
+		Entitlements:        claimToken.Entitlements,
+	})
+}
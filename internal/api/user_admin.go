@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+
+	"ewallet-ums/cmd/proto/useradmin"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserAdminHandler struct {
+	useradmin.UnimplementedUserAdminServiceServer
+	UserAdminService interfaces.IUserAdminService
+}
+
+func (h *UserAdminHandler) SuspendUser(ctx context.Context, req *useradmin.SuspendUserRequest) (*useradmin.UserAdminResponse, error) {
+	actor, err := checkUserAdminRequest(ctx, req.GetUserId(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := h.UserAdminService.RequestSuspension(ctx, actor, int(req.GetUserId()), int(req.GetExpectedVersion()), req.GetReason())
+	if err != nil {
+		helpers.Logger.Error("failed on user admin service: ", err)
+		return nil, apperr.GRPCError(err)
+	}
+
+	return &useradmin.UserAdminResponse{Message: constants.SuccessMessage, ApprovalId: int64(approval.ID)}, nil
+}
+
+func (h *UserAdminHandler) AssignRole(ctx context.Context, req *useradmin.AssignRoleRequest) (*useradmin.UserAdminResponse, error) {
+	actor, err := checkUserAdminRequest(ctx, req.GetUserId(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := h.UserAdminService.RequestRole(ctx, actor, int(req.GetUserId()), req.GetRole(), req.GetReason())
+	if err != nil {
+		helpers.Logger.Error("failed on user admin service: ", err)
+		return nil, apperr.GRPCError(err)
+	}
+
+	return &useradmin.UserAdminResponse{Message: constants.SuccessMessage, ApprovalId: int64(approval.ID)}, nil
+}
+
+func (h *UserAdminHandler) ForceLogout(ctx context.Context, req *useradmin.ForceLogoutRequest) (*useradmin.UserAdminResponse, error) {
+	actor, err := checkUserAdminRequest(ctx, req.GetUserId(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := h.UserAdminService.ForceLogout(ctx, actor, int(req.GetUserId()), req.GetReason())
+	if err != nil {
+		helpers.Logger.Error("failed on user admin service: ", err)
+		return nil, apperr.GRPCError(err)
+	}
+
+	return &useradmin.UserAdminResponse{Message: constants.SuccessMessage, SessionsRevoked: int32(revoked)}, nil
+}
+
+// checkUserAdminRequest returns the caller set by the auth interceptor and
+// requires a target user and a reason for the audit trail.
+func checkUserAdminRequest(ctx context.Context, userID int64, reason string) (string, error) {
+	actor, ok := helpers.ActorFromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	if userID <= 0 || reason == "" {
+		return "", status.Error(codes.InvalidArgument, "user_id and reason are required")
+	}
+	return actor, nil
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserImportHandler struct {
+	UserImportService interfaces.IUserImportService
+	MaxBytes          int64
+}
+
+func (api *UserImportHandler) ImportUsers(c *gin.Context) {
+	log := helpers.Logger
+	req := models.UserImportRequest{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if req.Format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			req.Format = constants.UserImportFormatCSV
+		case "application/x-ndjson":
+			req.Format = constants.UserImportFormatNDJSON
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, api.MaxBytes))
+	if err != nil {
+		log.Error("failed to read import file: ", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			helpers.SendResponseHTTP(c, http.StatusRequestEntityTooLarge, constants.ErrFailedBadRequest, nil)
+			return
+		}
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.UserImportService.StartImport(c.Request.Context(), models.UserActor(tokenClaim.UserID), req.Format, req.DryRun, data)
+	if err != nil {
+		log.Error("failed on user import service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusAccepted, constants.SuccessMessage, resp)
+}
+
+func (api *UserImportHandler) GetImport(c *gin.Context) {
+	log := helpers.Logger
+
+	importID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user import id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.UserImportService.GetImport(c.Request.Context(), importID)
+	if err != nil {
+		log.Error("failed on user import service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
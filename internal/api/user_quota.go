@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserQuotaHandler struct {
+	UserQuotaService interfaces.IUserQuotaService
+}
+
+func (api *UserQuotaHandler) GetQuotaUsage(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.UserQuotaService.GetQuotaUsage(c.Request.Context(), tokenClaim.UserID, time.Now())
+	if err != nil {
+		log.Error("failed on user quota service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
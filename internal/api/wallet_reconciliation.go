@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WalletReconciliationHandler struct {
+	WalletReconciliationService interfaces.IWalletReconciliationService
+}
+
+func (api *WalletReconciliationHandler) GetReport(c *gin.Context) {
+	log := helpers.Logger
+	req := pagination.Request{}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Error("failed to parse request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	if _, err := req.GetCursor(); err != nil {
+		log.Error("failed to validate request: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	resp, err := api.WalletReconciliationService.GetReport(c.Request.Context(), req)
+	if err != nil {
+		log.Error("failed on wallet reconciliation service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
+
+func (api *WalletReconciliationHandler) RetryProvisioning(c *gin.Context) {
+	log := helpers.Logger
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse user id: ", err)
+		helpers.SendResponseHTTP(c, http.StatusBadRequest, constants.ErrFailedBadRequest, nil)
+		return
+	}
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	err = api.WalletReconciliationService.RetryProvisioning(c.Request.Context(), models.UserActor(tokenClaim.UserID), userID)
+	if err != nil {
+		log.Error("failed on wallet reconciliation service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, nil)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WalletStatusHandler struct {
+	WalletProvisioningService interfaces.IWalletProvisioningService
+}
+
+func (api *WalletStatusHandler) GetWalletStatus(c *gin.Context) {
+	log := helpers.Logger
+
+	tokenClaim, err := helpers.GetTokenClaim(c)
+	if err != nil {
+		log.Error(err)
+		helpers.SendResponseHTTP(c, http.StatusInternalServerError, constants.ErrServerError, nil)
+		return
+	}
+
+	resp, err := api.WalletProvisioningService.GetWalletStatus(c.Request.Context(), tokenClaim.UserID)
+	if err != nil {
+		log.Error("failed on wallet provisioning service: ", err)
+		helpers.SendErrorHTTP(c, err)
+		return
+	}
+
+	helpers.SendResponseHTTP(c, http.StatusOK, constants.SuccessMessage, resp)
+}
@@ -0,0 +1,83 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind says what went wrong in terms a caller can act on, HTTP and gRPC
+// handlers map it to their status codes.
+type Kind int
+
+const (
+	// Internal is the kind of any error not created by this package.
+	Internal Kind = iota
+	Invalid
+	Unauthorized
+	Forbidden
+	NotFound
+	Conflict
+	RateLimited
+	// Upstream is a failed call to a dependency outside this service, like
+	// the wallet service or the mail server.
+	Upstream
+)
+
+// Error is an error of a known Kind, optionally wrapping its cause.
+type Error struct {
+	Kind Kind
+	msg  string
+	err  error
+}
+
+func (e *Error) Error() string {
+	if e.err == nil {
+		return e.msg
+	}
+	return e.msg + ": " + e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// New returns an error of kind, services declare their sentinel errors with
+// it so handlers don't need to know each of them.
+func New(kind Kind, msg string) error {
+	return &Error{Kind: kind, msg: msg}
+}
+
+func Newf(kind Kind, format string, args ...any) error {
+	return &Error{Kind: kind, msg: fmt.Sprintf(format, args...)}
+}
+
+// Wrap adds msg to err keeping its kind, so a wrapped NotFound is still a
+// NotFound. errors.Is and errors.As see through it.
+func Wrap(err error, msg string) error {
+	return &Error{Kind: KindOf(err), msg: msg, err: err}
+}
+
+func Wrapf(err error, format string, args ...any) error {
+	return Wrap(err, fmt.Sprintf(format, args...))
+}
+
+// WrapAs wraps err as kind, for causes that carry no kind of their own such
+// as an upstream call failing.
+func WrapAs(kind Kind, err error, msg string) error {
+	return &Error{Kind: kind, msg: msg, err: err}
+}
+
+// KindOf returns the kind of the outermost Error in err's chain, Internal
+// when there is none.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return Internal
+}
+
+// Is reports whether err is of kind.
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
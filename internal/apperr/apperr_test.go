@@ -0,0 +1,62 @@
+package apperr
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWrapKeepsKind(t *testing.T) {
+	errNotFound := New(NotFound, "user not found")
+
+	err := Wrapf(Wrap(errNotFound, "failed to get user"), "failed to suspend user %d", 7)
+	if KindOf(err) != NotFound {
+		t.Fatalf("expected NotFound, got %v", KindOf(err))
+	}
+	if !errors.Is(err, errNotFound) {
+		t.Fatal("expected errors.Is to see the sentinel through the wraps")
+	}
+	if err.Error() != "failed to suspend user 7: failed to get user: user not found" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+
+	if KindOf(Wrap(errors.New("connection refused"), "failed to get user")) != Internal {
+		t.Fatal("expected a plain error to be Internal")
+	}
+	if KindOf(WrapAs(Upstream, errors.New("connection refused"), "failed to send mail")) != Upstream {
+		t.Fatal("expected WrapAs to set the kind")
+	}
+}
+
+func TestMapping(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantHTTP int
+		wantGRPC codes.Code
+	}{
+		{err: errors.New("boom"), wantHTTP: http.StatusInternalServerError, wantGRPC: codes.Internal},
+		{err: New(Invalid, "unknown role"), wantHTTP: http.StatusBadRequest, wantGRPC: codes.InvalidArgument},
+		{err: New(Unauthorized, "refresh token is not active"), wantHTTP: http.StatusUnauthorized, wantGRPC: codes.Unauthenticated},
+		{err: New(Forbidden, "user is not a guest"), wantHTTP: http.StatusForbidden, wantGRPC: codes.PermissionDenied},
+		{err: New(NotFound, "user not found"), wantHTTP: http.StatusNotFound, wantGRPC: codes.NotFound},
+		{err: New(Conflict, "username already taken"), wantHTTP: http.StatusConflict, wantGRPC: codes.Aborted},
+		{err: New(RateLimited, "account recovery is locked"), wantHTTP: http.StatusTooManyRequests, wantGRPC: codes.ResourceExhausted},
+		{err: WrapAs(Upstream, errors.New("timeout"), "failed to upload export"), wantHTTP: http.StatusBadGateway, wantGRPC: codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		if code, _ := HTTPStatus(tt.err); code != tt.wantHTTP {
+			t.Errorf("%v: expected HTTP %d, got %d", tt.err, tt.wantHTTP, code)
+		}
+		if code := status.Code(GRPCError(tt.err)); code != tt.wantGRPC {
+			t.Errorf("%v: expected gRPC %v, got %v", tt.err, tt.wantGRPC, code)
+		}
+	}
+
+	if status.Convert(GRPCError(errors.New("dial tcp 10.0.0.1: refused"))).Message() == "dial tcp 10.0.0.1: refused" {
+		t.Fatal("expected internal error details to stay out of the gRPC status")
+	}
+}
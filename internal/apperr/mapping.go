@@ -0,0 +1,52 @@
+package apperr
+
+import (
+	"net/http"
+
+	"ewallet-ums/constants"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var httpStatuses = map[Kind]struct {
+	code    int
+	message string
+}{
+	Internal:     {http.StatusInternalServerError, constants.ErrServerError},
+	Invalid:      {http.StatusBadRequest, constants.ErrFailedBadRequest},
+	Unauthorized: {http.StatusUnauthorized, constants.ErrUnauthorized},
+	Forbidden:    {http.StatusForbidden, constants.ErrForbidden},
+	NotFound:     {http.StatusNotFound, constants.ErrNotFound},
+	Conflict:     {http.StatusConflict, constants.ErrConflict},
+	RateLimited:  {http.StatusTooManyRequests, constants.ErrTooManyRequests},
+	Upstream:     {http.StatusBadGateway, constants.ErrUpstream},
+}
+
+var grpcCodes = map[Kind]codes.Code{
+	Internal:     codes.Internal,
+	Invalid:      codes.InvalidArgument,
+	Unauthorized: codes.Unauthenticated,
+	Forbidden:    codes.PermissionDenied,
+	NotFound:     codes.NotFound,
+	Conflict:     codes.Aborted,
+	RateLimited:  codes.ResourceExhausted,
+	Upstream:     codes.Unavailable,
+}
+
+// HTTPStatus returns the status code and response message for err. The
+// message is the generic one of its kind, err itself is only logged.
+func HTTPStatus(err error) (int, string) {
+	s := httpStatuses[KindOf(err)]
+	return s.code, s.message
+}
+
+// GRPCError converts err to a gRPC status error. Internal errors only say
+// something went wrong, others keep err's message.
+func GRPCError(err error) error {
+	kind := KindOf(err)
+	if kind == Internal {
+		return status.Error(codes.Internal, constants.ErrServerError)
+	}
+	return status.Error(grpcCodes[kind], err.Error())
+}
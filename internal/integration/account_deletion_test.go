@@ -0,0 +1,110 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestScheduledAccountDeletion(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	passwordHasher := helpers.NewPasswordHasher(2)
+	deletionSvc := &services.AccountDeletionService{
+		DeletionRepo:   &repository.AccountDeletionRepository{DB: helpers.DB},
+		UserRepo:       userRepo,
+		SessionRepo:    sessionRepo,
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:     repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+		PasswordHasher: passwordHasher,
+		EventPublisher: &external.EventPublisher{Redis: helpers.Redis},
+		Window:         time.Hour,
+		BatchSize:      10,
+	}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+		AccountDeletion:  deletionSvc,
+	}
+
+	password := "delete-me-please"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal("failed to hash password: ", err)
+	}
+	user := models.User{Username: uniqueName("delete"), Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, &user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+
+	if _, err := deletionSvc.RequestDeletion(ctx, user.ID, models.AccountDeletionRequest{Password: "wrong"}); !errors.Is(err, services.ErrIncorrectPassword) {
+		t.Fatalf("got err %v with a wrong password, want ErrIncorrectPassword", err)
+	}
+	resp, err := deletionSvc.RequestDeletion(ctx, user.ID, models.AccountDeletionRequest{Password: password})
+	if err != nil || time.Until(resp.DeletionScheduledAt) < 59*time.Minute {
+		t.Fatalf("got %+v, err %v, want a deletion in an hour", resp, err)
+	}
+
+	login := models.LoginRequest{Identifier: user.Username, Password: password}
+	if _, err := loginSvc.Login(ctx, login); !errors.Is(err, services.ErrAccountPendingDeletion) {
+		t.Fatalf("got err %v logging in, want ErrAccountPendingDeletion", err)
+	}
+	// the window hasn't ended, nothing is due
+	if _, err := deletionSvc.DeleteDueAccounts(ctx, time.Now()); err != nil {
+		t.Fatal("failed to delete due accounts: ", err)
+	}
+
+	login.CancelDeletion = true
+	if _, err := loginSvc.Login(ctx, login); err != nil {
+		t.Fatal("failed to log in cancelling the deletion: ", err)
+	}
+	got, err := userRepo.GetUserByID(ctx, user.ID)
+	if err != nil || got.Status != constants.UserStatusActive || got.DeletionScheduledAt != nil {
+		t.Fatalf("got user %+v, err %v, want the deletion cancelled", got, err)
+	}
+
+	if _, err := deletionSvc.RequestDeletion(ctx, user.ID, models.AccountDeletionRequest{Password: password}); err != nil {
+		t.Fatal("failed to request deletion again: ", err)
+	}
+
+	// a user under legal hold stays pending deletion
+	held := newUser(t, userRepo)
+	scheduled, err := deletionSvc.DeletionRepo.ScheduleUserDeletion(ctx, held.ID, held.Version, time.Now(), time.Now().Add(time.Hour))
+	if err != nil || !scheduled {
+		t.Fatalf("got scheduled %v, err %v, want the held user's deletion scheduled", scheduled, err)
+	}
+	legalHoldRepo := &repository.LegalHoldRepository{DB: helpers.DB}
+	if err := legalHoldRepo.InsertLegalHold(ctx, &models.LegalHold{UserID: held.ID, Reason: "litigation"}); err != nil {
+		t.Fatal("failed to place legal hold: ", err)
+	}
+
+	// a batch size that isn't positive falls back to the default
+	deletionSvc.BatchSize = 0
+	if _, err := deletionSvc.DeleteDueAccounts(ctx, time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatal("failed to delete due accounts: ", err)
+	}
+	if _, err := userRepo.GetUserByID(ctx, user.ID); err == nil {
+		t.Error("deleted user can still be read")
+	}
+	if got, err := userRepo.GetUserByID(ctx, held.ID); err != nil || got.Status != constants.UserStatusPendingDeletion {
+		t.Errorf("got user %+v, err %v, want the user under legal hold kept", got, err)
+	}
+	if deleted, err := deletionSvc.DeletionRepo.DeleteUser(ctx, held.ID, time.Now().Add(2*time.Hour)); err != nil || deleted {
+		t.Errorf("got deleted %v, err %v, want the user under legal hold kept", deleted, err)
+	}
+	if _, err := loginSvc.Login(ctx, login); err == nil {
+		t.Error("deleted user could log in")
+	}
+}
@@ -0,0 +1,76 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestAccountNotes(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	adminRepo := &repository.AdminRepository{DB: helpers.DB}
+	svc := &services.AccountNoteService{
+		NoteRepo:  &repository.AccountNoteRepository{DB: helpers.DB},
+		UserRepo:  userRepo,
+		AdminRepo: adminRepo,
+		AuditRepo: &repository.AuditRepository{DB: helpers.DB},
+	}
+
+	agent, officer, user := newUser(t, userRepo), newUser(t, userRepo), newUser(t, userRepo)
+	if err := adminRepo.InsertUserRole(ctx, &models.UserRole{UserID: officer.ID, Role: constants.RoleCompliance}); err != nil {
+		t.Fatal("failed to grant compliance role: ", err)
+	}
+
+	support, err := svc.AddNote(ctx, agent.ID, user.ID, models.AccountNoteRequest{Visibility: constants.AccountNoteVisibilitySupport, Body: "called about a failed top-up"})
+	if err != nil {
+		t.Fatal("failed to add support note: ", err)
+	}
+	compliance := models.AccountNoteRequest{Visibility: constants.AccountNoteVisibilityCompliance, Body: "source of funds under review"}
+	if _, err := svc.AddNote(ctx, agent.ID, user.ID, compliance); !errors.Is(err, services.ErrAccountNoteVisibility) {
+		t.Fatalf("got error %v adding a compliance note without the role", err)
+	}
+	restricted, err := svc.AddNote(ctx, officer.ID, user.ID, compliance)
+	if err != nil {
+		t.Fatal("failed to add compliance note: ", err)
+	}
+
+	page, err := svc.GetNotes(ctx, agent.ID, user.ID, models.AccountNoteListRequest{})
+	if err != nil {
+		t.Fatal("failed to get notes: ", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Body != support.Body {
+		t.Errorf("got notes %+v, want only the support note", page.Items)
+	}
+	page, err = svc.GetNotes(ctx, officer.ID, user.ID, models.AccountNoteListRequest{})
+	if err != nil {
+		t.Fatal("failed to get notes: ", err)
+	}
+	if len(page.Items) != 2 || page.Items[0].ID != restricted.ID {
+		t.Errorf("got notes %+v, want both, newest first", page.Items)
+	}
+
+	update := models.AccountNoteRequest{Visibility: constants.AccountNoteVisibilitySupport, Body: "refunded"}
+	if _, err := svc.UpdateNote(ctx, officer.ID, support.ID, update); !errors.Is(err, services.ErrAccountNoteNotAuthor) {
+		t.Errorf("got error %v editing someone else's note", err)
+	}
+	if err := svc.DeleteNote(ctx, agent.ID, restricted.ID); !errors.Is(err, services.ErrAccountNoteNotFound) {
+		t.Errorf("got error %v deleting a hidden note", err)
+	}
+	if _, err := svc.UpdateNote(ctx, agent.ID, support.ID, update); err != nil {
+		t.Fatal("failed to update note: ", err)
+	}
+	if err := svc.DeleteNote(ctx, agent.ID, support.ID); err != nil {
+		t.Fatal("failed to delete note: ", err)
+	}
+
+	if _, err := svc.GetNotes(ctx, agent.ID, 0, models.AccountNoteListRequest{}); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("got error %v for an unknown user", err)
+	}
+}
@@ -0,0 +1,168 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestManualAccountRecovery(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	auditRepo := &repository.AuditRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+	storage := memoryObjectStorage{}
+	mailer := &recordingMailer{}
+	recoverySvc := &services.AccountRecoveryService{
+		RecoveryRepo:   &repository.AccountRecoveryRepository{DB: helpers.DB},
+		UserRepo:       userRepo,
+		SessionRepo:    sessionRepo,
+		AuditRepo:      auditRepo,
+		TokenCache:     tokenCache,
+		CallerGuard:    &repository.CallerGuardRepository{Redis: helpers.Redis},
+		PasswordHasher: passwordHasher,
+		ObjectStorage:  storage,
+		Notifications: &services.NotificationService{
+			NotificationRepo: &repository.NotificationRepository{DB: helpers.DB},
+			UserRepo:         userRepo,
+			Mailer:           mailer,
+		},
+		SubmitLimit:  2,
+		SubmitWindow: time.Minute,
+		ResetURL:     "https://example.com/reset-password",
+		ResetTTL:     time.Hour,
+		EvidenceTTL:  time.Minute,
+	}
+	approvalSvc := &services.AdminApprovalService{
+		AdminRepo:   &repository.AdminRepository{DB: helpers.DB},
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		AuditRepo:   auditRepo,
+		TokenCache:  tokenCache,
+		Recovery:    recoverySvc,
+	}
+
+	user := newUser(t, userRepo)
+	maker := newUser(t, userRepo)
+	checker := newUser(t, userRepo)
+	ipAddress := fmt.Sprintf("192.0.2.%d", user.ID%250)
+	contactEmail := uniqueEmail("New.Address")
+
+	req := models.RecoveryTicketRequest{
+		Identifier:   user.Username,
+		ContactEmail: contactEmail,
+		FullName:     "repository test",
+		Dob:          "1990-01-02",
+		Statement:    "lost my phone and my email provider closed my account",
+	}
+	_, err := recoverySvc.SubmitTicket(ctx, ipAddress, req, []models.RecoveryEvidence{{Body: bytes.NewReader([]byte("plain text")), Size: 10}})
+	if !errors.Is(err, services.ErrInvalidRecoveryEvidence) {
+		t.Fatalf("got err %v submitting text evidence, want ErrInvalidRecoveryEvidence", err)
+	}
+	receipt, err := recoverySvc.SubmitTicket(ctx, ipAddress, req, []models.RecoveryEvidence{{Body: bytes.NewReader(pngHeader), Size: int64(len(pngHeader))}})
+	if err != nil || receipt.Reference == "" {
+		t.Fatalf("got receipt %+v, err %v", receipt, err)
+	}
+	if _, err := recoverySvc.SubmitTicket(ctx, ipAddress, req, nil); !errors.Is(err, services.ErrTooManyRecoveryTickets) {
+		t.Fatalf("got err %v over the submit limit, want ErrTooManyRecoveryTickets", err)
+	}
+
+	tickets, err := recoverySvc.GetTickets(ctx, models.RecoveryTicketListRequest{Status: constants.RecoveryTicketStatusPending})
+	if err != nil {
+		t.Fatal("failed to get tickets: ", err)
+	}
+	var ticketID int
+	for _, ticket := range tickets.Items {
+		if ticket.Reference == receipt.Reference {
+			ticketID = ticket.ID
+		}
+	}
+	ticket, err := recoverySvc.GetTicket(ctx, ticketID)
+	if err != nil {
+		t.Fatal("failed to get ticket: ", err)
+	}
+	if ticket.UserID != user.ID || ticket.ContactEmail != strings.ToLower(contactEmail) || len(ticket.EvidenceURLs) != 1 {
+		t.Fatalf("unexpected ticket %+v", ticket)
+	}
+	if ticket.Matches == nil || !ticket.Matches.FullName || ticket.Matches.Dob {
+		t.Errorf("got matches %+v, want the name to match and the unset dob not to", ticket.Matches)
+	}
+
+	approval, err := approvalSvc.RequestApproval(ctx, maker.ID, models.AdminApprovalRequest{
+		Action:       constants.AdminActionRecoverAccount,
+		TargetUserID: maker.ID,
+		Payload:      json.RawMessage(fmt.Sprintf(`{"ticket_id":%d}`, ticket.ID)),
+		Reason:       "identity documents match",
+	})
+	if !errors.Is(err, services.ErrRecoveryTicketMismatch) {
+		t.Fatalf("got approval %+v, err %v for another user's ticket, want ErrRecoveryTicketMismatch", approval, err)
+	}
+	recoverAccount := models.AdminApprovalRequest{
+		Action:       constants.AdminActionRecoverAccount,
+		TargetUserID: user.ID,
+		Payload:      json.RawMessage(fmt.Sprintf(`{"ticket_id":%d}`, ticket.ID)),
+		Reason:       "identity documents match",
+	}
+
+	// the contact email becomes the user's, so it can't be another account's
+	if _, err := userRepo.UpdateUser(ctx, checker.ID, checker.Version, models.User{Email: ticket.ContactEmail}); err != nil {
+		t.Fatal("failed to update user: ", err)
+	}
+	if _, err := approvalSvc.RequestApproval(ctx, maker.ID, recoverAccount); !errors.Is(err, services.ErrRecoveryEmailTaken) {
+		t.Fatalf("got err %v for a contact email in use, want ErrRecoveryEmailTaken", err)
+	}
+	if _, err := userRepo.UpdateUser(ctx, checker.ID, checker.Version+1, models.User{Email: checker.Email}); err != nil {
+		t.Fatal("failed to update user: ", err)
+	}
+
+	approval, err = approvalSvc.RequestApproval(ctx, maker.ID, recoverAccount)
+	if err != nil {
+		t.Fatal("failed to request recover_account: ", err)
+	}
+	if _, err := approvalSvc.Approve(ctx, approval.ID, checker.ID, "checked"); err != nil {
+		t.Fatal("failed to approve recover_account: ", err)
+	}
+
+	if len(mailer.sent) != 1 || mailer.sent[0].To != ticket.ContactEmail {
+		t.Fatalf("got mail %+v, want a reset link to the contact email", mailer.sent)
+	}
+	match := regexp.MustCompile(`token=([A-Z0-9]+)`).FindStringSubmatch(mailer.sent[0].Body)
+	if match == nil {
+		t.Fatalf("no reset link in %q", mailer.sent[0].Body)
+	}
+
+	reset := models.ManualRecoveryResetRequest{Token: match[1], NewPassword: "new-password"}
+	if err := recoverySvc.CompleteReset(ctx, reset); err != nil {
+		t.Fatal("failed to complete reset: ", err)
+	}
+	if err := recoverySvc.CompleteReset(ctx, reset); !errors.Is(err, services.ErrInvalidRecoveryToken) {
+		t.Fatalf("got err %v reusing the link, want ErrInvalidRecoveryToken", err)
+	}
+
+	got, err := userRepo.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal("failed to get user: ", err)
+	}
+	if got.Email != ticket.ContactEmail {
+		t.Errorf("got email %q, want the contact email", got.Email)
+	}
+	if err := passwordHasher.ComparePassword(ctx, got.Password, "new-password"); err != nil {
+		t.Error("password wasn't reset: ", err)
+	}
+}
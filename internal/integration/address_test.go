@@ -0,0 +1,88 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestAddresses(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	svc := &services.AddressService{
+		AddressRepo: &repository.AddressRepository{DB: helpers.DB},
+		UserRepo:    userRepo,
+		AuditRepo:   &repository.AuditRepository{DB: helpers.DB},
+		MaxPerUser:  3,
+	}
+
+	user := newUser(t, userRepo)
+
+	// the first address is primary even when it doesn't ask to be
+	home, err := svc.AddAddress(ctx, user.ID, models.AddressRequest{Type: constants.AddressTypeHome, Line1: "Jl. Sudirman 1", City: "Jakarta", PostalCode: "12190", Country: "ID"})
+	if err != nil || !home.IsPrimary {
+		t.Fatalf("got address %+v, err %v, want a primary one", home, err)
+	}
+
+	if _, err := svc.AddAddress(ctx, user.ID, models.AddressRequest{Type: constants.AddressTypeMailing, Line1: "1 High St", City: "London", PostalCode: "1234", Country: "GB"}); !errors.Is(err, services.ErrInvalidPostalCode) {
+		t.Errorf("got err %v for a malformed postal code", err)
+	}
+	mailing, err := svc.AddAddress(ctx, user.ID, models.AddressRequest{Type: constants.AddressTypeMailing, Line1: "1 High St", City: "London", PostalCode: "sw1a1aa", Country: "GB", IsPrimary: true})
+	if err != nil || mailing.PostalCode != "SW1A 1AA" {
+		t.Fatalf("got address %+v, err %v", mailing, err)
+	}
+	if _, err := svc.AddAddress(ctx, user.ID, models.AddressRequest{Type: constants.AddressTypeBilling, Line1: "1 Queen's Rd", City: "Hong Kong", Country: "HK"}); err != nil {
+		t.Fatal("failed to add an address without postal code: ", err)
+	}
+	if _, err := svc.AddAddress(ctx, user.ID, models.AddressRequest{Type: constants.AddressTypeBilling, Line1: "2 Queen's Rd", City: "Hong Kong", Country: "HK"}); !errors.Is(err, services.ErrTooManyAddresses) {
+		t.Errorf("got err %v past the limit", err)
+	}
+
+	addresses, err := svc.GetUserAddresses(ctx, user.ID)
+	if err != nil || len(addresses) != 3 || addresses[0].ID != mailing.ID || addresses[1].IsPrimary || addresses[2].IsPrimary {
+		t.Fatalf("got addresses %+v, err %v, want the mailing one alone primary and first", addresses, err)
+	}
+
+	updated, err := svc.UpdateAddress(ctx, user.ID, mailing.ID, models.AddressRequest{Type: constants.AddressTypeMailing, Line1: "2 High St", City: "London", PostalCode: "SW1A 1AA", Country: "GB"})
+	if err != nil || !updated.IsPrimary || updated.Line1 != "2 High St" {
+		t.Errorf("got address %+v, err %v, want it updated and still primary", updated, err)
+	}
+
+	other := newUser(t, userRepo)
+	if err := svc.DeleteAddress(ctx, other.ID, mailing.ID); !errors.Is(err, services.ErrAddressNotFound) {
+		t.Errorf("got err %v removing another user's address", err)
+	}
+
+	// removing the primary address promotes the oldest remaining one
+	if err := svc.DeleteAddress(ctx, user.ID, mailing.ID); err != nil {
+		t.Fatal("failed to delete address: ", err)
+	}
+	addresses, _ = svc.GetAddresses(ctx, user.ID)
+	if len(addresses) != 2 || addresses[0].ID != home.ID || !addresses[0].IsPrimary {
+		t.Errorf("got addresses %+v, want home primary", addresses)
+	}
+
+	if err := svc.SetPrimaryAddress(ctx, user.ID, addresses[1].ID); err != nil {
+		t.Fatal("failed to set primary address: ", err)
+	}
+	addresses, _ = svc.GetAddresses(ctx, user.ID)
+	if addresses[0].Country != "HK" || !addresses[0].IsPrimary || addresses[1].IsPrimary {
+		t.Errorf("got addresses %+v, want the HK one alone primary", addresses)
+	}
+
+	if _, err := svc.GetUserAddresses(ctx, 0); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("got err %v for a missing user", err)
+	}
+
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("target_user_id = ? AND action LIKE ?", user.ID, "address.%").Count(&audits)
+	if audits != 6 {
+		t.Errorf("got %d audit events, want 6", audits)
+	}
+}
@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestAdminApprovalFlow(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	adminRepo := &repository.AdminRepository{DB: helpers.DB}
+	svc := &services.AdminApprovalService{
+		AdminRepo:   adminRepo,
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		AuditRepo:   &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:  repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+	}
+
+	maker := newUser(t, userRepo)
+	checker := newUser(t, userRepo)
+	target := newUser(t, userRepo)
+
+	grant, err := svc.RequestApproval(ctx, maker.ID, models.AdminApprovalRequest{
+		Action:       constants.AdminActionGrantRole,
+		TargetUserID: target.ID,
+		Payload:      json.RawMessage(`{"role":"compliance"}`),
+		Reason:       "joined compliance team",
+	})
+	if err != nil {
+		t.Fatal("failed to request grant role: ", err)
+	}
+
+	if _, err := svc.Approve(ctx, grant.ID, maker.ID, ""); !errors.Is(err, services.ErrSelfApproval) {
+		t.Fatalf("got error %v approving own request, want ErrSelfApproval", err)
+	}
+	if _, err := svc.Approve(ctx, grant.ID, checker.ID, "ok"); err != nil {
+		t.Fatal("failed to approve grant role: ", err)
+	}
+	if _, err := svc.Reject(ctx, grant.ID, checker.ID, ""); !errors.Is(err, services.ErrApprovalNotPending) {
+		t.Fatalf("got error %v rejecting reviewed approval, want ErrApprovalNotPending", err)
+	}
+
+	roles, err := adminRepo.GetUserRoles(ctx, target.ID)
+	if err != nil {
+		t.Fatal("failed to get roles: ", err)
+	}
+	if len(roles) != 1 || roles[0] != constants.RoleCompliance {
+		t.Errorf("got roles %v, want [compliance]", roles)
+	}
+
+	ban, err := svc.RequestApproval(ctx, maker.ID, models.AdminApprovalRequest{
+		Action:       constants.AdminActionBanUser,
+		TargetUserID: target.ID,
+		Reason:       "fraud report",
+	})
+	if err != nil {
+		t.Fatal("failed to request ban: ", err)
+	}
+
+	got, err := userRepo.GetUserByID(ctx, target.ID)
+	if err != nil {
+		t.Fatal("failed to get user: ", err)
+	}
+	if got.Status != constants.UserStatusActive {
+		t.Errorf("got status %q before approval, want active", got.Status)
+	}
+
+	if _, err := svc.Approve(ctx, ban.ID, checker.ID, ""); err != nil {
+		t.Fatal("failed to approve ban: ", err)
+	}
+	got, err = userRepo.GetUserByID(ctx, target.ID)
+	if err != nil {
+		t.Fatal("failed to get user: ", err)
+	}
+	if got.Status != constants.UserStatusBanned {
+		t.Errorf("got status %q after approval, want banned", got.Status)
+	}
+}
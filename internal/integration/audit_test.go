@@ -0,0 +1,85 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestAuditQuery(t *testing.T) {
+	auditRepo := &repository.AuditRepository{DB: helpers.DB}
+	svc := &services.AuditService{AuditRepo: auditRepo, ExportMaxRows: 3}
+
+	actor := uniqueName("auditor")
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i, event := range []models.AuditEvent{
+		{Actor: actor, Action: constants.AuditActionUserSuspended, TargetUserID: 7, Details: `{"reason":"fraud, confirmed"}`},
+		{Actor: actor, Action: constants.AuditActionUserSuspended, TargetUserID: 8},
+		{Actor: actor, Action: constants.AuditActionUserForceLogout, TargetUserID: 7},
+		{Actor: actor, Action: constants.AuditActionUserForceLogout, TargetUserID: 7},
+	} {
+		event.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+		if err := auditRepo.InsertAuditEvent(ctx, &event); err != nil {
+			t.Fatal("failed to insert audit event: ", err)
+		}
+	}
+
+	filter := models.AuditEventFilter{Actor: actor, TargetUserID: 7}
+	page, err := svc.GetAuditEvents(ctx, models.AuditEventListRequest{Request: pagination.Request{Limit: 2}, AuditEventFilter: filter})
+	if err != nil || len(page.Items) != 2 || page.NextCursor == "" || page.Items[0].Action != constants.AuditActionUserForceLogout {
+		t.Fatalf("got page %+v, err %v, want the newest two events of user 7", page, err)
+	}
+	page, err = svc.GetAuditEvents(ctx, models.AuditEventListRequest{Request: pagination.Request{Limit: 2, Cursor: page.NextCursor}, AuditEventFilter: filter})
+	if err != nil || len(page.Items) != 1 || page.NextCursor != "" || page.Items[0].Action != constants.AuditActionUserSuspended {
+		t.Fatalf("got page %+v, err %v, want the oldest event last", page, err)
+	}
+
+	// From is inclusive and To exclusive
+	ranged := models.AuditEventFilter{Actor: actor, From: start.Add(time.Minute), To: start.Add(3 * time.Minute)}
+	page, err = svc.GetAuditEvents(ctx, models.AuditEventListRequest{AuditEventFilter: ranged})
+	if err != nil || len(page.Items) != 2 || page.Items[0].TargetUserID != 7 || page.Items[1].TargetUserID != 8 {
+		t.Fatalf("got page %+v, err %v, want the second and third events", page, err)
+	}
+
+	exporter := newUser(t, &repository.UserRepository{DB: helpers.DB})
+	if _, err := svc.ExportAuditEvents(ctx, exporter.ID, models.AuditEventFilter{Actor: actor}); !errors.Is(err, services.ErrAuditExportTooLarge) {
+		t.Fatalf("got err %v exporting over the limit, want ErrAuditExportTooLarge", err)
+	}
+	body, err := svc.ExportAuditEvents(ctx, exporter.ID, filter)
+	if err != nil {
+		t.Fatal("failed to export audit events: ", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+	if err != nil || len(records) != 4 || records[0][0] != "id" || records[3][5] != `{"reason":"fraud, confirmed"}` {
+		t.Fatalf("got records %q, err %v", records, err)
+	}
+
+	exports, err := svc.GetAuditEvents(ctx, models.AuditEventListRequest{AuditEventFilter: models.AuditEventFilter{
+		Actor:  models.UserActor(exporter.ID),
+		Action: constants.AuditActionAuditExported,
+	}})
+	if err != nil || len(exports.Items) != 1 || !strings.Contains(exports.Items[0].Details, `"rows":3`) {
+		t.Errorf("got %+v, err %v, want the export audited", exports, err)
+	}
+
+	// admin requests carry their ticket reference into the audit log
+	ticketRef := uniqueName("SUP-")
+	if err := auditRepo.InsertAuditEvent(helpers.ContextWithTicketRef(ctx, ticketRef), &models.AuditEvent{Actor: actor, Action: constants.AuditActionUserForceLogout}); err != nil {
+		t.Fatal("failed to insert audit event: ", err)
+	}
+	page, err = svc.GetAuditEvents(ctx, models.AuditEventListRequest{AuditEventFilter: models.AuditEventFilter{TicketRef: ticketRef}})
+	if err != nil || len(page.Items) != 1 || page.Items[0].TicketRef != ticketRef {
+		t.Errorf("got page %+v, err %v, want the event of the ticket", page, err)
+	}
+}
@@ -0,0 +1,462 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestAuthFlow(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	walletProvisioningRepo := &repository.WalletProvisioningRepository{DB: helpers.DB}
+	loginHistoryRepo := &repository.LoginHistoryRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	registerSvc := &services.RegisterService{
+		WalletProvisioningRepo: walletProvisioningRepo,
+		PasswordHasher:         passwordHasher,
+		EventPublisher:         &external.EventPublisher{Redis: helpers.Redis},
+	}
+	walletProvisioningSvc := &services.WalletProvisioningService{
+		WalletProvisioningRepo: walletProvisioningRepo,
+		ExternalWallet:         &external.ExtWallet{HTTPClient: helpers.NewHTTPClient()},
+		BatchSize:              10,
+		MaxAttempts:            3,
+		Interval:               time.Second,
+	}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: loginHistoryRepo,
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+	tokenValidationSvc := &services.TokenValidationService{
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		TokenCache:  tokenCache,
+	}
+	refreshTokenSvc := &services.RefreshTokenService{
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		AuditRepo:        &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:       tokenCache,
+	}
+	logoutSvc := &services.LogoutService{
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		TokenCache:       tokenCache,
+	}
+	introspectionSvc := &services.IntrospectionService{
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		TokenCache:  tokenCache,
+	}
+
+	username := uniqueName("flow")
+	password := "s3cret-password"
+	attribution := models.RegistrationAttribution{Source: "newsletter", Campaign: "spring-promo", Platform: constants.ClientPlatformWeb}
+
+	resp, err := registerSvc.Register(ctx, &models.User{
+		Username:    username,
+		Email:       uniqueEmail("flow"),
+		PhoneNumber: uniquePhone(),
+		FullName:    "Flow Test",
+		Password:    password,
+		Attribution: attribution,
+	})
+	if err != nil {
+		t.Fatal("failed to register: ", err)
+	}
+	registered := resp.(models.RegisterResponse)
+	if registered.WalletStatus != constants.WalletStatusProvisioning {
+		t.Errorf("got wallet status %q, want provisioning", registered.WalletStatus)
+	}
+	if got, err := userRepo.GetUserByID(ctx, registered.ID); err != nil || got.Attribution != attribution {
+		t.Errorf("got user %+v, err %v, want the attribution stored", got, err)
+	}
+
+	if _, err := walletProvisioningSvc.ProcessPendingWallets(ctx); err != nil {
+		t.Fatal("failed to process wallet provisioning: ", err)
+	}
+	provisioning, err := walletProvisioningSvc.GetWalletStatus(ctx, registered.ID)
+	if err != nil {
+		t.Fatal("failed to get wallet status: ", err)
+	}
+	if provisioning.Status != constants.WalletStatusCreated {
+		t.Errorf("got wallet status %q, want created", provisioning.Status)
+	}
+
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Username: username, Password: "wrong"}); err == nil {
+		t.Error("expected login with wrong password to fail")
+	}
+
+	login, err := loginSvc.Login(ctx, models.LoginRequest{Username: username, Password: password})
+	if err != nil {
+		t.Fatal("failed to login: ", err)
+	}
+
+	claim, err := tokenValidationSvc.TokenValidation(ctx, login.Token)
+	if err != nil {
+		t.Fatal("failed to validate token: ", err)
+	}
+	if claim.UserID != registered.ID {
+		t.Errorf("got user id %d, want %d", claim.UserID, registered.ID)
+	}
+
+	refreshClaim, err := helpers.ValidateToken(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatal("failed to parse refresh token: ", err)
+	}
+	if !refreshClaim.ExpiresAt.Time.Equal(login.RefreshTokenExpiresAt) {
+		t.Errorf("got refresh_token_expires_at %v, want the token's exp %v", login.RefreshTokenExpiresAt, refreshClaim.ExpiresAt.Time)
+	}
+	refreshed, err := refreshTokenSvc.RefreshToken(ctx, login.RefreshToken, *refreshClaim, models.TokenOrigin{})
+	if err != nil {
+		t.Fatal("failed to refresh token: ", err)
+	}
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == login.RefreshToken {
+		t.Error("refresh token should be rotated")
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, login.Token); err == nil {
+		t.Error("old access token should be invalid after refresh")
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, refreshed.Token); err != nil {
+		t.Fatal("failed to validate refreshed token: ", err)
+	}
+
+	introspected, err := introspectionSvc.Introspect(ctx, refreshed.Token, "")
+	if err != nil {
+		t.Fatal("failed to introspect token: ", err)
+	}
+	if !introspected.Active || introspected.TokenType != constants.TokenTypeAccess || introspected.Username != username {
+		t.Errorf("unexpected introspection of access token: %+v", introspected)
+	}
+	introspected, err = introspectionSvc.Introspect(ctx, refreshed.RefreshToken, constants.TokenTypeRefresh)
+	if err != nil {
+		t.Fatal("failed to introspect refresh token: ", err)
+	}
+	if !introspected.Active || introspected.TokenType != constants.TokenTypeRefresh {
+		t.Errorf("unexpected introspection of refresh token: %+v", introspected)
+	}
+
+	if err := logoutSvc.Logout(ctx, refreshed.Token); err != nil {
+		t.Fatal("failed to logout: ", err)
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, refreshed.Token); err == nil {
+		t.Error("token should be invalid after logout")
+	}
+	revoked, err := tokenCache.IsTokenRevoked(ctx, refreshed.Token, "")
+	if err != nil || !revoked {
+		t.Errorf("token should be on the revocation list, got %v, %v", revoked, err)
+	}
+	if revoked, err := tokenCache.IsTokenRevoked(ctx, refreshed.RefreshToken, ""); err != nil || !revoked {
+		t.Errorf("refresh token should be on the revocation list, got %v, %v", revoked, err)
+	}
+	refreshClaim, err = helpers.ValidateToken(ctx, refreshed.RefreshToken)
+	if err != nil {
+		t.Fatal("failed to parse refresh token: ", err)
+	}
+	if _, err := refreshTokenSvc.RefreshToken(ctx, refreshed.RefreshToken, *refreshClaim, models.TokenOrigin{}); err == nil {
+		t.Error("refresh token should be revoked after logout")
+	}
+
+	introspected, err = introspectionSvc.Introspect(ctx, refreshed.Token, "")
+	if err != nil {
+		t.Fatal("failed to introspect token: ", err)
+	}
+	if introspected.Active {
+		t.Errorf("token should be inactive after logout, got %+v", introspected)
+	}
+}
+
+func TestRefreshTokenReuse(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	refreshTokenRepo := &repository.RefreshTokenRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: refreshTokenRepo,
+		PasswordHasher:   passwordHasher,
+	}
+	refreshTokenSvc := &services.RefreshTokenService{
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		AuditRepo:        &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:       tokenCache,
+	}
+	tokenValidationSvc := &services.TokenValidationService{UserRepo: userRepo, SessionRepo: sessionRepo, TokenCache: tokenCache}
+
+	password := "s3cret-password"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{Username: uniqueName("reuse"), Email: uniqueEmail("reuse"), PhoneNumber: uniquePhone(), Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+
+	login, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password, IPAddress: "203.0.113.1"})
+	if err != nil {
+		t.Fatal("failed to login: ", err)
+	}
+
+	refresh := func(refreshToken string, origin models.TokenOrigin) (models.RefreshTokenResponse, error) {
+		claim, err := helpers.ValidateToken(ctx, refreshToken)
+		if err != nil {
+			t.Fatal("failed to parse refresh token: ", err)
+		}
+		return refreshTokenSvc.RefreshToken(ctx, refreshToken, *claim, origin)
+	}
+
+	first, err := refresh(login.RefreshToken, models.TokenOrigin{IPAddress: "203.0.113.1"})
+	if err != nil {
+		t.Fatal("failed to refresh token: ", err)
+	}
+	// a fresh access token, the refresh token keeps the login's expiry
+	if !first.TokenExpiresAt.After(time.Now()) || !first.TokenExpiresAt.Before(first.RefreshTokenExpiresAt) || !first.RefreshTokenExpiresAt.Equal(login.RefreshTokenExpiresAt) {
+		t.Errorf("got expiries %v and %v, want an access token expiring first and the login's refresh expiry %v", first.TokenExpiresAt, first.RefreshTokenExpiresAt, login.RefreshTokenExpiresAt)
+	}
+	second, err := refresh(first.RefreshToken, models.TokenOrigin{IPAddress: "203.0.113.1"})
+	if err != nil {
+		t.Fatal("failed to refresh rotated token: ", err)
+	}
+
+	// the first refresh token shows up again, e.g. replayed by whoever stole it
+	if _, err := refresh(login.RefreshToken, models.TokenOrigin{IPAddress: "198.51.100.9"}); !errors.Is(err, services.ErrRefreshTokenReused) {
+		t.Fatalf("got err %v, want reuse detected", err)
+	}
+
+	if _, err := refresh(second.RefreshToken, models.TokenOrigin{}); !errors.Is(err, services.ErrInvalidRefreshToken) {
+		t.Errorf("got err %v, want the latest refresh token revoked with its family", err)
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, second.Token); err == nil {
+		t.Error("access token should be invalid once its family is revoked")
+	}
+
+	root, err := refreshTokenRepo.GetRefreshTokenByHash(ctx, helpers.HashToken(login.RefreshToken))
+	if err != nil {
+		t.Fatal("failed to get refresh token: ", err)
+	}
+	family, err := refreshTokenRepo.GetRefreshTokenFamily(ctx, root.FamilyID)
+	if err != nil || len(family) != 3 {
+		t.Fatalf("got family %+v, err %v, want the root and two descendants", family, err)
+	}
+	for _, token := range family {
+		if token.RevokedAt == nil {
+			t.Errorf("refresh token %d not revoked", token.ID)
+		}
+	}
+	if family[2].ParentID == nil || *family[2].ParentID != family[1].ID || family[0].IPAddress != "203.0.113.1" {
+		t.Errorf("unexpected family %+v", family)
+	}
+
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("action = ? AND details LIKE ?", constants.AuditActionRefreshTokenReuse, "%"+root.FamilyID+"%").Count(&audits)
+	if audits != 1 {
+		t.Errorf("got %d reuse audit events, want 1", audits)
+	}
+}
+
+func TestProgressiveRegistration(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	registerSvc := &services.RegisterService{
+		WalletProvisioningRepo: &repository.WalletProvisioningRepository{DB: helpers.DB},
+		PasswordHasher:         passwordHasher,
+		EventPublisher:         &external.EventPublisher{Redis: helpers.Redis},
+	}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+	tokenValidationSvc := &services.TokenValidationService{UserRepo: userRepo, SessionRepo: sessionRepo, TokenCache: tokenCache}
+	profileSvc := &services.ProfileService{
+		UserRepo: userRepo,
+		UserClaimsService: &services.UserClaimsService{
+			SessionRepo:    sessionRepo,
+			TokenCache:     tokenCache,
+			EventPublisher: &external.EventPublisher{Redis: helpers.Redis},
+		},
+		PasswordHasher: passwordHasher,
+	}
+
+	username := uniqueName("partial")
+	password := "s3cret-password"
+	user := &models.User{Username: username, PhoneNumber: uniquePhone(), Password: password}
+	if err := user.Validate(); err != nil {
+		t.Fatal("phone and password should be enough to register: ", err)
+	}
+	resp, err := registerSvc.Register(ctx, user)
+	if err != nil {
+		t.Fatal("failed to register: ", err)
+	}
+	if got := resp.(models.RegisterResponse).ProfileCompleteness; got != 20 {
+		t.Errorf("got profile completeness %d after registration, want 20", got)
+	}
+
+	login, err := loginSvc.Login(ctx, models.LoginRequest{Username: username, Password: password})
+	if err != nil {
+		t.Fatal("failed to login: ", err)
+	}
+	if claim, err := tokenValidationSvc.TokenValidation(ctx, login.Token); err != nil || claim.ProfileCompleteness != 20 {
+		t.Fatalf("got claim %+v, err %v, want profile completeness 20", claim, err)
+	}
+
+	profile, err := profileSvc.UpdateProfile(ctx, user.ID, models.UpdateProfileRequest{
+		Email:    uniqueEmail("partial"),
+		FullName: "Partial User",
+		Address:  "Jl. Sudirman 1",
+		Dob:      "1990-01-01",
+		// adding an email confirms the password like changing one
+		CurrentPassword: password,
+	})
+	if err != nil {
+		t.Fatal("failed to complete profile: ", err)
+	}
+	if profile.ProfileCompleteness != 100 {
+		t.Errorf("got profile completeness %d after completing the profile, want 100", profile.ProfileCompleteness)
+	}
+
+	// the cached validation is evicted, so the claim follows the profile
+	if claim, err := tokenValidationSvc.TokenValidation(ctx, login.Token); err != nil || claim.ProfileCompleteness != 100 {
+		t.Errorf("got claim %+v, err %v, want profile completeness 100", claim, err)
+	}
+}
+
+func TestGuestUpgrade(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	guestSvc := &services.GuestService{
+		UserRepo:               userRepo,
+		SessionRepo:            sessionRepo,
+		WalletProvisioningRepo: &repository.WalletProvisioningRepository{DB: helpers.DB},
+		AuditRepo:              &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:             tokenCache,
+		RefreshTokenRepo:       &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:         passwordHasher,
+	}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+	tokenValidationSvc := &services.TokenValidationService{UserRepo: userRepo, SessionRepo: sessionRepo, TokenCache: tokenCache}
+
+	guest, err := guestSvc.CreateGuest(ctx, models.GuestRequest{DeviceID: "device-1"})
+	if err != nil {
+		t.Fatal("failed to create guest: ", err)
+	}
+	claim, err := tokenValidationSvc.TokenValidation(ctx, guest.Token)
+	if err != nil || !claim.Guest || claim.DeviceID != "device-1" {
+		t.Fatalf("got claim %+v, err %v, want a guest token bound to device-1", claim, err)
+	}
+
+	// a guest has no password to log in with
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Username: guest.Username, Password: "anything"}); err == nil {
+		t.Error("guest should not be able to log in")
+	}
+
+	username := uniqueName("upgraded")
+	password := "s3cret-password"
+	upgraded, err := guestSvc.UpgradeGuest(ctx, guest.UserID, models.UpgradeGuestRequest{
+		Username: username,
+		Password: password,
+		Email:    uniqueEmail("upgraded"),
+	})
+	if err != nil {
+		t.Fatal("failed to upgrade guest: ", err)
+	}
+	if upgraded.UserID != guest.UserID {
+		t.Errorf("got user id %d after upgrade, want %d", upgraded.UserID, guest.UserID)
+	}
+
+	if _, err := tokenValidationSvc.TokenValidation(ctx, guest.Token); err == nil {
+		t.Error("guest token should be revoked after upgrade")
+	}
+	claim, err = tokenValidationSvc.TokenValidation(ctx, upgraded.Token)
+	if err != nil || claim.Guest || claim.DeviceID != "" {
+		t.Errorf("got claim %+v, err %v, want a regular token", claim, err)
+	}
+
+	login, err := loginSvc.Login(ctx, models.LoginRequest{Username: username, Password: password})
+	if err != nil || login.UserID != guest.UserID {
+		t.Errorf("got login %+v, err %v, want user %d", login, err, guest.UserID)
+	}
+
+	if _, err := guestSvc.UpgradeGuest(ctx, guest.UserID, models.UpgradeGuestRequest{Username: username, Password: password}); !errors.Is(err, services.ErrNotGuest) {
+		t.Errorf("got err %v upgrading twice, want ErrNotGuest", err)
+	}
+}
+
+func TestLoginByIdentifier(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	registerSvc := &services.RegisterService{
+		WalletProvisioningRepo: &repository.WalletProvisioningRepository{DB: helpers.DB},
+		PasswordHasher:         passwordHasher,
+		EventPublisher:         &external.EventPublisher{Redis: helpers.Redis},
+	}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+
+	username := uniqueName("ident")
+	suffix := strings.TrimPrefix(username, "ident")
+	password := "s3cret-password"
+	user := &models.User{
+		Username:    username,
+		Email:       username + "@example.com",
+		PhoneNumber: "+62" + suffix,
+		Password:    password,
+	}
+	if _, err := registerSvc.Register(ctx, user); err != nil {
+		t.Fatal("failed to register: ", err)
+	}
+
+	for _, identifier := range []string{username, strings.ToUpper(username) + "@Example.com", "0" + suffix, "+62 " + suffix} {
+		login, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: identifier, Password: password})
+		if err != nil || login.UserID != user.ID {
+			t.Errorf("login with %q: got %+v, err %v, want user %d", identifier, login, err, user.ID)
+		}
+	}
+
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: "nobody@example.com", Password: password}); err == nil {
+		t.Error("expected error for unknown email")
+	}
+}
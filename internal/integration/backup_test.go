@@ -0,0 +1,81 @@
+//go:build integration
+
+package integration
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func newBackupKeyEncrypter(t *testing.T, id string) *helpers.LocalKeyEncrypter {
+	t.Helper()
+	key := make([]byte, 32)
+	rand.Read(key)
+	encrypter, err := helpers.NewLocalKeyEncrypter(id+":"+base64.StdEncoding.EncodeToString(key), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encrypter
+}
+
+func TestBackupExportAndVerify(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	storage := memoryObjectStorage{}
+	svc := &services.BackupService{
+		BackupRepo: &repository.BackupRepository{DB: helpers.DB},
+		AuditRepo:  &repository.AuditRepository{DB: helpers.DB},
+		Storage:    storage,
+		Encrypter:  newBackupKeyEncrypter(t, "backup-1"),
+		BatchSize:  2,
+	}
+
+	newUser(t, userRepo)
+	newUser(t, userRepo)
+	newUser(t, userRepo)
+
+	// batches of none would make an empty backup that still verifies
+	empty := *svc
+	empty.BatchSize = 0
+	if _, err := empty.Export(ctx, []string{constants.BackupTableUsers}, "backups/", time.Now()); !apperr.Is(err, apperr.Invalid) || len(storage) != 0 {
+		t.Fatalf("got err %v, %d archives, want a batch size of 0 refused", err, len(storage))
+	}
+
+	exported, err := svc.Export(ctx, []string{constants.BackupTableUsers, constants.BackupTableAudit}, "backups/", time.Now())
+	if err != nil {
+		t.Fatal("failed to export backup: ", err)
+	}
+	if len(exported.Manifest.Tables) != 2 || exported.Manifest.Tables[0].Rows < 3 {
+		t.Fatalf("unexpected manifest %+v", exported.Manifest)
+	}
+
+	verified, err := svc.Verify(ctx, exported.ObjectKey)
+	if err != nil {
+		t.Fatal("failed to verify backup: ", err)
+	}
+	if verified.KeyID != "backup-1" || len(verified.Manifest.Tables) != 2 || verified.Manifest.Tables[0] != exported.Manifest.Tables[0] {
+		t.Errorf("got %+v, want the exported manifest %+v", verified, exported)
+	}
+
+	// a flipped byte anywhere fails verification
+	data := storage[exported.ObjectKey]
+	data[len(data)/2] ^= 1
+	if _, err := svc.Verify(ctx, exported.ObjectKey); !apperr.Is(err, apperr.Invalid) {
+		t.Errorf("got %v, want a corrupt backup", err)
+	}
+	data[len(data)/2] ^= 1
+
+	// the archive can't be read without its master key
+	other := *svc
+	other.Encrypter = newBackupKeyEncrypter(t, "backup-2")
+	if _, err := other.Verify(ctx, exported.ObjectKey); err == nil {
+		t.Error("expected an error without the master key")
+	}
+}
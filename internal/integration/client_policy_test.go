@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestClientTokenPolicy(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	clientRepo := &repository.ClientRepository{DB: helpers.DB}
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	client := &models.Client{
+		ClientID:               uniqueName("client"),
+		Name:                   "Mobile",
+		AccessTokenTTLSeconds:  60,
+		RefreshTokenTTLSeconds: 600,
+		Scopes:                 "wallet:read wallet:write",
+		Claims:                 "username",
+		Audience:               "mobile",
+	}
+	if err := clientRepo.UpsertClient(ctx, client); err != nil {
+		t.Fatal("failed to register client: ", err)
+	}
+
+	password := "s3cret-password"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{
+		Username:    uniqueName("policy"),
+		Email:       uniqueEmail("policy"),
+		PhoneNumber: uniquePhone(),
+		FullName:    "Policy Test",
+		Password:    hashed,
+	}
+	if err := userRepo.InsertNewUser(ctx, user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		ClientRepo:       clientRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password, ClientID: "unknown"}); err == nil {
+		t.Error("expected login with an unregistered client to fail")
+	}
+
+	now := time.Now()
+	login, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password, ClientID: client.ClientID, Scope: "wallet:read admin"})
+	if err != nil {
+		t.Fatal("failed to login: ", err)
+	}
+	if login.Scope != "wallet:read" {
+		t.Errorf("got scope %q, want only the allowed requested scope", login.Scope)
+	}
+
+	claim, err := helpers.ValidateToken(ctx, login.Token)
+	if err != nil {
+		t.Fatal("failed to validate token: ", err)
+	}
+	if claim.ClientID != client.ClientID || claim.Scope != "wallet:read" || !slices.Equal(claim.Audience, []string{"mobile"}) {
+		t.Errorf("unexpected client claims %+v", claim)
+	}
+	if claim.Username != user.Username || claim.Email != "" || claim.FullName != "" {
+		t.Errorf("token should only carry the username claim, got %+v", claim)
+	}
+	if ttl := claim.ExpiresAt.Sub(now); ttl > 61*time.Second {
+		t.Errorf("got access token ttl %v, want the client's 60s", ttl)
+	}
+
+	session, err := sessionRepo.GetUserSessionByRefreshToken(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatal("failed to get session: ", err)
+	}
+	if ttl := session.RefreshTokenExpired.Sub(now); ttl > 601*time.Second {
+		t.Errorf("got refresh token ttl %v, want the client's 600s", ttl)
+	}
+}
@@ -0,0 +1,51 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestConsentService(t *testing.T) {
+	user := newUser(t, &repository.UserRepository{DB: helpers.DB})
+	svc := &services.ConsentService{ConsentRepo: &repository.ConsentRepository{DB: helpers.DB}}
+	granted, withdrawn := true, false
+
+	update := func(items ...models.ConsentItem) []models.UserConsent {
+		t.Helper()
+		consents, err := svc.UpdateConsents(ctx, user.ID, models.UpdateConsentsRequest{Consents: items})
+		if err != nil {
+			t.Fatal("failed to update consents: ", err)
+		}
+		return consents
+	}
+
+	update(
+		models.ConsentItem{Type: "marketing", Granted: &granted, Version: "v1"},
+		models.ConsentItem{Type: "data_sharing", Granted: &granted, Version: "v1"},
+	)
+	consents := update(
+		models.ConsentItem{Type: "marketing", Granted: &withdrawn, Version: "v1"},
+		models.ConsentItem{Type: "data_sharing", Granted: &granted, Version: "v1"},
+	)
+
+	current := map[string]bool{}
+	for _, consent := range consents {
+		current[consent.ConsentType] = consent.Granted
+	}
+	if len(current) != 2 || current["marketing"] || !current["data_sharing"] {
+		t.Errorf("unexpected current consents: %+v", consents)
+	}
+
+	var history int64
+	helpers.DB.Model(&models.UserConsent{}).Where("user_id = ?", user.ID).Count(&history)
+	// the unchanged data_sharing resubmission is not recorded again
+	if history != 3 {
+		t.Errorf("got %d consent records, want 3", history)
+	}
+}
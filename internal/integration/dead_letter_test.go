@@ -0,0 +1,98 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestDeadLetters(t *testing.T) {
+	deadLetterRepo := &repository.DeadLetterRepository{DB: helpers.DB}
+	svc := &services.DeadLetterService{
+		DeadLetterRepo: deadLetterRepo,
+		Publisher:      &external.EventPublisher{Redis: helpers.Redis},
+		Consumer:       &external.EventConsumer{Redis: helpers.Redis},
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+	}
+
+	// nothing listens there, so publishing fails
+	unreachable := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer unreachable.Close()
+	publisher := &services.DeadLetterPublisher{
+		Publisher:   &external.EventPublisher{Redis: unreachable},
+		DeadLetters: svc,
+	}
+
+	topic := constants.EventUserRegistered
+	if err := publisher.Publish(ctx, topic, &events.UserRegistered{SchemaVersion: 1, UserId: 7}); err != nil {
+		t.Fatalf("expected the failed publish to be dead lettered, got %v", err)
+	}
+
+	page, err := svc.GetDeadLetters(ctx, models.DeadLetterListRequest{Status: constants.DeadLetterStatusPending, Topic: topic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) == 0 || page.Items[0].Direction != constants.DeadLetterDirectionPublish {
+		t.Fatalf("got %+v, want the failed publish pending", page.Items)
+	}
+	published := page.Items[0]
+
+	_, err = svc.UpdateDeadLetter(ctx, constants.AuditActorSystem, published.ID, models.DeadLetterUpdateRequest{Payload: []byte(`{"user_id":"seven"}`), Note: "typo"})
+	if !apperr.Is(err, apperr.Invalid) {
+		t.Errorf("expected a payload off the schema to be refused, got %v", err)
+	}
+
+	replayed, err := svc.ReplayDeadLetter(ctx, constants.AuditActorSystem, published.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Status != constants.DeadLetterStatusReplayed || replayed.ResolvedAt == nil {
+		t.Errorf("got %+v, want it replayed", replayed)
+	}
+	if _, err := svc.ReplayDeadLetter(ctx, constants.AuditActorSystem, published.ID); !apperr.Is(err, apperr.Conflict) {
+		t.Errorf("expected a second replay to conflict, got %v", err)
+	}
+
+	// an event the inbox can't apply is dead lettered, not dropped
+	inbox := &services.InboxService{DeadLetters: svc}
+	if err := inbox.HandleEvent(ctx, constants.EventFraudFlagged, []byte(`{"user_id":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	page, err = svc.GetDeadLetters(ctx, models.DeadLetterListRequest{Status: constants.DeadLetterStatusPending, Topic: constants.EventFraudFlagged})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) == 0 || page.Items[0].Direction != constants.DeadLetterDirectionConsume {
+		t.Fatalf("got %+v, want the invalid event pending", page.Items)
+	}
+	consumed := page.Items[0]
+
+	if _, err := svc.DiscardDeadLetter(ctx, constants.AuditActorSystem, consumed.ID, models.DeadLetterDiscardRequest{Note: "test event"}); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := deadLetterRepo.GetDeadLetterByID(ctx, consumed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Status != constants.DeadLetterStatusDiscarded || latest.Note != "test event" {
+		t.Errorf("got %+v, want it discarded with the note", latest)
+	}
+
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("action IN ?", []string{constants.AuditActionDeadLetterReplayed, constants.AuditActionDeadLetterDiscarded}).Count(&audits)
+	if audits < 2 {
+		t.Errorf("got %d dead letter audit events, want the replay and discard audited", audits)
+	}
+}
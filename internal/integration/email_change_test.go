@@ -0,0 +1,130 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+// recordingNotifications keeps the security events instead of sending them in
+// the background.
+type recordingNotifications struct {
+	interfaces.INotificationService
+	events []models.SecurityEvent
+}
+
+func (n *recordingNotifications) NotifySecurityEvent(ctx context.Context, userID int, event models.SecurityEvent) {
+	n.events = append(n.events, event)
+}
+
+func TestEmailChangeRevert(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	mailer := &recordingMailer{}
+	notificationSvc := &services.NotificationService{
+		NotificationRepo: &repository.NotificationRepository{DB: helpers.DB},
+		UserRepo:         userRepo,
+		Mailer:           mailer,
+	}
+	notifications := &recordingNotifications{INotificationService: notificationSvc}
+	profileSvc := &services.ProfileService{
+		UserRepo: userRepo,
+		UserClaimsService: &services.UserClaimsService{
+			SessionRepo:    sessionRepo,
+			TokenCache:     tokenCache,
+			EventPublisher: &external.EventPublisher{Redis: helpers.Redis},
+		},
+		Notifications:   notifications,
+		EmailChangeRepo: &repository.EmailChangeRepository{DB: helpers.DB},
+		SessionRepo:     sessionRepo,
+		TokenCache:      tokenCache,
+		AuditRepo:       &repository.AuditRepository{DB: helpers.DB},
+		RevertURL:       "https://example.com/revert-email-change",
+		RevertTTL:       72 * time.Hour,
+		PasswordHasher:  helpers.NewPasswordHasher(2),
+	}
+
+	user := newUser(t, userRepo)
+	passwordHash, err := profileSvc.PasswordHasher.HashPassword(ctx, "s3cret-password")
+	if err != nil {
+		t.Fatal("failed to hash password: ", err)
+	}
+	if err := userRepo.UpdateUserPassword(ctx, user.ID, passwordHash, false); err != nil {
+		t.Fatal("failed to set password: ", err)
+	}
+	session := &models.UserSession{UserID: user.ID, Token: uniqueName("revert"), TokenExpired: time.Now().Add(time.Hour)}
+	if err := sessionRepo.InsertNewUserSession(ctx, session); err != nil {
+		t.Fatal("failed to insert session: ", err)
+	}
+
+	// a session alone can't move the account to another email
+	newEmail := uniqueEmail("takenover")
+	if _, err := profileSvc.UpdateProfile(ctx, user.ID, models.UpdateProfileRequest{Email: newEmail, CurrentPassword: "wrong-password"}); !errors.Is(err, services.ErrIncorrectPassword) {
+		t.Fatalf("got err %v changing email with a wrong password, want ErrIncorrectPassword", err)
+	}
+	if _, err := profileSvc.UpdateProfile(ctx, user.ID, models.UpdateProfileRequest{Email: newEmail, CurrentPassword: "s3cret-password"}); err != nil {
+		t.Fatal("failed to change email: ", err)
+	}
+	if len(notifications.events) != 1 {
+		t.Fatalf("got events %+v, want one email_changed", notifications.events)
+	}
+	event := notifications.events[0]
+	if event.PreviousEmail != user.Email || event.RevertExpiresInHours != 72 || !strings.HasPrefix(event.RevertURL, "https://example.com/revert-email-change?token=") {
+		t.Fatalf("got event %+v, want an undo link for the previous email", event)
+	}
+
+	if err := notificationSvc.SendSecurityEmail(ctx, user.ID, event); err != nil {
+		t.Fatal("failed to send email_changed: ", err)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != user.Email || !strings.Contains(mailer.sent[0].Body, event.RevertURL) {
+		t.Fatalf("got mail %+v, want the undo link sent to the previous email", mailer.sent)
+	}
+	if strings.Contains(mailer.sent[0].Body, "Stop these emails") {
+		t.Errorf("got mail %+v, want no unsubscribe link on a mandatory notice", mailer.sent[0])
+	}
+
+	req := models.EmailChangeRevertRequest{
+		Token:       strings.TrimPrefix(event.RevertURL, "https://example.com/revert-email-change?token="),
+		NewPassword: "n3w-s3cret-password",
+	}
+	resp, err := profileSvc.RevertEmailChange(ctx, req)
+	if err != nil || resp.SessionsRevoked != 1 {
+		t.Fatalf("got %+v, err %v, want the session revoked", resp, err)
+	}
+	if _, err := profileSvc.RevertEmailChange(ctx, req); !errors.Is(err, services.ErrInvalidEmailChangeRevert) {
+		t.Fatalf("got err %v reusing the link, want ErrInvalidEmailChangeRevert", err)
+	}
+
+	got, err := userRepo.GetUserByID(ctx, user.ID)
+	if err != nil || got.Email != user.Email {
+		t.Fatalf("got user %+v, err %v, want the previous email restored", got, err)
+	}
+	if err := profileSvc.PasswordHasher.ComparePassword(ctx, got.Password, req.NewPassword); err != nil {
+		t.Errorf("got err %v, want the new password set", err)
+	}
+	if last := notifications.events[len(notifications.events)-1]; last.Event != constants.NotificationEventPasswordChanged {
+		t.Errorf("got event %+v, want password_changed", last)
+	}
+	revoked, err := tokenCache.IsTokenRevoked(ctx, session.Token, "")
+	if err != nil || !revoked {
+		t.Errorf("got revoked %v, err %v, want the session token revoked", revoked, err)
+	}
+
+	events, err := (&repository.AuditRepository{DB: helpers.DB}).GetAuditEvents(ctx, models.AuditEventFilter{TargetUserID: user.ID, Action: constants.AuditActionEmailChangeReverted}, nil, 10)
+	if err != nil || len(events) != 1 {
+		t.Errorf("got audit events %+v, err %v, want one email change revert", events, err)
+	}
+}
@@ -0,0 +1,93 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestEntitlements(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	byKycStatus, err := services.ParseEntitlementsByKycStatus("unverified=crypto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := &services.EntitlementService{
+		EntitlementRepo: &repository.EntitlementRepository{DB: helpers.DB},
+		UserRepo:        userRepo,
+		AuditRepo:       &repository.AuditRepository{DB: helpers.DB},
+		UserClaims: &services.UserClaimsService{
+			SessionRepo:    &repository.SessionRepository{DB: helpers.DB},
+			TokenCache:     repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+			EventPublisher: &external.EventPublisher{Redis: helpers.Redis},
+		},
+		ByKycStatus: byKycStatus,
+	}
+
+	if _, err := services.ParseEntitlementsByKycStatus("verified=teleport"); err == nil {
+		t.Error("unknown features should be refused")
+	}
+
+	user := newUser(t, userRepo)
+	actor := models.UserActor(1)
+
+	features, err := svc.GetUserFeatures(ctx, user.ID)
+	if err != nil || !slices.Equal(features, []string{constants.FeatureCrypto}) {
+		t.Fatalf("got features %v, err %v, want the kyc ones", features, err)
+	}
+
+	grant := models.EntitlementRequest{Feature: constants.FeatureInternationalTransfer, Reason: "approved corridor"}
+	for range 2 {
+		if err := svc.GrantEntitlement(ctx, actor, user.ID, grant); err != nil {
+			t.Fatal("failed to grant entitlement: ", err)
+		}
+	}
+	if err := svc.GrantEntitlement(ctx, actor, user.ID, models.EntitlementRequest{Feature: constants.FeatureCrypto, Reason: "early access"}); err != nil {
+		t.Fatal("failed to grant entitlement: ", err)
+	}
+
+	entitlements, err := svc.GetEntitlements(ctx, user.ID)
+	if err != nil {
+		t.Fatal("failed to get entitlements: ", err)
+	}
+	if len(entitlements) != 2 || entitlements[0].Source != constants.EntitlementSourceGrant || entitlements[1].Feature != constants.FeatureInternationalTransfer || entitlements[1].GrantedBy != actor {
+		t.Errorf("unexpected entitlements %+v", entitlements)
+	}
+
+	// the grant goes, the kyc entitlement stays
+	if err := svc.RevokeEntitlement(ctx, actor, user.ID, models.EntitlementRequest{Feature: constants.FeatureCrypto, Reason: "early access over"}); err != nil {
+		t.Fatal("failed to revoke entitlement: ", err)
+	}
+	if err := svc.RevokeEntitlement(ctx, actor, user.ID, models.EntitlementRequest{Feature: constants.FeatureCrypto, Reason: "again"}); !errors.Is(err, services.ErrEntitlementNotGranted) {
+		t.Errorf("got err %v revoking a kyc entitlement", err)
+	}
+	features, _ = svc.GetUserFeatures(ctx, user.ID)
+	if !slices.Equal(features, []string{constants.FeatureCrypto, constants.FeatureInternationalTransfer}) {
+		t.Errorf("got features %v after revoking the crypto grant", features)
+	}
+
+	claims, err := svc.EnrichClaims(ctx, &helpers.ClaimToken{UserID: user.ID})
+	if err != nil || !slices.Equal(claims[constants.ClaimEntitlements].([]string), features) {
+		t.Errorf("got claims %v, err %v", claims, err)
+	}
+
+	if _, err := svc.GetUserFeatures(ctx, 0); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("got err %v for a missing user", err)
+	}
+
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("target_user_id = ? AND action IN ?", user.ID, []string{constants.AuditActionEntitlementGranted, constants.AuditActionEntitlementRevoked}).Count(&audits)
+	if audits != 3 {
+		t.Errorf("got %d audit events, want 3", audits)
+	}
+}
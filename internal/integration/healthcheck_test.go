@@ -0,0 +1,63 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// downWallet is a wallet service that can't be reached.
+type downWallet struct {
+	external.ExtWallet
+}
+
+func (w *downWallet) Ping(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func TestHealthcheck(t *testing.T) {
+	healthcheckRepo := &repository.HealthcheckRepository{DB: helpers.DB, Redis: helpers.Redis}
+	svc := &services.Healthcheck{
+		HealthcheckRepository: healthcheckRepo,
+		ExternalWallet:        &external.ExtWallet{HTTPClient: helpers.NewHTTPClient()},
+		Timeout:               time.Second,
+	}
+
+	report := svc.Check(ctx)
+	if report.Status != constants.HealthStatusUp {
+		t.Fatalf("expected up, got %+v", report)
+	}
+	for _, dependency := range report.Dependencies {
+		if dependency.Status != constants.HealthStatusUp {
+			t.Errorf("expected %s to be up, got %+v", dependency.Name, dependency)
+		}
+	}
+
+	svc.ExternalWallet = &downWallet{}
+	report = svc.Check(ctx)
+	if report.Status != constants.HealthStatusDegraded {
+		t.Fatalf("expected degraded without the wallet service, got %+v", report)
+	}
+
+	unreachable := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+	defer unreachable.Close()
+	svc.HealthcheckRepository = &repository.HealthcheckRepository{DB: helpers.DB, Redis: unreachable}
+	report = svc.Check(ctx)
+	if report.Status != constants.HealthStatusDown {
+		t.Fatalf("expected down without redis, got %+v", report)
+	}
+	if report.Dependencies[1].Name != constants.DependencyRedis || report.Dependencies[1].Error == "" {
+		t.Fatalf("expected the redis error to be reported, got %+v", report.Dependencies[1])
+	}
+}
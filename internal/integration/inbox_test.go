@@ -0,0 +1,116 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestInboxFraudFlagged(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	svc := &services.InboxService{
+		InboxRepo: &repository.InboxRepository{DB: helpers.DB},
+		UserAdmin: &services.UserAdminService{
+			AdminRepo:   &repository.AdminRepository{DB: helpers.DB},
+			UserRepo:    userRepo,
+			SessionRepo: &repository.SessionRepository{DB: helpers.DB},
+			AuditRepo:   &repository.AuditRepository{DB: helpers.DB},
+			TokenCache:  repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+		},
+		WalletProvisioningRepo: &repository.WalletProvisioningRepository{DB: helpers.DB},
+		AuditRepo:              &repository.AuditRepository{DB: helpers.DB},
+		ClaimTimeout:           time.Minute,
+	}
+
+	user := newUser(t, userRepo)
+	payload := fmt.Appendf(nil, `{"event_id":%q,"user_id":%d,"reason":"card testing"}`, uniqueName("evt"), user.ID)
+
+	// delivered twice, applied once
+	for range 2 {
+		if err := svc.HandleEvent(ctx, constants.EventFraudFlagged, payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latest, err := userRepo.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Status != constants.UserStatusBanned {
+		t.Errorf("got status %q, want the flagged user banned", latest.Status)
+	}
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("action = ? AND target_user_id = ?", constants.AuditActionUserSuspended, user.ID).Count(&audits)
+	if audits != 1 {
+		t.Errorf("got %d suspensions, want 1", audits)
+	}
+
+	if err := svc.HandleEvent(ctx, constants.EventFraudFlagged, []byte(`{"user_id":1}`)); err != nil {
+		t.Errorf("expected an invalid event to be dropped, got %v", err)
+	}
+}
+
+func TestEventConsumer(t *testing.T) {
+	topic := uniqueName("topic")
+	consumer := &external.EventConsumer{
+		Redis:         helpers.Redis,
+		Group:         "ewallet-ums",
+		Consumer:      "test",
+		ReclaimIdle:   100 * time.Millisecond,
+		MaxDeliveries: 10,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the first delivery fails, the reclaimed one succeeds
+	payloads := make(chan string, 10)
+	failed := false
+	go consumer.Consume(ctx, []string{topic}, func(ctx context.Context, topic string, payload []byte) error {
+		payloads <- string(payload)
+		if !failed {
+			failed = true
+			return fmt.Errorf("temporary failure")
+		}
+		return nil
+	})
+
+	// the group is created at the stream's start, so this is read even if
+	// it is added before the consumer starts
+	err := helpers.Redis.XAdd(ctx, &redis.XAddArgs{Stream: topic, Values: map[string]any{"payload": `{"event_id":"1"}`}}).Err()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for attempt := range 2 {
+		select {
+		case payload := <-payloads:
+			if payload != `{"event_id":"1"}` {
+				t.Fatalf("got payload %s", payload)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("event not delivered on attempt %d", attempt+1)
+		}
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	pending, err := helpers.Redis.XPending(ctx, topic, "ewallet-ums").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Count != 0 {
+		t.Errorf("got %d pending events, want the handled one acknowledged", pending.Count)
+	}
+}
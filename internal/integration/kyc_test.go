@@ -0,0 +1,81 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestKycProfile(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	addressRepo := &repository.AddressRepository{DB: helpers.DB}
+	requiredFields, err := services.ParseKycRequiredFields("default=occupation,ID=occupation employer source_of_funds next_of_kin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := &services.KycService{
+		KycRepo:        &repository.KycRepository{DB: helpers.DB},
+		AddressRepo:    addressRepo,
+		UserRepo:       userRepo,
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		RequiredFields: requiredFields,
+	}
+
+	for _, value := range []string{"default=hobby", "Indonesia=occupation"} {
+		if _, err := services.ParseKycRequiredFields(value); err == nil {
+			t.Errorf("%q should be refused", value)
+		}
+	}
+
+	user := newUser(t, userRepo)
+
+	// without an address the default rules apply
+	profile, err := svc.GetKycProfile(ctx, user.ID)
+	if err != nil || profile.Jurisdiction != constants.KycJurisdictionDefault || !slices.Equal(profile.Missing, []string{constants.KycFieldOccupation}) {
+		t.Fatalf("got profile %+v, err %v", profile, err)
+	}
+
+	if err := addressRepo.InsertUserAddress(ctx, &models.UserAddress{UserID: user.ID, Type: constants.AddressTypeHome, Line1: "Jl. Sudirman 1", City: "Jakarta", PostalCode: "12190", Country: "ID"}); err != nil {
+		t.Fatal("failed to insert address: ", err)
+	}
+
+	req := models.KycProfileRequest{EmploymentStatus: constants.EmploymentStatusEmployed, Occupation: "Engineer", SourceOfFunds: "salary"}
+	if _, err := svc.UpdateKycProfile(ctx, user.ID, req); !errors.Is(err, services.ErrKycFieldsMissing) {
+		t.Errorf("got err %v without employer and next of kin in ID", err)
+	}
+
+	req.EmployerName = "Acme"
+	req.NextOfKin = &models.NextOfKin{FullName: "Siti", Relationship: "spouse", PhoneNumber: "0812-3456-7890"}
+	profile, err = svc.UpdateKycProfile(ctx, user.ID, req)
+	if err != nil || profile.Jurisdiction != "ID" || len(profile.Missing) != 0 || profile.NextOfKin.PhoneNumber != "+6281234567890" {
+		t.Fatalf("got profile %+v, err %v", profile, err)
+	}
+
+	// students have no employer to name
+	req = models.KycProfileRequest{EmploymentStatus: "student", Occupation: "Student", SourceOfFunds: "allowance", NextOfKin: req.NextOfKin}
+	if _, err := svc.UpdateKycProfile(ctx, user.ID, req); err != nil {
+		t.Fatal("failed to update kyc profile: ", err)
+	}
+
+	profile, err = svc.GetUserKycProfile(ctx, user.ID)
+	if err != nil || profile.Occupation != "Student" || profile.EmployerName != "" || profile.NextOfKin.FullName != "Siti" {
+		t.Errorf("got profile %+v, err %v", profile, err)
+	}
+	if _, err := svc.GetUserKycProfile(ctx, 0); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("got err %v for a missing user", err)
+	}
+
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("target_user_id = ? AND action = ?", user.ID, constants.AuditActionKycProfileUpdated).Count(&audits)
+	if audits != 2 {
+		t.Errorf("got %d audit events, want 2", audits)
+	}
+}
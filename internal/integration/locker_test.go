@@ -0,0 +1,73 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/locker"
+)
+
+func TestLockers(t *testing.T) {
+	lockers := map[string]interfaces.ILocker{
+		"redis": &locker.RedisLocker{Redis: helpers.Redis},
+		"mysql": &locker.MySQLLocker{DB: helpers.DB},
+	}
+
+	for backend, l := range lockers {
+		t.Run(backend, func(t *testing.T) {
+			name := uniqueName("job")
+
+			lock, ok, err := l.TryLock(ctx, name, time.Minute)
+			if err != nil || !ok {
+				t.Fatalf("expected to take a free lock, got %t: %v", ok, err)
+			}
+			if _, ok, err := l.TryLock(ctx, name, time.Minute); err != nil || ok {
+				t.Fatalf("expected a held lock not to be taken again, got %t: %v", ok, err)
+			}
+			if err := lock.Refresh(ctx); err != nil {
+				t.Fatalf("expected the holder to refresh its lock: %v", err)
+			}
+
+			if err := lock.Unlock(ctx); err != nil {
+				t.Fatal(err)
+			}
+			next, ok, err := l.TryLock(ctx, name, time.Minute)
+			if err != nil || !ok {
+				t.Fatalf("expected to take a released lock, got %t: %v", ok, err)
+			}
+			defer next.Unlock(ctx)
+		})
+	}
+
+	t.Run("expired redis lock", func(t *testing.T) {
+		l := &locker.RedisLocker{Redis: helpers.Redis}
+		name := uniqueName("job")
+
+		lock, ok, err := l.TryLock(ctx, name, 50*time.Millisecond)
+		if err != nil || !ok {
+			t.Fatalf("expected to take a free lock, got %t: %v", ok, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		other, ok, err := l.TryLock(ctx, name, time.Minute)
+		if err != nil || !ok {
+			t.Fatalf("expected to take an expired lock, got %t: %v", ok, err)
+		}
+		defer other.Unlock(ctx)
+
+		if err := lock.Refresh(ctx); !errors.Is(err, locker.ErrLockLost) {
+			t.Fatalf("expected the expired lock to be lost, got %v", err)
+		}
+		// the expired holder must not release the new holder's lock
+		if err := lock.Unlock(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok, _ := l.TryLock(ctx, name, time.Minute); ok {
+			t.Fatal("expected the new holder to keep the lock")
+		}
+	})
+}
@@ -0,0 +1,73 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+type staticGeoIP map[string]external.GeoLocation
+
+func (g staticGeoIP) Lookup(ctx context.Context, ip string) (external.GeoLocation, error) {
+	return g[ip], nil
+}
+
+func TestLoginGeoIP(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	loginHistoryRepo := &repository.LoginHistoryRepository{DB: helpers.DB}
+	passwordHasher := helpers.NewPasswordHasher(2)
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      &repository.SessionRepository{DB: helpers.DB},
+		LoginHistoryRepo: loginHistoryRepo,
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+		GeoIP: staticGeoIP{
+			"203.0.113.1":  {Country: "ID", City: "Jakarta"},
+			"198.51.100.1": {Country: "SG", City: "Singapore"},
+		},
+	}
+
+	password := "somewhere-else"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal("failed to hash password: ", err)
+	}
+	user := models.User{Username: uniqueName("geoip"), Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, &user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+
+	login := models.LoginRequest{Identifier: user.Username, Password: password, IPAddress: "203.0.113.1"}
+	if _, err := loginSvc.Login(ctx, login); err != nil {
+		t.Fatal("failed to log in: ", err)
+	}
+	histories, err := loginHistoryRepo.GetLoginHistoriesByUserID(ctx, user.ID, nil, 10)
+	if err != nil || len(histories) != 1 || histories[0].Country != "ID" || histories[0].City != "Jakarta" {
+		t.Fatalf("got histories %+v, err %v, want one login from Jakarta, ID", histories, err)
+	}
+
+	// a proxy header naming another country wins, the city no longer applies
+	login.Country = "MY"
+	if _, err := loginSvc.Login(ctx, login); err != nil {
+		t.Fatal("failed to log in: ", err)
+	}
+	histories, err = loginHistoryRepo.GetLoginHistoriesByUserID(ctx, user.ID, nil, 10)
+	if err != nil || len(histories) != 2 || histories[0].Country != "MY" || histories[0].City != "" {
+		t.Fatalf("got histories %+v, err %v, want the latest login from MY", histories, err)
+	}
+
+	for country, want := range map[string]bool{"ID": true, "MY": true, "SG": false, "": true} {
+		got, err := loginHistoryRepo.HasSuccessfulLoginFromCountry(ctx, user.ID, country)
+		if err != nil || got != want {
+			t.Errorf("got %v, err %v for country %q, want %v", got, err, country, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+type channelAlerter chan external.Alert
+
+func (a channelAlerter) Alert(ctx context.Context, alert external.Alert) error {
+	a <- alert
+	return nil
+}
+
+func TestLoginVelocityAlerts(t *testing.T) {
+	alerts := make(channelAlerter, 10)
+	svc := &services.LoginVelocityService{
+		VelocityRepo: &repository.LoginVelocityRepository{Redis: helpers.Redis},
+		Alerter:      alerts,
+		Window:       time.Minute,
+		Thresholds:   map[string]int{constants.LoginSourceIP: 3},
+	}
+
+	// unique per run, redis outlives a single test run
+	ip := uniqueName("ip")
+	sources := models.LoginRequest{IPAddress: ip, Country: "ID"}.LoginSources()
+	for range 5 {
+		svc.RecordFailure(ctx, sources)
+	}
+
+	select {
+	case alert := <-alerts:
+		if alert.Fields["value"] != ip || alert.Fields["failures"] != "3" {
+			t.Errorf("unexpected alert %+v", alert)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no alert sent")
+	}
+	select {
+	case alert := <-alerts:
+		t.Errorf("got a second alert %+v, want one per window", alert)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	hot, err := svc.GetHotSources(ctx)
+	if err != nil {
+		t.Fatal("failed to get hot sources: ", err)
+	}
+	for _, source := range hot {
+		if source.Kind == constants.LoginSourceCountry && source.Value == "ID" {
+			t.Error("sources without a threshold should not be tracked")
+		}
+		if source.Kind == constants.LoginSourceIP && source.Value == ip {
+			if source.Failures != 5 || !source.Breached {
+				t.Errorf("unexpected hot source %+v", source)
+			}
+			return
+		}
+	}
+	t.Errorf("source %s missing from hot sources %+v", ip, hot)
+}
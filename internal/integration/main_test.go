@@ -0,0 +1,53 @@
+//go:build integration
+
+// Package integration runs the repositories and services against real MySQL
+// and Redis containers. Run with:
+//
+//	go test -tags integration ./internal/integration
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"ewallet-ums/external/walletfake"
+	"ewallet-ums/internal/testenv"
+)
+
+var (
+	ctx    = context.Background()
+	wallet *walletfake.Server
+)
+
+func TestMain(m *testing.M) {
+	env, err := testenv.Start(ctx, nil)
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+	wallet = env.Wallet
+
+	code := m.Run()
+	env.Stop()
+	os.Exit(code)
+}
+
+// uniqueName returns a username that fits the varchar(20) column and does
+// not collide between tests sharing the same database.
+func uniqueName(prefix string) string {
+	return fmt.Sprintf("%s%d", prefix, time.Now().UnixNano()%1e12)
+}
+
+// uniqueEmail and uniquePhone don't collide between tests either, the
+// email and phone number lookups are unique.
+func uniqueEmail(prefix string) string {
+	return uniqueName(prefix) + "@example.com"
+}
+
+func uniquePhone() string {
+	return fmt.Sprintf("+628%d", time.Now().UnixNano()%1e10)
+}
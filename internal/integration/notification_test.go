@@ -0,0 +1,268 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+type recordingMailer struct {
+	sent []external.Mail
+}
+
+func (m *recordingMailer) Send(ctx context.Context, mail external.Mail) error {
+	m.sent = append(m.sent, mail)
+	return nil
+}
+
+func TestSecurityNotifications(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	mailer := &recordingMailer{}
+	svc := &services.NotificationService{
+		NotificationRepo: &repository.NotificationRepository{DB: helpers.DB},
+		UserRepo:         userRepo,
+		Mailer:           mailer,
+	}
+	user := newUser(t, userRepo)
+
+	preferences, err := svc.GetPreferences(ctx, user.ID)
+	if err != nil || len(preferences) != 6 || !preferences[0].Enabled {
+		t.Fatalf("got preferences %+v, err %v, want every event on by default", preferences, err)
+	}
+
+	event := models.SecurityEvent{Event: constants.NotificationEventNewDeviceLogin, IPAddress: "203.0.113.7", UserAgent: "test-agent"}
+	if err := svc.SendSecurityEmail(ctx, user.ID, event); err != nil {
+		t.Fatal("failed to send notification: ", err)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != user.Email || !strings.Contains(mailer.sent[0].Body, "203.0.113.7") {
+		t.Fatalf("got mail %+v", mailer.sent)
+	}
+	// users without a locale get the default, English here
+	if !strings.Contains(mailer.sent[0].HTMLBody, "203.0.113.7") || !strings.HasPrefix(mailer.sent[0].Template, "new_device_login/en/") {
+		t.Errorf("got html %q from template %q", mailer.sent[0].HTMLBody, mailer.sent[0].Template)
+	}
+
+	disabled := false
+	_, err = svc.UpdatePreferences(ctx, user.ID, models.UpdateNotificationPreferencesRequest{
+		Preferences: []models.NotificationPreferenceItem{{Event: constants.NotificationEventNewDeviceLogin, Enabled: &disabled}},
+	})
+	if err != nil {
+		t.Fatal("failed to update preferences: ", err)
+	}
+	if err := svc.SendSecurityEmail(ctx, user.ID, event); err != nil || len(mailer.sent) != 1 {
+		t.Fatalf("got err %v, %d mails, want no mail for a disabled event", err, len(mailer.sent))
+	}
+
+	// email changes are reported to the old address
+	err = svc.SendSecurityEmail(ctx, user.ID, models.SecurityEvent{Event: constants.NotificationEventEmailChanged, PreviousEmail: "old@example.com"})
+	if err != nil || len(mailer.sent) != 2 || mailer.sent[1].To != "old@example.com" {
+		t.Errorf("got err %v, mail %+v, want a notice to the old address", err, mailer.sent)
+	}
+
+	// password and email changes can't be turned off
+	if err := svc.Unsubscribe(ctx, helpers.SignUnsubscribeToken(user.ID, constants.NotificationEventPasswordChanged)); !errors.Is(err, services.ErrInvalidUnsubscribeToken) {
+		t.Fatalf("got err %v unsubscribing from password_changed, want ErrInvalidUnsubscribeToken", err)
+	}
+	err = svc.SendSecurityEmail(ctx, user.ID, models.SecurityEvent{Event: constants.NotificationEventPasswordChanged})
+	if err != nil || len(mailer.sent) != 3 || mailer.sent[2].UnsubscribeURL != "" {
+		t.Errorf("got err %v, mails %+v, want a password change notice without an unsubscribe link", err, mailer.sent)
+	}
+}
+
+func TestBrandedNotifications(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	clientRepo := &repository.ClientRepository{DB: helpers.DB}
+	mailer := &recordingMailer{}
+	svc := &services.NotificationService{
+		NotificationRepo: &repository.NotificationRepository{DB: helpers.DB},
+		UserRepo:         userRepo,
+		Mailer:           mailer,
+		Branding: &services.EmailBranding{
+			ClientRepo: clientRepo,
+			Default:    models.Brand{Name: "E-Wallet", SupportURL: "https://help.example.com"},
+		},
+	}
+
+	client := &models.Client{ClientID: uniqueName("brand"), BrandName: "Acme Pay", MailFrom: "no-reply@acmepay.example"}
+	if err := clientRepo.UpsertClient(ctx, client); err != nil {
+		t.Fatal("failed to register client: ", err)
+	}
+	branded := models.User{Username: uniqueName("brand"), Email: uniqueEmail("brand"), Password: "hashed", ClientID: client.ClientID}
+	if err := userRepo.InsertNewUser(ctx, &branded); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+	unbranded := newUser(t, userRepo)
+
+	event := models.SecurityEvent{Event: constants.NotificationEventPasswordChanged}
+	for _, userID := range []int{branded.ID, unbranded.ID} {
+		if err := svc.SendSecurityEmail(ctx, userID, event); err != nil {
+			t.Fatal("failed to send notification: ", err)
+		}
+	}
+	if len(mailer.sent) != 2 {
+		t.Fatalf("got %d mails, want 2", len(mailer.sent))
+	}
+
+	// the client's brand, with the default support link it doesn't override
+	acme := mailer.sent[0]
+	if acme.From != "no-reply@acmepay.example" || !strings.Contains(acme.Body, "Acme Pay") || !strings.Contains(acme.HTMLBody, "https://help.example.com") {
+		t.Errorf("got mail %+v, want the client's brand", acme)
+	}
+	fallback := mailer.sent[1]
+	if fallback.From != "" || !strings.Contains(fallback.Body, "E-Wallet") {
+		t.Errorf("got mail %+v, want the default brand", fallback)
+	}
+}
+
+func TestActivityDigest(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	mailer := &recordingMailer{}
+	notifications := &services.NotificationService{
+		NotificationRepo: &repository.NotificationRepository{DB: helpers.DB},
+		UserRepo:         userRepo,
+		Mailer:           mailer,
+		BaseURL:          "https://ums.example.com",
+	}
+	svc := &services.ActivityDigestService{
+		DigestRepo:    &repository.ActivityDigestRepository{DB: helpers.DB},
+		Notifications: notifications,
+		BatchSize:     10,
+	}
+	user := newUser(t, userRepo)
+
+	now := time.Date(2031, time.March, 5, 0, 0, 0, 0, time.UTC)
+	for _, history := range []models.LoginHistory{
+		{UserID: user.ID, Success: true, UserAgent: "phone", IPAddress: "203.0.113.7", CreatedAt: now.AddDate(0, -1, 1)},
+		{UserID: user.ID, Success: true, UserAgent: "phone", IPAddress: "203.0.113.7", CreatedAt: now.AddDate(0, -1, 2)},
+		{UserID: user.ID, Success: false, UserAgent: "laptop", CreatedAt: now.AddDate(0, -1, 3)},
+		// this month, reported next time
+		{UserID: user.ID, Success: true, UserAgent: "tablet", CreatedAt: now},
+	} {
+		if err := helpers.DB.Create(&history).Error; err != nil {
+			t.Fatal("failed to insert login history: ", err)
+		}
+	}
+
+	if sent, err := svc.SendDigests(ctx, now); err != nil || sent != 0 {
+		t.Fatalf("got %d sent, err %v, want none before opting in", sent, err)
+	}
+
+	enabled := true
+	_, err := notifications.UpdatePreferences(ctx, user.ID, models.UpdateNotificationPreferencesRequest{
+		Preferences: []models.NotificationPreferenceItem{{Event: constants.NotificationEventActivityDigest, Enabled: &enabled}},
+	})
+	if err != nil {
+		t.Fatal("failed to update preferences: ", err)
+	}
+
+	if sent, err := svc.SendDigests(ctx, now); err != nil || sent != 1 {
+		t.Fatalf("got %d sent, err %v, want the digest", sent, err)
+	}
+	mail := mailer.sent[len(mailer.sent)-1]
+	if mail.To != user.Email || !strings.Contains(mail.Body, "2031-02") || strings.Contains(mail.Body, "tablet") || mail.UnsubscribeURL == "" {
+		t.Fatalf("got mail %+v", mail)
+	}
+
+	if sent, err := svc.SendDigests(ctx, now); err != nil || sent != 0 {
+		t.Fatalf("got %d sent, err %v, want the digest sent only once", sent, err)
+	}
+
+	token := strings.TrimPrefix(mail.UnsubscribeURL, "https://ums.example.com/user/v1/notification-preferences/unsubscribe?token=")
+	if err := notifications.Unsubscribe(ctx, token); err != nil {
+		t.Fatal("failed to unsubscribe: ", err)
+	}
+	if err := notifications.Unsubscribe(ctx, token+"x"); !errors.Is(err, services.ErrInvalidUnsubscribeToken) {
+		t.Errorf("got err %v for a tampered token", err)
+	}
+	if sent, err := svc.SendDigests(ctx, now.AddDate(0, 1, 0)); err != nil || sent != 0 {
+		t.Errorf("got %d sent, err %v, want none after unsubscribing", sent, err)
+	}
+}
+
+func TestNotificationThrottleAndQuietHours(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	notificationRepo := &repository.NotificationRepository{DB: helpers.DB}
+	mailer := &recordingMailer{}
+	svc := &services.NotificationService{
+		NotificationRepo: notificationRepo,
+		UserRepo:         userRepo,
+		Mailer:           mailer,
+		Throttle:         &repository.NotificationThrottleRepository{Redis: helpers.Redis},
+		ThrottleWindow:   time.Minute,
+	}
+	summaries := &services.NotificationSummaryService{
+		NotificationRepo: notificationRepo,
+		Notifications:    svc,
+		BatchSize:        10,
+	}
+	user := newUser(t, userRepo)
+
+	// an attack: the first email goes out, the repeats are held back
+	event := models.SecurityEvent{Event: constants.NotificationEventNewDeviceLogin, IPAddress: "203.0.113.7"}
+	for range 4 {
+		if err := svc.SendSecurityEmail(ctx, user.ID, event); err != nil {
+			t.Fatal("failed to send notification: ", err)
+		}
+	}
+	if len(mailer.sent) != 1 {
+		t.Fatalf("got %d mails, want the repeats held back", len(mailer.sent))
+	}
+	if sent, err := summaries.SendSummaries(ctx, time.Now()); err != nil || sent != 0 {
+		t.Fatalf("got %d sent, err %v, want nothing due yet", sent, err)
+	}
+	if sent, err := summaries.SendSummaries(ctx, time.Now().Add(2*time.Minute)); err != nil || sent != 1 {
+		t.Fatalf("got %d sent, err %v, want the summary", sent, err)
+	}
+	summary := mailer.sent[len(mailer.sent)-1]
+	if len(mailer.sent) != 2 || !strings.HasPrefix(summary.Template, "security_summary/") || !strings.Contains(summary.Body, "3 times") {
+		t.Fatalf("got mails %+v, want one summary of 3 logins", mailer.sent)
+	}
+
+	// quiet hours around now, whatever the time of day
+	now := time.Now().UTC()
+	quietHours, err := svc.UpdateQuietHours(ctx, user.ID, models.UpdateQuietHoursRequest{
+		Start:    now.Add(-time.Hour).Format("15:04"),
+		End:      now.Add(time.Hour).Format("15:04"),
+		TimeZone: "UTC",
+	})
+	if err != nil || quietHours == nil || quietHours.TimeZone != "UTC" {
+		t.Fatalf("got quiet hours %+v, err %v", quietHours, err)
+	}
+
+	if err := svc.SendSecurityEmail(ctx, user.ID, models.SecurityEvent{Event: constants.NotificationEventPasswordChanged}); err != nil {
+		t.Fatal("failed to send notification: ", err)
+	}
+	// email changes go to the old address straight away
+	err = svc.SendSecurityEmail(ctx, user.ID, models.SecurityEvent{Event: constants.NotificationEventEmailChanged, PreviousEmail: "old@example.com"})
+	if err != nil || len(mailer.sent) != 3 || mailer.sent[2].To != "old@example.com" {
+		t.Fatalf("got err %v, mails %+v, want only the email change notice sent", err, mailer.sent)
+	}
+	if sent, err := summaries.SendSummaries(ctx, time.Now().Add(30*time.Minute)); err != nil || sent != 0 {
+		t.Fatalf("got %d sent, err %v, want nothing during quiet hours", sent, err)
+	}
+
+	if err := svc.DeleteQuietHours(ctx, user.ID); err != nil {
+		t.Fatal("failed to delete quiet hours: ", err)
+	}
+	if quietHours, err := svc.GetQuietHours(ctx, user.ID); err != nil || quietHours != nil {
+		t.Fatalf("got quiet hours %+v, err %v, want none", quietHours, err)
+	}
+	// a single held email is sent as itself
+	if sent, err := summaries.SendSummaries(ctx, time.Now().Add(2*time.Hour)); err != nil || sent != 1 {
+		t.Fatalf("got %d sent, err %v, want the held email", sent, err)
+	}
+	if len(mailer.sent) != 4 || !strings.HasPrefix(mailer.sent[3].Template, "password_changed/") {
+		t.Errorf("got mails %+v, want the password change notice", mailer.sent)
+	}
+}
@@ -0,0 +1,257 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+// recordingSMS keeps the texts instead of sending them.
+type recordingSMS struct {
+	sent []external.SMS
+}
+
+func (r *recordingSMS) SendSMS(ctx context.Context, sms external.SMS) error {
+	r.sent = append(r.sent, sms)
+	return nil
+}
+
+var otpCode = regexp.MustCompile(`\d{6}`)
+
+func (r *recordingSMS) lastCode(t *testing.T) string {
+	t.Helper()
+	if len(r.sent) == 0 {
+		t.Fatal("no code was texted")
+	}
+	return otpCode.FindString(r.sent[len(r.sent)-1].Text)
+}
+
+func TestPasswordlessAccount(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	refreshTokenRepo := &repository.RefreshTokenRepository{DB: helpers.DB}
+	passwordHasher := helpers.NewPasswordHasher(2)
+	sms := &recordingSMS{}
+	otpSvc := &services.OTPService{
+		OTPRepo:  &repository.OTPRepository{Redis: helpers.Redis},
+		SMS:      sms,
+		UserRepo: userRepo,
+		Register: &services.RegisterService{
+			WalletProvisioningRepo: &repository.WalletProvisioningRepository{DB: helpers.DB},
+			PasswordHasher:         passwordHasher,
+			EventPublisher:         &external.EventPublisher{Redis: helpers.Redis},
+		},
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		TTL:              time.Minute,
+		MaxAttempts:      3,
+	}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: refreshTokenRepo,
+		PasswordHasher:   passwordHasher,
+		OTP:              otpSvc,
+	}
+	profileSvc := &services.ProfileService{
+		UserRepo:       userRepo,
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		PasswordHasher: passwordHasher,
+	}
+
+	phoneNumber := fmt.Sprintf("+6281%09d", time.Now().UnixNano()%1_000_000_000)
+	username := uniqueName("phone")
+
+	if err := otpSvc.RequestOTP(ctx, models.OTPRequest{PhoneNumber: phoneNumber, Purpose: constants.OTPPurposeRegister}); err != nil {
+		t.Fatal(err)
+	}
+	code := sms.lastCode(t)
+
+	// a wrong code is refused and counts as an attempt
+	_, err := otpSvc.RegisterWithPhone(ctx, models.PhoneRegisterRequest{Username: username, PhoneNumber: phoneNumber, OTP: "000000"})
+	if code != "000000" && !apperr.Is(err, apperr.Unauthorized) {
+		t.Fatalf("expected a wrong code to be refused, got %v", err)
+	}
+
+	registered, err := otpSvc.RegisterWithPhone(ctx, models.PhoneRegisterRequest{Username: username, PhoneNumber: phoneNumber, OTP: code})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registered.Token == "" {
+		t.Error("expected registration to log the user in")
+	}
+	if _, err := otpSvc.RegisterWithPhone(ctx, models.PhoneRegisterRequest{Username: uniqueName("phone"), PhoneNumber: phoneNumber, OTP: code}); !apperr.Is(err, apperr.Conflict) {
+		t.Errorf("expected the number to be taken, got %v", err)
+	}
+
+	// codes are single use
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: phoneNumber, OTP: code}); err == nil {
+		t.Fatal("expected the spent registration code to be refused at login")
+	}
+
+	// no resend interval is set here
+	if err := otpSvc.RequestOTP(ctx, models.OTPRequest{PhoneNumber: phoneNumber, Purpose: constants.OTPPurposeLogin}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: phoneNumber, OTP: sms.lastCode(t)}); err != nil {
+		t.Fatalf("expected to log in with the code, got %v", err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: username, Password: ""}); err == nil {
+		t.Fatal("expected a passwordless user to have no password to log in with")
+	}
+
+	password := "s3cret-password"
+	if err := profileSvc.SetPassword(ctx, registered.UserID, models.SetPasswordRequest{NewPassword: password}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: username, Password: password}); err != nil {
+		t.Errorf("expected to log in with the new password, got %v", err)
+	}
+	if err := profileSvc.SetPassword(ctx, registered.UserID, models.SetPasswordRequest{NewPassword: "another-password"}); err == nil {
+		t.Error("expected changing a password to take the current one")
+	}
+
+	// with a password set, codes are neither sent nor accepted for login
+	sent := len(sms.sent)
+	if err := otpSvc.RequestOTP(ctx, models.OTPRequest{PhoneNumber: phoneNumber, Purpose: constants.OTPPurposeLogin}); err != nil {
+		t.Fatal(err)
+	}
+	if len(sms.sent) != sent {
+		t.Error("expected no login code for a user with a password")
+	}
+	phoneLookup := helpers.PIILookup(phoneNumber)
+	if err := otpSvc.OTPRepo.SaveOTP(ctx, constants.OTPPurposeLogin, phoneLookup, helpers.PIILookup(phoneLookup+":123456"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: phoneNumber, OTP: "123456"}); !apperr.Is(err, apperr.Unauthorized) {
+		t.Errorf("expected a user with a password not to log in with a code alone, got %v", err)
+	}
+}
+
+func TestTrustedDevice(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	refreshTokenRepo := &repository.RefreshTokenRepository{DB: helpers.DB}
+	trustedDeviceRepo := &repository.TrustedDeviceRepository{DB: helpers.DB}
+	sms := &recordingSMS{}
+	otpSvc := &services.OTPService{
+		OTPRepo:     &repository.OTPRepository{Redis: helpers.Redis},
+		SMS:         sms,
+		UserRepo:    userRepo,
+		TTL:         time.Minute,
+		MaxAttempts: 3,
+	}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: refreshTokenRepo,
+		PasswordHasher:   helpers.NewPasswordHasher(2),
+		OTP:              otpSvc,
+		TrustedDevices:   trustedDeviceRepo,
+		TrustedDeviceTTL: time.Hour,
+	}
+	userAdminSvc := &services.UserAdminService{
+		AdminRepo:      &repository.AdminRepository{DB: helpers.DB},
+		UserRepo:       userRepo,
+		SessionRepo:    sessionRepo,
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:     repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+		TrustedDevices: trustedDeviceRepo,
+	}
+	tokenRevocationSvc := &services.TokenRevocationService{
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		AuditRepo:        &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:       userAdminSvc.TokenCache,
+		TrustedDevices:   trustedDeviceRepo,
+	}
+	profileSvc := &services.ProfileService{
+		UserRepo:       userRepo,
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		PasswordHasher: loginSvc.PasswordHasher,
+		TrustedDevices: trustedDeviceRepo,
+	}
+
+	user := newUser(t, userRepo)
+	other := newUser(t, userRepo)
+	// only passwordless users log in with codes
+	for _, userID := range []int{user.ID, other.ID} {
+		if err := userRepo.UpdateUserPassword(ctx, userID, "", false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := otpSvc.RequestOTP(ctx, models.OTPRequest{PhoneNumber: user.PhoneNumber, Purpose: constants.OTPPurposeLogin}); err != nil {
+		t.Fatal(err)
+	}
+	login, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: user.Username, OTP: sms.lastCode(t), RememberDevice: true})
+	if err != nil || login.DeviceToken == "" || !login.DeviceTokenExpiresAt.After(time.Now()) {
+		t.Fatalf("got login %+v, err %v, want a trusted device token", login, err)
+	}
+
+	// the device stands in for a code, only for the user it was trusted for
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: user.Username, DeviceToken: login.DeviceToken}); err != nil {
+		t.Fatalf("expected the trusted device to skip the code, got %v", err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: other.Username, DeviceToken: login.DeviceToken}); !apperr.Is(err, apperr.Unauthorized) {
+		t.Errorf("expected another user's device to be refused, got %v", err)
+	}
+
+	// every way of taking the account back forgets its devices
+	trustDevice := func() string {
+		t.Helper()
+		if err := otpSvc.RequestOTP(ctx, models.OTPRequest{PhoneNumber: user.PhoneNumber, Purpose: constants.OTPPurposeLogin}); err != nil {
+			t.Fatal(err)
+		}
+		login, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: user.Username, OTP: sms.lastCode(t), RememberDevice: true})
+		if err != nil || login.DeviceToken == "" {
+			t.Fatalf("got login %+v, err %v, want a trusted device token", login, err)
+		}
+		return login.DeviceToken
+	}
+	for name, takeBack := range map[string]func() error{
+		"forced logout": func() error {
+			_, err := userAdminSvc.ForceLogout(ctx, models.UserActor(1), user.ID, "reported compromised")
+			return err
+		},
+		"token revocation": func() error {
+			_, err := tokenRevocationSvc.RevokeUserTokens(ctx, models.UserActor(1), user.ID, "reported compromised")
+			return err
+		},
+	} {
+		deviceToken := trustDevice()
+		if err := takeBack(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: user.Username, DeviceToken: deviceToken}); !apperr.Is(err, apperr.Unauthorized) {
+			t.Errorf("expected a %s to forget the device, got %v", name, err)
+		}
+	}
+
+	// nor does a device stand in for a password once one is set
+	deviceToken := trustDevice()
+	if err := profileSvc.SetPassword(ctx, user.ID, models.SetPasswordRequest{NewPassword: "s3cret-password"}); err != nil {
+		t.Fatal(err)
+	}
+	var devices int64
+	helpers.DB.Model(&models.TrustedDevice{}).Where("user_id = ?", user.ID).Count(&devices)
+	if devices != 0 {
+		t.Errorf("got %d trusted devices after setting a password, want 0", devices)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Identifier: user.Username, DeviceToken: deviceToken}); !apperr.Is(err, apperr.Unauthorized) {
+		t.Errorf("expected a device not to stand in for a password, got %v", err)
+	}
+}
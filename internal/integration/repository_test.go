@@ -0,0 +1,161 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+	"ewallet-ums/internal/repository"
+)
+
+func newUser(t *testing.T, repo *repository.UserRepository) models.User {
+	t.Helper()
+
+	user := models.User{
+		Username:    uniqueName("repo"),
+		Email:       uniqueEmail("repo"),
+		PhoneNumber: uniquePhone(),
+		FullName:    "Repository Test",
+		Password:    "hashed",
+	}
+	if err := repo.InsertNewUser(ctx, &user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+	return user
+}
+
+func TestUserRepository(t *testing.T) {
+	repo := &repository.UserRepository{DB: helpers.DB}
+	user := newUser(t, repo)
+
+	got, err := repo.GetUserByUsername(ctx, user.Username)
+	if err != nil {
+		t.Fatal("failed to get user by username: ", err)
+	}
+	if got.ID != user.ID {
+		t.Errorf("got user id %d, want %d", got.ID, user.ID)
+	}
+
+	applied, err := repo.UpdateUser(ctx, user.ID, user.Version, models.User{FullName: "Updated Name"})
+	if err != nil {
+		t.Fatal("failed to update user: ", err)
+	}
+	if !applied {
+		t.Fatal("expected update at the current version to apply")
+	}
+
+	// a second writer still holding the old version loses
+	applied, err = repo.UpdateUser(ctx, user.ID, user.Version, models.User{FullName: "Stale Name"})
+	if err != nil {
+		t.Fatal("failed to update user: ", err)
+	}
+	if applied {
+		t.Error("expected update at a stale version not to apply")
+	}
+
+	got, err = repo.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal("failed to get user by id: ", err)
+	}
+	if got.FullName != "Updated Name" || got.Email != user.Email {
+		t.Errorf("unexpected user after update: %+v", got)
+	}
+
+	if _, err := repo.GetUserByUsername(ctx, uniqueName("missing")); err == nil {
+		t.Error("expected error for unknown username")
+	}
+}
+
+func TestUserSessionRepository(t *testing.T) {
+	repo := &repository.SessionRepository{DB: helpers.DB}
+	user := newUser(t, &repository.UserRepository{DB: helpers.DB})
+	now := time.Now()
+
+	active := &models.UserSession{
+		UserID:              user.ID,
+		Token:               uniqueName("token"),
+		RefreshToken:        uniqueName("refresh"),
+		TokenExpired:        now.Add(time.Hour),
+		RefreshTokenExpired: now.Add(24 * time.Hour),
+	}
+	expired := &models.UserSession{
+		UserID:              user.ID,
+		Token:               uniqueName("token"),
+		RefreshToken:        uniqueName("refresh"),
+		TokenExpired:        now.Add(-2 * time.Hour),
+		RefreshTokenExpired: now.Add(-time.Hour),
+	}
+	for _, session := range []*models.UserSession{active, expired} {
+		if err := repo.InsertNewUserSession(ctx, session); err != nil {
+			t.Fatal("failed to insert session: ", err)
+		}
+	}
+
+	if _, err := repo.GetUserSessionByToken(ctx, active.Token); err != nil {
+		t.Error("failed to get session by token: ", err)
+	}
+
+	if err := repo.UpdateTokenByRefreshToken(ctx, "rotated"+active.Token, active.RefreshToken); err != nil {
+		t.Fatal("failed to rotate token: ", err)
+	}
+	rotated, err := repo.GetUserSessionByRefreshToken(ctx, active.RefreshToken)
+	if err != nil {
+		t.Fatal("failed to get session by refresh token: ", err)
+	}
+	if rotated.Token != "rotated"+active.Token {
+		t.Errorf("got token %q after rotation", rotated.Token)
+	}
+
+	page, err := repo.GetUserSessionsByUserID(ctx, user.ID, nil, 1)
+	if err != nil {
+		t.Fatal("failed to list sessions: ", err)
+	}
+	// one extra row signals there is a next page
+	if len(page) != 2 {
+		t.Errorf("got %d sessions, want 2", len(page))
+	}
+	cursor := &pagination.Cursor{CreatedAt: page[0].CreatedAt, ID: page[0].ID}
+	next, err := repo.GetUserSessionsByUserID(ctx, user.ID, cursor, 1)
+	if err != nil {
+		t.Fatal("failed to list next sessions page: ", err)
+	}
+	if len(next) != 1 || next[0].ID == page[0].ID {
+		t.Errorf("unexpected next page: %+v", next)
+	}
+
+	if err := repo.DeleteUserSession(ctx, rotated.Token); err != nil {
+		t.Fatal("failed to delete session: ", err)
+	}
+	if _, err := repo.GetUserSessionByToken(ctx, rotated.Token); err == nil {
+		t.Error("session should have been deleted")
+	}
+}
+
+func TestUserIdentifierLookups(t *testing.T) {
+	repo := &repository.UserRepository{DB: helpers.DB}
+	user := newUser(t, repo)
+	other := newUser(t, repo)
+
+	taken := models.User{Username: uniqueName("repo"), Email: user.Email, Password: "hashed"}
+	if err := repo.InsertNewUser(ctx, &taken); err == nil {
+		t.Error("expected a second user with the same email to be refused")
+	}
+	// users without a phone number don't collide
+	for range 2 {
+		if err := repo.InsertNewUser(ctx, &models.User{Username: uniqueName("repo"), Email: uniqueEmail("repo"), Password: "hashed"}); err != nil {
+			t.Fatal("failed to insert user without a phone number: ", err)
+		}
+	}
+
+	if got, err := repo.GetUserByIdentifier(ctx, user.Username, other.Email, ""); err != nil || got.ID != user.ID {
+		t.Errorf("got user %d, err %v, want the username match %d", got.ID, err, user.ID)
+	}
+	if _, err := repo.GetUserByIdentifier(ctx, uniqueName("missing"), user.Email, other.PhoneNumber); !errors.Is(err, models.ErrAmbiguousIdentifier) {
+		t.Errorf("got err %v, want ErrAmbiguousIdentifier", err)
+	}
+}
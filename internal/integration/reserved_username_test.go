@@ -0,0 +1,73 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestReservedUsernames(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	svc := &services.ReservedUsernameService{
+		ReservedUsernameRepo: &repository.ReservedUsernameRepository{DB: helpers.DB},
+		UserRepo:             userRepo,
+		AuditRepo:            &repository.AuditRepository{DB: helpers.DB},
+		UserClaims: &services.UserClaimsService{
+			SessionRepo:    &repository.SessionRepository{DB: helpers.DB},
+			TokenCache:     repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+			EventPublisher: &external.EventPublisher{Redis: helpers.Redis},
+		},
+	}
+	registerSvc := &services.RegisterService{
+		WalletProvisioningRepo: &repository.WalletProvisioningRepository{DB: helpers.DB},
+		PasswordHasher:         helpers.NewPasswordHasher(2),
+		EventPublisher:         &external.EventPublisher{Redis: helpers.Redis},
+		ReservedUsernames:      svc,
+	}
+	actor := models.UserActor(1)
+
+	username := uniqueName("Brand")
+	reservation, err := svc.ReserveUsername(ctx, actor, models.ReservedUsernameRequest{Username: username, Category: "brand"})
+	if err != nil || reservation.Username != strings.ToLower(username) {
+		t.Fatalf("got reservation %+v, err %v, want it lowercased", reservation, err)
+	}
+	if _, err := svc.ReserveUsername(ctx, actor, models.ReservedUsernameRequest{Username: strings.ToLower(username), Category: "brand"}); !errors.Is(err, services.ErrUsernameAlreadyReserved) {
+		t.Fatalf("got err %v reserving twice, want ErrUsernameAlreadyReserved", err)
+	}
+
+	_, err = registerSvc.Register(ctx, &models.User{Username: strings.ToUpper(username), PhoneNumber: uniquePhone(), Password: "s3cret-password"})
+	if !errors.Is(err, services.ErrUsernameReserved) {
+		t.Fatalf("got err %v registering a reserved username, want ErrUsernameReserved", err)
+	}
+
+	owner := newUser(t, userRepo)
+	claimed, err := svc.ClaimReservedUsername(ctx, actor, username, models.ClaimReservedUsernameRequest{UserID: owner.ID, Reason: "trademark verified"})
+	if err != nil || claimed.ClaimedBy != owner.ID {
+		t.Fatalf("got reservation %+v, err %v, want it claimed by the owner", claimed, err)
+	}
+	got, err := userRepo.GetUserByID(ctx, owner.ID)
+	if err != nil || got.Username != reservation.Username || got.Version != owner.Version+1 {
+		t.Fatalf("got user %+v, err %v, want the reserved username", got, err)
+	}
+
+	other := newUser(t, userRepo)
+	if _, err := svc.ClaimReservedUsername(ctx, actor, username, models.ClaimReservedUsernameRequest{UserID: other.ID, Reason: "again"}); !errors.Is(err, services.ErrReservedUsernameClaimed) {
+		t.Fatalf("got err %v claiming twice, want ErrReservedUsernameClaimed", err)
+	}
+
+	if err := svc.DeleteReservedUsername(ctx, actor, username); err != nil {
+		t.Fatal("failed to delete reservation: ", err)
+	}
+	if err := svc.CheckUsername(ctx, uniqueName("free")); err != nil {
+		t.Errorf("got err %v for an unreserved username", err)
+	}
+}
@@ -0,0 +1,145 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestRetentionService(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	loginHistoryRepo := &repository.LoginHistoryRepository{DB: helpers.DB}
+	user := newUser(t, userRepo)
+	held := newUser(t, userRepo)
+	now := time.Now()
+
+	for _, userID := range []int{user.ID, held.ID} {
+		history := &models.LoginHistory{UserID: userID, Success: true, CreatedAt: now.AddDate(-1, 0, -1)}
+		if err := loginHistoryRepo.InsertLoginHistory(ctx, history); err != nil {
+			t.Fatal("failed to insert login history: ", err)
+		}
+	}
+
+	legalHoldRepo := &repository.LegalHoldRepository{DB: helpers.DB}
+	if err := legalHoldRepo.InsertLegalHold(ctx, &models.LegalHold{UserID: held.ID, Reason: "litigation"}); err != nil {
+		t.Fatal("failed to place legal hold: ", err)
+	}
+
+	svc := &services.RetentionService{
+		RetentionRepo: &repository.RetentionRepository{DB: helpers.DB},
+		AuditRepo:     &repository.AuditRepository{DB: helpers.DB},
+		Rules:         []models.RetentionRule{{Name: constants.RetentionRuleLoginHistory, Retention: 365 * 24 * time.Hour}},
+		BatchSize:     100,
+	}
+
+	results, err := svc.Enforce(ctx, now, true)
+	if err != nil {
+		t.Fatal("failed on dry run: ", err)
+	}
+	if len(results) != 1 || results[0].Rows < 1 {
+		t.Errorf("unexpected dry run results: %+v", results)
+	}
+
+	if _, err := svc.Enforce(ctx, now, false); err != nil {
+		t.Fatal("failed to enforce retention: ", err)
+	}
+
+	countHistories := func(userID int) int64 {
+		var count int64
+		helpers.DB.Model(&models.LoginHistory{}).Where("user_id = ?", userID).Count(&count)
+		return count
+	}
+	if got := countHistories(user.ID); got != 0 {
+		t.Errorf("got %d login histories past retention, want 0", got)
+	}
+	if got := countHistories(held.ID); got != 1 {
+		t.Errorf("got %d login histories for user under legal hold, want 1", got)
+	}
+
+	// a batch size that isn't positive falls back to the default
+	history := &models.LoginHistory{UserID: user.ID, Success: true, CreatedAt: now.AddDate(-1, 0, -1)}
+	if err := loginHistoryRepo.InsertLoginHistory(ctx, history); err != nil {
+		t.Fatal("failed to insert login history: ", err)
+	}
+	svc.BatchSize = 0
+	if _, err := svc.Enforce(ctx, now, false); err != nil {
+		t.Fatal("failed to enforce retention without a batch size: ", err)
+	}
+	if got := countHistories(user.ID); got != 0 {
+		t.Errorf("got %d login histories past retention without a batch size, want 0", got)
+	}
+
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("action = ? AND created_at >= ?", constants.AuditActionRetentionPurge, now.Add(-time.Second)).Count(&audits)
+	if audits < 2 {
+		t.Errorf("got %d retention audit events, want at least 2", audits)
+	}
+}
+
+func TestAnonymizationService(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	user := newUser(t, userRepo)
+	held := newUser(t, userRepo)
+	now := time.Now()
+
+	for _, userID := range []int{user.ID, held.ID} {
+		if err := helpers.DB.Delete(&models.User{}, userID).Error; err != nil {
+			t.Fatal("failed to soft delete user: ", err)
+		}
+		if err := helpers.DB.Exec("UPDATE users SET deleted_at = ? WHERE id = ?", now.AddDate(0, 0, -91), userID).Error; err != nil {
+			t.Fatal("failed to backdate deletion: ", err)
+		}
+	}
+
+	legalHoldRepo := &repository.LegalHoldRepository{DB: helpers.DB}
+	if err := legalHoldRepo.InsertLegalHold(ctx, &models.LegalHold{UserID: held.ID, Reason: "litigation"}); err != nil {
+		t.Fatal("failed to place legal hold: ", err)
+	}
+
+	svc := &services.AnonymizationService{
+		AnonymizationRepo: &repository.AnonymizationRepository{DB: helpers.DB},
+		AuditRepo:         &repository.AuditRepository{DB: helpers.DB},
+		EventPublisher:    &external.EventPublisher{Redis: helpers.Redis},
+		GracePeriod:       90 * 24 * time.Hour,
+		BatchSize:         100,
+	}
+
+	if _, err := svc.AnonymizeDeletedUsers(ctx, now); err != nil {
+		t.Fatal("failed to anonymize users: ", err)
+	}
+
+	anonymized := models.User{}
+	if err := helpers.DB.Unscoped().First(&anonymized, user.ID).Error; err != nil {
+		t.Fatal("failed to get anonymized user: ", err)
+	}
+	if anonymized.AnonymizedAt == nil || anonymized.Email == user.Email || anonymized.PhoneNumber != "" || anonymized.FullName == user.FullName {
+		t.Errorf("user not anonymized: %+v", anonymized)
+	}
+
+	kept := models.User{}
+	if err := helpers.DB.Unscoped().First(&kept, held.ID).Error; err != nil {
+		t.Fatal("failed to get held user: ", err)
+	}
+	if kept.AnonymizedAt != nil || kept.Email != held.Email {
+		t.Errorf("user under legal hold was anonymized: %+v", kept)
+	}
+
+	// a second run has nothing left to do, and finishes with a batch size
+	// that isn't positive too
+	svc.BatchSize = 0
+	count, err := svc.AnonymizeDeletedUsers(ctx, now)
+	if err != nil {
+		t.Fatal("failed to re-run anonymization: ", err)
+	}
+	if count != 0 {
+		t.Errorf("got %d users anonymized on re-run, want 0", count)
+	}
+}
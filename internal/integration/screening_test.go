@@ -0,0 +1,102 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+// fakeScreeningProvider matches the users listed, by reference.
+type fakeScreeningProvider map[string][]external.ScreeningMatch
+
+func (p fakeScreeningProvider) Screen(ctx context.Context, subject external.ScreeningSubject) ([]external.ScreeningMatch, error) {
+	return p[subject.Reference], nil
+}
+
+func TestScreening(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	provider := fakeScreeningProvider{}
+	alerts := make(channelAlerter, 10)
+	svc := &services.ScreeningService{
+		ScreeningRepo: &repository.ScreeningRepository{DB: helpers.DB},
+		AddressRepo:   &repository.AddressRepository{DB: helpers.DB},
+		AuditRepo:     &repository.AuditRepository{DB: helpers.DB},
+		Provider:      provider,
+		Alerter:       alerts,
+		Cadence:       24 * time.Hour,
+		BatchSize:     2,
+	}
+
+	hit, clear, unverified := newUser(t, userRepo), newUser(t, userRepo), newUser(t, userRepo)
+	helpers.DB.Model(&models.User{}).Where("id IN ?", []int{hit.ID, clear.ID}).Update("kyc_status", constants.KycStatusVerified)
+	match := external.ScreeningMatch{ID: "ofac-1", List: "sanctions", Name: "Repository Test", Score: 0.9}
+	provider[strconv.Itoa(hit.ID)] = []external.ScreeningMatch{match}
+	provider[strconv.Itoa(unverified.ID)] = []external.ScreeningMatch{match}
+
+	now := time.Now()
+	raised, err := svc.Screen(ctx, now)
+	if err != nil || raised != 1 {
+		t.Fatalf("got %d reviews, err %v, want the verified hit only", raised, err)
+	}
+	select {
+	case alert := <-alerts:
+		if alert.Fields["user_ids"] != strconv.Itoa(hit.ID) {
+			t.Errorf("got alert %+v", alert)
+		}
+	default:
+		t.Error("expected an alert for the hit")
+	}
+
+	// nobody is due again within the cadence
+	if raised, err := svc.Screen(ctx, now.Add(time.Hour)); err != nil || raised != 0 {
+		t.Errorf("got %d reviews, err %v, within the cadence", raised, err)
+	}
+
+	page, err := svc.GetScreeningReviews(ctx, models.ScreeningReviewListRequest{Status: constants.ScreeningReviewStatusPending, Request: pagination.Request{Limit: 100}})
+	if err != nil {
+		t.Fatal("failed to get screening reviews: ", err)
+	}
+	var review models.ScreeningReview
+	for _, item := range page.Items {
+		if item.UserID == hit.ID {
+			review = item
+		}
+	}
+	if review.ID == 0 || review.Lists != "sanctions" {
+		t.Fatalf("got reviews %+v, want the hit pending", page.Items)
+	}
+
+	actor := models.UserActor(1)
+	resolve := models.ScreeningReviewResolveRequest{Decision: constants.ScreeningReviewStatusCleared, Note: "different date of birth"}
+	if _, err := svc.ResolveScreeningReview(ctx, actor, review.ID, resolve); err != nil {
+		t.Fatal("failed to resolve screening review: ", err)
+	}
+	if _, err := svc.ResolveScreeningReview(ctx, actor, review.ID, resolve); !errors.Is(err, services.ErrScreeningReviewResolved) {
+		t.Errorf("got err %v resolving twice", err)
+	}
+
+	// the cleared hit isn't raised again past the cadence, new matches are
+	if raised, err := svc.Screen(ctx, now.Add(25*time.Hour)); err != nil || raised != 0 {
+		t.Errorf("got %d reviews, err %v, for the cleared matches", raised, err)
+	}
+	provider[strconv.Itoa(hit.ID)] = append(provider[strconv.Itoa(hit.ID)], external.ScreeningMatch{ID: "pep-7", List: "pep", Name: "R. Test", Score: 0.8})
+	if raised, err := svc.Screen(ctx, now.Add(50*time.Hour)); err != nil || raised != 1 {
+		t.Errorf("got %d reviews, err %v, for new matches", raised, err)
+	}
+
+	if _, err := svc.GetScreeningReviews(ctx, models.ScreeningReviewListRequest{Status: "open"}); !errors.Is(err, services.ErrInvalidScreeningReviewStatus) {
+		t.Errorf("got err %v for an unknown status", err)
+	}
+}
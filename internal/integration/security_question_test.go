@@ -0,0 +1,209 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestSecurityQuestionRecovery(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	passwordHasher := helpers.NewPasswordHasher(2)
+	sms := &recordingSMS{}
+	otpSvc := &services.OTPService{
+		OTPRepo:     &repository.OTPRepository{Redis: helpers.Redis},
+		SMS:         sms,
+		UserRepo:    userRepo,
+		TTL:         time.Minute,
+		MaxAttempts: 3,
+	}
+	svc := &services.SecurityQuestionService{
+		SecurityQuestionRepo: &repository.SecurityQuestionRepository{DB: helpers.DB},
+		UserRepo:             userRepo,
+		SessionRepo:          sessionRepo,
+		AuditRepo:            &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:           repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+		CallerGuard:          &repository.CallerGuardRepository{Redis: helpers.Redis},
+		PasswordHasher:       passwordHasher,
+		OTP:                  otpSvc,
+		FailureLimit:         3,
+		FailureWindow:        time.Minute,
+		LockDuration:         time.Minute,
+	}
+
+	password := "old-password"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal("failed to hash password: ", err)
+	}
+	phoneNumber := fmt.Sprintf("+62812%08d", time.Now().UnixNano()%1e8)
+	user := models.User{Username: uniqueName("recover"), PhoneNumber: phoneNumber, Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, &user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+
+	items := []models.SecurityQuestionItem{
+		{Question: "First pet?", Answer: "Rex"},
+		{Question: "Birth city?", Answer: "New  York"},
+	}
+	if _, err := svc.SetSecurityQuestions(ctx, user.ID, models.SetSecurityQuestionsRequest{Password: "wrong", Questions: items}); !errors.Is(err, services.ErrIncorrectPassword) {
+		t.Fatalf("got err %v setting questions with a wrong password, want ErrIncorrectPassword", err)
+	}
+	if _, err := svc.SetSecurityQuestions(ctx, user.ID, models.SetSecurityQuestionsRequest{Password: password, Questions: items}); err != nil {
+		t.Fatal("failed to set security questions: ", err)
+	}
+
+	questions, err := svc.GetRecoveryQuestions(ctx, user.Username)
+	if err != nil || len(questions) != 2 || questions[1].Question != "Birth city?" {
+		t.Fatalf("got questions %+v, err %v", questions, err)
+	}
+
+	// unknown accounts get the same decoys on every call
+	unknown := uniqueName("nobody")
+	decoys, err := svc.GetRecoveryQuestions(ctx, unknown)
+	if err != nil || len(decoys) < 2 || decoys[0].ID != 1 {
+		t.Fatalf("got decoys %+v, err %v", decoys, err)
+	}
+	if again, _ := svc.GetRecoveryQuestions(ctx, unknown); !slices.Equal(again, decoys) {
+		t.Errorf("got decoys %+v, then %+v", decoys, again)
+	}
+
+	recoverAccount := func(first, second, code string) error {
+		return svc.RecoverWithSecurityQuestions(ctx, models.SecurityQuestionRecoveryRequest{
+			Identifier: user.Username,
+			Answers: []models.SecurityAnswerItem{
+				{QuestionID: questions[0].ID, Answer: first},
+				{QuestionID: questions[1].ID, Answer: second},
+			},
+			OTP:         code,
+			NewPassword: "new-password",
+		})
+	}
+
+	if err := otpSvc.RequestOTP(ctx, models.OTPRequest{PhoneNumber: phoneNumber, Purpose: constants.OTPPurposeRecovery}); err != nil {
+		t.Fatal("failed to request recovery code: ", err)
+	}
+	code := sms.lastCode(t)
+
+	if err := recoverAccount("rex", "boston", code); !errors.Is(err, services.ErrRecoveryFailed) {
+		t.Fatalf("got err %v for a wrong answer, want ErrRecoveryFailed", err)
+	}
+	if err := recoverAccount("rex", "new york", "000000"); !errors.Is(err, services.ErrRecoveryFailed) {
+		t.Fatalf("got err %v for a wrong code, want ErrRecoveryFailed", err)
+	}
+	// answers match regardless of case and spacing
+	if err := recoverAccount(" REX ", "new york", code); err != nil {
+		t.Fatal("failed to recover: ", err)
+	}
+
+	got, err := userRepo.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal("failed to get user: ", err)
+	}
+	if err := passwordHasher.ComparePassword(ctx, got.Password, "new-password"); err != nil {
+		t.Error("password should be reset after recovery")
+	}
+
+	// the third failure within the window locks recovery, even for the
+	// right answers
+	recoverAccount("rex", "boston", code)
+	if err := recoverAccount("rex", "new york", code); !errors.Is(err, services.ErrRecoveryLocked) {
+		t.Errorf("got err %v after repeated failures, want ErrRecoveryLocked", err)
+	}
+}
+
+func TestSecurityQuestionsWithoutPasswordOrPhone(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	passwordHasher := helpers.NewPasswordHasher(2)
+	sms := &recordingSMS{}
+	otpSvc := &services.OTPService{
+		OTPRepo:     &repository.OTPRepository{Redis: helpers.Redis},
+		SMS:         sms,
+		UserRepo:    userRepo,
+		TTL:         time.Minute,
+		MaxAttempts: 3,
+	}
+	svc := &services.SecurityQuestionService{
+		SecurityQuestionRepo: &repository.SecurityQuestionRepository{DB: helpers.DB},
+		UserRepo:             userRepo,
+		SessionRepo:          &repository.SessionRepository{DB: helpers.DB},
+		AuditRepo:            &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:           repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute),
+		CallerGuard:          &repository.CallerGuardRepository{Redis: helpers.Redis},
+		PasswordHasher:       passwordHasher,
+		OTP:                  otpSvc,
+		FailureLimit:         3,
+		NoPhoneFailureLimit:  1,
+		FailureWindow:        time.Minute,
+		LockDuration:         time.Minute,
+	}
+	items := []models.SecurityQuestionItem{
+		{Question: "First pet?", Answer: "Rex"},
+		{Question: "Birth city?", Answer: "Jakarta"},
+	}
+
+	// passwordless users confirm with a login code
+	phoneNumber := uniquePhone()
+	passwordless := models.User{Username: uniqueName("nopassword"), PhoneNumber: phoneNumber}
+	if err := userRepo.InsertNewUser(ctx, &passwordless); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+	if _, err := svc.SetSecurityQuestions(ctx, passwordless.ID, models.SetSecurityQuestionsRequest{Questions: items}); !errors.Is(err, services.ErrIncorrectCode) {
+		t.Fatalf("got err %v setting questions without a code, want ErrIncorrectCode", err)
+	}
+	if err := otpSvc.RequestOTP(ctx, models.OTPRequest{PhoneNumber: phoneNumber, Purpose: constants.OTPPurposeLogin}); err != nil {
+		t.Fatal("failed to request login code: ", err)
+	}
+	if _, err := svc.SetSecurityQuestions(ctx, passwordless.ID, models.SetSecurityQuestionsRequest{OTP: sms.lastCode(t), Questions: items}); err != nil {
+		t.Fatal("failed to set security questions with a code: ", err)
+	}
+
+	// users without a phone number recover with their answers alone
+	hashed, err := passwordHasher.HashPassword(ctx, "old-password")
+	if err != nil {
+		t.Fatal("failed to hash password: ", err)
+	}
+	phoneless := models.User{Username: uniqueName("nophone"), Email: uniqueEmail("nophone"), Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, &phoneless); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+	if _, err := svc.SetSecurityQuestions(ctx, phoneless.ID, models.SetSecurityQuestionsRequest{Password: "old-password", Questions: items}); err != nil {
+		t.Fatal("failed to set security questions: ", err)
+	}
+	recoverAccount := func(second string) error {
+		return svc.RecoverWithSecurityQuestions(ctx, models.SecurityQuestionRecoveryRequest{
+			Identifier: phoneless.Username,
+			Answers: []models.SecurityAnswerItem{
+				{QuestionID: 1, Answer: "rex"},
+				{QuestionID: 2, Answer: second},
+			},
+			NewPassword: "new-password",
+		})
+	}
+	if err := recoverAccount("jakarta"); err != nil {
+		t.Fatal("failed to recover without a phone number: ", err)
+	}
+	got, err := userRepo.GetUserByID(ctx, phoneless.ID)
+	if err != nil || passwordHasher.ComparePassword(ctx, got.Password, "new-password") != nil {
+		t.Fatalf("got user %+v, err %v, want the password reset", got, err)
+	}
+
+	// with one factor fewer, a single failure locks recovery
+	if err := recoverAccount("bandung"); !errors.Is(err, services.ErrRecoveryFailed) {
+		t.Fatalf("got err %v for a wrong answer, want ErrRecoveryFailed", err)
+	}
+	if err := recoverAccount("jakarta"); !errors.Is(err, services.ErrRecoveryLocked) {
+		t.Errorf("got err %v after a failure, want ErrRecoveryLocked", err)
+	}
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestServiceAccountLifecycle(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+	svc := &services.ServiceAccountService{
+		ServiceAccountRepo: &repository.ServiceAccountRepository{DB: helpers.DB},
+		UserRepo:           userRepo,
+		SessionRepo:        sessionRepo,
+		AuditRepo:          &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:         tokenCache,
+		PasswordHasher:     passwordHasher,
+		TokenTTL:           time.Minute,
+	}
+	tokenValidationSvc := &services.TokenValidationService{UserRepo: userRepo, SessionRepo: sessionRepo, TokenCache: tokenCache}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+
+	admin := newUser(t, userRepo)
+
+	creds, err := svc.CreateServiceAccount(ctx, admin.ID, models.ServiceAccountRequest{Name: "Nightly settlement", Scopes: "wallet:read reports:write"})
+	if err != nil {
+		t.Fatal("failed to create service account: ", err)
+	}
+	if creds.ClientID == "" || creds.ClientSecret == "" || creds.UserID == 0 {
+		t.Fatalf("unexpected credentials %+v", creds)
+	}
+
+	if _, err := svc.IssueToken(ctx, creds.ClientID, "wrong", ""); !errors.Is(err, services.ErrInvalidClientCredentials) {
+		t.Errorf("got %v for a wrong secret, want invalid client credentials", err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Username: creds.ClientID, Password: creds.ClientSecret}); err == nil {
+		t.Error("service accounts should not be able to log in with a password")
+	}
+
+	token, err := svc.IssueToken(ctx, creds.ClientID, creds.ClientSecret, "wallet:read admin")
+	if err != nil {
+		t.Fatal("failed to issue token: ", err)
+	}
+	if token.Scope != "wallet:read" {
+		t.Errorf("got scope %q, want wallet:read", token.Scope)
+	}
+	claim, err := tokenValidationSvc.TokenValidation(ctx, token.AccessToken)
+	if err != nil {
+		t.Fatal("failed to validate service account token: ", err)
+	}
+	if claim.UserID != creds.UserID || claim.ClientID != creds.ClientID || !claim.Service {
+		t.Errorf("unexpected claims %+v", claim)
+	}
+
+	rotated, err := svc.RotateSecret(ctx, creds.ID, admin.ID)
+	if err != nil {
+		t.Fatal("failed to rotate secret: ", err)
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, token.AccessToken); err == nil {
+		t.Error("tokens issued before rotation should be revoked")
+	}
+	if _, err := svc.IssueToken(ctx, creds.ClientID, creds.ClientSecret, ""); err == nil {
+		t.Error("old secret should stop working after rotation")
+	}
+	if _, err := svc.IssueToken(ctx, creds.ClientID, rotated.ClientSecret, ""); err != nil {
+		t.Fatal("failed to issue token with rotated secret: ", err)
+	}
+
+	if err := svc.DeleteServiceAccount(ctx, creds.ID, admin.ID); err != nil {
+		t.Fatal("failed to delete service account: ", err)
+	}
+	if _, err := svc.IssueToken(ctx, creds.ClientID, rotated.ClientSecret, ""); !errors.Is(err, services.ErrInvalidClientCredentials) {
+		t.Errorf("got %v after delete, want invalid client credentials", err)
+	}
+}
@@ -0,0 +1,81 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestSessionCleanup(t *testing.T) {
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	refreshTokenRepo := &repository.RefreshTokenRepository{DB: helpers.DB}
+	user := newUser(t, &repository.UserRepository{DB: helpers.DB})
+	held := newUser(t, &repository.UserRepository{DB: helpers.DB})
+	now := time.Now()
+
+	newSession := func(userID int, refreshExpired time.Time) *models.UserSession {
+		session := &models.UserSession{
+			UserID:              userID,
+			Token:               uniqueName("token"),
+			RefreshToken:        uniqueName("refresh"),
+			TokenExpired:        refreshExpired.Add(-time.Hour),
+			RefreshTokenExpired: refreshExpired,
+		}
+		if err := sessionRepo.InsertNewUserSession(ctx, session); err != nil {
+			t.Fatal("failed to insert session: ", err)
+		}
+		return session
+	}
+	expired := newSession(user.ID, now.Add(-time.Hour))
+	stale := newSession(user.ID, now.AddDate(0, 0, -31))
+	heldStale := newSession(held.ID, now.AddDate(0, 0, -31))
+
+	token := &models.RefreshToken{FamilyID: uniqueName("family"), UserID: user.ID, TokenHash: uniqueName("hash"), TokenID: uniqueName("jti"), ExpiresAt: now.Add(-time.Hour)}
+	if err := refreshTokenRepo.StartRefreshTokenFamily(ctx, expired.ID, token); err != nil {
+		t.Fatal("failed to insert refresh token: ", err)
+	}
+
+	legalHoldRepo := &repository.LegalHoldRepository{DB: helpers.DB}
+	if err := legalHoldRepo.InsertLegalHold(ctx, &models.LegalHold{UserID: held.ID, Reason: "litigation"}); err != nil {
+		t.Fatal("failed to place legal hold: ", err)
+	}
+
+	// a batch size that isn't positive falls back to the default
+	cleanup := &services.SessionCleanupService{SessionRepo: sessionRepo, RefreshTokenRepo: refreshTokenRepo}
+	if _, err := cleanup.CleanupExpiredSessions(ctx); err != nil {
+		t.Fatal("failed to clean up sessions without a batch size: ", err)
+	}
+	if got, err := refreshTokenRepo.GetRefreshTokenByHash(ctx, token.TokenHash); err != nil || got.ID != 0 {
+		t.Errorf("got refresh token %+v, err %v, want the expired one deleted", got, err)
+	}
+	// sessions are left to retention, which audits the purge
+	for _, session := range []*models.UserSession{expired, stale} {
+		if _, err := sessionRepo.GetUserSessionByToken(ctx, session.Token); err != nil {
+			t.Error("expired session should have been kept for retention: ", err)
+		}
+	}
+
+	retention := &services.RetentionService{
+		RetentionRepo: &repository.RetentionRepository{DB: helpers.DB},
+		AuditRepo:     &repository.AuditRepository{DB: helpers.DB},
+		Rules:         []models.RetentionRule{{Name: constants.RetentionRuleUserSession, Retention: 30 * 24 * time.Hour}},
+	}
+	if _, err := retention.Enforce(ctx, now, false); err != nil {
+		t.Fatal("failed to enforce retention: ", err)
+	}
+	if _, err := sessionRepo.GetUserSessionByToken(ctx, stale.Token); err == nil {
+		t.Error("session past retention should have been purged")
+	}
+	for _, session := range []*models.UserSession{expired, heldStale} {
+		if _, err := sessionRepo.GetUserSessionByToken(ctx, session.Token); err != nil {
+			t.Error("session within retention or under legal hold should have been kept: ", err)
+		}
+	}
+}
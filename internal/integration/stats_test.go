@@ -0,0 +1,80 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestStats(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	svc := &services.StatsService{
+		StatsRepo:   &repository.StatsRepository{DB: helpers.DB},
+		SessionRepo: &repository.SessionRepository{DB: helpers.DB},
+		Days:        7,
+	}
+
+	user := newUser(t, userRepo)
+	err := helpers.DB.Create(&models.LoginHistory{UserID: user.ID, Username: user.Username, Success: false}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.Refresh(ctx, time.Now(), 7); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := svc.GetStats(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats.Registrations) != 7 || len(stats.FailedLogins) != 7 {
+		t.Fatalf("got %d and %d days, want 7", len(stats.Registrations), len(stats.FailedLogins))
+	}
+	today := stats.Registrations[6]
+	if today.Day != time.Now().Format("2006-01-02") || today.Count < 1 {
+		t.Errorf("got %+v for today, want the new user counted", today)
+	}
+	if stats.FailedLogins[6].Count < 1 {
+		t.Errorf("got %+v for today, want the failed login counted", stats.FailedLogins[6])
+	}
+	if stats.RefreshedAt == nil {
+		t.Error("expected the refresh time")
+	}
+
+	// refreshing again updates the rows rather than adding more
+	if err := svc.Refresh(ctx, time.Now(), 7); err != nil {
+		t.Fatal(err)
+	}
+	var rows int64
+	helpers.DB.Model(&models.DailyStat{}).Where("day = ?", today.Day).Count(&rows)
+	if rows != 2 {
+		t.Errorf("got %d rows for today, want one per metric", rows)
+	}
+}
+
+func TestActiveUsers(t *testing.T) {
+	repo := &repository.ActiveUsersRepository{Redis: helpers.Redis}
+	// a day of its own, other tests record real logins
+	now := time.Date(2001, 2, 3, 12, 30, 0, 0, time.UTC)
+
+	for _, record := range []struct {
+		userID int
+		at     time.Time
+	}{{1, now}, {1, now.Add(-time.Hour)}, {2, now.Add(-23 * time.Hour)}, {3, now.Add(-24 * time.Hour)}} {
+		if err := repo.RecordActiveUser(ctx, record.userID, record.at); err != nil {
+			t.Fatal("failed to record active user: ", err)
+		}
+	}
+
+	active, err := repo.CountActiveUsers(ctx, now, 24)
+	if err != nil || active != 2 {
+		t.Errorf("got %d active users, err %v, want users 1 and 2", active, err)
+	}
+}
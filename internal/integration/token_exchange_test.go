@@ -0,0 +1,87 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestDelegatedTokenFollowsSubjectSession(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	refreshTokenRepo := &repository.RefreshTokenRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	tokenValidationSvc := &services.TokenValidationService{
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		TokenCache:  tokenCache,
+	}
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: refreshTokenRepo,
+		PasswordHasher:   passwordHasher,
+	}
+	exchangeSvc := &services.TokenExchangeService{
+		TokenValidationService: tokenValidationSvc,
+		AuditRepo:              &repository.AuditRepository{DB: helpers.DB},
+		Policy:                 map[string][]string{"gateway": {"wallet"}},
+		TTL:                    5 * time.Minute,
+	}
+	logoutSvc := &services.LogoutService{
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		TokenCache:       tokenCache,
+	}
+
+	password := "s3cret-password"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	username := uniqueName("delegate")
+	user := &models.User{Username: username, Email: username + "@example.com", FullName: "Delegate Test", Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+
+	login, err := loginSvc.Login(ctx, models.LoginRequest{Username: username, Password: password})
+	if err != nil {
+		t.Fatal("failed to login: ", err)
+	}
+	exchanged, err := exchangeSvc.Exchange(ctx, "gateway", models.TokenExchangeRequest{
+		SubjectToken:     login.Token,
+		SubjectTokenType: constants.TokenTypeURIAccess,
+		Audience:         "wallet",
+	})
+	if err != nil {
+		t.Fatal("failed to exchange token: ", err)
+	}
+
+	if _, err := tokenValidationSvc.TokenValidationForAudience(ctx, exchanged.AccessToken, "wallet"); err != nil {
+		t.Fatal("failed to validate delegated token: ", err)
+	}
+	if _, err := tokenValidationSvc.TokenValidationForAudience(ctx, exchanged.AccessToken, "ledger"); err == nil {
+		t.Error("expected a delegated token to be rejected for another audience")
+	}
+	if _, err := tokenValidationSvc.TokenValidationForAudience(ctx, exchanged.AccessToken, ""); err == nil {
+		t.Error("expected a delegated token to be rejected without an audience")
+	}
+
+	if err := logoutSvc.Logout(ctx, login.Token); err != nil {
+		t.Fatal("failed to logout: ", err)
+	}
+	if _, err := tokenValidationSvc.TokenValidationForAudience(ctx, exchanged.AccessToken, "wallet"); err == nil {
+		t.Error("expected the delegated token to end with the subject's session")
+	}
+}
@@ -0,0 +1,218 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestTokenRevocation(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	refreshTokenRepo := &repository.RefreshTokenRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: refreshTokenRepo,
+		PasswordHasher:   passwordHasher,
+	}
+	revocationSvc := &services.TokenRevocationService{
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		AuditRepo:        &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:       tokenCache,
+	}
+	tokenValidationSvc := &services.TokenValidationService{UserRepo: userRepo, SessionRepo: sessionRepo, TokenCache: tokenCache}
+
+	password := "s3cret-password"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{Username: uniqueName("revoke"), Email: uniqueEmail("revoke"), PhoneNumber: uniquePhone(), Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+
+	logins := []models.LoginResponse{}
+	for range 3 {
+		login, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password})
+		if err != nil {
+			t.Fatal("failed to login: ", err)
+		}
+		logins = append(logins, login)
+	}
+
+	// by access token jti
+	revoked, err := revocationSvc.RevokeTokenID(ctx, constants.AuditActorSystem, helpers.TokenID(ctx, logins[0].Token), "leaked in logs")
+	if err != nil || revoked != 1 {
+		t.Fatalf("got %d sessions revoked, err %v, want 1", revoked, err)
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, logins[0].Token); err == nil {
+		t.Error("revoked token should be invalid")
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, logins[1].Token); err != nil {
+		t.Error("other sessions should stay valid: ", err)
+	}
+
+	// by refresh token jti
+	revoked, err = revocationSvc.RevokeTokenID(ctx, constants.AuditActorSystem, helpers.TokenID(ctx, logins[1].RefreshToken), "leaked in logs")
+	if err != nil || revoked != 1 {
+		t.Fatalf("got %d sessions revoked, err %v, want 1", revoked, err)
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, logins[1].Token); err == nil {
+		t.Error("session of the revoked refresh token should be invalid")
+	}
+
+	if _, err := revocationSvc.RevokeTokenID(ctx, constants.AuditActorSystem, "unknown", "typo"); !errors.Is(err, services.ErrTokenNotFound) {
+		t.Errorf("got err %v for an unknown token", err)
+	}
+
+	// a jti without a session only goes on the list with a ttl
+	delegatedID := uniqueName("jti")
+	if _, err := revocationSvc.RevokeTokens(ctx, constants.AuditActorSystem, models.RevokeTokensRequest{TokenID: delegatedID, Reason: "typo"}); !errors.Is(err, services.ErrTokenNotFound) {
+		t.Errorf("got err %v for an unknown token without ttl", err)
+	}
+	if _, err := revocationSvc.RevokeTokens(ctx, constants.AuditActorSystem, models.RevokeTokensRequest{TokenID: delegatedID, Reason: "delegated token leaked", TTLSeconds: 60}); err != nil {
+		t.Fatal("failed to revoke token id: ", err)
+	}
+	if revoked, err := tokenCache.IsTokenRevoked(ctx, "some-token", delegatedID); err != nil || !revoked {
+		t.Errorf("got revoked %v, err %v for a listed jti", revoked, err)
+	}
+
+	listed := map[string]models.RevokedToken{}
+	for req := (models.RevokedTokenListRequest{Limit: 100}); ; {
+		page, err := revocationSvc.GetRevokedTokens(ctx, req)
+		if err != nil {
+			t.Fatal("failed to get revoked tokens: ", err)
+		}
+		for _, token := range page.Items {
+			listed[token.Key] = token
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		req.Cursor, _ = strconv.ParseUint(page.NextCursor, 10, 64)
+	}
+	if entry := listed[helpers.HashToken(logins[0].Token)]; entry.TokenID != helpers.TokenID(ctx, logins[0].Token) || entry.UserID != user.ID || entry.ExpiresAt.IsZero() {
+		t.Errorf("unexpected entry %+v for the revoked access token", entry)
+	}
+	if entry, ok := listed["jti:"+delegatedID]; !ok || entry.TokenID != delegatedID {
+		t.Errorf("got entry %+v, listed %v for the revoked jti", entry, ok)
+	}
+
+	if err := revocationSvc.DeleteRevokedToken(ctx, constants.AuditActorSystem, "jti:"+delegatedID); err != nil {
+		t.Fatal("failed to delete revoked token: ", err)
+	}
+	if revoked, _ := tokenCache.IsTokenRevoked(ctx, "some-token", delegatedID); revoked {
+		t.Error("jti should be off the list after deleting it")
+	}
+	if err := revocationSvc.DeleteRevokedToken(ctx, constants.AuditActorSystem, "jti:"+delegatedID); !errors.Is(err, services.ErrRevokedTokenNotFound) {
+		t.Errorf("got err %v deleting a missing entry", err)
+	}
+
+	// by user
+	revoked, err = revocationSvc.RevokeUserTokens(ctx, constants.AuditActorSystem, user.ID, "stolen laptop")
+	if err != nil || revoked != 1 {
+		t.Fatalf("got %d sessions revoked, err %v, want 1", revoked, err)
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, logins[2].Token); err == nil {
+		t.Error("token should be invalid after revoking the user")
+	}
+
+	var live int64
+	helpers.DB.Model(&models.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", user.ID).Count(&live)
+	if live != 0 {
+		t.Errorf("got %d unrevoked refresh tokens, want 0", live)
+	}
+
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("action = ? AND target_user_id = ?", constants.AuditActionTokenRevoked, user.ID).Count(&audits)
+	if audits != 3 {
+		t.Errorf("got %d audit events, want 3", audits)
+	}
+}
+
+func TestStatelessTokenValidation(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+	// no UserRepo or SessionRepo, stateless validation must not read them
+	tokenValidationSvc := &services.TokenValidationService{
+		TokenCache: tokenCache,
+		Stateless:  helpers.NewReloadable(func() bool { return true }),
+	}
+
+	password := "s3cret-password"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{Username: uniqueName("stateless"), Email: uniqueEmail("stateless"), FullName: "Stateless Test", Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+	login, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password})
+	if err != nil {
+		t.Fatal("failed to login: ", err)
+	}
+
+	claim, err := tokenValidationSvc.TokenValidation(ctx, login.Token)
+	if err != nil {
+		t.Fatal("failed to validate token: ", err)
+	}
+	if claim.Username != user.Username || claim.Email != user.Email || claim.FullName != user.FullName || claim.Guest {
+		t.Errorf("got claim %+v, want the profile claims signed into the token", claim)
+	}
+
+	if err := tokenCache.RevokeToken(ctx, login.Token, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal("failed to revoke token: ", err)
+	}
+	if _, err := tokenValidationSvc.TokenValidation(ctx, login.Token); err == nil {
+		t.Error("expected a revoked token to be rejected")
+	}
+}
+
+func TestTokenCacheExpiresWithToken(t *testing.T) {
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+
+	token := uniqueName("expiring")
+	claim := &helpers.ClaimToken{UserID: 1}
+	claim.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Second))
+	if err := tokenCache.SetTokenClaim(ctx, token, claim); err != nil {
+		t.Fatal("failed to cache claim: ", err)
+	}
+	if _, err := tokenCache.GetTokenClaim(ctx, token); err != nil {
+		t.Fatal("failed to get cached claim: ", err)
+	}
+
+	// the local cache TTL is a minute, the token's second is what counts
+	time.Sleep(1100 * time.Millisecond)
+	if got, err := tokenCache.GetTokenClaim(ctx, token); err == nil {
+		t.Errorf("got claim %+v after the token expired, want a cache miss", got)
+	}
+}
@@ -0,0 +1,241 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestForceLogout(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+	userAdminSvc := &services.UserAdminService{
+		AdminRepo:   &repository.AdminRepository{DB: helpers.DB},
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		AuditRepo:   &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:  tokenCache,
+	}
+	tokenValidationSvc := &services.TokenValidationService{UserRepo: userRepo, SessionRepo: sessionRepo, TokenCache: tokenCache}
+
+	password := "s3cret-password"
+	hashed, err := passwordHasher.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{Username: uniqueName("logout"), Email: uniqueEmail("logout"), PhoneNumber: uniquePhone(), Password: hashed}
+	if err := userRepo.InsertNewUser(ctx, user); err != nil {
+		t.Fatal("failed to insert user: ", err)
+	}
+
+	tokens := []string{}
+	for range 2 {
+		login, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password})
+		if err != nil {
+			t.Fatal("failed to login: ", err)
+		}
+		tokens = append(tokens, login.Token)
+	}
+
+	revoked, err := userAdminSvc.ForceLogout(ctx, models.UserActor(1), user.ID, "reported compromised")
+	if err != nil || revoked != 2 {
+		t.Fatalf("got %d sessions revoked, err %v, want 2", revoked, err)
+	}
+	for _, token := range tokens {
+		if _, err := tokenValidationSvc.TokenValidation(ctx, token); err == nil {
+			t.Error("token should be invalid after a forced logout")
+		}
+	}
+
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("action = ? AND target_user_id = ?", constants.AuditActionUserForceLogout, user.ID).Count(&audits)
+	if audits != 1 {
+		t.Errorf("got %d audit events, want 1", audits)
+	}
+
+	if _, err := userAdminSvc.ForceLogout(ctx, models.UserActor(1), 1<<30, "typo"); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("got err %v for an unknown user", err)
+	}
+}
+
+func TestCreateAdmin(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	adminRepo := &repository.AdminRepository{DB: helpers.DB}
+	passwordHasher := helpers.NewPasswordHasher(2)
+
+	loginSvc := &services.LoginService{
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: &repository.LoginHistoryRepository{DB: helpers.DB},
+		RefreshTokenRepo: &repository.RefreshTokenRepository{DB: helpers.DB},
+		PasswordHasher:   passwordHasher,
+	}
+	userAdminSvc := &services.UserAdminService{
+		AdminRepo:      adminRepo,
+		UserRepo:       userRepo,
+		SessionRepo:    sessionRepo,
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		PasswordHasher: passwordHasher,
+	}
+
+	req := models.CreateAdminRequest{Username: uniqueName("admin"), Email: uniqueEmail("admin")}
+	user, password, err := userAdminSvc.CreateAdmin(ctx, constants.AuditActorSystem, req)
+	if err != nil {
+		t.Fatal("failed to create admin: ", err)
+	}
+
+	roles, err := adminRepo.GetUserRoles(ctx, user.ID)
+	if err != nil || len(roles) != 1 || roles[0] != constants.RoleAdmin {
+		t.Fatalf("got roles %v, err %v", roles, err)
+	}
+
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password}); !errors.Is(err, services.ErrPasswordChangeRequired) {
+		t.Fatalf("got err %v, want the password change to be required", err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password, NewPassword: "my-own-password"}); err != nil {
+		t.Fatal("failed to login with a new password: ", err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: "my-own-password"}); err != nil {
+		t.Fatal("failed to login after the password change: ", err)
+	}
+	if _, err := loginSvc.Login(ctx, models.LoginRequest{Username: user.Username, Password: password}); err == nil {
+		t.Error("the generated password should no longer work")
+	}
+
+	if _, _, err := userAdminSvc.CreateAdmin(ctx, constants.AuditActorSystem, req); !errors.Is(err, services.ErrUsernameTaken) {
+		t.Errorf("got err %v for a taken username", err)
+	}
+}
+
+func TestUserAdminRequestsApproval(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	adminRepo := &repository.AdminRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	approvalSvc := &services.AdminApprovalService{
+		AdminRepo:   adminRepo,
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		AuditRepo:   &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:  tokenCache,
+	}
+	userAdminSvc := &services.UserAdminService{
+		AdminRepo:   adminRepo,
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		AuditRepo:   &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:  tokenCache,
+		Approvals:   approvalSvc,
+	}
+
+	serviceAccount := newUser(t, userRepo)
+	checker := newUser(t, userRepo)
+	target := newUser(t, userRepo)
+
+	// a suspension from the back office waits for a second admin
+	suspension, err := userAdminSvc.RequestSuspension(ctx, "cert:backoffice", target.ID, target.Version, "chargeback fraud")
+	if err != nil {
+		t.Fatal("failed to request suspension: ", err)
+	}
+	if latest, _ := userRepo.GetUserByID(ctx, target.ID); latest.Status == constants.UserStatusBanned {
+		t.Fatal("user banned before the suspension was approved")
+	}
+	if _, err := approvalSvc.Approve(ctx, suspension.ID, checker.ID, "confirmed"); err != nil {
+		t.Fatal("failed to approve suspension: ", err)
+	}
+	if latest, _ := userRepo.GetUserByID(ctx, target.ID); latest.Status != constants.UserStatusBanned {
+		t.Errorf("got status %q, want the approved suspension applied", latest.Status)
+	}
+	var audits int64
+	helpers.DB.Model(&models.AuditEvent{}).Where("action = ? AND actor = ? AND target_user_id = ?", constants.AuditActionApprovalRequested, "cert:backoffice", target.ID).Count(&audits)
+	if audits != 1 {
+		t.Errorf("got %d approval requests audited for the certificate, want 1", audits)
+	}
+
+	// a service account can't approve the role it requested itself
+	grant, err := userAdminSvc.RequestRole(ctx, models.UserActor(serviceAccount.ID), target.ID, constants.RoleAdmin, "new hire")
+	if err != nil {
+		t.Fatal("failed to request role: ", err)
+	}
+	if roles, _ := adminRepo.GetUserRoles(ctx, target.ID); len(roles) > 0 {
+		t.Fatalf("got roles %v before the grant was approved", roles)
+	}
+	if _, err := approvalSvc.Approve(ctx, grant.ID, serviceAccount.ID, ""); !errors.Is(err, services.ErrSelfApproval) {
+		t.Fatalf("got error %v approving own request, want ErrSelfApproval", err)
+	}
+	if _, err := approvalSvc.Approve(ctx, grant.ID, checker.ID, "ok"); err != nil {
+		t.Fatal("failed to approve role: ", err)
+	}
+	if roles, _ := adminRepo.GetUserRoles(ctx, target.ID); len(roles) != 1 || roles[0] != constants.RoleAdmin {
+		t.Errorf("got roles %v, want [admin]", roles)
+	}
+
+	if _, err := userAdminSvc.RequestRole(ctx, "cert:backoffice", target.ID, "root", "typo"); !errors.Is(err, services.ErrUnknownRole) {
+		t.Errorf("got error %v, want ErrUnknownRole", err)
+	}
+	if _, err := userAdminSvc.RequestSuspension(ctx, "cert:backoffice", checker.ID, checker.Version+1, "stale"); !errors.Is(err, services.ErrUserVersionConflict) {
+		t.Errorf("got error %v, want ErrUserVersionConflict", err)
+	}
+}
+
+func TestGetUsers(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	adminRepo := &repository.AdminRepository{DB: helpers.DB}
+	userAdminSvc := &services.UserAdminService{AdminRepo: adminRepo, UserRepo: userRepo}
+
+	users := []models.User{}
+	for range 3 {
+		users = append(users, newUser(t, userRepo))
+	}
+	if _, err := adminRepo.UpdateUserStatus(ctx, users[1].ID, 0, constants.UserStatusBanned); err != nil {
+		t.Fatal(err)
+	}
+
+	// the newest users come first, one page at a time
+	req := models.AdminUserListRequest{Request: pagination.Request{Limit: 2}}
+	page, err := userAdminSvc.GetUsers(ctx, req)
+	if err != nil || len(page.Items) != 2 || page.NextCursor == "" {
+		t.Fatalf("got page %+v, err %v, want 2 users and a next cursor", page, err)
+	}
+	if page.Items[0].ID != users[2].ID || page.Items[1].ID != users[1].ID || page.Items[1].Status != constants.UserStatusBanned {
+		t.Fatalf("got users %+v, want the newest first", page.Items)
+	}
+	req.Cursor = page.NextCursor
+	next, err := userAdminSvc.GetUsers(ctx, req)
+	if err != nil || len(next.Items) == 0 || next.Items[0].ID != users[0].ID {
+		t.Fatalf("got next page %+v, err %v, want it to continue after the cursor", next, err)
+	}
+
+	banned, err := userAdminSvc.GetUsers(ctx, models.AdminUserListRequest{AdminUserFilter: models.AdminUserFilter{Status: constants.UserStatusBanned}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, user := range banned.Items {
+		if user.Status != constants.UserStatusBanned {
+			t.Errorf("got user %+v filtering banned users", user)
+		}
+	}
+	if len(banned.Items) == 0 || banned.Items[0].ID != users[1].ID {
+		t.Errorf("got users %+v, want the banned user first", banned.Items)
+	}
+}
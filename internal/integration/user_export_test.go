@@ -0,0 +1,110 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+// memoryObjectStorage keeps uploaded objects in memory.
+type memoryObjectStorage map[string][]byte
+
+func (m memoryObjectStorage) PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	data, err := io.ReadAll(body)
+	m[key] = data
+	return err
+}
+
+func (m memoryObjectStorage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := m[key]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m memoryObjectStorage) PresignGetURL(key string, ttl time.Duration) (string, error) {
+	return "https://storage.example.com/" + key, nil
+}
+
+func TestUserExportColumnsFollowPermissions(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	adminRepo := &repository.AdminRepository{DB: helpers.DB}
+	storage := memoryObjectStorage{}
+	svc := &services.UserExportService{
+		UserExportRepo: &repository.UserExportRepository{DB: helpers.DB},
+		AdminRepo:      adminRepo,
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		ObjectStorage:  storage,
+		BatchSize:      1,
+		Timeout:        time.Hour,
+		URLTTL:         time.Minute,
+	}
+
+	from := time.Now().Add(-time.Second)
+	verified := newUser(t, userRepo)
+	newUser(t, userRepo)
+	if err := helpers.DB.Model(&models.User{}).Where("id = ?", verified.ID).Update("kyc_status", constants.KycStatusVerified).Error; err != nil {
+		t.Fatal("failed to set kyc status: ", err)
+	}
+	to := time.Now().Add(time.Second)
+
+	admin := newUser(t, userRepo)
+	piiReader := newUser(t, userRepo)
+	if err := adminRepo.InsertUserRole(ctx, &models.UserRole{UserID: piiReader.ID, Role: constants.RolePIIReader}); err != nil {
+		t.Fatal("failed to grant role: ", err)
+	}
+
+	export := func(requestedBy int) [][]string {
+		t.Helper()
+		req := models.UserExportRequest{Format: constants.UserExportFormatCSV, KycStatus: constants.KycStatusVerified, CreatedFrom: &from, CreatedTo: &to}
+		requested, err := svc.RequestExport(ctx, requestedBy, req)
+		if err != nil {
+			t.Fatal("failed to request export: ", err)
+		}
+		if _, err := svc.ProcessPendingExports(ctx); err != nil {
+			t.Fatal("failed to process exports: ", err)
+		}
+
+		got, err := svc.GetExport(ctx, requested.ID, requestedBy)
+		if err != nil {
+			t.Fatal("failed to get export: ", err)
+		}
+		if got.Status != constants.UserExportStatusCompleted || got.RowCount != 1 || got.DownloadURL == "" {
+			t.Fatalf("unexpected export %+v", got)
+		}
+		if _, err := svc.GetExport(ctx, requested.ID, requestedBy+1000000); err == nil {
+			t.Error("another admin should not see the export")
+		}
+
+		records, err := csv.NewReader(bytes.NewReader(storage[fmt.Sprintf("user-exports/%d/users-%d.csv", got.ID, got.ID)])).ReadAll()
+		if err != nil {
+			t.Fatal("failed to read export: ", err)
+		}
+		if len(records) != 2 || records[1][0] != strconv.Itoa(verified.ID) {
+			t.Fatalf("unexpected export rows %v", records)
+		}
+		return records
+	}
+
+	if header := export(admin.ID)[0]; slices.Contains(header, "email") || slices.Contains(header, "full_name") {
+		t.Errorf("export without pii_reader has PII columns %v", header)
+	}
+	if header := export(piiReader.ID)[0]; !slices.Contains(header, "email") || !slices.Contains(header, "phone_number") {
+		t.Errorf("export with pii_reader is missing PII columns %v", header)
+	}
+}
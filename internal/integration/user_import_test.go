@@ -0,0 +1,76 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestUserImportRerunIsIdempotent(t *testing.T) {
+	svc := &services.UserImportService{
+		UserImportRepo: &repository.UserImportRepository{DB: helpers.DB},
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		PasswordHasher: helpers.NewPasswordHasher(2),
+		ProgressEvery:  1,
+	}
+
+	first, second := uniqueName("imp"), uniqueName("imq")
+	file := strings.Join([]string{
+		"external_id,username,email,phone_number,full_name,password",
+		fmt.Sprintf("legacy-%s,%s,%s@example.com,%s,First User,password123", first, first, first, uniquePhone()),
+		fmt.Sprintf("legacy-%s,%s,%s@example.com,%s,Second User,password123", second, second, second, uniquePhone()),
+		fmt.Sprintf("legacy-bad-%s,%s,not-an-email,081234567892,Bad User,password123", first, uniqueName("imr")),
+	}, "\n")
+
+	run := func(dryRun bool) (created, skipped, failed int) {
+		t.Helper()
+		job, err := svc.CreateImport(ctx, constants.AuditActorSystem, constants.UserImportFormatCSV, dryRun)
+		if err != nil {
+			t.Fatal("failed to create import: ", err)
+		}
+		if err := svc.RunImport(ctx, &job, strings.NewReader(file)); err != nil {
+			t.Fatal("failed to run import: ", err)
+		}
+
+		stored, err := svc.GetImport(ctx, job.ID)
+		if err != nil {
+			t.Fatal("failed to get import: ", err)
+		}
+		if stored.Status != constants.UserImportStatusCompleted || stored.ProcessedRows != 3 {
+			t.Fatalf("unexpected import %+v", stored)
+		}
+		if len(stored.Errors) != stored.FailedRows || (stored.FailedRows > 0 && stored.Errors[0].Row != 3) {
+			t.Errorf("unexpected row errors %+v", stored.Errors)
+		}
+		return stored.CreatedRows, stored.SkippedRows, stored.FailedRows
+	}
+
+	if created, skipped, failed := run(true); created != 2 || skipped != 0 || failed != 1 {
+		t.Errorf("dry run: got %d created, %d skipped, %d failed, want 2, 0, 1", created, skipped, failed)
+	}
+	if _, err := (&repository.UserRepository{DB: helpers.DB}).GetUserByUsername(ctx, first); err == nil {
+		t.Error("dry run should not create users")
+	}
+
+	if created, skipped, failed := run(false); created != 2 || skipped != 0 || failed != 1 {
+		t.Errorf("first run: got %d created, %d skipped, %d failed, want 2, 0, 1", created, skipped, failed)
+	}
+	if created, skipped, failed := run(false); created != 0 || skipped != 2 || failed != 1 {
+		t.Errorf("re-run: got %d created, %d skipped, %d failed, want 0, 2, 1", created, skipped, failed)
+	}
+
+	user, err := (&repository.UserRepository{DB: helpers.DB}).GetUserByUsername(ctx, first)
+	if err != nil {
+		t.Fatal("failed to get imported user: ", err)
+	}
+	if user.ExternalID == nil || *user.ExternalID != "legacy-"+first {
+		t.Errorf("got external id %v, want legacy-%s", user.ExternalID, first)
+	}
+}
@@ -0,0 +1,85 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestUserPurge(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	sessionRepo := &repository.SessionRepository{DB: helpers.DB}
+	adminRepo := &repository.AdminRepository{DB: helpers.DB}
+	legalHoldRepo := &repository.LegalHoldRepository{DB: helpers.DB}
+	tokenCache := repository.NewTokenCacheRepository(helpers.Redis, 100, time.Minute)
+	purgeSvc := &services.UserPurgeService{
+		PurgeRepo:      &repository.UserPurgeRepository{DB: helpers.DB},
+		LegalHoldRepo:  legalHoldRepo,
+		SessionRepo:    sessionRepo,
+		AuditRepo:      &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:     tokenCache,
+		EventPublisher: &external.EventPublisher{Redis: helpers.Redis},
+		BatchSize:      10,
+	}
+	svc := &services.AdminApprovalService{
+		AdminRepo:   adminRepo,
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		AuditRepo:   &repository.AuditRepository{DB: helpers.DB},
+		TokenCache:  tokenCache,
+		Purges:      purgeSvc,
+	}
+
+	maker, admin, officer := newUser(t, userRepo), newUser(t, userRepo), newUser(t, userRepo)
+	target, held := newUser(t, userRepo), newUser(t, userRepo)
+	if err := adminRepo.InsertUserRole(ctx, &models.UserRole{UserID: officer.ID, Role: constants.RoleCompliance}); err != nil {
+		t.Fatal("failed to grant compliance role: ", err)
+	}
+	if err := legalHoldRepo.InsertLegalHold(ctx, &models.LegalHold{UserID: held.ID, Reason: "litigation", PlacedBy: officer.ID}); err != nil {
+		t.Fatal("failed to place legal hold: ", err)
+	}
+
+	_, err := svc.RequestApproval(ctx, maker.ID, models.AdminApprovalRequest{Action: constants.AdminActionPurgeUser, TargetUserID: held.ID, Reason: "court order"})
+	if !errors.Is(err, services.ErrUserUnderLegalHold) {
+		t.Fatalf("got error %v requesting a purge of a held user, want ErrUserUnderLegalHold", err)
+	}
+
+	purge, err := svc.RequestApproval(ctx, maker.ID, models.AdminApprovalRequest{Action: constants.AdminActionPurgeUser, TargetUserID: target.ID, Reason: "erasure request"})
+	if err != nil {
+		t.Fatal("failed to request purge: ", err)
+	}
+	if _, err := svc.Approve(ctx, purge.ID, admin.ID, ""); !errors.Is(err, services.ErrComplianceApproval) {
+		t.Fatalf("got error %v approving without the compliance role, want ErrComplianceApproval", err)
+	}
+	if _, err := svc.Approve(ctx, purge.ID, officer.ID, "verified"); err != nil {
+		t.Fatal("failed to approve purge: ", err)
+	}
+
+	// approval only queues the purge
+	if _, err := userRepo.GetUserByID(ctx, target.ID); err != nil {
+		t.Fatal("user purged before the job ran: ", err)
+	}
+
+	if _, err := purgeSvc.PurgeUsers(ctx, time.Now()); err != nil {
+		t.Fatal("failed to purge users: ", err)
+	}
+	var count int64
+	helpers.DB.Unscoped().Model(&models.User{}).Where("id = ?", target.ID).Count(&count)
+	if count != 0 {
+		t.Error("user still exists after the purge")
+	}
+	purges := []models.UserPurge{}
+	helpers.DB.Where("approval_id = ?", purge.ID).Find(&purges)
+	if len(purges) != 1 || purges[0].Status != constants.UserPurgeStatusCompleted || purges[0].PurgedAt == nil {
+		t.Errorf("got purges %+v, want one completed", purges)
+	}
+}
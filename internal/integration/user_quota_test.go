@@ -0,0 +1,48 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestUserQuota(t *testing.T) {
+	svc := &services.UserQuotaService{
+		QuotaRepo: &repository.UserQuotaRepository{Redis: helpers.Redis},
+		Limits:    map[string]int{"exports": 2, "unlimited": 0},
+	}
+	user := newUser(t, &repository.UserRepository{DB: helpers.DB})
+	now := time.Now()
+
+	for range 2 {
+		if _, err := svc.ConsumeQuota(ctx, user.ID, "exports", now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	usage, err := svc.ConsumeQuota(ctx, user.ID, "exports", now)
+	if !apperr.Is(err, apperr.RateLimited) {
+		t.Fatalf("expected the third call to be refused, got %v", err)
+	}
+	if usage.Remaining != 0 || !usage.ResetAt.After(now) {
+		t.Errorf("got %+v, want nothing remaining until the next day", usage)
+	}
+
+	// the next day starts afresh
+	if _, err := svc.ConsumeQuota(ctx, user.ID, "exports", now.Add(24*time.Hour)); err != nil {
+		t.Errorf("expected the quota to reset, got %v", err)
+	}
+
+	all, err := svc.GetQuotaUsage(ctx, user.ID, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].Name != "exports" || all[0].Used != 2 {
+		t.Errorf("got %+v, want only the limited quota, used up", all)
+	}
+}
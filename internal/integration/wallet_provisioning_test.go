@@ -0,0 +1,128 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/repository"
+	"ewallet-ums/internal/services"
+)
+
+func TestWalletProvisioningRetry(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	walletProvisioningRepo := &repository.WalletProvisioningRepository{DB: helpers.DB}
+	walletProvisioningSvc := &services.WalletProvisioningService{
+		WalletProvisioningRepo: walletProvisioningRepo,
+		ExternalWallet:         &external.ExtWallet{HTTPClient: helpers.NewHTTPClient()},
+		BatchSize:              100,
+		MaxAttempts:            3,
+		Interval:               10 * time.Millisecond,
+	}
+
+	user := newUser(t, userRepo)
+	err := walletProvisioningRepo.InsertWalletProvisioning(ctx, &models.WalletProvisioning{
+		UserID:        user.ID,
+		Status:        constants.WalletStatusProvisioning,
+		NextAttemptAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatal("failed to insert wallet provisioning: ", err)
+	}
+
+	wallet.FailNext(1)
+	if _, err := walletProvisioningSvc.ProcessPendingWallets(ctx); err != nil {
+		t.Fatal("failed to process wallet provisioning: ", err)
+	}
+
+	provisioning, err := walletProvisioningSvc.GetWalletStatus(ctx, user.ID)
+	if err != nil {
+		t.Fatal("failed to get wallet status: ", err)
+	}
+	if provisioning.Status != constants.WalletStatusProvisioning || provisioning.Attempts != 1 {
+		t.Fatalf("got status %q after %d attempts, want a pending retry", provisioning.Status, provisioning.Attempts)
+	}
+
+	// wait out the retry backoff
+	time.Sleep(100 * time.Millisecond)
+	if _, err := walletProvisioningSvc.ProcessPendingWallets(ctx); err != nil {
+		t.Fatal("failed to process wallet provisioning: ", err)
+	}
+
+	provisioning, err = walletProvisioningSvc.GetWalletStatus(ctx, user.ID)
+	if err != nil {
+		t.Fatal("failed to get wallet status: ", err)
+	}
+	if provisioning.Status != constants.WalletStatusCreated {
+		t.Errorf("got status %q, want created", provisioning.Status)
+	}
+	if _, ok := wallet.Wallet(user.ID); !ok {
+		t.Error("fake wallet service has no wallet for the user")
+	}
+}
+
+func TestWalletReconciliation(t *testing.T) {
+	userRepo := &repository.UserRepository{DB: helpers.DB}
+	walletProvisioningRepo := &repository.WalletProvisioningRepository{DB: helpers.DB}
+	alerts := make(channelAlerter, 10)
+	reconciliationSvc := &services.WalletReconciliationService{
+		WalletProvisioningRepo: walletProvisioningRepo,
+		AuditRepo:              &repository.AuditRepository{DB: helpers.DB},
+		Alerter:                alerts,
+		SLA:                    time.Hour,
+	}
+
+	failed := newUser(t, userRepo)
+	fresh := newUser(t, userRepo)
+	for _, provisioning := range []models.WalletProvisioning{
+		{UserID: failed.ID, Status: constants.WalletStatusFailed, Attempts: 10, LastError: "wallet service unavailable", NextAttemptAt: time.Now()},
+		{UserID: fresh.ID, Status: constants.WalletStatusProvisioning, NextAttemptAt: time.Now()},
+	} {
+		if err := walletProvisioningRepo.InsertWalletProvisioning(ctx, &provisioning); err != nil {
+			t.Fatal("failed to insert wallet provisioning: ", err)
+		}
+	}
+
+	report, err := reconciliationSvc.Reconcile(ctx)
+	if err != nil {
+		t.Fatal("failed to reconcile: ", err)
+	}
+	if report.Failed < 1 {
+		t.Errorf("got %d failed, want at least 1", report.Failed)
+	}
+	listed := map[int]models.WalletReconciliationItem{}
+	for _, item := range report.Items {
+		listed[item.UserID] = item
+	}
+	if item, ok := listed[failed.ID]; !ok || item.LastError == "" {
+		t.Errorf("failed wallet not listed with its error: %+v", item)
+	}
+	if _, ok := listed[fresh.ID]; ok {
+		t.Error("wallet pending within the sla should not be listed")
+	}
+	select {
+	case <-alerts:
+	default:
+		t.Error("no alert sent for the backlog")
+	}
+
+	if err := reconciliationSvc.RetryProvisioning(ctx, models.UserActor(1), failed.ID); err != nil {
+		t.Fatal("failed to retry: ", err)
+	}
+	provisioning, err := walletProvisioningRepo.GetWalletProvisioningByUserID(ctx, failed.ID)
+	if err != nil || provisioning.Status != constants.WalletStatusProvisioning || provisioning.Attempts != 0 {
+		t.Errorf("got %+v, err %v, want a fresh pending provisioning", provisioning, err)
+	}
+
+	for _, userID := range []int{failed.ID, fresh.ID} {
+		if err := reconciliationSvc.RetryProvisioning(ctx, models.UserActor(1), userID); !errors.Is(err, services.ErrWalletProvisioningNotStuck) {
+			t.Errorf("got err %v retrying a wallet within its sla", err)
+		}
+	}
+}
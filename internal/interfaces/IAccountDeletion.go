@@ -0,0 +1,30 @@
+package interfaces
+
+//go:generate mockgen -source=IAccountDeletion.go -destination=../mocks/mock_IAccountDeletion.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAccountDeletionRepository interface {
+	ScheduleUserDeletion(ctx context.Context, userID, version int, requestedAt, scheduledAt time.Time) (bool, error)
+	CancelUserDeletion(ctx context.Context, userID int) (bool, error)
+	GetUsersDueForDeletion(ctx context.Context, now time.Time, limit int) ([]models.User, error)
+	DeleteUser(ctx context.Context, userID int, now time.Time) (bool, error)
+}
+
+type IAccountDeletionService interface {
+	RequestDeletion(ctx context.Context, userID int, req models.AccountDeletionRequest) (models.AccountDeletionResponse, error)
+	CancelDeletion(ctx context.Context, userID int) error
+	DeleteDueAccounts(ctx context.Context, now time.Time) (int, error)
+	Run(ctx context.Context)
+}
+
+type IAccountDeletionHandler interface {
+	RequestDeletion(c *gin.Context)
+}
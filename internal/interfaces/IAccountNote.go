@@ -0,0 +1,34 @@
+package interfaces
+
+//go:generate mockgen -source=IAccountNote.go -destination=../mocks/mock_IAccountNote.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAccountNoteRepository interface {
+	InsertAccountNote(ctx context.Context, note *models.AccountNote) error
+	GetAccountNoteByID(ctx context.Context, noteID int) (models.AccountNote, error)
+	GetAccountNotes(ctx context.Context, userID int, visibilities []string, cursor *pagination.Cursor, limit int) ([]models.AccountNote, error)
+	UpdateAccountNote(ctx context.Context, note *models.AccountNote) error
+	DeleteAccountNote(ctx context.Context, noteID int) error
+}
+
+type IAccountNoteService interface {
+	GetNotes(ctx context.Context, adminID, userID int, req models.AccountNoteListRequest) (pagination.Page[models.AccountNote], error)
+	AddNote(ctx context.Context, adminID, userID int, req models.AccountNoteRequest) (models.AccountNote, error)
+	UpdateNote(ctx context.Context, adminID, noteID int, req models.AccountNoteRequest) (models.AccountNote, error)
+	DeleteNote(ctx context.Context, adminID, noteID int) error
+}
+
+type IAccountNoteHandler interface {
+	GetNotes(c *gin.Context)
+	AddNote(c *gin.Context)
+	UpdateNote(c *gin.Context)
+	DeleteNote(c *gin.Context)
+}
@@ -0,0 +1,40 @@
+package interfaces
+
+//go:generate mockgen -source=IAccountRecovery.go -destination=../mocks/mock_IAccountRecovery.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAccountRecoveryRepository interface {
+	InsertRecoveryTicket(ctx context.Context, ticket *models.RecoveryTicket) error
+	GetRecoveryTicketByID(ctx context.Context, ticketID int) (models.RecoveryTicket, error)
+	GetRecoveryTicketByResetToken(ctx context.Context, tokenHash string) (models.RecoveryTicket, error)
+	GetRecoveryTickets(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.RecoveryTicket, error)
+	UpdateRecoveryTicketStatus(ctx context.Context, ticket *models.RecoveryTicket, fromStatus string) (bool, error)
+	CompleteRecoveryReset(ctx context.Context, ticket *models.RecoveryTicket, version int, password string, now time.Time) (bool, error)
+}
+
+type IAccountRecoveryService interface {
+	SubmitTicket(ctx context.Context, ipAddress string, req models.RecoveryTicketRequest, evidence []models.RecoveryEvidence) (models.RecoveryTicketReceipt, error)
+	GetTickets(ctx context.Context, req models.RecoveryTicketListRequest) (pagination.Page[models.RecoveryTicket], error)
+	GetTicket(ctx context.Context, ticketID int) (models.RecoveryTicket, error)
+	RejectTicket(ctx context.Context, ticketID, reviewedBy int, note string) (models.RecoveryTicket, error)
+	CheckTicket(ctx context.Context, ticketID, userID int) error
+	IssueReset(ctx context.Context, ticketID, userID, approvedBy int) error
+	CompleteReset(ctx context.Context, req models.ManualRecoveryResetRequest) error
+}
+
+type IAccountRecoveryHandler interface {
+	SubmitTicket(c *gin.Context)
+	CompleteReset(c *gin.Context)
+	GetTickets(c *gin.Context)
+	GetTicket(c *gin.Context)
+	RejectTicket(c *gin.Context)
+}
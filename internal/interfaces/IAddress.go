@@ -0,0 +1,39 @@
+package interfaces
+
+//go:generate mockgen -source=IAddress.go -destination=../mocks/mock_IAddress.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/cmd/proto/user"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAddressRepository interface {
+	GetUserAddresses(ctx context.Context, userID int) ([]models.UserAddress, error)
+	GetUserAddress(ctx context.Context, userID, id int) (models.UserAddress, error)
+	InsertUserAddress(ctx context.Context, address *models.UserAddress) error
+	UpdateUserAddress(ctx context.Context, address *models.UserAddress) (bool, error)
+	SetPrimaryUserAddress(ctx context.Context, userID, id int) (bool, error)
+	DeleteUserAddress(ctx context.Context, userID, id int) (bool, error)
+}
+
+type IAddressService interface {
+	GetAddresses(ctx context.Context, userID int) ([]models.UserAddress, error)
+	GetUserAddresses(ctx context.Context, userID int) ([]models.UserAddress, error)
+	AddAddress(ctx context.Context, userID int, req models.AddressRequest) (models.UserAddress, error)
+	UpdateAddress(ctx context.Context, userID, id int, req models.AddressRequest) (models.UserAddress, error)
+	SetPrimaryAddress(ctx context.Context, userID, id int) error
+	DeleteAddress(ctx context.Context, userID, id int) error
+}
+
+type IAddressHandler interface {
+	GetUserAddresses(ctx context.Context, req *user.UserAddressesRequest) (*user.UserAddressesResponse, error)
+	GetAddresses(c *gin.Context)
+	AddAddress(c *gin.Context)
+	UpdateAddress(c *gin.Context)
+	SetPrimaryAddress(c *gin.Context)
+	DeleteAddress(c *gin.Context)
+}
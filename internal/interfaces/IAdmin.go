@@ -0,0 +1,39 @@
+package interfaces
+
+//go:generate mockgen -source=IAdmin.go -destination=../mocks/mock_IAdmin.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAdminRepository interface {
+	GetUserRoles(ctx context.Context, userID int) ([]string, error)
+	GetUsers(ctx context.Context, filter models.AdminUserFilter, cursor *pagination.Cursor, limit int) ([]models.User, error)
+	InsertUserRole(ctx context.Context, role *models.UserRole) error
+	UpdateUserStatus(ctx context.Context, userID, version int, status string) (bool, error)
+	InsertAdminApproval(ctx context.Context, approval *models.AdminApproval) error
+	GetAdminApprovalByID(ctx context.Context, approvalID int) (models.AdminApproval, error)
+	GetAdminApprovals(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.AdminApproval, error)
+	ReviewAdminApproval(ctx context.Context, approval *models.AdminApproval) (bool, error)
+	UpdateAdminApprovalStatus(ctx context.Context, approvalID int, status string) error
+}
+
+type IAdminApprovalService interface {
+	RequestApproval(ctx context.Context, requestedBy int, req models.AdminApprovalRequest) (models.AdminApproval, error)
+	RequestApprovalAs(ctx context.Context, actor string, req models.AdminApprovalRequest) (models.AdminApproval, error)
+	GetApprovals(ctx context.Context, req models.AdminApprovalListRequest) (pagination.Page[models.AdminApproval], error)
+	Approve(ctx context.Context, approvalID, reviewedBy int, note string) (models.AdminApproval, error)
+	Reject(ctx context.Context, approvalID, reviewedBy int, note string) (models.AdminApproval, error)
+}
+
+type IAdminApprovalHandler interface {
+	RequestApproval(c *gin.Context)
+	GetApprovals(c *gin.Context)
+	Approve(c *gin.Context)
+	Reject(c *gin.Context)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+//go:generate mockgen -source=IAnonymization.go -destination=../mocks/mock_IAnonymization.go -package=mocks
+
+import (
+	"context"
+	"time"
+)
+
+type IAnonymizationRepository interface {
+	GetUserIDsToAnonymize(ctx context.Context, deletedBefore time.Time, limit int) ([]int, error)
+	AnonymizeUser(ctx context.Context, userID int, now time.Time) (bool, error)
+}
+
+type IAnonymizationService interface {
+	AnonymizeDeletedUsers(ctx context.Context, now time.Time) (int, error)
+	Run(ctx context.Context)
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+//go:generate mockgen -source=IAudit.go -destination=../mocks/mock_IAudit.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAuditRepository interface {
+	InsertAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	GetAuditEvents(ctx context.Context, filter models.AuditEventFilter, cursor *pagination.Cursor, limit int) ([]models.AuditEvent, error)
+	CountAuditEvents(ctx context.Context, filter models.AuditEventFilter) (int64, error)
+}
+
+type IAuditService interface {
+	GetAuditEvents(ctx context.Context, req models.AuditEventListRequest) (pagination.Page[models.AuditEvent], error)
+	ExportAuditEvents(ctx context.Context, requestedBy int, filter models.AuditEventFilter) ([]byte, error)
+}
+
+type IAuditHandler interface {
+	GetAuditEvents(c *gin.Context)
+}
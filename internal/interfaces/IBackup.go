@@ -0,0 +1,19 @@
+package interfaces
+
+//go:generate mockgen -source=IBackup.go -destination=../mocks/mock_IBackup.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+)
+
+type IBackupRepository interface {
+	DumpTable(ctx context.Context, table string, afterID int64, limit int) ([]map[string]any, error)
+}
+
+type IBackupService interface {
+	Export(ctx context.Context, tables []string, prefix string, now time.Time) (models.BackupResult, error)
+	Verify(ctx context.Context, key string) (models.BackupResult, error)
+}
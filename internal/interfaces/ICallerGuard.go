@@ -0,0 +1,15 @@
+package interfaces
+
+//go:generate mockgen -source=ICallerGuard.go -destination=../mocks/mock_ICallerGuard.go -package=mocks
+
+import (
+	"context"
+	"time"
+)
+
+type ICallerGuardRepository interface {
+	Allow(ctx context.Context, caller string, limit int, window time.Duration) (bool, error)
+	RecordFailure(ctx context.Context, caller string, window time.Duration) (int64, error)
+	Ban(ctx context.Context, caller string, duration time.Duration) error
+	IsBanned(ctx context.Context, caller string) (bool, error)
+}
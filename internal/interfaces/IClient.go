@@ -0,0 +1,14 @@
+package interfaces
+
+//go:generate mockgen -source=IClient.go -destination=../mocks/mock_IClient.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+)
+
+type IClientRepository interface {
+	GetClientByClientID(ctx context.Context, clientID string) (models.Client, error)
+	UpsertClient(ctx context.Context, client *models.Client) error
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+//go:generate mockgen -source=IConsent.go -destination=../mocks/mock_IConsent.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IConsentRepository interface {
+	InsertUserConsents(ctx context.Context, consents []models.UserConsent) error
+	GetLatestUserConsents(ctx context.Context, userID int) ([]models.UserConsent, error)
+}
+
+type IConsentService interface {
+	GetConsents(ctx context.Context, userID int) ([]models.UserConsent, error)
+	UpdateConsents(ctx context.Context, userID int, req models.UpdateConsentsRequest) ([]models.UserConsent, error)
+}
+
+type IConsentHandler interface {
+	GetConsents(c *gin.Context)
+	UpdateConsents(c *gin.Context)
+}
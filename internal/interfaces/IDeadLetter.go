@@ -0,0 +1,37 @@
+package interfaces
+
+//go:generate mockgen -source=IDeadLetter.go -destination=../mocks/mock_IDeadLetter.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IDeadLetterRepository interface {
+	InsertDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error
+	GetDeadLetterByID(ctx context.Context, deadLetterID int) (models.DeadLetter, error)
+	GetDeadLetters(ctx context.Context, status, topic string, cursor *pagination.Cursor, limit int) ([]models.DeadLetter, error)
+	UpdateDeadLetter(ctx context.Context, deadLetter *models.DeadLetter, fromStatus string) (bool, error)
+}
+
+type IDeadLetterService interface {
+	RecordPublished(ctx context.Context, topic string, message []byte, reason string) error
+	RecordConsumed(ctx context.Context, topic, messageID string, payload []byte, reason string) error
+	GetDeadLetters(ctx context.Context, req models.DeadLetterListRequest) (pagination.Page[models.DeadLetter], error)
+	GetDeadLetter(ctx context.Context, deadLetterID int) (models.DeadLetter, error)
+	UpdateDeadLetter(ctx context.Context, actor string, deadLetterID int, req models.DeadLetterUpdateRequest) (models.DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, actor string, deadLetterID int) (models.DeadLetter, error)
+	DiscardDeadLetter(ctx context.Context, actor string, deadLetterID int, req models.DeadLetterDiscardRequest) (models.DeadLetter, error)
+}
+
+type IDeadLetterHandler interface {
+	GetDeadLetters(c *gin.Context)
+	GetDeadLetter(c *gin.Context)
+	UpdateDeadLetter(c *gin.Context)
+	ReplayDeadLetter(c *gin.Context)
+	DiscardDeadLetter(c *gin.Context)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+//go:generate mockgen -source=IEmailTemplate.go -destination=../mocks/mock_IEmailTemplate.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IEmailTemplateService interface {
+	GetTemplates(ctx context.Context) []models.EmailTemplate
+	Preview(ctx context.Context, name, locale, clientID string) (models.RenderedEmail, error)
+}
+
+type IEmailTemplateHandler interface {
+	GetTemplates(c *gin.Context)
+	Preview(c *gin.Context)
+}
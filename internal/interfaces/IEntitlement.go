@@ -0,0 +1,35 @@
+package interfaces
+
+//go:generate mockgen -source=IEntitlement.go -destination=../mocks/mock_IEntitlement.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/cmd/proto/entitlement"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IEntitlementRepository interface {
+	GetUserEntitlements(ctx context.Context, userID int) ([]models.UserEntitlement, error)
+	InsertUserEntitlement(ctx context.Context, entitlement *models.UserEntitlement) (bool, error)
+	DeleteUserEntitlement(ctx context.Context, userID int, feature string) (bool, error)
+}
+
+type IEntitlementService interface {
+	GetEntitlements(ctx context.Context, userID int) ([]models.Entitlement, error)
+	GetFeatures(ctx context.Context, user models.User) ([]string, error)
+	GetUserFeatures(ctx context.Context, userID int) ([]string, error)
+	GrantEntitlement(ctx context.Context, actor string, userID int, req models.EntitlementRequest) error
+	RevokeEntitlement(ctx context.Context, actor string, userID int, req models.EntitlementRequest) error
+	EnrichClaims(ctx context.Context, claims *helpers.ClaimToken) (map[string]any, error)
+}
+
+type IEntitlementHandler interface {
+	GetUserEntitlements(ctx context.Context, req *entitlement.UserEntitlementsRequest) (*entitlement.UserEntitlementsResponse, error)
+	GetEntitlements(c *gin.Context)
+	GrantEntitlement(c *gin.Context)
+	RevokeEntitlement(c *gin.Context)
+}
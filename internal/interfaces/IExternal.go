@@ -1,11 +1,62 @@
 package interfaces
 
+//go:generate mockgen -source=IExternal.go -destination=../mocks/mock_IExternal.go -package=mocks
+
 import (
 	"context"
+	"io"
+	"time"
 
 	"ewallet-ums/external"
+
+	"google.golang.org/protobuf/proto"
 )
 
 type IWallet interface {
 	CreateWallet(ctx context.Context, userID int) (*external.Wallet, error)
+	Ping(ctx context.Context) error
+}
+
+type IEventPublisher interface {
+	Publish(ctx context.Context, topic string, event proto.Message) error
+	PublishMessage(ctx context.Context, topic string, message []byte) error
+}
+
+// IEventConsumer delivers the events of topics to handle until they are
+// handled without an error.
+type IEventConsumer interface {
+	Consume(ctx context.Context, topics []string, handle func(ctx context.Context, topic string, payload []byte) error) error
+	Redeliver(ctx context.Context, topic string, payload []byte) error
+}
+
+type IObjectStorage interface {
+	PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	PresignGetURL(key string, ttl time.Duration) (string, error)
+}
+
+// IGeoIP locates IP addresses, an address it can't locate gets an empty
+// location rather than an error.
+type IGeoIP interface {
+	Lookup(ctx context.Context, ip string) (external.GeoLocation, error)
+}
+
+type IMailer interface {
+	Send(ctx context.Context, mail external.Mail) error
+}
+
+type IAlerter interface {
+	Alert(ctx context.Context, alert external.Alert) error
+}
+
+type ISMSSender interface {
+	SendSMS(ctx context.Context, sms external.SMS) error
+}
+
+type IScreeningProvider interface {
+	Screen(ctx context.Context, subject external.ScreeningSubject) ([]external.ScreeningMatch, error)
+}
+
+type IErrorReporter interface {
+	Report(ctx context.Context, report external.ErrorReport) error
 }
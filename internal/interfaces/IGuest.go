@@ -0,0 +1,21 @@
+package interfaces
+
+//go:generate mockgen -source=IGuest.go -destination=../mocks/mock_IGuest.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IGuestService interface {
+	CreateGuest(ctx context.Context, req models.GuestRequest) (models.LoginResponse, error)
+	UpgradeGuest(ctx context.Context, userID int, req models.UpgradeGuestRequest) (models.LoginResponse, error)
+}
+
+type IGuestHandler interface {
+	CreateGuest(c *gin.Context)
+	UpgradeGuest(c *gin.Context)
+}
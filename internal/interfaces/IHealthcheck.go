@@ -1,13 +1,25 @@
 package interfaces
 
-import "github.com/gin-gonic/gin"
+//go:generate mockgen -source=IHealthcheck.go -destination=../mocks/mock_IHealthcheck.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
 
 type IHealthcheckServices interface {
 	HealthcheckServices() (string, error)
+	Check(ctx context.Context) models.HealthReport
 }
 
 type IHealthcheckHandler interface {
 	HealthcheckHandlerHTTP(c *gin.Context)
 }
 
-type IHealthcheckRepo interface{}
+type IHealthcheckRepo interface {
+	PingDB(ctx context.Context) error
+	PingRedis(ctx context.Context) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+//go:generate mockgen -source=IInbox.go -destination=../mocks/mock_IInbox.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+)
+
+type IInboxRepository interface {
+	ClaimInboxEvent(ctx context.Context, event *models.InboxEvent, staleBefore time.Time) (bool, error)
+	MarkInboxEventProcessed(ctx context.Context, event *models.InboxEvent, now time.Time) error
+	DeleteInboxEvent(ctx context.Context, event *models.InboxEvent) error
+}
+
+type IInboxService interface {
+	HandleEvent(ctx context.Context, topic string, payload []byte) error
+	Run(ctx context.Context)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+//go:generate mockgen -source=IIntrospection.go -destination=../mocks/mock_IIntrospection.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IIntrospectionService interface {
+	Introspect(ctx context.Context, token, tokenTypeHint string) (models.IntrospectionResponse, error)
+}
+
+type IIntrospectionHandler interface {
+	Introspect(c *gin.Context)
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+//go:generate mockgen -source=IKyc.go -destination=../mocks/mock_IKyc.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IKycRepository interface {
+	GetKycProfile(ctx context.Context, userID int) (models.KycProfile, error)
+	UpsertKycProfile(ctx context.Context, profile *models.KycProfile) error
+}
+
+type IKycService interface {
+	GetKycProfile(ctx context.Context, userID int) (models.KycProfileResponse, error)
+	GetUserKycProfile(ctx context.Context, userID int) (models.KycProfileResponse, error)
+	UpdateKycProfile(ctx context.Context, userID int, req models.KycProfileRequest) (models.KycProfileResponse, error)
+}
+
+type IKycHandler interface {
+	GetKycProfile(c *gin.Context)
+	UpdateKycProfile(c *gin.Context)
+	GetUserKycProfile(c *gin.Context)
+}
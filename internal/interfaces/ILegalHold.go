@@ -0,0 +1,31 @@
+package interfaces
+
+//go:generate mockgen -source=ILegalHold.go -destination=../mocks/mock_ILegalHold.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ILegalHoldRepository interface {
+	InsertLegalHold(ctx context.Context, hold *models.LegalHold) error
+	GetLegalHoldByID(ctx context.Context, holdID int) (models.LegalHold, error)
+	GetLegalHoldsByUserID(ctx context.Context, userID int) ([]models.LegalHold, error)
+	LiftLegalHold(ctx context.Context, hold *models.LegalHold) (bool, error)
+	HasActiveLegalHold(ctx context.Context, userID int) (bool, error)
+}
+
+type ILegalHoldService interface {
+	PlaceHold(ctx context.Context, userID, placedBy int, reason string) (models.LegalHold, error)
+	LiftHold(ctx context.Context, holdID, liftedBy int, reason string) (models.LegalHold, error)
+	GetHolds(ctx context.Context, userID int) ([]models.LegalHold, error)
+}
+
+type ILegalHoldHandler interface {
+	PlaceHold(c *gin.Context)
+	LiftHold(c *gin.Context)
+	GetHolds(c *gin.Context)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+//go:generate mockgen -source=ILocker.go -destination=../mocks/mock_ILocker.go -package=mocks
+
+import (
+	"context"
+	"time"
+)
+
+// ILocker takes named locks shared by every instance of the service.
+type ILocker interface {
+	// TryLock takes the lock for ttl without waiting, ok is false when
+	// another instance holds it.
+	TryLock(ctx context.Context, name string, ttl time.Duration) (lock ILock, ok bool, err error)
+}
+
+type ILock interface {
+	// Refresh extends the lock by its ttl, it returns locker.ErrLockLost once
+	// another instance may have taken it.
+	Refresh(ctx context.Context) error
+	Unlock(ctx context.Context) error
+}
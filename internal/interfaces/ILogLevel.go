@@ -0,0 +1,21 @@
+package interfaces
+
+//go:generate mockgen -source=ILogLevel.go -destination=../mocks/mock_ILogLevel.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ILogLevelService interface {
+	GetLogLevel(ctx context.Context) models.LogLevelResponse
+	SetLogLevel(ctx context.Context, actor, level string) (models.LogLevelResponse, error)
+}
+
+type ILogLevelHandler interface {
+	GetLogLevel(c *gin.Context)
+	SetLogLevel(c *gin.Context)
+}
@@ -1,5 +1,7 @@
 package interfaces
 
+//go:generate mockgen -source=ILogin.go -destination=../mocks/mock_ILogin.go -package=mocks
+
 import (
 	"context"
 
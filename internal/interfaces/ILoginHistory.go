@@ -0,0 +1,27 @@
+package interfaces
+
+//go:generate mockgen -source=ILoginHistory.go -destination=../mocks/mock_ILoginHistory.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ILoginHistoryRepository interface {
+	InsertLoginHistory(ctx context.Context, history *models.LoginHistory) error
+	GetLoginHistoriesByUserID(ctx context.Context, userID int, cursor *pagination.Cursor, limit int) ([]models.LoginHistory, error)
+	HasSuccessfulLogin(ctx context.Context, userID int, userAgent string) (bool, error)
+	HasSuccessfulLoginFromCountry(ctx context.Context, userID int, country string) (bool, error)
+}
+
+type ILoginHistoryService interface {
+	GetLoginHistories(ctx context.Context, userID int, req pagination.Request) (pagination.Page[models.LoginHistory], error)
+}
+
+type ILoginHistoryHandler interface {
+	GetLoginHistories(c *gin.Context)
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+//go:generate mockgen -source=ILoginVelocity.go -destination=../mocks/mock_ILoginVelocity.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ILoginVelocityRepository interface {
+	RecordLoginFailure(ctx context.Context, source string, now time.Time, window time.Duration) (int64, error)
+	GetLoginFailureCounts(ctx context.Context, now time.Time, window time.Duration) (map[string]int64, error)
+	MarkLoginVelocityAlerted(ctx context.Context, source string, window time.Duration) (bool, error)
+}
+
+type ILoginVelocityService interface {
+	RecordFailure(ctx context.Context, sources []models.LoginSource)
+	GetHotSources(ctx context.Context) ([]models.HotLoginSource, error)
+}
+
+type ILoginVelocityHandler interface {
+	GetHotSources(c *gin.Context)
+}
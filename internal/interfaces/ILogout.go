@@ -1,5 +1,7 @@
 package interfaces
 
+//go:generate mockgen -source=ILogout.go -destination=../mocks/mock_ILogout.go -package=mocks
+
 import (
 	"context"
 
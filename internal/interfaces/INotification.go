@@ -0,0 +1,69 @@
+package interfaces
+
+//go:generate mockgen -source=INotification.go -destination=../mocks/mock_INotification.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type INotificationRepository interface {
+	GetNotificationPreferences(ctx context.Context, userID int) ([]models.NotificationPreference, error)
+	UpsertNotificationPreferences(ctx context.Context, preferences []models.NotificationPreference) error
+	GetQuietHours(ctx context.Context, userID int) (models.NotificationQuietHours, error)
+	UpsertQuietHours(ctx context.Context, quietHours *models.NotificationQuietHours) error
+	DeleteQuietHours(ctx context.Context, userID int) error
+	InsertPendingNotification(ctx context.Context, notification *models.PendingNotification) error
+	GetDuePendingNotificationUsers(ctx context.Context, now time.Time, afterUserID, limit int) ([]int, error)
+	GetPendingNotifications(ctx context.Context, userID int) ([]models.PendingNotification, error)
+	DeferPendingNotifications(ctx context.Context, userID int, until time.Time) error
+	DeletePendingNotifications(ctx context.Context, ids []int) error
+}
+
+type INotificationThrottleRepository interface {
+	ClaimNotification(ctx context.Context, userID int, event string, window time.Duration) (bool, error)
+}
+
+type INotificationService interface {
+	GetPreferences(ctx context.Context, userID int) ([]models.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, userID int, req models.UpdateNotificationPreferencesRequest) ([]models.NotificationPreference, error)
+	GetQuietHours(ctx context.Context, userID int) (*models.NotificationQuietHours, error)
+	UpdateQuietHours(ctx context.Context, userID int, req models.UpdateQuietHoursRequest) (*models.NotificationQuietHours, error)
+	DeleteQuietHours(ctx context.Context, userID int) error
+	NotifySecurityEvent(ctx context.Context, userID int, event models.SecurityEvent)
+	SendSecurityEmail(ctx context.Context, userID int, event models.SecurityEvent) error
+	SendActivityDigest(ctx context.Context, userID int, summary models.ActivitySummary) error
+	SendSecuritySummary(ctx context.Context, userID int, pending []models.PendingNotification) error
+	SendPasswordResetLink(ctx context.Context, userID int, to string, link models.EmailLink) error
+	Unsubscribe(ctx context.Context, token string) error
+}
+
+type INotificationHandler interface {
+	GetPreferences(c *gin.Context)
+	UpdatePreferences(c *gin.Context)
+	GetQuietHours(c *gin.Context)
+	UpdateQuietHours(c *gin.Context)
+	DeleteQuietHours(c *gin.Context)
+	Unsubscribe(c *gin.Context)
+}
+
+type IActivityDigestRepository interface {
+	GetDigestRecipients(ctx context.Context, month string, afterUserID, limit int) ([]int, error)
+	ClaimActivityDigest(ctx context.Context, digest *models.ActivityDigest) (bool, error)
+	DeleteActivityDigest(ctx context.Context, digest *models.ActivityDigest) error
+	GetLoginHistoriesBetween(ctx context.Context, userID int, from, to time.Time) ([]models.LoginHistory, error)
+}
+
+type IActivityDigestService interface {
+	SendDigests(ctx context.Context, now time.Time) (int, error)
+	Run(ctx context.Context)
+}
+
+type INotificationSummaryService interface {
+	SendSummaries(ctx context.Context, now time.Time) (int, error)
+	Run(ctx context.Context)
+}
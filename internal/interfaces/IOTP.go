@@ -0,0 +1,36 @@
+package interfaces
+
+//go:generate mockgen -source=IOTP.go -destination=../mocks/mock_IOTP.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IOTPRepository interface {
+	MarkOTPSent(ctx context.Context, phoneLookup string, interval time.Duration) (bool, error)
+	SaveOTP(ctx context.Context, purpose, phoneLookup, codeHash string, ttl time.Duration) error
+	AttemptOTP(ctx context.Context, purpose, phoneLookup string) (string, int, error)
+	DeleteOTP(ctx context.Context, purpose, phoneLookup string) error
+}
+
+type ITrustedDeviceRepository interface {
+	InsertTrustedDevice(ctx context.Context, device *models.TrustedDevice) error
+	UseTrustedDevice(ctx context.Context, userID int, tokenHash string, now time.Time) (bool, error)
+	DeleteTrustedDevices(ctx context.Context, userID int) error
+}
+
+type IOTPService interface {
+	RequestOTP(ctx context.Context, req models.OTPRequest) error
+	VerifyOTP(ctx context.Context, purpose, phoneNumber, code string) error
+	RegisterWithPhone(ctx context.Context, req models.PhoneRegisterRequest) (models.LoginResponse, error)
+}
+
+type IOTPHandler interface {
+	RequestOTP(c *gin.Context)
+	RegisterWithPhone(c *gin.Context)
+}
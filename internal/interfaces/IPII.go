@@ -0,0 +1,19 @@
+package interfaces
+
+//go:generate mockgen -source=IPII.go -destination=../mocks/mock_IPII.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+)
+
+type IPIIRepository interface {
+	GetUsersWithStalePII(ctx context.Context, activePrefix string, afterID, limit int) ([]models.User, error)
+	GetUsersAfter(ctx context.Context, afterID, limit int) ([]models.User, error)
+	ReencryptUserPII(ctx context.Context, user *models.User) error
+}
+
+type IPIIRotationService interface {
+	Rotate(ctx context.Context, newDataKey, rewrap, reindex bool) (int, error)
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+//go:generate mockgen -source=IPasswordHasher.go -destination=../mocks/mock_IPasswordHasher.go -package=mocks
+
+import "context"
+
+type IPasswordHasher interface {
+	HashPassword(ctx context.Context, password string) (string, error)
+	ComparePassword(ctx context.Context, hashPassword, password string) error
+}
@@ -0,0 +1,36 @@
+package interfaces
+
+//go:generate mockgen -source=IProfile.go -destination=../mocks/mock_IProfile.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IUserClaimsService interface {
+	InvalidateUserClaims(ctx context.Context, userID int, changedFields []string) error
+}
+
+type IEmailChangeRepository interface {
+	InsertEmailChangeRevert(ctx context.Context, revert *models.EmailChangeRevert) error
+	GetEmailChangeRevertByToken(ctx context.Context, tokenHash string) (models.EmailChangeRevert, error)
+	RevertEmailChange(ctx context.Context, revert *models.EmailChangeRevert, version int, password string, now time.Time) (bool, error)
+}
+
+type IProfileService interface {
+	GetProfile(ctx context.Context, userID int) (models.User, error)
+	UpdateProfile(ctx context.Context, userID int, req models.UpdateProfileRequest) (models.User, error)
+	RevertEmailChange(ctx context.Context, req models.EmailChangeRevertRequest) (models.EmailChangeRevertResponse, error)
+	SetPassword(ctx context.Context, userID int, req models.SetPasswordRequest) error
+}
+
+type IProfileHandler interface {
+	GetProfile(c *gin.Context)
+	UpdateProfile(c *gin.Context)
+	RevertEmailChange(c *gin.Context)
+	SetPassword(c *gin.Context)
+}
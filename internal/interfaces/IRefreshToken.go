@@ -1,7 +1,10 @@
 package interfaces
 
+//go:generate mockgen -source=IRefreshToken.go -destination=../mocks/mock_IRefreshToken.go -package=mocks
+
 import (
 	"context"
+	"time"
 
 	"ewallet-ums/helpers"
 	"ewallet-ums/internal/models"
@@ -9,8 +12,21 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type IRefreshTokenRepository interface {
+	StartRefreshTokenFamily(ctx context.Context, sessionID int, root *models.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	GetRefreshTokenByTokenID(ctx context.Context, tokenID string) (models.RefreshToken, error)
+	GetRefreshTokenFamily(ctx context.Context, familyID string) ([]models.RefreshToken, error)
+	GetUserSessionByFamilyID(ctx context.Context, familyID string) (models.UserSession, error)
+	GetUserSessionByTokenID(ctx context.Context, tokenID string) (models.UserSession, error)
+	RotateRefreshToken(ctx context.Context, parent models.RefreshToken, child *models.RefreshToken, session models.UserSession, now time.Time) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string, now time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int, now time.Time) error
+	DeleteExpiredRefreshTokens(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
 type IRefreshTokenService interface {
-	RefreshToken(ctx context.Context, refreshToken string, tokenClaim helpers.ClaimToken) (models.RefreshTokenResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string, tokenClaim helpers.ClaimToken, origin models.TokenOrigin) (models.RefreshTokenResponse, error)
 }
 
 type IRefreshTokenHandler interface {
@@ -1,5 +1,7 @@
 package interfaces
 
+//go:generate mockgen -source=IRegister.go -destination=../mocks/mock_IRegister.go -package=mocks
+
 import (
 	"context"
 
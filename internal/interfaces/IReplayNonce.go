@@ -0,0 +1,12 @@
+package interfaces
+
+//go:generate mockgen -source=IReplayNonce.go -destination=../mocks/mock_IReplayNonce.go -package=mocks
+
+import (
+	"context"
+	"time"
+)
+
+type IReplayNonceRepository interface {
+	ClaimRequestNonce(ctx context.Context, userID int, nonce string, ttl time.Duration) (bool, error)
+}
@@ -0,0 +1,36 @@
+package interfaces
+
+//go:generate mockgen -source=IReservedUsername.go -destination=../mocks/mock_IReservedUsername.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IReservedUsernameRepository interface {
+	InsertReservedUsername(ctx context.Context, reservation *models.ReservedUsername) (bool, error)
+	GetReservedUsername(ctx context.Context, username string) (models.ReservedUsername, error)
+	GetReservedUsernames(ctx context.Context, cursor *pagination.Cursor, limit int) ([]models.ReservedUsername, error)
+	DeleteReservedUsername(ctx context.Context, username string) (bool, error)
+	ClaimReservedUsername(ctx context.Context, reservation *models.ReservedUsername, userID, version int, now time.Time) (bool, error)
+}
+
+type IReservedUsernameService interface {
+	CheckUsername(ctx context.Context, username string) error
+	GetReservedUsernames(ctx context.Context, req models.ReservedUsernameListRequest) (pagination.Page[models.ReservedUsername], error)
+	ReserveUsername(ctx context.Context, actor string, req models.ReservedUsernameRequest) (models.ReservedUsername, error)
+	DeleteReservedUsername(ctx context.Context, actor, username string) error
+	ClaimReservedUsername(ctx context.Context, actor, username string, req models.ClaimReservedUsernameRequest) (models.ReservedUsername, error)
+}
+
+type IReservedUsernameHandler interface {
+	GetReservedUsernames(c *gin.Context)
+	ReserveUsername(c *gin.Context)
+	DeleteReservedUsername(c *gin.Context)
+	ClaimReservedUsername(c *gin.Context)
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+//go:generate mockgen -source=IRetention.go -destination=../mocks/mock_IRetention.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+)
+
+type IRetentionRepository interface {
+	CountExpired(ctx context.Context, rule string, before time.Time) (int64, error)
+	PurgeExpired(ctx context.Context, rule string, before time.Time, limit int) (int64, error)
+}
+
+type IRetentionService interface {
+	Enforce(ctx context.Context, now time.Time, dryRun bool) ([]models.RetentionResult, error)
+	Run(ctx context.Context)
+}
@@ -0,0 +1,36 @@
+package interfaces
+
+//go:generate mockgen -source=IScreening.go -destination=../mocks/mock_IScreening.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IScreeningRepository interface {
+	GetUsersDueForScreening(ctx context.Context, screenedBefore time.Time, afterID, limit int) ([]models.User, error)
+	MarkUserScreened(ctx context.Context, userID int, screenedAt time.Time) error
+	InsertScreeningReview(ctx context.Context, review *models.ScreeningReview) (bool, error)
+	GetScreeningReviewByID(ctx context.Context, reviewID int) (models.ScreeningReview, error)
+	GetScreeningReviews(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.ScreeningReview, error)
+	ResolveScreeningReview(ctx context.Context, review *models.ScreeningReview) (bool, error)
+}
+
+type IScreeningService interface {
+	Screen(ctx context.Context, now time.Time) (int, error)
+	GetScreeningReviews(ctx context.Context, req models.ScreeningReviewListRequest) (pagination.Page[models.ScreeningReview], error)
+	GetScreeningReview(ctx context.Context, reviewID int) (models.ScreeningReview, error)
+	ResolveScreeningReview(ctx context.Context, actor string, reviewID int, req models.ScreeningReviewResolveRequest) (models.ScreeningReview, error)
+	Run(ctx context.Context)
+}
+
+type IScreeningHandler interface {
+	GetScreeningReviews(c *gin.Context)
+	GetScreeningReview(c *gin.Context)
+	ResolveScreeningReview(c *gin.Context)
+}
@@ -0,0 +1,30 @@
+package interfaces
+
+//go:generate mockgen -source=ISecurityQuestion.go -destination=../mocks/mock_ISecurityQuestion.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ISecurityQuestionRepository interface {
+	GetSecurityQuestions(ctx context.Context, userID int) ([]models.SecurityQuestion, error)
+	ReplaceSecurityQuestions(ctx context.Context, userID int, questions []models.SecurityQuestion) error
+}
+
+type ISecurityQuestionService interface {
+	GetSecurityQuestions(ctx context.Context, userID int) ([]models.SecurityQuestion, error)
+	SetSecurityQuestions(ctx context.Context, userID int, req models.SetSecurityQuestionsRequest) ([]models.SecurityQuestion, error)
+	GetRecoveryQuestions(ctx context.Context, identifier string) ([]models.RecoveryQuestion, error)
+	RecoverWithSecurityQuestions(ctx context.Context, req models.SecurityQuestionRecoveryRequest) error
+}
+
+type ISecurityQuestionHandler interface {
+	GetSecurityQuestions(c *gin.Context)
+	SetSecurityQuestions(c *gin.Context)
+	GetRecoveryQuestions(c *gin.Context)
+	RecoverWithSecurityQuestions(c *gin.Context)
+}
@@ -0,0 +1,41 @@
+package interfaces
+
+//go:generate mockgen -source=IServiceAccount.go -destination=../mocks/mock_IServiceAccount.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IServiceAccountRepository interface {
+	InsertServiceAccount(ctx context.Context, user *models.User, account *models.ServiceAccount) error
+	GetServiceAccountByID(ctx context.Context, accountID int) (models.ServiceAccount, error)
+	GetServiceAccountByClientID(ctx context.Context, clientID string) (models.ServiceAccount, error)
+	GetServiceAccounts(ctx context.Context, cursor *pagination.Cursor, limit int) ([]models.ServiceAccount, error)
+	UpdateServiceAccount(ctx context.Context, account *models.ServiceAccount) error
+	UpdateServiceAccountSecret(ctx context.Context, userID int, secretHash string) error
+	DeleteServiceAccount(ctx context.Context, account *models.ServiceAccount) error
+}
+
+type IServiceAccountService interface {
+	CreateServiceAccount(ctx context.Context, createdBy int, req models.ServiceAccountRequest) (models.ServiceAccountCredentials, error)
+	GetServiceAccounts(ctx context.Context, req models.ServiceAccountListRequest) (pagination.Page[models.ServiceAccount], error)
+	GetServiceAccount(ctx context.Context, accountID int) (models.ServiceAccount, error)
+	UpdateServiceAccount(ctx context.Context, accountID, updatedBy int, req models.ServiceAccountRequest) (models.ServiceAccount, error)
+	DeleteServiceAccount(ctx context.Context, accountID, deletedBy int) error
+	RotateSecret(ctx context.Context, accountID, rotatedBy int) (models.ServiceAccountCredentials, error)
+	IssueToken(ctx context.Context, clientID, clientSecret, scope string) (models.OAuthTokenResponse, error)
+}
+
+type IServiceAccountHandler interface {
+	CreateServiceAccount(c *gin.Context)
+	GetServiceAccounts(c *gin.Context)
+	GetServiceAccount(c *gin.Context)
+	UpdateServiceAccount(c *gin.Context)
+	DeleteServiceAccount(c *gin.Context)
+	RotateSecret(c *gin.Context)
+}
@@ -0,0 +1,33 @@
+package interfaces
+
+//go:generate mockgen -source=ISession.go -destination=../mocks/mock_ISession.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ISessionRepository interface {
+	InsertNewUserSession(ctx context.Context, session *models.UserSession) error
+	DeleteUserSession(ctx context.Context, token string) error
+	GetUserSessionByToken(ctx context.Context, token string) (models.UserSession, error)
+	GetUserSessionByTokenID(ctx context.Context, tokenID string) (models.UserSession, error)
+	UpdateTokenByRefreshToken(ctx context.Context, token, refreshToken string) error
+	GetUserSessionByRefreshToken(ctx context.Context, refreshToken string) (models.UserSession, error)
+	CountActiveUserSessions(ctx context.Context, now time.Time) (int64, error)
+	GetActiveUserSessions(ctx context.Context, userID int, now time.Time) ([]models.UserSession, error)
+	GetUserSessionsByUserID(ctx context.Context, userID int, cursor *pagination.Cursor, limit int) ([]models.UserSession, error)
+}
+
+type ISessionService interface {
+	GetSessions(ctx context.Context, userID int, req pagination.Request) (pagination.Page[models.SessionItem], error)
+}
+
+type ISessionHandler interface {
+	GetSessions(c *gin.Context)
+}
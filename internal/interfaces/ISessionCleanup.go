@@ -0,0 +1,10 @@
+package interfaces
+
+//go:generate mockgen -source=ISessionCleanup.go -destination=../mocks/mock_ISessionCleanup.go -package=mocks
+
+import "context"
+
+type ISessionCleanupService interface {
+	CleanupExpiredSessions(ctx context.Context) (int64, error)
+	Run(ctx context.Context)
+}
@@ -0,0 +1,35 @@
+package interfaces
+
+//go:generate mockgen -source=IStats.go -destination=../mocks/mock_IStats.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IStatsRepository interface {
+	CountRegistrationsByDay(ctx context.Context, since time.Time) (map[string]int64, error)
+	CountFailedLoginsByDay(ctx context.Context, since time.Time) (map[string]int64, error)
+	UpsertDailyStats(ctx context.Context, stats []models.DailyStat) error
+	GetDailyStats(ctx context.Context, sinceDay string) ([]models.DailyStat, error)
+	CountUsersByKycStatus(ctx context.Context, status string) (int64, error)
+}
+
+type IActiveUsersRepository interface {
+	RecordActiveUser(ctx context.Context, userID int, now time.Time) error
+	CountActiveUsers(ctx context.Context, now time.Time, hours int) (int64, error)
+}
+
+type IStatsService interface {
+	GetStats(ctx context.Context, days int) (models.AdminStats, error)
+	Refresh(ctx context.Context, now time.Time, days int) error
+	Run(ctx context.Context)
+}
+
+type IStatsHandler interface {
+	GetStats(c *gin.Context)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+//go:generate mockgen -source=ITokenCache.go -destination=../mocks/mock_ITokenCache.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+)
+
+type ITokenCacheRepository interface {
+	GetTokenClaim(ctx context.Context, token string) (*helpers.ClaimToken, error)
+	SetTokenClaim(ctx context.Context, token string, claim *helpers.ClaimToken) error
+	EvictToken(ctx context.Context, token string) error
+	RevokeToken(ctx context.Context, token string, expiresAt time.Time) error
+	RevokeTokenID(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, token, tokenID string) (bool, error)
+	GetRevokedTokens(ctx context.Context, cursor uint64, count int) ([]models.RevokedToken, uint64, error)
+	DeleteRevokedToken(ctx context.Context, key string) (bool, error)
+	SubscribeRevocation(ctx context.Context)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+//go:generate mockgen -source=ITokenExchange.go -destination=../mocks/mock_ITokenExchange.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ITokenExchangeService interface {
+	Exchange(ctx context.Context, clientID string, req models.TokenExchangeRequest) (models.OAuthTokenResponse, error)
+}
+
+type IOAuthTokenHandler interface {
+	Token(c *gin.Context)
+}
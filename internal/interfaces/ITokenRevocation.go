@@ -0,0 +1,26 @@
+package interfaces
+
+//go:generate mockgen -source=ITokenRevocation.go -destination=../mocks/mock_ITokenRevocation.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ITokenRevocationService interface {
+	RevokeUserTokens(ctx context.Context, actor string, userID int, reason string) (int, error)
+	RevokeTokenID(ctx context.Context, actor, tokenID, reason string) (int, error)
+	RevokeTokens(ctx context.Context, actor string, req models.RevokeTokensRequest) (int, error)
+	GetRevokedTokens(ctx context.Context, req models.RevokedTokenListRequest) (pagination.Page[models.RevokedToken], error)
+	DeleteRevokedToken(ctx context.Context, actor, key string) error
+}
+
+type ITokenRevocationHandler interface {
+	GetRevokedTokens(c *gin.Context)
+	RevokeTokens(c *gin.Context)
+	DeleteRevokedToken(c *gin.Context)
+}
@@ -1,16 +1,22 @@
 package interfaces
 
+//go:generate mockgen -source=ITokenValidation.go -destination=../mocks/mock_ITokenValidation.go -package=mocks
+
 import (
 	"context"
 
 	"ewallet-ums/cmd/proto/tokenvalidation"
 	"ewallet-ums/helpers"
+
+	"github.com/gin-gonic/gin"
 )
 
 type ITokenValidationHandler interface {
 	ValidateToken(ctx context.Context, req *tokenvalidation.TokenRequest) (*tokenvalidation.TokenResponse, error)
+	ValidateTokenHTTP(c *gin.Context)
 }
 
 type ITokenValidationService interface {
 	TokenValidation(ctx context.Context, token string) (*helpers.ClaimToken, error)
+	TokenValidationForAudience(ctx context.Context, token, audience string) (*helpers.ClaimToken, error)
 }
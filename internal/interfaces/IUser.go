@@ -1,17 +1,30 @@
 package interfaces
 
+//go:generate mockgen -source=IUser.go -destination=../mocks/mock_IUser.go -package=mocks
+
 import (
 	"context"
 
 	"ewallet-ums/internal/models"
 )
 
-type IUserRepository interface {
-	InsertNewUser(ctx context.Context, user *models.User) error
+// IUserReader looks users up, the part of the user repository that could be
+// cached or served from a replica.
+type IUserReader interface {
 	GetUserByUsername(ctx context.Context, username string) (models.User, error)
-	InsertNewUserSession(ctx context.Context, session *models.UserSession) error
-	DeleteUserSession(ctx context.Context, token string) error
-	GetUserSessionByToken(ctx context.Context, token string) (models.UserSession, error)
-	UpdateTokenByRefreshToken(ctx context.Context, token, refreshToken string) error
-	GetUserSessionByRefreshToken(ctx context.Context, refreshToken string) (models.UserSession, error)
+	GetUserByIdentifier(ctx context.Context, username, email, phoneNumber string) (models.User, error)
+	GetUserByID(ctx context.Context, userID int) (models.User, error)
+}
+
+type IUserWriter interface {
+	InsertNewUser(ctx context.Context, user *models.User) error
+	UpdateUser(ctx context.Context, userID, version int, user models.User) (bool, error)
+	UpdateUserPassword(ctx context.Context, userID int, password string, changeRequired bool) error
+}
+
+// IUserRepository is for services that both read and write users, sessions
+// are in ISessionRepository.
+type IUserRepository interface {
+	IUserReader
+	IUserWriter
 }
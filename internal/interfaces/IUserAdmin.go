@@ -0,0 +1,34 @@
+package interfaces
+
+//go:generate mockgen -source=IUserAdmin.go -destination=../mocks/mock_IUserAdmin.go -package=mocks
+
+import (
+	"context"
+
+	"ewallet-ums/cmd/proto/useradmin"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IUserAdminService interface {
+	GetUsers(ctx context.Context, req models.AdminUserListRequest) (pagination.Page[models.AdminUserSummary], error)
+	SuspendUser(ctx context.Context, actor string, userID, version int, reason string) (int, error)
+	RequestSuspension(ctx context.Context, actor string, userID, version int, reason string) (models.AdminApproval, error)
+	RequestRole(ctx context.Context, actor string, userID int, role, reason string) (models.AdminApproval, error)
+	ForceLogout(ctx context.Context, actor string, userID int, reason string) (int, error)
+	CreateAdmin(ctx context.Context, actor string, req models.CreateAdminRequest) (models.User, string, error)
+}
+
+type IUserAdminHandler interface {
+	SuspendUser(ctx context.Context, req *useradmin.SuspendUserRequest) (*useradmin.UserAdminResponse, error)
+	AssignRole(ctx context.Context, req *useradmin.AssignRoleRequest) (*useradmin.UserAdminResponse, error)
+	ForceLogout(ctx context.Context, req *useradmin.ForceLogoutRequest) (*useradmin.UserAdminResponse, error)
+}
+
+// IAdminUserHandler exposes UserAdminService actions on the HTTP admin API.
+type IAdminUserHandler interface {
+	GetUsers(c *gin.Context)
+	ForceLogout(c *gin.Context)
+}
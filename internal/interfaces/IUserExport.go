@@ -0,0 +1,33 @@
+package interfaces
+
+//go:generate mockgen -source=IUserExport.go -destination=../mocks/mock_IUserExport.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IUserExportRepository interface {
+	InsertUserExport(ctx context.Context, export *models.UserExport) error
+	GetUserExportByID(ctx context.Context, exportID int) (models.UserExport, error)
+	GetPendingUserExports(ctx context.Context, staleBefore time.Time, limit int) ([]models.UserExport, error)
+	ClaimUserExport(ctx context.Context, export *models.UserExport, now time.Time) (bool, error)
+	UpdateUserExport(ctx context.Context, export *models.UserExport) error
+	GetUsersForExport(ctx context.Context, export models.UserExport, afterID, limit int) ([]models.User, error)
+}
+
+type IUserExportService interface {
+	RequestExport(ctx context.Context, requestedBy int, req models.UserExportRequest) (models.UserExport, error)
+	GetExport(ctx context.Context, exportID, requestedBy int) (models.UserExport, error)
+	ProcessPendingExports(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
+
+type IUserExportHandler interface {
+	RequestExport(c *gin.Context)
+	GetExport(c *gin.Context)
+}
@@ -0,0 +1,33 @@
+package interfaces
+
+//go:generate mockgen -source=IUserImport.go -destination=../mocks/mock_IUserImport.go -package=mocks
+
+import (
+	"context"
+	"io"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IUserImportRepository interface {
+	InsertUserImport(ctx context.Context, job *models.UserImport) error
+	UpdateUserImport(ctx context.Context, job *models.UserImport) error
+	GetUserImportByID(ctx context.Context, importID int) (models.UserImport, error)
+	GetUserIDByExternalID(ctx context.Context, externalID string) (int, error)
+	UsernameExists(ctx context.Context, username string) (bool, error)
+	InsertImportedUser(ctx context.Context, user *models.User, provisioning *models.WalletProvisioning) error
+}
+
+type IUserImportService interface {
+	CreateImport(ctx context.Context, actor, format string, dryRun bool) (models.UserImport, error)
+	RunImport(ctx context.Context, job *models.UserImport, r io.Reader) error
+	StartImport(ctx context.Context, actor, format string, dryRun bool, data []byte) (models.UserImport, error)
+	GetImport(ctx context.Context, importID int) (models.UserImport, error)
+}
+
+type IUserImportHandler interface {
+	ImportUsers(c *gin.Context)
+	GetImport(c *gin.Context)
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+//go:generate mockgen -source=IUserPurge.go -destination=../mocks/mock_IUserPurge.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+)
+
+type IUserPurgeRepository interface {
+	InsertUserPurge(ctx context.Context, purge *models.UserPurge) error
+	GetPendingUserPurges(ctx context.Context, afterID, limit int) ([]models.UserPurge, error)
+	PurgeUser(ctx context.Context, purge models.UserPurge, now time.Time) (*models.User, error)
+	RecordUserPurgeFailure(ctx context.Context, purgeID int, reason string) error
+}
+
+type IUserPurgeService interface {
+	CheckPurge(ctx context.Context, userID int) error
+	SchedulePurge(ctx context.Context, approval models.AdminApproval) error
+	PurgeUsers(ctx context.Context, now time.Time) (int, error)
+	Run(ctx context.Context)
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+//go:generate mockgen -source=IUserQuota.go -destination=../mocks/mock_IUserQuota.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IUserQuotaRepository interface {
+	IncrUserQuota(ctx context.Context, name string, userID int, day, resetAt time.Time) (int64, error)
+	GetUserQuotaCounts(ctx context.Context, names []string, userID int, day time.Time) (map[string]int64, error)
+}
+
+type IUserQuotaService interface {
+	ConsumeQuota(ctx context.Context, userID int, name string, now time.Time) (models.QuotaUsage, error)
+	GetQuotaUsage(ctx context.Context, userID int, now time.Time) ([]models.QuotaUsage, error)
+}
+
+type IUserQuotaHandler interface {
+	GetQuotaUsage(c *gin.Context)
+}
@@ -0,0 +1,47 @@
+package interfaces
+
+//go:generate mockgen -source=IWalletProvisioning.go -destination=../mocks/mock_IWalletProvisioning.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IWalletProvisioningRepository interface {
+	InsertWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning) error
+	InsertUserWithWalletProvisioning(ctx context.Context, user *models.User, provisioning *models.WalletProvisioning) error
+	GetWalletProvisioningByUserID(ctx context.Context, userID int) (models.WalletProvisioning, error)
+	GetPendingWalletProvisionings(ctx context.Context, now time.Time, limit int) ([]models.WalletProvisioning, error)
+	ClaimWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning, lockUntil time.Time) (bool, error)
+	UpdateWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning) error
+	GetStuckWalletProvisionings(ctx context.Context, createdBefore time.Time, cursor *pagination.Cursor, limit int) ([]models.WalletProvisioning, error)
+	CountStuckWalletProvisionings(ctx context.Context, createdBefore time.Time) (map[string]int64, error)
+	RetryWalletProvisioning(ctx context.Context, userID int, createdBefore, now time.Time) (bool, error)
+}
+
+type IWalletProvisioningService interface {
+	GetWalletStatus(ctx context.Context, userID int) (models.WalletProvisioning, error)
+	ProcessPendingWallets(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
+
+type IWalletStatusHandler interface {
+	GetWalletStatus(c *gin.Context)
+}
+
+type IWalletReconciliationService interface {
+	GetReport(ctx context.Context, req pagination.Request) (models.WalletReconciliationReport, error)
+	RetryProvisioning(ctx context.Context, actor string, userID int) error
+	Reconcile(ctx context.Context) (models.WalletReconciliationReport, error)
+	Run(ctx context.Context)
+}
+
+type IWalletReconciliationHandler interface {
+	GetReport(c *gin.Context)
+	RetryProvisioning(c *gin.Context)
+}
@@ -0,0 +1,99 @@
+// Package locker runs jobs on exactly one instance of a multi-replica
+// deployment, under a lock held in redis or a MySQL named lock.
+package locker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+)
+
+var ErrLockLost = apperr.New(apperr.Conflict, "lock was lost")
+
+// RunSingleton runs run on this instance while it holds the lock name, until
+// ctx is done. Instances without the lock try again every third of ttl, so a
+// crashed holder is replaced about ttl after it stopped refreshing. When the
+// lock is lost, run's context is cancelled and RunSingleton goes back to
+// waiting for it.
+//
+// run is expected to loop until its context is done, like the workers' Run.
+func RunSingleton(ctx context.Context, locker interfaces.ILocker, name string, ttl time.Duration, run func(ctx context.Context)) {
+	log := helpers.Logger
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		lock, ok, err := locker.TryLock(ctx, name, ttl)
+		if err != nil {
+			log.Errorf("failed to take job lock %s: %v", name, err)
+		} else if ok {
+			log.Infof("took job lock %s, running the job on this instance", name)
+			hold(ctx, lock, name, ttl, run)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// hold runs run and refreshes the lock until run returns or the lock can't
+// be refreshed before it would expire.
+func hold(ctx context.Context, lock interfaces.ILock, name string, ttl time.Duration, run func(ctx context.Context)) {
+	log := helpers.Logger
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	helpers.JobLocksHeld.WithLabelValues(name).Set(1)
+	defer helpers.JobLocksHeld.WithLabelValues(name).Set(0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(jobCtx)
+	}()
+
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+	refreshed := time.Now()
+
+	for {
+		select {
+		case <-done:
+			unlock(ctx, lock, name)
+			return
+		case <-ticker.C:
+		}
+
+		err := lock.Refresh(jobCtx)
+		if err == nil {
+			refreshed = time.Now()
+			continue
+		}
+		// a failed refresh is retried while the lock still has a third of
+		// its ttl left, stopping before then leaves time for run to return
+		// before another instance can take over
+		if !errors.Is(err, ErrLockLost) && time.Since(refreshed) < ttl*2/3 {
+			log.Warnf("failed to refresh job lock %s, retrying: %v", name, err)
+			continue
+		}
+
+		log.Errorf("lost job lock %s, stopping the job on this instance: %v", name, err)
+		cancel()
+		<-done
+		unlock(ctx, lock, name)
+		return
+	}
+}
+
+func unlock(ctx context.Context, lock interfaces.ILock, name string) {
+	if err := lock.Unlock(context.WithoutCancel(ctx)); err != nil {
+		helpers.Logger.Errorf("failed to release job lock %s: %v", name, err)
+	}
+}
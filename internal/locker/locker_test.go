@@ -0,0 +1,94 @@
+package locker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeLocker hands out the lock while free is set, its locks are lost once
+// free is set again.
+type fakeLocker struct {
+	free atomic.Bool
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (interfaces.ILock, bool, error) {
+	if !l.free.CompareAndSwap(true, false) {
+		return nil, false, nil
+	}
+	return fakeLock{l}, true, nil
+}
+
+type fakeLock struct {
+	locker *fakeLocker
+}
+
+func (l fakeLock) Refresh(ctx context.Context) error {
+	if l.locker.free.Load() {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (l fakeLock) Unlock(ctx context.Context) error {
+	return nil
+}
+
+func TestRunSingleton(t *testing.T) {
+	helpers.Logger = logrus.New()
+	helpers.Logger.SetLevel(logrus.PanicLevel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	locker := &fakeLocker{}
+	runs := make(chan context.Context)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunSingleton(ctx, locker, "test", 30*time.Millisecond, func(ctx context.Context) {
+			runs <- ctx
+			<-ctx.Done()
+		})
+	}()
+
+	select {
+	case <-runs:
+		t.Fatal("expected the job not to run while another instance holds the lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	locker.free.Store(true)
+	var jobCtx context.Context
+	select {
+	case jobCtx = <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to run once the lock is free")
+	}
+
+	// the lock expired and was freed for another instance
+	locker.free.Store(true)
+	select {
+	case <-jobCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to stop once the lock is lost")
+	}
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to run again when the lock is taken back")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected RunSingleton to return when its context is done")
+	}
+}
@@ -0,0 +1,69 @@
+package locker
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+
+	"gorm.io/gorm"
+)
+
+// MySQLLocker holds a lock with GET_LOCK on a connection set aside for it.
+// MySQL releases the lock when the connection closes, so a crashed holder
+// frees it straight away and the ttl isn't used. Lock names are server-wide,
+// they are prefixed with the database name so deployments sharing a server
+// don't contend.
+type MySQLLocker struct {
+	DB *gorm.DB
+}
+
+func (l *MySQLLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (interfaces.ILock, bool, error) {
+	sqlDB, err := l.DB.DB()
+	if err != nil {
+		return nil, false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, apperr.Wrap(err, "failed to get a connection for the lock")
+	}
+
+	lock := &mysqlLock{conn: conn, name: constants.JobLockKeyPrefix + name}
+	// GET_LOCK returns 1 when taken, 0 when held elsewhere
+	var taken sql.NullInt64
+	err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(CONCAT(DATABASE(), ':', ?), 0)", lock.name).Scan(&taken)
+	if err != nil || taken.Int64 != 1 {
+		conn.Close()
+		return nil, false, err
+	}
+	return lock, true, nil
+}
+
+type mysqlLock struct {
+	conn *sql.Conn
+	name string
+}
+
+// Refresh checks the lock is still held by its connection. A failing
+// connection may already have been closed by the server, releasing the
+// lock, so any error means it is lost.
+func (l *mysqlLock) Refresh(ctx context.Context) error {
+	var held sql.NullInt64
+	err := l.conn.QueryRowContext(ctx, "SELECT IS_USED_LOCK(CONCAT(DATABASE(), ':', ?)) = CONNECTION_ID()", l.name).Scan(&held)
+	if err != nil {
+		return apperr.Wrapf(ErrLockLost, "failed to check the lock: %v", err)
+	}
+	if held.Int64 != 1 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (l *mysqlLock) Unlock(ctx context.Context) error {
+	defer l.conn.Close()
+	_, err := l.conn.ExecContext(ctx, "DO RELEASE_LOCK(CONCAT(DATABASE(), ':', ?))", l.name)
+	return err
+}
@@ -0,0 +1,70 @@
+package locker
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/interfaces"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// refreshScript and unlockScript only touch the key while it still holds
+// the lock's token, so an expired lock taken by another instance is left
+// alone.
+var (
+	refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// RedisLocker holds a lock as a key with a random token that expires after
+// the lock's ttl unless refreshed.
+type RedisLocker struct {
+	Redis *redis.Client
+}
+
+func (l *RedisLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (interfaces.ILock, bool, error) {
+	lock := &redisLock{
+		redis: l.Redis,
+		key:   constants.JobLockKeyPrefix + name,
+		token: rand.Text(),
+		ttl:   ttl,
+	}
+	ok, err := l.Redis.SetNX(ctx, lock.key, lock.token, ttl).Result()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return lock, true, nil
+}
+
+type redisLock struct {
+	redis *redis.Client
+	key   string
+	token string
+	ttl   time.Duration
+}
+
+func (l *redisLock) Refresh(ctx context.Context) error {
+	refreshed, err := refreshScript.Run(ctx, l.redis, []string{l.key}, l.token, l.ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if refreshed == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (l *redisLock) Unlock(ctx context.Context) error {
+	return unlockScript.Run(ctx, l.redis, []string{l.key}, l.token).Err()
+}
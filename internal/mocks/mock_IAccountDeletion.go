@@ -0,0 +1,220 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IAccountDeletion.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IAccountDeletion.go -destination=../mocks/mock_IAccountDeletion.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAccountDeletionRepository is a mock of IAccountDeletionRepository interface.
+type MockIAccountDeletionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountDeletionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountDeletionRepositoryMockRecorder is the mock recorder for MockIAccountDeletionRepository.
+type MockIAccountDeletionRepositoryMockRecorder struct {
+	mock *MockIAccountDeletionRepository
+}
+
+// NewMockIAccountDeletionRepository creates a new mock instance.
+func NewMockIAccountDeletionRepository(ctrl *gomock.Controller) *MockIAccountDeletionRepository {
+	mock := &MockIAccountDeletionRepository{ctrl: ctrl}
+	mock.recorder = &MockIAccountDeletionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountDeletionRepository) EXPECT() *MockIAccountDeletionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelUserDeletion mocks base method.
+func (m *MockIAccountDeletionRepository) CancelUserDeletion(ctx context.Context, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUserDeletion", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUserDeletion indicates an expected call of CancelUserDeletion.
+func (mr *MockIAccountDeletionRepositoryMockRecorder) CancelUserDeletion(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserDeletion", reflect.TypeOf((*MockIAccountDeletionRepository)(nil).CancelUserDeletion), ctx, userID)
+}
+
+// DeleteUser mocks base method.
+func (m *MockIAccountDeletionRepository) DeleteUser(ctx context.Context, userID int, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockIAccountDeletionRepositoryMockRecorder) DeleteUser(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIAccountDeletionRepository)(nil).DeleteUser), ctx, userID, now)
+}
+
+// GetUsersDueForDeletion mocks base method.
+func (m *MockIAccountDeletionRepository) GetUsersDueForDeletion(ctx context.Context, now time.Time, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersDueForDeletion", ctx, now, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersDueForDeletion indicates an expected call of GetUsersDueForDeletion.
+func (mr *MockIAccountDeletionRepositoryMockRecorder) GetUsersDueForDeletion(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersDueForDeletion", reflect.TypeOf((*MockIAccountDeletionRepository)(nil).GetUsersDueForDeletion), ctx, now, limit)
+}
+
+// ScheduleUserDeletion mocks base method.
+func (m *MockIAccountDeletionRepository) ScheduleUserDeletion(ctx context.Context, userID, version int, requestedAt, scheduledAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleUserDeletion", ctx, userID, version, requestedAt, scheduledAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleUserDeletion indicates an expected call of ScheduleUserDeletion.
+func (mr *MockIAccountDeletionRepositoryMockRecorder) ScheduleUserDeletion(ctx, userID, version, requestedAt, scheduledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleUserDeletion", reflect.TypeOf((*MockIAccountDeletionRepository)(nil).ScheduleUserDeletion), ctx, userID, version, requestedAt, scheduledAt)
+}
+
+// MockIAccountDeletionService is a mock of IAccountDeletionService interface.
+type MockIAccountDeletionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountDeletionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountDeletionServiceMockRecorder is the mock recorder for MockIAccountDeletionService.
+type MockIAccountDeletionServiceMockRecorder struct {
+	mock *MockIAccountDeletionService
+}
+
+// NewMockIAccountDeletionService creates a new mock instance.
+func NewMockIAccountDeletionService(ctrl *gomock.Controller) *MockIAccountDeletionService {
+	mock := &MockIAccountDeletionService{ctrl: ctrl}
+	mock.recorder = &MockIAccountDeletionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountDeletionService) EXPECT() *MockIAccountDeletionServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelDeletion mocks base method.
+func (m *MockIAccountDeletionService) CancelDeletion(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDeletion", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelDeletion indicates an expected call of CancelDeletion.
+func (mr *MockIAccountDeletionServiceMockRecorder) CancelDeletion(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDeletion", reflect.TypeOf((*MockIAccountDeletionService)(nil).CancelDeletion), ctx, userID)
+}
+
+// DeleteDueAccounts mocks base method.
+func (m *MockIAccountDeletionService) DeleteDueAccounts(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDueAccounts", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDueAccounts indicates an expected call of DeleteDueAccounts.
+func (mr *MockIAccountDeletionServiceMockRecorder) DeleteDueAccounts(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDueAccounts", reflect.TypeOf((*MockIAccountDeletionService)(nil).DeleteDueAccounts), ctx, now)
+}
+
+// RequestDeletion mocks base method.
+func (m *MockIAccountDeletionService) RequestDeletion(ctx context.Context, userID int, req models.AccountDeletionRequest) (models.AccountDeletionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDeletion", ctx, userID, req)
+	ret0, _ := ret[0].(models.AccountDeletionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDeletion indicates an expected call of RequestDeletion.
+func (mr *MockIAccountDeletionServiceMockRecorder) RequestDeletion(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDeletion", reflect.TypeOf((*MockIAccountDeletionService)(nil).RequestDeletion), ctx, userID, req)
+}
+
+// Run mocks base method.
+func (m *MockIAccountDeletionService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIAccountDeletionServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIAccountDeletionService)(nil).Run), ctx)
+}
+
+// MockIAccountDeletionHandler is a mock of IAccountDeletionHandler interface.
+type MockIAccountDeletionHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountDeletionHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountDeletionHandlerMockRecorder is the mock recorder for MockIAccountDeletionHandler.
+type MockIAccountDeletionHandlerMockRecorder struct {
+	mock *MockIAccountDeletionHandler
+}
+
+// NewMockIAccountDeletionHandler creates a new mock instance.
+func NewMockIAccountDeletionHandler(ctrl *gomock.Controller) *MockIAccountDeletionHandler {
+	mock := &MockIAccountDeletionHandler{ctrl: ctrl}
+	mock.recorder = &MockIAccountDeletionHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountDeletionHandler) EXPECT() *MockIAccountDeletionHandlerMockRecorder {
+	return m.recorder
+}
+
+// RequestDeletion mocks base method.
+func (m *MockIAccountDeletionHandler) RequestDeletion(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestDeletion", c)
+}
+
+// RequestDeletion indicates an expected call of RequestDeletion.
+func (mr *MockIAccountDeletionHandlerMockRecorder) RequestDeletion(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDeletion", reflect.TypeOf((*MockIAccountDeletionHandler)(nil).RequestDeletion), c)
+}
@@ -0,0 +1,271 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IAccountNote.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IAccountNote.go -destination=../mocks/mock_IAccountNote.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAccountNoteRepository is a mock of IAccountNoteRepository interface.
+type MockIAccountNoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountNoteRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountNoteRepositoryMockRecorder is the mock recorder for MockIAccountNoteRepository.
+type MockIAccountNoteRepositoryMockRecorder struct {
+	mock *MockIAccountNoteRepository
+}
+
+// NewMockIAccountNoteRepository creates a new mock instance.
+func NewMockIAccountNoteRepository(ctrl *gomock.Controller) *MockIAccountNoteRepository {
+	mock := &MockIAccountNoteRepository{ctrl: ctrl}
+	mock.recorder = &MockIAccountNoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountNoteRepository) EXPECT() *MockIAccountNoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccountNote mocks base method.
+func (m *MockIAccountNoteRepository) DeleteAccountNote(ctx context.Context, noteID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountNote", ctx, noteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccountNote indicates an expected call of DeleteAccountNote.
+func (mr *MockIAccountNoteRepositoryMockRecorder) DeleteAccountNote(ctx, noteID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountNote", reflect.TypeOf((*MockIAccountNoteRepository)(nil).DeleteAccountNote), ctx, noteID)
+}
+
+// GetAccountNoteByID mocks base method.
+func (m *MockIAccountNoteRepository) GetAccountNoteByID(ctx context.Context, noteID int) (models.AccountNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountNoteByID", ctx, noteID)
+	ret0, _ := ret[0].(models.AccountNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountNoteByID indicates an expected call of GetAccountNoteByID.
+func (mr *MockIAccountNoteRepositoryMockRecorder) GetAccountNoteByID(ctx, noteID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountNoteByID", reflect.TypeOf((*MockIAccountNoteRepository)(nil).GetAccountNoteByID), ctx, noteID)
+}
+
+// GetAccountNotes mocks base method.
+func (m *MockIAccountNoteRepository) GetAccountNotes(ctx context.Context, userID int, visibilities []string, cursor *pagination.Cursor, limit int) ([]models.AccountNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountNotes", ctx, userID, visibilities, cursor, limit)
+	ret0, _ := ret[0].([]models.AccountNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountNotes indicates an expected call of GetAccountNotes.
+func (mr *MockIAccountNoteRepositoryMockRecorder) GetAccountNotes(ctx, userID, visibilities, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountNotes", reflect.TypeOf((*MockIAccountNoteRepository)(nil).GetAccountNotes), ctx, userID, visibilities, cursor, limit)
+}
+
+// InsertAccountNote mocks base method.
+func (m *MockIAccountNoteRepository) InsertAccountNote(ctx context.Context, note *models.AccountNote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccountNote", ctx, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAccountNote indicates an expected call of InsertAccountNote.
+func (mr *MockIAccountNoteRepositoryMockRecorder) InsertAccountNote(ctx, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountNote", reflect.TypeOf((*MockIAccountNoteRepository)(nil).InsertAccountNote), ctx, note)
+}
+
+// UpdateAccountNote mocks base method.
+func (m *MockIAccountNoteRepository) UpdateAccountNote(ctx context.Context, note *models.AccountNote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountNote", ctx, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountNote indicates an expected call of UpdateAccountNote.
+func (mr *MockIAccountNoteRepositoryMockRecorder) UpdateAccountNote(ctx, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountNote", reflect.TypeOf((*MockIAccountNoteRepository)(nil).UpdateAccountNote), ctx, note)
+}
+
+// MockIAccountNoteService is a mock of IAccountNoteService interface.
+type MockIAccountNoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountNoteServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountNoteServiceMockRecorder is the mock recorder for MockIAccountNoteService.
+type MockIAccountNoteServiceMockRecorder struct {
+	mock *MockIAccountNoteService
+}
+
+// NewMockIAccountNoteService creates a new mock instance.
+func NewMockIAccountNoteService(ctrl *gomock.Controller) *MockIAccountNoteService {
+	mock := &MockIAccountNoteService{ctrl: ctrl}
+	mock.recorder = &MockIAccountNoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountNoteService) EXPECT() *MockIAccountNoteServiceMockRecorder {
+	return m.recorder
+}
+
+// AddNote mocks base method.
+func (m *MockIAccountNoteService) AddNote(ctx context.Context, adminID, userID int, req models.AccountNoteRequest) (models.AccountNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNote", ctx, adminID, userID, req)
+	ret0, _ := ret[0].(models.AccountNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNote indicates an expected call of AddNote.
+func (mr *MockIAccountNoteServiceMockRecorder) AddNote(ctx, adminID, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockIAccountNoteService)(nil).AddNote), ctx, adminID, userID, req)
+}
+
+// DeleteNote mocks base method.
+func (m *MockIAccountNoteService) DeleteNote(ctx context.Context, adminID, noteID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, adminID, noteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockIAccountNoteServiceMockRecorder) DeleteNote(ctx, adminID, noteID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockIAccountNoteService)(nil).DeleteNote), ctx, adminID, noteID)
+}
+
+// GetNotes mocks base method.
+func (m *MockIAccountNoteService) GetNotes(ctx context.Context, adminID, userID int, req models.AccountNoteListRequest) (pagination.Page[models.AccountNote], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotes", ctx, adminID, userID, req)
+	ret0, _ := ret[0].(pagination.Page[models.AccountNote])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotes indicates an expected call of GetNotes.
+func (mr *MockIAccountNoteServiceMockRecorder) GetNotes(ctx, adminID, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotes", reflect.TypeOf((*MockIAccountNoteService)(nil).GetNotes), ctx, adminID, userID, req)
+}
+
+// UpdateNote mocks base method.
+func (m *MockIAccountNoteService) UpdateNote(ctx context.Context, adminID, noteID int, req models.AccountNoteRequest) (models.AccountNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, adminID, noteID, req)
+	ret0, _ := ret[0].(models.AccountNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNote indicates an expected call of UpdateNote.
+func (mr *MockIAccountNoteServiceMockRecorder) UpdateNote(ctx, adminID, noteID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockIAccountNoteService)(nil).UpdateNote), ctx, adminID, noteID, req)
+}
+
+// MockIAccountNoteHandler is a mock of IAccountNoteHandler interface.
+type MockIAccountNoteHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountNoteHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountNoteHandlerMockRecorder is the mock recorder for MockIAccountNoteHandler.
+type MockIAccountNoteHandlerMockRecorder struct {
+	mock *MockIAccountNoteHandler
+}
+
+// NewMockIAccountNoteHandler creates a new mock instance.
+func NewMockIAccountNoteHandler(ctrl *gomock.Controller) *MockIAccountNoteHandler {
+	mock := &MockIAccountNoteHandler{ctrl: ctrl}
+	mock.recorder = &MockIAccountNoteHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountNoteHandler) EXPECT() *MockIAccountNoteHandlerMockRecorder {
+	return m.recorder
+}
+
+// AddNote mocks base method.
+func (m *MockIAccountNoteHandler) AddNote(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddNote", c)
+}
+
+// AddNote indicates an expected call of AddNote.
+func (mr *MockIAccountNoteHandlerMockRecorder) AddNote(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockIAccountNoteHandler)(nil).AddNote), c)
+}
+
+// DeleteNote mocks base method.
+func (m *MockIAccountNoteHandler) DeleteNote(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteNote", c)
+}
+
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockIAccountNoteHandlerMockRecorder) DeleteNote(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockIAccountNoteHandler)(nil).DeleteNote), c)
+}
+
+// GetNotes mocks base method.
+func (m *MockIAccountNoteHandler) GetNotes(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetNotes", c)
+}
+
+// GetNotes indicates an expected call of GetNotes.
+func (mr *MockIAccountNoteHandlerMockRecorder) GetNotes(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotes", reflect.TypeOf((*MockIAccountNoteHandler)(nil).GetNotes), c)
+}
+
+// UpdateNote mocks base method.
+func (m *MockIAccountNoteHandler) UpdateNote(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateNote", c)
+}
+
+// UpdateNote indicates an expected call of UpdateNote.
+func (mr *MockIAccountNoteHandlerMockRecorder) UpdateNote(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockIAccountNoteHandler)(nil).UpdateNote), c)
+}
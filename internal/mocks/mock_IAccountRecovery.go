@@ -0,0 +1,344 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IAccountRecovery.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IAccountRecovery.go -destination=../mocks/mock_IAccountRecovery.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAccountRecoveryRepository is a mock of IAccountRecoveryRepository interface.
+type MockIAccountRecoveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountRecoveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountRecoveryRepositoryMockRecorder is the mock recorder for MockIAccountRecoveryRepository.
+type MockIAccountRecoveryRepositoryMockRecorder struct {
+	mock *MockIAccountRecoveryRepository
+}
+
+// NewMockIAccountRecoveryRepository creates a new mock instance.
+func NewMockIAccountRecoveryRepository(ctrl *gomock.Controller) *MockIAccountRecoveryRepository {
+	mock := &MockIAccountRecoveryRepository{ctrl: ctrl}
+	mock.recorder = &MockIAccountRecoveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountRecoveryRepository) EXPECT() *MockIAccountRecoveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CompleteRecoveryReset mocks base method.
+func (m *MockIAccountRecoveryRepository) CompleteRecoveryReset(ctx context.Context, ticket *models.RecoveryTicket, version int, password string, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRecoveryReset", ctx, ticket, version, password, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteRecoveryReset indicates an expected call of CompleteRecoveryReset.
+func (mr *MockIAccountRecoveryRepositoryMockRecorder) CompleteRecoveryReset(ctx, ticket, version, password, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRecoveryReset", reflect.TypeOf((*MockIAccountRecoveryRepository)(nil).CompleteRecoveryReset), ctx, ticket, version, password, now)
+}
+
+// GetRecoveryTicketByID mocks base method.
+func (m *MockIAccountRecoveryRepository) GetRecoveryTicketByID(ctx context.Context, ticketID int) (models.RecoveryTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecoveryTicketByID", ctx, ticketID)
+	ret0, _ := ret[0].(models.RecoveryTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecoveryTicketByID indicates an expected call of GetRecoveryTicketByID.
+func (mr *MockIAccountRecoveryRepositoryMockRecorder) GetRecoveryTicketByID(ctx, ticketID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecoveryTicketByID", reflect.TypeOf((*MockIAccountRecoveryRepository)(nil).GetRecoveryTicketByID), ctx, ticketID)
+}
+
+// GetRecoveryTicketByResetToken mocks base method.
+func (m *MockIAccountRecoveryRepository) GetRecoveryTicketByResetToken(ctx context.Context, tokenHash string) (models.RecoveryTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecoveryTicketByResetToken", ctx, tokenHash)
+	ret0, _ := ret[0].(models.RecoveryTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecoveryTicketByResetToken indicates an expected call of GetRecoveryTicketByResetToken.
+func (mr *MockIAccountRecoveryRepositoryMockRecorder) GetRecoveryTicketByResetToken(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecoveryTicketByResetToken", reflect.TypeOf((*MockIAccountRecoveryRepository)(nil).GetRecoveryTicketByResetToken), ctx, tokenHash)
+}
+
+// GetRecoveryTickets mocks base method.
+func (m *MockIAccountRecoveryRepository) GetRecoveryTickets(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.RecoveryTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecoveryTickets", ctx, status, cursor, limit)
+	ret0, _ := ret[0].([]models.RecoveryTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecoveryTickets indicates an expected call of GetRecoveryTickets.
+func (mr *MockIAccountRecoveryRepositoryMockRecorder) GetRecoveryTickets(ctx, status, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecoveryTickets", reflect.TypeOf((*MockIAccountRecoveryRepository)(nil).GetRecoveryTickets), ctx, status, cursor, limit)
+}
+
+// InsertRecoveryTicket mocks base method.
+func (m *MockIAccountRecoveryRepository) InsertRecoveryTicket(ctx context.Context, ticket *models.RecoveryTicket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRecoveryTicket", ctx, ticket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRecoveryTicket indicates an expected call of InsertRecoveryTicket.
+func (mr *MockIAccountRecoveryRepositoryMockRecorder) InsertRecoveryTicket(ctx, ticket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRecoveryTicket", reflect.TypeOf((*MockIAccountRecoveryRepository)(nil).InsertRecoveryTicket), ctx, ticket)
+}
+
+// UpdateRecoveryTicketStatus mocks base method.
+func (m *MockIAccountRecoveryRepository) UpdateRecoveryTicketStatus(ctx context.Context, ticket *models.RecoveryTicket, fromStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecoveryTicketStatus", ctx, ticket, fromStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecoveryTicketStatus indicates an expected call of UpdateRecoveryTicketStatus.
+func (mr *MockIAccountRecoveryRepositoryMockRecorder) UpdateRecoveryTicketStatus(ctx, ticket, fromStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecoveryTicketStatus", reflect.TypeOf((*MockIAccountRecoveryRepository)(nil).UpdateRecoveryTicketStatus), ctx, ticket, fromStatus)
+}
+
+// MockIAccountRecoveryService is a mock of IAccountRecoveryService interface.
+type MockIAccountRecoveryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountRecoveryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountRecoveryServiceMockRecorder is the mock recorder for MockIAccountRecoveryService.
+type MockIAccountRecoveryServiceMockRecorder struct {
+	mock *MockIAccountRecoveryService
+}
+
+// NewMockIAccountRecoveryService creates a new mock instance.
+func NewMockIAccountRecoveryService(ctrl *gomock.Controller) *MockIAccountRecoveryService {
+	mock := &MockIAccountRecoveryService{ctrl: ctrl}
+	mock.recorder = &MockIAccountRecoveryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountRecoveryService) EXPECT() *MockIAccountRecoveryServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckTicket mocks base method.
+func (m *MockIAccountRecoveryService) CheckTicket(ctx context.Context, ticketID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTicket", ctx, ticketID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckTicket indicates an expected call of CheckTicket.
+func (mr *MockIAccountRecoveryServiceMockRecorder) CheckTicket(ctx, ticketID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTicket", reflect.TypeOf((*MockIAccountRecoveryService)(nil).CheckTicket), ctx, ticketID, userID)
+}
+
+// CompleteReset mocks base method.
+func (m *MockIAccountRecoveryService) CompleteReset(ctx context.Context, req models.ManualRecoveryResetRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteReset", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteReset indicates an expected call of CompleteReset.
+func (mr *MockIAccountRecoveryServiceMockRecorder) CompleteReset(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteReset", reflect.TypeOf((*MockIAccountRecoveryService)(nil).CompleteReset), ctx, req)
+}
+
+// GetTicket mocks base method.
+func (m *MockIAccountRecoveryService) GetTicket(ctx context.Context, ticketID int) (models.RecoveryTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicket", ctx, ticketID)
+	ret0, _ := ret[0].(models.RecoveryTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicket indicates an expected call of GetTicket.
+func (mr *MockIAccountRecoveryServiceMockRecorder) GetTicket(ctx, ticketID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicket", reflect.TypeOf((*MockIAccountRecoveryService)(nil).GetTicket), ctx, ticketID)
+}
+
+// GetTickets mocks base method.
+func (m *MockIAccountRecoveryService) GetTickets(ctx context.Context, req models.RecoveryTicketListRequest) (pagination.Page[models.RecoveryTicket], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTickets", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.RecoveryTicket])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTickets indicates an expected call of GetTickets.
+func (mr *MockIAccountRecoveryServiceMockRecorder) GetTickets(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickets", reflect.TypeOf((*MockIAccountRecoveryService)(nil).GetTickets), ctx, req)
+}
+
+// IssueReset mocks base method.
+func (m *MockIAccountRecoveryService) IssueReset(ctx context.Context, ticketID, userID, approvedBy int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueReset", ctx, ticketID, userID, approvedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IssueReset indicates an expected call of IssueReset.
+func (mr *MockIAccountRecoveryServiceMockRecorder) IssueReset(ctx, ticketID, userID, approvedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueReset", reflect.TypeOf((*MockIAccountRecoveryService)(nil).IssueReset), ctx, ticketID, userID, approvedBy)
+}
+
+// RejectTicket mocks base method.
+func (m *MockIAccountRecoveryService) RejectTicket(ctx context.Context, ticketID, reviewedBy int, note string) (models.RecoveryTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectTicket", ctx, ticketID, reviewedBy, note)
+	ret0, _ := ret[0].(models.RecoveryTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectTicket indicates an expected call of RejectTicket.
+func (mr *MockIAccountRecoveryServiceMockRecorder) RejectTicket(ctx, ticketID, reviewedBy, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectTicket", reflect.TypeOf((*MockIAccountRecoveryService)(nil).RejectTicket), ctx, ticketID, reviewedBy, note)
+}
+
+// SubmitTicket mocks base method.
+func (m *MockIAccountRecoveryService) SubmitTicket(ctx context.Context, ipAddress string, req models.RecoveryTicketRequest, evidence []models.RecoveryEvidence) (models.RecoveryTicketReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitTicket", ctx, ipAddress, req, evidence)
+	ret0, _ := ret[0].(models.RecoveryTicketReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitTicket indicates an expected call of SubmitTicket.
+func (mr *MockIAccountRecoveryServiceMockRecorder) SubmitTicket(ctx, ipAddress, req, evidence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTicket", reflect.TypeOf((*MockIAccountRecoveryService)(nil).SubmitTicket), ctx, ipAddress, req, evidence)
+}
+
+// MockIAccountRecoveryHandler is a mock of IAccountRecoveryHandler interface.
+type MockIAccountRecoveryHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountRecoveryHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIAccountRecoveryHandlerMockRecorder is the mock recorder for MockIAccountRecoveryHandler.
+type MockIAccountRecoveryHandlerMockRecorder struct {
+	mock *MockIAccountRecoveryHandler
+}
+
+// NewMockIAccountRecoveryHandler creates a new mock instance.
+func NewMockIAccountRecoveryHandler(ctrl *gomock.Controller) *MockIAccountRecoveryHandler {
+	mock := &MockIAccountRecoveryHandler{ctrl: ctrl}
+	mock.recorder = &MockIAccountRecoveryHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountRecoveryHandler) EXPECT() *MockIAccountRecoveryHandlerMockRecorder {
+	return m.recorder
+}
+
+// CompleteReset mocks base method.
+func (m *MockIAccountRecoveryHandler) CompleteReset(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CompleteReset", c)
+}
+
+// CompleteReset indicates an expected call of CompleteReset.
+func (mr *MockIAccountRecoveryHandlerMockRecorder) CompleteReset(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteReset", reflect.TypeOf((*MockIAccountRecoveryHandler)(nil).CompleteReset), c)
+}
+
+// GetTicket mocks base method.
+func (m *MockIAccountRecoveryHandler) GetTicket(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetTicket", c)
+}
+
+// GetTicket indicates an expected call of GetTicket.
+func (mr *MockIAccountRecoveryHandlerMockRecorder) GetTicket(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicket", reflect.TypeOf((*MockIAccountRecoveryHandler)(nil).GetTicket), c)
+}
+
+// GetTickets mocks base method.
+func (m *MockIAccountRecoveryHandler) GetTickets(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetTickets", c)
+}
+
+// GetTickets indicates an expected call of GetTickets.
+func (mr *MockIAccountRecoveryHandlerMockRecorder) GetTickets(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickets", reflect.TypeOf((*MockIAccountRecoveryHandler)(nil).GetTickets), c)
+}
+
+// RejectTicket mocks base method.
+func (m *MockIAccountRecoveryHandler) RejectTicket(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RejectTicket", c)
+}
+
+// RejectTicket indicates an expected call of RejectTicket.
+func (mr *MockIAccountRecoveryHandlerMockRecorder) RejectTicket(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectTicket", reflect.TypeOf((*MockIAccountRecoveryHandler)(nil).RejectTicket), c)
+}
+
+// SubmitTicket mocks base method.
+func (m *MockIAccountRecoveryHandler) SubmitTicket(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SubmitTicket", c)
+}
+
+// SubmitTicket indicates an expected call of SubmitTicket.
+func (mr *MockIAccountRecoveryHandlerMockRecorder) SubmitTicket(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTicket", reflect.TypeOf((*MockIAccountRecoveryHandler)(nil).SubmitTicket), c)
+}
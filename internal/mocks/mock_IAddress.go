@@ -0,0 +1,344 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IAddress.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IAddress.go -destination=../mocks/mock_IAddress.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	user "ewallet-ums/cmd/proto/user"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAddressRepository is a mock of IAddressRepository interface.
+type MockIAddressRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAddressRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIAddressRepositoryMockRecorder is the mock recorder for MockIAddressRepository.
+type MockIAddressRepositoryMockRecorder struct {
+	mock *MockIAddressRepository
+}
+
+// NewMockIAddressRepository creates a new mock instance.
+func NewMockIAddressRepository(ctrl *gomock.Controller) *MockIAddressRepository {
+	mock := &MockIAddressRepository{ctrl: ctrl}
+	mock.recorder = &MockIAddressRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAddressRepository) EXPECT() *MockIAddressRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserAddress mocks base method.
+func (m *MockIAddressRepository) DeleteUserAddress(ctx context.Context, userID, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserAddress", ctx, userID, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserAddress indicates an expected call of DeleteUserAddress.
+func (mr *MockIAddressRepositoryMockRecorder) DeleteUserAddress(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAddress", reflect.TypeOf((*MockIAddressRepository)(nil).DeleteUserAddress), ctx, userID, id)
+}
+
+// GetUserAddress mocks base method.
+func (m *MockIAddressRepository) GetUserAddress(ctx context.Context, userID, id int) (models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAddress", ctx, userID, id)
+	ret0, _ := ret[0].(models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAddress indicates an expected call of GetUserAddress.
+func (mr *MockIAddressRepositoryMockRecorder) GetUserAddress(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddress", reflect.TypeOf((*MockIAddressRepository)(nil).GetUserAddress), ctx, userID, id)
+}
+
+// GetUserAddresses mocks base method.
+func (m *MockIAddressRepository) GetUserAddresses(ctx context.Context, userID int) ([]models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAddresses", ctx, userID)
+	ret0, _ := ret[0].([]models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAddresses indicates an expected call of GetUserAddresses.
+func (mr *MockIAddressRepositoryMockRecorder) GetUserAddresses(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddresses", reflect.TypeOf((*MockIAddressRepository)(nil).GetUserAddresses), ctx, userID)
+}
+
+// InsertUserAddress mocks base method.
+func (m *MockIAddressRepository) InsertUserAddress(ctx context.Context, address *models.UserAddress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserAddress", ctx, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserAddress indicates an expected call of InsertUserAddress.
+func (mr *MockIAddressRepositoryMockRecorder) InsertUserAddress(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserAddress", reflect.TypeOf((*MockIAddressRepository)(nil).InsertUserAddress), ctx, address)
+}
+
+// SetPrimaryUserAddress mocks base method.
+func (m *MockIAddressRepository) SetPrimaryUserAddress(ctx context.Context, userID, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimaryUserAddress", ctx, userID, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPrimaryUserAddress indicates an expected call of SetPrimaryUserAddress.
+func (mr *MockIAddressRepositoryMockRecorder) SetPrimaryUserAddress(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryUserAddress", reflect.TypeOf((*MockIAddressRepository)(nil).SetPrimaryUserAddress), ctx, userID, id)
+}
+
+// UpdateUserAddress mocks base method.
+func (m *MockIAddressRepository) UpdateUserAddress(ctx context.Context, address *models.UserAddress) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserAddress", ctx, address)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserAddress indicates an expected call of UpdateUserAddress.
+func (mr *MockIAddressRepositoryMockRecorder) UpdateUserAddress(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAddress", reflect.TypeOf((*MockIAddressRepository)(nil).UpdateUserAddress), ctx, address)
+}
+
+// MockIAddressService is a mock of IAddressService interface.
+type MockIAddressService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAddressServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIAddressServiceMockRecorder is the mock recorder for MockIAddressService.
+type MockIAddressServiceMockRecorder struct {
+	mock *MockIAddressService
+}
+
+// NewMockIAddressService creates a new mock instance.
+func NewMockIAddressService(ctrl *gomock.Controller) *MockIAddressService {
+	mock := &MockIAddressService{ctrl: ctrl}
+	mock.recorder = &MockIAddressServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAddressService) EXPECT() *MockIAddressServiceMockRecorder {
+	return m.recorder
+}
+
+// AddAddress mocks base method.
+func (m *MockIAddressService) AddAddress(ctx context.Context, userID int, req models.AddressRequest) (models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAddress", ctx, userID, req)
+	ret0, _ := ret[0].(models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAddress indicates an expected call of AddAddress.
+func (mr *MockIAddressServiceMockRecorder) AddAddress(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAddress", reflect.TypeOf((*MockIAddressService)(nil).AddAddress), ctx, userID, req)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockIAddressService) DeleteAddress(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockIAddressServiceMockRecorder) DeleteAddress(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockIAddressService)(nil).DeleteAddress), ctx, userID, id)
+}
+
+// GetAddresses mocks base method.
+func (m *MockIAddressService) GetAddresses(ctx context.Context, userID int) ([]models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", ctx, userID)
+	ret0, _ := ret[0].([]models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockIAddressServiceMockRecorder) GetAddresses(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockIAddressService)(nil).GetAddresses), ctx, userID)
+}
+
+// GetUserAddresses mocks base method.
+func (m *MockIAddressService) GetUserAddresses(ctx context.Context, userID int) ([]models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAddresses", ctx, userID)
+	ret0, _ := ret[0].([]models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAddresses indicates an expected call of GetUserAddresses.
+func (mr *MockIAddressServiceMockRecorder) GetUserAddresses(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddresses", reflect.TypeOf((*MockIAddressService)(nil).GetUserAddresses), ctx, userID)
+}
+
+// SetPrimaryAddress mocks base method.
+func (m *MockIAddressService) SetPrimaryAddress(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimaryAddress", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrimaryAddress indicates an expected call of SetPrimaryAddress.
+func (mr *MockIAddressServiceMockRecorder) SetPrimaryAddress(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryAddress", reflect.TypeOf((*MockIAddressService)(nil).SetPrimaryAddress), ctx, userID, id)
+}
+
+// UpdateAddress mocks base method.
+func (m *MockIAddressService) UpdateAddress(ctx context.Context, userID, id int, req models.AddressRequest) (models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAddress", ctx, userID, id, req)
+	ret0, _ := ret[0].(models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAddress indicates an expected call of UpdateAddress.
+func (mr *MockIAddressServiceMockRecorder) UpdateAddress(ctx, userID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddress", reflect.TypeOf((*MockIAddressService)(nil).UpdateAddress), ctx, userID, id, req)
+}
+
+// MockIAddressHandler is a mock of IAddressHandler interface.
+type MockIAddressHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAddressHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIAddressHandlerMockRecorder is the mock recorder for MockIAddressHandler.
+type MockIAddressHandlerMockRecorder struct {
+	mock *MockIAddressHandler
+}
+
+// NewMockIAddressHandler creates a new mock instance.
+func NewMockIAddressHandler(ctrl *gomock.Controller) *MockIAddressHandler {
+	mock := &MockIAddressHandler{ctrl: ctrl}
+	mock.recorder = &MockIAddressHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAddressHandler) EXPECT() *MockIAddressHandlerMockRecorder {
+	return m.recorder
+}
+
+// AddAddress mocks base method.
+func (m *MockIAddressHandler) AddAddress(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddAddress", c)
+}
+
+// AddAddress indicates an expected call of AddAddress.
+func (mr *MockIAddressHandlerMockRecorder) AddAddress(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAddress", reflect.TypeOf((*MockIAddressHandler)(nil).AddAddress), c)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockIAddressHandler) DeleteAddress(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteAddress", c)
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockIAddressHandlerMockRecorder) DeleteAddress(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockIAddressHandler)(nil).DeleteAddress), c)
+}
+
+// GetAddresses mocks base method.
+func (m *MockIAddressHandler) GetAddresses(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAddresses", c)
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockIAddressHandlerMockRecorder) GetAddresses(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockIAddressHandler)(nil).GetAddresses), c)
+}
+
+// GetUserAddresses mocks base method.
+func (m *MockIAddressHandler) GetUserAddresses(ctx context.Context, req *user.UserAddressesRequest) (*user.UserAddressesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAddresses", ctx, req)
+	ret0, _ := ret[0].(*user.UserAddressesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAddresses indicates an expected call of GetUserAddresses.
+func (mr *MockIAddressHandlerMockRecorder) GetUserAddresses(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddresses", reflect.TypeOf((*MockIAddressHandler)(nil).GetUserAddresses), ctx, req)
+}
+
+// SetPrimaryAddress mocks base method.
+func (m *MockIAddressHandler) SetPrimaryAddress(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPrimaryAddress", c)
+}
+
+// SetPrimaryAddress indicates an expected call of SetPrimaryAddress.
+func (mr *MockIAddressHandlerMockRecorder) SetPrimaryAddress(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryAddress", reflect.TypeOf((*MockIAddressHandler)(nil).SetPrimaryAddress), c)
+}
+
+// UpdateAddress mocks base method.
+func (m *MockIAddressHandler) UpdateAddress(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateAddress", c)
+}
+
+// UpdateAddress indicates an expected call of UpdateAddress.
+func (mr *MockIAddressHandlerMockRecorder) UpdateAddress(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddress", reflect.TypeOf((*MockIAddressHandler)(nil).UpdateAddress), c)
+}
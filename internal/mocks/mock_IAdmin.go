@@ -0,0 +1,347 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IAdmin.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IAdmin.go -destination=../mocks/mock_IAdmin.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAdminRepository is a mock of IAdminRepository interface.
+type MockIAdminRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAdminRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIAdminRepositoryMockRecorder is the mock recorder for MockIAdminRepository.
+type MockIAdminRepositoryMockRecorder struct {
+	mock *MockIAdminRepository
+}
+
+// NewMockIAdminRepository creates a new mock instance.
+func NewMockIAdminRepository(ctrl *gomock.Controller) *MockIAdminRepository {
+	mock := &MockIAdminRepository{ctrl: ctrl}
+	mock.recorder = &MockIAdminRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAdminRepository) EXPECT() *MockIAdminRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAdminApprovalByID mocks base method.
+func (m *MockIAdminRepository) GetAdminApprovalByID(ctx context.Context, approvalID int) (models.AdminApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminApprovalByID", ctx, approvalID)
+	ret0, _ := ret[0].(models.AdminApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminApprovalByID indicates an expected call of GetAdminApprovalByID.
+func (mr *MockIAdminRepositoryMockRecorder) GetAdminApprovalByID(ctx, approvalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminApprovalByID", reflect.TypeOf((*MockIAdminRepository)(nil).GetAdminApprovalByID), ctx, approvalID)
+}
+
+// GetAdminApprovals mocks base method.
+func (m *MockIAdminRepository) GetAdminApprovals(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.AdminApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminApprovals", ctx, status, cursor, limit)
+	ret0, _ := ret[0].([]models.AdminApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminApprovals indicates an expected call of GetAdminApprovals.
+func (mr *MockIAdminRepositoryMockRecorder) GetAdminApprovals(ctx, status, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminApprovals", reflect.TypeOf((*MockIAdminRepository)(nil).GetAdminApprovals), ctx, status, cursor, limit)
+}
+
+// GetUserRoles mocks base method.
+func (m *MockIAdminRepository) GetUserRoles(ctx context.Context, userID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockIAdminRepositoryMockRecorder) GetUserRoles(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockIAdminRepository)(nil).GetUserRoles), ctx, userID)
+}
+
+// GetUsers mocks base method.
+func (m *MockIAdminRepository) GetUsers(ctx context.Context, filter models.AdminUserFilter, cursor *pagination.Cursor, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, filter, cursor, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIAdminRepositoryMockRecorder) GetUsers(ctx, filter, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIAdminRepository)(nil).GetUsers), ctx, filter, cursor, limit)
+}
+
+// InsertAdminApproval mocks base method.
+func (m *MockIAdminRepository) InsertAdminApproval(ctx context.Context, approval *models.AdminApproval) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAdminApproval", ctx, approval)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAdminApproval indicates an expected call of InsertAdminApproval.
+func (mr *MockIAdminRepositoryMockRecorder) InsertAdminApproval(ctx, approval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAdminApproval", reflect.TypeOf((*MockIAdminRepository)(nil).InsertAdminApproval), ctx, approval)
+}
+
+// InsertUserRole mocks base method.
+func (m *MockIAdminRepository) InsertUserRole(ctx context.Context, role *models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserRole", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserRole indicates an expected call of InsertUserRole.
+func (mr *MockIAdminRepositoryMockRecorder) InsertUserRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserRole", reflect.TypeOf((*MockIAdminRepository)(nil).InsertUserRole), ctx, role)
+}
+
+// ReviewAdminApproval mocks base method.
+func (m *MockIAdminRepository) ReviewAdminApproval(ctx context.Context, approval *models.AdminApproval) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewAdminApproval", ctx, approval)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewAdminApproval indicates an expected call of ReviewAdminApproval.
+func (mr *MockIAdminRepositoryMockRecorder) ReviewAdminApproval(ctx, approval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewAdminApproval", reflect.TypeOf((*MockIAdminRepository)(nil).ReviewAdminApproval), ctx, approval)
+}
+
+// UpdateAdminApprovalStatus mocks base method.
+func (m *MockIAdminRepository) UpdateAdminApprovalStatus(ctx context.Context, approvalID int, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdminApprovalStatus", ctx, approvalID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAdminApprovalStatus indicates an expected call of UpdateAdminApprovalStatus.
+func (mr *MockIAdminRepositoryMockRecorder) UpdateAdminApprovalStatus(ctx, approvalID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminApprovalStatus", reflect.TypeOf((*MockIAdminRepository)(nil).UpdateAdminApprovalStatus), ctx, approvalID, status)
+}
+
+// UpdateUserStatus mocks base method.
+func (m *MockIAdminRepository) UpdateUserStatus(ctx context.Context, userID, version int, status string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserStatus", ctx, userID, version, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserStatus indicates an expected call of UpdateUserStatus.
+func (mr *MockIAdminRepositoryMockRecorder) UpdateUserStatus(ctx, userID, version, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockIAdminRepository)(nil).UpdateUserStatus), ctx, userID, version, status)
+}
+
+// MockIAdminApprovalService is a mock of IAdminApprovalService interface.
+type MockIAdminApprovalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAdminApprovalServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIAdminApprovalServiceMockRecorder is the mock recorder for MockIAdminApprovalService.
+type MockIAdminApprovalServiceMockRecorder struct {
+	mock *MockIAdminApprovalService
+}
+
+// NewMockIAdminApprovalService creates a new mock instance.
+func NewMockIAdminApprovalService(ctrl *gomock.Controller) *MockIAdminApprovalService {
+	mock := &MockIAdminApprovalService{ctrl: ctrl}
+	mock.recorder = &MockIAdminApprovalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAdminApprovalService) EXPECT() *MockIAdminApprovalServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockIAdminApprovalService) Approve(ctx context.Context, approvalID, reviewedBy int, note string) (models.AdminApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, approvalID, reviewedBy, note)
+	ret0, _ := ret[0].(models.AdminApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockIAdminApprovalServiceMockRecorder) Approve(ctx, approvalID, reviewedBy, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockIAdminApprovalService)(nil).Approve), ctx, approvalID, reviewedBy, note)
+}
+
+// GetApprovals mocks base method.
+func (m *MockIAdminApprovalService) GetApprovals(ctx context.Context, req models.AdminApprovalListRequest) (pagination.Page[models.AdminApproval], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovals", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.AdminApproval])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovals indicates an expected call of GetApprovals.
+func (mr *MockIAdminApprovalServiceMockRecorder) GetApprovals(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovals", reflect.TypeOf((*MockIAdminApprovalService)(nil).GetApprovals), ctx, req)
+}
+
+// Reject mocks base method.
+func (m *MockIAdminApprovalService) Reject(ctx context.Context, approvalID, reviewedBy int, note string) (models.AdminApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, approvalID, reviewedBy, note)
+	ret0, _ := ret[0].(models.AdminApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockIAdminApprovalServiceMockRecorder) Reject(ctx, approvalID, reviewedBy, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockIAdminApprovalService)(nil).Reject), ctx, approvalID, reviewedBy, note)
+}
+
+// RequestApproval mocks base method.
+func (m *MockIAdminApprovalService) RequestApproval(ctx context.Context, requestedBy int, req models.AdminApprovalRequest) (models.AdminApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestApproval", ctx, requestedBy, req)
+	ret0, _ := ret[0].(models.AdminApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestApproval indicates an expected call of RequestApproval.
+func (mr *MockIAdminApprovalServiceMockRecorder) RequestApproval(ctx, requestedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestApproval", reflect.TypeOf((*MockIAdminApprovalService)(nil).RequestApproval), ctx, requestedBy, req)
+}
+
+// RequestApprovalAs mocks base method.
+func (m *MockIAdminApprovalService) RequestApprovalAs(ctx context.Context, actor string, req models.AdminApprovalRequest) (models.AdminApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestApprovalAs", ctx, actor, req)
+	ret0, _ := ret[0].(models.AdminApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestApprovalAs indicates an expected call of RequestApprovalAs.
+func (mr *MockIAdminApprovalServiceMockRecorder) RequestApprovalAs(ctx, actor, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestApprovalAs", reflect.TypeOf((*MockIAdminApprovalService)(nil).RequestApprovalAs), ctx, actor, req)
+}
+
+// MockIAdminApprovalHandler is a mock of IAdminApprovalHandler interface.
+type MockIAdminApprovalHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAdminApprovalHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIAdminApprovalHandlerMockRecorder is the mock recorder for MockIAdminApprovalHandler.
+type MockIAdminApprovalHandlerMockRecorder struct {
+	mock *MockIAdminApprovalHandler
+}
+
+// NewMockIAdminApprovalHandler creates a new mock instance.
+func NewMockIAdminApprovalHandler(ctrl *gomock.Controller) *MockIAdminApprovalHandler {
+	mock := &MockIAdminApprovalHandler{ctrl: ctrl}
+	mock.recorder = &MockIAdminApprovalHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAdminApprovalHandler) EXPECT() *MockIAdminApprovalHandlerMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockIAdminApprovalHandler) Approve(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Approve", c)
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockIAdminApprovalHandlerMockRecorder) Approve(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockIAdminApprovalHandler)(nil).Approve), c)
+}
+
+// GetApprovals mocks base method.
+func (m *MockIAdminApprovalHandler) GetApprovals(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetApprovals", c)
+}
+
+// GetApprovals indicates an expected call of GetApprovals.
+func (mr *MockIAdminApprovalHandlerMockRecorder) GetApprovals(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovals", reflect.TypeOf((*MockIAdminApprovalHandler)(nil).GetApprovals), c)
+}
+
+// Reject mocks base method.
+func (m *MockIAdminApprovalHandler) Reject(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reject", c)
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockIAdminApprovalHandlerMockRecorder) Reject(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockIAdminApprovalHandler)(nil).Reject), c)
+}
+
+// RequestApproval mocks base method.
+func (m *MockIAdminApprovalHandler) RequestApproval(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestApproval", c)
+}
+
+// RequestApproval indicates an expected call of RequestApproval.
+func (mr *MockIAdminApprovalHandlerMockRecorder) RequestApproval(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestApproval", reflect.TypeOf((*MockIAdminApprovalHandler)(nil).RequestApproval), c)
+}
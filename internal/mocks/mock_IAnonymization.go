@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IAnonymization.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IAnonymization.go -destination=../mocks/mock_IAnonymization.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAnonymizationRepository is a mock of IAnonymizationRepository interface.
+type MockIAnonymizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAnonymizationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIAnonymizationRepositoryMockRecorder is the mock recorder for MockIAnonymizationRepository.
+type MockIAnonymizationRepositoryMockRecorder struct {
+	mock *MockIAnonymizationRepository
+}
+
+// NewMockIAnonymizationRepository creates a new mock instance.
+func NewMockIAnonymizationRepository(ctrl *gomock.Controller) *MockIAnonymizationRepository {
+	mock := &MockIAnonymizationRepository{ctrl: ctrl}
+	mock.recorder = &MockIAnonymizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAnonymizationRepository) EXPECT() *MockIAnonymizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeUser mocks base method.
+func (m *MockIAnonymizationRepository) AnonymizeUser(ctx context.Context, userID int, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", ctx, userID, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockIAnonymizationRepositoryMockRecorder) AnonymizeUser(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockIAnonymizationRepository)(nil).AnonymizeUser), ctx, userID, now)
+}
+
+// GetUserIDsToAnonymize mocks base method.
+func (m *MockIAnonymizationRepository) GetUserIDsToAnonymize(ctx context.Context, deletedBefore time.Time, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDsToAnonymize", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDsToAnonymize indicates an expected call of GetUserIDsToAnonymize.
+func (mr *MockIAnonymizationRepositoryMockRecorder) GetUserIDsToAnonymize(ctx, deletedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDsToAnonymize", reflect.TypeOf((*MockIAnonymizationRepository)(nil).GetUserIDsToAnonymize), ctx, deletedBefore, limit)
+}
+
+// MockIAnonymizationService is a mock of IAnonymizationService interface.
+type MockIAnonymizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAnonymizationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIAnonymizationServiceMockRecorder is the mock recorder for MockIAnonymizationService.
+type MockIAnonymizationServiceMockRecorder struct {
+	mock *MockIAnonymizationService
+}
+
+// NewMockIAnonymizationService creates a new mock instance.
+func NewMockIAnonymizationService(ctrl *gomock.Controller) *MockIAnonymizationService {
+	mock := &MockIAnonymizationService{ctrl: ctrl}
+	mock.recorder = &MockIAnonymizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAnonymizationService) EXPECT() *MockIAnonymizationServiceMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeDeletedUsers mocks base method.
+func (m *MockIAnonymizationService) AnonymizeDeletedUsers(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeDeletedUsers", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeDeletedUsers indicates an expected call of AnonymizeDeletedUsers.
+func (mr *MockIAnonymizationServiceMockRecorder) AnonymizeDeletedUsers(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeDeletedUsers", reflect.TypeOf((*MockIAnonymizationService)(nil).AnonymizeDeletedUsers), ctx, now)
+}
+
+// Run mocks base method.
+func (m *MockIAnonymizationService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIAnonymizationServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIAnonymizationService)(nil).Run), ctx)
+}
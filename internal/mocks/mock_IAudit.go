@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IAudit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IAudit.go -destination=../mocks/mock_IAudit.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuditRepository is a mock of IAuditRepository interface.
+type MockIAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIAuditRepositoryMockRecorder is the mock recorder for MockIAuditRepository.
+type MockIAuditRepositoryMockRecorder struct {
+	mock *MockIAuditRepository
+}
+
+// NewMockIAuditRepository creates a new mock instance.
+func NewMockIAuditRepository(ctrl *gomock.Controller) *MockIAuditRepository {
+	mock := &MockIAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockIAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditRepository) EXPECT() *MockIAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAuditEvents mocks base method.
+func (m *MockIAuditRepository) CountAuditEvents(ctx context.Context, filter models.AuditEventFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAuditEvents", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAuditEvents indicates an expected call of CountAuditEvents.
+func (mr *MockIAuditRepositoryMockRecorder) CountAuditEvents(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAuditEvents", reflect.TypeOf((*MockIAuditRepository)(nil).CountAuditEvents), ctx, filter)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockIAuditRepository) GetAuditEvents(ctx context.Context, filter models.AuditEventFilter, cursor *pagination.Cursor, limit int) ([]models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, filter, cursor, limit)
+	ret0, _ := ret[0].([]models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockIAuditRepositoryMockRecorder) GetAuditEvents(ctx, filter, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockIAuditRepository)(nil).GetAuditEvents), ctx, filter, cursor, limit)
+}
+
+// InsertAuditEvent mocks base method.
+func (m *MockIAuditRepository) InsertAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuditEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAuditEvent indicates an expected call of InsertAuditEvent.
+func (mr *MockIAuditRepositoryMockRecorder) InsertAuditEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditEvent", reflect.TypeOf((*MockIAuditRepository)(nil).InsertAuditEvent), ctx, event)
+}
+
+// MockIAuditService is a mock of IAuditService interface.
+type MockIAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIAuditServiceMockRecorder is the mock recorder for MockIAuditService.
+type MockIAuditServiceMockRecorder struct {
+	mock *MockIAuditService
+}
+
+// NewMockIAuditService creates a new mock instance.
+func NewMockIAuditService(ctrl *gomock.Controller) *MockIAuditService {
+	mock := &MockIAuditService{ctrl: ctrl}
+	mock.recorder = &MockIAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditService) EXPECT() *MockIAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportAuditEvents mocks base method.
+func (m *MockIAuditService) ExportAuditEvents(ctx context.Context, requestedBy int, filter models.AuditEventFilter) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAuditEvents", ctx, requestedBy, filter)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAuditEvents indicates an expected call of ExportAuditEvents.
+func (mr *MockIAuditServiceMockRecorder) ExportAuditEvents(ctx, requestedBy, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAuditEvents", reflect.TypeOf((*MockIAuditService)(nil).ExportAuditEvents), ctx, requestedBy, filter)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockIAuditService) GetAuditEvents(ctx context.Context, req models.AuditEventListRequest) (pagination.Page[models.AuditEvent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.AuditEvent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockIAuditServiceMockRecorder) GetAuditEvents(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockIAuditService)(nil).GetAuditEvents), ctx, req)
+}
+
+// MockIAuditHandler is a mock of IAuditHandler interface.
+type MockIAuditHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIAuditHandlerMockRecorder is the mock recorder for MockIAuditHandler.
+type MockIAuditHandlerMockRecorder struct {
+	mock *MockIAuditHandler
+}
+
+// NewMockIAuditHandler creates a new mock instance.
+func NewMockIAuditHandler(ctrl *gomock.Controller) *MockIAuditHandler {
+	mock := &MockIAuditHandler{ctrl: ctrl}
+	mock.recorder = &MockIAuditHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditHandler) EXPECT() *MockIAuditHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockIAuditHandler) GetAuditEvents(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAuditEvents", c)
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockIAuditHandlerMockRecorder) GetAuditEvents(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockIAuditHandler)(nil).GetAuditEvents), c)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IBackup.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IBackup.go -destination=../mocks/mock_IBackup.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIBackupRepository is a mock of IBackupRepository interface.
+type MockIBackupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBackupRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIBackupRepositoryMockRecorder is the mock recorder for MockIBackupRepository.
+type MockIBackupRepositoryMockRecorder struct {
+	mock *MockIBackupRepository
+}
+
+// NewMockIBackupRepository creates a new mock instance.
+func NewMockIBackupRepository(ctrl *gomock.Controller) *MockIBackupRepository {
+	mock := &MockIBackupRepository{ctrl: ctrl}
+	mock.recorder = &MockIBackupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBackupRepository) EXPECT() *MockIBackupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DumpTable mocks base method.
+func (m *MockIBackupRepository) DumpTable(ctx context.Context, table string, afterID int64, limit int) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpTable", ctx, table, afterID, limit)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpTable indicates an expected call of DumpTable.
+func (mr *MockIBackupRepositoryMockRecorder) DumpTable(ctx, table, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpTable", reflect.TypeOf((*MockIBackupRepository)(nil).DumpTable), ctx, table, afterID, limit)
+}
+
+// MockIBackupService is a mock of IBackupService interface.
+type MockIBackupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBackupServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIBackupServiceMockRecorder is the mock recorder for MockIBackupService.
+type MockIBackupServiceMockRecorder struct {
+	mock *MockIBackupService
+}
+
+// NewMockIBackupService creates a new mock instance.
+func NewMockIBackupService(ctrl *gomock.Controller) *MockIBackupService {
+	mock := &MockIBackupService{ctrl: ctrl}
+	mock.recorder = &MockIBackupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBackupService) EXPECT() *MockIBackupServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockIBackupService) Export(ctx context.Context, tables []string, prefix string, now time.Time) (models.BackupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, tables, prefix, now)
+	ret0, _ := ret[0].(models.BackupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockIBackupServiceMockRecorder) Export(ctx, tables, prefix, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockIBackupService)(nil).Export), ctx, tables, prefix, now)
+}
+
+// Verify mocks base method.
+func (m *MockIBackupService) Verify(ctx context.Context, key string) (models.BackupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, key)
+	ret0, _ := ret[0].(models.BackupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockIBackupServiceMockRecorder) Verify(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockIBackupService)(nil).Verify), ctx, key)
+}
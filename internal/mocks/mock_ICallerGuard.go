@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ICallerGuard.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ICallerGuard.go -destination=../mocks/mock_ICallerGuard.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICallerGuardRepository is a mock of ICallerGuardRepository interface.
+type MockICallerGuardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICallerGuardRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockICallerGuardRepositoryMockRecorder is the mock recorder for MockICallerGuardRepository.
+type MockICallerGuardRepositoryMockRecorder struct {
+	mock *MockICallerGuardRepository
+}
+
+// NewMockICallerGuardRepository creates a new mock instance.
+func NewMockICallerGuardRepository(ctrl *gomock.Controller) *MockICallerGuardRepository {
+	mock := &MockICallerGuardRepository{ctrl: ctrl}
+	mock.recorder = &MockICallerGuardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICallerGuardRepository) EXPECT() *MockICallerGuardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockICallerGuardRepository) Allow(ctx context.Context, caller string, limit int, window time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, caller, limit, window)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockICallerGuardRepositoryMockRecorder) Allow(ctx, caller, limit, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockICallerGuardRepository)(nil).Allow), ctx, caller, limit, window)
+}
+
+// Ban mocks base method.
+func (m *MockICallerGuardRepository) Ban(ctx context.Context, caller string, duration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ban", ctx, caller, duration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ban indicates an expected call of Ban.
+func (mr *MockICallerGuardRepositoryMockRecorder) Ban(ctx, caller, duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ban", reflect.TypeOf((*MockICallerGuardRepository)(nil).Ban), ctx, caller, duration)
+}
+
+// IsBanned mocks base method.
+func (m *MockICallerGuardRepository) IsBanned(ctx context.Context, caller string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", ctx, caller)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockICallerGuardRepositoryMockRecorder) IsBanned(ctx, caller any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*MockICallerGuardRepository)(nil).IsBanned), ctx, caller)
+}
+
+// RecordFailure mocks base method.
+func (m *MockICallerGuardRepository) RecordFailure(ctx context.Context, caller string, window time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, caller, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockICallerGuardRepositoryMockRecorder) RecordFailure(ctx, caller, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockICallerGuardRepository)(nil).RecordFailure), ctx, caller, window)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IClient.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IClient.go -destination=../mocks/mock_IClient.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIClientRepository is a mock of IClientRepository interface.
+type MockIClientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIClientRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIClientRepositoryMockRecorder is the mock recorder for MockIClientRepository.
+type MockIClientRepositoryMockRecorder struct {
+	mock *MockIClientRepository
+}
+
+// NewMockIClientRepository creates a new mock instance.
+func NewMockIClientRepository(ctrl *gomock.Controller) *MockIClientRepository {
+	mock := &MockIClientRepository{ctrl: ctrl}
+	mock.recorder = &MockIClientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIClientRepository) EXPECT() *MockIClientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetClientByClientID mocks base method.
+func (m *MockIClientRepository) GetClientByClientID(ctx context.Context, clientID string) (models.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientByClientID", ctx, clientID)
+	ret0, _ := ret[0].(models.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientByClientID indicates an expected call of GetClientByClientID.
+func (mr *MockIClientRepositoryMockRecorder) GetClientByClientID(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientByClientID", reflect.TypeOf((*MockIClientRepository)(nil).GetClientByClientID), ctx, clientID)
+}
+
+// UpsertClient mocks base method.
+func (m *MockIClientRepository) UpsertClient(ctx context.Context, client *models.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertClient", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertClient indicates an expected call of UpsertClient.
+func (mr *MockIClientRepositoryMockRecorder) UpsertClient(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertClient", reflect.TypeOf((*MockIClientRepository)(nil).UpsertClient), ctx, client)
+}
@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IConsent.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IConsent.go -destination=../mocks/mock_IConsent.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIConsentRepository is a mock of IConsentRepository interface.
+type MockIConsentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIConsentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIConsentRepositoryMockRecorder is the mock recorder for MockIConsentRepository.
+type MockIConsentRepositoryMockRecorder struct {
+	mock *MockIConsentRepository
+}
+
+// NewMockIConsentRepository creates a new mock instance.
+func NewMockIConsentRepository(ctrl *gomock.Controller) *MockIConsentRepository {
+	mock := &MockIConsentRepository{ctrl: ctrl}
+	mock.recorder = &MockIConsentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIConsentRepository) EXPECT() *MockIConsentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLatestUserConsents mocks base method.
+func (m *MockIConsentRepository) GetLatestUserConsents(ctx context.Context, userID int) ([]models.UserConsent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestUserConsents", ctx, userID)
+	ret0, _ := ret[0].([]models.UserConsent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestUserConsents indicates an expected call of GetLatestUserConsents.
+func (mr *MockIConsentRepositoryMockRecorder) GetLatestUserConsents(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestUserConsents", reflect.TypeOf((*MockIConsentRepository)(nil).GetLatestUserConsents), ctx, userID)
+}
+
+// InsertUserConsents mocks base method.
+func (m *MockIConsentRepository) InsertUserConsents(ctx context.Context, consents []models.UserConsent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserConsents", ctx, consents)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserConsents indicates an expected call of InsertUserConsents.
+func (mr *MockIConsentRepositoryMockRecorder) InsertUserConsents(ctx, consents any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserConsents", reflect.TypeOf((*MockIConsentRepository)(nil).InsertUserConsents), ctx, consents)
+}
+
+// MockIConsentService is a mock of IConsentService interface.
+type MockIConsentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIConsentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIConsentServiceMockRecorder is the mock recorder for MockIConsentService.
+type MockIConsentServiceMockRecorder struct {
+	mock *MockIConsentService
+}
+
+// NewMockIConsentService creates a new mock instance.
+func NewMockIConsentService(ctrl *gomock.Controller) *MockIConsentService {
+	mock := &MockIConsentService{ctrl: ctrl}
+	mock.recorder = &MockIConsentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIConsentService) EXPECT() *MockIConsentServiceMockRecorder {
+	return m.recorder
+}
+
+// GetConsents mocks base method.
+func (m *MockIConsentService) GetConsents(ctx context.Context, userID int) ([]models.UserConsent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsents", ctx, userID)
+	ret0, _ := ret[0].([]models.UserConsent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsents indicates an expected call of GetConsents.
+func (mr *MockIConsentServiceMockRecorder) GetConsents(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsents", reflect.TypeOf((*MockIConsentService)(nil).GetConsents), ctx, userID)
+}
+
+// UpdateConsents mocks base method.
+func (m *MockIConsentService) UpdateConsents(ctx context.Context, userID int, req models.UpdateConsentsRequest) ([]models.UserConsent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConsents", ctx, userID, req)
+	ret0, _ := ret[0].([]models.UserConsent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateConsents indicates an expected call of UpdateConsents.
+func (mr *MockIConsentServiceMockRecorder) UpdateConsents(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConsents", reflect.TypeOf((*MockIConsentService)(nil).UpdateConsents), ctx, userID, req)
+}
+
+// MockIConsentHandler is a mock of IConsentHandler interface.
+type MockIConsentHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIConsentHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIConsentHandlerMockRecorder is the mock recorder for MockIConsentHandler.
+type MockIConsentHandlerMockRecorder struct {
+	mock *MockIConsentHandler
+}
+
+// NewMockIConsentHandler creates a new mock instance.
+func NewMockIConsentHandler(ctrl *gomock.Controller) *MockIConsentHandler {
+	mock := &MockIConsentHandler{ctrl: ctrl}
+	mock.recorder = &MockIConsentHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIConsentHandler) EXPECT() *MockIConsentHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetConsents mocks base method.
+func (m *MockIConsentHandler) GetConsents(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetConsents", c)
+}
+
+// GetConsents indicates an expected call of GetConsents.
+func (mr *MockIConsentHandlerMockRecorder) GetConsents(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsents", reflect.TypeOf((*MockIConsentHandler)(nil).GetConsents), c)
+}
+
+// UpdateConsents mocks base method.
+func (m *MockIConsentHandler) UpdateConsents(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateConsents", c)
+}
+
+// UpdateConsents indicates an expected call of UpdateConsents.
+func (mr *MockIConsentHandlerMockRecorder) UpdateConsents(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConsents", reflect.TypeOf((*MockIConsentHandler)(nil).UpdateConsents), c)
+}
@@ -0,0 +1,314 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IDeadLetter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IDeadLetter.go -destination=../mocks/mock_IDeadLetter.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIDeadLetterRepository is a mock of IDeadLetterRepository interface.
+type MockIDeadLetterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDeadLetterRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIDeadLetterRepositoryMockRecorder is the mock recorder for MockIDeadLetterRepository.
+type MockIDeadLetterRepositoryMockRecorder struct {
+	mock *MockIDeadLetterRepository
+}
+
+// NewMockIDeadLetterRepository creates a new mock instance.
+func NewMockIDeadLetterRepository(ctrl *gomock.Controller) *MockIDeadLetterRepository {
+	mock := &MockIDeadLetterRepository{ctrl: ctrl}
+	mock.recorder = &MockIDeadLetterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDeadLetterRepository) EXPECT() *MockIDeadLetterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDeadLetterByID mocks base method.
+func (m *MockIDeadLetterRepository) GetDeadLetterByID(ctx context.Context, deadLetterID int) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetterByID", ctx, deadLetterID)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetterByID indicates an expected call of GetDeadLetterByID.
+func (mr *MockIDeadLetterRepositoryMockRecorder) GetDeadLetterByID(ctx, deadLetterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetterByID", reflect.TypeOf((*MockIDeadLetterRepository)(nil).GetDeadLetterByID), ctx, deadLetterID)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockIDeadLetterRepository) GetDeadLetters(ctx context.Context, status, topic string, cursor *pagination.Cursor, limit int) ([]models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx, status, topic, cursor, limit)
+	ret0, _ := ret[0].([]models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockIDeadLetterRepositoryMockRecorder) GetDeadLetters(ctx, status, topic, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockIDeadLetterRepository)(nil).GetDeadLetters), ctx, status, topic, cursor, limit)
+}
+
+// InsertDeadLetter mocks base method.
+func (m *MockIDeadLetterRepository) InsertDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDeadLetter", ctx, deadLetter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertDeadLetter indicates an expected call of InsertDeadLetter.
+func (mr *MockIDeadLetterRepositoryMockRecorder) InsertDeadLetter(ctx, deadLetter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDeadLetter", reflect.TypeOf((*MockIDeadLetterRepository)(nil).InsertDeadLetter), ctx, deadLetter)
+}
+
+// UpdateDeadLetter mocks base method.
+func (m *MockIDeadLetterRepository) UpdateDeadLetter(ctx context.Context, deadLetter *models.DeadLetter, fromStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeadLetter", ctx, deadLetter, fromStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDeadLetter indicates an expected call of UpdateDeadLetter.
+func (mr *MockIDeadLetterRepositoryMockRecorder) UpdateDeadLetter(ctx, deadLetter, fromStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeadLetter", reflect.TypeOf((*MockIDeadLetterRepository)(nil).UpdateDeadLetter), ctx, deadLetter, fromStatus)
+}
+
+// MockIDeadLetterService is a mock of IDeadLetterService interface.
+type MockIDeadLetterService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDeadLetterServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIDeadLetterServiceMockRecorder is the mock recorder for MockIDeadLetterService.
+type MockIDeadLetterServiceMockRecorder struct {
+	mock *MockIDeadLetterService
+}
+
+// NewMockIDeadLetterService creates a new mock instance.
+func NewMockIDeadLetterService(ctrl *gomock.Controller) *MockIDeadLetterService {
+	mock := &MockIDeadLetterService{ctrl: ctrl}
+	mock.recorder = &MockIDeadLetterServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDeadLetterService) EXPECT() *MockIDeadLetterServiceMockRecorder {
+	return m.recorder
+}
+
+// DiscardDeadLetter mocks base method.
+func (m *MockIDeadLetterService) DiscardDeadLetter(ctx context.Context, actor string, deadLetterID int, req models.DeadLetterDiscardRequest) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardDeadLetter", ctx, actor, deadLetterID, req)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardDeadLetter indicates an expected call of DiscardDeadLetter.
+func (mr *MockIDeadLetterServiceMockRecorder) DiscardDeadLetter(ctx, actor, deadLetterID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardDeadLetter", reflect.TypeOf((*MockIDeadLetterService)(nil).DiscardDeadLetter), ctx, actor, deadLetterID, req)
+}
+
+// GetDeadLetter mocks base method.
+func (m *MockIDeadLetterService) GetDeadLetter(ctx context.Context, deadLetterID int) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetter", ctx, deadLetterID)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetter indicates an expected call of GetDeadLetter.
+func (mr *MockIDeadLetterServiceMockRecorder) GetDeadLetter(ctx, deadLetterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetter", reflect.TypeOf((*MockIDeadLetterService)(nil).GetDeadLetter), ctx, deadLetterID)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockIDeadLetterService) GetDeadLetters(ctx context.Context, req models.DeadLetterListRequest) (pagination.Page[models.DeadLetter], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.DeadLetter])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockIDeadLetterServiceMockRecorder) GetDeadLetters(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockIDeadLetterService)(nil).GetDeadLetters), ctx, req)
+}
+
+// RecordConsumed mocks base method.
+func (m *MockIDeadLetterService) RecordConsumed(ctx context.Context, topic, messageID string, payload []byte, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordConsumed", ctx, topic, messageID, payload, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordConsumed indicates an expected call of RecordConsumed.
+func (mr *MockIDeadLetterServiceMockRecorder) RecordConsumed(ctx, topic, messageID, payload, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordConsumed", reflect.TypeOf((*MockIDeadLetterService)(nil).RecordConsumed), ctx, topic, messageID, payload, reason)
+}
+
+// RecordPublished mocks base method.
+func (m *MockIDeadLetterService) RecordPublished(ctx context.Context, topic string, message []byte, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPublished", ctx, topic, message, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordPublished indicates an expected call of RecordPublished.
+func (mr *MockIDeadLetterServiceMockRecorder) RecordPublished(ctx, topic, message, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPublished", reflect.TypeOf((*MockIDeadLetterService)(nil).RecordPublished), ctx, topic, message, reason)
+}
+
+// ReplayDeadLetter mocks base method.
+func (m *MockIDeadLetterService) ReplayDeadLetter(ctx context.Context, actor string, deadLetterID int) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDeadLetter", ctx, actor, deadLetterID)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDeadLetter indicates an expected call of ReplayDeadLetter.
+func (mr *MockIDeadLetterServiceMockRecorder) ReplayDeadLetter(ctx, actor, deadLetterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDeadLetter", reflect.TypeOf((*MockIDeadLetterService)(nil).ReplayDeadLetter), ctx, actor, deadLetterID)
+}
+
+// UpdateDeadLetter mocks base method.
+func (m *MockIDeadLetterService) UpdateDeadLetter(ctx context.Context, actor string, deadLetterID int, req models.DeadLetterUpdateRequest) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeadLetter", ctx, actor, deadLetterID, req)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDeadLetter indicates an expected call of UpdateDeadLetter.
+func (mr *MockIDeadLetterServiceMockRecorder) UpdateDeadLetter(ctx, actor, deadLetterID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeadLetter", reflect.TypeOf((*MockIDeadLetterService)(nil).UpdateDeadLetter), ctx, actor, deadLetterID, req)
+}
+
+// MockIDeadLetterHandler is a mock of IDeadLetterHandler interface.
+type MockIDeadLetterHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDeadLetterHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIDeadLetterHandlerMockRecorder is the mock recorder for MockIDeadLetterHandler.
+type MockIDeadLetterHandlerMockRecorder struct {
+	mock *MockIDeadLetterHandler
+}
+
+// NewMockIDeadLetterHandler creates a new mock instance.
+func NewMockIDeadLetterHandler(ctrl *gomock.Controller) *MockIDeadLetterHandler {
+	mock := &MockIDeadLetterHandler{ctrl: ctrl}
+	mock.recorder = &MockIDeadLetterHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDeadLetterHandler) EXPECT() *MockIDeadLetterHandlerMockRecorder {
+	return m.recorder
+}
+
+// DiscardDeadLetter mocks base method.
+func (m *MockIDeadLetterHandler) DiscardDeadLetter(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiscardDeadLetter", c)
+}
+
+// DiscardDeadLetter indicates an expected call of DiscardDeadLetter.
+func (mr *MockIDeadLetterHandlerMockRecorder) DiscardDeadLetter(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardDeadLetter", reflect.TypeOf((*MockIDeadLetterHandler)(nil).DiscardDeadLetter), c)
+}
+
+// GetDeadLetter mocks base method.
+func (m *MockIDeadLetterHandler) GetDeadLetter(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetDeadLetter", c)
+}
+
+// GetDeadLetter indicates an expected call of GetDeadLetter.
+func (mr *MockIDeadLetterHandlerMockRecorder) GetDeadLetter(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetter", reflect.TypeOf((*MockIDeadLetterHandler)(nil).GetDeadLetter), c)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockIDeadLetterHandler) GetDeadLetters(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetDeadLetters", c)
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockIDeadLetterHandlerMockRecorder) GetDeadLetters(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockIDeadLetterHandler)(nil).GetDeadLetters), c)
+}
+
+// ReplayDeadLetter mocks base method.
+func (m *MockIDeadLetterHandler) ReplayDeadLetter(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReplayDeadLetter", c)
+}
+
+// ReplayDeadLetter indicates an expected call of ReplayDeadLetter.
+func (mr *MockIDeadLetterHandlerMockRecorder) ReplayDeadLetter(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDeadLetter", reflect.TypeOf((*MockIDeadLetterHandler)(nil).ReplayDeadLetter), c)
+}
+
+// UpdateDeadLetter mocks base method.
+func (m *MockIDeadLetterHandler) UpdateDeadLetter(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateDeadLetter", c)
+}
+
+// UpdateDeadLetter indicates an expected call of UpdateDeadLetter.
+func (mr *MockIDeadLetterHandlerMockRecorder) UpdateDeadLetter(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeadLetter", reflect.TypeOf((*MockIDeadLetterHandler)(nil).UpdateDeadLetter), c)
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IEmailTemplate.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IEmailTemplate.go -destination=../mocks/mock_IEmailTemplate.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEmailTemplateService is a mock of IEmailTemplateService interface.
+type MockIEmailTemplateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEmailTemplateServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIEmailTemplateServiceMockRecorder is the mock recorder for MockIEmailTemplateService.
+type MockIEmailTemplateServiceMockRecorder struct {
+	mock *MockIEmailTemplateService
+}
+
+// NewMockIEmailTemplateService creates a new mock instance.
+func NewMockIEmailTemplateService(ctrl *gomock.Controller) *MockIEmailTemplateService {
+	mock := &MockIEmailTemplateService{ctrl: ctrl}
+	mock.recorder = &MockIEmailTemplateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEmailTemplateService) EXPECT() *MockIEmailTemplateServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTemplates mocks base method.
+func (m *MockIEmailTemplateService) GetTemplates(ctx context.Context) []models.EmailTemplate {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates", ctx)
+	ret0, _ := ret[0].([]models.EmailTemplate)
+	return ret0
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockIEmailTemplateServiceMockRecorder) GetTemplates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockIEmailTemplateService)(nil).GetTemplates), ctx)
+}
+
+// Preview mocks base method.
+func (m *MockIEmailTemplateService) Preview(ctx context.Context, name, locale, clientID string) (models.RenderedEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", ctx, name, locale, clientID)
+	ret0, _ := ret[0].(models.RenderedEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockIEmailTemplateServiceMockRecorder) Preview(ctx, name, locale, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockIEmailTemplateService)(nil).Preview), ctx, name, locale, clientID)
+}
+
+// MockIEmailTemplateHandler is a mock of IEmailTemplateHandler interface.
+type MockIEmailTemplateHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEmailTemplateHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIEmailTemplateHandlerMockRecorder is the mock recorder for MockIEmailTemplateHandler.
+type MockIEmailTemplateHandlerMockRecorder struct {
+	mock *MockIEmailTemplateHandler
+}
+
+// NewMockIEmailTemplateHandler creates a new mock instance.
+func NewMockIEmailTemplateHandler(ctrl *gomock.Controller) *MockIEmailTemplateHandler {
+	mock := &MockIEmailTemplateHandler{ctrl: ctrl}
+	mock.recorder = &MockIEmailTemplateHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEmailTemplateHandler) EXPECT() *MockIEmailTemplateHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetTemplates mocks base method.
+func (m *MockIEmailTemplateHandler) GetTemplates(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetTemplates", c)
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockIEmailTemplateHandlerMockRecorder) GetTemplates(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockIEmailTemplateHandler)(nil).GetTemplates), c)
+}
+
+// Preview mocks base method.
+func (m *MockIEmailTemplateHandler) Preview(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Preview", c)
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockIEmailTemplateHandlerMockRecorder) Preview(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockIEmailTemplateHandler)(nil).Preview), c)
+}
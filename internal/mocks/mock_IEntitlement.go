@@ -0,0 +1,277 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IEntitlement.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IEntitlement.go -destination=../mocks/mock_IEntitlement.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entitlement "ewallet-ums/cmd/proto/entitlement"
+	helpers "ewallet-ums/helpers"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEntitlementRepository is a mock of IEntitlementRepository interface.
+type MockIEntitlementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEntitlementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIEntitlementRepositoryMockRecorder is the mock recorder for MockIEntitlementRepository.
+type MockIEntitlementRepositoryMockRecorder struct {
+	mock *MockIEntitlementRepository
+}
+
+// NewMockIEntitlementRepository creates a new mock instance.
+func NewMockIEntitlementRepository(ctrl *gomock.Controller) *MockIEntitlementRepository {
+	mock := &MockIEntitlementRepository{ctrl: ctrl}
+	mock.recorder = &MockIEntitlementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEntitlementRepository) EXPECT() *MockIEntitlementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserEntitlement mocks base method.
+func (m *MockIEntitlementRepository) DeleteUserEntitlement(ctx context.Context, userID int, feature string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserEntitlement", ctx, userID, feature)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserEntitlement indicates an expected call of DeleteUserEntitlement.
+func (mr *MockIEntitlementRepositoryMockRecorder) DeleteUserEntitlement(ctx, userID, feature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserEntitlement", reflect.TypeOf((*MockIEntitlementRepository)(nil).DeleteUserEntitlement), ctx, userID, feature)
+}
+
+// GetUserEntitlements mocks base method.
+func (m *MockIEntitlementRepository) GetUserEntitlements(ctx context.Context, userID int) ([]models.UserEntitlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEntitlements", ctx, userID)
+	ret0, _ := ret[0].([]models.UserEntitlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEntitlements indicates an expected call of GetUserEntitlements.
+func (mr *MockIEntitlementRepositoryMockRecorder) GetUserEntitlements(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEntitlements", reflect.TypeOf((*MockIEntitlementRepository)(nil).GetUserEntitlements), ctx, userID)
+}
+
+// InsertUserEntitlement mocks base method.
+func (m *MockIEntitlementRepository) InsertUserEntitlement(ctx context.Context, entitlement *models.UserEntitlement) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserEntitlement", ctx, entitlement)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUserEntitlement indicates an expected call of InsertUserEntitlement.
+func (mr *MockIEntitlementRepositoryMockRecorder) InsertUserEntitlement(ctx, entitlement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserEntitlement", reflect.TypeOf((*MockIEntitlementRepository)(nil).InsertUserEntitlement), ctx, entitlement)
+}
+
+// MockIEntitlementService is a mock of IEntitlementService interface.
+type MockIEntitlementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEntitlementServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIEntitlementServiceMockRecorder is the mock recorder for MockIEntitlementService.
+type MockIEntitlementServiceMockRecorder struct {
+	mock *MockIEntitlementService
+}
+
+// NewMockIEntitlementService creates a new mock instance.
+func NewMockIEntitlementService(ctrl *gomock.Controller) *MockIEntitlementService {
+	mock := &MockIEntitlementService{ctrl: ctrl}
+	mock.recorder = &MockIEntitlementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEntitlementService) EXPECT() *MockIEntitlementServiceMockRecorder {
+	return m.recorder
+}
+
+// EnrichClaims mocks base method.
+func (m *MockIEntitlementService) EnrichClaims(ctx context.Context, claims *helpers.ClaimToken) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrichClaims", ctx, claims)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrichClaims indicates an expected call of EnrichClaims.
+func (mr *MockIEntitlementServiceMockRecorder) EnrichClaims(ctx, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrichClaims", reflect.TypeOf((*MockIEntitlementService)(nil).EnrichClaims), ctx, claims)
+}
+
+// GetEntitlements mocks base method.
+func (m *MockIEntitlementService) GetEntitlements(ctx context.Context, userID int) ([]models.Entitlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntitlements", ctx, userID)
+	ret0, _ := ret[0].([]models.Entitlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntitlements indicates an expected call of GetEntitlements.
+func (mr *MockIEntitlementServiceMockRecorder) GetEntitlements(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntitlements", reflect.TypeOf((*MockIEntitlementService)(nil).GetEntitlements), ctx, userID)
+}
+
+// GetFeatures mocks base method.
+func (m *MockIEntitlementService) GetFeatures(ctx context.Context, user models.User) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeatures", ctx, user)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeatures indicates an expected call of GetFeatures.
+func (mr *MockIEntitlementServiceMockRecorder) GetFeatures(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatures", reflect.TypeOf((*MockIEntitlementService)(nil).GetFeatures), ctx, user)
+}
+
+// GetUserFeatures mocks base method.
+func (m *MockIEntitlementService) GetUserFeatures(ctx context.Context, userID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFeatures", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFeatures indicates an expected call of GetUserFeatures.
+func (mr *MockIEntitlementServiceMockRecorder) GetUserFeatures(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFeatures", reflect.TypeOf((*MockIEntitlementService)(nil).GetUserFeatures), ctx, userID)
+}
+
+// GrantEntitlement mocks base method.
+func (m *MockIEntitlementService) GrantEntitlement(ctx context.Context, actor string, userID int, req models.EntitlementRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantEntitlement", ctx, actor, userID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantEntitlement indicates an expected call of GrantEntitlement.
+func (mr *MockIEntitlementServiceMockRecorder) GrantEntitlement(ctx, actor, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantEntitlement", reflect.TypeOf((*MockIEntitlementService)(nil).GrantEntitlement), ctx, actor, userID, req)
+}
+
+// RevokeEntitlement mocks base method.
+func (m *MockIEntitlementService) RevokeEntitlement(ctx context.Context, actor string, userID int, req models.EntitlementRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeEntitlement", ctx, actor, userID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeEntitlement indicates an expected call of RevokeEntitlement.
+func (mr *MockIEntitlementServiceMockRecorder) RevokeEntitlement(ctx, actor, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeEntitlement", reflect.TypeOf((*MockIEntitlementService)(nil).RevokeEntitlement), ctx, actor, userID, req)
+}
+
+// MockIEntitlementHandler is a mock of IEntitlementHandler interface.
+type MockIEntitlementHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEntitlementHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIEntitlementHandlerMockRecorder is the mock recorder for MockIEntitlementHandler.
+type MockIEntitlementHandlerMockRecorder struct {
+	mock *MockIEntitlementHandler
+}
+
+// NewMockIEntitlementHandler creates a new mock instance.
+func NewMockIEntitlementHandler(ctrl *gomock.Controller) *MockIEntitlementHandler {
+	mock := &MockIEntitlementHandler{ctrl: ctrl}
+	mock.recorder = &MockIEntitlementHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEntitlementHandler) EXPECT() *MockIEntitlementHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetEntitlements mocks base method.
+func (m *MockIEntitlementHandler) GetEntitlements(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetEntitlements", c)
+}
+
+// GetEntitlements indicates an expected call of GetEntitlements.
+func (mr *MockIEntitlementHandlerMockRecorder) GetEntitlements(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntitlements", reflect.TypeOf((*MockIEntitlementHandler)(nil).GetEntitlements), c)
+}
+
+// GetUserEntitlements mocks base method.
+func (m *MockIEntitlementHandler) GetUserEntitlements(ctx context.Context, req *entitlement.UserEntitlementsRequest) (*entitlement.UserEntitlementsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEntitlements", ctx, req)
+	ret0, _ := ret[0].(*entitlement.UserEntitlementsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEntitlements indicates an expected call of GetUserEntitlements.
+func (mr *MockIEntitlementHandlerMockRecorder) GetUserEntitlements(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEntitlements", reflect.TypeOf((*MockIEntitlementHandler)(nil).GetUserEntitlements), ctx, req)
+}
+
+// GrantEntitlement mocks base method.
+func (m *MockIEntitlementHandler) GrantEntitlement(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GrantEntitlement", c)
+}
+
+// GrantEntitlement indicates an expected call of GrantEntitlement.
+func (mr *MockIEntitlementHandlerMockRecorder) GrantEntitlement(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantEntitlement", reflect.TypeOf((*MockIEntitlementHandler)(nil).GrantEntitlement), c)
+}
+
+// RevokeEntitlement mocks base method.
+func (m *MockIEntitlementHandler) RevokeEntitlement(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeEntitlement", c)
+}
+
+// RevokeEntitlement indicates an expected call of RevokeEntitlement.
+func (mr *MockIEntitlementHandlerMockRecorder) RevokeEntitlement(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeEntitlement", reflect.TypeOf((*MockIEntitlementHandler)(nil).RevokeEntitlement), c)
+}
@@ -0,0 +1,476 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IExternal.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IExternal.go -destination=../mocks/mock_IExternal.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	external "ewallet-ums/external"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+	proto "google.golang.org/protobuf/proto"
+)
+
+// MockIWallet is a mock of IWallet interface.
+type MockIWallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWalletMockRecorder
+	isgomock struct{}
+}
+
+// MockIWalletMockRecorder is the mock recorder for MockIWallet.
+type MockIWalletMockRecorder struct {
+	mock *MockIWallet
+}
+
+// NewMockIWallet creates a new mock instance.
+func NewMockIWallet(ctrl *gomock.Controller) *MockIWallet {
+	mock := &MockIWallet{ctrl: ctrl}
+	mock.recorder = &MockIWalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWallet) EXPECT() *MockIWalletMockRecorder {
+	return m.recorder
+}
+
+// CreateWallet mocks base method.
+func (m *MockIWallet) CreateWallet(ctx context.Context, userID int) (*external.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWallet", ctx, userID)
+	ret0, _ := ret[0].(*external.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWallet indicates an expected call of CreateWallet.
+func (mr *MockIWalletMockRecorder) CreateWallet(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockIWallet)(nil).CreateWallet), ctx, userID)
+}
+
+// Ping mocks base method.
+func (m *MockIWallet) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockIWalletMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIWallet)(nil).Ping), ctx)
+}
+
+// MockIEventPublisher is a mock of IEventPublisher interface.
+type MockIEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockIEventPublisherMockRecorder is the mock recorder for MockIEventPublisher.
+type MockIEventPublisherMockRecorder struct {
+	mock *MockIEventPublisher
+}
+
+// NewMockIEventPublisher creates a new mock instance.
+func NewMockIEventPublisher(ctrl *gomock.Controller) *MockIEventPublisher {
+	mock := &MockIEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockIEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventPublisher) EXPECT() *MockIEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIEventPublisher) Publish(ctx context.Context, topic string, event proto.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, topic, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIEventPublisherMockRecorder) Publish(ctx, topic, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIEventPublisher)(nil).Publish), ctx, topic, event)
+}
+
+// PublishMessage mocks base method.
+func (m *MockIEventPublisher) PublishMessage(ctx context.Context, topic string, message []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishMessage", ctx, topic, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishMessage indicates an expected call of PublishMessage.
+func (mr *MockIEventPublisherMockRecorder) PublishMessage(ctx, topic, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessage", reflect.TypeOf((*MockIEventPublisher)(nil).PublishMessage), ctx, topic, message)
+}
+
+// MockIEventConsumer is a mock of IEventConsumer interface.
+type MockIEventConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventConsumerMockRecorder
+	isgomock struct{}
+}
+
+// MockIEventConsumerMockRecorder is the mock recorder for MockIEventConsumer.
+type MockIEventConsumerMockRecorder struct {
+	mock *MockIEventConsumer
+}
+
+// NewMockIEventConsumer creates a new mock instance.
+func NewMockIEventConsumer(ctrl *gomock.Controller) *MockIEventConsumer {
+	mock := &MockIEventConsumer{ctrl: ctrl}
+	mock.recorder = &MockIEventConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventConsumer) EXPECT() *MockIEventConsumerMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockIEventConsumer) Consume(ctx context.Context, topics []string, handle func(context.Context, string, []byte) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, topics, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockIEventConsumerMockRecorder) Consume(ctx, topics, handle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockIEventConsumer)(nil).Consume), ctx, topics, handle)
+}
+
+// Redeliver mocks base method.
+func (m *MockIEventConsumer) Redeliver(ctx context.Context, topic string, payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, topic, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockIEventConsumerMockRecorder) Redeliver(ctx, topic, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockIEventConsumer)(nil).Redeliver), ctx, topic, payload)
+}
+
+// MockIObjectStorage is a mock of IObjectStorage interface.
+type MockIObjectStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIObjectStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockIObjectStorageMockRecorder is the mock recorder for MockIObjectStorage.
+type MockIObjectStorageMockRecorder struct {
+	mock *MockIObjectStorage
+}
+
+// NewMockIObjectStorage creates a new mock instance.
+func NewMockIObjectStorage(ctrl *gomock.Controller) *MockIObjectStorage {
+	mock := &MockIObjectStorage{ctrl: ctrl}
+	mock.recorder = &MockIObjectStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIObjectStorage) EXPECT() *MockIObjectStorageMockRecorder {
+	return m.recorder
+}
+
+// GetObject mocks base method.
+func (m *MockIObjectStorage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockIObjectStorageMockRecorder) GetObject(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockIObjectStorage)(nil).GetObject), ctx, key)
+}
+
+// PresignGetURL mocks base method.
+func (m *MockIObjectStorage) PresignGetURL(key string, ttl time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignGetURL", key, ttl)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignGetURL indicates an expected call of PresignGetURL.
+func (mr *MockIObjectStorageMockRecorder) PresignGetURL(key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignGetURL", reflect.TypeOf((*MockIObjectStorage)(nil).PresignGetURL), key, ttl)
+}
+
+// PutObject mocks base method.
+func (m *MockIObjectStorage) PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, key, contentType, body, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockIObjectStorageMockRecorder) PutObject(ctx, key, contentType, body, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockIObjectStorage)(nil).PutObject), ctx, key, contentType, body, size)
+}
+
+// MockIGeoIP is a mock of IGeoIP interface.
+type MockIGeoIP struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGeoIPMockRecorder
+	isgomock struct{}
+}
+
+// MockIGeoIPMockRecorder is the mock recorder for MockIGeoIP.
+type MockIGeoIPMockRecorder struct {
+	mock *MockIGeoIP
+}
+
+// NewMockIGeoIP creates a new mock instance.
+func NewMockIGeoIP(ctrl *gomock.Controller) *MockIGeoIP {
+	mock := &MockIGeoIP{ctrl: ctrl}
+	mock.recorder = &MockIGeoIPMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGeoIP) EXPECT() *MockIGeoIPMockRecorder {
+	return m.recorder
+}
+
+// Lookup mocks base method.
+func (m *MockIGeoIP) Lookup(ctx context.Context, ip string) (external.GeoLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ctx, ip)
+	ret0, _ := ret[0].(external.GeoLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockIGeoIPMockRecorder) Lookup(ctx, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockIGeoIP)(nil).Lookup), ctx, ip)
+}
+
+// MockIMailer is a mock of IMailer interface.
+type MockIMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMailerMockRecorder
+	isgomock struct{}
+}
+
+// MockIMailerMockRecorder is the mock recorder for MockIMailer.
+type MockIMailerMockRecorder struct {
+	mock *MockIMailer
+}
+
+// NewMockIMailer creates a new mock instance.
+func NewMockIMailer(ctrl *gomock.Controller) *MockIMailer {
+	mock := &MockIMailer{ctrl: ctrl}
+	mock.recorder = &MockIMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMailer) EXPECT() *MockIMailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockIMailer) Send(ctx context.Context, mail external.Mail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, mail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockIMailerMockRecorder) Send(ctx, mail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockIMailer)(nil).Send), ctx, mail)
+}
+
+// MockIAlerter is a mock of IAlerter interface.
+type MockIAlerter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAlerterMockRecorder
+	isgomock struct{}
+}
+
+// MockIAlerterMockRecorder is the mock recorder for MockIAlerter.
+type MockIAlerterMockRecorder struct {
+	mock *MockIAlerter
+}
+
+// NewMockIAlerter creates a new mock instance.
+func NewMockIAlerter(ctrl *gomock.Controller) *MockIAlerter {
+	mock := &MockIAlerter{ctrl: ctrl}
+	mock.recorder = &MockIAlerterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAlerter) EXPECT() *MockIAlerterMockRecorder {
+	return m.recorder
+}
+
+// Alert mocks base method.
+func (m *MockIAlerter) Alert(ctx context.Context, alert external.Alert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Alert", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Alert indicates an expected call of Alert.
+func (mr *MockIAlerterMockRecorder) Alert(ctx, alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Alert", reflect.TypeOf((*MockIAlerter)(nil).Alert), ctx, alert)
+}
+
+// MockISMSSender is a mock of ISMSSender interface.
+type MockISMSSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockISMSSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockISMSSenderMockRecorder is the mock recorder for MockISMSSender.
+type MockISMSSenderMockRecorder struct {
+	mock *MockISMSSender
+}
+
+// NewMockISMSSender creates a new mock instance.
+func NewMockISMSSender(ctrl *gomock.Controller) *MockISMSSender {
+	mock := &MockISMSSender{ctrl: ctrl}
+	mock.recorder = &MockISMSSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISMSSender) EXPECT() *MockISMSSenderMockRecorder {
+	return m.recorder
+}
+
+// SendSMS mocks base method.
+func (m *MockISMSSender) SendSMS(ctx context.Context, sms external.SMS) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSMS", ctx, sms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSMS indicates an expected call of SendSMS.
+func (mr *MockISMSSenderMockRecorder) SendSMS(ctx, sms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSMS", reflect.TypeOf((*MockISMSSender)(nil).SendSMS), ctx, sms)
+}
+
+// MockIScreeningProvider is a mock of IScreeningProvider interface.
+type MockIScreeningProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScreeningProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockIScreeningProviderMockRecorder is the mock recorder for MockIScreeningProvider.
+type MockIScreeningProviderMockRecorder struct {
+	mock *MockIScreeningProvider
+}
+
+// NewMockIScreeningProvider creates a new mock instance.
+func NewMockIScreeningProvider(ctrl *gomock.Controller) *MockIScreeningProvider {
+	mock := &MockIScreeningProvider{ctrl: ctrl}
+	mock.recorder = &MockIScreeningProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScreeningProvider) EXPECT() *MockIScreeningProviderMockRecorder {
+	return m.recorder
+}
+
+// Screen mocks base method.
+func (m *MockIScreeningProvider) Screen(ctx context.Context, subject external.ScreeningSubject) ([]external.ScreeningMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", ctx, subject)
+	ret0, _ := ret[0].([]external.ScreeningMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockIScreeningProviderMockRecorder) Screen(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockIScreeningProvider)(nil).Screen), ctx, subject)
+}
+
+// MockIErrorReporter is a mock of IErrorReporter interface.
+type MockIErrorReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIErrorReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockIErrorReporterMockRecorder is the mock recorder for MockIErrorReporter.
+type MockIErrorReporterMockRecorder struct {
+	mock *MockIErrorReporter
+}
+
+// NewMockIErrorReporter creates a new mock instance.
+func NewMockIErrorReporter(ctrl *gomock.Controller) *MockIErrorReporter {
+	mock := &MockIErrorReporter{ctrl: ctrl}
+	mock.recorder = &MockIErrorReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIErrorReporter) EXPECT() *MockIErrorReporterMockRecorder {
+	return m.recorder
+}
+
+// Report mocks base method.
+func (m *MockIErrorReporter) Report(ctx context.Context, report external.ErrorReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockIErrorReporterMockRecorder) Report(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockIErrorReporter)(nil).Report), ctx, report)
+}
@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IGuest.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IGuest.go -destination=../mocks/mock_IGuest.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIGuestService is a mock of IGuestService interface.
+type MockIGuestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGuestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIGuestServiceMockRecorder is the mock recorder for MockIGuestService.
+type MockIGuestServiceMockRecorder struct {
+	mock *MockIGuestService
+}
+
+// NewMockIGuestService creates a new mock instance.
+func NewMockIGuestService(ctrl *gomock.Controller) *MockIGuestService {
+	mock := &MockIGuestService{ctrl: ctrl}
+	mock.recorder = &MockIGuestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGuestService) EXPECT() *MockIGuestServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateGuest mocks base method.
+func (m *MockIGuestService) CreateGuest(ctx context.Context, req models.GuestRequest) (models.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuest", ctx, req)
+	ret0, _ := ret[0].(models.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuest indicates an expected call of CreateGuest.
+func (mr *MockIGuestServiceMockRecorder) CreateGuest(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuest", reflect.TypeOf((*MockIGuestService)(nil).CreateGuest), ctx, req)
+}
+
+// UpgradeGuest mocks base method.
+func (m *MockIGuestService) UpgradeGuest(ctx context.Context, userID int, req models.UpgradeGuestRequest) (models.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeGuest", ctx, userID, req)
+	ret0, _ := ret[0].(models.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeGuest indicates an expected call of UpgradeGuest.
+func (mr *MockIGuestServiceMockRecorder) UpgradeGuest(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeGuest", reflect.TypeOf((*MockIGuestService)(nil).UpgradeGuest), ctx, userID, req)
+}
+
+// MockIGuestHandler is a mock of IGuestHandler interface.
+type MockIGuestHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGuestHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIGuestHandlerMockRecorder is the mock recorder for MockIGuestHandler.
+type MockIGuestHandlerMockRecorder struct {
+	mock *MockIGuestHandler
+}
+
+// NewMockIGuestHandler creates a new mock instance.
+func NewMockIGuestHandler(ctrl *gomock.Controller) *MockIGuestHandler {
+	mock := &MockIGuestHandler{ctrl: ctrl}
+	mock.recorder = &MockIGuestHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGuestHandler) EXPECT() *MockIGuestHandlerMockRecorder {
+	return m.recorder
+}
+
+// CreateGuest mocks base method.
+func (m *MockIGuestHandler) CreateGuest(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateGuest", c)
+}
+
+// CreateGuest indicates an expected call of CreateGuest.
+func (mr *MockIGuestHandlerMockRecorder) CreateGuest(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuest", reflect.TypeOf((*MockIGuestHandler)(nil).CreateGuest), c)
+}
+
+// UpgradeGuest mocks base method.
+func (m *MockIGuestHandler) UpgradeGuest(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpgradeGuest", c)
+}
+
+// UpgradeGuest indicates an expected call of UpgradeGuest.
+func (mr *MockIGuestHandlerMockRecorder) UpgradeGuest(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeGuest", reflect.TypeOf((*MockIGuestHandler)(nil).UpgradeGuest), c)
+}
@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IHealthcheck.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IHealthcheck.go -destination=../mocks/mock_IHealthcheck.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIHealthcheckServices is a mock of IHealthcheckServices interface.
+type MockIHealthcheckServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthcheckServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockIHealthcheckServicesMockRecorder is the mock recorder for MockIHealthcheckServices.
+type MockIHealthcheckServicesMockRecorder struct {
+	mock *MockIHealthcheckServices
+}
+
+// NewMockIHealthcheckServices creates a new mock instance.
+func NewMockIHealthcheckServices(ctrl *gomock.Controller) *MockIHealthcheckServices {
+	mock := &MockIHealthcheckServices{ctrl: ctrl}
+	mock.recorder = &MockIHealthcheckServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealthcheckServices) EXPECT() *MockIHealthcheckServicesMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockIHealthcheckServices) Check(ctx context.Context) models.HealthReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(models.HealthReport)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockIHealthcheckServicesMockRecorder) Check(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockIHealthcheckServices)(nil).Check), ctx)
+}
+
+// HealthcheckServices mocks base method.
+func (m *MockIHealthcheckServices) HealthcheckServices() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthcheckServices")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HealthcheckServices indicates an expected call of HealthcheckServices.
+func (mr *MockIHealthcheckServicesMockRecorder) HealthcheckServices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthcheckServices", reflect.TypeOf((*MockIHealthcheckServices)(nil).HealthcheckServices))
+}
+
+// MockIHealthcheckHandler is a mock of IHealthcheckHandler interface.
+type MockIHealthcheckHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthcheckHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIHealthcheckHandlerMockRecorder is the mock recorder for MockIHealthcheckHandler.
+type MockIHealthcheckHandlerMockRecorder struct {
+	mock *MockIHealthcheckHandler
+}
+
+// NewMockIHealthcheckHandler creates a new mock instance.
+func NewMockIHealthcheckHandler(ctrl *gomock.Controller) *MockIHealthcheckHandler {
+	mock := &MockIHealthcheckHandler{ctrl: ctrl}
+	mock.recorder = &MockIHealthcheckHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealthcheckHandler) EXPECT() *MockIHealthcheckHandlerMockRecorder {
+	return m.recorder
+}
+
+// HealthcheckHandlerHTTP mocks base method.
+func (m *MockIHealthcheckHandler) HealthcheckHandlerHTTP(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HealthcheckHandlerHTTP", c)
+}
+
+// HealthcheckHandlerHTTP indicates an expected call of HealthcheckHandlerHTTP.
+func (mr *MockIHealthcheckHandlerMockRecorder) HealthcheckHandlerHTTP(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthcheckHandlerHTTP", reflect.TypeOf((*MockIHealthcheckHandler)(nil).HealthcheckHandlerHTTP), c)
+}
+
+// MockIHealthcheckRepo is a mock of IHealthcheckRepo interface.
+type MockIHealthcheckRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthcheckRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockIHealthcheckRepoMockRecorder is the mock recorder for MockIHealthcheckRepo.
+type MockIHealthcheckRepoMockRecorder struct {
+	mock *MockIHealthcheckRepo
+}
+
+// NewMockIHealthcheckRepo creates a new mock instance.
+func NewMockIHealthcheckRepo(ctrl *gomock.Controller) *MockIHealthcheckRepo {
+	mock := &MockIHealthcheckRepo{ctrl: ctrl}
+	mock.recorder = &MockIHealthcheckRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealthcheckRepo) EXPECT() *MockIHealthcheckRepoMockRecorder {
+	return m.recorder
+}
+
+// PingDB mocks base method.
+func (m *MockIHealthcheckRepo) PingDB(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingDB", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingDB indicates an expected call of PingDB.
+func (mr *MockIHealthcheckRepoMockRecorder) PingDB(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingDB", reflect.TypeOf((*MockIHealthcheckRepo)(nil).PingDB), ctx)
+}
+
+// PingRedis mocks base method.
+func (m *MockIHealthcheckRepo) PingRedis(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingRedis", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingRedis indicates an expected call of PingRedis.
+func (mr *MockIHealthcheckRepoMockRecorder) PingRedis(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingRedis", reflect.TypeOf((*MockIHealthcheckRepo)(nil).PingRedis), ctx)
+}
@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IInbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IInbox.go -destination=../mocks/mock_IInbox.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIInboxRepository is a mock of IInboxRepository interface.
+type MockIInboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIInboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIInboxRepositoryMockRecorder is the mock recorder for MockIInboxRepository.
+type MockIInboxRepositoryMockRecorder struct {
+	mock *MockIInboxRepository
+}
+
+// NewMockIInboxRepository creates a new mock instance.
+func NewMockIInboxRepository(ctrl *gomock.Controller) *MockIInboxRepository {
+	mock := &MockIInboxRepository{ctrl: ctrl}
+	mock.recorder = &MockIInboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIInboxRepository) EXPECT() *MockIInboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimInboxEvent mocks base method.
+func (m *MockIInboxRepository) ClaimInboxEvent(ctx context.Context, event *models.InboxEvent, staleBefore time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimInboxEvent", ctx, event, staleBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimInboxEvent indicates an expected call of ClaimInboxEvent.
+func (mr *MockIInboxRepositoryMockRecorder) ClaimInboxEvent(ctx, event, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimInboxEvent", reflect.TypeOf((*MockIInboxRepository)(nil).ClaimInboxEvent), ctx, event, staleBefore)
+}
+
+// DeleteInboxEvent mocks base method.
+func (m *MockIInboxRepository) DeleteInboxEvent(ctx context.Context, event *models.InboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInboxEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInboxEvent indicates an expected call of DeleteInboxEvent.
+func (mr *MockIInboxRepositoryMockRecorder) DeleteInboxEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInboxEvent", reflect.TypeOf((*MockIInboxRepository)(nil).DeleteInboxEvent), ctx, event)
+}
+
+// MarkInboxEventProcessed mocks base method.
+func (m *MockIInboxRepository) MarkInboxEventProcessed(ctx context.Context, event *models.InboxEvent, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkInboxEventProcessed", ctx, event, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkInboxEventProcessed indicates an expected call of MarkInboxEventProcessed.
+func (mr *MockIInboxRepositoryMockRecorder) MarkInboxEventProcessed(ctx, event, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInboxEventProcessed", reflect.TypeOf((*MockIInboxRepository)(nil).MarkInboxEventProcessed), ctx, event, now)
+}
+
+// MockIInboxService is a mock of IInboxService interface.
+type MockIInboxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIInboxServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIInboxServiceMockRecorder is the mock recorder for MockIInboxService.
+type MockIInboxServiceMockRecorder struct {
+	mock *MockIInboxService
+}
+
+// NewMockIInboxService creates a new mock instance.
+func NewMockIInboxService(ctrl *gomock.Controller) *MockIInboxService {
+	mock := &MockIInboxService{ctrl: ctrl}
+	mock.recorder = &MockIInboxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIInboxService) EXPECT() *MockIInboxServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleEvent mocks base method.
+func (m *MockIInboxService) HandleEvent(ctx context.Context, topic string, payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, topic, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockIInboxServiceMockRecorder) HandleEvent(ctx, topic, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockIInboxService)(nil).HandleEvent), ctx, topic, payload)
+}
+
+// Run mocks base method.
+func (m *MockIInboxService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIInboxServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIInboxService)(nil).Run), ctx)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IIntrospection.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IIntrospection.go -destination=../mocks/mock_IIntrospection.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIIntrospectionService is a mock of IIntrospectionService interface.
+type MockIIntrospectionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIntrospectionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIIntrospectionServiceMockRecorder is the mock recorder for MockIIntrospectionService.
+type MockIIntrospectionServiceMockRecorder struct {
+	mock *MockIIntrospectionService
+}
+
+// NewMockIIntrospectionService creates a new mock instance.
+func NewMockIIntrospectionService(ctrl *gomock.Controller) *MockIIntrospectionService {
+	mock := &MockIIntrospectionService{ctrl: ctrl}
+	mock.recorder = &MockIIntrospectionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIntrospectionService) EXPECT() *MockIIntrospectionServiceMockRecorder {
+	return m.recorder
+}
+
+// Introspect mocks base method.
+func (m *MockIIntrospectionService) Introspect(ctx context.Context, token, tokenTypeHint string) (models.IntrospectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Introspect", ctx, token, tokenTypeHint)
+	ret0, _ := ret[0].(models.IntrospectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Introspect indicates an expected call of Introspect.
+func (mr *MockIIntrospectionServiceMockRecorder) Introspect(ctx, token, tokenTypeHint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Introspect", reflect.TypeOf((*MockIIntrospectionService)(nil).Introspect), ctx, token, tokenTypeHint)
+}
+
+// MockIIntrospectionHandler is a mock of IIntrospectionHandler interface.
+type MockIIntrospectionHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIntrospectionHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIIntrospectionHandlerMockRecorder is the mock recorder for MockIIntrospectionHandler.
+type MockIIntrospectionHandlerMockRecorder struct {
+	mock *MockIIntrospectionHandler
+}
+
+// NewMockIIntrospectionHandler creates a new mock instance.
+func NewMockIIntrospectionHandler(ctrl *gomock.Controller) *MockIIntrospectionHandler {
+	mock := &MockIIntrospectionHandler{ctrl: ctrl}
+	mock.recorder = &MockIIntrospectionHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIntrospectionHandler) EXPECT() *MockIIntrospectionHandlerMockRecorder {
+	return m.recorder
+}
+
+// Introspect mocks base method.
+func (m *MockIIntrospectionHandler) Introspect(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Introspect", c)
+}
+
+// Introspect indicates an expected call of Introspect.
+func (mr *MockIIntrospectionHandlerMockRecorder) Introspect(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Introspect", reflect.TypeOf((*MockIIntrospectionHandler)(nil).Introspect), c)
+}
@@ -0,0 +1,201 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IKyc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IKyc.go -destination=../mocks/mock_IKyc.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIKycRepository is a mock of IKycRepository interface.
+type MockIKycRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIKycRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIKycRepositoryMockRecorder is the mock recorder for MockIKycRepository.
+type MockIKycRepositoryMockRecorder struct {
+	mock *MockIKycRepository
+}
+
+// NewMockIKycRepository creates a new mock instance.
+func NewMockIKycRepository(ctrl *gomock.Controller) *MockIKycRepository {
+	mock := &MockIKycRepository{ctrl: ctrl}
+	mock.recorder = &MockIKycRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIKycRepository) EXPECT() *MockIKycRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetKycProfile mocks base method.
+func (m *MockIKycRepository) GetKycProfile(ctx context.Context, userID int) (models.KycProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKycProfile", ctx, userID)
+	ret0, _ := ret[0].(models.KycProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKycProfile indicates an expected call of GetKycProfile.
+func (mr *MockIKycRepositoryMockRecorder) GetKycProfile(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKycProfile", reflect.TypeOf((*MockIKycRepository)(nil).GetKycProfile), ctx, userID)
+}
+
+// UpsertKycProfile mocks base method.
+func (m *MockIKycRepository) UpsertKycProfile(ctx context.Context, profile *models.KycProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertKycProfile", ctx, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertKycProfile indicates an expected call of UpsertKycProfile.
+func (mr *MockIKycRepositoryMockRecorder) UpsertKycProfile(ctx, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertKycProfile", reflect.TypeOf((*MockIKycRepository)(nil).UpsertKycProfile), ctx, profile)
+}
+
+// MockIKycService is a mock of IKycService interface.
+type MockIKycService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIKycServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIKycServiceMockRecorder is the mock recorder for MockIKycService.
+type MockIKycServiceMockRecorder struct {
+	mock *MockIKycService
+}
+
+// NewMockIKycService creates a new mock instance.
+func NewMockIKycService(ctrl *gomock.Controller) *MockIKycService {
+	mock := &MockIKycService{ctrl: ctrl}
+	mock.recorder = &MockIKycServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIKycService) EXPECT() *MockIKycServiceMockRecorder {
+	return m.recorder
+}
+
+// GetKycProfile mocks base method.
+func (m *MockIKycService) GetKycProfile(ctx context.Context, userID int) (models.KycProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKycProfile", ctx, userID)
+	ret0, _ := ret[0].(models.KycProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKycProfile indicates an expected call of GetKycProfile.
+func (mr *MockIKycServiceMockRecorder) GetKycProfile(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKycProfile", reflect.TypeOf((*MockIKycService)(nil).GetKycProfile), ctx, userID)
+}
+
+// GetUserKycProfile mocks base method.
+func (m *MockIKycService) GetUserKycProfile(ctx context.Context, userID int) (models.KycProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserKycProfile", ctx, userID)
+	ret0, _ := ret[0].(models.KycProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserKycProfile indicates an expected call of GetUserKycProfile.
+func (mr *MockIKycServiceMockRecorder) GetUserKycProfile(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserKycProfile", reflect.TypeOf((*MockIKycService)(nil).GetUserKycProfile), ctx, userID)
+}
+
+// UpdateKycProfile mocks base method.
+func (m *MockIKycService) UpdateKycProfile(ctx context.Context, userID int, req models.KycProfileRequest) (models.KycProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKycProfile", ctx, userID, req)
+	ret0, _ := ret[0].(models.KycProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateKycProfile indicates an expected call of UpdateKycProfile.
+func (mr *MockIKycServiceMockRecorder) UpdateKycProfile(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKycProfile", reflect.TypeOf((*MockIKycService)(nil).UpdateKycProfile), ctx, userID, req)
+}
+
+// MockIKycHandler is a mock of IKycHandler interface.
+type MockIKycHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIKycHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIKycHandlerMockRecorder is the mock recorder for MockIKycHandler.
+type MockIKycHandlerMockRecorder struct {
+	mock *MockIKycHandler
+}
+
+// NewMockIKycHandler creates a new mock instance.
+func NewMockIKycHandler(ctrl *gomock.Controller) *MockIKycHandler {
+	mock := &MockIKycHandler{ctrl: ctrl}
+	mock.recorder = &MockIKycHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIKycHandler) EXPECT() *MockIKycHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetKycProfile mocks base method.
+func (m *MockIKycHandler) GetKycProfile(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetKycProfile", c)
+}
+
+// GetKycProfile indicates an expected call of GetKycProfile.
+func (mr *MockIKycHandlerMockRecorder) GetKycProfile(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKycProfile", reflect.TypeOf((*MockIKycHandler)(nil).GetKycProfile), c)
+}
+
+// GetUserKycProfile mocks base method.
+func (m *MockIKycHandler) GetUserKycProfile(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUserKycProfile", c)
+}
+
+// GetUserKycProfile indicates an expected call of GetUserKycProfile.
+func (mr *MockIKycHandlerMockRecorder) GetUserKycProfile(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserKycProfile", reflect.TypeOf((*MockIKycHandler)(nil).GetUserKycProfile), c)
+}
+
+// UpdateKycProfile mocks base method.
+func (m *MockIKycHandler) UpdateKycProfile(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateKycProfile", c)
+}
+
+// UpdateKycProfile indicates an expected call of UpdateKycProfile.
+func (mr *MockIKycHandlerMockRecorder) UpdateKycProfile(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKycProfile", reflect.TypeOf((*MockIKycHandler)(nil).UpdateKycProfile), c)
+}
@@ -0,0 +1,246 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ILegalHold.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ILegalHold.go -destination=../mocks/mock_ILegalHold.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILegalHoldRepository is a mock of ILegalHoldRepository interface.
+type MockILegalHoldRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILegalHoldRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockILegalHoldRepositoryMockRecorder is the mock recorder for MockILegalHoldRepository.
+type MockILegalHoldRepositoryMockRecorder struct {
+	mock *MockILegalHoldRepository
+}
+
+// NewMockILegalHoldRepository creates a new mock instance.
+func NewMockILegalHoldRepository(ctrl *gomock.Controller) *MockILegalHoldRepository {
+	mock := &MockILegalHoldRepository{ctrl: ctrl}
+	mock.recorder = &MockILegalHoldRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILegalHoldRepository) EXPECT() *MockILegalHoldRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLegalHoldByID mocks base method.
+func (m *MockILegalHoldRepository) GetLegalHoldByID(ctx context.Context, holdID int) (models.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegalHoldByID", ctx, holdID)
+	ret0, _ := ret[0].(models.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLegalHoldByID indicates an expected call of GetLegalHoldByID.
+func (mr *MockILegalHoldRepositoryMockRecorder) GetLegalHoldByID(ctx, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegalHoldByID", reflect.TypeOf((*MockILegalHoldRepository)(nil).GetLegalHoldByID), ctx, holdID)
+}
+
+// GetLegalHoldsByUserID mocks base method.
+func (m *MockILegalHoldRepository) GetLegalHoldsByUserID(ctx context.Context, userID int) ([]models.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegalHoldsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]models.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLegalHoldsByUserID indicates an expected call of GetLegalHoldsByUserID.
+func (mr *MockILegalHoldRepositoryMockRecorder) GetLegalHoldsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegalHoldsByUserID", reflect.TypeOf((*MockILegalHoldRepository)(nil).GetLegalHoldsByUserID), ctx, userID)
+}
+
+// HasActiveLegalHold mocks base method.
+func (m *MockILegalHoldRepository) HasActiveLegalHold(ctx context.Context, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveLegalHold", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasActiveLegalHold indicates an expected call of HasActiveLegalHold.
+func (mr *MockILegalHoldRepositoryMockRecorder) HasActiveLegalHold(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveLegalHold", reflect.TypeOf((*MockILegalHoldRepository)(nil).HasActiveLegalHold), ctx, userID)
+}
+
+// InsertLegalHold mocks base method.
+func (m *MockILegalHoldRepository) InsertLegalHold(ctx context.Context, hold *models.LegalHold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLegalHold", ctx, hold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertLegalHold indicates an expected call of InsertLegalHold.
+func (mr *MockILegalHoldRepositoryMockRecorder) InsertLegalHold(ctx, hold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLegalHold", reflect.TypeOf((*MockILegalHoldRepository)(nil).InsertLegalHold), ctx, hold)
+}
+
+// LiftLegalHold mocks base method.
+func (m *MockILegalHoldRepository) LiftLegalHold(ctx context.Context, hold *models.LegalHold) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LiftLegalHold", ctx, hold)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LiftLegalHold indicates an expected call of LiftLegalHold.
+func (mr *MockILegalHoldRepositoryMockRecorder) LiftLegalHold(ctx, hold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiftLegalHold", reflect.TypeOf((*MockILegalHoldRepository)(nil).LiftLegalHold), ctx, hold)
+}
+
+// MockILegalHoldService is a mock of ILegalHoldService interface.
+type MockILegalHoldService struct {
+	ctrl     *gomock.Controller
+	recorder *MockILegalHoldServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockILegalHoldServiceMockRecorder is the mock recorder for MockILegalHoldService.
+type MockILegalHoldServiceMockRecorder struct {
+	mock *MockILegalHoldService
+}
+
+// NewMockILegalHoldService creates a new mock instance.
+func NewMockILegalHoldService(ctrl *gomock.Controller) *MockILegalHoldService {
+	mock := &MockILegalHoldService{ctrl: ctrl}
+	mock.recorder = &MockILegalHoldServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILegalHoldService) EXPECT() *MockILegalHoldServiceMockRecorder {
+	return m.recorder
+}
+
+// GetHolds mocks base method.
+func (m *MockILegalHoldService) GetHolds(ctx context.Context, userID int) ([]models.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHolds", ctx, userID)
+	ret0, _ := ret[0].([]models.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHolds indicates an expected call of GetHolds.
+func (mr *MockILegalHoldServiceMockRecorder) GetHolds(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolds", reflect.TypeOf((*MockILegalHoldService)(nil).GetHolds), ctx, userID)
+}
+
+// LiftHold mocks base method.
+func (m *MockILegalHoldService) LiftHold(ctx context.Context, holdID, liftedBy int, reason string) (models.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LiftHold", ctx, holdID, liftedBy, reason)
+	ret0, _ := ret[0].(models.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LiftHold indicates an expected call of LiftHold.
+func (mr *MockILegalHoldServiceMockRecorder) LiftHold(ctx, holdID, liftedBy, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiftHold", reflect.TypeOf((*MockILegalHoldService)(nil).LiftHold), ctx, holdID, liftedBy, reason)
+}
+
+// PlaceHold mocks base method.
+func (m *MockILegalHoldService) PlaceHold(ctx context.Context, userID, placedBy int, reason string) (models.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceHold", ctx, userID, placedBy, reason)
+	ret0, _ := ret[0].(models.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceHold indicates an expected call of PlaceHold.
+func (mr *MockILegalHoldServiceMockRecorder) PlaceHold(ctx, userID, placedBy, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHold", reflect.TypeOf((*MockILegalHoldService)(nil).PlaceHold), ctx, userID, placedBy, reason)
+}
+
+// MockILegalHoldHandler is a mock of ILegalHoldHandler interface.
+type MockILegalHoldHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockILegalHoldHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockILegalHoldHandlerMockRecorder is the mock recorder for MockILegalHoldHandler.
+type MockILegalHoldHandlerMockRecorder struct {
+	mock *MockILegalHoldHandler
+}
+
+// NewMockILegalHoldHandler creates a new mock instance.
+func NewMockILegalHoldHandler(ctrl *gomock.Controller) *MockILegalHoldHandler {
+	mock := &MockILegalHoldHandler{ctrl: ctrl}
+	mock.recorder = &MockILegalHoldHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILegalHoldHandler) EXPECT() *MockILegalHoldHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetHolds mocks base method.
+func (m *MockILegalHoldHandler) GetHolds(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetHolds", c)
+}
+
+// GetHolds indicates an expected call of GetHolds.
+func (mr *MockILegalHoldHandlerMockRecorder) GetHolds(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolds", reflect.TypeOf((*MockILegalHoldHandler)(nil).GetHolds), c)
+}
+
+// LiftHold mocks base method.
+func (m *MockILegalHoldHandler) LiftHold(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LiftHold", c)
+}
+
+// LiftHold indicates an expected call of LiftHold.
+func (mr *MockILegalHoldHandlerMockRecorder) LiftHold(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiftHold", reflect.TypeOf((*MockILegalHoldHandler)(nil).LiftHold), c)
+}
+
+// PlaceHold mocks base method.
+func (m *MockILegalHoldHandler) PlaceHold(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PlaceHold", c)
+}
+
+// PlaceHold indicates an expected call of PlaceHold.
+func (mr *MockILegalHoldHandlerMockRecorder) PlaceHold(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHold", reflect.TypeOf((*MockILegalHoldHandler)(nil).PlaceHold), c)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ILocker.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ILocker.go -destination=../mocks/mock_ILocker.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	interfaces "ewallet-ums/internal/interfaces"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILocker is a mock of ILocker interface.
+type MockILocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockILockerMockRecorder
+	isgomock struct{}
+}
+
+// MockILockerMockRecorder is the mock recorder for MockILocker.
+type MockILockerMockRecorder struct {
+	mock *MockILocker
+}
+
+// NewMockILocker creates a new mock instance.
+func NewMockILocker(ctrl *gomock.Controller) *MockILocker {
+	mock := &MockILocker{ctrl: ctrl}
+	mock.recorder = &MockILockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILocker) EXPECT() *MockILockerMockRecorder {
+	return m.recorder
+}
+
+// TryLock mocks base method.
+func (m *MockILocker) TryLock(ctx context.Context, name string, ttl time.Duration) (interfaces.ILock, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, name, ttl)
+	ret0, _ := ret[0].(interfaces.ILock)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockILockerMockRecorder) TryLock(ctx, name, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockILocker)(nil).TryLock), ctx, name, ttl)
+}
+
+// MockILock is a mock of ILock interface.
+type MockILock struct {
+	ctrl     *gomock.Controller
+	recorder *MockILockMockRecorder
+	isgomock struct{}
+}
+
+// MockILockMockRecorder is the mock recorder for MockILock.
+type MockILockMockRecorder struct {
+	mock *MockILock
+}
+
+// NewMockILock creates a new mock instance.
+func NewMockILock(ctrl *gomock.Controller) *MockILock {
+	mock := &MockILock{ctrl: ctrl}
+	mock.recorder = &MockILockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILock) EXPECT() *MockILockMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockILock) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockILockMockRecorder) Refresh(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockILock)(nil).Refresh), ctx)
+}
+
+// Unlock mocks base method.
+func (m *MockILock) Unlock(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockILockMockRecorder) Unlock(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockILock)(nil).Unlock), ctx)
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ILogLevel.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ILogLevel.go -destination=../mocks/mock_ILogLevel.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILogLevelService is a mock of ILogLevelService interface.
+type MockILogLevelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockILogLevelServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockILogLevelServiceMockRecorder is the mock recorder for MockILogLevelService.
+type MockILogLevelServiceMockRecorder struct {
+	mock *MockILogLevelService
+}
+
+// NewMockILogLevelService creates a new mock instance.
+func NewMockILogLevelService(ctrl *gomock.Controller) *MockILogLevelService {
+	mock := &MockILogLevelService{ctrl: ctrl}
+	mock.recorder = &MockILogLevelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILogLevelService) EXPECT() *MockILogLevelServiceMockRecorder {
+	return m.recorder
+}
+
+// GetLogLevel mocks base method.
+func (m *MockILogLevelService) GetLogLevel(ctx context.Context) models.LogLevelResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogLevel", ctx)
+	ret0, _ := ret[0].(models.LogLevelResponse)
+	return ret0
+}
+
+// GetLogLevel indicates an expected call of GetLogLevel.
+func (mr *MockILogLevelServiceMockRecorder) GetLogLevel(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogLevel", reflect.TypeOf((*MockILogLevelService)(nil).GetLogLevel), ctx)
+}
+
+// SetLogLevel mocks base method.
+func (m *MockILogLevelService) SetLogLevel(ctx context.Context, actor, level string) (models.LogLevelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLogLevel", ctx, actor, level)
+	ret0, _ := ret[0].(models.LogLevelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLogLevel indicates an expected call of SetLogLevel.
+func (mr *MockILogLevelServiceMockRecorder) SetLogLevel(ctx, actor, level any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogLevel", reflect.TypeOf((*MockILogLevelService)(nil).SetLogLevel), ctx, actor, level)
+}
+
+// MockILogLevelHandler is a mock of ILogLevelHandler interface.
+type MockILogLevelHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockILogLevelHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockILogLevelHandlerMockRecorder is the mock recorder for MockILogLevelHandler.
+type MockILogLevelHandlerMockRecorder struct {
+	mock *MockILogLevelHandler
+}
+
+// NewMockILogLevelHandler creates a new mock instance.
+func NewMockILogLevelHandler(ctrl *gomock.Controller) *MockILogLevelHandler {
+	mock := &MockILogLevelHandler{ctrl: ctrl}
+	mock.recorder = &MockILogLevelHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILogLevelHandler) EXPECT() *MockILogLevelHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetLogLevel mocks base method.
+func (m *MockILogLevelHandler) GetLogLevel(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetLogLevel", c)
+}
+
+// GetLogLevel indicates an expected call of GetLogLevel.
+func (mr *MockILogLevelHandlerMockRecorder) GetLogLevel(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogLevel", reflect.TypeOf((*MockILogLevelHandler)(nil).GetLogLevel), c)
+}
+
+// SetLogLevel mocks base method.
+func (m *MockILogLevelHandler) SetLogLevel(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLogLevel", c)
+}
+
+// SetLogLevel indicates an expected call of SetLogLevel.
+func (mr *MockILogLevelHandlerMockRecorder) SetLogLevel(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogLevel", reflect.TypeOf((*MockILogLevelHandler)(nil).SetLogLevel), c)
+}
@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ILoginHistory.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ILoginHistory.go -destination=../mocks/mock_ILoginHistory.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILoginHistoryRepository is a mock of ILoginHistoryRepository interface.
+type MockILoginHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockILoginHistoryRepositoryMockRecorder is the mock recorder for MockILoginHistoryRepository.
+type MockILoginHistoryRepositoryMockRecorder struct {
+	mock *MockILoginHistoryRepository
+}
+
+// NewMockILoginHistoryRepository creates a new mock instance.
+func NewMockILoginHistoryRepository(ctrl *gomock.Controller) *MockILoginHistoryRepository {
+	mock := &MockILoginHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockILoginHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginHistoryRepository) EXPECT() *MockILoginHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLoginHistoriesByUserID mocks base method.
+func (m *MockILoginHistoryRepository) GetLoginHistoriesByUserID(ctx context.Context, userID int, cursor *pagination.Cursor, limit int) ([]models.LoginHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginHistoriesByUserID", ctx, userID, cursor, limit)
+	ret0, _ := ret[0].([]models.LoginHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginHistoriesByUserID indicates an expected call of GetLoginHistoriesByUserID.
+func (mr *MockILoginHistoryRepositoryMockRecorder) GetLoginHistoriesByUserID(ctx, userID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginHistoriesByUserID", reflect.TypeOf((*MockILoginHistoryRepository)(nil).GetLoginHistoriesByUserID), ctx, userID, cursor, limit)
+}
+
+// HasSuccessfulLogin mocks base method.
+func (m *MockILoginHistoryRepository) HasSuccessfulLogin(ctx context.Context, userID int, userAgent string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSuccessfulLogin", ctx, userID, userAgent)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasSuccessfulLogin indicates an expected call of HasSuccessfulLogin.
+func (mr *MockILoginHistoryRepositoryMockRecorder) HasSuccessfulLogin(ctx, userID, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSuccessfulLogin", reflect.TypeOf((*MockILoginHistoryRepository)(nil).HasSuccessfulLogin), ctx, userID, userAgent)
+}
+
+// HasSuccessfulLoginFromCountry mocks base method.
+func (m *MockILoginHistoryRepository) HasSuccessfulLoginFromCountry(ctx context.Context, userID int, country string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSuccessfulLoginFromCountry", ctx, userID, country)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasSuccessfulLoginFromCountry indicates an expected call of HasSuccessfulLoginFromCountry.
+func (mr *MockILoginHistoryRepositoryMockRecorder) HasSuccessfulLoginFromCountry(ctx, userID, country any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSuccessfulLoginFromCountry", reflect.TypeOf((*MockILoginHistoryRepository)(nil).HasSuccessfulLoginFromCountry), ctx, userID, country)
+}
+
+// InsertLoginHistory mocks base method.
+func (m *MockILoginHistoryRepository) InsertLoginHistory(ctx context.Context, history *models.LoginHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLoginHistory", ctx, history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertLoginHistory indicates an expected call of InsertLoginHistory.
+func (mr *MockILoginHistoryRepositoryMockRecorder) InsertLoginHistory(ctx, history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLoginHistory", reflect.TypeOf((*MockILoginHistoryRepository)(nil).InsertLoginHistory), ctx, history)
+}
+
+// MockILoginHistoryService is a mock of ILoginHistoryService interface.
+type MockILoginHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginHistoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockILoginHistoryServiceMockRecorder is the mock recorder for MockILoginHistoryService.
+type MockILoginHistoryServiceMockRecorder struct {
+	mock *MockILoginHistoryService
+}
+
+// NewMockILoginHistoryService creates a new mock instance.
+func NewMockILoginHistoryService(ctrl *gomock.Controller) *MockILoginHistoryService {
+	mock := &MockILoginHistoryService{ctrl: ctrl}
+	mock.recorder = &MockILoginHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginHistoryService) EXPECT() *MockILoginHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// GetLoginHistories mocks base method.
+func (m *MockILoginHistoryService) GetLoginHistories(ctx context.Context, userID int, req pagination.Request) (pagination.Page[models.LoginHistory], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginHistories", ctx, userID, req)
+	ret0, _ := ret[0].(pagination.Page[models.LoginHistory])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginHistories indicates an expected call of GetLoginHistories.
+func (mr *MockILoginHistoryServiceMockRecorder) GetLoginHistories(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginHistories", reflect.TypeOf((*MockILoginHistoryService)(nil).GetLoginHistories), ctx, userID, req)
+}
+
+// MockILoginHistoryHandler is a mock of ILoginHistoryHandler interface.
+type MockILoginHistoryHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginHistoryHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockILoginHistoryHandlerMockRecorder is the mock recorder for MockILoginHistoryHandler.
+type MockILoginHistoryHandlerMockRecorder struct {
+	mock *MockILoginHistoryHandler
+}
+
+// NewMockILoginHistoryHandler creates a new mock instance.
+func NewMockILoginHistoryHandler(ctrl *gomock.Controller) *MockILoginHistoryHandler {
+	mock := &MockILoginHistoryHandler{ctrl: ctrl}
+	mock.recorder = &MockILoginHistoryHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginHistoryHandler) EXPECT() *MockILoginHistoryHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetLoginHistories mocks base method.
+func (m *MockILoginHistoryHandler) GetLoginHistories(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetLoginHistories", c)
+}
+
+// GetLoginHistories indicates an expected call of GetLoginHistories.
+func (mr *MockILoginHistoryHandlerMockRecorder) GetLoginHistories(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginHistories", reflect.TypeOf((*MockILoginHistoryHandler)(nil).GetLoginHistories), c)
+}
@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ILoginVelocity.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ILoginVelocity.go -destination=../mocks/mock_ILoginVelocity.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILoginVelocityRepository is a mock of ILoginVelocityRepository interface.
+type MockILoginVelocityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginVelocityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockILoginVelocityRepositoryMockRecorder is the mock recorder for MockILoginVelocityRepository.
+type MockILoginVelocityRepositoryMockRecorder struct {
+	mock *MockILoginVelocityRepository
+}
+
+// NewMockILoginVelocityRepository creates a new mock instance.
+func NewMockILoginVelocityRepository(ctrl *gomock.Controller) *MockILoginVelocityRepository {
+	mock := &MockILoginVelocityRepository{ctrl: ctrl}
+	mock.recorder = &MockILoginVelocityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginVelocityRepository) EXPECT() *MockILoginVelocityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLoginFailureCounts mocks base method.
+func (m *MockILoginVelocityRepository) GetLoginFailureCounts(ctx context.Context, now time.Time, window time.Duration) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginFailureCounts", ctx, now, window)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginFailureCounts indicates an expected call of GetLoginFailureCounts.
+func (mr *MockILoginVelocityRepositoryMockRecorder) GetLoginFailureCounts(ctx, now, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginFailureCounts", reflect.TypeOf((*MockILoginVelocityRepository)(nil).GetLoginFailureCounts), ctx, now, window)
+}
+
+// MarkLoginVelocityAlerted mocks base method.
+func (m *MockILoginVelocityRepository) MarkLoginVelocityAlerted(ctx context.Context, source string, window time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkLoginVelocityAlerted", ctx, source, window)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkLoginVelocityAlerted indicates an expected call of MarkLoginVelocityAlerted.
+func (mr *MockILoginVelocityRepositoryMockRecorder) MarkLoginVelocityAlerted(ctx, source, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLoginVelocityAlerted", reflect.TypeOf((*MockILoginVelocityRepository)(nil).MarkLoginVelocityAlerted), ctx, source, window)
+}
+
+// RecordLoginFailure mocks base method.
+func (m *MockILoginVelocityRepository) RecordLoginFailure(ctx context.Context, source string, now time.Time, window time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginFailure", ctx, source, now, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordLoginFailure indicates an expected call of RecordLoginFailure.
+func (mr *MockILoginVelocityRepositoryMockRecorder) RecordLoginFailure(ctx, source, now, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailure", reflect.TypeOf((*MockILoginVelocityRepository)(nil).RecordLoginFailure), ctx, source, now, window)
+}
+
+// MockILoginVelocityService is a mock of ILoginVelocityService interface.
+type MockILoginVelocityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginVelocityServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockILoginVelocityServiceMockRecorder is the mock recorder for MockILoginVelocityService.
+type MockILoginVelocityServiceMockRecorder struct {
+	mock *MockILoginVelocityService
+}
+
+// NewMockILoginVelocityService creates a new mock instance.
+func NewMockILoginVelocityService(ctrl *gomock.Controller) *MockILoginVelocityService {
+	mock := &MockILoginVelocityService{ctrl: ctrl}
+	mock.recorder = &MockILoginVelocityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginVelocityService) EXPECT() *MockILoginVelocityServiceMockRecorder {
+	return m.recorder
+}
+
+// GetHotSources mocks base method.
+func (m *MockILoginVelocityService) GetHotSources(ctx context.Context) ([]models.HotLoginSource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotSources", ctx)
+	ret0, _ := ret[0].([]models.HotLoginSource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotSources indicates an expected call of GetHotSources.
+func (mr *MockILoginVelocityServiceMockRecorder) GetHotSources(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotSources", reflect.TypeOf((*MockILoginVelocityService)(nil).GetHotSources), ctx)
+}
+
+// RecordFailure mocks base method.
+func (m *MockILoginVelocityService) RecordFailure(ctx context.Context, sources []models.LoginSource) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordFailure", ctx, sources)
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockILoginVelocityServiceMockRecorder) RecordFailure(ctx, sources any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockILoginVelocityService)(nil).RecordFailure), ctx, sources)
+}
+
+// MockILoginVelocityHandler is a mock of ILoginVelocityHandler interface.
+type MockILoginVelocityHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginVelocityHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockILoginVelocityHandlerMockRecorder is the mock recorder for MockILoginVelocityHandler.
+type MockILoginVelocityHandlerMockRecorder struct {
+	mock *MockILoginVelocityHandler
+}
+
+// NewMockILoginVelocityHandler creates a new mock instance.
+func NewMockILoginVelocityHandler(ctrl *gomock.Controller) *MockILoginVelocityHandler {
+	mock := &MockILoginVelocityHandler{ctrl: ctrl}
+	mock.recorder = &MockILoginVelocityHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginVelocityHandler) EXPECT() *MockILoginVelocityHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetHotSources mocks base method.
+func (m *MockILoginVelocityHandler) GetHotSources(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetHotSources", c)
+}
+
+// GetHotSources indicates an expected call of GetHotSources.
+func (mr *MockILoginVelocityHandlerMockRecorder) GetHotSources(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotSources", reflect.TypeOf((*MockILoginVelocityHandler)(nil).GetHotSources), c)
+}
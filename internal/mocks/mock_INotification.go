@@ -0,0 +1,688 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: INotification.go
+//
+// Generated by this command:
+//
+//	mockgen -source=INotification.go -destination=../mocks/mock_INotification.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockINotificationRepository is a mock of INotificationRepository interface.
+type MockINotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockINotificationRepositoryMockRecorder is the mock recorder for MockINotificationRepository.
+type MockINotificationRepositoryMockRecorder struct {
+	mock *MockINotificationRepository
+}
+
+// NewMockINotificationRepository creates a new mock instance.
+func NewMockINotificationRepository(ctrl *gomock.Controller) *MockINotificationRepository {
+	mock := &MockINotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockINotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationRepository) EXPECT() *MockINotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeferPendingNotifications mocks base method.
+func (m *MockINotificationRepository) DeferPendingNotifications(ctx context.Context, userID int, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeferPendingNotifications", ctx, userID, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeferPendingNotifications indicates an expected call of DeferPendingNotifications.
+func (mr *MockINotificationRepositoryMockRecorder) DeferPendingNotifications(ctx, userID, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferPendingNotifications", reflect.TypeOf((*MockINotificationRepository)(nil).DeferPendingNotifications), ctx, userID, until)
+}
+
+// DeletePendingNotifications mocks base method.
+func (m *MockINotificationRepository) DeletePendingNotifications(ctx context.Context, ids []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingNotifications", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingNotifications indicates an expected call of DeletePendingNotifications.
+func (mr *MockINotificationRepositoryMockRecorder) DeletePendingNotifications(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingNotifications", reflect.TypeOf((*MockINotificationRepository)(nil).DeletePendingNotifications), ctx, ids)
+}
+
+// DeleteQuietHours mocks base method.
+func (m *MockINotificationRepository) DeleteQuietHours(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuietHours", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuietHours indicates an expected call of DeleteQuietHours.
+func (mr *MockINotificationRepositoryMockRecorder) DeleteQuietHours(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuietHours", reflect.TypeOf((*MockINotificationRepository)(nil).DeleteQuietHours), ctx, userID)
+}
+
+// GetDuePendingNotificationUsers mocks base method.
+func (m *MockINotificationRepository) GetDuePendingNotificationUsers(ctx context.Context, now time.Time, afterUserID, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuePendingNotificationUsers", ctx, now, afterUserID, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuePendingNotificationUsers indicates an expected call of GetDuePendingNotificationUsers.
+func (mr *MockINotificationRepositoryMockRecorder) GetDuePendingNotificationUsers(ctx, now, afterUserID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuePendingNotificationUsers", reflect.TypeOf((*MockINotificationRepository)(nil).GetDuePendingNotificationUsers), ctx, now, afterUserID, limit)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *MockINotificationRepository) GetNotificationPreferences(ctx context.Context, userID int) ([]models.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", ctx, userID)
+	ret0, _ := ret[0].([]models.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *MockINotificationRepositoryMockRecorder) GetNotificationPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*MockINotificationRepository)(nil).GetNotificationPreferences), ctx, userID)
+}
+
+// GetPendingNotifications mocks base method.
+func (m *MockINotificationRepository) GetPendingNotifications(ctx context.Context, userID int) ([]models.PendingNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingNotifications", ctx, userID)
+	ret0, _ := ret[0].([]models.PendingNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingNotifications indicates an expected call of GetPendingNotifications.
+func (mr *MockINotificationRepositoryMockRecorder) GetPendingNotifications(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNotifications", reflect.TypeOf((*MockINotificationRepository)(nil).GetPendingNotifications), ctx, userID)
+}
+
+// GetQuietHours mocks base method.
+func (m *MockINotificationRepository) GetQuietHours(ctx context.Context, userID int) (models.NotificationQuietHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuietHours", ctx, userID)
+	ret0, _ := ret[0].(models.NotificationQuietHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuietHours indicates an expected call of GetQuietHours.
+func (mr *MockINotificationRepositoryMockRecorder) GetQuietHours(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuietHours", reflect.TypeOf((*MockINotificationRepository)(nil).GetQuietHours), ctx, userID)
+}
+
+// InsertPendingNotification mocks base method.
+func (m *MockINotificationRepository) InsertPendingNotification(ctx context.Context, notification *models.PendingNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPendingNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPendingNotification indicates an expected call of InsertPendingNotification.
+func (mr *MockINotificationRepositoryMockRecorder) InsertPendingNotification(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPendingNotification", reflect.TypeOf((*MockINotificationRepository)(nil).InsertPendingNotification), ctx, notification)
+}
+
+// UpsertNotificationPreferences mocks base method.
+func (m *MockINotificationRepository) UpsertNotificationPreferences(ctx context.Context, preferences []models.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationPreferences", ctx, preferences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertNotificationPreferences indicates an expected call of UpsertNotificationPreferences.
+func (mr *MockINotificationRepositoryMockRecorder) UpsertNotificationPreferences(ctx, preferences any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationPreferences", reflect.TypeOf((*MockINotificationRepository)(nil).UpsertNotificationPreferences), ctx, preferences)
+}
+
+// UpsertQuietHours mocks base method.
+func (m *MockINotificationRepository) UpsertQuietHours(ctx context.Context, quietHours *models.NotificationQuietHours) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertQuietHours", ctx, quietHours)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertQuietHours indicates an expected call of UpsertQuietHours.
+func (mr *MockINotificationRepositoryMockRecorder) UpsertQuietHours(ctx, quietHours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertQuietHours", reflect.TypeOf((*MockINotificationRepository)(nil).UpsertQuietHours), ctx, quietHours)
+}
+
+// MockINotificationThrottleRepository is a mock of INotificationThrottleRepository interface.
+type MockINotificationThrottleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationThrottleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockINotificationThrottleRepositoryMockRecorder is the mock recorder for MockINotificationThrottleRepository.
+type MockINotificationThrottleRepositoryMockRecorder struct {
+	mock *MockINotificationThrottleRepository
+}
+
+// NewMockINotificationThrottleRepository creates a new mock instance.
+func NewMockINotificationThrottleRepository(ctrl *gomock.Controller) *MockINotificationThrottleRepository {
+	mock := &MockINotificationThrottleRepository{ctrl: ctrl}
+	mock.recorder = &MockINotificationThrottleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationThrottleRepository) EXPECT() *MockINotificationThrottleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimNotification mocks base method.
+func (m *MockINotificationThrottleRepository) ClaimNotification(ctx context.Context, userID int, event string, window time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNotification", ctx, userID, event, window)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNotification indicates an expected call of ClaimNotification.
+func (mr *MockINotificationThrottleRepositoryMockRecorder) ClaimNotification(ctx, userID, event, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNotification", reflect.TypeOf((*MockINotificationThrottleRepository)(nil).ClaimNotification), ctx, userID, event, window)
+}
+
+// MockINotificationService is a mock of INotificationService interface.
+type MockINotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockINotificationServiceMockRecorder is the mock recorder for MockINotificationService.
+type MockINotificationServiceMockRecorder struct {
+	mock *MockINotificationService
+}
+
+// NewMockINotificationService creates a new mock instance.
+func NewMockINotificationService(ctrl *gomock.Controller) *MockINotificationService {
+	mock := &MockINotificationService{ctrl: ctrl}
+	mock.recorder = &MockINotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationService) EXPECT() *MockINotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteQuietHours mocks base method.
+func (m *MockINotificationService) DeleteQuietHours(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuietHours", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuietHours indicates an expected call of DeleteQuietHours.
+func (mr *MockINotificationServiceMockRecorder) DeleteQuietHours(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuietHours", reflect.TypeOf((*MockINotificationService)(nil).DeleteQuietHours), ctx, userID)
+}
+
+// GetPreferences mocks base method.
+func (m *MockINotificationService) GetPreferences(ctx context.Context, userID int) ([]models.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].([]models.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockINotificationServiceMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockINotificationService)(nil).GetPreferences), ctx, userID)
+}
+
+// GetQuietHours mocks base method.
+func (m *MockINotificationService) GetQuietHours(ctx context.Context, userID int) (*models.NotificationQuietHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuietHours", ctx, userID)
+	ret0, _ := ret[0].(*models.NotificationQuietHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuietHours indicates an expected call of GetQuietHours.
+func (mr *MockINotificationServiceMockRecorder) GetQuietHours(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuietHours", reflect.TypeOf((*MockINotificationService)(nil).GetQuietHours), ctx, userID)
+}
+
+// NotifySecurityEvent mocks base method.
+func (m *MockINotificationService) NotifySecurityEvent(ctx context.Context, userID int, event models.SecurityEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifySecurityEvent", ctx, userID, event)
+}
+
+// NotifySecurityEvent indicates an expected call of NotifySecurityEvent.
+func (mr *MockINotificationServiceMockRecorder) NotifySecurityEvent(ctx, userID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifySecurityEvent", reflect.TypeOf((*MockINotificationService)(nil).NotifySecurityEvent), ctx, userID, event)
+}
+
+// SendActivityDigest mocks base method.
+func (m *MockINotificationService) SendActivityDigest(ctx context.Context, userID int, summary models.ActivitySummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendActivityDigest", ctx, userID, summary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendActivityDigest indicates an expected call of SendActivityDigest.
+func (mr *MockINotificationServiceMockRecorder) SendActivityDigest(ctx, userID, summary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendActivityDigest", reflect.TypeOf((*MockINotificationService)(nil).SendActivityDigest), ctx, userID, summary)
+}
+
+// SendPasswordResetLink mocks base method.
+func (m *MockINotificationService) SendPasswordResetLink(ctx context.Context, userID int, to string, link models.EmailLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPasswordResetLink", ctx, userID, to, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPasswordResetLink indicates an expected call of SendPasswordResetLink.
+func (mr *MockINotificationServiceMockRecorder) SendPasswordResetLink(ctx, userID, to, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordResetLink", reflect.TypeOf((*MockINotificationService)(nil).SendPasswordResetLink), ctx, userID, to, link)
+}
+
+// SendSecurityEmail mocks base method.
+func (m *MockINotificationService) SendSecurityEmail(ctx context.Context, userID int, event models.SecurityEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSecurityEmail", ctx, userID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSecurityEmail indicates an expected call of SendSecurityEmail.
+func (mr *MockINotificationServiceMockRecorder) SendSecurityEmail(ctx, userID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSecurityEmail", reflect.TypeOf((*MockINotificationService)(nil).SendSecurityEmail), ctx, userID, event)
+}
+
+// SendSecuritySummary mocks base method.
+func (m *MockINotificationService) SendSecuritySummary(ctx context.Context, userID int, pending []models.PendingNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSecuritySummary", ctx, userID, pending)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSecuritySummary indicates an expected call of SendSecuritySummary.
+func (mr *MockINotificationServiceMockRecorder) SendSecuritySummary(ctx, userID, pending any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSecuritySummary", reflect.TypeOf((*MockINotificationService)(nil).SendSecuritySummary), ctx, userID, pending)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockINotificationService) Unsubscribe(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockINotificationServiceMockRecorder) Unsubscribe(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockINotificationService)(nil).Unsubscribe), ctx, token)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockINotificationService) UpdatePreferences(ctx context.Context, userID int, req models.UpdateNotificationPreferencesRequest) ([]models.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, userID, req)
+	ret0, _ := ret[0].([]models.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockINotificationServiceMockRecorder) UpdatePreferences(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockINotificationService)(nil).UpdatePreferences), ctx, userID, req)
+}
+
+// UpdateQuietHours mocks base method.
+func (m *MockINotificationService) UpdateQuietHours(ctx context.Context, userID int, req models.UpdateQuietHoursRequest) (*models.NotificationQuietHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuietHours", ctx, userID, req)
+	ret0, _ := ret[0].(*models.NotificationQuietHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateQuietHours indicates an expected call of UpdateQuietHours.
+func (mr *MockINotificationServiceMockRecorder) UpdateQuietHours(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuietHours", reflect.TypeOf((*MockINotificationService)(nil).UpdateQuietHours), ctx, userID, req)
+}
+
+// MockINotificationHandler is a mock of INotificationHandler interface.
+type MockINotificationHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockINotificationHandlerMockRecorder is the mock recorder for MockINotificationHandler.
+type MockINotificationHandlerMockRecorder struct {
+	mock *MockINotificationHandler
+}
+
+// NewMockINotificationHandler creates a new mock instance.
+func NewMockINotificationHandler(ctrl *gomock.Controller) *MockINotificationHandler {
+	mock := &MockINotificationHandler{ctrl: ctrl}
+	mock.recorder = &MockINotificationHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationHandler) EXPECT() *MockINotificationHandlerMockRecorder {
+	return m.recorder
+}
+
+// DeleteQuietHours mocks base method.
+func (m *MockINotificationHandler) DeleteQuietHours(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteQuietHours", c)
+}
+
+// DeleteQuietHours indicates an expected call of DeleteQuietHours.
+func (mr *MockINotificationHandlerMockRecorder) DeleteQuietHours(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuietHours", reflect.TypeOf((*MockINotificationHandler)(nil).DeleteQuietHours), c)
+}
+
+// GetPreferences mocks base method.
+func (m *MockINotificationHandler) GetPreferences(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetPreferences", c)
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockINotificationHandlerMockRecorder) GetPreferences(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockINotificationHandler)(nil).GetPreferences), c)
+}
+
+// GetQuietHours mocks base method.
+func (m *MockINotificationHandler) GetQuietHours(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetQuietHours", c)
+}
+
+// GetQuietHours indicates an expected call of GetQuietHours.
+func (mr *MockINotificationHandlerMockRecorder) GetQuietHours(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuietHours", reflect.TypeOf((*MockINotificationHandler)(nil).GetQuietHours), c)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockINotificationHandler) Unsubscribe(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", c)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockINotificationHandlerMockRecorder) Unsubscribe(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockINotificationHandler)(nil).Unsubscribe), c)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockINotificationHandler) UpdatePreferences(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePreferences", c)
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockINotificationHandlerMockRecorder) UpdatePreferences(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockINotificationHandler)(nil).UpdatePreferences), c)
+}
+
+// UpdateQuietHours mocks base method.
+func (m *MockINotificationHandler) UpdateQuietHours(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateQuietHours", c)
+}
+
+// UpdateQuietHours indicates an expected call of UpdateQuietHours.
+func (mr *MockINotificationHandlerMockRecorder) UpdateQuietHours(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuietHours", reflect.TypeOf((*MockINotificationHandler)(nil).UpdateQuietHours), c)
+}
+
+// MockIActivityDigestRepository is a mock of IActivityDigestRepository interface.
+type MockIActivityDigestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIActivityDigestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIActivityDigestRepositoryMockRecorder is the mock recorder for MockIActivityDigestRepository.
+type MockIActivityDigestRepositoryMockRecorder struct {
+	mock *MockIActivityDigestRepository
+}
+
+// NewMockIActivityDigestRepository creates a new mock instance.
+func NewMockIActivityDigestRepository(ctrl *gomock.Controller) *MockIActivityDigestRepository {
+	mock := &MockIActivityDigestRepository{ctrl: ctrl}
+	mock.recorder = &MockIActivityDigestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIActivityDigestRepository) EXPECT() *MockIActivityDigestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimActivityDigest mocks base method.
+func (m *MockIActivityDigestRepository) ClaimActivityDigest(ctx context.Context, digest *models.ActivityDigest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimActivityDigest", ctx, digest)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimActivityDigest indicates an expected call of ClaimActivityDigest.
+func (mr *MockIActivityDigestRepositoryMockRecorder) ClaimActivityDigest(ctx, digest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimActivityDigest", reflect.TypeOf((*MockIActivityDigestRepository)(nil).ClaimActivityDigest), ctx, digest)
+}
+
+// DeleteActivityDigest mocks base method.
+func (m *MockIActivityDigestRepository) DeleteActivityDigest(ctx context.Context, digest *models.ActivityDigest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActivityDigest", ctx, digest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActivityDigest indicates an expected call of DeleteActivityDigest.
+func (mr *MockIActivityDigestRepositoryMockRecorder) DeleteActivityDigest(ctx, digest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActivityDigest", reflect.TypeOf((*MockIActivityDigestRepository)(nil).DeleteActivityDigest), ctx, digest)
+}
+
+// GetDigestRecipients mocks base method.
+func (m *MockIActivityDigestRepository) GetDigestRecipients(ctx context.Context, month string, afterUserID, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestRecipients", ctx, month, afterUserID, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigestRecipients indicates an expected call of GetDigestRecipients.
+func (mr *MockIActivityDigestRepositoryMockRecorder) GetDigestRecipients(ctx, month, afterUserID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestRecipients", reflect.TypeOf((*MockIActivityDigestRepository)(nil).GetDigestRecipients), ctx, month, afterUserID, limit)
+}
+
+// GetLoginHistoriesBetween mocks base method.
+func (m *MockIActivityDigestRepository) GetLoginHistoriesBetween(ctx context.Context, userID int, from, to time.Time) ([]models.LoginHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginHistoriesBetween", ctx, userID, from, to)
+	ret0, _ := ret[0].([]models.LoginHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginHistoriesBetween indicates an expected call of GetLoginHistoriesBetween.
+func (mr *MockIActivityDigestRepositoryMockRecorder) GetLoginHistoriesBetween(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginHistoriesBetween", reflect.TypeOf((*MockIActivityDigestRepository)(nil).GetLoginHistoriesBetween), ctx, userID, from, to)
+}
+
+// MockIActivityDigestService is a mock of IActivityDigestService interface.
+type MockIActivityDigestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIActivityDigestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIActivityDigestServiceMockRecorder is the mock recorder for MockIActivityDigestService.
+type MockIActivityDigestServiceMockRecorder struct {
+	mock *MockIActivityDigestService
+}
+
+// NewMockIActivityDigestService creates a new mock instance.
+func NewMockIActivityDigestService(ctrl *gomock.Controller) *MockIActivityDigestService {
+	mock := &MockIActivityDigestService{ctrl: ctrl}
+	mock.recorder = &MockIActivityDigestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIActivityDigestService) EXPECT() *MockIActivityDigestServiceMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockIActivityDigestService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIActivityDigestServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIActivityDigestService)(nil).Run), ctx)
+}
+
+// SendDigests mocks base method.
+func (m *MockIActivityDigestService) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDigests", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDigests indicates an expected call of SendDigests.
+func (mr *MockIActivityDigestServiceMockRecorder) SendDigests(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDigests", reflect.TypeOf((*MockIActivityDigestService)(nil).SendDigests), ctx, now)
+}
+
+// MockINotificationSummaryService is a mock of INotificationSummaryService interface.
+type MockINotificationSummaryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationSummaryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockINotificationSummaryServiceMockRecorder is the mock recorder for MockINotificationSummaryService.
+type MockINotificationSummaryServiceMockRecorder struct {
+	mock *MockINotificationSummaryService
+}
+
+// NewMockINotificationSummaryService creates a new mock instance.
+func NewMockINotificationSummaryService(ctrl *gomock.Controller) *MockINotificationSummaryService {
+	mock := &MockINotificationSummaryService{ctrl: ctrl}
+	mock.recorder = &MockINotificationSummaryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationSummaryService) EXPECT() *MockINotificationSummaryServiceMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockINotificationSummaryService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockINotificationSummaryServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockINotificationSummaryService)(nil).Run), ctx)
+}
+
+// SendSummaries mocks base method.
+func (m *MockINotificationSummaryService) SendSummaries(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSummaries", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendSummaries indicates an expected call of SendSummaries.
+func (mr *MockINotificationSummaryServiceMockRecorder) SendSummaries(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSummaries", reflect.TypeOf((*MockINotificationSummaryService)(nil).SendSummaries), ctx, now)
+}
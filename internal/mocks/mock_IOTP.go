@@ -0,0 +1,285 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IOTP.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IOTP.go -destination=../mocks/mock_IOTP.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIOTPRepository is a mock of IOTPRepository interface.
+type MockIOTPRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOTPRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIOTPRepositoryMockRecorder is the mock recorder for MockIOTPRepository.
+type MockIOTPRepositoryMockRecorder struct {
+	mock *MockIOTPRepository
+}
+
+// NewMockIOTPRepository creates a new mock instance.
+func NewMockIOTPRepository(ctrl *gomock.Controller) *MockIOTPRepository {
+	mock := &MockIOTPRepository{ctrl: ctrl}
+	mock.recorder = &MockIOTPRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOTPRepository) EXPECT() *MockIOTPRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AttemptOTP mocks base method.
+func (m *MockIOTPRepository) AttemptOTP(ctx context.Context, purpose, phoneLookup string) (string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttemptOTP", ctx, purpose, phoneLookup)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AttemptOTP indicates an expected call of AttemptOTP.
+func (mr *MockIOTPRepositoryMockRecorder) AttemptOTP(ctx, purpose, phoneLookup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttemptOTP", reflect.TypeOf((*MockIOTPRepository)(nil).AttemptOTP), ctx, purpose, phoneLookup)
+}
+
+// DeleteOTP mocks base method.
+func (m *MockIOTPRepository) DeleteOTP(ctx context.Context, purpose, phoneLookup string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOTP", ctx, purpose, phoneLookup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOTP indicates an expected call of DeleteOTP.
+func (mr *MockIOTPRepositoryMockRecorder) DeleteOTP(ctx, purpose, phoneLookup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOTP", reflect.TypeOf((*MockIOTPRepository)(nil).DeleteOTP), ctx, purpose, phoneLookup)
+}
+
+// MarkOTPSent mocks base method.
+func (m *MockIOTPRepository) MarkOTPSent(ctx context.Context, phoneLookup string, interval time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOTPSent", ctx, phoneLookup, interval)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOTPSent indicates an expected call of MarkOTPSent.
+func (mr *MockIOTPRepositoryMockRecorder) MarkOTPSent(ctx, phoneLookup, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOTPSent", reflect.TypeOf((*MockIOTPRepository)(nil).MarkOTPSent), ctx, phoneLookup, interval)
+}
+
+// SaveOTP mocks base method.
+func (m *MockIOTPRepository) SaveOTP(ctx context.Context, purpose, phoneLookup, codeHash string, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOTP", ctx, purpose, phoneLookup, codeHash, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOTP indicates an expected call of SaveOTP.
+func (mr *MockIOTPRepositoryMockRecorder) SaveOTP(ctx, purpose, phoneLookup, codeHash, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOTP", reflect.TypeOf((*MockIOTPRepository)(nil).SaveOTP), ctx, purpose, phoneLookup, codeHash, ttl)
+}
+
+// MockITrustedDeviceRepository is a mock of ITrustedDeviceRepository interface.
+type MockITrustedDeviceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITrustedDeviceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockITrustedDeviceRepositoryMockRecorder is the mock recorder for MockITrustedDeviceRepository.
+type MockITrustedDeviceRepositoryMockRecorder struct {
+	mock *MockITrustedDeviceRepository
+}
+
+// NewMockITrustedDeviceRepository creates a new mock instance.
+func NewMockITrustedDeviceRepository(ctrl *gomock.Controller) *MockITrustedDeviceRepository {
+	mock := &MockITrustedDeviceRepository{ctrl: ctrl}
+	mock.recorder = &MockITrustedDeviceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITrustedDeviceRepository) EXPECT() *MockITrustedDeviceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteTrustedDevices mocks base method.
+func (m *MockITrustedDeviceRepository) DeleteTrustedDevices(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrustedDevices", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrustedDevices indicates an expected call of DeleteTrustedDevices.
+func (mr *MockITrustedDeviceRepositoryMockRecorder) DeleteTrustedDevices(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrustedDevices", reflect.TypeOf((*MockITrustedDeviceRepository)(nil).DeleteTrustedDevices), ctx, userID)
+}
+
+// InsertTrustedDevice mocks base method.
+func (m *MockITrustedDeviceRepository) InsertTrustedDevice(ctx context.Context, device *models.TrustedDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTrustedDevice", ctx, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertTrustedDevice indicates an expected call of InsertTrustedDevice.
+func (mr *MockITrustedDeviceRepositoryMockRecorder) InsertTrustedDevice(ctx, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTrustedDevice", reflect.TypeOf((*MockITrustedDeviceRepository)(nil).InsertTrustedDevice), ctx, device)
+}
+
+// UseTrustedDevice mocks base method.
+func (m *MockITrustedDeviceRepository) UseTrustedDevice(ctx context.Context, userID int, tokenHash string, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTrustedDevice", ctx, userID, tokenHash, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTrustedDevice indicates an expected call of UseTrustedDevice.
+func (mr *MockITrustedDeviceRepositoryMockRecorder) UseTrustedDevice(ctx, userID, tokenHash, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTrustedDevice", reflect.TypeOf((*MockITrustedDeviceRepository)(nil).UseTrustedDevice), ctx, userID, tokenHash, now)
+}
+
+// MockIOTPService is a mock of IOTPService interface.
+type MockIOTPService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOTPServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIOTPServiceMockRecorder is the mock recorder for MockIOTPService.
+type MockIOTPServiceMockRecorder struct {
+	mock *MockIOTPService
+}
+
+// NewMockIOTPService creates a new mock instance.
+func NewMockIOTPService(ctrl *gomock.Controller) *MockIOTPService {
+	mock := &MockIOTPService{ctrl: ctrl}
+	mock.recorder = &MockIOTPServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOTPService) EXPECT() *MockIOTPServiceMockRecorder {
+	return m.recorder
+}
+
+// RegisterWithPhone mocks base method.
+func (m *MockIOTPService) RegisterWithPhone(ctx context.Context, req models.PhoneRegisterRequest) (models.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterWithPhone", ctx, req)
+	ret0, _ := ret[0].(models.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterWithPhone indicates an expected call of RegisterWithPhone.
+func (mr *MockIOTPServiceMockRecorder) RegisterWithPhone(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterWithPhone", reflect.TypeOf((*MockIOTPService)(nil).RegisterWithPhone), ctx, req)
+}
+
+// RequestOTP mocks base method.
+func (m *MockIOTPService) RequestOTP(ctx context.Context, req models.OTPRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestOTP", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestOTP indicates an expected call of RequestOTP.
+func (mr *MockIOTPServiceMockRecorder) RequestOTP(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestOTP", reflect.TypeOf((*MockIOTPService)(nil).RequestOTP), ctx, req)
+}
+
+// VerifyOTP mocks base method.
+func (m *MockIOTPService) VerifyOTP(ctx context.Context, purpose, phoneNumber, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyOTP", ctx, purpose, phoneNumber, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyOTP indicates an expected call of VerifyOTP.
+func (mr *MockIOTPServiceMockRecorder) VerifyOTP(ctx, purpose, phoneNumber, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyOTP", reflect.TypeOf((*MockIOTPService)(nil).VerifyOTP), ctx, purpose, phoneNumber, code)
+}
+
+// MockIOTPHandler is a mock of IOTPHandler interface.
+type MockIOTPHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOTPHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIOTPHandlerMockRecorder is the mock recorder for MockIOTPHandler.
+type MockIOTPHandlerMockRecorder struct {
+	mock *MockIOTPHandler
+}
+
+// NewMockIOTPHandler creates a new mock instance.
+func NewMockIOTPHandler(ctrl *gomock.Controller) *MockIOTPHandler {
+	mock := &MockIOTPHandler{ctrl: ctrl}
+	mock.recorder = &MockIOTPHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOTPHandler) EXPECT() *MockIOTPHandlerMockRecorder {
+	return m.recorder
+}
+
+// RegisterWithPhone mocks base method.
+func (m *MockIOTPHandler) RegisterWithPhone(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterWithPhone", c)
+}
+
+// RegisterWithPhone indicates an expected call of RegisterWithPhone.
+func (mr *MockIOTPHandlerMockRecorder) RegisterWithPhone(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterWithPhone", reflect.TypeOf((*MockIOTPHandler)(nil).RegisterWithPhone), c)
+}
+
+// RequestOTP mocks base method.
+func (m *MockIOTPHandler) RequestOTP(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestOTP", c)
+}
+
+// RequestOTP indicates an expected call of RequestOTP.
+func (mr *MockIOTPHandlerMockRecorder) RequestOTP(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestOTP", reflect.TypeOf((*MockIOTPHandler)(nil).RequestOTP), c)
+}
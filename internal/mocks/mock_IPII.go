@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IPII.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IPII.go -destination=../mocks/mock_IPII.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPIIRepository is a mock of IPIIRepository interface.
+type MockIPIIRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPIIRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIPIIRepositoryMockRecorder is the mock recorder for MockIPIIRepository.
+type MockIPIIRepositoryMockRecorder struct {
+	mock *MockIPIIRepository
+}
+
+// NewMockIPIIRepository creates a new mock instance.
+func NewMockIPIIRepository(ctrl *gomock.Controller) *MockIPIIRepository {
+	mock := &MockIPIIRepository{ctrl: ctrl}
+	mock.recorder = &MockIPIIRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPIIRepository) EXPECT() *MockIPIIRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUsersAfter mocks base method.
+func (m *MockIPIIRepository) GetUsersAfter(ctx context.Context, afterID, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersAfter", ctx, afterID, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersAfter indicates an expected call of GetUsersAfter.
+func (mr *MockIPIIRepositoryMockRecorder) GetUsersAfter(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersAfter", reflect.TypeOf((*MockIPIIRepository)(nil).GetUsersAfter), ctx, afterID, limit)
+}
+
+// GetUsersWithStalePII mocks base method.
+func (m *MockIPIIRepository) GetUsersWithStalePII(ctx context.Context, activePrefix string, afterID, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersWithStalePII", ctx, activePrefix, afterID, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersWithStalePII indicates an expected call of GetUsersWithStalePII.
+func (mr *MockIPIIRepositoryMockRecorder) GetUsersWithStalePII(ctx, activePrefix, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithStalePII", reflect.TypeOf((*MockIPIIRepository)(nil).GetUsersWithStalePII), ctx, activePrefix, afterID, limit)
+}
+
+// ReencryptUserPII mocks base method.
+func (m *MockIPIIRepository) ReencryptUserPII(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReencryptUserPII", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReencryptUserPII indicates an expected call of ReencryptUserPII.
+func (mr *MockIPIIRepositoryMockRecorder) ReencryptUserPII(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReencryptUserPII", reflect.TypeOf((*MockIPIIRepository)(nil).ReencryptUserPII), ctx, user)
+}
+
+// MockIPIIRotationService is a mock of IPIIRotationService interface.
+type MockIPIIRotationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPIIRotationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIPIIRotationServiceMockRecorder is the mock recorder for MockIPIIRotationService.
+type MockIPIIRotationServiceMockRecorder struct {
+	mock *MockIPIIRotationService
+}
+
+// NewMockIPIIRotationService creates a new mock instance.
+func NewMockIPIIRotationService(ctrl *gomock.Controller) *MockIPIIRotationService {
+	mock := &MockIPIIRotationService{ctrl: ctrl}
+	mock.recorder = &MockIPIIRotationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPIIRotationService) EXPECT() *MockIPIIRotationServiceMockRecorder {
+	return m.recorder
+}
+
+// Rotate mocks base method.
+func (m *MockIPIIRotationService) Rotate(ctx context.Context, newDataKey, rewrap, reindex bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, newDataKey, rewrap, reindex)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockIPIIRotationServiceMockRecorder) Rotate(ctx, newDataKey, rewrap, reindex any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockIPIIRotationService)(nil).Rotate), ctx, newDataKey, rewrap, reindex)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IPasswordHasher.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IPasswordHasher.go -destination=../mocks/mock_IPasswordHasher.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPasswordHasher is a mock of IPasswordHasher interface.
+type MockIPasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPasswordHasherMockRecorder
+	isgomock struct{}
+}
+
+// MockIPasswordHasherMockRecorder is the mock recorder for MockIPasswordHasher.
+type MockIPasswordHasherMockRecorder struct {
+	mock *MockIPasswordHasher
+}
+
+// NewMockIPasswordHasher creates a new mock instance.
+func NewMockIPasswordHasher(ctrl *gomock.Controller) *MockIPasswordHasher {
+	mock := &MockIPasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockIPasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPasswordHasher) EXPECT() *MockIPasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// ComparePassword mocks base method.
+func (m *MockIPasswordHasher) ComparePassword(ctx context.Context, hashPassword, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComparePassword", ctx, hashPassword, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ComparePassword indicates an expected call of ComparePassword.
+func (mr *MockIPasswordHasherMockRecorder) ComparePassword(ctx, hashPassword, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComparePassword", reflect.TypeOf((*MockIPasswordHasher)(nil).ComparePassword), ctx, hashPassword, password)
+}
+
+// HashPassword mocks base method.
+func (m *MockIPasswordHasher) HashPassword(ctx context.Context, password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashPassword", ctx, password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HashPassword indicates an expected call of HashPassword.
+func (mr *MockIPasswordHasherMockRecorder) HashPassword(ctx, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashPassword", reflect.TypeOf((*MockIPasswordHasher)(nil).HashPassword), ctx, password)
+}
@@ -0,0 +1,281 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IProfile.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IProfile.go -destination=../mocks/mock_IProfile.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserClaimsService is a mock of IUserClaimsService interface.
+type MockIUserClaimsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserClaimsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserClaimsServiceMockRecorder is the mock recorder for MockIUserClaimsService.
+type MockIUserClaimsServiceMockRecorder struct {
+	mock *MockIUserClaimsService
+}
+
+// NewMockIUserClaimsService creates a new mock instance.
+func NewMockIUserClaimsService(ctrl *gomock.Controller) *MockIUserClaimsService {
+	mock := &MockIUserClaimsService{ctrl: ctrl}
+	mock.recorder = &MockIUserClaimsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserClaimsService) EXPECT() *MockIUserClaimsServiceMockRecorder {
+	return m.recorder
+}
+
+// InvalidateUserClaims mocks base method.
+func (m *MockIUserClaimsService) InvalidateUserClaims(ctx context.Context, userID int, changedFields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUserClaims", ctx, userID, changedFields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUserClaims indicates an expected call of InvalidateUserClaims.
+func (mr *MockIUserClaimsServiceMockRecorder) InvalidateUserClaims(ctx, userID, changedFields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserClaims", reflect.TypeOf((*MockIUserClaimsService)(nil).InvalidateUserClaims), ctx, userID, changedFields)
+}
+
+// MockIEmailChangeRepository is a mock of IEmailChangeRepository interface.
+type MockIEmailChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEmailChangeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIEmailChangeRepositoryMockRecorder is the mock recorder for MockIEmailChangeRepository.
+type MockIEmailChangeRepositoryMockRecorder struct {
+	mock *MockIEmailChangeRepository
+}
+
+// NewMockIEmailChangeRepository creates a new mock instance.
+func NewMockIEmailChangeRepository(ctrl *gomock.Controller) *MockIEmailChangeRepository {
+	mock := &MockIEmailChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockIEmailChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEmailChangeRepository) EXPECT() *MockIEmailChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetEmailChangeRevertByToken mocks base method.
+func (m *MockIEmailChangeRepository) GetEmailChangeRevertByToken(ctx context.Context, tokenHash string) (models.EmailChangeRevert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChangeRevertByToken", ctx, tokenHash)
+	ret0, _ := ret[0].(models.EmailChangeRevert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailChangeRevertByToken indicates an expected call of GetEmailChangeRevertByToken.
+func (mr *MockIEmailChangeRepositoryMockRecorder) GetEmailChangeRevertByToken(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChangeRevertByToken", reflect.TypeOf((*MockIEmailChangeRepository)(nil).GetEmailChangeRevertByToken), ctx, tokenHash)
+}
+
+// InsertEmailChangeRevert mocks base method.
+func (m *MockIEmailChangeRepository) InsertEmailChangeRevert(ctx context.Context, revert *models.EmailChangeRevert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEmailChangeRevert", ctx, revert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertEmailChangeRevert indicates an expected call of InsertEmailChangeRevert.
+func (mr *MockIEmailChangeRepositoryMockRecorder) InsertEmailChangeRevert(ctx, revert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEmailChangeRevert", reflect.TypeOf((*MockIEmailChangeRepository)(nil).InsertEmailChangeRevert), ctx, revert)
+}
+
+// RevertEmailChange mocks base method.
+func (m *MockIEmailChangeRepository) RevertEmailChange(ctx context.Context, revert *models.EmailChangeRevert, version int, password string, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertEmailChange", ctx, revert, version, password, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertEmailChange indicates an expected call of RevertEmailChange.
+func (mr *MockIEmailChangeRepositoryMockRecorder) RevertEmailChange(ctx, revert, version, password, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertEmailChange", reflect.TypeOf((*MockIEmailChangeRepository)(nil).RevertEmailChange), ctx, revert, version, password, now)
+}
+
+// MockIProfileService is a mock of IProfileService interface.
+type MockIProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIProfileServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIProfileServiceMockRecorder is the mock recorder for MockIProfileService.
+type MockIProfileServiceMockRecorder struct {
+	mock *MockIProfileService
+}
+
+// NewMockIProfileService creates a new mock instance.
+func NewMockIProfileService(ctrl *gomock.Controller) *MockIProfileService {
+	mock := &MockIProfileService{ctrl: ctrl}
+	mock.recorder = &MockIProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIProfileService) EXPECT() *MockIProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// GetProfile mocks base method.
+func (m *MockIProfileService) GetProfile(ctx context.Context, userID int) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockIProfileServiceMockRecorder) GetProfile(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockIProfileService)(nil).GetProfile), ctx, userID)
+}
+
+// RevertEmailChange mocks base method.
+func (m *MockIProfileService) RevertEmailChange(ctx context.Context, req models.EmailChangeRevertRequest) (models.EmailChangeRevertResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertEmailChange", ctx, req)
+	ret0, _ := ret[0].(models.EmailChangeRevertResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertEmailChange indicates an expected call of RevertEmailChange.
+func (mr *MockIProfileServiceMockRecorder) RevertEmailChange(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertEmailChange", reflect.TypeOf((*MockIProfileService)(nil).RevertEmailChange), ctx, req)
+}
+
+// SetPassword mocks base method.
+func (m *MockIProfileService) SetPassword(ctx context.Context, userID int, req models.SetPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, userID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockIProfileServiceMockRecorder) SetPassword(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockIProfileService)(nil).SetPassword), ctx, userID, req)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockIProfileService) UpdateProfile(ctx context.Context, userID int, req models.UpdateProfileRequest) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, userID, req)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockIProfileServiceMockRecorder) UpdateProfile(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockIProfileService)(nil).UpdateProfile), ctx, userID, req)
+}
+
+// MockIProfileHandler is a mock of IProfileHandler interface.
+type MockIProfileHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIProfileHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIProfileHandlerMockRecorder is the mock recorder for MockIProfileHandler.
+type MockIProfileHandlerMockRecorder struct {
+	mock *MockIProfileHandler
+}
+
+// NewMockIProfileHandler creates a new mock instance.
+func NewMockIProfileHandler(ctrl *gomock.Controller) *MockIProfileHandler {
+	mock := &MockIProfileHandler{ctrl: ctrl}
+	mock.recorder = &MockIProfileHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIProfileHandler) EXPECT() *MockIProfileHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetProfile mocks base method.
+func (m *MockIProfileHandler) GetProfile(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetProfile", c)
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockIProfileHandlerMockRecorder) GetProfile(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockIProfileHandler)(nil).GetProfile), c)
+}
+
+// RevertEmailChange mocks base method.
+func (m *MockIProfileHandler) RevertEmailChange(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevertEmailChange", c)
+}
+
+// RevertEmailChange indicates an expected call of RevertEmailChange.
+func (mr *MockIProfileHandlerMockRecorder) RevertEmailChange(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertEmailChange", reflect.TypeOf((*MockIProfileHandler)(nil).RevertEmailChange), c)
+}
+
+// SetPassword mocks base method.
+func (m *MockIProfileHandler) SetPassword(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPassword", c)
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockIProfileHandlerMockRecorder) SetPassword(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockIProfileHandler)(nil).SetPassword), c)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockIProfileHandler) UpdateProfile(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateProfile", c)
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockIProfileHandlerMockRecorder) UpdateProfile(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockIProfileHandler)(nil).UpdateProfile), c)
+}
@@ -0,0 +1,267 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IRefreshToken.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IRefreshToken.go -destination=../mocks/mock_IRefreshToken.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	helpers "ewallet-ums/helpers"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRefreshTokenRepository is a mock of IRefreshTokenRepository interface.
+type MockIRefreshTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRefreshTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIRefreshTokenRepositoryMockRecorder is the mock recorder for MockIRefreshTokenRepository.
+type MockIRefreshTokenRepositoryMockRecorder struct {
+	mock *MockIRefreshTokenRepository
+}
+
+// NewMockIRefreshTokenRepository creates a new mock instance.
+func NewMockIRefreshTokenRepository(ctrl *gomock.Controller) *MockIRefreshTokenRepository {
+	mock := &MockIRefreshTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockIRefreshTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRefreshTokenRepository) EXPECT() *MockIRefreshTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpiredRefreshTokens mocks base method.
+func (m *MockIRefreshTokenRepository) DeleteExpiredRefreshTokens(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRefreshTokens", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRefreshTokens indicates an expected call of DeleteExpiredRefreshTokens.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) DeleteExpiredRefreshTokens(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRefreshTokens", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).DeleteExpiredRefreshTokens), ctx, before, limit)
+}
+
+// GetRefreshTokenByHash mocks base method.
+func (m *MockIRefreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenByHash indicates an expected call of GetRefreshTokenByHash.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) GetRefreshTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByHash", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).GetRefreshTokenByHash), ctx, tokenHash)
+}
+
+// GetRefreshTokenByTokenID mocks base method.
+func (m *MockIRefreshTokenRepository) GetRefreshTokenByTokenID(ctx context.Context, tokenID string) (models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenByTokenID", ctx, tokenID)
+	ret0, _ := ret[0].(models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenByTokenID indicates an expected call of GetRefreshTokenByTokenID.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) GetRefreshTokenByTokenID(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByTokenID", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).GetRefreshTokenByTokenID), ctx, tokenID)
+}
+
+// GetRefreshTokenFamily mocks base method.
+func (m *MockIRefreshTokenRepository) GetRefreshTokenFamily(ctx context.Context, familyID string) ([]models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].([]models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenFamily indicates an expected call of GetRefreshTokenFamily.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) GetRefreshTokenFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenFamily", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).GetRefreshTokenFamily), ctx, familyID)
+}
+
+// GetUserSessionByFamilyID mocks base method.
+func (m *MockIRefreshTokenRepository) GetUserSessionByFamilyID(ctx context.Context, familyID string) (models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessionByFamilyID", ctx, familyID)
+	ret0, _ := ret[0].(models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessionByFamilyID indicates an expected call of GetUserSessionByFamilyID.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) GetUserSessionByFamilyID(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessionByFamilyID", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).GetUserSessionByFamilyID), ctx, familyID)
+}
+
+// GetUserSessionByTokenID mocks base method.
+func (m *MockIRefreshTokenRepository) GetUserSessionByTokenID(ctx context.Context, tokenID string) (models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessionByTokenID", ctx, tokenID)
+	ret0, _ := ret[0].(models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessionByTokenID indicates an expected call of GetUserSessionByTokenID.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) GetUserSessionByTokenID(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessionByTokenID", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).GetUserSessionByTokenID), ctx, tokenID)
+}
+
+// RevokeRefreshTokenFamily mocks base method.
+func (m *MockIRefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, familyID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) RevokeRefreshTokenFamily(ctx, familyID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).RevokeRefreshTokenFamily), ctx, familyID, now)
+}
+
+// RevokeUserRefreshTokens mocks base method.
+func (m *MockIRefreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID int, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefreshTokens", ctx, userID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefreshTokens indicates an expected call of RevokeUserRefreshTokens.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) RevokeUserRefreshTokens(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefreshTokens", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).RevokeUserRefreshTokens), ctx, userID, now)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockIRefreshTokenRepository) RotateRefreshToken(ctx context.Context, parent models.RefreshToken, child *models.RefreshToken, session models.UserSession, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, parent, child, session, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) RotateRefreshToken(ctx, parent, child, session, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).RotateRefreshToken), ctx, parent, child, session, now)
+}
+
+// StartRefreshTokenFamily mocks base method.
+func (m *MockIRefreshTokenRepository) StartRefreshTokenFamily(ctx context.Context, sessionID int, root *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRefreshTokenFamily", ctx, sessionID, root)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRefreshTokenFamily indicates an expected call of StartRefreshTokenFamily.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) StartRefreshTokenFamily(ctx, sessionID, root any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRefreshTokenFamily", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).StartRefreshTokenFamily), ctx, sessionID, root)
+}
+
+// MockIRefreshTokenService is a mock of IRefreshTokenService interface.
+type MockIRefreshTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRefreshTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIRefreshTokenServiceMockRecorder is the mock recorder for MockIRefreshTokenService.
+type MockIRefreshTokenServiceMockRecorder struct {
+	mock *MockIRefreshTokenService
+}
+
+// NewMockIRefreshTokenService creates a new mock instance.
+func NewMockIRefreshTokenService(ctrl *gomock.Controller) *MockIRefreshTokenService {
+	mock := &MockIRefreshTokenService{ctrl: ctrl}
+	mock.recorder = &MockIRefreshTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRefreshTokenService) EXPECT() *MockIRefreshTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// RefreshToken mocks base method.
+func (m *MockIRefreshTokenService) RefreshToken(ctx context.Context, refreshToken string, tokenClaim helpers.ClaimToken, origin models.TokenOrigin) (models.RefreshTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken, tokenClaim, origin)
+	ret0, _ := ret[0].(models.RefreshTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockIRefreshTokenServiceMockRecorder) RefreshToken(ctx, refreshToken, tokenClaim, origin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockIRefreshTokenService)(nil).RefreshToken), ctx, refreshToken, tokenClaim, origin)
+}
+
+// MockIRefreshTokenHandler is a mock of IRefreshTokenHandler interface.
+type MockIRefreshTokenHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRefreshTokenHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIRefreshTokenHandlerMockRecorder is the mock recorder for MockIRefreshTokenHandler.
+type MockIRefreshTokenHandlerMockRecorder struct {
+	mock *MockIRefreshTokenHandler
+}
+
+// NewMockIRefreshTokenHandler creates a new mock instance.
+func NewMockIRefreshTokenHandler(ctrl *gomock.Controller) *MockIRefreshTokenHandler {
+	mock := &MockIRefreshTokenHandler{ctrl: ctrl}
+	mock.recorder = &MockIRefreshTokenHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRefreshTokenHandler) EXPECT() *MockIRefreshTokenHandlerMockRecorder {
+	return m.recorder
+}
+
+// RefreshToken mocks base method.
+func (m *MockIRefreshTokenHandler) RefreshToken(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RefreshToken", c)
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockIRefreshTokenHandlerMockRecorder) RefreshToken(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockIRefreshTokenHandler)(nil).RefreshToken), c)
+}
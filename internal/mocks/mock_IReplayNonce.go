@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IReplayNonce.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IReplayNonce.go -destination=../mocks/mock_IReplayNonce.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIReplayNonceRepository is a mock of IReplayNonceRepository interface.
+type MockIReplayNonceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReplayNonceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIReplayNonceRepositoryMockRecorder is the mock recorder for MockIReplayNonceRepository.
+type MockIReplayNonceRepositoryMockRecorder struct {
+	mock *MockIReplayNonceRepository
+}
+
+// NewMockIReplayNonceRepository creates a new mock instance.
+func NewMockIReplayNonceRepository(ctrl *gomock.Controller) *MockIReplayNonceRepository {
+	mock := &MockIReplayNonceRepository{ctrl: ctrl}
+	mock.recorder = &MockIReplayNonceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReplayNonceRepository) EXPECT() *MockIReplayNonceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimRequestNonce mocks base method.
+func (m *MockIReplayNonceRepository) ClaimRequestNonce(ctx context.Context, userID int, nonce string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimRequestNonce", ctx, userID, nonce, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimRequestNonce indicates an expected call of ClaimRequestNonce.
+func (mr *MockIReplayNonceRepositoryMockRecorder) ClaimRequestNonce(ctx, userID, nonce, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimRequestNonce", reflect.TypeOf((*MockIReplayNonceRepository)(nil).ClaimRequestNonce), ctx, userID, nonce, ttl)
+}
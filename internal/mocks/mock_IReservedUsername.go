@@ -0,0 +1,289 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IReservedUsername.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IReservedUsername.go -destination=../mocks/mock_IReservedUsername.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIReservedUsernameRepository is a mock of IReservedUsernameRepository interface.
+type MockIReservedUsernameRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReservedUsernameRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIReservedUsernameRepositoryMockRecorder is the mock recorder for MockIReservedUsernameRepository.
+type MockIReservedUsernameRepositoryMockRecorder struct {
+	mock *MockIReservedUsernameRepository
+}
+
+// NewMockIReservedUsernameRepository creates a new mock instance.
+func NewMockIReservedUsernameRepository(ctrl *gomock.Controller) *MockIReservedUsernameRepository {
+	mock := &MockIReservedUsernameRepository{ctrl: ctrl}
+	mock.recorder = &MockIReservedUsernameRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReservedUsernameRepository) EXPECT() *MockIReservedUsernameRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimReservedUsername mocks base method.
+func (m *MockIReservedUsernameRepository) ClaimReservedUsername(ctx context.Context, reservation *models.ReservedUsername, userID, version int, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReservedUsername", ctx, reservation, userID, version, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReservedUsername indicates an expected call of ClaimReservedUsername.
+func (mr *MockIReservedUsernameRepositoryMockRecorder) ClaimReservedUsername(ctx, reservation, userID, version, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReservedUsername", reflect.TypeOf((*MockIReservedUsernameRepository)(nil).ClaimReservedUsername), ctx, reservation, userID, version, now)
+}
+
+// DeleteReservedUsername mocks base method.
+func (m *MockIReservedUsernameRepository) DeleteReservedUsername(ctx context.Context, username string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReservedUsername", ctx, username)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReservedUsername indicates an expected call of DeleteReservedUsername.
+func (mr *MockIReservedUsernameRepositoryMockRecorder) DeleteReservedUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReservedUsername", reflect.TypeOf((*MockIReservedUsernameRepository)(nil).DeleteReservedUsername), ctx, username)
+}
+
+// GetReservedUsername mocks base method.
+func (m *MockIReservedUsernameRepository) GetReservedUsername(ctx context.Context, username string) (models.ReservedUsername, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedUsername", ctx, username)
+	ret0, _ := ret[0].(models.ReservedUsername)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedUsername indicates an expected call of GetReservedUsername.
+func (mr *MockIReservedUsernameRepositoryMockRecorder) GetReservedUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedUsername", reflect.TypeOf((*MockIReservedUsernameRepository)(nil).GetReservedUsername), ctx, username)
+}
+
+// GetReservedUsernames mocks base method.
+func (m *MockIReservedUsernameRepository) GetReservedUsernames(ctx context.Context, cursor *pagination.Cursor, limit int) ([]models.ReservedUsername, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedUsernames", ctx, cursor, limit)
+	ret0, _ := ret[0].([]models.ReservedUsername)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedUsernames indicates an expected call of GetReservedUsernames.
+func (mr *MockIReservedUsernameRepositoryMockRecorder) GetReservedUsernames(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedUsernames", reflect.TypeOf((*MockIReservedUsernameRepository)(nil).GetReservedUsernames), ctx, cursor, limit)
+}
+
+// InsertReservedUsername mocks base method.
+func (m *MockIReservedUsernameRepository) InsertReservedUsername(ctx context.Context, reservation *models.ReservedUsername) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertReservedUsername", ctx, reservation)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertReservedUsername indicates an expected call of InsertReservedUsername.
+func (mr *MockIReservedUsernameRepositoryMockRecorder) InsertReservedUsername(ctx, reservation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertReservedUsername", reflect.TypeOf((*MockIReservedUsernameRepository)(nil).InsertReservedUsername), ctx, reservation)
+}
+
+// MockIReservedUsernameService is a mock of IReservedUsernameService interface.
+type MockIReservedUsernameService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReservedUsernameServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIReservedUsernameServiceMockRecorder is the mock recorder for MockIReservedUsernameService.
+type MockIReservedUsernameServiceMockRecorder struct {
+	mock *MockIReservedUsernameService
+}
+
+// NewMockIReservedUsernameService creates a new mock instance.
+func NewMockIReservedUsernameService(ctrl *gomock.Controller) *MockIReservedUsernameService {
+	mock := &MockIReservedUsernameService{ctrl: ctrl}
+	mock.recorder = &MockIReservedUsernameServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReservedUsernameService) EXPECT() *MockIReservedUsernameServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckUsername mocks base method.
+func (m *MockIReservedUsernameService) CheckUsername(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUsername", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckUsername indicates an expected call of CheckUsername.
+func (mr *MockIReservedUsernameServiceMockRecorder) CheckUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUsername", reflect.TypeOf((*MockIReservedUsernameService)(nil).CheckUsername), ctx, username)
+}
+
+// ClaimReservedUsername mocks base method.
+func (m *MockIReservedUsernameService) ClaimReservedUsername(ctx context.Context, actor, username string, req models.ClaimReservedUsernameRequest) (models.ReservedUsername, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReservedUsername", ctx, actor, username, req)
+	ret0, _ := ret[0].(models.ReservedUsername)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReservedUsername indicates an expected call of ClaimReservedUsername.
+func (mr *MockIReservedUsernameServiceMockRecorder) ClaimReservedUsername(ctx, actor, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReservedUsername", reflect.TypeOf((*MockIReservedUsernameService)(nil).ClaimReservedUsername), ctx, actor, username, req)
+}
+
+// DeleteReservedUsername mocks base method.
+func (m *MockIReservedUsernameService) DeleteReservedUsername(ctx context.Context, actor, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReservedUsername", ctx, actor, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReservedUsername indicates an expected call of DeleteReservedUsername.
+func (mr *MockIReservedUsernameServiceMockRecorder) DeleteReservedUsername(ctx, actor, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReservedUsername", reflect.TypeOf((*MockIReservedUsernameService)(nil).DeleteReservedUsername), ctx, actor, username)
+}
+
+// GetReservedUsernames mocks base method.
+func (m *MockIReservedUsernameService) GetReservedUsernames(ctx context.Context, req models.ReservedUsernameListRequest) (pagination.Page[models.ReservedUsername], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedUsernames", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.ReservedUsername])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedUsernames indicates an expected call of GetReservedUsernames.
+func (mr *MockIReservedUsernameServiceMockRecorder) GetReservedUsernames(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedUsernames", reflect.TypeOf((*MockIReservedUsernameService)(nil).GetReservedUsernames), ctx, req)
+}
+
+// ReserveUsername mocks base method.
+func (m *MockIReservedUsernameService) ReserveUsername(ctx context.Context, actor string, req models.ReservedUsernameRequest) (models.ReservedUsername, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveUsername", ctx, actor, req)
+	ret0, _ := ret[0].(models.ReservedUsername)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveUsername indicates an expected call of ReserveUsername.
+func (mr *MockIReservedUsernameServiceMockRecorder) ReserveUsername(ctx, actor, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveUsername", reflect.TypeOf((*MockIReservedUsernameService)(nil).ReserveUsername), ctx, actor, req)
+}
+
+// MockIReservedUsernameHandler is a mock of IReservedUsernameHandler interface.
+type MockIReservedUsernameHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReservedUsernameHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIReservedUsernameHandlerMockRecorder is the mock recorder for MockIReservedUsernameHandler.
+type MockIReservedUsernameHandlerMockRecorder struct {
+	mock *MockIReservedUsernameHandler
+}
+
+// NewMockIReservedUsernameHandler creates a new mock instance.
+func NewMockIReservedUsernameHandler(ctrl *gomock.Controller) *MockIReservedUsernameHandler {
+	mock := &MockIReservedUsernameHandler{ctrl: ctrl}
+	mock.recorder = &MockIReservedUsernameHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReservedUsernameHandler) EXPECT() *MockIReservedUsernameHandlerMockRecorder {
+	return m.recorder
+}
+
+// ClaimReservedUsername mocks base method.
+func (m *MockIReservedUsernameHandler) ClaimReservedUsername(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClaimReservedUsername", c)
+}
+
+// ClaimReservedUsername indicates an expected call of ClaimReservedUsername.
+func (mr *MockIReservedUsernameHandlerMockRecorder) ClaimReservedUsername(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReservedUsername", reflect.TypeOf((*MockIReservedUsernameHandler)(nil).ClaimReservedUsername), c)
+}
+
+// DeleteReservedUsername mocks base method.
+func (m *MockIReservedUsernameHandler) DeleteReservedUsername(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteReservedUsername", c)
+}
+
+// DeleteReservedUsername indicates an expected call of DeleteReservedUsername.
+func (mr *MockIReservedUsernameHandlerMockRecorder) DeleteReservedUsername(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReservedUsername", reflect.TypeOf((*MockIReservedUsernameHandler)(nil).DeleteReservedUsername), c)
+}
+
+// GetReservedUsernames mocks base method.
+func (m *MockIReservedUsernameHandler) GetReservedUsernames(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetReservedUsernames", c)
+}
+
+// GetReservedUsernames indicates an expected call of GetReservedUsernames.
+func (mr *MockIReservedUsernameHandlerMockRecorder) GetReservedUsernames(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedUsernames", reflect.TypeOf((*MockIReservedUsernameHandler)(nil).GetReservedUsernames), c)
+}
+
+// ReserveUsername mocks base method.
+func (m *MockIReservedUsernameHandler) ReserveUsername(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReserveUsername", c)
+}
+
+// ReserveUsername indicates an expected call of ReserveUsername.
+func (mr *MockIReservedUsernameHandlerMockRecorder) ReserveUsername(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveUsername", reflect.TypeOf((*MockIReservedUsernameHandler)(nil).ReserveUsername), c)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IRetention.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IRetention.go -destination=../mocks/mock_IRetention.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRetentionRepository is a mock of IRetentionRepository interface.
+type MockIRetentionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRetentionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIRetentionRepositoryMockRecorder is the mock recorder for MockIRetentionRepository.
+type MockIRetentionRepositoryMockRecorder struct {
+	mock *MockIRetentionRepository
+}
+
+// NewMockIRetentionRepository creates a new mock instance.
+func NewMockIRetentionRepository(ctrl *gomock.Controller) *MockIRetentionRepository {
+	mock := &MockIRetentionRepository{ctrl: ctrl}
+	mock.recorder = &MockIRetentionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRetentionRepository) EXPECT() *MockIRetentionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountExpired mocks base method.
+func (m *MockIRetentionRepository) CountExpired(ctx context.Context, rule string, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountExpired", ctx, rule, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountExpired indicates an expected call of CountExpired.
+func (mr *MockIRetentionRepositoryMockRecorder) CountExpired(ctx, rule, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountExpired", reflect.TypeOf((*MockIRetentionRepository)(nil).CountExpired), ctx, rule, before)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockIRetentionRepository) PurgeExpired(ctx context.Context, rule string, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx, rule, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockIRetentionRepositoryMockRecorder) PurgeExpired(ctx, rule, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockIRetentionRepository)(nil).PurgeExpired), ctx, rule, before, limit)
+}
+
+// MockIRetentionService is a mock of IRetentionService interface.
+type MockIRetentionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRetentionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIRetentionServiceMockRecorder is the mock recorder for MockIRetentionService.
+type MockIRetentionServiceMockRecorder struct {
+	mock *MockIRetentionService
+}
+
+// NewMockIRetentionService creates a new mock instance.
+func NewMockIRetentionService(ctrl *gomock.Controller) *MockIRetentionService {
+	mock := &MockIRetentionService{ctrl: ctrl}
+	mock.recorder = &MockIRetentionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRetentionService) EXPECT() *MockIRetentionServiceMockRecorder {
+	return m.recorder
+}
+
+// Enforce mocks base method.
+func (m *MockIRetentionService) Enforce(ctx context.Context, now time.Time, dryRun bool) ([]models.RetentionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enforce", ctx, now, dryRun)
+	ret0, _ := ret[0].([]models.RetentionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enforce indicates an expected call of Enforce.
+func (mr *MockIRetentionServiceMockRecorder) Enforce(ctx, now, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enforce", reflect.TypeOf((*MockIRetentionService)(nil).Enforce), ctx, now, dryRun)
+}
+
+// Run mocks base method.
+func (m *MockIRetentionService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIRetentionServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIRetentionService)(nil).Run), ctx)
+}
@@ -0,0 +1,290 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IScreening.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IScreening.go -destination=../mocks/mock_IScreening.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIScreeningRepository is a mock of IScreeningRepository interface.
+type MockIScreeningRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScreeningRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIScreeningRepositoryMockRecorder is the mock recorder for MockIScreeningRepository.
+type MockIScreeningRepositoryMockRecorder struct {
+	mock *MockIScreeningRepository
+}
+
+// NewMockIScreeningRepository creates a new mock instance.
+func NewMockIScreeningRepository(ctrl *gomock.Controller) *MockIScreeningRepository {
+	mock := &MockIScreeningRepository{ctrl: ctrl}
+	mock.recorder = &MockIScreeningRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScreeningRepository) EXPECT() *MockIScreeningRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetScreeningReviewByID mocks base method.
+func (m *MockIScreeningRepository) GetScreeningReviewByID(ctx context.Context, reviewID int) (models.ScreeningReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreeningReviewByID", ctx, reviewID)
+	ret0, _ := ret[0].(models.ScreeningReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreeningReviewByID indicates an expected call of GetScreeningReviewByID.
+func (mr *MockIScreeningRepositoryMockRecorder) GetScreeningReviewByID(ctx, reviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreeningReviewByID", reflect.TypeOf((*MockIScreeningRepository)(nil).GetScreeningReviewByID), ctx, reviewID)
+}
+
+// GetScreeningReviews mocks base method.
+func (m *MockIScreeningRepository) GetScreeningReviews(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.ScreeningReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreeningReviews", ctx, status, cursor, limit)
+	ret0, _ := ret[0].([]models.ScreeningReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreeningReviews indicates an expected call of GetScreeningReviews.
+func (mr *MockIScreeningRepositoryMockRecorder) GetScreeningReviews(ctx, status, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreeningReviews", reflect.TypeOf((*MockIScreeningRepository)(nil).GetScreeningReviews), ctx, status, cursor, limit)
+}
+
+// GetUsersDueForScreening mocks base method.
+func (m *MockIScreeningRepository) GetUsersDueForScreening(ctx context.Context, screenedBefore time.Time, afterID, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersDueForScreening", ctx, screenedBefore, afterID, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersDueForScreening indicates an expected call of GetUsersDueForScreening.
+func (mr *MockIScreeningRepositoryMockRecorder) GetUsersDueForScreening(ctx, screenedBefore, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersDueForScreening", reflect.TypeOf((*MockIScreeningRepository)(nil).GetUsersDueForScreening), ctx, screenedBefore, afterID, limit)
+}
+
+// InsertScreeningReview mocks base method.
+func (m *MockIScreeningRepository) InsertScreeningReview(ctx context.Context, review *models.ScreeningReview) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertScreeningReview", ctx, review)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertScreeningReview indicates an expected call of InsertScreeningReview.
+func (mr *MockIScreeningRepositoryMockRecorder) InsertScreeningReview(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertScreeningReview", reflect.TypeOf((*MockIScreeningRepository)(nil).InsertScreeningReview), ctx, review)
+}
+
+// MarkUserScreened mocks base method.
+func (m *MockIScreeningRepository) MarkUserScreened(ctx context.Context, userID int, screenedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserScreened", ctx, userID, screenedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUserScreened indicates an expected call of MarkUserScreened.
+func (mr *MockIScreeningRepositoryMockRecorder) MarkUserScreened(ctx, userID, screenedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserScreened", reflect.TypeOf((*MockIScreeningRepository)(nil).MarkUserScreened), ctx, userID, screenedAt)
+}
+
+// ResolveScreeningReview mocks base method.
+func (m *MockIScreeningRepository) ResolveScreeningReview(ctx context.Context, review *models.ScreeningReview) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveScreeningReview", ctx, review)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveScreeningReview indicates an expected call of ResolveScreeningReview.
+func (mr *MockIScreeningRepositoryMockRecorder) ResolveScreeningReview(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveScreeningReview", reflect.TypeOf((*MockIScreeningRepository)(nil).ResolveScreeningReview), ctx, review)
+}
+
+// MockIScreeningService is a mock of IScreeningService interface.
+type MockIScreeningService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScreeningServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIScreeningServiceMockRecorder is the mock recorder for MockIScreeningService.
+type MockIScreeningServiceMockRecorder struct {
+	mock *MockIScreeningService
+}
+
+// NewMockIScreeningService creates a new mock instance.
+func NewMockIScreeningService(ctrl *gomock.Controller) *MockIScreeningService {
+	mock := &MockIScreeningService{ctrl: ctrl}
+	mock.recorder = &MockIScreeningServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScreeningService) EXPECT() *MockIScreeningServiceMockRecorder {
+	return m.recorder
+}
+
+// GetScreeningReview mocks base method.
+func (m *MockIScreeningService) GetScreeningReview(ctx context.Context, reviewID int) (models.ScreeningReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreeningReview", ctx, reviewID)
+	ret0, _ := ret[0].(models.ScreeningReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreeningReview indicates an expected call of GetScreeningReview.
+func (mr *MockIScreeningServiceMockRecorder) GetScreeningReview(ctx, reviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreeningReview", reflect.TypeOf((*MockIScreeningService)(nil).GetScreeningReview), ctx, reviewID)
+}
+
+// GetScreeningReviews mocks base method.
+func (m *MockIScreeningService) GetScreeningReviews(ctx context.Context, req models.ScreeningReviewListRequest) (pagination.Page[models.ScreeningReview], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreeningReviews", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.ScreeningReview])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreeningReviews indicates an expected call of GetScreeningReviews.
+func (mr *MockIScreeningServiceMockRecorder) GetScreeningReviews(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreeningReviews", reflect.TypeOf((*MockIScreeningService)(nil).GetScreeningReviews), ctx, req)
+}
+
+// ResolveScreeningReview mocks base method.
+func (m *MockIScreeningService) ResolveScreeningReview(ctx context.Context, actor string, reviewID int, req models.ScreeningReviewResolveRequest) (models.ScreeningReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveScreeningReview", ctx, actor, reviewID, req)
+	ret0, _ := ret[0].(models.ScreeningReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveScreeningReview indicates an expected call of ResolveScreeningReview.
+func (mr *MockIScreeningServiceMockRecorder) ResolveScreeningReview(ctx, actor, reviewID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveScreeningReview", reflect.TypeOf((*MockIScreeningService)(nil).ResolveScreeningReview), ctx, actor, reviewID, req)
+}
+
+// Run mocks base method.
+func (m *MockIScreeningService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIScreeningServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIScreeningService)(nil).Run), ctx)
+}
+
+// Screen mocks base method.
+func (m *MockIScreeningService) Screen(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockIScreeningServiceMockRecorder) Screen(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockIScreeningService)(nil).Screen), ctx, now)
+}
+
+// MockIScreeningHandler is a mock of IScreeningHandler interface.
+type MockIScreeningHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScreeningHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIScreeningHandlerMockRecorder is the mock recorder for MockIScreeningHandler.
+type MockIScreeningHandlerMockRecorder struct {
+	mock *MockIScreeningHandler
+}
+
+// NewMockIScreeningHandler creates a new mock instance.
+func NewMockIScreeningHandler(ctrl *gomock.Controller) *MockIScreeningHandler {
+	mock := &MockIScreeningHandler{ctrl: ctrl}
+	mock.recorder = &MockIScreeningHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScreeningHandler) EXPECT() *MockIScreeningHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetScreeningReview mocks base method.
+func (m *MockIScreeningHandler) GetScreeningReview(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetScreeningReview", c)
+}
+
+// GetScreeningReview indicates an expected call of GetScreeningReview.
+func (mr *MockIScreeningHandlerMockRecorder) GetScreeningReview(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreeningReview", reflect.TypeOf((*MockIScreeningHandler)(nil).GetScreeningReview), c)
+}
+
+// GetScreeningReviews mocks base method.
+func (m *MockIScreeningHandler) GetScreeningReviews(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetScreeningReviews", c)
+}
+
+// GetScreeningReviews indicates an expected call of GetScreeningReviews.
+func (mr *MockIScreeningHandlerMockRecorder) GetScreeningReviews(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreeningReviews", reflect.TypeOf((*MockIScreeningHandler)(nil).GetScreeningReviews), c)
+}
+
+// ResolveScreeningReview mocks base method.
+func (m *MockIScreeningHandler) ResolveScreeningReview(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResolveScreeningReview", c)
+}
+
+// ResolveScreeningReview indicates an expected call of ResolveScreeningReview.
+func (mr *MockIScreeningHandlerMockRecorder) ResolveScreeningReview(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveScreeningReview", reflect.TypeOf((*MockIScreeningHandler)(nil).ResolveScreeningReview), c)
+}
@@ -0,0 +1,227 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ISecurityQuestion.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ISecurityQuestion.go -destination=../mocks/mock_ISecurityQuestion.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISecurityQuestionRepository is a mock of ISecurityQuestionRepository interface.
+type MockISecurityQuestionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISecurityQuestionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockISecurityQuestionRepositoryMockRecorder is the mock recorder for MockISecurityQuestionRepository.
+type MockISecurityQuestionRepositoryMockRecorder struct {
+	mock *MockISecurityQuestionRepository
+}
+
+// NewMockISecurityQuestionRepository creates a new mock instance.
+func NewMockISecurityQuestionRepository(ctrl *gomock.Controller) *MockISecurityQuestionRepository {
+	mock := &MockISecurityQuestionRepository{ctrl: ctrl}
+	mock.recorder = &MockISecurityQuestionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISecurityQuestionRepository) EXPECT() *MockISecurityQuestionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSecurityQuestions mocks base method.
+func (m *MockISecurityQuestionRepository) GetSecurityQuestions(ctx context.Context, userID int) ([]models.SecurityQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityQuestions", ctx, userID)
+	ret0, _ := ret[0].([]models.SecurityQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityQuestions indicates an expected call of GetSecurityQuestions.
+func (mr *MockISecurityQuestionRepositoryMockRecorder) GetSecurityQuestions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityQuestions", reflect.TypeOf((*MockISecurityQuestionRepository)(nil).GetSecurityQuestions), ctx, userID)
+}
+
+// ReplaceSecurityQuestions mocks base method.
+func (m *MockISecurityQuestionRepository) ReplaceSecurityQuestions(ctx context.Context, userID int, questions []models.SecurityQuestion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceSecurityQuestions", ctx, userID, questions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceSecurityQuestions indicates an expected call of ReplaceSecurityQuestions.
+func (mr *MockISecurityQuestionRepositoryMockRecorder) ReplaceSecurityQuestions(ctx, userID, questions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceSecurityQuestions", reflect.TypeOf((*MockISecurityQuestionRepository)(nil).ReplaceSecurityQuestions), ctx, userID, questions)
+}
+
+// MockISecurityQuestionService is a mock of ISecurityQuestionService interface.
+type MockISecurityQuestionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISecurityQuestionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockISecurityQuestionServiceMockRecorder is the mock recorder for MockISecurityQuestionService.
+type MockISecurityQuestionServiceMockRecorder struct {
+	mock *MockISecurityQuestionService
+}
+
+// NewMockISecurityQuestionService creates a new mock instance.
+func NewMockISecurityQuestionService(ctrl *gomock.Controller) *MockISecurityQuestionService {
+	mock := &MockISecurityQuestionService{ctrl: ctrl}
+	mock.recorder = &MockISecurityQuestionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISecurityQuestionService) EXPECT() *MockISecurityQuestionServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRecoveryQuestions mocks base method.
+func (m *MockISecurityQuestionService) GetRecoveryQuestions(ctx context.Context, identifier string) ([]models.RecoveryQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecoveryQuestions", ctx, identifier)
+	ret0, _ := ret[0].([]models.RecoveryQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecoveryQuestions indicates an expected call of GetRecoveryQuestions.
+func (mr *MockISecurityQuestionServiceMockRecorder) GetRecoveryQuestions(ctx, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecoveryQuestions", reflect.TypeOf((*MockISecurityQuestionService)(nil).GetRecoveryQuestions), ctx, identifier)
+}
+
+// GetSecurityQuestions mocks base method.
+func (m *MockISecurityQuestionService) GetSecurityQuestions(ctx context.Context, userID int) ([]models.SecurityQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityQuestions", ctx, userID)
+	ret0, _ := ret[0].([]models.SecurityQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityQuestions indicates an expected call of GetSecurityQuestions.
+func (mr *MockISecurityQuestionServiceMockRecorder) GetSecurityQuestions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityQuestions", reflect.TypeOf((*MockISecurityQuestionService)(nil).GetSecurityQuestions), ctx, userID)
+}
+
+// RecoverWithSecurityQuestions mocks base method.
+func (m *MockISecurityQuestionService) RecoverWithSecurityQuestions(ctx context.Context, req models.SecurityQuestionRecoveryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverWithSecurityQuestions", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecoverWithSecurityQuestions indicates an expected call of RecoverWithSecurityQuestions.
+func (mr *MockISecurityQuestionServiceMockRecorder) RecoverWithSecurityQuestions(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverWithSecurityQuestions", reflect.TypeOf((*MockISecurityQuestionService)(nil).RecoverWithSecurityQuestions), ctx, req)
+}
+
+// SetSecurityQuestions mocks base method.
+func (m *MockISecurityQuestionService) SetSecurityQuestions(ctx context.Context, userID int, req models.SetSecurityQuestionsRequest) ([]models.SecurityQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSecurityQuestions", ctx, userID, req)
+	ret0, _ := ret[0].([]models.SecurityQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSecurityQuestions indicates an expected call of SetSecurityQuestions.
+func (mr *MockISecurityQuestionServiceMockRecorder) SetSecurityQuestions(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecurityQuestions", reflect.TypeOf((*MockISecurityQuestionService)(nil).SetSecurityQuestions), ctx, userID, req)
+}
+
+// MockISecurityQuestionHandler is a mock of ISecurityQuestionHandler interface.
+type MockISecurityQuestionHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockISecurityQuestionHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockISecurityQuestionHandlerMockRecorder is the mock recorder for MockISecurityQuestionHandler.
+type MockISecurityQuestionHandlerMockRecorder struct {
+	mock *MockISecurityQuestionHandler
+}
+
+// NewMockISecurityQuestionHandler creates a new mock instance.
+func NewMockISecurityQuestionHandler(ctrl *gomock.Controller) *MockISecurityQuestionHandler {
+	mock := &MockISecurityQuestionHandler{ctrl: ctrl}
+	mock.recorder = &MockISecurityQuestionHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISecurityQuestionHandler) EXPECT() *MockISecurityQuestionHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetRecoveryQuestions mocks base method.
+func (m *MockISecurityQuestionHandler) GetRecoveryQuestions(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetRecoveryQuestions", c)
+}
+
+// GetRecoveryQuestions indicates an expected call of GetRecoveryQuestions.
+func (mr *MockISecurityQuestionHandlerMockRecorder) GetRecoveryQuestions(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecoveryQuestions", reflect.TypeOf((*MockISecurityQuestionHandler)(nil).GetRecoveryQuestions), c)
+}
+
+// GetSecurityQuestions mocks base method.
+func (m *MockISecurityQuestionHandler) GetSecurityQuestions(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetSecurityQuestions", c)
+}
+
+// GetSecurityQuestions indicates an expected call of GetSecurityQuestions.
+func (mr *MockISecurityQuestionHandlerMockRecorder) GetSecurityQuestions(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityQuestions", reflect.TypeOf((*MockISecurityQuestionHandler)(nil).GetSecurityQuestions), c)
+}
+
+// RecoverWithSecurityQuestions mocks base method.
+func (m *MockISecurityQuestionHandler) RecoverWithSecurityQuestions(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecoverWithSecurityQuestions", c)
+}
+
+// RecoverWithSecurityQuestions indicates an expected call of RecoverWithSecurityQuestions.
+func (mr *MockISecurityQuestionHandlerMockRecorder) RecoverWithSecurityQuestions(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverWithSecurityQuestions", reflect.TypeOf((*MockISecurityQuestionHandler)(nil).RecoverWithSecurityQuestions), c)
+}
+
+// SetSecurityQuestions mocks base method.
+func (m *MockISecurityQuestionHandler) SetSecurityQuestions(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSecurityQuestions", c)
+}
+
+// SetSecurityQuestions indicates an expected call of SetSecurityQuestions.
+func (mr *MockISecurityQuestionHandlerMockRecorder) SetSecurityQuestions(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecurityQuestions", reflect.TypeOf((*MockISecurityQuestionHandler)(nil).SetSecurityQuestions), c)
+}
@@ -0,0 +1,369 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IServiceAccount.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IServiceAccount.go -destination=../mocks/mock_IServiceAccount.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIServiceAccountRepository is a mock of IServiceAccountRepository interface.
+type MockIServiceAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceAccountRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIServiceAccountRepositoryMockRecorder is the mock recorder for MockIServiceAccountRepository.
+type MockIServiceAccountRepositoryMockRecorder struct {
+	mock *MockIServiceAccountRepository
+}
+
+// NewMockIServiceAccountRepository creates a new mock instance.
+func NewMockIServiceAccountRepository(ctrl *gomock.Controller) *MockIServiceAccountRepository {
+	mock := &MockIServiceAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockIServiceAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIServiceAccountRepository) EXPECT() *MockIServiceAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteServiceAccount mocks base method.
+func (m *MockIServiceAccountRepository) DeleteServiceAccount(ctx context.Context, account *models.ServiceAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceAccount", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServiceAccount indicates an expected call of DeleteServiceAccount.
+func (mr *MockIServiceAccountRepositoryMockRecorder) DeleteServiceAccount(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockIServiceAccountRepository)(nil).DeleteServiceAccount), ctx, account)
+}
+
+// GetServiceAccountByClientID mocks base method.
+func (m *MockIServiceAccountRepository) GetServiceAccountByClientID(ctx context.Context, clientID string) (models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccountByClientID", ctx, clientID)
+	ret0, _ := ret[0].(models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccountByClientID indicates an expected call of GetServiceAccountByClientID.
+func (mr *MockIServiceAccountRepositoryMockRecorder) GetServiceAccountByClientID(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccountByClientID", reflect.TypeOf((*MockIServiceAccountRepository)(nil).GetServiceAccountByClientID), ctx, clientID)
+}
+
+// GetServiceAccountByID mocks base method.
+func (m *MockIServiceAccountRepository) GetServiceAccountByID(ctx context.Context, accountID int) (models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccountByID", ctx, accountID)
+	ret0, _ := ret[0].(models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccountByID indicates an expected call of GetServiceAccountByID.
+func (mr *MockIServiceAccountRepositoryMockRecorder) GetServiceAccountByID(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccountByID", reflect.TypeOf((*MockIServiceAccountRepository)(nil).GetServiceAccountByID), ctx, accountID)
+}
+
+// GetServiceAccounts mocks base method.
+func (m *MockIServiceAccountRepository) GetServiceAccounts(ctx context.Context, cursor *pagination.Cursor, limit int) ([]models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccounts", ctx, cursor, limit)
+	ret0, _ := ret[0].([]models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccounts indicates an expected call of GetServiceAccounts.
+func (mr *MockIServiceAccountRepositoryMockRecorder) GetServiceAccounts(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccounts", reflect.TypeOf((*MockIServiceAccountRepository)(nil).GetServiceAccounts), ctx, cursor, limit)
+}
+
+// InsertServiceAccount mocks base method.
+func (m *MockIServiceAccountRepository) InsertServiceAccount(ctx context.Context, user *models.User, account *models.ServiceAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertServiceAccount", ctx, user, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertServiceAccount indicates an expected call of InsertServiceAccount.
+func (mr *MockIServiceAccountRepositoryMockRecorder) InsertServiceAccount(ctx, user, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertServiceAccount", reflect.TypeOf((*MockIServiceAccountRepository)(nil).InsertServiceAccount), ctx, user, account)
+}
+
+// UpdateServiceAccount mocks base method.
+func (m *MockIServiceAccountRepository) UpdateServiceAccount(ctx context.Context, account *models.ServiceAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceAccount", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateServiceAccount indicates an expected call of UpdateServiceAccount.
+func (mr *MockIServiceAccountRepositoryMockRecorder) UpdateServiceAccount(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccount", reflect.TypeOf((*MockIServiceAccountRepository)(nil).UpdateServiceAccount), ctx, account)
+}
+
+// UpdateServiceAccountSecret mocks base method.
+func (m *MockIServiceAccountRepository) UpdateServiceAccountSecret(ctx context.Context, userID int, secretHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceAccountSecret", ctx, userID, secretHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateServiceAccountSecret indicates an expected call of UpdateServiceAccountSecret.
+func (mr *MockIServiceAccountRepositoryMockRecorder) UpdateServiceAccountSecret(ctx, userID, secretHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccountSecret", reflect.TypeOf((*MockIServiceAccountRepository)(nil).UpdateServiceAccountSecret), ctx, userID, secretHash)
+}
+
+// MockIServiceAccountService is a mock of IServiceAccountService interface.
+type MockIServiceAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceAccountServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIServiceAccountServiceMockRecorder is the mock recorder for MockIServiceAccountService.
+type MockIServiceAccountServiceMockRecorder struct {
+	mock *MockIServiceAccountService
+}
+
+// NewMockIServiceAccountService creates a new mock instance.
+func NewMockIServiceAccountService(ctrl *gomock.Controller) *MockIServiceAccountService {
+	mock := &MockIServiceAccountService{ctrl: ctrl}
+	mock.recorder = &MockIServiceAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIServiceAccountService) EXPECT() *MockIServiceAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateServiceAccount mocks base method.
+func (m *MockIServiceAccountService) CreateServiceAccount(ctx context.Context, createdBy int, req models.ServiceAccountRequest) (models.ServiceAccountCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceAccount", ctx, createdBy, req)
+	ret0, _ := ret[0].(models.ServiceAccountCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateServiceAccount indicates an expected call of CreateServiceAccount.
+func (mr *MockIServiceAccountServiceMockRecorder) CreateServiceAccount(ctx, createdBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAccount", reflect.TypeOf((*MockIServiceAccountService)(nil).CreateServiceAccount), ctx, createdBy, req)
+}
+
+// DeleteServiceAccount mocks base method.
+func (m *MockIServiceAccountService) DeleteServiceAccount(ctx context.Context, accountID, deletedBy int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceAccount", ctx, accountID, deletedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServiceAccount indicates an expected call of DeleteServiceAccount.
+func (mr *MockIServiceAccountServiceMockRecorder) DeleteServiceAccount(ctx, accountID, deletedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockIServiceAccountService)(nil).DeleteServiceAccount), ctx, accountID, deletedBy)
+}
+
+// GetServiceAccount mocks base method.
+func (m *MockIServiceAccountService) GetServiceAccount(ctx context.Context, accountID int) (models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccount", ctx, accountID)
+	ret0, _ := ret[0].(models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccount indicates an expected call of GetServiceAccount.
+func (mr *MockIServiceAccountServiceMockRecorder) GetServiceAccount(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockIServiceAccountService)(nil).GetServiceAccount), ctx, accountID)
+}
+
+// GetServiceAccounts mocks base method.
+func (m *MockIServiceAccountService) GetServiceAccounts(ctx context.Context, req models.ServiceAccountListRequest) (pagination.Page[models.ServiceAccount], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccounts", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.ServiceAccount])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccounts indicates an expected call of GetServiceAccounts.
+func (mr *MockIServiceAccountServiceMockRecorder) GetServiceAccounts(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccounts", reflect.TypeOf((*MockIServiceAccountService)(nil).GetServiceAccounts), ctx, req)
+}
+
+// IssueToken mocks base method.
+func (m *MockIServiceAccountService) IssueToken(ctx context.Context, clientID, clientSecret, scope string) (models.OAuthTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueToken", ctx, clientID, clientSecret, scope)
+	ret0, _ := ret[0].(models.OAuthTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueToken indicates an expected call of IssueToken.
+func (mr *MockIServiceAccountServiceMockRecorder) IssueToken(ctx, clientID, clientSecret, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueToken", reflect.TypeOf((*MockIServiceAccountService)(nil).IssueToken), ctx, clientID, clientSecret, scope)
+}
+
+// RotateSecret mocks base method.
+func (m *MockIServiceAccountService) RotateSecret(ctx context.Context, accountID, rotatedBy int) (models.ServiceAccountCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSecret", ctx, accountID, rotatedBy)
+	ret0, _ := ret[0].(models.ServiceAccountCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSecret indicates an expected call of RotateSecret.
+func (mr *MockIServiceAccountServiceMockRecorder) RotateSecret(ctx, accountID, rotatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecret", reflect.TypeOf((*MockIServiceAccountService)(nil).RotateSecret), ctx, accountID, rotatedBy)
+}
+
+// UpdateServiceAccount mocks base method.
+func (m *MockIServiceAccountService) UpdateServiceAccount(ctx context.Context, accountID, updatedBy int, req models.ServiceAccountRequest) (models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceAccount", ctx, accountID, updatedBy, req)
+	ret0, _ := ret[0].(models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateServiceAccount indicates an expected call of UpdateServiceAccount.
+func (mr *MockIServiceAccountServiceMockRecorder) UpdateServiceAccount(ctx, accountID, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccount", reflect.TypeOf((*MockIServiceAccountService)(nil).UpdateServiceAccount), ctx, accountID, updatedBy, req)
+}
+
+// MockIServiceAccountHandler is a mock of IServiceAccountHandler interface.
+type MockIServiceAccountHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceAccountHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIServiceAccountHandlerMockRecorder is the mock recorder for MockIServiceAccountHandler.
+type MockIServiceAccountHandlerMockRecorder struct {
+	mock *MockIServiceAccountHandler
+}
+
+// NewMockIServiceAccountHandler creates a new mock instance.
+func NewMockIServiceAccountHandler(ctrl *gomock.Controller) *MockIServiceAccountHandler {
+	mock := &MockIServiceAccountHandler{ctrl: ctrl}
+	mock.recorder = &MockIServiceAccountHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIServiceAccountHandler) EXPECT() *MockIServiceAccountHandlerMockRecorder {
+	return m.recorder
+}
+
+// CreateServiceAccount mocks base method.
+func (m *MockIServiceAccountHandler) CreateServiceAccount(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateServiceAccount", c)
+}
+
+// CreateServiceAccount indicates an expected call of CreateServiceAccount.
+func (mr *MockIServiceAccountHandlerMockRecorder) CreateServiceAccount(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAccount", reflect.TypeOf((*MockIServiceAccountHandler)(nil).CreateServiceAccount), c)
+}
+
+// DeleteServiceAccount mocks base method.
+func (m *MockIServiceAccountHandler) DeleteServiceAccount(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteServiceAccount", c)
+}
+
+// DeleteServiceAccount indicates an expected call of DeleteServiceAccount.
+func (mr *MockIServiceAccountHandlerMockRecorder) DeleteServiceAccount(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockIServiceAccountHandler)(nil).DeleteServiceAccount), c)
+}
+
+// GetServiceAccount mocks base method.
+func (m *MockIServiceAccountHandler) GetServiceAccount(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetServiceAccount", c)
+}
+
+// GetServiceAccount indicates an expected call of GetServiceAccount.
+func (mr *MockIServiceAccountHandlerMockRecorder) GetServiceAccount(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockIServiceAccountHandler)(nil).GetServiceAccount), c)
+}
+
+// GetServiceAccounts mocks base method.
+func (m *MockIServiceAccountHandler) GetServiceAccounts(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetServiceAccounts", c)
+}
+
+// GetServiceAccounts indicates an expected call of GetServiceAccounts.
+func (mr *MockIServiceAccountHandlerMockRecorder) GetServiceAccounts(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccounts", reflect.TypeOf((*MockIServiceAccountHandler)(nil).GetServiceAccounts), c)
+}
+
+// RotateSecret mocks base method.
+func (m *MockIServiceAccountHandler) RotateSecret(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RotateSecret", c)
+}
+
+// RotateSecret indicates an expected call of RotateSecret.
+func (mr *MockIServiceAccountHandlerMockRecorder) RotateSecret(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecret", reflect.TypeOf((*MockIServiceAccountHandler)(nil).RotateSecret), c)
+}
+
+// UpdateServiceAccount mocks base method.
+func (m *MockIServiceAccountHandler) UpdateServiceAccount(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateServiceAccount", c)
+}
+
+// UpdateServiceAccount indicates an expected call of UpdateServiceAccount.
+func (mr *MockIServiceAccountHandlerMockRecorder) UpdateServiceAccount(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccount", reflect.TypeOf((*MockIServiceAccountHandler)(nil).UpdateServiceAccount), c)
+}
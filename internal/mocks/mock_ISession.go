@@ -0,0 +1,252 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ISession.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ISession.go -destination=../mocks/mock_ISession.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISessionRepository is a mock of ISessionRepository interface.
+type MockISessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockISessionRepositoryMockRecorder is the mock recorder for MockISessionRepository.
+type MockISessionRepositoryMockRecorder struct {
+	mock *MockISessionRepository
+}
+
+// NewMockISessionRepository creates a new mock instance.
+func NewMockISessionRepository(ctrl *gomock.Controller) *MockISessionRepository {
+	mock := &MockISessionRepository{ctrl: ctrl}
+	mock.recorder = &MockISessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionRepository) EXPECT() *MockISessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountActiveUserSessions mocks base method.
+func (m *MockISessionRepository) CountActiveUserSessions(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveUserSessions", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveUserSessions indicates an expected call of CountActiveUserSessions.
+func (mr *MockISessionRepositoryMockRecorder) CountActiveUserSessions(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveUserSessions", reflect.TypeOf((*MockISessionRepository)(nil).CountActiveUserSessions), ctx, now)
+}
+
+// DeleteUserSession mocks base method.
+func (m *MockISessionRepository) DeleteUserSession(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSession", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSession indicates an expected call of DeleteUserSession.
+func (mr *MockISessionRepositoryMockRecorder) DeleteUserSession(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSession", reflect.TypeOf((*MockISessionRepository)(nil).DeleteUserSession), ctx, token)
+}
+
+// GetActiveUserSessions mocks base method.
+func (m *MockISessionRepository) GetActiveUserSessions(ctx context.Context, userID int, now time.Time) ([]models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveUserSessions", ctx, userID, now)
+	ret0, _ := ret[0].([]models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveUserSessions indicates an expected call of GetActiveUserSessions.
+func (mr *MockISessionRepositoryMockRecorder) GetActiveUserSessions(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserSessions", reflect.TypeOf((*MockISessionRepository)(nil).GetActiveUserSessions), ctx, userID, now)
+}
+
+// GetUserSessionByRefreshToken mocks base method.
+func (m *MockISessionRepository) GetUserSessionByRefreshToken(ctx context.Context, refreshToken string) (models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessionByRefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessionByRefreshToken indicates an expected call of GetUserSessionByRefreshToken.
+func (mr *MockISessionRepositoryMockRecorder) GetUserSessionByRefreshToken(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessionByRefreshToken", reflect.TypeOf((*MockISessionRepository)(nil).GetUserSessionByRefreshToken), ctx, refreshToken)
+}
+
+// GetUserSessionByToken mocks base method.
+func (m *MockISessionRepository) GetUserSessionByToken(ctx context.Context, token string) (models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessionByToken", ctx, token)
+	ret0, _ := ret[0].(models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessionByToken indicates an expected call of GetUserSessionByToken.
+func (mr *MockISessionRepositoryMockRecorder) GetUserSessionByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessionByToken", reflect.TypeOf((*MockISessionRepository)(nil).GetUserSessionByToken), ctx, token)
+}
+
+// GetUserSessionByTokenID mocks base method.
+func (m *MockISessionRepository) GetUserSessionByTokenID(ctx context.Context, tokenID string) (models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessionByTokenID", ctx, tokenID)
+	ret0, _ := ret[0].(models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessionByTokenID indicates an expected call of GetUserSessionByTokenID.
+func (mr *MockISessionRepositoryMockRecorder) GetUserSessionByTokenID(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessionByTokenID", reflect.TypeOf((*MockISessionRepository)(nil).GetUserSessionByTokenID), ctx, tokenID)
+}
+
+// GetUserSessionsByUserID mocks base method.
+func (m *MockISessionRepository) GetUserSessionsByUserID(ctx context.Context, userID int, cursor *pagination.Cursor, limit int) ([]models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessionsByUserID", ctx, userID, cursor, limit)
+	ret0, _ := ret[0].([]models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessionsByUserID indicates an expected call of GetUserSessionsByUserID.
+func (mr *MockISessionRepositoryMockRecorder) GetUserSessionsByUserID(ctx, userID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessionsByUserID", reflect.TypeOf((*MockISessionRepository)(nil).GetUserSessionsByUserID), ctx, userID, cursor, limit)
+}
+
+// InsertNewUserSession mocks base method.
+func (m *MockISessionRepository) InsertNewUserSession(ctx context.Context, session *models.UserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewUserSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewUserSession indicates an expected call of InsertNewUserSession.
+func (mr *MockISessionRepositoryMockRecorder) InsertNewUserSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewUserSession", reflect.TypeOf((*MockISessionRepository)(nil).InsertNewUserSession), ctx, session)
+}
+
+// UpdateTokenByRefreshToken mocks base method.
+func (m *MockISessionRepository) UpdateTokenByRefreshToken(ctx context.Context, token, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTokenByRefreshToken", ctx, token, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTokenByRefreshToken indicates an expected call of UpdateTokenByRefreshToken.
+func (mr *MockISessionRepositoryMockRecorder) UpdateTokenByRefreshToken(ctx, token, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTokenByRefreshToken", reflect.TypeOf((*MockISessionRepository)(nil).UpdateTokenByRefreshToken), ctx, token, refreshToken)
+}
+
+// MockISessionService is a mock of ISessionService interface.
+type MockISessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockISessionServiceMockRecorder is the mock recorder for MockISessionService.
+type MockISessionServiceMockRecorder struct {
+	mock *MockISessionService
+}
+
+// NewMockISessionService creates a new mock instance.
+func NewMockISessionService(ctrl *gomock.Controller) *MockISessionService {
+	mock := &MockISessionService{ctrl: ctrl}
+	mock.recorder = &MockISessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionService) EXPECT() *MockISessionServiceMockRecorder {
+	return m.recorder
+}
+
+// GetSessions mocks base method.
+func (m *MockISessionService) GetSessions(ctx context.Context, userID int, req pagination.Request) (pagination.Page[models.SessionItem], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", ctx, userID, req)
+	ret0, _ := ret[0].(pagination.Page[models.SessionItem])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockISessionServiceMockRecorder) GetSessions(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockISessionService)(nil).GetSessions), ctx, userID, req)
+}
+
+// MockISessionHandler is a mock of ISessionHandler interface.
+type MockISessionHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockISessionHandlerMockRecorder is the mock recorder for MockISessionHandler.
+type MockISessionHandlerMockRecorder struct {
+	mock *MockISessionHandler
+}
+
+// NewMockISessionHandler creates a new mock instance.
+func NewMockISessionHandler(ctrl *gomock.Controller) *MockISessionHandler {
+	mock := &MockISessionHandler{ctrl: ctrl}
+	mock.recorder = &MockISessionHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionHandler) EXPECT() *MockISessionHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetSessions mocks base method.
+func (m *MockISessionHandler) GetSessions(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetSessions", c)
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockISessionHandlerMockRecorder) GetSessions(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockISessionHandler)(nil).GetSessions), c)
+}
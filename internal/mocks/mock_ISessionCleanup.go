@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ISessionCleanup.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ISessionCleanup.go -destination=../mocks/mock_ISessionCleanup.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISessionCleanupService is a mock of ISessionCleanupService interface.
+type MockISessionCleanupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionCleanupServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockISessionCleanupServiceMockRecorder is the mock recorder for MockISessionCleanupService.
+type MockISessionCleanupServiceMockRecorder struct {
+	mock *MockISessionCleanupService
+}
+
+// NewMockISessionCleanupService creates a new mock instance.
+func NewMockISessionCleanupService(ctrl *gomock.Controller) *MockISessionCleanupService {
+	mock := &MockISessionCleanupService{ctrl: ctrl}
+	mock.recorder = &MockISessionCleanupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionCleanupService) EXPECT() *MockISessionCleanupServiceMockRecorder {
+	return m.recorder
+}
+
+// CleanupExpiredSessions mocks base method.
+func (m *MockISessionCleanupService) CleanupExpiredSessions(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupExpiredSessions", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupExpiredSessions indicates an expected call of CleanupExpiredSessions.
+func (mr *MockISessionCleanupServiceMockRecorder) CleanupExpiredSessions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupExpiredSessions", reflect.TypeOf((*MockISessionCleanupService)(nil).CleanupExpiredSessions), ctx)
+}
+
+// Run mocks base method.
+func (m *MockISessionCleanupService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockISessionCleanupServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockISessionCleanupService)(nil).Run), ctx)
+}
@@ -0,0 +1,272 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IStats.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IStats.go -destination=../mocks/mock_IStats.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIStatsRepository is a mock of IStatsRepository interface.
+type MockIStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIStatsRepositoryMockRecorder is the mock recorder for MockIStatsRepository.
+type MockIStatsRepositoryMockRecorder struct {
+	mock *MockIStatsRepository
+}
+
+// NewMockIStatsRepository creates a new mock instance.
+func NewMockIStatsRepository(ctrl *gomock.Controller) *MockIStatsRepository {
+	mock := &MockIStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockIStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatsRepository) EXPECT() *MockIStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountFailedLoginsByDay mocks base method.
+func (m *MockIStatsRepository) CountFailedLoginsByDay(ctx context.Context, since time.Time) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFailedLoginsByDay", ctx, since)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFailedLoginsByDay indicates an expected call of CountFailedLoginsByDay.
+func (mr *MockIStatsRepositoryMockRecorder) CountFailedLoginsByDay(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFailedLoginsByDay", reflect.TypeOf((*MockIStatsRepository)(nil).CountFailedLoginsByDay), ctx, since)
+}
+
+// CountRegistrationsByDay mocks base method.
+func (m *MockIStatsRepository) CountRegistrationsByDay(ctx context.Context, since time.Time) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRegistrationsByDay", ctx, since)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRegistrationsByDay indicates an expected call of CountRegistrationsByDay.
+func (mr *MockIStatsRepositoryMockRecorder) CountRegistrationsByDay(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRegistrationsByDay", reflect.TypeOf((*MockIStatsRepository)(nil).CountRegistrationsByDay), ctx, since)
+}
+
+// CountUsersByKycStatus mocks base method.
+func (m *MockIStatsRepository) CountUsersByKycStatus(ctx context.Context, status string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsersByKycStatus", ctx, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsersByKycStatus indicates an expected call of CountUsersByKycStatus.
+func (mr *MockIStatsRepositoryMockRecorder) CountUsersByKycStatus(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsersByKycStatus", reflect.TypeOf((*MockIStatsRepository)(nil).CountUsersByKycStatus), ctx, status)
+}
+
+// GetDailyStats mocks base method.
+func (m *MockIStatsRepository) GetDailyStats(ctx context.Context, sinceDay string) ([]models.DailyStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyStats", ctx, sinceDay)
+	ret0, _ := ret[0].([]models.DailyStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyStats indicates an expected call of GetDailyStats.
+func (mr *MockIStatsRepositoryMockRecorder) GetDailyStats(ctx, sinceDay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyStats", reflect.TypeOf((*MockIStatsRepository)(nil).GetDailyStats), ctx, sinceDay)
+}
+
+// UpsertDailyStats mocks base method.
+func (m *MockIStatsRepository) UpsertDailyStats(ctx context.Context, stats []models.DailyStat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDailyStats", ctx, stats)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertDailyStats indicates an expected call of UpsertDailyStats.
+func (mr *MockIStatsRepositoryMockRecorder) UpsertDailyStats(ctx, stats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDailyStats", reflect.TypeOf((*MockIStatsRepository)(nil).UpsertDailyStats), ctx, stats)
+}
+
+// MockIActiveUsersRepository is a mock of IActiveUsersRepository interface.
+type MockIActiveUsersRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIActiveUsersRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIActiveUsersRepositoryMockRecorder is the mock recorder for MockIActiveUsersRepository.
+type MockIActiveUsersRepositoryMockRecorder struct {
+	mock *MockIActiveUsersRepository
+}
+
+// NewMockIActiveUsersRepository creates a new mock instance.
+func NewMockIActiveUsersRepository(ctrl *gomock.Controller) *MockIActiveUsersRepository {
+	mock := &MockIActiveUsersRepository{ctrl: ctrl}
+	mock.recorder = &MockIActiveUsersRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIActiveUsersRepository) EXPECT() *MockIActiveUsersRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountActiveUsers mocks base method.
+func (m *MockIActiveUsersRepository) CountActiveUsers(ctx context.Context, now time.Time, hours int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveUsers", ctx, now, hours)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveUsers indicates an expected call of CountActiveUsers.
+func (mr *MockIActiveUsersRepositoryMockRecorder) CountActiveUsers(ctx, now, hours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveUsers", reflect.TypeOf((*MockIActiveUsersRepository)(nil).CountActiveUsers), ctx, now, hours)
+}
+
+// RecordActiveUser mocks base method.
+func (m *MockIActiveUsersRepository) RecordActiveUser(ctx context.Context, userID int, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordActiveUser", ctx, userID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordActiveUser indicates an expected call of RecordActiveUser.
+func (mr *MockIActiveUsersRepositoryMockRecorder) RecordActiveUser(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordActiveUser", reflect.TypeOf((*MockIActiveUsersRepository)(nil).RecordActiveUser), ctx, userID, now)
+}
+
+// MockIStatsService is a mock of IStatsService interface.
+type MockIStatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIStatsServiceMockRecorder is the mock recorder for MockIStatsService.
+type MockIStatsServiceMockRecorder struct {
+	mock *MockIStatsService
+}
+
+// NewMockIStatsService creates a new mock instance.
+func NewMockIStatsService(ctrl *gomock.Controller) *MockIStatsService {
+	mock := &MockIStatsService{ctrl: ctrl}
+	mock.recorder = &MockIStatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatsService) EXPECT() *MockIStatsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetStats mocks base method.
+func (m *MockIStatsService) GetStats(ctx context.Context, days int) (models.AdminStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, days)
+	ret0, _ := ret[0].(models.AdminStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockIStatsServiceMockRecorder) GetStats(ctx, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockIStatsService)(nil).GetStats), ctx, days)
+}
+
+// Refresh mocks base method.
+func (m *MockIStatsService) Refresh(ctx context.Context, now time.Time, days int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, now, days)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockIStatsServiceMockRecorder) Refresh(ctx, now, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockIStatsService)(nil).Refresh), ctx, now, days)
+}
+
+// Run mocks base method.
+func (m *MockIStatsService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIStatsServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIStatsService)(nil).Run), ctx)
+}
+
+// MockIStatsHandler is a mock of IStatsHandler interface.
+type MockIStatsHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatsHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIStatsHandlerMockRecorder is the mock recorder for MockIStatsHandler.
+type MockIStatsHandlerMockRecorder struct {
+	mock *MockIStatsHandler
+}
+
+// NewMockIStatsHandler creates a new mock instance.
+func NewMockIStatsHandler(ctrl *gomock.Controller) *MockIStatsHandler {
+	mock := &MockIStatsHandler{ctrl: ctrl}
+	mock.recorder = &MockIStatsHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatsHandler) EXPECT() *MockIStatsHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetStats mocks base method.
+func (m *MockIStatsHandler) GetStats(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetStats", c)
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockIStatsHandlerMockRecorder) GetStats(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockIStatsHandler)(nil).GetStats), c)
+}
@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ITokenCache.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ITokenCache.go -destination=../mocks/mock_ITokenCache.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	helpers "ewallet-ums/helpers"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITokenCacheRepository is a mock of ITokenCacheRepository interface.
+type MockITokenCacheRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenCacheRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockITokenCacheRepositoryMockRecorder is the mock recorder for MockITokenCacheRepository.
+type MockITokenCacheRepositoryMockRecorder struct {
+	mock *MockITokenCacheRepository
+}
+
+// NewMockITokenCacheRepository creates a new mock instance.
+func NewMockITokenCacheRepository(ctrl *gomock.Controller) *MockITokenCacheRepository {
+	mock := &MockITokenCacheRepository{ctrl: ctrl}
+	mock.recorder = &MockITokenCacheRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenCacheRepository) EXPECT() *MockITokenCacheRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRevokedToken mocks base method.
+func (m *MockITokenCacheRepository) DeleteRevokedToken(ctx context.Context, key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRevokedToken", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRevokedToken indicates an expected call of DeleteRevokedToken.
+func (mr *MockITokenCacheRepositoryMockRecorder) DeleteRevokedToken(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRevokedToken", reflect.TypeOf((*MockITokenCacheRepository)(nil).DeleteRevokedToken), ctx, key)
+}
+
+// EvictToken mocks base method.
+func (m *MockITokenCacheRepository) EvictToken(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvictToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EvictToken indicates an expected call of EvictToken.
+func (mr *MockITokenCacheRepositoryMockRecorder) EvictToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictToken", reflect.TypeOf((*MockITokenCacheRepository)(nil).EvictToken), ctx, token)
+}
+
+// GetRevokedTokens mocks base method.
+func (m *MockITokenCacheRepository) GetRevokedTokens(ctx context.Context, cursor uint64, count int) ([]models.RevokedToken, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevokedTokens", ctx, cursor, count)
+	ret0, _ := ret[0].([]models.RevokedToken)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRevokedTokens indicates an expected call of GetRevokedTokens.
+func (mr *MockITokenCacheRepositoryMockRecorder) GetRevokedTokens(ctx, cursor, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevokedTokens", reflect.TypeOf((*MockITokenCacheRepository)(nil).GetRevokedTokens), ctx, cursor, count)
+}
+
+// GetTokenClaim mocks base method.
+func (m *MockITokenCacheRepository) GetTokenClaim(ctx context.Context, token string) (*helpers.ClaimToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenClaim", ctx, token)
+	ret0, _ := ret[0].(*helpers.ClaimToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenClaim indicates an expected call of GetTokenClaim.
+func (mr *MockITokenCacheRepositoryMockRecorder) GetTokenClaim(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenClaim", reflect.TypeOf((*MockITokenCacheRepository)(nil).GetTokenClaim), ctx, token)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockITokenCacheRepository) IsTokenRevoked(ctx context.Context, token, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, token, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockITokenCacheRepositoryMockRecorder) IsTokenRevoked(ctx, token, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockITokenCacheRepository)(nil).IsTokenRevoked), ctx, token, tokenID)
+}
+
+// RevokeToken mocks base method.
+func (m *MockITokenCacheRepository) RevokeToken(ctx context.Context, token string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, token, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockITokenCacheRepositoryMockRecorder) RevokeToken(ctx, token, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockITokenCacheRepository)(nil).RevokeToken), ctx, token, expiresAt)
+}
+
+// RevokeTokenID mocks base method.
+func (m *MockITokenCacheRepository) RevokeTokenID(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokenID", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTokenID indicates an expected call of RevokeTokenID.
+func (mr *MockITokenCacheRepositoryMockRecorder) RevokeTokenID(ctx, tokenID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokenID", reflect.TypeOf((*MockITokenCacheRepository)(nil).RevokeTokenID), ctx, tokenID, expiresAt)
+}
+
+// SetTokenClaim mocks base method.
+func (m *MockITokenCacheRepository) SetTokenClaim(ctx context.Context, token string, claim *helpers.ClaimToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTokenClaim", ctx, token, claim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTokenClaim indicates an expected call of SetTokenClaim.
+func (mr *MockITokenCacheRepositoryMockRecorder) SetTokenClaim(ctx, token, claim any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTokenClaim", reflect.TypeOf((*MockITokenCacheRepository)(nil).SetTokenClaim), ctx, token, claim)
+}
+
+// SubscribeRevocation mocks base method.
+func (m *MockITokenCacheRepository) SubscribeRevocation(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SubscribeRevocation", ctx)
+}
+
+// SubscribeRevocation indicates an expected call of SubscribeRevocation.
+func (mr *MockITokenCacheRepositoryMockRecorder) SubscribeRevocation(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeRevocation", reflect.TypeOf((*MockITokenCacheRepository)(nil).SubscribeRevocation), ctx)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ITokenExchange.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ITokenExchange.go -destination=../mocks/mock_ITokenExchange.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITokenExchangeService is a mock of ITokenExchangeService interface.
+type MockITokenExchangeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenExchangeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockITokenExchangeServiceMockRecorder is the mock recorder for MockITokenExchangeService.
+type MockITokenExchangeServiceMockRecorder struct {
+	mock *MockITokenExchangeService
+}
+
+// NewMockITokenExchangeService creates a new mock instance.
+func NewMockITokenExchangeService(ctrl *gomock.Controller) *MockITokenExchangeService {
+	mock := &MockITokenExchangeService{ctrl: ctrl}
+	mock.recorder = &MockITokenExchangeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenExchangeService) EXPECT() *MockITokenExchangeServiceMockRecorder {
+	return m.recorder
+}
+
+// Exchange mocks base method.
+func (m *MockITokenExchangeService) Exchange(ctx context.Context, clientID string, req models.TokenExchangeRequest) (models.OAuthTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, clientID, req)
+	ret0, _ := ret[0].(models.OAuthTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockITokenExchangeServiceMockRecorder) Exchange(ctx, clientID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockITokenExchangeService)(nil).Exchange), ctx, clientID, req)
+}
+
+// MockIOAuthTokenHandler is a mock of IOAuthTokenHandler interface.
+type MockIOAuthTokenHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOAuthTokenHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIOAuthTokenHandlerMockRecorder is the mock recorder for MockIOAuthTokenHandler.
+type MockIOAuthTokenHandlerMockRecorder struct {
+	mock *MockIOAuthTokenHandler
+}
+
+// NewMockIOAuthTokenHandler creates a new mock instance.
+func NewMockIOAuthTokenHandler(ctrl *gomock.Controller) *MockIOAuthTokenHandler {
+	mock := &MockIOAuthTokenHandler{ctrl: ctrl}
+	mock.recorder = &MockIOAuthTokenHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOAuthTokenHandler) EXPECT() *MockIOAuthTokenHandlerMockRecorder {
+	return m.recorder
+}
+
+// Token mocks base method.
+func (m *MockIOAuthTokenHandler) Token(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Token", c)
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockIOAuthTokenHandlerMockRecorder) Token(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockIOAuthTokenHandler)(nil).Token), c)
+}
@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ITokenRevocation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ITokenRevocation.go -destination=../mocks/mock_ITokenRevocation.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITokenRevocationService is a mock of ITokenRevocationService interface.
+type MockITokenRevocationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenRevocationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockITokenRevocationServiceMockRecorder is the mock recorder for MockITokenRevocationService.
+type MockITokenRevocationServiceMockRecorder struct {
+	mock *MockITokenRevocationService
+}
+
+// NewMockITokenRevocationService creates a new mock instance.
+func NewMockITokenRevocationService(ctrl *gomock.Controller) *MockITokenRevocationService {
+	mock := &MockITokenRevocationService{ctrl: ctrl}
+	mock.recorder = &MockITokenRevocationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenRevocationService) EXPECT() *MockITokenRevocationServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteRevokedToken mocks base method.
+func (m *MockITokenRevocationService) DeleteRevokedToken(ctx context.Context, actor, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRevokedToken", ctx, actor, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRevokedToken indicates an expected call of DeleteRevokedToken.
+func (mr *MockITokenRevocationServiceMockRecorder) DeleteRevokedToken(ctx, actor, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRevokedToken", reflect.TypeOf((*MockITokenRevocationService)(nil).DeleteRevokedToken), ctx, actor, key)
+}
+
+// GetRevokedTokens mocks base method.
+func (m *MockITokenRevocationService) GetRevokedTokens(ctx context.Context, req models.RevokedTokenListRequest) (pagination.Page[models.RevokedToken], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevokedTokens", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.RevokedToken])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevokedTokens indicates an expected call of GetRevokedTokens.
+func (mr *MockITokenRevocationServiceMockRecorder) GetRevokedTokens(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevokedTokens", reflect.TypeOf((*MockITokenRevocationService)(nil).GetRevokedTokens), ctx, req)
+}
+
+// RevokeTokenID mocks base method.
+func (m *MockITokenRevocationService) RevokeTokenID(ctx context.Context, actor, tokenID, reason string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokenID", ctx, actor, tokenID, reason)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeTokenID indicates an expected call of RevokeTokenID.
+func (mr *MockITokenRevocationServiceMockRecorder) RevokeTokenID(ctx, actor, tokenID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokenID", reflect.TypeOf((*MockITokenRevocationService)(nil).RevokeTokenID), ctx, actor, tokenID, reason)
+}
+
+// RevokeTokens mocks base method.
+func (m *MockITokenRevocationService) RevokeTokens(ctx context.Context, actor string, req models.RevokeTokensRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokens", ctx, actor, req)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeTokens indicates an expected call of RevokeTokens.
+func (mr *MockITokenRevocationServiceMockRecorder) RevokeTokens(ctx, actor, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokens", reflect.TypeOf((*MockITokenRevocationService)(nil).RevokeTokens), ctx, actor, req)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockITokenRevocationService) RevokeUserTokens(ctx context.Context, actor string, userID int, reason string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", ctx, actor, userID, reason)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockITokenRevocationServiceMockRecorder) RevokeUserTokens(ctx, actor, userID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockITokenRevocationService)(nil).RevokeUserTokens), ctx, actor, userID, reason)
+}
+
+// MockITokenRevocationHandler is a mock of ITokenRevocationHandler interface.
+type MockITokenRevocationHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenRevocationHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockITokenRevocationHandlerMockRecorder is the mock recorder for MockITokenRevocationHandler.
+type MockITokenRevocationHandlerMockRecorder struct {
+	mock *MockITokenRevocationHandler
+}
+
+// NewMockITokenRevocationHandler creates a new mock instance.
+func NewMockITokenRevocationHandler(ctrl *gomock.Controller) *MockITokenRevocationHandler {
+	mock := &MockITokenRevocationHandler{ctrl: ctrl}
+	mock.recorder = &MockITokenRevocationHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenRevocationHandler) EXPECT() *MockITokenRevocationHandlerMockRecorder {
+	return m.recorder
+}
+
+// DeleteRevokedToken mocks base method.
+func (m *MockITokenRevocationHandler) DeleteRevokedToken(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteRevokedToken", c)
+}
+
+// DeleteRevokedToken indicates an expected call of DeleteRevokedToken.
+func (mr *MockITokenRevocationHandlerMockRecorder) DeleteRevokedToken(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRevokedToken", reflect.TypeOf((*MockITokenRevocationHandler)(nil).DeleteRevokedToken), c)
+}
+
+// GetRevokedTokens mocks base method.
+func (m *MockITokenRevocationHandler) GetRevokedTokens(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetRevokedTokens", c)
+}
+
+// GetRevokedTokens indicates an expected call of GetRevokedTokens.
+func (mr *MockITokenRevocationHandlerMockRecorder) GetRevokedTokens(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevokedTokens", reflect.TypeOf((*MockITokenRevocationHandler)(nil).GetRevokedTokens), c)
+}
+
+// RevokeTokens mocks base method.
+func (m *MockITokenRevocationHandler) RevokeTokens(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeTokens", c)
+}
+
+// RevokeTokens indicates an expected call of RevokeTokens.
+func (mr *MockITokenRevocationHandlerMockRecorder) RevokeTokens(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokens", reflect.TypeOf((*MockITokenRevocationHandler)(nil).RevokeTokens), c)
+}
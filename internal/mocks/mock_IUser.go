@@ -0,0 +1,266 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IUser.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IUser.go -destination=../mocks/mock_IUser.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserReader is a mock of IUserReader interface.
+type MockIUserReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserReaderMockRecorder is the mock recorder for MockIUserReader.
+type MockIUserReaderMockRecorder struct {
+	mock *MockIUserReader
+}
+
+// NewMockIUserReader creates a new mock instance.
+func NewMockIUserReader(ctrl *gomock.Controller) *MockIUserReader {
+	mock := &MockIUserReader{ctrl: ctrl}
+	mock.recorder = &MockIUserReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserReader) EXPECT() *MockIUserReaderMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockIUserReader) GetUserByID(ctx context.Context, userID int) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockIUserReaderMockRecorder) GetUserByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockIUserReader)(nil).GetUserByID), ctx, userID)
+}
+
+// GetUserByIdentifier mocks base method.
+func (m *MockIUserReader) GetUserByIdentifier(ctx context.Context, username, email, phoneNumber string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIdentifier", ctx, username, email, phoneNumber)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIdentifier indicates an expected call of GetUserByIdentifier.
+func (mr *MockIUserReaderMockRecorder) GetUserByIdentifier(ctx, username, email, phoneNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIdentifier", reflect.TypeOf((*MockIUserReader)(nil).GetUserByIdentifier), ctx, username, email, phoneNumber)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockIUserReader) GetUserByUsername(ctx context.Context, username string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockIUserReaderMockRecorder) GetUserByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockIUserReader)(nil).GetUserByUsername), ctx, username)
+}
+
+// MockIUserWriter is a mock of IUserWriter interface.
+type MockIUserWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserWriterMockRecorder is the mock recorder for MockIUserWriter.
+type MockIUserWriterMockRecorder struct {
+	mock *MockIUserWriter
+}
+
+// NewMockIUserWriter creates a new mock instance.
+func NewMockIUserWriter(ctrl *gomock.Controller) *MockIUserWriter {
+	mock := &MockIUserWriter{ctrl: ctrl}
+	mock.recorder = &MockIUserWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserWriter) EXPECT() *MockIUserWriterMockRecorder {
+	return m.recorder
+}
+
+// InsertNewUser mocks base method.
+func (m *MockIUserWriter) InsertNewUser(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewUser indicates an expected call of InsertNewUser.
+func (mr *MockIUserWriterMockRecorder) InsertNewUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewUser", reflect.TypeOf((*MockIUserWriter)(nil).InsertNewUser), ctx, user)
+}
+
+// UpdateUser mocks base method.
+func (m *MockIUserWriter) UpdateUser(ctx context.Context, userID, version int, user models.User) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, userID, version, user)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockIUserWriterMockRecorder) UpdateUser(ctx, userID, version, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserWriter)(nil).UpdateUser), ctx, userID, version, user)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockIUserWriter) UpdateUserPassword(ctx context.Context, userID int, password string, changeRequired bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, userID, password, changeRequired)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockIUserWriterMockRecorder) UpdateUserPassword(ctx, userID, password, changeRequired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockIUserWriter)(nil).UpdateUserPassword), ctx, userID, password, changeRequired)
+}
+
+// MockIUserRepository is a mock of IUserRepository interface.
+type MockIUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserRepositoryMockRecorder is the mock recorder for MockIUserRepository.
+type MockIUserRepositoryMockRecorder struct {
+	mock *MockIUserRepository
+}
+
+// NewMockIUserRepository creates a new mock instance.
+func NewMockIUserRepository(ctrl *gomock.Controller) *MockIUserRepository {
+	mock := &MockIUserRepository{ctrl: ctrl}
+	mock.recorder = &MockIUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserRepository) EXPECT() *MockIUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockIUserRepository) GetUserByID(ctx context.Context, userID int) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockIUserRepositoryMockRecorder) GetUserByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockIUserRepository)(nil).GetUserByID), ctx, userID)
+}
+
+// GetUserByIdentifier mocks base method.
+func (m *MockIUserRepository) GetUserByIdentifier(ctx context.Context, username, email, phoneNumber string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIdentifier", ctx, username, email, phoneNumber)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIdentifier indicates an expected call of GetUserByIdentifier.
+func (mr *MockIUserRepositoryMockRecorder) GetUserByIdentifier(ctx, username, email, phoneNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIdentifier", reflect.TypeOf((*MockIUserRepository)(nil).GetUserByIdentifier), ctx, username, email, phoneNumber)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockIUserRepository) GetUserByUsername(ctx context.Context, username string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockIUserRepositoryMockRecorder) GetUserByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockIUserRepository)(nil).GetUserByUsername), ctx, username)
+}
+
+// InsertNewUser mocks base method.
+func (m *MockIUserRepository) InsertNewUser(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNewUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNewUser indicates an expected call of InsertNewUser.
+func (mr *MockIUserRepositoryMockRecorder) InsertNewUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNewUser", reflect.TypeOf((*MockIUserRepository)(nil).InsertNewUser), ctx, user)
+}
+
+// UpdateUser mocks base method.
+func (m *MockIUserRepository) UpdateUser(ctx context.Context, userID, version int, user models.User) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, userID, version, user)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockIUserRepositoryMockRecorder) UpdateUser(ctx, userID, version, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserRepository)(nil).UpdateUser), ctx, userID, version, user)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockIUserRepository) UpdateUserPassword(ctx context.Context, userID int, password string, changeRequired bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, userID, password, changeRequired)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockIUserRepositoryMockRecorder) UpdateUserPassword(ctx, userID, password, changeRequired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockIUserRepository)(nil).UpdateUserPassword), ctx, userID, password, changeRequired)
+}
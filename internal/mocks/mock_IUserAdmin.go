@@ -0,0 +1,253 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IUserAdmin.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IUserAdmin.go -destination=../mocks/mock_IUserAdmin.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	useradmin "ewallet-ums/cmd/proto/useradmin"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserAdminService is a mock of IUserAdminService interface.
+type MockIUserAdminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserAdminServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserAdminServiceMockRecorder is the mock recorder for MockIUserAdminService.
+type MockIUserAdminServiceMockRecorder struct {
+	mock *MockIUserAdminService
+}
+
+// NewMockIUserAdminService creates a new mock instance.
+func NewMockIUserAdminService(ctrl *gomock.Controller) *MockIUserAdminService {
+	mock := &MockIUserAdminService{ctrl: ctrl}
+	mock.recorder = &MockIUserAdminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserAdminService) EXPECT() *MockIUserAdminServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateAdmin mocks base method.
+func (m *MockIUserAdminService) CreateAdmin(ctx context.Context, actor string, req models.CreateAdminRequest) (models.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdmin", ctx, actor, req)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAdmin indicates an expected call of CreateAdmin.
+func (mr *MockIUserAdminServiceMockRecorder) CreateAdmin(ctx, actor, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdmin", reflect.TypeOf((*MockIUserAdminService)(nil).CreateAdmin), ctx, actor, req)
+}
+
+// ForceLogout mocks base method.
+func (m *MockIUserAdminService) ForceLogout(ctx context.Context, actor string, userID int, reason string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceLogout", ctx, actor, userID, reason)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockIUserAdminServiceMockRecorder) ForceLogout(ctx, actor, userID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockIUserAdminService)(nil).ForceLogout), ctx, actor, userID, reason)
+}
+
+// GetUsers mocks base method.
+func (m *MockIUserAdminService) GetUsers(ctx context.Context, req models.AdminUserListRequest) (pagination.Page[models.AdminUserSummary], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, req)
+	ret0, _ := ret[0].(pagination.Page[models.AdminUserSummary])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIUserAdminServiceMockRecorder) GetUsers(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIUserAdminService)(nil).GetUsers), ctx, req)
+}
+
+// RequestRole mocks base method.
+func (m *MockIUserAdminService) RequestRole(ctx context.Context, actor string, userID int, role, reason string) (models.AdminApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestRole", ctx, actor, userID, role, reason)
+	ret0, _ := ret[0].(models.AdminApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestRole indicates an expected call of RequestRole.
+func (mr *MockIUserAdminServiceMockRecorder) RequestRole(ctx, actor, userID, role, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestRole", reflect.TypeOf((*MockIUserAdminService)(nil).RequestRole), ctx, actor, userID, role, reason)
+}
+
+// RequestSuspension mocks base method.
+func (m *MockIUserAdminService) RequestSuspension(ctx context.Context, actor string, userID, version int, reason string) (models.AdminApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestSuspension", ctx, actor, userID, version, reason)
+	ret0, _ := ret[0].(models.AdminApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestSuspension indicates an expected call of RequestSuspension.
+func (mr *MockIUserAdminServiceMockRecorder) RequestSuspension(ctx, actor, userID, version, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestSuspension", reflect.TypeOf((*MockIUserAdminService)(nil).RequestSuspension), ctx, actor, userID, version, reason)
+}
+
+// SuspendUser mocks base method.
+func (m *MockIUserAdminService) SuspendUser(ctx context.Context, actor string, userID, version int, reason string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, actor, userID, version, reason)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockIUserAdminServiceMockRecorder) SuspendUser(ctx, actor, userID, version, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockIUserAdminService)(nil).SuspendUser), ctx, actor, userID, version, reason)
+}
+
+// MockIUserAdminHandler is a mock of IUserAdminHandler interface.
+type MockIUserAdminHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserAdminHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserAdminHandlerMockRecorder is the mock recorder for MockIUserAdminHandler.
+type MockIUserAdminHandlerMockRecorder struct {
+	mock *MockIUserAdminHandler
+}
+
+// NewMockIUserAdminHandler creates a new mock instance.
+func NewMockIUserAdminHandler(ctrl *gomock.Controller) *MockIUserAdminHandler {
+	mock := &MockIUserAdminHandler{ctrl: ctrl}
+	mock.recorder = &MockIUserAdminHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserAdminHandler) EXPECT() *MockIUserAdminHandlerMockRecorder {
+	return m.recorder
+}
+
+// AssignRole mocks base method.
+func (m *MockIUserAdminHandler) AssignRole(ctx context.Context, req *useradmin.AssignRoleRequest) (*useradmin.UserAdminResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", ctx, req)
+	ret0, _ := ret[0].(*useradmin.UserAdminResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockIUserAdminHandlerMockRecorder) AssignRole(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockIUserAdminHandler)(nil).AssignRole), ctx, req)
+}
+
+// ForceLogout mocks base method.
+func (m *MockIUserAdminHandler) ForceLogout(ctx context.Context, req *useradmin.ForceLogoutRequest) (*useradmin.UserAdminResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceLogout", ctx, req)
+	ret0, _ := ret[0].(*useradmin.UserAdminResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockIUserAdminHandlerMockRecorder) ForceLogout(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockIUserAdminHandler)(nil).ForceLogout), ctx, req)
+}
+
+// SuspendUser mocks base method.
+func (m *MockIUserAdminHandler) SuspendUser(ctx context.Context, req *useradmin.SuspendUserRequest) (*useradmin.UserAdminResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, req)
+	ret0, _ := ret[0].(*useradmin.UserAdminResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockIUserAdminHandlerMockRecorder) SuspendUser(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockIUserAdminHandler)(nil).SuspendUser), ctx, req)
+}
+
+// MockIAdminUserHandler is a mock of IAdminUserHandler interface.
+type MockIAdminUserHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAdminUserHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIAdminUserHandlerMockRecorder is the mock recorder for MockIAdminUserHandler.
+type MockIAdminUserHandlerMockRecorder struct {
+	mock *MockIAdminUserHandler
+}
+
+// NewMockIAdminUserHandler creates a new mock instance.
+func NewMockIAdminUserHandler(ctrl *gomock.Controller) *MockIAdminUserHandler {
+	mock := &MockIAdminUserHandler{ctrl: ctrl}
+	mock.recorder = &MockIAdminUserHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAdminUserHandler) EXPECT() *MockIAdminUserHandlerMockRecorder {
+	return m.recorder
+}
+
+// ForceLogout mocks base method.
+func (m *MockIAdminUserHandler) ForceLogout(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForceLogout", c)
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockIAdminUserHandlerMockRecorder) ForceLogout(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockIAdminUserHandler)(nil).ForceLogout), c)
+}
+
+// GetUsers mocks base method.
+func (m *MockIAdminUserHandler) GetUsers(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUsers", c)
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIAdminUserHandlerMockRecorder) GetUsers(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIAdminUserHandler)(nil).GetUsers), c)
+}
@@ -0,0 +1,261 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IUserExport.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IUserExport.go -destination=../mocks/mock_IUserExport.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserExportRepository is a mock of IUserExportRepository interface.
+type MockIUserExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserExportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserExportRepositoryMockRecorder is the mock recorder for MockIUserExportRepository.
+type MockIUserExportRepositoryMockRecorder struct {
+	mock *MockIUserExportRepository
+}
+
+// NewMockIUserExportRepository creates a new mock instance.
+func NewMockIUserExportRepository(ctrl *gomock.Controller) *MockIUserExportRepository {
+	mock := &MockIUserExportRepository{ctrl: ctrl}
+	mock.recorder = &MockIUserExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserExportRepository) EXPECT() *MockIUserExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimUserExport mocks base method.
+func (m *MockIUserExportRepository) ClaimUserExport(ctx context.Context, export *models.UserExport, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUserExport", ctx, export, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUserExport indicates an expected call of ClaimUserExport.
+func (mr *MockIUserExportRepositoryMockRecorder) ClaimUserExport(ctx, export, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUserExport", reflect.TypeOf((*MockIUserExportRepository)(nil).ClaimUserExport), ctx, export, now)
+}
+
+// GetPendingUserExports mocks base method.
+func (m *MockIUserExportRepository) GetPendingUserExports(ctx context.Context, staleBefore time.Time, limit int) ([]models.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingUserExports", ctx, staleBefore, limit)
+	ret0, _ := ret[0].([]models.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingUserExports indicates an expected call of GetPendingUserExports.
+func (mr *MockIUserExportRepositoryMockRecorder) GetPendingUserExports(ctx, staleBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingUserExports", reflect.TypeOf((*MockIUserExportRepository)(nil).GetPendingUserExports), ctx, staleBefore, limit)
+}
+
+// GetUserExportByID mocks base method.
+func (m *MockIUserExportRepository) GetUserExportByID(ctx context.Context, exportID int) (models.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserExportByID", ctx, exportID)
+	ret0, _ := ret[0].(models.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserExportByID indicates an expected call of GetUserExportByID.
+func (mr *MockIUserExportRepositoryMockRecorder) GetUserExportByID(ctx, exportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserExportByID", reflect.TypeOf((*MockIUserExportRepository)(nil).GetUserExportByID), ctx, exportID)
+}
+
+// GetUsersForExport mocks base method.
+func (m *MockIUserExportRepository) GetUsersForExport(ctx context.Context, export models.UserExport, afterID, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersForExport", ctx, export, afterID, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersForExport indicates an expected call of GetUsersForExport.
+func (mr *MockIUserExportRepositoryMockRecorder) GetUsersForExport(ctx, export, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersForExport", reflect.TypeOf((*MockIUserExportRepository)(nil).GetUsersForExport), ctx, export, afterID, limit)
+}
+
+// InsertUserExport mocks base method.
+func (m *MockIUserExportRepository) InsertUserExport(ctx context.Context, export *models.UserExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserExport indicates an expected call of InsertUserExport.
+func (mr *MockIUserExportRepositoryMockRecorder) InsertUserExport(ctx, export any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserExport", reflect.TypeOf((*MockIUserExportRepository)(nil).InsertUserExport), ctx, export)
+}
+
+// UpdateUserExport mocks base method.
+func (m *MockIUserExportRepository) UpdateUserExport(ctx context.Context, export *models.UserExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserExport indicates an expected call of UpdateUserExport.
+func (mr *MockIUserExportRepositoryMockRecorder) UpdateUserExport(ctx, export any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserExport", reflect.TypeOf((*MockIUserExportRepository)(nil).UpdateUserExport), ctx, export)
+}
+
+// MockIUserExportService is a mock of IUserExportService interface.
+type MockIUserExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserExportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserExportServiceMockRecorder is the mock recorder for MockIUserExportService.
+type MockIUserExportServiceMockRecorder struct {
+	mock *MockIUserExportService
+}
+
+// NewMockIUserExportService creates a new mock instance.
+func NewMockIUserExportService(ctrl *gomock.Controller) *MockIUserExportService {
+	mock := &MockIUserExportService{ctrl: ctrl}
+	mock.recorder = &MockIUserExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserExportService) EXPECT() *MockIUserExportServiceMockRecorder {
+	return m.recorder
+}
+
+// GetExport mocks base method.
+func (m *MockIUserExportService) GetExport(ctx context.Context, exportID, requestedBy int) (models.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, exportID, requestedBy)
+	ret0, _ := ret[0].(models.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockIUserExportServiceMockRecorder) GetExport(ctx, exportID, requestedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockIUserExportService)(nil).GetExport), ctx, exportID, requestedBy)
+}
+
+// ProcessPendingExports mocks base method.
+func (m *MockIUserExportService) ProcessPendingExports(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPendingExports", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessPendingExports indicates an expected call of ProcessPendingExports.
+func (mr *MockIUserExportServiceMockRecorder) ProcessPendingExports(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPendingExports", reflect.TypeOf((*MockIUserExportService)(nil).ProcessPendingExports), ctx)
+}
+
+// RequestExport mocks base method.
+func (m *MockIUserExportService) RequestExport(ctx context.Context, requestedBy int, req models.UserExportRequest) (models.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestExport", ctx, requestedBy, req)
+	ret0, _ := ret[0].(models.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestExport indicates an expected call of RequestExport.
+func (mr *MockIUserExportServiceMockRecorder) RequestExport(ctx, requestedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExport", reflect.TypeOf((*MockIUserExportService)(nil).RequestExport), ctx, requestedBy, req)
+}
+
+// Run mocks base method.
+func (m *MockIUserExportService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIUserExportServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIUserExportService)(nil).Run), ctx)
+}
+
+// MockIUserExportHandler is a mock of IUserExportHandler interface.
+type MockIUserExportHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserExportHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserExportHandlerMockRecorder is the mock recorder for MockIUserExportHandler.
+type MockIUserExportHandlerMockRecorder struct {
+	mock *MockIUserExportHandler
+}
+
+// NewMockIUserExportHandler creates a new mock instance.
+func NewMockIUserExportHandler(ctrl *gomock.Controller) *MockIUserExportHandler {
+	mock := &MockIUserExportHandler{ctrl: ctrl}
+	mock.recorder = &MockIUserExportHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserExportHandler) EXPECT() *MockIUserExportHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetExport mocks base method.
+func (m *MockIUserExportHandler) GetExport(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetExport", c)
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockIUserExportHandlerMockRecorder) GetExport(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockIUserExportHandler)(nil).GetExport), c)
+}
+
+// RequestExport mocks base method.
+func (m *MockIUserExportHandler) RequestExport(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestExport", c)
+}
+
+// RequestExport indicates an expected call of RequestExport.
+func (mr *MockIUserExportHandlerMockRecorder) RequestExport(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExport", reflect.TypeOf((*MockIUserExportHandler)(nil).RequestExport), c)
+}
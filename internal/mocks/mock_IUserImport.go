@@ -0,0 +1,262 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IUserImport.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IUserImport.go -destination=../mocks/mock_IUserImport.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	io "io"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserImportRepository is a mock of IUserImportRepository interface.
+type MockIUserImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserImportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserImportRepositoryMockRecorder is the mock recorder for MockIUserImportRepository.
+type MockIUserImportRepositoryMockRecorder struct {
+	mock *MockIUserImportRepository
+}
+
+// NewMockIUserImportRepository creates a new mock instance.
+func NewMockIUserImportRepository(ctrl *gomock.Controller) *MockIUserImportRepository {
+	mock := &MockIUserImportRepository{ctrl: ctrl}
+	mock.recorder = &MockIUserImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserImportRepository) EXPECT() *MockIUserImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDByExternalID mocks base method.
+func (m *MockIUserImportRepository) GetUserIDByExternalID(ctx context.Context, externalID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByExternalID", ctx, externalID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByExternalID indicates an expected call of GetUserIDByExternalID.
+func (mr *MockIUserImportRepositoryMockRecorder) GetUserIDByExternalID(ctx, externalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByExternalID", reflect.TypeOf((*MockIUserImportRepository)(nil).GetUserIDByExternalID), ctx, externalID)
+}
+
+// GetUserImportByID mocks base method.
+func (m *MockIUserImportRepository) GetUserImportByID(ctx context.Context, importID int) (models.UserImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserImportByID", ctx, importID)
+	ret0, _ := ret[0].(models.UserImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserImportByID indicates an expected call of GetUserImportByID.
+func (mr *MockIUserImportRepositoryMockRecorder) GetUserImportByID(ctx, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserImportByID", reflect.TypeOf((*MockIUserImportRepository)(nil).GetUserImportByID), ctx, importID)
+}
+
+// InsertImportedUser mocks base method.
+func (m *MockIUserImportRepository) InsertImportedUser(ctx context.Context, user *models.User, provisioning *models.WalletProvisioning) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertImportedUser", ctx, user, provisioning)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertImportedUser indicates an expected call of InsertImportedUser.
+func (mr *MockIUserImportRepositoryMockRecorder) InsertImportedUser(ctx, user, provisioning any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertImportedUser", reflect.TypeOf((*MockIUserImportRepository)(nil).InsertImportedUser), ctx, user, provisioning)
+}
+
+// InsertUserImport mocks base method.
+func (m *MockIUserImportRepository) InsertUserImport(ctx context.Context, job *models.UserImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserImport", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserImport indicates an expected call of InsertUserImport.
+func (mr *MockIUserImportRepositoryMockRecorder) InsertUserImport(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserImport", reflect.TypeOf((*MockIUserImportRepository)(nil).InsertUserImport), ctx, job)
+}
+
+// UpdateUserImport mocks base method.
+func (m *MockIUserImportRepository) UpdateUserImport(ctx context.Context, job *models.UserImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserImport", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserImport indicates an expected call of UpdateUserImport.
+func (mr *MockIUserImportRepositoryMockRecorder) UpdateUserImport(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserImport", reflect.TypeOf((*MockIUserImportRepository)(nil).UpdateUserImport), ctx, job)
+}
+
+// UsernameExists mocks base method.
+func (m *MockIUserImportRepository) UsernameExists(ctx context.Context, username string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsernameExists", ctx, username)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsernameExists indicates an expected call of UsernameExists.
+func (mr *MockIUserImportRepositoryMockRecorder) UsernameExists(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsernameExists", reflect.TypeOf((*MockIUserImportRepository)(nil).UsernameExists), ctx, username)
+}
+
+// MockIUserImportService is a mock of IUserImportService interface.
+type MockIUserImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserImportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserImportServiceMockRecorder is the mock recorder for MockIUserImportService.
+type MockIUserImportServiceMockRecorder struct {
+	mock *MockIUserImportService
+}
+
+// NewMockIUserImportService creates a new mock instance.
+func NewMockIUserImportService(ctrl *gomock.Controller) *MockIUserImportService {
+	mock := &MockIUserImportService{ctrl: ctrl}
+	mock.recorder = &MockIUserImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserImportService) EXPECT() *MockIUserImportServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateImport mocks base method.
+func (m *MockIUserImportService) CreateImport(ctx context.Context, actor, format string, dryRun bool) (models.UserImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImport", ctx, actor, format, dryRun)
+	ret0, _ := ret[0].(models.UserImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImport indicates an expected call of CreateImport.
+func (mr *MockIUserImportServiceMockRecorder) CreateImport(ctx, actor, format, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImport", reflect.TypeOf((*MockIUserImportService)(nil).CreateImport), ctx, actor, format, dryRun)
+}
+
+// GetImport mocks base method.
+func (m *MockIUserImportService) GetImport(ctx context.Context, importID int) (models.UserImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", ctx, importID)
+	ret0, _ := ret[0].(models.UserImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockIUserImportServiceMockRecorder) GetImport(ctx, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockIUserImportService)(nil).GetImport), ctx, importID)
+}
+
+// RunImport mocks base method.
+func (m *MockIUserImportService) RunImport(ctx context.Context, job *models.UserImport, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunImport", ctx, job, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunImport indicates an expected call of RunImport.
+func (mr *MockIUserImportServiceMockRecorder) RunImport(ctx, job, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunImport", reflect.TypeOf((*MockIUserImportService)(nil).RunImport), ctx, job, r)
+}
+
+// StartImport mocks base method.
+func (m *MockIUserImportService) StartImport(ctx context.Context, actor, format string, dryRun bool, data []byte) (models.UserImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", ctx, actor, format, dryRun, data)
+	ret0, _ := ret[0].(models.UserImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockIUserImportServiceMockRecorder) StartImport(ctx, actor, format, dryRun, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockIUserImportService)(nil).StartImport), ctx, actor, format, dryRun, data)
+}
+
+// MockIUserImportHandler is a mock of IUserImportHandler interface.
+type MockIUserImportHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserImportHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserImportHandlerMockRecorder is the mock recorder for MockIUserImportHandler.
+type MockIUserImportHandlerMockRecorder struct {
+	mock *MockIUserImportHandler
+}
+
+// NewMockIUserImportHandler creates a new mock instance.
+func NewMockIUserImportHandler(ctrl *gomock.Controller) *MockIUserImportHandler {
+	mock := &MockIUserImportHandler{ctrl: ctrl}
+	mock.recorder = &MockIUserImportHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserImportHandler) EXPECT() *MockIUserImportHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetImport mocks base method.
+func (m *MockIUserImportHandler) GetImport(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetImport", c)
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockIUserImportHandlerMockRecorder) GetImport(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockIUserImportHandler)(nil).GetImport), c)
+}
+
+// ImportUsers mocks base method.
+func (m *MockIUserImportHandler) ImportUsers(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportUsers", c)
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockIUserImportHandlerMockRecorder) ImportUsers(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockIUserImportHandler)(nil).ImportUsers), c)
+}
@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IUserPurge.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IUserPurge.go -destination=../mocks/mock_IUserPurge.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserPurgeRepository is a mock of IUserPurgeRepository interface.
+type MockIUserPurgeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserPurgeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserPurgeRepositoryMockRecorder is the mock recorder for MockIUserPurgeRepository.
+type MockIUserPurgeRepositoryMockRecorder struct {
+	mock *MockIUserPurgeRepository
+}
+
+// NewMockIUserPurgeRepository creates a new mock instance.
+func NewMockIUserPurgeRepository(ctrl *gomock.Controller) *MockIUserPurgeRepository {
+	mock := &MockIUserPurgeRepository{ctrl: ctrl}
+	mock.recorder = &MockIUserPurgeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserPurgeRepository) EXPECT() *MockIUserPurgeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPendingUserPurges mocks base method.
+func (m *MockIUserPurgeRepository) GetPendingUserPurges(ctx context.Context, afterID, limit int) ([]models.UserPurge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingUserPurges", ctx, afterID, limit)
+	ret0, _ := ret[0].([]models.UserPurge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingUserPurges indicates an expected call of GetPendingUserPurges.
+func (mr *MockIUserPurgeRepositoryMockRecorder) GetPendingUserPurges(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingUserPurges", reflect.TypeOf((*MockIUserPurgeRepository)(nil).GetPendingUserPurges), ctx, afterID, limit)
+}
+
+// InsertUserPurge mocks base method.
+func (m *MockIUserPurgeRepository) InsertUserPurge(ctx context.Context, purge *models.UserPurge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserPurge", ctx, purge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserPurge indicates an expected call of InsertUserPurge.
+func (mr *MockIUserPurgeRepositoryMockRecorder) InsertUserPurge(ctx, purge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserPurge", reflect.TypeOf((*MockIUserPurgeRepository)(nil).InsertUserPurge), ctx, purge)
+}
+
+// PurgeUser mocks base method.
+func (m *MockIUserPurgeRepository) PurgeUser(ctx context.Context, purge models.UserPurge, now time.Time) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", ctx, purge, now)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockIUserPurgeRepositoryMockRecorder) PurgeUser(ctx, purge, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockIUserPurgeRepository)(nil).PurgeUser), ctx, purge, now)
+}
+
+// RecordUserPurgeFailure mocks base method.
+func (m *MockIUserPurgeRepository) RecordUserPurgeFailure(ctx context.Context, purgeID int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUserPurgeFailure", ctx, purgeID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordUserPurgeFailure indicates an expected call of RecordUserPurgeFailure.
+func (mr *MockIUserPurgeRepositoryMockRecorder) RecordUserPurgeFailure(ctx, purgeID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUserPurgeFailure", reflect.TypeOf((*MockIUserPurgeRepository)(nil).RecordUserPurgeFailure), ctx, purgeID, reason)
+}
+
+// MockIUserPurgeService is a mock of IUserPurgeService interface.
+type MockIUserPurgeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserPurgeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserPurgeServiceMockRecorder is the mock recorder for MockIUserPurgeService.
+type MockIUserPurgeServiceMockRecorder struct {
+	mock *MockIUserPurgeService
+}
+
+// NewMockIUserPurgeService creates a new mock instance.
+func NewMockIUserPurgeService(ctrl *gomock.Controller) *MockIUserPurgeService {
+	mock := &MockIUserPurgeService{ctrl: ctrl}
+	mock.recorder = &MockIUserPurgeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserPurgeService) EXPECT() *MockIUserPurgeServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckPurge mocks base method.
+func (m *MockIUserPurgeService) CheckPurge(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPurge", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPurge indicates an expected call of CheckPurge.
+func (mr *MockIUserPurgeServiceMockRecorder) CheckPurge(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPurge", reflect.TypeOf((*MockIUserPurgeService)(nil).CheckPurge), ctx, userID)
+}
+
+// PurgeUsers mocks base method.
+func (m *MockIUserPurgeService) PurgeUsers(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUsers", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeUsers indicates an expected call of PurgeUsers.
+func (mr *MockIUserPurgeServiceMockRecorder) PurgeUsers(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUsers", reflect.TypeOf((*MockIUserPurgeService)(nil).PurgeUsers), ctx, now)
+}
+
+// Run mocks base method.
+func (m *MockIUserPurgeService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIUserPurgeServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIUserPurgeService)(nil).Run), ctx)
+}
+
+// SchedulePurge mocks base method.
+func (m *MockIUserPurgeService) SchedulePurge(ctx context.Context, approval models.AdminApproval) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePurge", ctx, approval)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SchedulePurge indicates an expected call of SchedulePurge.
+func (mr *MockIUserPurgeServiceMockRecorder) SchedulePurge(ctx, approval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePurge", reflect.TypeOf((*MockIUserPurgeService)(nil).SchedulePurge), ctx, approval)
+}
@@ -0,0 +1,164 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IUserQuota.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IUserQuota.go -destination=../mocks/mock_IUserQuota.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserQuotaRepository is a mock of IUserQuotaRepository interface.
+type MockIUserQuotaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserQuotaRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserQuotaRepositoryMockRecorder is the mock recorder for MockIUserQuotaRepository.
+type MockIUserQuotaRepositoryMockRecorder struct {
+	mock *MockIUserQuotaRepository
+}
+
+// NewMockIUserQuotaRepository creates a new mock instance.
+func NewMockIUserQuotaRepository(ctrl *gomock.Controller) *MockIUserQuotaRepository {
+	mock := &MockIUserQuotaRepository{ctrl: ctrl}
+	mock.recorder = &MockIUserQuotaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserQuotaRepository) EXPECT() *MockIUserQuotaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserQuotaCounts mocks base method.
+func (m *MockIUserQuotaRepository) GetUserQuotaCounts(ctx context.Context, names []string, userID int, day time.Time) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserQuotaCounts", ctx, names, userID, day)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserQuotaCounts indicates an expected call of GetUserQuotaCounts.
+func (mr *MockIUserQuotaRepositoryMockRecorder) GetUserQuotaCounts(ctx, names, userID, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserQuotaCounts", reflect.TypeOf((*MockIUserQuotaRepository)(nil).GetUserQuotaCounts), ctx, names, userID, day)
+}
+
+// IncrUserQuota mocks base method.
+func (m *MockIUserQuotaRepository) IncrUserQuota(ctx context.Context, name string, userID int, day, resetAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrUserQuota", ctx, name, userID, day, resetAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrUserQuota indicates an expected call of IncrUserQuota.
+func (mr *MockIUserQuotaRepositoryMockRecorder) IncrUserQuota(ctx, name, userID, day, resetAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrUserQuota", reflect.TypeOf((*MockIUserQuotaRepository)(nil).IncrUserQuota), ctx, name, userID, day, resetAt)
+}
+
+// MockIUserQuotaService is a mock of IUserQuotaService interface.
+type MockIUserQuotaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserQuotaServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserQuotaServiceMockRecorder is the mock recorder for MockIUserQuotaService.
+type MockIUserQuotaServiceMockRecorder struct {
+	mock *MockIUserQuotaService
+}
+
+// NewMockIUserQuotaService creates a new mock instance.
+func NewMockIUserQuotaService(ctrl *gomock.Controller) *MockIUserQuotaService {
+	mock := &MockIUserQuotaService{ctrl: ctrl}
+	mock.recorder = &MockIUserQuotaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserQuotaService) EXPECT() *MockIUserQuotaServiceMockRecorder {
+	return m.recorder
+}
+
+// ConsumeQuota mocks base method.
+func (m *MockIUserQuotaService) ConsumeQuota(ctx context.Context, userID int, name string, now time.Time) (models.QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeQuota", ctx, userID, name, now)
+	ret0, _ := ret[0].(models.QuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeQuota indicates an expected call of ConsumeQuota.
+func (mr *MockIUserQuotaServiceMockRecorder) ConsumeQuota(ctx, userID, name, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeQuota", reflect.TypeOf((*MockIUserQuotaService)(nil).ConsumeQuota), ctx, userID, name, now)
+}
+
+// GetQuotaUsage mocks base method.
+func (m *MockIUserQuotaService) GetQuotaUsage(ctx context.Context, userID int, now time.Time) ([]models.QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaUsage", ctx, userID, now)
+	ret0, _ := ret[0].([]models.QuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaUsage indicates an expected call of GetQuotaUsage.
+func (mr *MockIUserQuotaServiceMockRecorder) GetQuotaUsage(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaUsage", reflect.TypeOf((*MockIUserQuotaService)(nil).GetQuotaUsage), ctx, userID, now)
+}
+
+// MockIUserQuotaHandler is a mock of IUserQuotaHandler interface.
+type MockIUserQuotaHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserQuotaHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIUserQuotaHandlerMockRecorder is the mock recorder for MockIUserQuotaHandler.
+type MockIUserQuotaHandlerMockRecorder struct {
+	mock *MockIUserQuotaHandler
+}
+
+// NewMockIUserQuotaHandler creates a new mock instance.
+func NewMockIUserQuotaHandler(ctrl *gomock.Controller) *MockIUserQuotaHandler {
+	mock := &MockIUserQuotaHandler{ctrl: ctrl}
+	mock.recorder = &MockIUserQuotaHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserQuotaHandler) EXPECT() *MockIUserQuotaHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetQuotaUsage mocks base method.
+func (m *MockIUserQuotaHandler) GetQuotaUsage(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetQuotaUsage", c)
+}
+
+// GetQuotaUsage indicates an expected call of GetQuotaUsage.
+func (mr *MockIUserQuotaHandlerMockRecorder) GetQuotaUsage(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaUsage", reflect.TypeOf((*MockIUserQuotaHandler)(nil).GetQuotaUsage), c)
+}
@@ -0,0 +1,407 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: IWalletProvisioning.go
+//
+// Generated by this command:
+//
+//	mockgen -source=IWalletProvisioning.go -destination=../mocks/mock_IWalletProvisioning.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "ewallet-ums/internal/models"
+	pagination "ewallet-ums/internal/pagination"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWalletProvisioningRepository is a mock of IWalletProvisioningRepository interface.
+type MockIWalletProvisioningRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWalletProvisioningRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIWalletProvisioningRepositoryMockRecorder is the mock recorder for MockIWalletProvisioningRepository.
+type MockIWalletProvisioningRepositoryMockRecorder struct {
+	mock *MockIWalletProvisioningRepository
+}
+
+// NewMockIWalletProvisioningRepository creates a new mock instance.
+func NewMockIWalletProvisioningRepository(ctrl *gomock.Controller) *MockIWalletProvisioningRepository {
+	mock := &MockIWalletProvisioningRepository{ctrl: ctrl}
+	mock.recorder = &MockIWalletProvisioningRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWalletProvisioningRepository) EXPECT() *MockIWalletProvisioningRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimWalletProvisioning mocks base method.
+func (m *MockIWalletProvisioningRepository) ClaimWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning, lockUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWalletProvisioning", ctx, provisioning, lockUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWalletProvisioning indicates an expected call of ClaimWalletProvisioning.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) ClaimWalletProvisioning(ctx, provisioning, lockUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWalletProvisioning", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).ClaimWalletProvisioning), ctx, provisioning, lockUntil)
+}
+
+// CountStuckWalletProvisionings mocks base method.
+func (m *MockIWalletProvisioningRepository) CountStuckWalletProvisionings(ctx context.Context, createdBefore time.Time) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStuckWalletProvisionings", ctx, createdBefore)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStuckWalletProvisionings indicates an expected call of CountStuckWalletProvisionings.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) CountStuckWalletProvisionings(ctx, createdBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStuckWalletProvisionings", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).CountStuckWalletProvisionings), ctx, createdBefore)
+}
+
+// GetPendingWalletProvisionings mocks base method.
+func (m *MockIWalletProvisioningRepository) GetPendingWalletProvisionings(ctx context.Context, now time.Time, limit int) ([]models.WalletProvisioning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingWalletProvisionings", ctx, now, limit)
+	ret0, _ := ret[0].([]models.WalletProvisioning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingWalletProvisionings indicates an expected call of GetPendingWalletProvisionings.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) GetPendingWalletProvisionings(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingWalletProvisionings", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).GetPendingWalletProvisionings), ctx, now, limit)
+}
+
+// GetStuckWalletProvisionings mocks base method.
+func (m *MockIWalletProvisioningRepository) GetStuckWalletProvisionings(ctx context.Context, createdBefore time.Time, cursor *pagination.Cursor, limit int) ([]models.WalletProvisioning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStuckWalletProvisionings", ctx, createdBefore, cursor, limit)
+	ret0, _ := ret[0].([]models.WalletProvisioning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStuckWalletProvisionings indicates an expected call of GetStuckWalletProvisionings.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) GetStuckWalletProvisionings(ctx, createdBefore, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStuckWalletProvisionings", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).GetStuckWalletProvisionings), ctx, createdBefore, cursor, limit)
+}
+
+// GetWalletProvisioningByUserID mocks base method.
+func (m *MockIWalletProvisioningRepository) GetWalletProvisioningByUserID(ctx context.Context, userID int) (models.WalletProvisioning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletProvisioningByUserID", ctx, userID)
+	ret0, _ := ret[0].(models.WalletProvisioning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletProvisioningByUserID indicates an expected call of GetWalletProvisioningByUserID.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) GetWalletProvisioningByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletProvisioningByUserID", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).GetWalletProvisioningByUserID), ctx, userID)
+}
+
+// InsertUserWithWalletProvisioning mocks base method.
+func (m *MockIWalletProvisioningRepository) InsertUserWithWalletProvisioning(ctx context.Context, user *models.User, provisioning *models.WalletProvisioning) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserWithWalletProvisioning", ctx, user, provisioning)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserWithWalletProvisioning indicates an expected call of InsertUserWithWalletProvisioning.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) InsertUserWithWalletProvisioning(ctx, user, provisioning any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserWithWalletProvisioning", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).InsertUserWithWalletProvisioning), ctx, user, provisioning)
+}
+
+// InsertWalletProvisioning mocks base method.
+func (m *MockIWalletProvisioningRepository) InsertWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWalletProvisioning", ctx, provisioning)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWalletProvisioning indicates an expected call of InsertWalletProvisioning.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) InsertWalletProvisioning(ctx, provisioning any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWalletProvisioning", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).InsertWalletProvisioning), ctx, provisioning)
+}
+
+// RetryWalletProvisioning mocks base method.
+func (m *MockIWalletProvisioningRepository) RetryWalletProvisioning(ctx context.Context, userID int, createdBefore, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryWalletProvisioning", ctx, userID, createdBefore, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryWalletProvisioning indicates an expected call of RetryWalletProvisioning.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) RetryWalletProvisioning(ctx, userID, createdBefore, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryWalletProvisioning", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).RetryWalletProvisioning), ctx, userID, createdBefore, now)
+}
+
+// UpdateWalletProvisioning mocks base method.
+func (m *MockIWalletProvisioningRepository) UpdateWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWalletProvisioning", ctx, provisioning)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWalletProvisioning indicates an expected call of UpdateWalletProvisioning.
+func (mr *MockIWalletProvisioningRepositoryMockRecorder) UpdateWalletProvisioning(ctx, provisioning any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWalletProvisioning", reflect.TypeOf((*MockIWalletProvisioningRepository)(nil).UpdateWalletProvisioning), ctx, provisioning)
+}
+
+// MockIWalletProvisioningService is a mock of IWalletProvisioningService interface.
+type MockIWalletProvisioningService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWalletProvisioningServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIWalletProvisioningServiceMockRecorder is the mock recorder for MockIWalletProvisioningService.
+type MockIWalletProvisioningServiceMockRecorder struct {
+	mock *MockIWalletProvisioningService
+}
+
+// NewMockIWalletProvisioningService creates a new mock instance.
+func NewMockIWalletProvisioningService(ctrl *gomock.Controller) *MockIWalletProvisioningService {
+	mock := &MockIWalletProvisioningService{ctrl: ctrl}
+	mock.recorder = &MockIWalletProvisioningServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWalletProvisioningService) EXPECT() *MockIWalletProvisioningServiceMockRecorder {
+	return m.recorder
+}
+
+// GetWalletStatus mocks base method.
+func (m *MockIWalletProvisioningService) GetWalletStatus(ctx context.Context, userID int) (models.WalletProvisioning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletStatus", ctx, userID)
+	ret0, _ := ret[0].(models.WalletProvisioning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletStatus indicates an expected call of GetWalletStatus.
+func (mr *MockIWalletProvisioningServiceMockRecorder) GetWalletStatus(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletStatus", reflect.TypeOf((*MockIWalletProvisioningService)(nil).GetWalletStatus), ctx, userID)
+}
+
+// ProcessPendingWallets mocks base method.
+func (m *MockIWalletProvisioningService) ProcessPendingWallets(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPendingWallets", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessPendingWallets indicates an expected call of ProcessPendingWallets.
+func (mr *MockIWalletProvisioningServiceMockRecorder) ProcessPendingWallets(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPendingWallets", reflect.TypeOf((*MockIWalletProvisioningService)(nil).ProcessPendingWallets), ctx)
+}
+
+// Run mocks base method.
+func (m *MockIWalletProvisioningService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIWalletProvisioningServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIWalletProvisioningService)(nil).Run), ctx)
+}
+
+// MockIWalletStatusHandler is a mock of IWalletStatusHandler interface.
+type MockIWalletStatusHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWalletStatusHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIWalletStatusHandlerMockRecorder is the mock recorder for MockIWalletStatusHandler.
+type MockIWalletStatusHandlerMockRecorder struct {
+	mock *MockIWalletStatusHandler
+}
+
+// NewMockIWalletStatusHandler creates a new mock instance.
+func NewMockIWalletStatusHandler(ctrl *gomock.Controller) *MockIWalletStatusHandler {
+	mock := &MockIWalletStatusHandler{ctrl: ctrl}
+	mock.recorder = &MockIWalletStatusHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWalletStatusHandler) EXPECT() *MockIWalletStatusHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetWalletStatus mocks base method.
+func (m *MockIWalletStatusHandler) GetWalletStatus(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetWalletStatus", c)
+}
+
+// GetWalletStatus indicates an expected call of GetWalletStatus.
+func (mr *MockIWalletStatusHandlerMockRecorder) GetWalletStatus(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletStatus", reflect.TypeOf((*MockIWalletStatusHandler)(nil).GetWalletStatus), c)
+}
+
+// MockIWalletReconciliationService is a mock of IWalletReconciliationService interface.
+type MockIWalletReconciliationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWalletReconciliationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIWalletReconciliationServiceMockRecorder is the mock recorder for MockIWalletReconciliationService.
+type MockIWalletReconciliationServiceMockRecorder struct {
+	mock *MockIWalletReconciliationService
+}
+
+// NewMockIWalletReconciliationService creates a new mock instance.
+func NewMockIWalletReconciliationService(ctrl *gomock.Controller) *MockIWalletReconciliationService {
+	mock := &MockIWalletReconciliationService{ctrl: ctrl}
+	mock.recorder = &MockIWalletReconciliationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWalletReconciliationService) EXPECT() *MockIWalletReconciliationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetReport mocks base method.
+func (m *MockIWalletReconciliationService) GetReport(ctx context.Context, req pagination.Request) (models.WalletReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", ctx, req)
+	ret0, _ := ret[0].(models.WalletReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockIWalletReconciliationServiceMockRecorder) GetReport(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockIWalletReconciliationService)(nil).GetReport), ctx, req)
+}
+
+// Reconcile mocks base method.
+func (m *MockIWalletReconciliationService) Reconcile(ctx context.Context) (models.WalletReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx)
+	ret0, _ := ret[0].(models.WalletReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockIWalletReconciliationServiceMockRecorder) Reconcile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockIWalletReconciliationService)(nil).Reconcile), ctx)
+}
+
+// RetryProvisioning mocks base method.
+func (m *MockIWalletReconciliationService) RetryProvisioning(ctx context.Context, actor string, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryProvisioning", ctx, actor, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryProvisioning indicates an expected call of RetryProvisioning.
+func (mr *MockIWalletReconciliationServiceMockRecorder) RetryProvisioning(ctx, actor, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryProvisioning", reflect.TypeOf((*MockIWalletReconciliationService)(nil).RetryProvisioning), ctx, actor, userID)
+}
+
+// Run mocks base method.
+func (m *MockIWalletReconciliationService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIWalletReconciliationServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIWalletReconciliationService)(nil).Run), ctx)
+}
+
+// MockIWalletReconciliationHandler is a mock of IWalletReconciliationHandler interface.
+type MockIWalletReconciliationHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWalletReconciliationHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockIWalletReconciliationHandlerMockRecorder is the mock recorder for MockIWalletReconciliationHandler.
+type MockIWalletReconciliationHandlerMockRecorder struct {
+	mock *MockIWalletReconciliationHandler
+}
+
+// NewMockIWalletReconciliationHandler creates a new mock instance.
+func NewMockIWalletReconciliationHandler(ctrl *gomock.Controller) *MockIWalletReconciliationHandler {
+	mock := &MockIWalletReconciliationHandler{ctrl: ctrl}
+	mock.recorder = &MockIWalletReconciliationHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWalletReconciliationHandler) EXPECT() *MockIWalletReconciliationHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetReport mocks base method.
+func (m *MockIWalletReconciliationHandler) GetReport(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetReport", c)
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockIWalletReconciliationHandlerMockRecorder) GetReport(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockIWalletReconciliationHandler)(nil).GetReport), c)
+}
+
+// RetryProvisioning mocks base method.
+func (m *MockIWalletReconciliationHandler) RetryProvisioning(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RetryProvisioning", c)
+}
+
+// RetryProvisioning indicates an expected call of RetryProvisioning.
+func (mr *MockIWalletReconciliationHandlerMockRecorder) RetryProvisioning(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryProvisioning", reflect.TypeOf((*MockIWalletReconciliationHandler)(nil).RetryProvisioning), c)
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// AccountDeletionRequest asks for the caller's account to be deleted, the
+// current password is required.
+type AccountDeletionRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+func (l AccountDeletionRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type AccountDeletionResponse struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"ewallet-ums/internal/pagination"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// AccountNote is support context an admin wrote on a user's account. Users
+// never see their notes. The body is encrypted like other PII, since notes
+// tend to quote what the user told support.
+type AccountNote struct {
+	ID         int       `json:"id" gorm:"primarykey"`
+	UserID     int       `json:"user_id" gorm:"type:int;index"`
+	AuthorID   int       `json:"author_id" gorm:"type:int"`
+	Visibility string    `json:"visibility" gorm:"type:varchar(20)"`
+	Body       string    `json:"body" gorm:"type:text;serializer:pii"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (*AccountNote) TableName() string {
+	return "account_notes"
+}
+
+type AccountNoteRequest struct {
+	Visibility string `json:"visibility" validate:"required,oneof=support compliance"`
+	Body       string `json:"body" validate:"required,max=4000"`
+}
+
+func (l AccountNoteRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type AccountNoteListRequest struct {
+	pagination.Request
+}
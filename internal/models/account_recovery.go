@@ -0,0 +1,116 @@
+package models
+
+import (
+	"io"
+	"mime/multipart"
+	"time"
+
+	"ewallet-ums/internal/pagination"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RecoveryTicket is a manual recovery request from a user who lost both
+// their email and phone number. Support reviews the evidence, and a
+// recover_account admin approval sends a reset link to ContactEmail, which
+// becomes the account's email once the link is used.
+type RecoveryTicket struct {
+	ID int `json:"id" gorm:"primarykey"`
+	// Reference is handed to the submitter to quote to support.
+	Reference string `json:"reference" gorm:"type:varchar(32);uniqueIndex"`
+	// UserID is 0 when the identifier names no account, the submitter is
+	// told the same either way.
+	UserID       int      `json:"user_id" gorm:"type:int;index"`
+	Identifier   string   `json:"identifier" gorm:"type:varchar(255);serializer:pii"`
+	ContactEmail string   `json:"contact_email" gorm:"type:varchar(255);serializer:pii"`
+	FullName     string   `json:"full_name" gorm:"type:varchar(255);serializer:pii"`
+	Dob          string   `json:"dob" gorm:"type:varchar(128);serializer:pii"`
+	Statement    string   `json:"statement" gorm:"type:text;serializer:pii"`
+	EvidenceKeys []string `json:"-" gorm:"type:text;serializer:json"`
+	Status       string   `json:"status" gorm:"type:varchar(20);index"`
+	ReviewedBy   int      `json:"reviewed_by,omitempty" gorm:"type:int"`
+	ReviewNote   string   `json:"review_note,omitempty" gorm:"type:text"`
+	// ResetTokenHash is the SHA-256 of the token in the reset link.
+	ResetTokenHash string     `json:"-" gorm:"type:char(64);index"`
+	ResetExpiresAt *time.Time `json:"reset_expires_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// EvidenceURLs and Matches are filled when support reads the ticket,
+	// they aren't stored.
+	EvidenceURLs []string               `json:"evidence_urls,omitempty" gorm:"-"`
+	Matches      *RecoveryTicketMatches `json:"matches,omitempty" gorm:"-"`
+}
+
+func (*RecoveryTicket) TableName() string {
+	return "recovery_tickets"
+}
+
+// RecoveryTicketMatches says which claims match the account, so support
+// doesn't need to read the account's PII to compare them.
+type RecoveryTicketMatches struct {
+	FullName bool `json:"full_name"`
+	Dob      bool `json:"dob"`
+}
+
+// RecoveryTicketRequest is the multipart form a user submits, evidence is
+// one or more scans or photos of identity documents.
+type RecoveryTicketRequest struct {
+	Identifier   string                  `form:"identifier" validate:"required,max=255"`
+	ContactEmail string                  `form:"contact_email" validate:"required,email,max=255"`
+	FullName     string                  `form:"full_name" validate:"required,max=100"`
+	Dob          string                  `form:"dob" validate:"required,datetime=2006-01-02"`
+	Statement    string                  `form:"statement" validate:"required,max=2000"`
+	Evidence     []*multipart.FileHeader `form:"evidence" validate:"required,min=1,max=5"`
+}
+
+func (l RecoveryTicketRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// RecoveryEvidence is an uploaded evidence file.
+type RecoveryEvidence struct {
+	Body io.Reader
+	Size int64
+}
+
+type RecoveryTicketReceipt struct {
+	Reference string `json:"reference"`
+}
+
+type RecoveryTicketListRequest struct {
+	pagination.Request
+	Status string `form:"status"`
+}
+
+type RecoveryTicketRejectRequest struct {
+	Note string `json:"note" validate:"required,max=500"`
+}
+
+func (l RecoveryTicketRejectRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// RecoverAccountPayload is the payload of a recover_account approval.
+type RecoverAccountPayload struct {
+	TicketID int `json:"ticket_id" validate:"required"`
+}
+
+func (l RecoverAccountPayload) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// ManualRecoveryResetRequest redeems the link sent for an approved ticket.
+type ManualRecoveryResetRequest struct {
+	Token       string `json:"token" validate:"required,max=100"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+}
+
+func (l ManualRecoveryResetRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserAddress is a postal address of a user, for KYC and card issuance. The
+// address lines are encrypted like other PII. Exactly one of a user's
+// addresses is primary.
+type UserAddress struct {
+	ID         int       `json:"id" gorm:"primarykey"`
+	UserID     int       `json:"-" gorm:"type:int;index"`
+	Type       string    `json:"type" gorm:"type:varchar(20)"`
+	Line1      string    `json:"line1" gorm:"type:varchar(512);serializer:pii"`
+	Line2      string    `json:"line2,omitempty" gorm:"type:varchar(512);serializer:pii"`
+	City       string    `json:"city" gorm:"type:varchar(255);serializer:pii"`
+	Region     string    `json:"region,omitempty" gorm:"type:varchar(255);serializer:pii"`
+	PostalCode string    `json:"postal_code,omitempty" gorm:"type:varchar(128);serializer:pii"`
+	Country    string    `json:"country" gorm:"type:char(2)"`
+	IsPrimary  bool      `json:"is_primary" gorm:"not null;default:false"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (*UserAddress) TableName() string {
+	return "user_addresses"
+}
+
+// AddressRequest adds or replaces an address. PostalCode is checked against
+// the country's format by the service.
+type AddressRequest struct {
+	Type       string `json:"type" validate:"required,oneof=home mailing billing"`
+	Line1      string `json:"line1" validate:"required,max=200"`
+	Line2      string `json:"line2" validate:"max=200"`
+	City       string `json:"city" validate:"required,max=100"`
+	Region     string `json:"region" validate:"max=100"`
+	PostalCode string `json:"postal_code" validate:"max=20"`
+	Country    string `json:"country" validate:"required,iso3166_1_alpha2"`
+	// IsPrimary is only read when adding, PUT /addresses/:id/primary moves
+	// it later. A user's first address is always primary.
+	IsPrimary bool `json:"is_primary"`
+}
+
+func (l AddressRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ewallet-ums/internal/pagination"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UserRole struct {
+	ID        int       `json:"-" gorm:"primarykey"`
+	UserID    int       `json:"user_id" gorm:"type:int;uniqueIndex:idx_user_roles_user_id_role,priority:1"`
+	Role      string    `json:"role" gorm:"type:varchar(50);uniqueIndex:idx_user_roles_user_id_role,priority:2"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (*UserRole) TableName() string {
+	return "user_roles"
+}
+
+// AdminApproval is a sensitive admin action requested by one admin (the
+// maker) that only executes once a different admin (the checker) approves it.
+type AdminApproval struct {
+	ID           int        `json:"id" gorm:"primarykey"`
+	Action       string     `json:"action" gorm:"type:varchar(50)"`
+	TargetUserID int        `json:"target_user_id" gorm:"type:int;index"`
+	Payload      string     `json:"payload,omitempty" gorm:"type:text"`
+	Reason       string     `json:"reason" gorm:"type:text"`
+	Status       string     `json:"status" gorm:"type:varchar(20);index"`
+	RequestedBy  int        `json:"requested_by" gorm:"type:int"`
+	ReviewedBy   int        `json:"reviewed_by,omitempty" gorm:"type:int"`
+	ReviewNote   string     `json:"review_note,omitempty" gorm:"type:text"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (*AdminApproval) TableName() string {
+	return "admin_approvals"
+}
+
+type AdminApprovalRequest struct {
+	Action       string          `json:"action" validate:"required,oneof=ban_user grant_role recover_account purge_user"`
+	TargetUserID int             `json:"target_user_id" validate:"required"`
+	Payload      json.RawMessage `json:"payload"`
+	Reason       string          `json:"reason" validate:"required"`
+}
+
+func (l AdminApprovalRequest) Validate() error {
+	v := validator.New()
+	if err := v.Struct(l); err != nil {
+		return err
+	}
+
+	switch l.Action {
+	case "grant_role":
+		payload := GrantRolePayload{}
+		if err := json.Unmarshal(l.Payload, &payload); err != nil {
+			return fmt.Errorf("invalid grant_role payload: %v", err)
+		}
+		return payload.Validate()
+	case "recover_account":
+		payload := RecoverAccountPayload{}
+		if err := json.Unmarshal(l.Payload, &payload); err != nil {
+			return fmt.Errorf("invalid recover_account payload: %v", err)
+		}
+		return payload.Validate()
+	}
+	return nil
+}
+
+// GrantRolePayload is the payload of a grant_role approval.
+type GrantRolePayload struct {
+	Role string `json:"role" validate:"required,oneof=admin compliance pii_reader"`
+}
+
+func (l GrantRolePayload) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type AdminApprovalReviewRequest struct {
+	Note string `json:"note"`
+}
+
+type AdminApprovalListRequest struct {
+	pagination.Request
+	Status string `form:"status"`
+}
+
+// AdminUserFilter narrows the admin user listing, empty fields match any
+// user.
+type AdminUserFilter struct {
+	Status    string `form:"status"`
+	Type      string `form:"type"`
+	KycStatus string `form:"kyc_status"`
+}
+
+type AdminUserListRequest struct {
+	pagination.Request
+	AdminUserFilter
+}
+
+// AdminUserSummary is a user as the admin user listing shows them, without
+// contact details, which need the pii_reader role.
+type AdminUserSummary struct {
+	ID        int       `json:"id"`
+	Username  string    `json:"username"`
+	FullName  string    `json:"full_name"`
+	Status    string    `json:"status"`
+	Type      string    `json:"type"`
+	KycStatus string    `json:"kyc_status"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ForceLogoutRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+func (l ForceLogoutRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type CreateAdminRequest struct {
+	Username    string `validate:"required,max=20"`
+	Email       string `validate:"required,email,max=255"`
+	PhoneNumber string `validate:"omitempty,max=20"`
+}
+
+func (l CreateAdminRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type ForceLogoutResponse struct {
+	SessionsRevoked int `json:"sessions_revoked"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// BackupManifest ends every backup archive, what verify checks the archive
+// against.
+type BackupManifest struct {
+	CreatedAt time.Time             `json:"created_at"`
+	Tables    []BackupTableManifest `json:"tables"`
+}
+
+type BackupTableManifest struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+	// SHA256 is the hex hash of the table's rows as stored, one JSON object
+	// per line.
+	SHA256 string `json:"sha256"`
+}
+
+// BackupResult reports an exported or verified archive.
+type BackupResult struct {
+	ObjectKey string         `json:"object_key"`
+	KeyID     string         `json:"key_id"`
+	Manifest  BackupManifest `json:"manifest"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditColumns are the timestamp, soft delete and actor columns every table
+// should carry, embedded in its model. CreatedBy and UpdatedBy are filled
+// with the actor of the request's context, see helpers.ActorColumns.
+type AuditColumns struct {
+	CreatedAt time.Time      `json:"-"`
+	UpdatedAt time.Time      `json:"-"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	CreatedBy string         `json:"-" gorm:"type:varchar(100)"`
+	UpdatedBy string         `json:"-" gorm:"type:varchar(100)"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Client is a registered application (mobile app, web, partner) that users
+// log in through. Its settings shape the tokens issued at login; zero TTLs
+// keep the defaults and an empty Claims keeps every profile claim. Tokens
+// carry its Audience as aud, and are encrypted when JWE_AUDIENCES lists it. Users who
+// registered through a client get emails in its brand, empty brand fields
+// keep the defaults.
+type Client struct {
+	ID                     int       `json:"id" gorm:"primarykey"`
+	ClientID               string    `json:"client_id" gorm:"type:varchar(100);uniqueIndex"`
+	Name                   string    `json:"name" gorm:"type:varchar(100)"`
+	AccessTokenTTLSeconds  int       `json:"access_token_ttl_seconds"`
+	RefreshTokenTTLSeconds int       `json:"refresh_token_ttl_seconds"`
+	Scopes                 string    `json:"scopes" gorm:"type:varchar(500)"`
+	Claims                 string    `json:"claims" gorm:"type:varchar(255)"`
+	Audience               string    `json:"audience" gorm:"type:varchar(100)"`
+	BrandName              string    `json:"brand_name" gorm:"type:varchar(100)"`
+	MailFrom               string    `json:"mail_from" gorm:"type:varchar(255)"`
+	SupportURL             string    `json:"support_url" gorm:"type:varchar(255)"`
+	CreatedAt              time.Time `json:"-"`
+	UpdatedAt              time.Time `json:"-"`
+}
+
+func (*Client) TableName() string {
+	return "clients"
+}
+
+// AllowedScopes splits the space separated Scopes column.
+func (c Client) AllowedScopes() []string {
+	return strings.Fields(c.Scopes)
+}
+
+// ClaimSet splits the comma separated Claims column, nil when unset.
+func (c Client) ClaimSet() []string {
+	if strings.TrimSpace(c.Claims) == "" {
+		return nil
+	}
+
+	claims := []string{}
+	for _, claim := range strings.Split(c.Claims, ",") {
+		if claim = strings.TrimSpace(claim); claim != "" {
+			claims = append(claims, claim)
+		}
+	}
+	return claims
+}
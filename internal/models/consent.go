@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserConsent records one grant or withdrawal of a consent. Rows are never
+// updated, the latest row per type is the user's current choice and older
+// rows are the history.
+type UserConsent struct {
+	ID          int       `json:"-" gorm:"primarykey"`
+	UserID      int       `json:"-" gorm:"type:int;index:idx_user_consents_user_id_type,priority:1"`
+	ConsentType string    `json:"type" gorm:"type:varchar(50);index:idx_user_consents_user_id_type,priority:2"`
+	Granted     bool      `json:"granted"`
+	Version     string    `json:"version" gorm:"type:varchar(20)"`
+	IPAddress   string    `json:"-" gorm:"type:varchar(45)"`
+	UserAgent   string    `json:"-" gorm:"type:varchar(255)"`
+	CreatedAt   time.Time `json:"updated_at"`
+}
+
+func (*UserConsent) TableName() string {
+	return "user_consents"
+}
+
+// UpdateConsentsRequest carries the version of the consent text the user was
+// shown, so a later policy change can tell who agreed to which wording.
+type UpdateConsentsRequest struct {
+	Consents  []ConsentItem `json:"consents" validate:"required,min=1,dive"`
+	IPAddress string        `json:"-"`
+	UserAgent string        `json:"-"`
+}
+
+type ConsentItem struct {
+	Type    string `json:"type" validate:"required,oneof=marketing data_sharing biometric_processing"`
+	Granted *bool  `json:"granted" validate:"required"`
+	Version string `json:"version" validate:"required,max=20"`
+}
+
+func (l UpdateConsentsRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"ewallet-ums/internal/pagination"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DeadLetter is an event that failed for good: one this service couldn't
+// publish, or one it consumed and couldn't apply. It stays pending until an
+// admin replays it, after fixing its payload if need be, or discards it.
+type DeadLetter struct {
+	ID        int    `json:"id" gorm:"primarykey"`
+	Direction string `json:"direction" gorm:"type:varchar(10)"`
+	Topic     string `json:"topic" gorm:"type:varchar(100);index"`
+	// MessageID is the stream id of a consumed event, when known.
+	MessageID string          `json:"message_id,omitempty" gorm:"type:varchar(64)"`
+	Payload   json.RawMessage `json:"payload" gorm:"type:text"`
+	// Error is why the event failed, or why its last replay did.
+	Error string `json:"error" gorm:"type:text"`
+	// Replays counts the failed replays.
+	Replays    int        `json:"replays"`
+	Status     string     `json:"status" gorm:"type:varchar(20);index"`
+	ResolvedBy string     `json:"resolved_by,omitempty" gorm:"type:varchar(100)"`
+	Note       string     `json:"note,omitempty" gorm:"type:text"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (*DeadLetter) TableName() string {
+	return "dead_letters"
+}
+
+type DeadLetterListRequest struct {
+	pagination.Request
+	Status string `form:"status"`
+	Topic  string `form:"topic"`
+}
+
+// DeadLetterUpdateRequest fixes the payload of a pending dead letter before
+// it is replayed.
+type DeadLetterUpdateRequest struct {
+	Payload json.RawMessage `json:"payload" validate:"required"`
+	Note    string          `json:"note" validate:"required,max=500"`
+}
+
+func (l DeadLetterUpdateRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type DeadLetterDiscardRequest struct {
+	Note string `json:"note" validate:"required,max=500"`
+}
+
+func (l DeadLetterDiscardRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
@@ -0,0 +1,53 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// Brand is the brand an email is sent in, the client's the user registered
+// through or the default one.
+type Brand struct {
+	Name       string
+	SupportURL string
+}
+
+// EmailLink is the data of emails that send the user a link to act on, the
+// verification and password reset emails.
+type EmailLink struct {
+	Username         string
+	URL              string
+	ExpiresInMinutes int
+	Brand            Brand
+}
+
+// EmailTemplate is a template with the version of each of its translations.
+type EmailTemplate struct {
+	Name    string                `json:"name"`
+	Locales []EmailTemplateLocale `json:"locales"`
+}
+
+type EmailTemplateLocale struct {
+	Locale string `json:"locale"`
+	// Version changes whenever the translation or a partial it uses does.
+	Version string `json:"version"`
+}
+
+type RenderedEmail struct {
+	Template string `json:"template"`
+	Locale   string `json:"locale"`
+	Version  string `json:"version"`
+	Subject  string `json:"subject"`
+	Text     string `json:"text"`
+	HTML     string `json:"html"`
+}
+
+type EmailPreviewRequest struct {
+	Locale string `form:"locale" validate:"omitempty,oneof=en id"`
+	// ClientID previews the template as sent to users of that client.
+	ClientID string `form:"client_id" validate:"max=100"`
+	// Format html or text returns just that part, to view it in a browser.
+	Format string `form:"format" validate:"omitempty,oneof=json html text"`
+}
+
+func (l EmailPreviewRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
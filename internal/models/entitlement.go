@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserEntitlement is a feature an admin granted to a user. Entitlements that
+// follow from the KYC status aren't stored.
+type UserEntitlement struct {
+	ID        int       `json:"-" gorm:"primarykey"`
+	UserID    int       `json:"user_id" gorm:"type:int;uniqueIndex:idx_user_entitlements_user_id_feature,priority:1"`
+	Feature   string    `json:"feature" gorm:"type:varchar(50);uniqueIndex:idx_user_entitlements_user_id_feature,priority:2"`
+	GrantedBy string    `json:"granted_by" gorm:"type:varchar(100)"`
+	Reason    string    `json:"reason" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (*UserEntitlement) TableName() string {
+	return "user_entitlements"
+}
+
+// Entitlement is a feature the user is entitled to and why. A feature both
+// granted and following from KYC is listed once, as the grant.
+type Entitlement struct {
+	Feature   string     `json:"feature"`
+	Source    string     `json:"source"`
+	GrantedBy string     `json:"granted_by,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	GrantedAt *time.Time `json:"granted_at,omitempty"`
+}
+
+type EntitlementRequest struct {
+	// Feature comes from the path.
+	Feature string `json:"-" validate:"required,oneof=international_transfer crypto"`
+	Reason  string `json:"reason" validate:"required,max=500"`
+}
+
+func (l EntitlementRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
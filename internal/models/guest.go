@@ -0,0 +1,34 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// GuestRequest starts a guest session on a device. The tokens issued are
+// only accepted together with the same device id.
+type GuestRequest struct {
+	DeviceID  string `json:"device_id" validate:"required,max=100"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+func (l GuestRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// UpgradeGuestRequest turns a guest into a full account, keeping its user id.
+type UpgradeGuestRequest struct {
+	Username    string `json:"username" validate:"required,max=20"`
+	Password    string `json:"password" validate:"required"`
+	Email       string `json:"email" validate:"required_without=PhoneNumber"`
+	PhoneNumber string `json:"phone_number" validate:"required_without=Email"`
+	FullName    string `json:"full_name" validate:"max=100"`
+	Address     string `json:"address"`
+	Dob         string `json:"dob" validate:"omitempty,datetime=2006-01-02"`
+	IPAddress   string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+func (l UpgradeGuestRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// InboxEvent records an event consumed from another service, so one that is
+// delivered again is only applied once. ClaimedAt is when a consumer started
+// applying it, ProcessedAt is set once it has.
+type InboxEvent struct {
+	ID          int    `gorm:"primarykey"`
+	Topic       string `gorm:"type:varchar(100);uniqueIndex:idx_inbox_events_topic_event_id,priority:1"`
+	EventID     string `gorm:"type:varchar(100);uniqueIndex:idx_inbox_events_topic_event_id,priority:2"`
+	ClaimedAt   time.Time
+	ProcessedAt *time.Time
+	CreatedAt   time.Time
+}
+
+func (*InboxEvent) TableName() string {
+	return "inbox_events"
+}
+
+// WalletClosedEvent is published by the wallet service when it closes a
+// user's wallet.
+type WalletClosedEvent struct {
+	EventID  string `json:"event_id" validate:"required,max=100"`
+	UserID   int    `json:"user_id" validate:"required"`
+	WalletID int    `json:"wallet_id"`
+	Reason   string `json:"reason"`
+}
+
+func (l WalletClosedEvent) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// FraudFlaggedEvent is published by the fraud service when it flags a user.
+type FraudFlaggedEvent struct {
+	EventID string `json:"event_id" validate:"required,max=100"`
+	UserID  int    `json:"user_id" validate:"required"`
+	Reason  string `json:"reason" validate:"required"`
+}
+
+func (l FraudFlaggedEvent) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// KycProfile is the extended KYC data regulators require for higher wallet
+// tiers, one per user. Names and free text are encrypted like other PII.
+type KycProfile struct {
+	ID                  int       `json:"-" gorm:"primarykey"`
+	UserID              int       `json:"-" gorm:"type:int;uniqueIndex"`
+	EmploymentStatus    string    `json:"employment_status" gorm:"type:varchar(20)"`
+	Occupation          string    `json:"occupation,omitempty" gorm:"type:varchar(512);serializer:pii"`
+	EmployerName        string    `json:"employer_name,omitempty" gorm:"type:varchar(512);serializer:pii"`
+	SourceOfFunds       string    `json:"source_of_funds,omitempty" gorm:"type:varchar(20)"`
+	SourceOfFundsDetail string    `json:"source_of_funds_detail,omitempty" gorm:"type:text;serializer:pii"`
+	NextOfKin           NextOfKin `json:"next_of_kin" gorm:"embedded;embeddedPrefix:next_of_kin_"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+func (*KycProfile) TableName() string {
+	return "kyc_profiles"
+}
+
+type NextOfKin struct {
+	FullName     string `json:"full_name" gorm:"type:varchar(512);serializer:pii" validate:"required,max=100"`
+	Relationship string `json:"relationship" gorm:"type:varchar(20)" validate:"required,oneof=spouse parent child sibling relative friend other"`
+	PhoneNumber  string `json:"phone_number" gorm:"type:varchar(128);serializer:pii" validate:"required,max=20"`
+}
+
+// KycProfileRequest replaces the user's extended KYC data. Which fields are
+// required depends on the jurisdiction, checked by the service.
+type KycProfileRequest struct {
+	EmploymentStatus    string     `json:"employment_status" validate:"required,oneof=employed self_employed unemployed student retired"`
+	Occupation          string     `json:"occupation" validate:"max=100"`
+	EmployerName        string     `json:"employer_name" validate:"max=200"`
+	SourceOfFunds       string     `json:"source_of_funds" validate:"omitempty,oneof=salary business savings investment inheritance pension allowance other"`
+	SourceOfFundsDetail string     `json:"source_of_funds_detail" validate:"required_if=SourceOfFunds other,max=500"`
+	NextOfKin           *NextOfKin `json:"next_of_kin"`
+}
+
+func (l KycProfileRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// KycProfileResponse is the profile checked against the rules of the user's
+// jurisdiction as it is now, which may have changed since it was saved.
+type KycProfileResponse struct {
+	KycProfile
+	// Jurisdiction is the country of the primary address with rules of its
+	// own, or "default".
+	Jurisdiction string `json:"jurisdiction"`
+	// Missing are the fields the jurisdiction requires that aren't filled in.
+	Missing []string `json:"missing"`
+}
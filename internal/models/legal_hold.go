@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// LegalHold blocks deletion and anonymization of a user's data while
+// LiftedAt is nil. Lifted holds are kept as history.
+type LegalHold struct {
+	ID         int        `json:"id" gorm:"primarykey"`
+	UserID     int        `json:"user_id" gorm:"type:int;index:idx_legal_holds_user_id_lifted_at,priority:1"`
+	Reason     string     `json:"reason" gorm:"type:text"`
+	PlacedBy   int        `json:"placed_by" gorm:"type:int"`
+	LiftReason string     `json:"lift_reason,omitempty" gorm:"type:text"`
+	LiftedBy   int        `json:"lifted_by,omitempty" gorm:"type:int"`
+	LiftedAt   *time.Time `json:"lifted_at,omitempty" gorm:"index:idx_legal_holds_user_id_lifted_at,priority:2"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (*LegalHold) TableName() string {
+	return "legal_holds"
+}
+
+type LegalHoldRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+func (l LegalHoldRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
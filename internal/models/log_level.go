@@ -0,0 +1,16 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+type LogLevelRequest struct {
+	Level string `json:"level" validate:"required,oneof=panic fatal error warn warning info debug trace"`
+}
+
+func (l LogLevelRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
@@ -1,10 +1,42 @@
 package models
 
-import "github.com/go-playground/validator/v10"
+import (
+	"time"
+
+	"ewallet-ums/constants"
+
+	"github.com/go-playground/validator/v10"
+)
 
 type LoginRequest struct {
-	Username string `json:"username" validate:"required"`
-	Password string `json:"password" validate:"required"`
+	// Identifier is a username, email or phone number. Username is still
+	// accepted from clients that predate it.
+	Identifier string `json:"identifier" validate:"required_without=Username,max=255"`
+	Username   string `json:"username" validate:"required_without=Identifier"`
+	Password   string `json:"password" validate:"required_without_all=OTP DeviceToken"`
+	// OTP is a one-time login code texted to the user's phone number, in
+	// place of the password.
+	OTP string `json:"otp" validate:"omitempty,len=6,numeric"`
+	// RememberDevice asks for a trusted device cookie after a login with an
+	// OTP. DeviceToken is the token of that cookie, it stands in for the
+	// OTP on the next logins from the browser.
+	RememberDevice bool   `json:"remember_device"`
+	DeviceToken    string `json:"-"`
+	// NewPassword replaces the password of users who must change it, it's
+	// ignored for everyone else.
+	NewPassword string `json:"new_password" validate:"omitempty,min=8,max=72"`
+	// CancelDeletion must be set to log in to an account pending deletion,
+	// which cancels the deletion.
+	CancelDeletion bool   `json:"cancel_deletion"`
+	ClientID       string `json:"client_id" validate:"omitempty,max=100"`
+	Scope          string `json:"scope" validate:"omitempty,max=500"`
+	IPAddress      string `json:"-"`
+	UserAgent      string `json:"-"`
+	// ASN and Country come from the edge proxy's headers, when configured.
+	// Country and City are otherwise located from the IP address.
+	ASN     string `json:"-"`
+	Country string `json:"-"`
+	City    string `json:"-"`
 }
 
 func (l LoginRequest) Validate() error {
@@ -12,6 +44,13 @@ func (l LoginRequest) Validate() error {
 	return v.Struct(l)
 }
 
+func (l LoginRequest) GetIdentifier() string {
+	if l.Identifier != "" {
+		return l.Identifier
+	}
+	return l.Username
+}
+
 type LoginResponse struct {
 	UserID       int    `json:"user_id"`
 	Username     string `json:"username"`
@@ -19,4 +58,36 @@ type LoginResponse struct {
 	Email        string `json:"email"`
 	Token        string `json:"token"`
 	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope,omitempty"`
+
+	// TokenExpiresAt and RefreshTokenExpiresAt are the tokens' exp claims,
+	// clients needn't decode the tokens to schedule a refresh.
+	TokenExpiresAt        time.Time `json:"token_expires_at"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+
+	// RequestSigningKey signs requests to sensitive endpoints while the token
+	// is valid, see MiddlewareReplayProtection.
+	RequestSigningKey string `json:"request_signing_key"`
+
+	ProfileCompleteness int `json:"profile_completeness"`
+
+	// DeviceToken and DeviceTokenExpiresAt make the trusted device cookie of
+	// a login with remember_device, the handler sets it.
+	DeviceToken          string    `json:"-"`
+	DeviceTokenExpiresAt time.Time `json:"-"`
+}
+
+// LoginSources lists where the login came from, for failure rate tracking.
+func (l LoginRequest) LoginSources() []LoginSource {
+	sources := []LoginSource{}
+	for _, source := range []LoginSource{
+		{Kind: constants.LoginSourceIP, Value: l.IPAddress},
+		{Kind: constants.LoginSourceASN, Value: l.ASN},
+		{Kind: constants.LoginSourceCountry, Value: l.Country},
+	} {
+		if source.Value != "" {
+			sources = append(sources, source)
+		}
+	}
+	return sources
 }
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type LoginHistory struct {
+	ID        int    `json:"id" gorm:"primarykey"`
+	UserID    int    `json:"-" gorm:"type:int;index:idx_login_histories_user_id_created_at,priority:1"`
+	Username  string `json:"-" gorm:"type:varchar(20)"`
+	IPAddress string `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent string `json:"user_agent" gorm:"type:varchar(255)"`
+	// Country and City are located from the IP address, when known.
+	Country   string    `json:"country,omitempty" gorm:"type:varchar(2)"`
+	City      string    `json:"city,omitempty" gorm:"type:varchar(100)"`
+	Success   bool      `json:"success"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_login_histories_user_id_created_at,priority:2;index"`
+}
+
+func (*LoginHistory) TableName() string {
+	return "login_histories"
+}
@@ -0,0 +1,19 @@
+package models
+
+// LoginSource is where failed logins come from, see constants.LoginSourceIP.
+type LoginSource struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+func (s LoginSource) String() string {
+	return s.Kind + ":" + s.Value
+}
+
+// HotLoginSource is a source with failed logins in the current window.
+type HotLoginSource struct {
+	LoginSource
+	Failures  int64 `json:"failures"`
+	Threshold int   `json:"threshold"`
+	Breached  bool  `json:"breached"`
+}
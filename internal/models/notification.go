@@ -0,0 +1,186 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// NotificationPreference turns one security event email on or off. Events
+// without a row are on.
+type NotificationPreference struct {
+	ID        int       `json:"-" gorm:"primarykey"`
+	UserID    int       `json:"-" gorm:"type:int;uniqueIndex:idx_notification_preferences_user_id_event,priority:1"`
+	Event     string    `json:"event" gorm:"type:varchar(50);uniqueIndex:idx_notification_preferences_user_id_event,priority:2"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (*NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceItem `json:"preferences" validate:"required,min=1,dive"`
+}
+
+type NotificationPreferenceItem struct {
+	Event   string `json:"event" validate:"required,oneof=new_device_login new_country_login two_factor_changed activity_digest"`
+	Enabled *bool  `json:"enabled" validate:"required"`
+}
+
+func (l UpdateNotificationPreferencesRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// SecurityEvent describes what happened for the notification email.
+type SecurityEvent struct {
+	Event     string
+	IPAddress string
+	UserAgent string
+	// PreviousEmail receives email_changed notices, so the owner of the old
+	// address learns about the change.
+	PreviousEmail string
+	// RevertURL undoes an email change, it is left out of email_changed
+	// notices when empty.
+	RevertURL            string
+	RevertExpiresInHours int
+	// Country and City are where the IP address is, when known.
+	Country    string
+	City       string
+	OccurredAt time.Time
+}
+
+// NotificationQuietHours holds back a user's security emails between Start
+// and End (HH:MM) in TimeZone, they are sent as one summary afterwards. End
+// before Start spans midnight.
+type NotificationQuietHours struct {
+	ID        int       `json:"-" gorm:"primarykey"`
+	UserID    int       `json:"-" gorm:"type:int;uniqueIndex"`
+	Start     string    `json:"start" gorm:"type:varchar(5)"`
+	End       string    `json:"end" gorm:"type:varchar(5)"`
+	TimeZone  string    `json:"time_zone" gorm:"type:varchar(64)"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (*NotificationQuietHours) TableName() string {
+	return "notification_quiet_hours"
+}
+
+// Until returns when the quiet hours around now end, false when now isn't
+// within them.
+func (q NotificationQuietHours) Until(now time.Time) (time.Time, bool) {
+	loc, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	start, okStart := minuteOfDay(q.Start)
+	end, okEnd := minuteOfDay(q.End)
+	if !okStart || !okEnd || start == end {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	quiet := minute >= start && minute < end
+	if end < start {
+		quiet = minute >= start || minute < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+func minuteOfDay(clock string) (int, bool) {
+	hour, minute, ok := strings.Cut(clock, ":")
+	if !ok {
+		return 0, false
+	}
+	h, errHour := strconv.Atoi(hour)
+	m, errMinute := strconv.Atoi(minute)
+	if errHour != nil || errMinute != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+type UpdateQuietHoursRequest struct {
+	Start    string `json:"start" validate:"required,datetime=15:04"`
+	End      string `json:"end" validate:"required,datetime=15:04,nefield=Start"`
+	TimeZone string `json:"time_zone" validate:"required,timezone"`
+}
+
+func (l UpdateQuietHoursRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// PendingNotification is a security email held back by quiet hours or the
+// throttle. A user's pending notifications are sent together as one summary
+// once the earliest SendAfter has passed.
+type PendingNotification struct {
+	ID         int    `gorm:"primarykey"`
+	UserID     int    `gorm:"type:int;index"`
+	Event      string `gorm:"type:varchar(50)"`
+	IPAddress  string `gorm:"type:varchar(45)"`
+	UserAgent  string `gorm:"type:varchar(255)"`
+	Country    string `gorm:"type:varchar(2)"`
+	City       string `gorm:"type:varchar(100)"`
+	OccurredAt time.Time
+	SendAfter  time.Time `gorm:"index"`
+}
+
+func (*PendingNotification) TableName() string {
+	return "pending_notifications"
+}
+
+func (n PendingNotification) SecurityEvent() SecurityEvent {
+	return SecurityEvent{
+		Event:      n.Event,
+		IPAddress:  n.IPAddress,
+		UserAgent:  n.UserAgent,
+		Country:    n.Country,
+		City:       n.City,
+		OccurredAt: n.OccurredAt,
+	}
+}
+
+// SecurityEventSummary is how often one event happened while its emails
+// were held back, with the latest occurrence.
+type SecurityEventSummary struct {
+	Event string
+	Count int
+	Last  SecurityEvent
+}
+
+// ActivityDigest records that a user's digest for Month (YYYY-MM) was sent,
+// the unique index keeps workers from sending it twice.
+type ActivityDigest struct {
+	ID        int    `gorm:"primarykey"`
+	UserID    int    `gorm:"type:int;uniqueIndex:idx_activity_digests_user_id_month,priority:1"`
+	Month     string `gorm:"type:varchar(7);uniqueIndex:idx_activity_digests_user_id_month,priority:2"`
+	CreatedAt time.Time
+}
+
+func (*ActivityDigest) TableName() string {
+	return "activity_digests"
+}
+
+// ActivitySummary is a user's login activity for one month.
+type ActivitySummary struct {
+	Month            string
+	SuccessfulLogins int
+	FailedLogins     int
+	// Devices are the user agents of successful logins.
+	Devices     []string
+	IPAddresses []string
+}
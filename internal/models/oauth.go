@@ -0,0 +1,70 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// IntrospectionRequest is the form body of RFC 7662 introspection. Unknown
+// token_type_hint values are ignored, as the RFC requires.
+type IntrospectionRequest struct {
+	Token         string `form:"token" validate:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+}
+
+func (l IntrospectionRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// IntrospectionResponse only carries active=false for tokens that are not
+// live, everything else is omitted.
+type IntrospectionResponse struct {
+	Active   bool   `json:"active"`
+	Scope    string `json:"scope,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	// ProfileCompleteness lets resource servers gate features on how much of
+	// the profile the user has filled in.
+	ProfileCompleteness int            `json:"profile_completeness,omitempty"`
+	TokenType           string         `json:"token_type,omitempty"`
+	Exp                 int64          `json:"exp,omitempty"`
+	Iat                 int64          `json:"iat,omitempty"`
+	Sub                 string         `json:"sub,omitempty"`
+	Aud                 []string       `json:"aud,omitempty"`
+	Iss                 string         `json:"iss,omitempty"`
+	Ext                 map[string]any `json:"ext,omitempty"`
+}
+
+// TokenExchangeRequest is the form body of an RFC 8693 token exchange. Only
+// access tokens can be exchanged, for a single audience.
+type TokenExchangeRequest struct {
+	GrantType          string `form:"grant_type" validate:"required"`
+	SubjectToken       string `form:"subject_token" validate:"required"`
+	SubjectTokenType   string `form:"subject_token_type" validate:"required"`
+	Audience           string `form:"audience" validate:"required"`
+	RequestedTokenType string `form:"requested_token_type"`
+}
+
+func (l TokenExchangeRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// ClientCredentialsRequest is the form body of the client_credentials grant
+// used by service accounts, which authenticate with basic auth.
+type ClientCredentialsRequest struct {
+	GrantType string `form:"grant_type" validate:"required"`
+	Scope     string `form:"scope" validate:"omitempty,max=500"`
+}
+
+func (l ClientCredentialsRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// OAuthTokenResponse is the token endpoint's response for every grant.
+type OAuthTokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+	Scope           string `json:"scope,omitempty"`
+}
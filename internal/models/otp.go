@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// OTPRequest asks for a one-time code texted to PhoneNumber.
+type OTPRequest struct {
+	PhoneNumber string `json:"phone_number" validate:"required,max=32"`
+	Purpose     string `json:"purpose" validate:"required,oneof=register login recovery"`
+}
+
+func (l OTPRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// PhoneRegisterRequest registers a passwordless user, who logs in with
+// one-time codes texted to PhoneNumber until they set a password.
+type PhoneRegisterRequest struct {
+	Username    string                  `json:"username" validate:"required,max=20"`
+	PhoneNumber string                  `json:"phone_number" validate:"required,max=32"`
+	OTP         string                  `json:"otp" validate:"required,len=6,numeric"`
+	FullName    string                  `json:"full_name" validate:"max=100"`
+	Locale      string                  `json:"locale" validate:"max=10"`
+	ClientID    string                  `json:"client_id" validate:"max=100"`
+	Attribution RegistrationAttribution `json:"attribution"`
+	IPAddress   string                  `json:"-"`
+	UserAgent   string                  `json:"-"`
+}
+
+func (l PhoneRegisterRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// SetPasswordRequest sets the user's password. CurrentPassword is required
+// unless the user has none yet, e.g. passwordless users.
+type SetPasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+func (l SetPasswordRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// TrustedDevice is a browser the user logged in from with a one-time code and
+// asked to remember. Its cookie carries a random token, only the hash is
+// kept.
+type TrustedDevice struct {
+	ID         int        `json:"id" gorm:"primarykey"`
+	UserID     int        `json:"user_id" gorm:"type:int;index"`
+	TokenHash  string     `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+	UserAgent  string     `json:"user_agent" gorm:"type:varchar(255)"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"index"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (*TrustedDevice) TableName() string {
+	return "trusted_devices"
+}
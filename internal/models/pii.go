@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PIIDataKey is a data encryption key for PII columns, stored wrapped by a
+// master key that never touches the database.
+type PIIDataKey struct {
+	ID          int    `gorm:"primarykey"`
+	MasterKeyID string `gorm:"type:varchar(100)"`
+	WrappedKey  []byte `gorm:"type:varbinary(512)"`
+	Active      bool   `gorm:"index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (*PIIDataKey) TableName() string {
+	return "pii_data_keys"
+}
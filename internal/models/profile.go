@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UpdateProfileRequest struct {
+	Email       string `json:"email" validate:"omitempty,email,max=100"`
+	PhoneNumber string `json:"phone_number" validate:"omitempty,max=20"`
+	FullName    string `json:"full_name" validate:"omitempty,max=100"`
+	Address     string `json:"address"`
+	Dob         string `json:"dob" validate:"omitempty,datetime=2006-01-02"`
+	Locale      string `json:"locale" validate:"omitempty,oneof=en id"`
+	// Version is the profile version the edit is based on, from GET
+	// /user/v1/profile. Left out, the version read by the update is used.
+	Version int `json:"version" validate:"omitempty,min=1"`
+	// CurrentPassword confirms an email change, passwordless users send OTP,
+	// a login code texted to their phone number, instead.
+	CurrentPassword string `json:"current_password"`
+	OTP             string `json:"otp" validate:"omitempty,len=6,numeric"`
+}
+
+func (l UpdateProfileRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// EmailChangeRevert is the undo link emailed to the previous address when a
+// user changes their email. Using it before ExpiresAt restores
+// PreviousEmail and logs the user out everywhere.
+type EmailChangeRevert struct {
+	ID            int    `gorm:"primarykey"`
+	UserID        int    `gorm:"type:int;index"`
+	PreviousEmail string `gorm:"type:varchar(255);serializer:pii"`
+	NewEmail      string `gorm:"type:varchar(255);serializer:pii"`
+	// TokenHash is the SHA-256 of the token in the link.
+	TokenHash  string `gorm:"type:char(64);uniqueIndex"`
+	ExpiresAt  time.Time
+	RevertedAt *time.Time
+	CreatedAt  time.Time
+}
+
+func (*EmailChangeRevert) TableName() string {
+	return "email_change_reverts"
+}
+
+// EmailChangeRevertRequest redeems the link sent to the previous address.
+// EmailChangeRevertRequest sets a new password along with the undo, whoever
+// changed the email may know the current one.
+type EmailChangeRevertRequest struct {
+	Token       string `json:"token" validate:"required,max=100"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+}
+
+func (l EmailChangeRevertRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type EmailChangeRevertResponse struct {
+	SessionsRevoked int `json:"sessions_revoked"`
+}
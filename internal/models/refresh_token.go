@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// RefreshToken is one refresh token of a family. The token issued at login is
+// the family's root; every refresh mints a child and marks its parent used,
+// so a used token showing up again means the chain was stolen.
+type RefreshToken struct {
+	ID        int        `json:"id" gorm:"primarykey"`
+	FamilyID  string     `json:"family_id" gorm:"type:varchar(32);index"`
+	ParentID  *int       `json:"parent_id"`
+	UserID    int        `json:"user_id" gorm:"type:int;index"`
+	TokenHash string     `json:"-" gorm:"type:char(64);uniqueIndex"`
+	TokenID   string     `json:"token_id" gorm:"type:varchar(32);index"`
+	IPAddress string     `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent string     `json:"user_agent" gorm:"type:varchar(255)"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"index"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (*RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// TokenOrigin is where a token was requested from.
+type TokenOrigin struct {
+	IPAddress string
+	UserAgent string
+	Country   string
+	City      string
+}
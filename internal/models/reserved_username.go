@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"ewallet-ums/internal/pagination"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ReservedUsername is a username only its verified owner may get, such as a
+// brand, a system name or an executive's name. Nobody can register it or
+// upgrade a guest to it; an admin claims it for the owner instead.
+type ReservedUsername struct {
+	ID int `json:"id" gorm:"primarykey"`
+	// Username is lowercase, reservations match regardless of case.
+	Username  string `json:"username" gorm:"type:varchar(20);uniqueIndex"`
+	Category  string `json:"category" gorm:"type:varchar(20)"`
+	Note      string `json:"note,omitempty" gorm:"type:text"`
+	CreatedBy string `json:"created_by" gorm:"type:varchar(100)"`
+	// ClaimedBy is the user the username was claimed for, 0 until then.
+	ClaimedBy int        `json:"claimed_by,omitempty" gorm:"type:int"`
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (*ReservedUsername) TableName() string {
+	return "reserved_usernames"
+}
+
+type ReservedUsernameRequest struct {
+	Username string `json:"username" validate:"required,max=20"`
+	Category string `json:"category" validate:"required,oneof=brand system executive"`
+	Note     string `json:"note" validate:"max=500"`
+}
+
+func (l ReservedUsernameRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type ReservedUsernameListRequest struct {
+	pagination.Request
+}
+
+// ClaimReservedUsernameRequest gives a reserved username to its owner, whose
+// identity support verified.
+type ClaimReservedUsernameRequest struct {
+	UserID int    `json:"user_id" validate:"required,min=1"`
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+func (l ClaimReservedUsernameRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
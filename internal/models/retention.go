@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// RetentionRule keeps rows of one kind for Retention, measured from the
+// rule's reference timestamp (creation, expiry or deletion).
+type RetentionRule struct {
+	Name      string
+	Retention time.Duration
+}
+
+type RetentionResult struct {
+	Rule   string    `json:"rule"`
+	Cutoff time.Time `json:"cutoff"`
+	DryRun bool      `json:"dry_run"`
+	Rows   int64     `json:"rows"`
+}
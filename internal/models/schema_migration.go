@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// SchemaMigration records a migration applied to the database, see
+// helpers.Migration.
+type SchemaMigration struct {
+	Version   int       `json:"version" gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `json:"name" gorm:"type:varchar(100)"`
+	Phase     string    `json:"phase" gorm:"type:varchar(20)"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+func (*SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
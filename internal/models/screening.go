@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"ewallet-ums/internal/pagination"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserScreening is when a user was last screened against PEP and sanctions
+// lists.
+type UserScreening struct {
+	UserID     int       `gorm:"primaryKey;autoIncrement:false"`
+	ScreenedAt time.Time `gorm:"index"`
+}
+
+func (*UserScreening) TableName() string {
+	return "user_screenings"
+}
+
+// ScreeningReview is a screening hit waiting for compliance. A user gets one
+// review per distinct set of matches, so re-screening doesn't raise a hit
+// that was already reviewed again.
+type ScreeningReview struct {
+	ID     int `json:"id" gorm:"primarykey"`
+	UserID int `json:"user_id" gorm:"type:int;uniqueIndex:idx_screening_reviews_user_id_match_key,priority:1"`
+	// Matches are the provider's matches, as external.ScreeningMatch.
+	Matches json.RawMessage `json:"matches" gorm:"type:text"`
+	// Lists are the kinds of list matched, comma separated.
+	Lists string `json:"lists" gorm:"type:varchar(255)"`
+	// MatchKey is a hash of the matched entry ids.
+	MatchKey   string     `json:"-" gorm:"type:char(64);uniqueIndex:idx_screening_reviews_user_id_match_key,priority:2"`
+	Status     string     `json:"status" gorm:"type:varchar(20);index"`
+	ResolvedBy string     `json:"resolved_by,omitempty" gorm:"type:varchar(100)"`
+	Note       string     `json:"note,omitempty" gorm:"type:text"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (*ScreeningReview) TableName() string {
+	return "screening_reviews"
+}
+
+type ScreeningReviewListRequest struct {
+	pagination.Request
+	Status string `form:"status"`
+}
+
+// ScreeningReviewResolveRequest closes a pending review. Confirming a hit
+// doesn't change the account, compliance acts on it separately.
+type ScreeningReviewResolveRequest struct {
+	Decision string `json:"decision" validate:"required,oneof=cleared confirmed"`
+	Note     string `json:"note" validate:"required,max=500"`
+}
+
+func (l ScreeningReviewResolveRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// SecurityQuestion is a user-chosen question kept as a recovery factor. The
+// question is encrypted like other PII, the answer is only stored as a hash
+// of its normalized form.
+type SecurityQuestion struct {
+	ID         int       `json:"id" gorm:"primarykey"`
+	UserID     int       `json:"-" gorm:"type:int;index"`
+	Question   string    `json:"question" gorm:"type:varchar(1024);serializer:pii"`
+	AnswerHash string    `json:"-" gorm:"type:varchar(255)"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (*SecurityQuestion) TableName() string {
+	return "security_questions"
+}
+
+// SetSecurityQuestionsRequest replaces all of a user's questions, the
+// current password is required since they can be used to reset it.
+// Passwordless users send OTP, a login code texted to their phone number,
+// instead.
+type SetSecurityQuestionsRequest struct {
+	Password  string                 `json:"password"`
+	OTP       string                 `json:"otp" validate:"omitempty,len=6,numeric"`
+	Questions []SecurityQuestionItem `json:"questions" validate:"required,min=2,max=5,dive"`
+}
+
+type SecurityQuestionItem struct {
+	Question string `json:"question" validate:"required,max=255"`
+	Answer   string `json:"answer" validate:"required,max=255"`
+}
+
+func (l SetSecurityQuestionsRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// RecoveryQuestion is a question to answer for recovery. ID is its position
+// rather than the row's, so the questions of unknown accounts can't be told
+// from real ones.
+type RecoveryQuestion struct {
+	ID       int    `json:"id"`
+	Question string `json:"question"`
+}
+
+type RecoveryQuestionsRequest struct {
+	Identifier string `json:"identifier" validate:"required,max=255"`
+}
+
+func (l RecoveryQuestionsRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// SecurityQuestionRecoveryRequest resets the password of the user named by
+// identifier when every one of their questions is answered correctly and
+// OTP is the recovery code texted to their phone number, left out by users
+// without one.
+type SecurityQuestionRecoveryRequest struct {
+	Identifier  string               `json:"identifier" validate:"required,max=255"`
+	Answers     []SecurityAnswerItem `json:"answers" validate:"required,min=1,max=5,dive"`
+	OTP         string               `json:"otp" validate:"omitempty,len=6,numeric"`
+	NewPassword string               `json:"new_password" validate:"required,min=8"`
+}
+
+// SecurityAnswerItem answers the RecoveryQuestion with QuestionID.
+type SecurityAnswerItem struct {
+	QuestionID int    `json:"question_id" validate:"required"`
+	Answer     string `json:"answer" validate:"required,max=255"`
+}
+
+func (l SecurityQuestionRecoveryRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
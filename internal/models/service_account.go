@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"ewallet-ums/internal/pagination"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// ServiceAccount is a non-human caller such as a batch job. It is backed by a
+// users row of type service, so roles, sessions and token validation work the
+// same as for people: the row's username is the client id and its password
+// the hashed client secret.
+type ServiceAccount struct {
+	ID          int            `json:"id" gorm:"primarykey"`
+	UserID      int            `json:"user_id" gorm:"type:int;uniqueIndex"`
+	ClientID    string         `json:"client_id" gorm:"type:varchar(20);uniqueIndex"`
+	Name        string         `json:"name" gorm:"type:varchar(100)"`
+	Description string         `json:"description" gorm:"type:text"`
+	Scopes      string         `json:"scopes" gorm:"type:varchar(500)"`
+	CreatedBy   int            `json:"created_by" gorm:"type:int"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (*ServiceAccount) TableName() string {
+	return "service_accounts"
+}
+
+// AllowedScopes splits the space separated Scopes column.
+func (a ServiceAccount) AllowedScopes() []string {
+	return strings.Fields(a.Scopes)
+}
+
+type ServiceAccountRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+	Scopes      string `json:"scopes" validate:"max=500"`
+}
+
+func (l ServiceAccountRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// ServiceAccountCredentials is returned on create and secret rotation, the
+// only times the client secret is available.
+type ServiceAccountCredentials struct {
+	ServiceAccount
+	ClientSecret string `json:"client_secret"`
+}
+
+type ServiceAccountListRequest struct {
+	pagination.Request
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DailyStat is one day's count of a metric, see constants.StatsMetricRegistrations.
+// The stats worker refreshes the recent days, so reading them doesn't scan
+// the users and login histories.
+type DailyStat struct {
+	ID        int       `json:"-" gorm:"primarykey"`
+	Day       string    `json:"day" gorm:"type:varchar(10);uniqueIndex:idx_daily_stats_day_metric,priority:1"`
+	Metric    string    `json:"-" gorm:"type:varchar(50);uniqueIndex:idx_daily_stats_day_metric,priority:2"`
+	Count     int64     `json:"count"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func (*DailyStat) TableName() string {
+	return "daily_stats"
+}
+
+type AdminStatsRequest struct {
+	Days int `form:"days" validate:"omitempty,min=1,max=90"`
+}
+
+func (l AdminStatsRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// AdminStats are the aggregates of the admin dashboard. The daily series
+// have one entry per day, oldest first, including days without any.
+type AdminStats struct {
+	Days           int          `json:"days"`
+	ActiveSessions int64        `json:"active_sessions"`
+	Registrations  []DailyCount `json:"registrations"`
+	FailedLogins   []DailyCount `json:"failed_logins"`
+	// RefreshedAt is when the daily series were last computed.
+	RefreshedAt *time.Time `json:"refreshed_at"`
+}
+
+type DailyCount struct {
+	Day   string `json:"day"`
+	Count int64  `json:"count"`
+}
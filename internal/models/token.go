@@ -1,5 +1,81 @@
 package models
 
+import (
+	"time"
+
+	"ewallet-ums/internal/pagination"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RefreshTokenResponse carries the new token pair with their expiries, so
+// clients can refresh before the access token runs out.
 type RefreshTokenResponse struct {
-	Token string `json:"token"`
+	Token                 string    `json:"token"`
+	TokenExpiresAt        time.Time `json:"token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	RequestSigningKey     string    `json:"request_signing_key"`
+}
+
+type TokenValidationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Audience string `json:"audience" validate:"omitempty,max=100"`
+}
+
+func (l TokenValidationRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type TokenValidationResponse struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	FullName string `json:"full_name"`
+
+	ProfileCompleteness int    `json:"profile_completeness"`
+	Guest               bool   `json:"guest"`
+	DeviceID            string `json:"device_id,omitempty"`
+
+	Entitlements []string `json:"entitlements,omitempty"`
+}
+
+// RevokedToken is an entry of the revocation list. Key is the token's hash,
+// or jti:<jti> for tokens revoked by jti alone. The entry expires with the
+// token.
+type RevokedToken struct {
+	Key       string    `json:"key"`
+	TokenID   string    `json:"token_id,omitempty"`
+	UserID    int       `json:"user_id,omitempty"`
+	RevokedAt time.Time `json:"revoked_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RevokedTokenListRequest pages through the revocation list with a redis
+// scan cursor, so pages are roughly Limit entries and unordered.
+type RevokedTokenListRequest struct {
+	Cursor uint64 `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+func (l RevokedTokenListRequest) GetLimit() int {
+	if l.Limit <= 0 {
+		return pagination.DefaultLimit
+	}
+	return min(l.Limit, pagination.MaxLimit)
+}
+
+// RevokeTokensRequest revokes the session of one token, by its jti, or all
+// of a user's. A jti no session holds, like a delegated token's, is only
+// listed when TTLSeconds says how long the token can live.
+type RevokeTokensRequest struct {
+	TokenID    string `json:"token_id" validate:"required_without=UserID,excluded_with=UserID,max=64"`
+	UserID     int    `json:"user_id" validate:"required_without=TokenID,omitempty,min=1"`
+	Reason     string `json:"reason" validate:"required,max=500"`
+	TTLSeconds int    `json:"ttl_seconds" validate:"omitempty,min=1,max=2592000,excluded_with=UserID"`
+}
+
+func (l RevokeTokensRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
 }
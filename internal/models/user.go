@@ -1,42 +1,128 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// ErrAmbiguousIdentifier is returned when an identifier that isn't a
+// username matches one user's email and another's phone number.
+var ErrAmbiguousIdentifier = errors.New("identifier matches more than one user")
+
 type User struct {
-	ID          int       `json:"id"`
-	Username    string    `json:"username" gorm:"column:username;type:varchar(20)" validate:"required"`
-	Email       string    `json:"email" gorm:"column:email;type:varchar(100)" validate:"required"`
-	PhoneNumber string    `json:"phone_number" gorm:"columne:phone_number;type:varchar(15)" validate:"required"`
-	FullName    string    `json:"full_name" gorm:"column:full_name;type:varchar(100)" validate:"required"`
-	Address     string    `json:"address" gorm:"column:address;type:text"`
-	Dob         string    `json:"dob" gorm:"column:dob;type:date"`
-	Password    string    `json:"password,omitempty" gorm:"column:password;type:varchar(255)" validate:"required"`
-	CreatedAt   time.Time `json:"-"`
-	UpdatedAt   time.Time `json:"-"`
+	ID          int     `json:"id"`
+	Username    string  `json:"username" gorm:"column:username;type:varchar(20)" validate:"required"`
+	Email       string  `json:"email" gorm:"column:email;type:varchar(255);serializer:pii" validate:"required_without=PhoneNumber"`
+	PhoneNumber string  `json:"phone_number" gorm:"column:phone_number;type:varchar(128);serializer:pii" validate:"required_without=Email"`
+	EmailLookup *string `json:"-" gorm:"column:email_lookup;type:char(64);uniqueIndex:idx_users_email_lookup"`
+	PhoneLookup *string `json:"-" gorm:"column:phone_lookup;type:char(64);uniqueIndex:idx_users_phone_lookup"`
+	FullName    string  `json:"full_name" gorm:"column:full_name;type:varchar(100)"`
+	Address     string  `json:"address" gorm:"column:address;type:text"`
+	Dob         string  `json:"dob" gorm:"column:dob;type:date"`
+	Password    string  `json:"password,omitempty" gorm:"column:password;type:varchar(255)" validate:"required"`
+	Status      string  `json:"-" gorm:"column:status;type:varchar(20);default:active"`
+	Type        string  `json:"-" gorm:"column:type;type:varchar(20);default:human"`
+	ExternalID  *string `json:"-" gorm:"column:external_id;type:varchar(64);uniqueIndex"`
+	KycStatus   string  `json:"-" gorm:"column:kyc_status;type:varchar(20);default:unverified;index"`
+	// Locale picks the language of the user's emails, empty for the
+	// MAIL_DEFAULT_LOCALE.
+	Locale string `json:"locale" gorm:"column:locale;type:varchar(10)"`
+	// Version is bumped by every update, profile and status updates only
+	// apply while it still has the value the caller read.
+	Version int `json:"version" gorm:"column:version;not null;default:1"`
+	// PasswordChangeRequired users must send a new_password at their next
+	// login, e.g. admins created with a generated password.
+	PasswordChangeRequired bool `json:"-" gorm:"column:password_change_required;default:false"`
+	// DeletionScheduledAt is when a pending_deletion account is deleted,
+	// unless the user logs in and cancels before then.
+	DeletionRequestedAt *time.Time `json:"-"`
+	DeletionScheduledAt *time.Time `json:"-" gorm:"index"`
+	AnonymizedAt        *time.Time `json:"-"`
+	// ClientID is the client the user registered through, its brand is used
+	// in the user's emails.
+	ClientID string `json:"client_id,omitempty" gorm:"column:client_id;type:varchar(100)" validate:"max=100"`
+	// Attribution is where the user signed up from, kept for marketing
+	// analytics and never returned.
+	Attribution RegistrationAttribution `json:"-" gorm:"embedded;embeddedPrefix:signup_"`
+	AuditColumns
+
+	// ProfileCompleteness is filled from CalculateProfileCompleteness when a
+	// profile is returned, it isn't stored.
+	ProfileCompleteness int `json:"profile_completeness" gorm:"-"`
 }
 
 func (*User) TableName() string {
 	return "users"
 }
 
+// CalculateProfileCompleteness returns the percentage of profile fields
+// filled in. Users may register with only an email or phone number and
+// complete the rest later, the wallet gates features on this value.
+func (l User) CalculateProfileCompleteness() int {
+	fields := []string{l.Email, l.PhoneNumber, l.FullName, l.Dob, l.Address}
+
+	filled := 0
+	for _, field := range fields {
+		if field != "" {
+			filled++
+		}
+	}
+	return filled * 100 / len(fields)
+}
+
 func (l User) Validate() error {
 	v := validator.New()
 	return v.Struct(l)
 }
 
+// RegistrationAttribution is the campaign the client landed from, read from
+// the UTM parameters of its landing page, and the client's platform.
+type RegistrationAttribution struct {
+	Source   string `json:"utm_source" gorm:"type:varchar(100)" validate:"max=100"`
+	Medium   string `json:"utm_medium" gorm:"type:varchar(100)" validate:"max=100"`
+	Campaign string `json:"utm_campaign" gorm:"type:varchar(100)" validate:"max=100"`
+	Term     string `json:"utm_term" gorm:"type:varchar(100)" validate:"max=100"`
+	Content  string `json:"utm_content" gorm:"type:varchar(100)" validate:"max=100"`
+	Platform string `json:"platform" gorm:"type:varchar(20);index" validate:"omitempty,oneof=ios android web"`
+}
+
+// RegisterRequest is a new user with the optional attribution of the
+// registration.
+type RegisterRequest struct {
+	User
+	Attribution RegistrationAttribution `json:"attribution"`
+}
+
+func (l RegisterRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+type RegisterResponse struct {
+	User
+	WalletStatus string `json:"wallet_status"`
+}
+
 type UserSession struct {
-	ID                  int `gorm:"primarykey"`
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
-	UserID              int       `json:"user_id" gorm:"type:int" validate:"required"`
-	Token               string    `json:"token" gorm:"type:varchar(500)" validate:"required"`
-	RefreshToken        string    `json:"refresh_token" gorm:"type:varchar(500)" validate:"required"`
-	TokenExpired        time.Time `json:"-" validate:"required"`
-	RefreshTokenExpired time.Time `json:"-" validate:"required"`
+	ID                  int       `gorm:"primarykey"`
+	UserID              int       `json:"user_id" gorm:"type:int;index:idx_user_sessions_user_id_token_expired,priority:1" validate:"required"`
+	Token               string    `json:"token" gorm:"type:varchar(700);index:idx_user_sessions_token" validate:"required"`
+	RefreshToken        string    `json:"refresh_token" gorm:"type:varchar(700);index:idx_user_sessions_refresh_token" validate:"required"`
+	TokenExpired        time.Time `json:"-" gorm:"index:idx_user_sessions_user_id_token_expired,priority:2" validate:"required"`
+	RefreshTokenExpired time.Time `json:"-" gorm:"index:idx_user_sessions_refresh_token_expired" validate:"required"`
+	// FamilyID links the session to its refresh tokens, see RefreshToken.
+	FamilyID string `json:"-" gorm:"type:varchar(32);index"`
+	// TokenID is the jti of Token.
+	TokenID string `json:"-" gorm:"type:varchar(32);index"`
+	// IPAddress and UserAgent are where the session was started from,
+	// Country and City are located from the IP address when known.
+	IPAddress string `json:"-" gorm:"type:varchar(45)"`
+	UserAgent string `json:"-" gorm:"type:varchar(255)"`
+	Country   string `json:"-" gorm:"type:varchar(2)"`
+	City      string `json:"-" gorm:"type:varchar(100)"`
+	AuditColumns
 }
 
 func (*UserSession) TableName() string {
@@ -47,3 +133,14 @@ func (l UserSession) Validate() error {
 	v := validator.New()
 	return v.Struct(l)
 }
+
+type SessionItem struct {
+	ID                  int       `json:"id"`
+	CreatedAt           time.Time `json:"created_at"`
+	TokenExpired        time.Time `json:"token_expired"`
+	RefreshTokenExpired time.Time `json:"refresh_token_expired"`
+	IPAddress           string    `json:"ip_address,omitempty"`
+	UserAgent           string    `json:"user_agent,omitempty"`
+	Country             string    `json:"country,omitempty"`
+	City                string    `json:"city,omitempty"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserExport is a back-office report of users, built by the worker and
+// uploaded to object storage. IncludePII is fixed from the requester's roles
+// when the export is requested.
+type UserExport struct {
+	ID          int        `json:"id" gorm:"primarykey"`
+	Format      string     `json:"format" gorm:"type:varchar(10)"`
+	Status      string     `json:"status" gorm:"type:varchar(20);index"`
+	UserStatus  string     `json:"user_status,omitempty" gorm:"type:varchar(20)"`
+	KycStatus   string     `json:"kyc_status,omitempty" gorm:"type:varchar(20)"`
+	CreatedFrom *time.Time `json:"created_from,omitempty"`
+	CreatedTo   *time.Time `json:"created_to,omitempty"`
+	IncludePII  bool       `json:"include_pii"`
+	RequestedBy int        `json:"requested_by" gorm:"type:int;index"`
+	RowCount    int        `json:"row_count" gorm:"type:int"`
+	ObjectKey   string     `json:"-" gorm:"type:varchar(255)"`
+	Error       string     `json:"error,omitempty" gorm:"type:text"`
+	ClaimedAt   *time.Time `json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+
+	// DownloadURL is signed on every read of a completed export.
+	DownloadURL string `json:"download_url,omitempty" gorm:"-"`
+}
+
+func (*UserExport) TableName() string {
+	return "user_exports"
+}
+
+type UserExportRequest struct {
+	Format      string     `json:"format" validate:"required,oneof=csv parquet"`
+	UserStatus  string     `json:"user_status" validate:"omitempty,oneof=active banned pending_deletion"`
+	KycStatus   string     `json:"kyc_status" validate:"omitempty,oneof=unverified pending verified rejected"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+}
+
+func (l UserExportRequest) Validate() error {
+	v := validator.New()
+	if err := v.Struct(l); err != nil {
+		return err
+	}
+	if l.CreatedFrom != nil && l.CreatedTo != nil && !l.CreatedTo.After(*l.CreatedFrom) {
+		return errors.New("created_to must be after created_from")
+	}
+	return nil
+}
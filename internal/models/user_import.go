@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserImport tracks a bulk import of users from a legacy system. The counters
+// are updated while the import runs so callers can poll for progress.
+type UserImport struct {
+	ID            int                  `json:"id" gorm:"primarykey"`
+	Format        string               `json:"format" gorm:"type:varchar(10)"`
+	DryRun        bool                 `json:"dry_run"`
+	Status        string               `json:"status" gorm:"type:varchar(20)"`
+	ProcessedRows int                  `json:"processed_rows" gorm:"type:int"`
+	CreatedRows   int                  `json:"created_rows" gorm:"type:int"`
+	SkippedRows   int                  `json:"skipped_rows" gorm:"type:int"`
+	FailedRows    int                  `json:"failed_rows" gorm:"type:int"`
+	Errors        []UserImportRowError `json:"errors" gorm:"type:mediumtext;serializer:json"`
+	Actor         string               `json:"actor" gorm:"type:varchar(100)"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+	FinishedAt    *time.Time           `json:"finished_at,omitempty"`
+}
+
+func (*UserImport) TableName() string {
+	return "user_imports"
+}
+
+// UserImportRow is one user in a CSV (columns named like the json tags) or
+// NDJSON import. Legacy bcrypt hashes are taken as is through PasswordHash,
+// otherwise Password is hashed like at registration.
+type UserImportRow struct {
+	ExternalID   string `json:"external_id" validate:"required,max=64"`
+	Username     string `json:"username" validate:"required,max=20"`
+	Email        string `json:"email" validate:"required"`
+	PhoneNumber  string `json:"phone_number" validate:"required"`
+	FullName     string `json:"full_name" validate:"required,max=100"`
+	Address      string `json:"address"`
+	Dob          string `json:"dob" validate:"omitempty,datetime=2006-01-02"`
+	Password     string `json:"password" validate:"required_without=PasswordHash"`
+	PasswordHash string `json:"password_hash" validate:"required_without=Password"`
+}
+
+func (l UserImportRow) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
+
+// UserImportRowError reports why a row was rejected. Row is 1-based and
+// counts data rows only, not the CSV header.
+type UserImportRowError struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Error      string `json:"error"`
+}
+
+// UserImportRequest is read from the query string, the file itself is the
+// request body. Format defaults from the Content-Type header.
+type UserImportRequest struct {
+	Format string `form:"format" validate:"required,oneof=csv ndjson"`
+	DryRun bool   `form:"dry_run"`
+}
+
+func (l UserImportRequest) Validate() error {
+	v := validator.New()
+	return v.Struct(l)
+}
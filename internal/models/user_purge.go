@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// UserPurge is a hard delete of a user, queued once a purge_user approval is
+// approved and executed by the user_purge job. It outlives the user so the
+// purge can still be traced to its approval.
+type UserPurge struct {
+	ID         int        `json:"id" gorm:"primarykey"`
+	ApprovalID int        `json:"approval_id" gorm:"type:int;uniqueIndex"`
+	UserID     int        `json:"user_id" gorm:"type:int;index"`
+	Status     string     `json:"status" gorm:"type:varchar(20);index"`
+	Attempts   int        `json:"attempts" gorm:"type:int"`
+	LastError  string     `json:"last_error,omitempty" gorm:"type:text"`
+	PurgedAt   *time.Time `json:"purged_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (*UserPurge) TableName() string {
+	return "user_purges"
+}
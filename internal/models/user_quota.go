@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// QuotaUsage is how much of a daily quota a user used so far. The quota
+// resets at ResetAt, the next UTC midnight.
+type QuotaUsage struct {
+	Name      string    `json:"name"`
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+type WalletProvisioning struct {
+	ID            int       `json:"-" gorm:"primarykey"`
+	UserID        int       `json:"user_id" gorm:"type:int;uniqueIndex"`
+	Status        string    `json:"wallet_status" gorm:"type:varchar(20);index:idx_wallet_provisionings_status_next_attempt_at,priority:1"`
+	WalletID      int       `json:"wallet_id,omitempty" gorm:"type:int"`
+	Attempts      int       `json:"attempts" gorm:"type:int"`
+	LastError     string    `json:"-" gorm:"type:text"`
+	NextAttemptAt time.Time `json:"-" gorm:"index:idx_wallet_provisionings_status_next_attempt_at,priority:2"`
+	CreatedAt     time.Time `json:"-"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (*WalletProvisioning) TableName() string {
+	return "wallet_provisionings"
+}
+
+// WalletReconciliationItem is a wallet provisioning that failed or is still
+// pending past its SLA.
+type WalletReconciliationItem struct {
+	UserID    int       `json:"user_id"`
+	Status    string    `json:"wallet_status"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WalletReconciliationReport struct {
+	Failed         int64                      `json:"failed"`
+	PendingPastSLA int64                      `json:"pending_past_sla"`
+	Items          []WalletReconciliationItem `json:"items"`
+	NextCursor     string                     `json:"next_cursor,omitempty"`
+}
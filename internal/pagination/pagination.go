@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+type Request struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+// Cursor points at the last row of the previous page. Rows are ordered by
+// (created_at, id) descending so the id breaks ties between equal timestamps.
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int       `json:"id"`
+}
+
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func (r Request) GetLimit() int {
+	if r.Limit <= 0 {
+		return DefaultLimit
+	}
+	return min(r.Limit, MaxLimit)
+}
+
+func (r Request) GetCursor() (*Cursor, error) {
+	if r.Cursor == "" {
+		return nil, nil
+	}
+	return DecodeCursor(r.Cursor)
+}
+
+func EncodeCursor(cursor Cursor) string {
+	payload, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cursor: %v", err)
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(payload, cursor); err != nil {
+		return nil, fmt.Errorf("failed to parse cursor: %v", err)
+	}
+	return cursor, nil
+}
+
+// Apply adds the keyset condition and ordering to the query. It fetches one
+// extra row so NewPage can tell whether another page exists.
+func Apply(db *gorm.DB, cursor *Cursor, limit int) *gorm.DB {
+	if cursor != nil {
+		db = db.Where("created_at < ? OR (created_at = ? AND id < ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+	return db.Order("created_at DESC").Order("id DESC").Limit(limit + 1)
+}
+
+func NewPage[T any](items []T, limit int, cursorOf func(T) Cursor) Page[T] {
+	page := Page[T]{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = EncodeCursor(cursorOf(page.Items[limit-1]))
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return page
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AccountDeletionRepository struct {
+	DB *gorm.DB
+}
+
+// ScheduleUserDeletion marks an active user pending deletion while they are
+// still at version. False means the user changed or isn't active.
+func (r *AccountDeletionRepository) ScheduleUserDeletion(ctx context.Context, userID, version int, requestedAt, scheduledAt time.Time) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND version = ? AND status = ?", userID, version, constants.UserStatusActive).
+		Updates(map[string]any{
+			"status":                constants.UserStatusPendingDeletion,
+			"deletion_requested_at": requestedAt,
+			"deletion_scheduled_at": scheduledAt,
+			"version":               gorm.Expr("version + 1"),
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// CancelUserDeletion makes a pending_deletion user active again. False means
+// the user wasn't pending deletion.
+func (r *AccountDeletionRepository) CancelUserDeletion(ctx context.Context, userID int) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND status = ?", userID, constants.UserStatusPendingDeletion).
+		Updates(map[string]any{
+			"status":                constants.UserStatusActive,
+			"deletion_requested_at": nil,
+			"deletion_scheduled_at": nil,
+			"version":               gorm.Expr("version + 1"),
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetUsersDueForDeletion returns pending_deletion users whose window ended
+// by now, except users under legal hold.
+func (r *AccountDeletionRepository) GetUsersDueForDeletion(ctx context.Context, now time.Time, limit int) ([]models.User, error) {
+	users := []models.User{}
+	err := r.DB.WithContext(ctx).Select("id", "deletion_requested_at").
+		Where("status = ? AND deletion_scheduled_at <= ? AND id NOT IN ("+activeLegalHolds+")", constants.UserStatusPendingDeletion, now).
+		Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// DeleteUser soft-deletes the user if their deletion is still due and they
+// aren't under legal hold, so a cancellation or hold racing the scheduler
+// wins. Anonymization picks the user up
+// after its grace period.
+func (r *AccountDeletionRepository) DeleteUser(ctx context.Context, userID int, now time.Time) (bool, error) {
+	result := r.DB.WithContext(ctx).
+		Where("id = ? AND status = ? AND deletion_scheduled_at <= ? AND id NOT IN ("+activeLegalHolds+")", userID, constants.UserStatusPendingDeletion, now).
+		Delete(&models.User{})
+	return result.RowsAffected == 1, result.Error
+}
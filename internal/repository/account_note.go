@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+type AccountNoteRepository struct {
+	DB *gorm.DB
+}
+
+func (r *AccountNoteRepository) InsertAccountNote(ctx context.Context, note *models.AccountNote) error {
+	return r.DB.WithContext(ctx).Create(note).Error
+}
+
+func (r *AccountNoteRepository) GetAccountNoteByID(ctx context.Context, noteID int) (models.AccountNote, error) {
+	note := models.AccountNote{}
+	err := r.DB.WithContext(ctx).Where("id = ?", noteID).First(&note).Error
+	return note, err
+}
+
+// GetAccountNotes lists the user's notes of the given visibilities, newest
+// first.
+func (r *AccountNoteRepository) GetAccountNotes(ctx context.Context, userID int, visibilities []string, cursor *pagination.Cursor, limit int) ([]models.AccountNote, error) {
+	notes := []models.AccountNote{}
+	db := r.DB.WithContext(ctx).Where("user_id = ? AND visibility IN ?", userID, visibilities)
+	err := pagination.Apply(db, cursor, limit).Find(&notes).Error
+	return notes, err
+}
+
+func (r *AccountNoteRepository) UpdateAccountNote(ctx context.Context, note *models.AccountNote) error {
+	return r.DB.WithContext(ctx).Model(note).Select("visibility", "body").Updates(note).Error
+}
+
+func (r *AccountNoteRepository) DeleteAccountNote(ctx context.Context, noteID int) error {
+	return r.DB.WithContext(ctx).Where("id = ?", noteID).Delete(&models.AccountNote{}).Error
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+type AccountRecoveryRepository struct {
+	DB *gorm.DB
+}
+
+func (r *AccountRecoveryRepository) InsertRecoveryTicket(ctx context.Context, ticket *models.RecoveryTicket) error {
+	return r.DB.WithContext(ctx).Create(ticket).Error
+}
+
+func (r *AccountRecoveryRepository) GetRecoveryTicketByID(ctx context.Context, ticketID int) (models.RecoveryTicket, error) {
+	ticket := models.RecoveryTicket{}
+	err := r.DB.WithContext(ctx).Where("id = ?", ticketID).First(&ticket).Error
+	return ticket, err
+}
+
+func (r *AccountRecoveryRepository) GetRecoveryTicketByResetToken(ctx context.Context, tokenHash string) (models.RecoveryTicket, error) {
+	ticket := models.RecoveryTicket{}
+	err := r.DB.WithContext(ctx).Where("reset_token_hash = ?", tokenHash).First(&ticket).Error
+	return ticket, err
+}
+
+func (r *AccountRecoveryRepository) GetRecoveryTickets(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.RecoveryTicket, error) {
+	tickets := []models.RecoveryTicket{}
+
+	query := r.DB.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := pagination.Apply(query, cursor, limit).Find(&tickets).Error
+	return tickets, err
+}
+
+// UpdateRecoveryTicketStatus moves the ticket on only while it still has
+// fromStatus, so a ticket is rejected, approved or redeemed at most once. It
+// reports whether this call won.
+func (r *AccountRecoveryRepository) UpdateRecoveryTicketStatus(ctx context.Context, ticket *models.RecoveryTicket, fromStatus string) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.RecoveryTicket{}).
+		Where("id = ? AND status = ?", ticket.ID, fromStatus).
+		Updates(map[string]any{
+			"status":           ticket.Status,
+			"reviewed_by":      ticket.ReviewedBy,
+			"review_note":      ticket.ReviewNote,
+			"reset_token_hash": ticket.ResetTokenHash,
+			"reset_expires_at": ticket.ResetExpiresAt,
+			"completed_at":     ticket.CompletedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// CompleteRecoveryReset spends the approved ticket's reset link and makes
+// its contact email the user's email with the new password, in one
+// transaction. Nothing changes unless the link is unspent and unexpired and
+// the user is still at version. It reports whether this call won.
+func (r *AccountRecoveryRepository) CompleteRecoveryReset(ctx context.Context, ticket *models.RecoveryTicket, version int, password string, now time.Time) (bool, error) {
+	var completed bool
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RecoveryTicket{}).
+			Where("id = ? AND status = ? AND reset_expires_at > ?", ticket.ID, constants.RecoveryTicketStatusApproved, now).
+			Updates(map[string]any{"status": constants.RecoveryTicketStatusCompleted, "completed_at": now})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		user := models.User{Email: ticket.ContactEmail, Password: password, Version: version + 1}
+		setUserLookups(&user)
+		result = tx.Model(&models.User{}).Where("id = ? AND version = ?", ticket.UserID, version).
+			Select("email", "email_lookup", "password", "password_change_required", "version").Updates(&user)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errUserChanged
+		}
+		completed = true
+		return nil
+	})
+	if errors.Is(err, errUserChanged) {
+		return false, nil
+	}
+	return completed, err
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// activeUsersRetention keeps an hour's set a little longer than the longest
+// window counted.
+const activeUsersRetention = 25 * time.Hour
+
+// ActiveUsersRepository tracks active users in one redis HyperLogLog per
+// hour, so counting them is a PFCOUNT over a few keys rather than a scan of
+// the login histories. Counts are estimates, within about 1%.
+type ActiveUsersRepository struct {
+	Redis *redis.Client
+}
+
+func (r *ActiveUsersRepository) RecordActiveUser(ctx context.Context, userID int, now time.Time) error {
+	key := activeUsersKey(now)
+	pipe := r.Redis.Pipeline()
+	pipe.PFAdd(ctx, key, strconv.Itoa(userID))
+	pipe.Expire(ctx, key, activeUsersRetention)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// CountActiveUsers counts the distinct users active in the current hour and
+// the hours before it, hours in total.
+func (r *ActiveUsersRepository) CountActiveUsers(ctx context.Context, now time.Time, hours int) (int64, error) {
+	keys := make([]string, 0, hours)
+	for i := range hours {
+		keys = append(keys, activeUsersKey(now.Add(-time.Duration(i)*time.Hour)))
+	}
+	return r.Redis.PFCount(ctx, keys...).Result()
+}
+
+func activeUsersKey(t time.Time) string {
+	return constants.ActiveUsersKeyPrefix + t.UTC().Format("2006010215")
+}
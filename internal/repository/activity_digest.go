@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ActivityDigestRepository struct {
+	DB *gorm.DB
+}
+
+// GetDigestRecipients returns the ids of opted-in users whose digest for
+// month hasn't been sent yet.
+func (r *ActivityDigestRepository) GetDigestRecipients(ctx context.Context, month string, afterUserID, limit int) ([]int, error) {
+	userIDs := []int{}
+	err := r.DB.WithContext(ctx).Model(&models.NotificationPreference{}).
+		Joins("JOIN users ON users.id = notification_preferences.user_id AND users.deleted_at IS NULL").
+		Joins("LEFT JOIN activity_digests ON activity_digests.user_id = notification_preferences.user_id AND activity_digests.month = ?", month).
+		Where("notification_preferences.event = ? AND notification_preferences.enabled = ?", constants.NotificationEventActivityDigest, true).
+		Where("activity_digests.id IS NULL AND notification_preferences.user_id > ?", afterUserID).
+		Order("notification_preferences.user_id").Limit(limit).
+		Pluck("notification_preferences.user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// ClaimActivityDigest records the digest as sent, it reports false when
+// another worker got there first.
+func (r *ActivityDigestRepository) ClaimActivityDigest(ctx context.Context, digest *models.ActivityDigest) (bool, error) {
+	result := r.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(digest)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *ActivityDigestRepository) DeleteActivityDigest(ctx context.Context, digest *models.ActivityDigest) error {
+	return r.DB.WithContext(ctx).Delete(digest).Error
+}
+
+func (r *ActivityDigestRepository) GetLoginHistoriesBetween(ctx context.Context, userID int, from, to time.Time) ([]models.LoginHistory, error) {
+	histories := []models.LoginHistory{}
+	err := r.DB.WithContext(ctx).Where("user_id = ? AND created_at >= ? AND created_at < ?", userID, from, to).
+		Order("created_at").Find(&histories).Error
+	return histories, err
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AddressRepository struct {
+	DB *gorm.DB
+}
+
+// GetUserAddresses lists the user's addresses, the primary one first.
+func (r *AddressRepository) GetUserAddresses(ctx context.Context, userID int) ([]models.UserAddress, error) {
+	addresses := []models.UserAddress{}
+	err := r.DB.WithContext(ctx).Where("user_id = ?", userID).Order("is_primary DESC, id").Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *AddressRepository) GetUserAddress(ctx context.Context, userID, id int) (models.UserAddress, error) {
+	address := models.UserAddress{}
+	err := r.DB.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&address).Error
+	return address, err
+}
+
+// InsertUserAddress makes the address primary when it asks to be or is the
+// user's first, taking the flag off the others.
+func (r *AddressRepository) InsertUserAddress(ctx context.Context, address *models.UserAddress) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.UserAddress{}).Where("user_id = ?", address.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			address.IsPrimary = true
+		} else if address.IsPrimary {
+			if err := clearPrimaryAddress(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Create(address).Error
+	})
+}
+
+// UpdateUserAddress replaces everything but the primary flag and reports
+// whether the user has the address.
+func (r *AddressRepository) UpdateUserAddress(ctx context.Context, address *models.UserAddress) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(address).Where("user_id = ?", address.UserID).
+		Select("type", "line1", "line2", "city", "region", "postal_code", "country", "updated_at").Updates(address)
+	return result.RowsAffected == 1, result.Error
+}
+
+// SetPrimaryUserAddress reports whether the user has the address.
+func (r *AddressRepository) SetPrimaryUserAddress(ctx context.Context, userID, id int) (bool, error) {
+	var found bool
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		address := models.UserAddress{}
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&address).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		found = true
+		if err := clearPrimaryAddress(tx, userID); err != nil {
+			return err
+		}
+		return tx.Model(&address).Update("is_primary", true).Error
+	})
+	return found, err
+}
+
+// DeleteUserAddress reports whether the user had the address. Removing the
+// primary address makes the oldest remaining one primary.
+func (r *AddressRepository) DeleteUserAddress(ctx context.Context, userID, id int) (bool, error) {
+	var deleted bool
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		address := models.UserAddress{}
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&address).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err := tx.Delete(&address).Error; err != nil {
+			return err
+		}
+		deleted = true
+		if !address.IsPrimary {
+			return nil
+		}
+
+		next := models.UserAddress{}
+		err := tx.Where("user_id = ?", userID).Order("id").First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&next).Update("is_primary", true).Error
+	})
+	return deleted, err
+}
+
+func clearPrimaryAddress(tx *gorm.DB, userID int) error {
+	return tx.Model(&models.UserAddress{}).Where("user_id = ? AND is_primary = ?", userID, true).Update("is_primary", false).Error
+}
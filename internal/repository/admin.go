@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AdminRepository struct {
+	DB *gorm.DB
+}
+
+func (r *AdminRepository) GetUserRoles(ctx context.Context, userID int) ([]string, error) {
+	roles := []string{}
+	err := r.DB.WithContext(ctx).Model(&models.UserRole{}).Where("user_id = ?", userID).Pluck("role", &roles).Error
+	return roles, err
+}
+
+// InsertUserRole is a no-op when the user already has the role.
+func (r *AdminRepository) GetUsers(ctx context.Context, filter models.AdminUserFilter, cursor *pagination.Cursor, limit int) ([]models.User, error) {
+	users := []models.User{}
+
+	query := r.DB.WithContext(ctx)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.KycStatus != "" {
+		query = query.Where("kyc_status = ?", filter.KycStatus)
+	}
+	err := pagination.Apply(query, cursor, limit).Find(&users).Error
+	return users, err
+}
+
+func (r *AdminRepository) InsertUserRole(ctx context.Context, role *models.UserRole) error {
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(role).Error
+}
+
+// UpdateUserStatus bumps the user's version. A non-zero version makes the
+// update conditional like UserRepository.UpdateUser, false meaning the user
+// changed since it was read.
+func (r *AdminRepository) UpdateUserStatus(ctx context.Context, userID, version int, status string) (bool, error) {
+	query := r.DB.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	result := query.Updates(map[string]any{"status": status, "version": gorm.Expr("version + 1")})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *AdminRepository) InsertAdminApproval(ctx context.Context, approval *models.AdminApproval) error {
+	return r.DB.WithContext(ctx).Create(approval).Error
+}
+
+func (r *AdminRepository) GetAdminApprovalByID(ctx context.Context, approvalID int) (models.AdminApproval, error) {
+	approval := models.AdminApproval{}
+
+	if err := r.DB.WithContext(ctx).Where("id = ?", approvalID).First(&approval).Error; err != nil {
+		return approval, err
+	}
+
+	if approval.ID == 0 {
+		return approval, errors.New("admin approval not found")
+	}
+
+	return approval, nil
+}
+
+func (r *AdminRepository) GetAdminApprovals(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.AdminApproval, error) {
+	approvals := []models.AdminApproval{}
+
+	query := r.DB.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := pagination.Apply(query, cursor, limit).Find(&approvals).Error
+	return approvals, err
+}
+
+// ReviewAdminApproval records the review only while the approval is still
+// pending, so two admins reviewing at once can't both act on it. It reports
+// whether this call won.
+func (r *AdminRepository) ReviewAdminApproval(ctx context.Context, approval *models.AdminApproval) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.AdminApproval{}).
+		Where("id = ? AND status = ?", approval.ID, constants.ApprovalStatusPending).
+		Updates(map[string]any{
+			"status":      approval.Status,
+			"reviewed_by": approval.ReviewedBy,
+			"review_note": approval.ReviewNote,
+			"reviewed_at": approval.ReviewedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *AdminRepository) UpdateAdminApprovalStatus(ctx context.Context, approvalID int, status string) error {
+	return r.DB.WithContext(ctx).Model(&models.AdminApproval{}).Where("id = ?", approvalID).Update("status", status).Error
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AnonymizationRepository struct {
+	DB *gorm.DB
+}
+
+// GetUserIDsToAnonymize returns soft-deleted users past the grace period that
+// are not anonymized yet and not under legal hold.
+func (r *AnonymizationRepository) GetUserIDsToAnonymize(ctx context.Context, deletedBefore time.Time, limit int) ([]int, error) {
+	userIDs := []int{}
+	err := r.DB.WithContext(ctx).Table("users").
+		Where("deleted_at < ? AND anonymized_at IS NULL AND id NOT IN ("+activeLegalHolds+")", deletedBefore).
+		Order("id").Limit(limit).Pluck("id", &userIDs).Error
+	return userIDs, err
+}
+
+// AnonymizeUser overwrites the user's PII with placeholders derived from the
+// id, so the row and everything referencing it (wallet, transactions) stay
+// joinable but can't be traced back to a person. Sessions are dropped and
+// login history is stripped of network details. It reports false when the
+// user was already anonymized.
+func (r *AnonymizationRepository) AnonymizeUser(ctx context.Context, userID int, now time.Time) (bool, error) {
+	var anonymized bool
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		placeholder := fmt.Sprintf("deleted_%d", userID)
+
+		result := tx.Exec(`UPDATE users SET username = ?, email = ?, phone_number = '', email_lookup = NULL, phone_lookup = NULL, full_name = 'Deleted User',
+			address = '', dob = NULL, password = '', anonymized_at = ? WHERE id = ? AND anonymized_at IS NULL`,
+			placeholder, placeholder+"@anonymized.invalid", now, userID)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		anonymized = true
+
+		if err := tx.Exec("DELETE FROM user_sessions WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM security_questions WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM user_addresses WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM kyc_profiles WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM account_notes WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM trusted_devices WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM refresh_tokens WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM email_change_reverts WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE login_histories SET username = ?, ip_address = '', user_agent = '' WHERE user_id = ?", placeholder, userID).Error
+	})
+	return anonymized, err
+}
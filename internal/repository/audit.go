@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	DB *gorm.DB
+}
+
+// InsertAuditEvent stores the ticket reference of the request's context on
+// events that don't set their own.
+func (r *AuditRepository) InsertAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	if ref, ok := helpers.TicketRefFromContext(ctx); ok && event.TicketRef == "" {
+		event.TicketRef = ref
+	}
+	return r.DB.WithContext(ctx).Create(event).Error
+}
+
+func (r *AuditRepository) GetAuditEvents(ctx context.Context, filter models.AuditEventFilter, cursor *pagination.Cursor, limit int) ([]models.AuditEvent, error) {
+	events := []models.AuditEvent{}
+	err := pagination.Apply(r.filterAuditEvents(ctx, filter), cursor, limit).Find(&events).Error
+	return events, err
+}
+
+func (r *AuditRepository) CountAuditEvents(ctx context.Context, filter models.AuditEventFilter) (int64, error) {
+	var count int64
+	err := r.filterAuditEvents(ctx, filter).Model(&models.AuditEvent{}).Count(&count).Error
+	return count, err
+}
+
+func (r *AuditRepository) filterAuditEvents(ctx context.Context, filter models.AuditEventFilter) *gorm.DB {
+	query := r.DB.WithContext(ctx)
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.TargetUserID != 0 {
+		query = query.Where("target_user_id = ?", filter.TargetUserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TicketRef != "" {
+		query = query.Where("ticket_ref = ?", filter.TicketRef)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	return query
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"ewallet-ums/constants"
+
+	"gorm.io/gorm"
+)
+
+type BackupRepository struct {
+	DB *gorm.DB
+}
+
+// backupTables maps each table name of `backup export` to its table. Rows
+// are read as stored, soft-deleted ones and encrypted PII columns included.
+var backupTables = map[string]string{
+	constants.BackupTableUsers: "users",
+	constants.BackupTableAudit: "audit_events",
+}
+
+// DumpTable returns up to limit rows of table with an id above afterID, by
+// id, as column to value with text columns as strings.
+func (r *BackupRepository) DumpTable(ctx context.Context, table string, afterID int64, limit int) ([]map[string]any, error) {
+	name, ok := backupTables[table]
+	if !ok {
+		return nil, fmt.Errorf("unknown backup table %q", table)
+	}
+
+	rows := []map[string]any{}
+	err := r.DB.WithContext(ctx).Table(name).Where("id > ?", afterID).Order("id").Limit(limit).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		for column, value := range row {
+			if b, ok := value.([]byte); ok {
+				row[column] = string(b)
+			}
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/constants"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CallerGuardRepository keeps per-caller request and failure counters in
+// redis so limits and bans hold across every replica.
+type CallerGuardRepository struct {
+	Redis *redis.Client
+}
+
+// Allow counts the request in a fixed window and reports whether the caller
+// is still within limit.
+func (r *CallerGuardRepository) Allow(ctx context.Context, caller string, limit int, window time.Duration) (bool, error) {
+	count, err := r.incr(ctx, constants.CallerRateKeyPrefix+caller, window)
+	if err != nil {
+		return true, err
+	}
+	return count <= int64(limit), nil
+}
+
+func (r *CallerGuardRepository) RecordFailure(ctx context.Context, caller string, window time.Duration) (int64, error) {
+	return r.incr(ctx, constants.CallerFailureKeyPrefix+caller, window)
+}
+
+func (r *CallerGuardRepository) Ban(ctx context.Context, caller string, duration time.Duration) error {
+	pipe := r.Redis.TxPipeline()
+	pipe.Set(ctx, constants.CallerBanKeyPrefix+caller, 1, duration)
+	pipe.Del(ctx, constants.CallerFailureKeyPrefix+caller)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *CallerGuardRepository) IsBanned(ctx context.Context, caller string) (bool, error) {
+	count, err := r.Redis.Exists(ctx, constants.CallerBanKeyPrefix+caller).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// incr starts the window's expiry on the first hit only, so the window
+// doesn't slide forward with every request.
+func (r *CallerGuardRepository) incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	pipe := r.Redis.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ClientRepository struct {
+	DB *gorm.DB
+}
+
+func (r *ClientRepository) GetClientByClientID(ctx context.Context, clientID string) (models.Client, error) {
+	client := models.Client{}
+	err := r.DB.WithContext(ctx).Where("client_id = ?", clientID).First(&client).Error
+	return client, err
+}
+
+func (r *ClientRepository) UpsertClient(ctx context.Context, client *models.Client) error {
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "client_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "access_token_ttl_seconds", "refresh_token_ttl_seconds", "scopes", "claims", "audience", "brand_name", "mail_from", "support_url", "updated_at"}),
+	}).Create(client).Error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ConsentRepository struct {
+	DB *gorm.DB
+}
+
+func (r *ConsentRepository) InsertUserConsents(ctx context.Context, consents []models.UserConsent) error {
+	return r.DB.WithContext(ctx).Create(&consents).Error
+}
+
+func (r *ConsentRepository) GetLatestUserConsents(ctx context.Context, userID int) ([]models.UserConsent, error) {
+	consents := []models.UserConsent{}
+	latest := r.DB.WithContext(ctx).Model(&models.UserConsent{}).Select("MAX(id)").Where("user_id = ?", userID).Group("consent_type")
+	err := r.DB.WithContext(ctx).Where("id IN (?)", latest).Order("consent_type").Find(&consents).Error
+	return consents, err
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+type DeadLetterRepository struct {
+	DB *gorm.DB
+}
+
+func (r *DeadLetterRepository) InsertDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error {
+	return r.DB.WithContext(ctx).Create(deadLetter).Error
+}
+
+func (r *DeadLetterRepository) GetDeadLetterByID(ctx context.Context, deadLetterID int) (models.DeadLetter, error) {
+	deadLetter := models.DeadLetter{}
+	err := r.DB.WithContext(ctx).Where("id = ?", deadLetterID).First(&deadLetter).Error
+	return deadLetter, err
+}
+
+func (r *DeadLetterRepository) GetDeadLetters(ctx context.Context, status, topic string, cursor *pagination.Cursor, limit int) ([]models.DeadLetter, error) {
+	deadLetters := []models.DeadLetter{}
+
+	query := r.DB.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if topic != "" {
+		query = query.Where("topic = ?", topic)
+	}
+	err := pagination.Apply(query, cursor, limit).Find(&deadLetters).Error
+	return deadLetters, err
+}
+
+// UpdateDeadLetter saves the dead letter only while it still has fromStatus,
+// so it is replayed or discarded at most once. It reports whether this call
+// won.
+func (r *DeadLetterRepository) UpdateDeadLetter(ctx context.Context, deadLetter *models.DeadLetter, fromStatus string) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.DeadLetter{}).
+		Where("id = ? AND status = ?", deadLetter.ID, fromStatus).
+		Updates(map[string]any{
+			"payload":     string(deadLetter.Payload),
+			"error":       deadLetter.Error,
+			"replays":     deadLetter.Replays,
+			"status":      deadLetter.Status,
+			"resolved_by": deadLetter.ResolvedBy,
+			"note":        deadLetter.Note,
+			"resolved_at": deadLetter.ResolvedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"ewallet-ums/helpers"
+
+	"gorm.io/gorm"
+)
+
+// deleteLimited deletes at most limit rows of table matching where, args
+// being the where arguments followed by the limit. MySQL takes a LIMIT on
+// DELETE, SQLite builds usually don't, so there it goes through a rowid
+// subquery.
+func deleteLimited(db *gorm.DB, table, where string, args ...any) *gorm.DB {
+	if helpers.IsSQLite(db) {
+		return db.Exec("DELETE FROM "+table+" WHERE rowid IN (SELECT rowid FROM "+table+" WHERE "+where+" LIMIT ?)", args...)
+	}
+	return db.Exec("DELETE FROM "+table+" WHERE "+where+" LIMIT ?", args...)
+}
+
+// dayOf returns an expression for the day of the time column as YYYY-MM-DD.
+// SQLite converts times stored with an offset to UTC first, MySQL takes them
+// as stored.
+func dayOf(db *gorm.DB, column string) string {
+	if helpers.IsSQLite(db) {
+		return "strftime('%Y-%m-%d', " + column + ")"
+	}
+	return "DATE_FORMAT(" + column + ", '%Y-%m-%d')"
+}
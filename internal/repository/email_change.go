@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type EmailChangeRepository struct {
+	DB *gorm.DB
+}
+
+func (r *EmailChangeRepository) InsertEmailChangeRevert(ctx context.Context, revert *models.EmailChangeRevert) error {
+	return r.DB.WithContext(ctx).Create(revert).Error
+}
+
+func (r *EmailChangeRepository) GetEmailChangeRevertByToken(ctx context.Context, tokenHash string) (models.EmailChangeRevert, error) {
+	revert := models.EmailChangeRevert{}
+	err := r.DB.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&revert).Error
+	return revert, err
+}
+
+// RevertEmailChange spends the revert, expires the user's other unused
+// reverts so the links of later changes can't undo it, and restores the
+// previous email with the new password. Nothing changes unless the revert is
+// unused and unexpired and the user is still at version. It reports whether
+// this call won.
+func (r *EmailChangeRepository) RevertEmailChange(ctx context.Context, revert *models.EmailChangeRevert, version int, password string, now time.Time) (bool, error) {
+	var reverted bool
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.EmailChangeRevert{}).
+			Where("id = ? AND reverted_at IS NULL AND expires_at > ?", revert.ID, now).
+			Update("reverted_at", now)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Model(&models.EmailChangeRevert{}).
+			Where("user_id = ? AND id <> ? AND reverted_at IS NULL AND expires_at > ?", revert.UserID, revert.ID, now).
+			Update("expires_at", now).Error; err != nil {
+			return err
+		}
+
+		user := models.User{Email: revert.PreviousEmail, Password: password, Version: version + 1}
+		setUserLookups(&user)
+		result = tx.Model(&models.User{}).Where("id = ? AND version = ?", revert.UserID, version).
+			Select("email", "email_lookup", "password", "password_change_required", "version").Updates(&user)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errUserChanged
+		}
+		reverted = true
+		return nil
+	})
+	if errors.Is(err, errUserChanged) {
+		return false, nil
+	}
+	return reverted, err
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EntitlementRepository struct {
+	DB *gorm.DB
+}
+
+func (r *EntitlementRepository) GetUserEntitlements(ctx context.Context, userID int) ([]models.UserEntitlement, error) {
+	entitlements := []models.UserEntitlement{}
+	err := r.DB.WithContext(ctx).Where("user_id = ?", userID).Order("feature").Find(&entitlements).Error
+	return entitlements, err
+}
+
+// InsertUserEntitlement reports false, leaving the grant as it was, when the
+// user already has the feature.
+func (r *EntitlementRepository) InsertUserEntitlement(ctx context.Context, entitlement *models.UserEntitlement) (bool, error) {
+	result := r.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(entitlement)
+	return result.RowsAffected == 1, result.Error
+}
+
+// DeleteUserEntitlement reports whether the user had the feature.
+func (r *EntitlementRepository) DeleteUserEntitlement(ctx context.Context, userID int, feature string) (bool, error) {
+	result := r.DB.WithContext(ctx).Where("user_id = ? AND feature = ?", userID, feature).Delete(&models.UserEntitlement{})
+	return result.RowsAffected == 1, result.Error
+}
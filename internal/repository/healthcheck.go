@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type HealthcheckRepository struct {
+	DB    *gorm.DB
+	Redis *redis.Client
+}
+
+func (r *HealthcheckRepository) PingDB(ctx context.Context) error {
+	sqlDB, err := r.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (r *HealthcheckRepository) PingRedis(ctx context.Context) error {
+	return r.Redis.Ping(ctx).Err()
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InboxRepository struct {
+	DB *gorm.DB
+}
+
+// ClaimInboxEvent records event as being applied. An event seen before is
+// only claimed again when its claim is older than staleBefore and it wasn't
+// processed, i.e. the consumer applying it stopped. Otherwise it reports
+// false and fills event with the recorded one.
+func (r *InboxRepository) ClaimInboxEvent(ctx context.Context, event *models.InboxEvent, staleBefore time.Time) (bool, error) {
+	db := r.DB.WithContext(ctx)
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	if result.Error != nil || result.RowsAffected == 1 {
+		return result.RowsAffected == 1, result.Error
+	}
+
+	result = db.Model(&models.InboxEvent{}).
+		Where("topic = ? AND event_id = ? AND processed_at IS NULL AND claimed_at < ?", event.Topic, event.EventID, staleBefore).
+		Update("claimed_at", event.ClaimedAt)
+	if result.Error != nil || result.RowsAffected == 1 {
+		return result.RowsAffected == 1, result.Error
+	}
+
+	return false, db.Where("topic = ? AND event_id = ?", event.Topic, event.EventID).First(event).Error
+}
+
+func (r *InboxRepository) MarkInboxEventProcessed(ctx context.Context, event *models.InboxEvent, now time.Time) error {
+	return r.DB.WithContext(ctx).Model(&models.InboxEvent{}).
+		Where("topic = ? AND event_id = ?", event.Topic, event.EventID).
+		Update("processed_at", now).Error
+}
+
+// DeleteInboxEvent releases a claim, so the event is applied when delivered
+// again.
+func (r *InboxRepository) DeleteInboxEvent(ctx context.Context, event *models.InboxEvent) error {
+	return r.DB.WithContext(ctx).
+		Where("topic = ? AND event_id = ? AND processed_at IS NULL", event.Topic, event.EventID).
+		Delete(&models.InboxEvent{}).Error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type KycRepository struct {
+	DB *gorm.DB
+}
+
+// GetKycProfile returns the user's profile, with a zero ID when they have
+// none.
+func (r *KycRepository) GetKycProfile(ctx context.Context, userID int) (models.KycProfile, error) {
+	profile := models.KycProfile{}
+	err := r.DB.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&profile).Error
+	return profile, err
+}
+
+func (r *KycRepository) UpsertKycProfile(ctx context.Context, profile *models.KycProfile) error {
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"employment_status", "occupation", "employer_name", "source_of_funds", "source_of_funds_detail",
+			"next_of_kin_full_name", "next_of_kin_relationship", "next_of_kin_phone_number", "updated_at"}),
+	}).Create(profile).Error
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// activeLegalHolds selects the ids of users under an active legal hold, for
+// excluding them from purges.
+const activeLegalHolds = "SELECT user_id FROM legal_holds WHERE lifted_at IS NULL"
+
+type LegalHoldRepository struct {
+	DB *gorm.DB
+}
+
+func (r *LegalHoldRepository) InsertLegalHold(ctx context.Context, hold *models.LegalHold) error {
+	return r.DB.WithContext(ctx).Create(hold).Error
+}
+
+func (r *LegalHoldRepository) GetLegalHoldByID(ctx context.Context, holdID int) (models.LegalHold, error) {
+	hold := models.LegalHold{}
+
+	if err := r.DB.WithContext(ctx).Where("id = ?", holdID).First(&hold).Error; err != nil {
+		return hold, err
+	}
+
+	if hold.ID == 0 {
+		return hold, errors.New("legal hold not found")
+	}
+
+	return hold, nil
+}
+
+func (r *LegalHoldRepository) GetLegalHoldsByUserID(ctx context.Context, userID int) ([]models.LegalHold, error) {
+	holds := []models.LegalHold{}
+	err := r.DB.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&holds).Error
+	return holds, err
+}
+
+// LiftLegalHold lifts the hold unless it was already lifted, and reports
+// whether this call lifted it.
+func (r *LegalHoldRepository) LiftLegalHold(ctx context.Context, hold *models.LegalHold) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.LegalHold{}).
+		Where("id = ? AND lifted_at IS NULL", hold.ID).
+		Updates(map[string]any{
+			"lift_reason": hold.LiftReason,
+			"lifted_by":   hold.LiftedBy,
+			"lifted_at":   hold.LiftedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *LegalHoldRepository) HasActiveLegalHold(ctx context.Context, userID int) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.LegalHold{}).Where("user_id = ? AND lifted_at IS NULL", userID).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+type LoginHistoryRepository struct {
+	DB *gorm.DB
+}
+
+func (r *LoginHistoryRepository) InsertLoginHistory(ctx context.Context, history *models.LoginHistory) error {
+	return r.DB.WithContext(ctx).Create(history).Error
+}
+
+func (r *LoginHistoryRepository) GetLoginHistoriesByUserID(ctx context.Context, userID int, cursor *pagination.Cursor, limit int) ([]models.LoginHistory, error) {
+	histories := []models.LoginHistory{}
+	err := pagination.Apply(r.DB.WithContext(ctx).Where("user_id = ?", userID), cursor, limit).Find(&histories).Error
+	return histories, err
+}
+
+// HasSuccessfulLogin reports whether the user ever logged in successfully,
+// from userAgent when it is not empty.
+func (r *LoginHistoryRepository) HasSuccessfulLogin(ctx context.Context, userID int, userAgent string) (bool, error) {
+	query := r.DB.WithContext(ctx).Model(&models.LoginHistory{}).Where("user_id = ? AND success = ?", userID, true)
+	if userAgent != "" {
+		query = query.Where("user_agent = ?", userAgent)
+	}
+
+	var count int64
+	err := query.Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// HasSuccessfulLoginFromCountry reports whether the user logged in
+// successfully from country, or from any located country when it is empty.
+func (r *LoginHistoryRepository) HasSuccessfulLoginFromCountry(ctx context.Context, userID int, country string) (bool, error) {
+	query := r.DB.WithContext(ctx).Model(&models.LoginHistory{}).Where("user_id = ? AND success = ?", userID, true)
+	if country != "" {
+		query = query.Where("country = ?", country)
+	} else {
+		query = query.Where("country <> ''")
+	}
+
+	var count int64
+	err := query.Limit(1).Count(&count).Error
+	return count > 0, err
+}
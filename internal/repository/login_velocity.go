@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LoginVelocityRepository counts failed logins per source over a sliding
+// window in redis: each source has a sorted set of failure timestamps, and
+// a second sorted set indexes the sources by their latest failure.
+type LoginVelocityRepository struct {
+	Redis *redis.Client
+}
+
+// RecordLoginFailure adds a failure for source and returns how many it had
+// within window.
+func (r *LoginVelocityRepository) RecordLoginFailure(ctx context.Context, source string, now time.Time, window time.Duration) (int64, error) {
+	key := constants.LoginFailuresKeyPrefix + source
+	since := strconv.FormatInt(now.Add(-window).UnixMilli(), 10)
+
+	pipe := r.Redis.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixMilli()), Member: rand.Text()})
+	pipe.ZRemRangeByScore(ctx, key, "-inf", "("+since)
+	count := pipe.ZCard(ctx, key)
+	pipe.Expire(ctx, key, window)
+	pipe.ZAdd(ctx, constants.LoginFailureSourcesKey, redis.Z{Score: float64(now.UnixMilli()), Member: source})
+	pipe.ZRemRangeByScore(ctx, constants.LoginFailureSourcesKey, "-inf", "("+since)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
+
+// GetLoginFailureCounts returns the failures within window of every source
+// that failed recently.
+func (r *LoginVelocityRepository) GetLoginFailureCounts(ctx context.Context, now time.Time, window time.Duration) (map[string]int64, error) {
+	since := strconv.FormatInt(now.Add(-window).UnixMilli(), 10)
+
+	sources, err := r.Redis.ZRangeByScore(ctx, constants.LoginFailureSourcesKey, &redis.ZRangeBy{Min: since, Max: "+inf"}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.Redis.Pipeline()
+	counts := make([]*redis.IntCmd, len(sources))
+	for i, source := range sources {
+		counts[i] = pipe.ZCount(ctx, constants.LoginFailuresKeyPrefix+source, since, "+inf")
+	}
+	if len(sources) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[string]int64, len(sources))
+	for i, source := range sources {
+		if count := counts[i].Val(); count > 0 {
+			result[source] = count
+		}
+	}
+	return result, nil
+}
+
+// MarkLoginVelocityAlerted reports whether source wasn't alerted on within
+// window yet, so a breach is only alerted once.
+func (r *LoginVelocityRepository) MarkLoginVelocityAlerted(ctx context.Context, source string, window time.Duration) (bool, error) {
+	return r.Redis.SetNX(ctx, constants.LoginVelocityAlertKeyPrefix+source, 1, window).Result()
+}
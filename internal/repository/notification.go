@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	DB *gorm.DB
+}
+
+func (r *NotificationRepository) GetNotificationPreferences(ctx context.Context, userID int) ([]models.NotificationPreference, error) {
+	preferences := []models.NotificationPreference{}
+	err := r.DB.WithContext(ctx).Where("user_id = ?", userID).Order("event").Find(&preferences).Error
+	return preferences, err
+}
+
+func (r *NotificationRepository) UpsertNotificationPreferences(ctx context.Context, preferences []models.NotificationPreference) error {
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&preferences).Error
+}
+
+// GetQuietHours returns the user's quiet hours, with a zero ID when they
+// have none.
+func (r *NotificationRepository) GetQuietHours(ctx context.Context, userID int) (models.NotificationQuietHours, error) {
+	quietHours := models.NotificationQuietHours{}
+	err := r.DB.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&quietHours).Error
+	return quietHours, err
+}
+
+func (r *NotificationRepository) UpsertQuietHours(ctx context.Context, quietHours *models.NotificationQuietHours) error {
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"start", "end", "time_zone", "updated_at"}),
+	}).Create(quietHours).Error
+}
+
+func (r *NotificationRepository) DeleteQuietHours(ctx context.Context, userID int) error {
+	return r.DB.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.NotificationQuietHours{}).Error
+}
+
+func (r *NotificationRepository) InsertPendingNotification(ctx context.Context, notification *models.PendingNotification) error {
+	return r.DB.WithContext(ctx).Create(notification).Error
+}
+
+// GetDuePendingNotificationUsers returns the ids of users with a pending
+// notification due by now.
+func (r *NotificationRepository) GetDuePendingNotificationUsers(ctx context.Context, now time.Time, afterUserID, limit int) ([]int, error) {
+	userIDs := []int{}
+	err := r.DB.WithContext(ctx).Model(&models.PendingNotification{}).
+		Where("send_after <= ? AND user_id > ?", now, afterUserID).
+		Distinct("user_id").Order("user_id").Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *NotificationRepository) GetPendingNotifications(ctx context.Context, userID int) ([]models.PendingNotification, error) {
+	notifications := []models.PendingNotification{}
+	err := r.DB.WithContext(ctx).Where("user_id = ?", userID).Order("occurred_at, id").Find(&notifications).Error
+	return notifications, err
+}
+
+// DeferPendingNotifications holds the user's pending notifications until.
+func (r *NotificationRepository) DeferPendingNotifications(ctx context.Context, userID int, until time.Time) error {
+	return r.DB.WithContext(ctx).Model(&models.PendingNotification{}).
+		Where("user_id = ? AND send_after < ?", userID, until).
+		Update("send_after", until).Error
+}
+
+func (r *NotificationRepository) DeletePendingNotifications(ctx context.Context, ids []int) error {
+	return r.DB.WithContext(ctx).Where("id IN ?", ids).Delete(&models.PendingNotification{}).Error
+}
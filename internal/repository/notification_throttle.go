@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NotificationThrottleRepository limits how often a user gets the same
+// security email, with one redis key per user and event.
+type NotificationThrottleRepository struct {
+	Redis *redis.Client
+}
+
+// ClaimNotification reports whether the user wasn't sent event within
+// window yet, and if so counts this one as sent.
+func (r *NotificationThrottleRepository) ClaimNotification(ctx context.Context, userID int, event string, window time.Duration) (bool, error) {
+	key := constants.NotificationThrottleKeyPrefix + strconv.Itoa(userID) + ":" + event
+	return r.Redis.SetNX(ctx, key, 1, window).Result()
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ewallet-ums/constants"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// OTPRepository keeps one-time codes in redis, by purpose and the phone
+// number's lookup hash. Only a code's hash is stored, with the failed
+// attempts at it.
+type OTPRepository struct {
+	Redis *redis.Client
+}
+
+func otpKey(purpose, phoneLookup string) string {
+	return constants.OTPKeyPrefix + purpose + ":" + phoneLookup
+}
+
+// MarkOTPSent reports whether a code may be sent, at most one per interval
+// for a phone number whatever the purpose.
+func (r *OTPRepository) MarkOTPSent(ctx context.Context, phoneLookup string, interval time.Duration) (bool, error) {
+	return r.Redis.SetNX(ctx, constants.OTPResendKeyPrefix+phoneLookup, 1, interval).Result()
+}
+
+// SaveOTP replaces any earlier code of the purpose.
+func (r *OTPRepository) SaveOTP(ctx context.Context, purpose, phoneLookup, codeHash string, ttl time.Duration) error {
+	key := otpKey(purpose, phoneLookup)
+	pipe := r.Redis.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, "code", codeHash, "attempts", 0)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// AttemptOTP counts an attempt at the code and returns its hash with the
+// attempts so far, or an empty hash when there is no code.
+func (r *OTPRepository) AttemptOTP(ctx context.Context, purpose, phoneLookup string) (string, int, error) {
+	key := otpKey(purpose, phoneLookup)
+	pipe := r.Redis.TxPipeline()
+	code := pipe.HGet(ctx, key, "code")
+	attempts := pipe.HIncrBy(ctx, key, "attempts", 1)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return "", 0, err
+	}
+
+	if code.Val() == "" {
+		// the increment created the key, don't leave it behind
+		if err := r.Redis.Del(ctx, key).Err(); err != nil {
+			return "", 0, err
+		}
+		return "", 0, nil
+	}
+	return code.Val(), int(attempts.Val()), nil
+}
+
+func (r *OTPRepository) DeleteOTP(ctx context.Context, purpose, phoneLookup string) error {
+	return r.Redis.Del(ctx, otpKey(purpose, phoneLookup)).Err()
+}
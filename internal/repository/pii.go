@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PIIRepository struct {
+	DB *gorm.DB
+}
+
+// GetUsersWithStalePII returns users whose PII columns are plaintext or
+// encrypted under a data key other than the active one, or whose email or
+// phone number has no lookup yet.
+func (r *PIIRepository) GetUsersWithStalePII(ctx context.Context, activePrefix string, afterID, limit int) ([]models.User, error) {
+	users := []models.User{}
+	err := r.DB.WithContext(ctx).Where("id > ? AND ((email <> '' AND (email NOT LIKE ? OR email_lookup IS NULL)) OR (phone_number <> '' AND (phone_number NOT LIKE ? OR phone_lookup IS NULL)))", afterID, activePrefix+"%", activePrefix+"%").
+		Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// GetUsersAfter returns every user after afterID, for recomputing the
+// lookups of rows whose PII is already under the active data key.
+func (r *PIIRepository) GetUsersAfter(ctx context.Context, afterID, limit int) ([]models.User, error) {
+	users := []models.User{}
+	err := r.DB.WithContext(ctx).Where("id > ?", afterID).Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// ReencryptUserPII writes the PII columns and their lookups back through
+// the serializer, without touching updated_at.
+func (r *PIIRepository) ReencryptUserPII(ctx context.Context, user *models.User) error {
+	setUserLookups(user)
+	return r.DB.WithContext(ctx).Model(user).Select("email", "phone_number", "email_lookup", "phone_lookup").UpdateColumns(user).Error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+	DB *gorm.DB
+}
+
+// StartRefreshTokenFamily stores root as the first token of a new family and
+// links the session to it.
+func (r *RefreshTokenRepository) StartRefreshTokenFamily(ctx context.Context, sessionID int, root *models.RefreshToken) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(root).Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE user_sessions SET family_id = ? WHERE id = ?", root.FamilyID, sessionID).Error
+	})
+}
+
+// GetRefreshTokenByHash returns a zero RefreshToken for unknown tokens.
+func (r *RefreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	tokens := []models.RefreshToken{}
+	err := r.DB.WithContext(ctx).Where("token_hash = ?", tokenHash).Limit(1).Find(&tokens).Error
+	if err != nil || len(tokens) == 0 {
+		return models.RefreshToken{}, err
+	}
+	return tokens[0], nil
+}
+
+// GetRefreshTokenByTokenID returns a zero RefreshToken for unknown jtis.
+func (r *RefreshTokenRepository) GetRefreshTokenByTokenID(ctx context.Context, tokenID string) (models.RefreshToken, error) {
+	tokens := []models.RefreshToken{}
+	err := r.DB.WithContext(ctx).Where("token_id = ?", tokenID).Limit(1).Find(&tokens).Error
+	if err != nil || len(tokens) == 0 {
+		return models.RefreshToken{}, err
+	}
+	return tokens[0], nil
+}
+
+func (r *RefreshTokenRepository) GetRefreshTokenFamily(ctx context.Context, familyID string) ([]models.RefreshToken, error) {
+	tokens := []models.RefreshToken{}
+	err := r.DB.WithContext(ctx).Where("family_id = ?", familyID).Order("id").Find(&tokens).Error
+	return tokens, err
+}
+
+// GetUserSessionByFamilyID returns a zero UserSession once the family's
+// session has ended.
+func (r *RefreshTokenRepository) GetUserSessionByFamilyID(ctx context.Context, familyID string) (models.UserSession, error) {
+	sessions := []models.UserSession{}
+	err := r.DB.WithContext(ctx).Where("family_id = ?", familyID).Limit(1).Find(&sessions).Error
+	if err != nil || len(sessions) == 0 {
+		return models.UserSession{}, err
+	}
+	return sessions[0], nil
+}
+
+// GetUserSessionByTokenID finds the session by the jti of its current access
+// token, it returns a zero UserSession when none matches.
+func (r *RefreshTokenRepository) GetUserSessionByTokenID(ctx context.Context, tokenID string) (models.UserSession, error) {
+	sessions := []models.UserSession{}
+	err := r.DB.WithContext(ctx).Where("token_id = ?", tokenID).Limit(1).Find(&sessions).Error
+	if err != nil || len(sessions) == 0 {
+		return models.UserSession{}, err
+	}
+	return sessions[0], nil
+}
+
+// RotateRefreshToken marks parent used, stores child and saves the session's
+// new tokens. It reports false when parent was already used or revoked, e.g.
+// by a concurrent refresh with the same token.
+func (r *RefreshTokenRepository) RotateRefreshToken(ctx context.Context, parent models.RefreshToken, child *models.RefreshToken, session models.UserSession, now time.Time) (bool, error) {
+	var rotated bool
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("UPDATE refresh_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL", now, parent.ID)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		rotated = true
+
+		if err := tx.Create(child).Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE user_sessions SET token = ?, token_id = ?, token_expired = ?, refresh_token = ? WHERE id = ?", session.Token, session.TokenID, session.TokenExpired, session.RefreshToken, session.ID).Error
+	})
+	return rotated, err
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, now time.Time) error {
+	return r.DB.WithContext(ctx).Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", now, familyID).Error
+}
+
+func (r *RefreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID int, now time.Time) error {
+	return r.DB.WithContext(ctx).Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL", now, userID).Error
+}
+
+func (r *RefreshTokenRepository) DeleteExpiredRefreshTokens(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := deleteLimited(r.DB.WithContext(ctx), "refresh_tokens", "expires_at < ?", before, limit)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ReplayNonceRepository remembers the request nonces each user has used, in
+// redis, for as long as a request carrying them would be accepted.
+type ReplayNonceRepository struct {
+	Redis *redis.Client
+}
+
+// ClaimRequestNonce reports whether the user hasn't used nonce yet, and if
+// so marks it used for ttl.
+func (r *ReplayNonceRepository) ClaimRequestNonce(ctx context.Context, userID int, nonce string, ttl time.Duration) (bool, error) {
+	key := constants.ReplayNonceKeyPrefix + strconv.Itoa(userID) + ":" + nonce
+	return r.Redis.SetNX(ctx, key, 1, ttl).Result()
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errUserChanged rolls a claim back when the user changed in the meantime.
+var errUserChanged = errors.New("user changed")
+
+type ReservedUsernameRepository struct {
+	DB *gorm.DB
+}
+
+// InsertReservedUsername reports false when the username is already
+// reserved.
+func (r *ReservedUsernameRepository) InsertReservedUsername(ctx context.Context, reservation *models.ReservedUsername) (bool, error) {
+	result := r.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reservation)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *ReservedUsernameRepository) GetReservedUsername(ctx context.Context, username string) (models.ReservedUsername, error) {
+	reservation := models.ReservedUsername{}
+	err := r.DB.WithContext(ctx).Where("username = ?", username).First(&reservation).Error
+	return reservation, err
+}
+
+func (r *ReservedUsernameRepository) GetReservedUsernames(ctx context.Context, cursor *pagination.Cursor, limit int) ([]models.ReservedUsername, error) {
+	reservations := []models.ReservedUsername{}
+	err := pagination.Apply(r.DB.WithContext(ctx), cursor, limit).Find(&reservations).Error
+	return reservations, err
+}
+
+// DeleteReservedUsername reports whether the username was reserved.
+func (r *ReservedUsernameRepository) DeleteReservedUsername(ctx context.Context, username string) (bool, error) {
+	result := r.DB.WithContext(ctx).Where("username = ?", username).Delete(&models.ReservedUsername{})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ClaimReservedUsername gives the reserved username to the user, only while
+// the reservation is unclaimed and the user is still at version. It reports
+// whether this call won.
+func (r *ReservedUsernameRepository) ClaimReservedUsername(ctx context.Context, reservation *models.ReservedUsername, userID, version int, now time.Time) (bool, error) {
+	var claimed bool
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ReservedUsername{}).Where("id = ? AND claimed_by = 0", reservation.ID).
+			Updates(map[string]any{"claimed_by": userID, "claimed_at": now})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		result = tx.Model(&models.User{}).Where("id = ? AND version = ?", userID, version).
+			Updates(map[string]any{"username": reservation.Username, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errUserChanged
+		}
+		claimed = true
+		return nil
+	})
+	if errors.Is(err, errUserChanged) {
+		return false, nil
+	}
+	return claimed, err
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ewallet-ums/constants"
+
+	"gorm.io/gorm"
+)
+
+type RetentionRepository struct {
+	DB *gorm.DB
+}
+
+// retentionQueries maps each rule to the table and condition selecting rows
+// past retention, leaving out users under legal hold. Deleted users' PII is
+// handled by the anonymization job instead.
+var retentionQueries = map[string]struct {
+	table string
+	where string
+}{
+	constants.RetentionRuleLoginHistory: {"login_histories", "created_at < ? AND user_id NOT IN (" + activeLegalHolds + ")"},
+	constants.RetentionRuleUserSession:  {"user_sessions", "refresh_token_expired < ? AND user_id NOT IN (" + activeLegalHolds + ")"},
+}
+
+func (r *RetentionRepository) CountExpired(ctx context.Context, rule string, before time.Time) (int64, error) {
+	query, ok := retentionQueries[rule]
+	if !ok {
+		return 0, fmt.Errorf("unknown retention rule %q", rule)
+	}
+
+	var count int64
+	err := r.DB.WithContext(ctx).Table(query.table).Where(query.where, before).Count(&count).Error
+	return count, err
+}
+
+func (r *RetentionRepository) PurgeExpired(ctx context.Context, rule string, before time.Time, limit int) (int64, error) {
+	query, ok := retentionQueries[rule]
+	if !ok {
+		return 0, fmt.Errorf("unknown retention rule %q", rule)
+	}
+
+	result := deleteLimited(r.DB.WithContext(ctx), query.table, query.where, before, limit)
+	return result.RowsAffected, result.Error
+}
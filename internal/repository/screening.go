@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScreeningRepository struct {
+	DB *gorm.DB
+}
+
+// GetUsersDueForScreening returns active, KYC verified users with a name
+// that weren't screened since screenedBefore, by id after afterID.
+func (r *ScreeningRepository) GetUsersDueForScreening(ctx context.Context, screenedBefore time.Time, afterID, limit int) ([]models.User, error) {
+	users := []models.User{}
+	err := r.DB.WithContext(ctx).
+		Joins("LEFT JOIN user_screenings ON user_screenings.user_id = users.id").
+		Where("users.status = ? AND users.kyc_status = ? AND users.type = ? AND users.full_name <> ''", constants.UserStatusActive, constants.KycStatusVerified, constants.UserTypeHuman).
+		Where("(user_screenings.screened_at IS NULL OR user_screenings.screened_at < ?)", screenedBefore).
+		Where("users.id > ?", afterID).
+		Order("users.id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+func (r *ScreeningRepository) MarkUserScreened(ctx context.Context, userID int, screenedAt time.Time) error {
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"screened_at"}),
+	}).Create(&models.UserScreening{UserID: userID, ScreenedAt: screenedAt}).Error
+}
+
+// InsertScreeningReview reports false, adding nothing, when the user already
+// has a review of the same matches.
+func (r *ScreeningRepository) InsertScreeningReview(ctx context.Context, review *models.ScreeningReview) (bool, error) {
+	result := r.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(review)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *ScreeningRepository) GetScreeningReviewByID(ctx context.Context, reviewID int) (models.ScreeningReview, error) {
+	review := models.ScreeningReview{}
+	err := r.DB.WithContext(ctx).Where("id = ?", reviewID).First(&review).Error
+	return review, err
+}
+
+func (r *ScreeningRepository) GetScreeningReviews(ctx context.Context, status string, cursor *pagination.Cursor, limit int) ([]models.ScreeningReview, error) {
+	reviews := []models.ScreeningReview{}
+
+	query := r.DB.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := pagination.Apply(query, cursor, limit).Find(&reviews).Error
+	return reviews, err
+}
+
+// ResolveScreeningReview saves the decision only while the review is still
+// pending, so it is resolved once. It reports whether this call won.
+func (r *ScreeningRepository) ResolveScreeningReview(ctx context.Context, review *models.ScreeningReview) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.ScreeningReview{}).
+		Where("id = ? AND status = ?", review.ID, constants.ScreeningReviewStatusPending).
+		Updates(map[string]any{
+			"status":      review.Status,
+			"resolved_by": review.ResolvedBy,
+			"note":        review.Note,
+			"resolved_at": review.ResolvedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
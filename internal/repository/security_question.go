@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SecurityQuestionRepository struct {
+	DB *gorm.DB
+}
+
+func (r *SecurityQuestionRepository) GetSecurityQuestions(ctx context.Context, userID int) ([]models.SecurityQuestion, error) {
+	questions := []models.SecurityQuestion{}
+	err := r.DB.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&questions).Error
+	return questions, err
+}
+
+// ReplaceSecurityQuestions swaps the user's questions in one transaction, so
+// a failed update never leaves them with half a set.
+func (r *SecurityQuestionRepository) ReplaceSecurityQuestions(ctx context.Context, userID int, questions []models.SecurityQuestion) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.SecurityQuestion{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&questions).Error
+	})
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+type ServiceAccountRepository struct {
+	DB *gorm.DB
+}
+
+// InsertServiceAccount creates the backing user and the account together.
+func (r *ServiceAccountRepository) InsertServiceAccount(ctx context.Context, user *models.User, account *models.ServiceAccount) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		account.UserID = user.ID
+		return tx.Create(account).Error
+	})
+}
+
+func (r *ServiceAccountRepository) GetServiceAccountByID(ctx context.Context, accountID int) (models.ServiceAccount, error) {
+	account := models.ServiceAccount{}
+
+	if err := r.DB.WithContext(ctx).Where("id = ?", accountID).First(&account).Error; err != nil {
+		return account, err
+	}
+
+	if account.ID == 0 {
+		return account, errors.New("service account not found")
+	}
+
+	return account, nil
+}
+
+func (r *ServiceAccountRepository) GetServiceAccountByClientID(ctx context.Context, clientID string) (models.ServiceAccount, error) {
+	account := models.ServiceAccount{}
+
+	if err := r.DB.WithContext(ctx).Where("client_id = ?", clientID).First(&account).Error; err != nil {
+		return account, err
+	}
+
+	if account.ID == 0 {
+		return account, errors.New("service account not found")
+	}
+
+	return account, nil
+}
+
+func (r *ServiceAccountRepository) GetServiceAccounts(ctx context.Context, cursor *pagination.Cursor, limit int) ([]models.ServiceAccount, error) {
+	accounts := []models.ServiceAccount{}
+	err := pagination.Apply(r.DB.WithContext(ctx), cursor, limit).Find(&accounts).Error
+	return accounts, err
+}
+
+// UpdateServiceAccount saves the editable fields and keeps the backing
+// user's full name in step with the account name.
+func (r *ServiceAccountRepository) UpdateServiceAccount(ctx context.Context, account *models.ServiceAccount) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.ServiceAccount{}).Where("id = ?", account.ID).Updates(map[string]any{
+			"name":        account.Name,
+			"description": account.Description,
+			"scopes":      account.Scopes,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("id = ?", account.UserID).Update("full_name", account.Name).Error
+	})
+}
+
+func (r *ServiceAccountRepository) UpdateServiceAccountSecret(ctx context.Context, userID int, secretHash string) error {
+	return r.DB.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("password", secretHash).Error
+}
+
+// DeleteServiceAccount soft deletes the account and its backing user.
+func (r *ServiceAccountRepository) DeleteServiceAccount(ctx context.Context, account *models.ServiceAccount) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ServiceAccount{}, account.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.User{}, account.UserID).Error
+	})
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	DB *gorm.DB
+}
+
+func (r *SessionRepository) InsertNewUserSession(ctx context.Context, session *models.UserSession) error {
+	return r.DB.WithContext(ctx).Create(session).Error
+}
+
+func (r *SessionRepository) DeleteUserSession(ctx context.Context, token string) error {
+	return r.DB.WithContext(ctx).Exec("DELETE FROM user_sessions WHERE token = ?", token).Error
+}
+
+func (r *SessionRepository) UpdateTokenByRefreshToken(ctx context.Context, token, refreshToken string) error {
+	return r.DB.WithContext(ctx).Exec("UPDATE user_sessions SET token = ? WHERE refresh_token = ?", token, refreshToken).Error
+}
+
+func (r *SessionRepository) GetUserSessionByToken(ctx context.Context, token string) (models.UserSession, error) {
+	session := models.UserSession{}
+
+	if err := r.DB.WithContext(ctx).Where("token = ?", token).First(&session).Error; err != nil {
+		return session, err
+	}
+
+	if session.ID == 0 {
+		return session, errors.New("user session not found")
+	}
+
+	return session, nil
+}
+
+// GetUserSessionByTokenID finds the session by the jti of its access token.
+func (r *SessionRepository) GetUserSessionByTokenID(ctx context.Context, tokenID string) (models.UserSession, error) {
+	session := models.UserSession{}
+	err := r.DB.WithContext(ctx).Where("token_id = ?", tokenID).First(&session).Error
+	return session, err
+}
+
+func (r *SessionRepository) GetUserSessionByRefreshToken(ctx context.Context, refreshToken string) (models.UserSession, error) {
+	session := models.UserSession{}
+
+	if err := r.DB.WithContext(ctx).Where("refresh_token = ?", refreshToken).First(&session).Error; err != nil {
+		return session, err
+	}
+
+	if session.ID == 0 {
+		return session, errors.New("user session not found")
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepository) CountActiveUserSessions(ctx context.Context, now time.Time) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.UserSession{}).Where("refresh_token_expired >= ?", now).Count(&count).Error
+	return count, err
+}
+
+func (r *SessionRepository) GetUserSessionsByUserID(ctx context.Context, userID int, cursor *pagination.Cursor, limit int) ([]models.UserSession, error) {
+	sessions := []models.UserSession{}
+	err := pagination.Apply(r.DB.WithContext(ctx).Where("user_id = ?", userID), cursor, limit).Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *SessionRepository) GetActiveUserSessions(ctx context.Context, userID int, now time.Time) ([]models.UserSession, error) {
+	sessions := []models.UserSession{}
+	err := r.DB.WithContext(ctx).Where("user_id = ? AND refresh_token_expired >= ?", userID, now).Find(&sessions).Error
+	return sessions, err
+}
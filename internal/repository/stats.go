@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StatsRepository struct {
+	DB *gorm.DB
+}
+
+type dayCount struct {
+	Day   string
+	Count int64
+}
+
+// CountRegistrationsByDay counts the users created since, soft deleted ones
+// included, by day.
+func (r *StatsRepository) CountRegistrationsByDay(ctx context.Context, since time.Time) (map[string]int64, error) {
+	return r.countByDay(r.DB.WithContext(ctx).Unscoped().Model(&models.User{}).Where("created_at >= ?", since), "created_at")
+}
+
+func (r *StatsRepository) CountFailedLoginsByDay(ctx context.Context, since time.Time) (map[string]int64, error) {
+	return r.countByDay(r.DB.WithContext(ctx).Model(&models.LoginHistory{}).Where("created_at >= ? AND success = ?", since, false), "created_at")
+}
+
+func (r *StatsRepository) countByDay(query *gorm.DB, column string) (map[string]int64, error) {
+	rows := []dayCount{}
+	day := dayOf(query, column)
+	if err := query.Select(day + " AS day, COUNT(*) AS count").Group(day).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Day] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *StatsRepository) UpsertDailyStats(ctx context.Context, stats []models.DailyStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "day"}, {Name: "metric"}},
+		DoUpdates: clause.AssignmentColumns([]string{"count", "updated_at"}),
+	}).Create(&stats).Error
+}
+
+// GetDailyStats returns the stats of the days from sinceDay on.
+func (r *StatsRepository) GetDailyStats(ctx context.Context, sinceDay string) ([]models.DailyStat, error) {
+	stats := []models.DailyStat{}
+	err := r.DB.WithContext(ctx).Where("day >= ?", sinceDay).Order("day").Find(&stats).Error
+	return stats, err
+}
+
+// CountUsersByKycStatus counts on the kyc_status index.
+func (r *StatsRepository) CountUsersByKycStatus(ctx context.Context, status string) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.User{}).Where("kyc_status = ?", status).Count(&count).Error
+	return count, err
+}
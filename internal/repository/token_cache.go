@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/models"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/redis/go-redis/v9"
+)
+
+type TokenCacheRepository struct {
+	Redis *redis.Client
+	Local *expirable.LRU[string, *helpers.ClaimToken]
+	TTL   time.Duration
+}
+
+func NewTokenCacheRepository(rdb *redis.Client, size int, ttl time.Duration) *TokenCacheRepository {
+	return &TokenCacheRepository{
+		Redis: rdb,
+		Local: expirable.NewLRU[string, *helpers.ClaimToken](size, nil, ttl),
+		TTL:   ttl,
+	}
+}
+
+func (r *TokenCacheRepository) GetTokenClaim(ctx context.Context, token string) (*helpers.ClaimToken, error) {
+	key := helpers.HashToken(token)
+
+	// the local entries all live for the cache TTL, so one outliving its
+	// token is dropped here, keeping it to min(TTL, exp-now) like Redis
+	if claim, ok := r.Local.Get(key); ok {
+		if !claimExpired(claim, time.Now()) {
+			return claim, nil
+		}
+		r.Local.Remove(key)
+	}
+
+	val, err := r.Redis.Get(ctx, constants.TokenCacheKeyPrefix+key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.New("token not found in cache")
+		}
+		return nil, err
+	}
+
+	claim := &helpers.ClaimToken{}
+	if err := json.Unmarshal([]byte(val), claim); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached claim: %v", err)
+	}
+
+	r.Local.Add(key, claim)
+	return claim, nil
+}
+
+func (r *TokenCacheRepository) SetTokenClaim(ctx context.Context, token string, claim *helpers.ClaimToken) error {
+	ttl := r.TTL
+	if claim.ExpiresAt != nil {
+		if remaining := time.Until(claim.ExpiresAt.Time); remaining < ttl {
+			ttl = remaining
+		}
+	}
+	if ttl <= 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(claim)
+	if err != nil {
+		return fmt.Errorf("failed to marshal claim: %v", err)
+	}
+
+	key := helpers.HashToken(token)
+	if err := r.Redis.Set(ctx, constants.TokenCacheKeyPrefix+key, payload, ttl).Err(); err != nil {
+		return err
+	}
+
+	r.Local.Add(key, claim)
+	return nil
+}
+
+func claimExpired(claim *helpers.ClaimToken, now time.Time) bool {
+	return claim.ExpiresAt != nil && !now.Before(claim.ExpiresAt.Time)
+}
+
+// EvictToken drops the cached validation result everywhere without revoking
+// the token, forcing the next validation to read fresh user data.
+func (r *TokenCacheRepository) EvictToken(ctx context.Context, token string) error {
+	key := helpers.HashToken(token)
+	r.Local.Remove(key)
+
+	if err := r.Redis.Del(ctx, constants.TokenCacheKeyPrefix+key).Err(); err != nil {
+		return err
+	}
+
+	return r.Redis.Publish(ctx, constants.TokenRevocationChannel, key).Err()
+}
+
+// revokedTokenEntry is what the revocation list keeps of a token, for
+// responders looking through it.
+type revokedTokenEntry struct {
+	TokenID   string    `json:"token_id,omitempty"`
+	UserID    int       `json:"user_id,omitempty"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+func (r *TokenCacheRepository) RevokeToken(ctx context.Context, token string, expiresAt time.Time) error {
+	key := helpers.HashToken(token)
+
+	entry := revokedTokenEntry{RevokedAt: time.Now()}
+	if claim, err := helpers.ValidateToken(ctx, token); err == nil {
+		entry.TokenID, entry.UserID = claim.ID, claim.UserID
+	}
+	if err := r.addRevokedToken(ctx, key, entry, expiresAt); err != nil {
+		return err
+	}
+	helpers.EmitSecurityEvent(ctx, helpers.SecurityEvent{
+		Type:      helpers.SecurityEventTokenRevoked,
+		UserID:    entry.UserID,
+		TokenID:   entry.TokenID,
+		ExpiresAt: expiresAt,
+	})
+
+	return r.EvictToken(ctx, token)
+}
+
+// RevokeTokenID lists a token by its jti alone, for tokens that can't be
+// revoked by their session. Cached validations of the token aren't evicted,
+// they run out within the cache TTL.
+func (r *TokenCacheRepository) RevokeTokenID(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if err := r.addRevokedToken(ctx, revokedTokenIDKey(tokenID), revokedTokenEntry{TokenID: tokenID, RevokedAt: time.Now()}, expiresAt); err != nil {
+		return err
+	}
+	helpers.EmitSecurityEvent(ctx, helpers.SecurityEvent{
+		Type:      helpers.SecurityEventTokenRevoked,
+		TokenID:   tokenID,
+		ExpiresAt: expiresAt,
+	})
+	return nil
+}
+
+func (r *TokenCacheRepository) addRevokedToken(ctx context.Context, key string, entry revokedTokenEntry, expiresAt time.Time) error {
+	// keep the revocation only as long as the token itself would be valid
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal revoked token: %v", err)
+	}
+	return r.Redis.Set(ctx, constants.RevokedTokenKeyPrefix+key, payload, ttl).Err()
+}
+
+// IsTokenRevoked checks the revocation list for the token and, when given,
+// its jti.
+func (r *TokenCacheRepository) IsTokenRevoked(ctx context.Context, token, tokenID string) (bool, error) {
+	keys := []string{constants.RevokedTokenKeyPrefix + helpers.HashToken(token)}
+	if tokenID != "" {
+		keys = append(keys, constants.RevokedTokenKeyPrefix+revokedTokenIDKey(tokenID))
+	}
+
+	count, err := r.Redis.Exists(ctx, keys...).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetRevokedTokens scans the revocation list from cursor, returning about
+// count entries and the cursor of the next page, 0 after the last.
+func (r *TokenCacheRepository) GetRevokedTokens(ctx context.Context, cursor uint64, count int) ([]models.RevokedToken, uint64, error) {
+	keys, next, err := r.Redis.Scan(ctx, cursor, constants.RevokedTokenKeyPrefix+"*", int64(count)).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pipe := r.Redis.Pipeline()
+	values := make([]*redis.StringCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		values[i] = pipe.Get(ctx, key)
+		ttls[i] = pipe.PTTL(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	tokens := make([]models.RevokedToken, 0, len(keys))
+	for i, key := range keys {
+		// expired between the scan and the reads
+		if values[i].Err() != nil || ttls[i].Val() <= 0 {
+			continue
+		}
+
+		// entries written before the list kept details only hold a 1
+		entry := revokedTokenEntry{}
+		_ = json.Unmarshal([]byte(values[i].Val()), &entry)
+		tokens = append(tokens, models.RevokedToken{
+			Key:       strings.TrimPrefix(key, constants.RevokedTokenKeyPrefix),
+			TokenID:   entry.TokenID,
+			UserID:    entry.UserID,
+			RevokedAt: entry.RevokedAt,
+			ExpiresAt: now.Add(ttls[i].Val()),
+		})
+	}
+	return tokens, next, nil
+}
+
+// DeleteRevokedToken takes an entry off the revocation list, reporting
+// whether there was one.
+func (r *TokenCacheRepository) DeleteRevokedToken(ctx context.Context, key string) (bool, error) {
+	deleted, err := r.Redis.Del(ctx, constants.RevokedTokenKeyPrefix+key).Result()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
+func revokedTokenIDKey(tokenID string) string {
+	return "jti:" + tokenID
+}
+
+func (r *TokenCacheRepository) SubscribeRevocation(ctx context.Context) {
+	sub := r.Redis.Subscribe(ctx, constants.TokenRevocationChannel)
+	defer sub.Close()
+
+	for msg := range sub.Channel() {
+		r.Local.Remove(msg.Payload)
+	}
+}
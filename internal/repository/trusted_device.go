@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type TrustedDeviceRepository struct {
+	DB *gorm.DB
+}
+
+func (r *TrustedDeviceRepository) InsertTrustedDevice(ctx context.Context, device *models.TrustedDevice) error {
+	return r.DB.WithContext(ctx).Create(device).Error
+}
+
+func (r *TrustedDeviceRepository) DeleteTrustedDevices(ctx context.Context, userID int) error {
+	return r.DB.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.TrustedDevice{}).Error
+}
+
+// UseTrustedDevice marks the user's unexpired device with tokenHash used. It
+// reports whether there was one.
+func (r *TrustedDeviceRepository) UseTrustedDevice(ctx context.Context, userID int, tokenHash string, now time.Time) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.TrustedDevice{}).
+		Where("user_id = ? AND token_hash = ? AND expires_at > ?", userID, tokenHash, now).
+		Update("last_used_at", now)
+	return result.RowsAffected == 1, result.Error
+}
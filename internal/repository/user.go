@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"ewallet-ums/helpers"
 	"ewallet-ums/internal/models"
 
 	"gorm.io/gorm"
@@ -14,13 +15,35 @@ type UserRepository struct {
 }
 
 func (r *UserRepository) InsertNewUser(ctx context.Context, user *models.User) error {
-	return r.DB.Create(user).Error
+	setUserLookups(user)
+	// MySQL doesn't return the column default, set it so callers see it
+	if user.Version == 0 {
+		user.Version = 1
+	}
+	return r.DB.WithContext(ctx).Create(user).Error
+}
+
+// setUserLookups fills the unique blind indexes of the encrypted email and
+// phone number columns, see helpers.PIILookup.
+func setUserLookups(user *models.User) {
+	user.EmailLookup = piiLookup(user.Email)
+	user.PhoneLookup = piiLookup(user.PhoneNumber)
+}
+
+// piiLookup is nil for an empty value, the unique index allows any number
+// of NULLs.
+func piiLookup(value string) *string {
+	if value == "" {
+		return nil
+	}
+	lookup := helpers.PIILookup(value)
+	return &lookup
 }
 
 func (r *UserRepository) GetUserByUsername(ctx context.Context, username string) (models.User, error) {
 	user := models.User{}
 
-	if err := r.DB.Where("username = ?", username).First(&user).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
 		return user, err
 	}
 
@@ -31,42 +54,65 @@ func (r *UserRepository) GetUserByUsername(ctx context.Context, username string)
 	return user, nil
 }
 
-func (r *UserRepository) InsertNewUserSession(ctx context.Context, session *models.UserSession) error {
-	return r.DB.Create(session).Error
-}
+// GetUserByIdentifier finds the user whose username, email or phone number
+// matches, empty values are skipped. A username match wins over the others.
+func (r *UserRepository) GetUserByIdentifier(ctx context.Context, username, email, phoneNumber string) (models.User, error) {
+	query := r.DB.WithContext(ctx).Where("username = ?", username)
+	if email != "" {
+		query = query.Or("email_lookup = ?", helpers.PIILookup(email))
+	}
+	if phoneNumber != "" {
+		query = query.Or("phone_lookup = ?", helpers.PIILookup(phoneNumber))
+	}
 
-func (r *UserRepository) DeleteUserSession(ctx context.Context, token string) error {
-	return r.DB.Exec("DELETE FROM user_sessions WHERE token = ?", token).Error
-}
+	users := []models.User{}
+	if err := query.Order("id").Limit(3).Find(&users).Error; err != nil {
+		return models.User{}, err
+	}
+	if len(users) == 0 {
+		return models.User{}, gorm.ErrRecordNotFound
+	}
 
-func (r *UserRepository) UpdateTokenByRefreshToken(ctx context.Context, token, refreshToken string) error {
-	return r.DB.Exec("UPDATE user_sessions SET token = ? WHERE refresh_token = ?", token, refreshToken).Error
+	for _, user := range users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	if len(users) > 1 {
+		return models.User{}, models.ErrAmbiguousIdentifier
+	}
+	return users[0], nil
 }
 
-func (r *UserRepository) GetUserSessionByToken(ctx context.Context, token string) (models.UserSession, error) {
-	session := models.UserSession{}
+func (r *UserRepository) GetUserByID(ctx context.Context, userID int) (models.User, error) {
+	user := models.User{}
 
-	if err := r.DB.Where("token = ?", token).First(&session).Error; err != nil {
-		return session, err
+	if err := r.DB.WithContext(ctx).Where("id = ?", userID).First(&user).Error; err != nil {
+		return user, err
 	}
 
-	if session.ID == 0 {
-		return session, errors.New("user session not found")
+	if user.ID == 0 {
+		return user, errors.New("user not found")
 	}
 
-	return session, nil
+	return user, nil
 }
 
-func (r *UserRepository) GetUserSessionByRefreshToken(ctx context.Context, refreshToken string) (models.UserSession, error) {
-	session := models.UserSession{}
-
-	if err := r.DB.Where("refresh_token = ?", refreshToken).First(&session).Error; err != nil {
-		return session, err
-	}
-
-	if session.ID == 0 {
-		return session, errors.New("user session not found")
-	}
+// UpdateUser only applies while the user is still at version, and bumps it.
+// False means another update got there first.
+func (r *UserRepository) UpdateUser(ctx context.Context, userID, version int, user models.User) (bool, error) {
+	setUserLookups(&user)
+	user.Version = version + 1
+	result := r.DB.WithContext(ctx).Model(&models.User{}).Where("id = ? AND version = ?", userID, version).Updates(user)
+	return result.RowsAffected == 1, result.Error
+}
 
-	return session, nil
+// UpdateUserPassword also sets password_change_required, which UpdateUser
+// can't clear.
+func (r *UserRepository) UpdateUserPassword(ctx context.Context, userID int, password string, changeRequired bool) error {
+	return r.DB.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Updates(map[string]any{
+		"password":                 password,
+		"password_change_required": changeRequired,
+		"version":                  gorm.Expr("version + 1"),
+	}).Error
 }
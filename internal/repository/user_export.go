@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type UserExportRepository struct {
+	DB *gorm.DB
+}
+
+func (r *UserExportRepository) InsertUserExport(ctx context.Context, export *models.UserExport) error {
+	return r.DB.WithContext(ctx).Create(export).Error
+}
+
+func (r *UserExportRepository) GetUserExportByID(ctx context.Context, exportID int) (models.UserExport, error) {
+	export := models.UserExport{}
+	err := r.DB.WithContext(ctx).Where("id = ?", exportID).First(&export).Error
+	return export, err
+}
+
+// GetPendingUserExports also returns running exports claimed before
+// staleBefore, whose worker is assumed to have died.
+func (r *UserExportRepository) GetPendingUserExports(ctx context.Context, staleBefore time.Time, limit int) ([]models.UserExport, error) {
+	exports := []models.UserExport{}
+
+	err := r.DB.WithContext(ctx).Where("status = ? OR (status = ? AND claimed_at < ?)", constants.UserExportStatusPending, constants.UserExportStatusRunning, staleBefore).
+		Order("id").
+		Limit(limit).
+		Find(&exports).Error
+
+	return exports, err
+}
+
+// ClaimUserExport marks the export running only if no other worker has
+// claimed it since it was read, so each export runs once.
+func (r *UserExportRepository) ClaimUserExport(ctx context.Context, export *models.UserExport, now time.Time) (bool, error) {
+	// spelled out rather than MySQL's <=>, so it runs on SQLite as well
+	query := "UPDATE user_exports SET status = ?, claimed_at = ? WHERE id = ? AND status = ? AND claimed_at IS NULL"
+	args := []any{constants.UserExportStatusRunning, now, export.ID, export.Status}
+	if export.ClaimedAt != nil {
+		query = "UPDATE user_exports SET status = ?, claimed_at = ? WHERE id = ? AND status = ? AND claimed_at = ?"
+		args = append(args, *export.ClaimedAt)
+	}
+	result := r.DB.WithContext(ctx).Exec(query, args...)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	export.Status = constants.UserExportStatusRunning
+	export.ClaimedAt = &now
+	return result.RowsAffected == 1, nil
+}
+
+func (r *UserExportRepository) UpdateUserExport(ctx context.Context, export *models.UserExport) error {
+	return r.DB.WithContext(ctx).Save(export).Error
+}
+
+// GetUsersForExport pages through the people matching the export's filters
+// in id order, service accounts are left out.
+func (r *UserExportRepository) GetUsersForExport(ctx context.Context, export models.UserExport, afterID, limit int) ([]models.User, error) {
+	users := []models.User{}
+
+	query := r.DB.WithContext(ctx).Where("id > ? AND type = ?", afterID, constants.UserTypeHuman)
+	if export.UserStatus != "" {
+		query = query.Where("status = ?", export.UserStatus)
+	}
+	if export.KycStatus != "" {
+		query = query.Where("kyc_status = ?", export.KycStatus)
+	}
+	if export.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *export.CreatedFrom)
+	}
+	if export.CreatedTo != nil {
+		query = query.Where("created_at < ?", *export.CreatedTo)
+	}
+
+	err := query.Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
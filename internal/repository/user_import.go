@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type UserImportRepository struct {
+	DB *gorm.DB
+}
+
+func (r *UserImportRepository) InsertUserImport(ctx context.Context, job *models.UserImport) error {
+	return r.DB.WithContext(ctx).Create(job).Error
+}
+
+func (r *UserImportRepository) UpdateUserImport(ctx context.Context, job *models.UserImport) error {
+	return r.DB.WithContext(ctx).Save(job).Error
+}
+
+func (r *UserImportRepository) GetUserImportByID(ctx context.Context, importID int) (models.UserImport, error) {
+	job := models.UserImport{}
+	err := r.DB.WithContext(ctx).Where("id = ?", importID).First(&job).Error
+	return job, err
+}
+
+// GetUserIDByExternalID returns 0 when no user was imported with the id.
+// Soft deleted users still count so a re-run doesn't bring them back.
+func (r *UserImportRepository) GetUserIDByExternalID(ctx context.Context, externalID string) (int, error) {
+	var ids []int
+	err := r.DB.WithContext(ctx).Unscoped().Model(&models.User{}).Where("external_id = ?", externalID).Limit(1).Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+func (r *UserImportRepository) UsernameExists(ctx context.Context, username string) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.User{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+// InsertImportedUser creates the user and its wallet provisioning together,
+// so an interrupted import never leaves a user without a wallet.
+func (r *UserImportRepository) InsertImportedUser(ctx context.Context, user *models.User, provisioning *models.WalletProvisioning) error {
+	setUserLookups(user)
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		provisioning.UserID = user.ID
+		return tx.Create(provisioning).Error
+	})
+}
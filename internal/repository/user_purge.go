@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// userPurgeTables hold the rows a purge deletes along with the user. Audit
+// events, admin approvals and legal holds are kept as the record of it.
+var userPurgeTables = []string{
+	"user_sessions", "refresh_tokens", "login_histories", "security_questions", "user_addresses", "kyc_profiles",
+	"email_change_reverts", "user_roles", "user_consents", "user_entitlements", "notification_preferences",
+	"notification_quiet_hours", "pending_notifications", "activity_digests", "user_screenings", "screening_reviews",
+	"recovery_tickets", "service_accounts", "wallet_provisionings", "account_notes", "trusted_devices",
+}
+
+type UserPurgeRepository struct {
+	DB *gorm.DB
+}
+
+func (r *UserPurgeRepository) InsertUserPurge(ctx context.Context, purge *models.UserPurge) error {
+	return r.DB.WithContext(ctx).Create(purge).Error
+}
+
+// GetPendingUserPurges returns pending purges with an id above afterID, in
+// id order.
+func (r *UserPurgeRepository) GetPendingUserPurges(ctx context.Context, afterID, limit int) ([]models.UserPurge, error) {
+	purges := []models.UserPurge{}
+	err := r.DB.WithContext(ctx).Where("status = ? AND id > ?", constants.UserPurgeStatusPending, afterID).
+		Order("id").Limit(limit).Find(&purges).Error
+	return purges, err
+}
+
+// PurgeUser deletes the user, soft-deleted or not, and their rows in
+// userPurgeTables, and completes the purge in the same transaction. It
+// returns the deleted user, nil when the purge was no longer pending or the
+// user was already gone.
+func (r *UserPurgeRepository) PurgeUser(ctx context.Context, purge models.UserPurge, now time.Time) (*models.User, error) {
+	var deleted *models.User
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.UserPurge{}).Where("id = ? AND status = ?", purge.ID, constants.UserPurgeStatusPending).
+			Updates(map[string]any{"status": constants.UserPurgeStatusCompleted, "purged_at": now})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		user := models.User{}
+		if err := tx.Unscoped().Where("id = ?", purge.UserID).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		for _, table := range userPurgeTables {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", purge.UserID).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Delete(&models.User{}, purge.UserID).Error; err != nil {
+			return err
+		}
+		deleted = &user
+		return nil
+	})
+	return deleted, err
+}
+
+// RecordUserPurgeFailure keeps the purge pending with the error, it is
+// retried on the next run.
+func (r *UserPurgeRepository) RecordUserPurgeFailure(ctx context.Context, purgeID int, reason string) error {
+	return r.DB.WithContext(ctx).Model(&models.UserPurge{}).Where("id = ?", purgeID).
+		Updates(map[string]any{"attempts": gorm.Expr("attempts + 1"), "last_error": reason}).Error
+}
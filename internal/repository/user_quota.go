@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UserQuotaRepository counts each user's calls per quota and day in redis,
+// so quotas hold across every replica. Counters expire when their day ends.
+type UserQuotaRepository struct {
+	Redis *redis.Client
+}
+
+func userQuotaKey(name string, userID int, day time.Time) string {
+	return constants.UserQuotaKeyPrefix + name + ":" + day.Format("20060102") + ":" + strconv.Itoa(userID)
+}
+
+// IncrUserQuota counts a call and returns the day's count so far.
+func (r *UserQuotaRepository) IncrUserQuota(ctx context.Context, name string, userID int, day, resetAt time.Time) (int64, error) {
+	key := userQuotaKey(name, userID, day)
+	pipe := r.Redis.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireAt(ctx, key, resetAt)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// GetUserQuotaCounts returns the day's count of each quota in names.
+func (r *UserQuotaRepository) GetUserQuotaCounts(ctx context.Context, names []string, userID int, day time.Time) (map[string]int64, error) {
+	counts := make(map[string]int64, len(names))
+	if len(names) == 0 {
+		return counts, nil
+	}
+
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, userQuotaKey(name, userID, day))
+	}
+	values, err := r.Redis.MGet(ctx, keys...).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		counts[names[i]] = count
+	}
+	return counts, nil
+}
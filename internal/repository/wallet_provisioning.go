@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+type WalletProvisioningRepository struct {
+	DB *gorm.DB
+}
+
+func (r *WalletProvisioningRepository) InsertWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning) error {
+	return r.DB.WithContext(ctx).Create(provisioning).Error
+}
+
+// InsertUserWithWalletProvisioning creates the user and its wallet
+// provisioning together, so a failed registration never leaves a user the
+// worker won't create a wallet for.
+func (r *WalletProvisioningRepository) InsertUserWithWalletProvisioning(ctx context.Context, user *models.User, provisioning *models.WalletProvisioning) error {
+	setUserLookups(user)
+	// MySQL doesn't return the column default, set it so callers see it
+	if user.Version == 0 {
+		user.Version = 1
+	}
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		provisioning.UserID = user.ID
+		return tx.Create(provisioning).Error
+	})
+}
+
+func (r *WalletProvisioningRepository) GetWalletProvisioningByUserID(ctx context.Context, userID int) (models.WalletProvisioning, error) {
+	provisioning := models.WalletProvisioning{}
+
+	if err := r.DB.WithContext(ctx).Where("user_id = ?", userID).First(&provisioning).Error; err != nil {
+		return provisioning, err
+	}
+
+	if provisioning.ID == 0 {
+		return provisioning, errors.New("wallet provisioning not found")
+	}
+
+	return provisioning, nil
+}
+
+func (r *WalletProvisioningRepository) GetPendingWalletProvisionings(ctx context.Context, now time.Time, limit int) ([]models.WalletProvisioning, error) {
+	provisionings := []models.WalletProvisioning{}
+
+	err := r.DB.WithContext(ctx).Where("status = ? AND next_attempt_at <= ?", constants.WalletStatusProvisioning, now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&provisionings).Error
+
+	return provisionings, err
+}
+
+// ClaimWalletProvisioning pushes next_attempt_at forward only if no other
+// worker has touched the row since it was read, so each job runs once.
+func (r *WalletProvisioningRepository) ClaimWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning, lockUntil time.Time) (bool, error) {
+	result := r.DB.WithContext(ctx).Exec("UPDATE wallet_provisionings SET next_attempt_at = ? WHERE id = ? AND next_attempt_at = ?", lockUntil, provisioning.ID, provisioning.NextAttemptAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	provisioning.NextAttemptAt = lockUntil
+	return result.RowsAffected == 1, nil
+}
+
+// stuckWalletProvisionings matches provisionings that failed, or are still
+// pending although created before createdBefore.
+func (r *WalletProvisioningRepository) stuckWalletProvisionings(ctx context.Context, createdBefore time.Time) *gorm.DB {
+	return r.DB.WithContext(ctx).Model(&models.WalletProvisioning{}).
+		Where("status = ? OR (status = ? AND created_at < ?)", constants.WalletStatusFailed, constants.WalletStatusProvisioning, createdBefore)
+}
+
+func (r *WalletProvisioningRepository) GetStuckWalletProvisionings(ctx context.Context, createdBefore time.Time, cursor *pagination.Cursor, limit int) ([]models.WalletProvisioning, error) {
+	provisionings := []models.WalletProvisioning{}
+	err := pagination.Apply(r.stuckWalletProvisionings(ctx, createdBefore), cursor, limit).Find(&provisionings).Error
+	return provisionings, err
+}
+
+// CountStuckWalletProvisionings counts the stuck provisionings by status.
+func (r *WalletProvisioningRepository) CountStuckWalletProvisionings(ctx context.Context, createdBefore time.Time) (map[string]int64, error) {
+	rows := []struct {
+		Status string
+		Count  int64
+	}{}
+	err := r.stuckWalletProvisionings(ctx, createdBefore).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error
+
+	counts := map[string]int64{}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, err
+}
+
+// RetryWalletProvisioning queues a stuck provisioning for an immediate fresh
+// run of attempts. It reports false when the user has none that is stuck.
+func (r *WalletProvisioningRepository) RetryWalletProvisioning(ctx context.Context, userID int, createdBefore, now time.Time) (bool, error) {
+	result := r.stuckWalletProvisionings(ctx, createdBefore).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{
+			"status":          constants.WalletStatusProvisioning,
+			"attempts":        0,
+			"last_error":      "",
+			"next_attempt_at": now,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *WalletProvisioningRepository) UpdateWalletProvisioning(ctx context.Context, provisioning *models.WalletProvisioning) error {
+	return r.DB.WithContext(ctx).Save(provisioning).Error
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// ErrAccountPendingDeletion is returned when the password was right but
+	// the account is pending deletion, logging in again with cancel_deletion
+	// cancels it.
+	ErrAccountPendingDeletion = apperr.New(apperr.Forbidden, "account pending deletion")
+	ErrAccountNotActive       = apperr.New(apperr.Conflict, "only active accounts can be deleted")
+)
+
+// defaultAccountDeletionBatchSize stands in for a BatchSize that isn't
+// positive, fetching batches of none would never finish.
+const defaultAccountDeletionBatchSize = 100
+
+// AccountDeletionService deletes accounts at the user's request after a
+// cancellation window. Until the window ends the account is pending_deletion
+// and logging in with cancel_deletion restores it. Deleted accounts are
+// soft-deleted, announced with user.deleted so the wallet service closes the
+// wallet, and anonymized later by AnonymizationService.
+type AccountDeletionService struct {
+	DeletionRepo   interfaces.IAccountDeletionRepository
+	UserRepo       interfaces.IUserReader
+	SessionRepo    interfaces.ISessionRepository
+	AuditRepo      interfaces.IAuditRepository
+	TokenCache     interfaces.ITokenCacheRepository
+	PasswordHasher interfaces.IPasswordHasher
+	EventPublisher interfaces.IEventPublisher
+
+	Window    time.Duration
+	BatchSize int
+	Interval  time.Duration
+}
+
+// RequestDeletion schedules the deletion and logs the user out everywhere,
+// so the next login goes through the cancellation flow.
+func (s *AccountDeletionService) RequestDeletion(ctx context.Context, userID int, req models.AccountDeletionRequest) (models.AccountDeletionResponse, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.AccountDeletionResponse{}, apperr.Wrap(err, "failed to get user")
+	}
+	if err := s.PasswordHasher.ComparePassword(ctx, user.Password, req.Password); err != nil {
+		return models.AccountDeletionResponse{}, ErrIncorrectPassword
+	}
+	if user.Status != constants.UserStatusActive {
+		return models.AccountDeletionResponse{}, ErrAccountNotActive
+	}
+
+	now := time.Now()
+	scheduledAt := now.Add(s.Window)
+	scheduled, err := s.DeletionRepo.ScheduleUserDeletion(ctx, userID, user.Version, now, scheduledAt)
+	if err != nil {
+		return models.AccountDeletionResponse{}, apperr.Wrap(err, "failed to schedule deletion")
+	}
+	if !scheduled {
+		return models.AccountDeletionResponse{}, ErrUserVersionConflict
+	}
+
+	revoked, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, userID)
+	if err != nil {
+		return models.AccountDeletionResponse{}, err
+	}
+
+	s.audit(ctx, models.UserActor(userID), constants.AuditActionUserDeletionRequested, userID, map[string]any{
+		"deletion_scheduled_at": scheduledAt,
+		"sessions_revoked":      revoked,
+	})
+	return models.AccountDeletionResponse{DeletionScheduledAt: scheduledAt}, nil
+}
+
+// CancelDeletion restores a pending_deletion account, it is a no-op for any
+// other account.
+func (s *AccountDeletionService) CancelDeletion(ctx context.Context, userID int) error {
+	cancelled, err := s.DeletionRepo.CancelUserDeletion(ctx, userID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to cancel deletion")
+	}
+	if cancelled {
+		s.audit(ctx, models.UserActor(userID), constants.AuditActionUserDeletionCancelled, userID, nil)
+	}
+	return nil
+}
+
+// DeleteDueAccounts deletes the accounts whose window ended by now.
+func (s *AccountDeletionService) DeleteDueAccounts(ctx context.Context, now time.Time) (int, error) {
+	var total int
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultAccountDeletionBatchSize
+	}
+
+	for {
+		users, err := s.DeletionRepo.GetUsersDueForDeletion(ctx, now, batchSize)
+		if err != nil {
+			return total, apperr.Wrap(err, "failed to get users due for deletion")
+		}
+
+		for _, user := range users {
+			deleted, err := s.DeletionRepo.DeleteUser(ctx, user.ID, now)
+			if err != nil {
+				return total, apperr.Wrapf(err, "failed to delete user %d", user.ID)
+			}
+			if !deleted {
+				continue
+			}
+			total++
+
+			s.audit(ctx, constants.AuditActorSystem, constants.AuditActionUserDeleted, user.ID, nil)
+
+			event := &events.UserDeleted{UserId: int32(user.ID), OccurredAt: timestamppb.New(now)}
+			if user.DeletionRequestedAt != nil {
+				event.RequestedAt = timestamppb.New(*user.DeletionRequestedAt)
+			}
+			if err := s.EventPublisher.Publish(ctx, constants.EventUserDeleted, event); err != nil {
+				helpers.Logger.Error("failed to publish user deleted event: ", err)
+			}
+		}
+
+		if len(users) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *AccountDeletionService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.DeleteDueAccounts(ctx, time.Now())
+		if err != nil {
+			log.Error("failed on account deletion: ", err)
+		} else {
+			log.Info("accounts deleted: ", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// audit failures are logged rather than returned, like for admin approvals.
+func (s *AccountDeletionService) audit(ctx context.Context, actor, action string, userID int, detail any) {
+	event := &models.AuditEvent{Actor: actor, Action: action, TargetUserID: userID}
+	var err error
+	if detail != nil {
+		var details []byte
+		details, err = json.Marshal(detail)
+		event.Details = string(details)
+	}
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, event)
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
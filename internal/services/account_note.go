@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAccountNoteNotFound   = apperr.New(apperr.NotFound, "account note not found")
+	ErrAccountNoteVisibility = apperr.New(apperr.Forbidden, "compliance notes need the compliance role")
+	ErrAccountNoteNotAuthor  = apperr.New(apperr.Forbidden, "only the author can change an account note")
+)
+
+// AccountNoteService keeps support's notes on user accounts. Any admin reads
+// and writes support notes, compliance notes are hidden from admins without
+// the compliance role. Only a note's author edits or deletes it.
+type AccountNoteService struct {
+	NoteRepo  interfaces.IAccountNoteRepository
+	UserRepo  interfaces.IUserReader
+	AdminRepo interfaces.IAdminRepository
+	AuditRepo interfaces.IAuditRepository
+}
+
+func (s *AccountNoteService) GetNotes(ctx context.Context, adminID, userID int, req models.AccountNoteListRequest) (pagination.Page[models.AccountNote], error) {
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.AccountNote]{}, err
+	}
+	if err := s.checkUser(ctx, userID); err != nil {
+		return pagination.Page[models.AccountNote]{}, err
+	}
+	visibilities, err := s.visibilities(ctx, adminID)
+	if err != nil {
+		return pagination.Page[models.AccountNote]{}, err
+	}
+
+	limit := req.GetLimit()
+	notes, err := s.NoteRepo.GetAccountNotes(ctx, userID, visibilities, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.AccountNote]{}, apperr.Wrap(err, "failed to get account notes")
+	}
+
+	return pagination.NewPage(notes, limit, func(note models.AccountNote) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: note.CreatedAt, ID: note.ID}
+	}), nil
+}
+
+func (s *AccountNoteService) AddNote(ctx context.Context, adminID, userID int, req models.AccountNoteRequest) (models.AccountNote, error) {
+	if err := s.checkUser(ctx, userID); err != nil {
+		return models.AccountNote{}, err
+	}
+	if err := s.checkVisibility(ctx, adminID, req.Visibility); err != nil {
+		return models.AccountNote{}, err
+	}
+
+	note := models.AccountNote{UserID: userID, AuthorID: adminID, Visibility: req.Visibility, Body: req.Body}
+	if err := s.NoteRepo.InsertAccountNote(ctx, &note); err != nil {
+		return note, apperr.Wrap(err, "failed to insert account note")
+	}
+
+	s.audit(ctx, adminID, constants.AuditActionAccountNoteAdded, note)
+	return note, nil
+}
+
+func (s *AccountNoteService) UpdateNote(ctx context.Context, adminID, noteID int, req models.AccountNoteRequest) (models.AccountNote, error) {
+	note, err := s.getOwnNote(ctx, adminID, noteID)
+	if err != nil {
+		return note, err
+	}
+	if err := s.checkVisibility(ctx, adminID, req.Visibility); err != nil {
+		return note, err
+	}
+
+	note.Visibility = req.Visibility
+	note.Body = req.Body
+	if err := s.NoteRepo.UpdateAccountNote(ctx, &note); err != nil {
+		return note, apperr.Wrap(err, "failed to update account note")
+	}
+
+	s.audit(ctx, adminID, constants.AuditActionAccountNoteUpdated, note)
+	return note, nil
+}
+
+func (s *AccountNoteService) DeleteNote(ctx context.Context, adminID, noteID int) error {
+	note, err := s.getOwnNote(ctx, adminID, noteID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.NoteRepo.DeleteAccountNote(ctx, noteID); err != nil {
+		return apperr.Wrap(err, "failed to delete account note")
+	}
+
+	s.audit(ctx, adminID, constants.AuditActionAccountNoteDeleted, note)
+	return nil
+}
+
+func (s *AccountNoteService) checkUser(ctx context.Context, userID int) error {
+	if _, err := s.UserRepo.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return apperr.Wrap(err, "failed to get user")
+	}
+	return nil
+}
+
+// getOwnNote returns the note if adminID wrote it. Notes the admin may not
+// see are reported as not found.
+func (s *AccountNoteService) getOwnNote(ctx context.Context, adminID, noteID int) (models.AccountNote, error) {
+	note, err := s.NoteRepo.GetAccountNoteByID(ctx, noteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return note, ErrAccountNoteNotFound
+		}
+		return note, apperr.Wrap(err, "failed to get account note")
+	}
+
+	visibilities, err := s.visibilities(ctx, adminID)
+	if err != nil {
+		return note, err
+	}
+	if !slices.Contains(visibilities, note.Visibility) {
+		return models.AccountNote{}, ErrAccountNoteNotFound
+	}
+	if note.AuthorID != adminID {
+		return note, ErrAccountNoteNotAuthor
+	}
+	return note, nil
+}
+
+func (s *AccountNoteService) checkVisibility(ctx context.Context, adminID int, visibility string) error {
+	visibilities, err := s.visibilities(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(visibilities, visibility) {
+		return ErrAccountNoteVisibility
+	}
+	return nil
+}
+
+// visibilities are the notes adminID may read and write.
+func (s *AccountNoteService) visibilities(ctx context.Context, adminID int) ([]string, error) {
+	roles, err := s.AdminRepo.GetUserRoles(ctx, adminID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get user roles")
+	}
+
+	visibilities := []string{constants.AccountNoteVisibilitySupport}
+	if slices.Contains(roles, constants.RoleCompliance) {
+		visibilities = append(visibilities, constants.AccountNoteVisibilityCompliance)
+	}
+	return visibilities, nil
+}
+
+// audit failures are logged rather than returned. The body isn't copied into
+// the audit log, it would outlive the note.
+func (s *AccountNoteService) audit(ctx context.Context, adminID int, action string, note models.AccountNote) {
+	details, err := json.Marshal(map[string]any{"note_id": note.ID, "visibility": note.Visibility})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        models.UserActor(adminID),
+			Action:       action,
+			TargetUserID: note.UserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
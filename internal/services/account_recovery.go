@@ -0,0 +1,392 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRecoveryTicketNotFound   = apperr.New(apperr.NotFound, "recovery ticket not found")
+	ErrRecoveryTicketNotPending = apperr.New(apperr.Conflict, "recovery ticket is no longer pending")
+	ErrRecoveryTicketMismatch   = apperr.New(apperr.Invalid, "recovery ticket is not for the target user")
+	ErrInvalidRecoveryEvidence  = apperr.New(apperr.Invalid, "recovery evidence must be a jpeg, png or pdf file")
+	ErrTooManyRecoveryTickets   = apperr.New(apperr.RateLimited, "too many recovery requests, try again later")
+	ErrRecoveryEmailTaken       = apperr.New(apperr.Conflict, "recovery contact email is used by another account")
+	// ErrInvalidRecoveryToken covers unknown, used and expired reset links
+	// alike.
+	ErrInvalidRecoveryToken = apperr.New(apperr.Unauthorized, "invalid or expired recovery link")
+)
+
+// recoveryEvidenceTypes are the evidence file types accepted, by the type
+// sniffed from their content, with the extension they are stored under.
+var recoveryEvidenceTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+// AccountRecoveryService handles manual recovery for users who lost both
+// their email and phone number. Anyone can submit a ticket with identity
+// evidence, support reviews it, and only a recover_account action approved
+// by a second admin sends a single-use reset link to the contact email the
+// user gave. Using the link sets the password and makes the contact email
+// the account's email.
+type AccountRecoveryService struct {
+	RecoveryRepo   interfaces.IAccountRecoveryRepository
+	UserRepo       interfaces.IUserRepository
+	SessionRepo    interfaces.ISessionRepository
+	AuditRepo      interfaces.IAuditRepository
+	TokenCache     interfaces.ITokenCacheRepository
+	CallerGuard    interfaces.ICallerGuardRepository
+	PasswordHasher interfaces.IPasswordHasher
+	ObjectStorage  interfaces.IObjectStorage
+	Notifications  interfaces.INotificationService
+	// TrustedDevices is optional, completed resets also forget the user's
+	// trusted devices with it.
+	TrustedDevices interfaces.ITrustedDeviceRepository
+
+	// SubmitLimit tickets may be submitted per IP address in SubmitWindow.
+	SubmitLimit  int
+	SubmitWindow time.Duration
+	// ResetURL is the page reset links point at, the token is appended.
+	ResetURL    string
+	ResetTTL    time.Duration
+	EvidenceTTL time.Duration
+}
+
+// SubmitTicket stores the evidence and opens a ticket. Unknown identifiers
+// get a ticket too, so the response doesn't reveal whether an account
+// exists.
+func (s *AccountRecoveryService) SubmitTicket(ctx context.Context, ipAddress string, req models.RecoveryTicketRequest, evidence []models.RecoveryEvidence) (models.RecoveryTicketReceipt, error) {
+	// the failure counter doubles as a submission counter here
+	submissions, err := s.CallerGuard.RecordFailure(ctx, "recovery_ticket:"+ipAddress, s.SubmitWindow)
+	if err != nil {
+		return models.RecoveryTicketReceipt{}, apperr.Wrap(err, "failed to count recovery requests")
+	}
+	if submissions > int64(s.SubmitLimit) {
+		return models.RecoveryTicketReceipt{}, ErrTooManyRecoveryTickets
+	}
+
+	contactEmail, err := helpers.NormalizeEmail(req.ContactEmail)
+	if err != nil {
+		return models.RecoveryTicketReceipt{}, apperr.WrapAs(apperr.Invalid, err, "invalid contact email")
+	}
+
+	ticket := models.RecoveryTicket{
+		Reference:    rand.Text(),
+		Identifier:   req.Identifier,
+		ContactEmail: contactEmail,
+		FullName:     strings.TrimSpace(req.FullName),
+		Dob:          req.Dob,
+		Statement:    req.Statement,
+		Status:       constants.RecoveryTicketStatusPending,
+	}
+	if user, err := getUserByIdentifier(ctx, s.UserRepo, req.Identifier); err == nil && user.Type == constants.UserTypeHuman {
+		ticket.UserID = user.ID
+	}
+
+	for i, file := range evidence {
+		key, err := s.putEvidence(ctx, ticket.Reference, i, file)
+		if err != nil {
+			return models.RecoveryTicketReceipt{}, err
+		}
+		ticket.EvidenceKeys = append(ticket.EvidenceKeys, key)
+	}
+
+	if err := s.RecoveryRepo.InsertRecoveryTicket(ctx, &ticket); err != nil {
+		return models.RecoveryTicketReceipt{}, apperr.Wrap(err, "failed to insert recovery ticket")
+	}
+
+	s.audit(ctx, models.RecoveryTicketActor(ticket.Reference), constants.AuditActionRecoveryRequested, ticket.UserID, map[string]any{
+		"ticket_id": ticket.ID,
+		"evidence":  len(ticket.EvidenceKeys),
+	})
+	return models.RecoveryTicketReceipt{Reference: ticket.Reference}, nil
+}
+
+// putEvidence uploads one evidence file, its type is sniffed from the
+// content rather than trusted from the upload.
+func (s *AccountRecoveryService) putEvidence(ctx context.Context, reference string, index int, file models.RecoveryEvidence) (string, error) {
+	body := bufio.NewReaderSize(file.Body, 512)
+	head, err := body.Peek(512)
+	if err != nil && len(head) == 0 {
+		return "", ErrInvalidRecoveryEvidence
+	}
+
+	contentType := http.DetectContentType(head)
+	extension, ok := recoveryEvidenceTypes[contentType]
+	if !ok {
+		return "", apperr.Wrapf(ErrInvalidRecoveryEvidence, "got %s", contentType)
+	}
+
+	key := fmt.Sprintf("recovery-evidence/%s/%d%s", reference, index+1, extension)
+	if err := s.ObjectStorage.PutObject(ctx, key, contentType, body, file.Size); err != nil {
+		return "", apperr.WrapAs(apperr.Upstream, err, "failed to upload recovery evidence")
+	}
+	return key, nil
+}
+
+func (s *AccountRecoveryService) GetTickets(ctx context.Context, req models.RecoveryTicketListRequest) (pagination.Page[models.RecoveryTicket], error) {
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.RecoveryTicket]{}, err
+	}
+
+	limit := req.GetLimit()
+	tickets, err := s.RecoveryRepo.GetRecoveryTickets(ctx, req.Status, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.RecoveryTicket]{}, apperr.Wrap(err, "failed to get recovery tickets")
+	}
+
+	return pagination.NewPage(tickets, limit, func(ticket models.RecoveryTicket) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: ticket.CreatedAt, ID: ticket.ID}
+	}), nil
+}
+
+// GetTicket returns the ticket with signed evidence URLs and whether the
+// claimed name and date of birth match the account.
+func (s *AccountRecoveryService) GetTicket(ctx context.Context, ticketID int) (models.RecoveryTicket, error) {
+	ticket, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return ticket, err
+	}
+
+	ticket.EvidenceURLs = make([]string, 0, len(ticket.EvidenceKeys))
+	for _, key := range ticket.EvidenceKeys {
+		url, err := s.ObjectStorage.PresignGetURL(key, s.EvidenceTTL)
+		if err != nil {
+			return ticket, apperr.WrapAs(apperr.Upstream, err, "failed to sign evidence url")
+		}
+		ticket.EvidenceURLs = append(ticket.EvidenceURLs, url)
+	}
+
+	if ticket.UserID != 0 {
+		user, err := s.UserRepo.GetUserByID(ctx, ticket.UserID)
+		if err != nil {
+			return ticket, apperr.Wrap(err, "failed to get user")
+		}
+		// dob may be read back with a time part
+		ticket.Matches = &models.RecoveryTicketMatches{
+			FullName: user.FullName != "" && strings.EqualFold(strings.TrimSpace(user.FullName), ticket.FullName),
+			Dob:      user.Dob != "" && strings.HasPrefix(user.Dob, ticket.Dob),
+		}
+	}
+	return ticket, nil
+}
+
+func (s *AccountRecoveryService) RejectTicket(ctx context.Context, ticketID, reviewedBy int, note string) (models.RecoveryTicket, error) {
+	ticket, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return ticket, err
+	}
+
+	ticket.Status = constants.RecoveryTicketStatusRejected
+	ticket.ReviewedBy = reviewedBy
+	ticket.ReviewNote = note
+	if err := s.updateStatus(ctx, &ticket, constants.RecoveryTicketStatusPending); err != nil {
+		return ticket, err
+	}
+
+	s.audit(ctx, models.UserActor(reviewedBy), constants.AuditActionRecoveryRejected, ticket.UserID, map[string]any{"ticket_id": ticket.ID})
+	return ticket, nil
+}
+
+// CheckTicket is called when a recover_account approval is requested, so a
+// ticket that can't be approved isn't sent to a second admin.
+func (s *AccountRecoveryService) CheckTicket(ctx context.Context, ticketID, userID int) error {
+	ticket, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	if err := checkTicket(ticket, userID); err != nil {
+		return err
+	}
+	return s.checkContactEmail(ctx, ticket)
+}
+
+// checkContactEmail refuses a contact email another account already has,
+// the reset would make it the user's email.
+func (s *AccountRecoveryService) checkContactEmail(ctx context.Context, ticket models.RecoveryTicket) error {
+	other, err := s.UserRepo.GetUserByIdentifier(ctx, "", ticket.ContactEmail, "")
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil
+	case err != nil:
+		return apperr.Wrap(err, "failed to check recovery contact email")
+	case other.ID != ticket.UserID:
+		return ErrRecoveryEmailTaken
+	}
+	return nil
+}
+
+func checkTicket(ticket models.RecoveryTicket, userID int) error {
+	if ticket.Status != constants.RecoveryTicketStatusPending {
+		return ErrRecoveryTicketNotPending
+	}
+	if ticket.UserID == 0 || ticket.UserID != userID {
+		return ErrRecoveryTicketMismatch
+	}
+	return nil
+}
+
+// IssueReset executes an approved recover_account action: it emails a reset
+// link to the ticket's contact email. The account isn't changed until the
+// link is used.
+func (s *AccountRecoveryService) IssueReset(ctx context.Context, ticketID, userID, approvedBy int) error {
+	ticket, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	if err := checkTicket(ticket, userID); err != nil {
+		return err
+	}
+	if err := s.checkContactEmail(ctx, ticket); err != nil {
+		return err
+	}
+
+	token := rand.Text()
+	expiresAt := time.Now().Add(s.ResetTTL)
+	ticket.Status = constants.RecoveryTicketStatusApproved
+	ticket.ReviewedBy = approvedBy
+	ticket.ResetTokenHash = helpers.HashToken(token)
+	ticket.ResetExpiresAt = &expiresAt
+	if err := s.updateStatus(ctx, &ticket, constants.RecoveryTicketStatusPending); err != nil {
+		return err
+	}
+
+	err = s.Notifications.SendPasswordResetLink(ctx, userID, ticket.ContactEmail, models.EmailLink{
+		URL:              s.ResetURL + "?token=" + token,
+		ExpiresInMinutes: int(s.ResetTTL.Minutes()),
+	})
+	if err != nil {
+		// back to pending, so the ticket can be approved again
+		ticket.Status = constants.RecoveryTicketStatusPending
+		ticket.ResetTokenHash = ""
+		ticket.ResetExpiresAt = nil
+		if updateErr := s.updateStatus(ctx, &ticket, constants.RecoveryTicketStatusApproved); updateErr != nil {
+			helpers.Logger.Error("failed to reopen recovery ticket: ", updateErr)
+		}
+		return apperr.Wrap(err, "failed to send recovery link")
+	}
+
+	s.audit(ctx, models.UserActor(approvedBy), constants.AuditActionRecoveryResetIssued, userID, map[string]any{
+		"ticket_id":  ticket.ID,
+		"expires_at": expiresAt,
+	})
+	return nil
+}
+
+// CompleteReset redeems a reset link: it sets the new password, makes the
+// contact email the account's email and logs the user out everywhere.
+func (s *AccountRecoveryService) CompleteReset(ctx context.Context, req models.ManualRecoveryResetRequest) error {
+	ticket, err := s.RecoveryRepo.GetRecoveryTicketByResetToken(ctx, helpers.HashToken(req.Token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidRecoveryToken
+	}
+	if err != nil {
+		return apperr.Wrap(err, "failed to get recovery ticket")
+	}
+	if ticket.Status != constants.RecoveryTicketStatusApproved || ticket.ResetExpiresAt == nil || time.Now().After(*ticket.ResetExpiresAt) {
+		return ErrInvalidRecoveryToken
+	}
+
+	user, err := s.UserRepo.GetUserByID(ctx, ticket.UserID)
+	if err != nil || user.Status == constants.UserStatusBanned {
+		return ErrInvalidRecoveryToken
+	}
+
+	password, err := s.PasswordHasher.HashPassword(ctx, req.NewPassword)
+	if err != nil {
+		return apperr.Wrap(err, "failed to hash password")
+	}
+
+	// the link is spent along with the account change, so it works only once
+	// and stays usable when the update loses to a concurrent one
+	now := time.Now()
+	completed, err := s.RecoveryRepo.CompleteRecoveryReset(ctx, &ticket, user.Version, password, now)
+	if err != nil {
+		return apperr.Wrap(err, "failed to complete recovery reset")
+	}
+	if !completed {
+		if latest, err := s.RecoveryRepo.GetRecoveryTicketByID(ctx, ticket.ID); err == nil && latest.Status == constants.RecoveryTicketStatusApproved && latest.ResetExpiresAt != nil && now.Before(*latest.ResetExpiresAt) {
+			return ErrUserVersionConflict
+		}
+		return ErrInvalidRecoveryToken
+	}
+
+	revoked, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, user.ID)
+	if err != nil {
+		return err
+	}
+	if err := forgetTrustedDevices(ctx, s.TrustedDevices, user.ID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, models.RecoveryTicketActor(ticket.Reference), constants.AuditActionUserRecovered, user.ID, map[string]any{
+		"factor":           "manual_review",
+		"ticket_id":        ticket.ID,
+		"sessions_revoked": revoked,
+	})
+	s.Notifications.NotifySecurityEvent(ctx, user.ID, models.SecurityEvent{Event: constants.NotificationEventPasswordChanged})
+	if user.Email != "" && user.Email != ticket.ContactEmail {
+		s.Notifications.NotifySecurityEvent(ctx, user.ID, models.SecurityEvent{
+			Event:         constants.NotificationEventEmailChanged,
+			PreviousEmail: user.Email,
+		})
+	}
+	return nil
+}
+
+func (s *AccountRecoveryService) getTicket(ctx context.Context, ticketID int) (models.RecoveryTicket, error) {
+	ticket, err := s.RecoveryRepo.GetRecoveryTicketByID(ctx, ticketID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ticket, apperr.Wrapf(ErrRecoveryTicketNotFound, "ticket %d", ticketID)
+	}
+	if err != nil {
+		return ticket, apperr.Wrap(err, "failed to get recovery ticket")
+	}
+	return ticket, nil
+}
+
+func (s *AccountRecoveryService) updateStatus(ctx context.Context, ticket *models.RecoveryTicket, fromStatus string) error {
+	won, err := s.RecoveryRepo.UpdateRecoveryTicketStatus(ctx, ticket, fromStatus)
+	if err != nil {
+		return apperr.Wrap(err, "failed to update recovery ticket")
+	}
+	if !won {
+		return apperr.Wrapf(ErrRecoveryTicketNotPending, "ticket %d", ticket.ID)
+	}
+	return nil
+}
+
+// audit failures are logged rather than returned, like for admin approvals.
+func (s *AccountRecoveryService) audit(ctx context.Context, actor, action string, targetUserID int, detail any) {
+	details, err := json.Marshal(detail)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        actor,
+			Action:       action,
+			TargetUserID: targetUserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
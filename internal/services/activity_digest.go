@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+// ActivityDigestService sends opted-in users a summary of last month's
+// logins and devices. It runs often, but each user's digest is only sent
+// once per month: the month is claimed before sending and released again if
+// the email fails, so the next run retries it.
+type ActivityDigestService struct {
+	DigestRepo    interfaces.IActivityDigestRepository
+	Notifications interfaces.INotificationService
+
+	BatchSize int
+	Interval  time.Duration
+}
+
+// SendDigests sends the digests for the month before now that are still
+// due and returns how many were sent.
+func (s *ActivityDigestService) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, -1, 0)
+	month := from.Format("2006-01")
+
+	sent := 0
+	for afterID := 0; ; {
+		userIDs, err := s.DigestRepo.GetDigestRecipients(ctx, month, afterID, s.BatchSize)
+		if err != nil {
+			return sent, apperr.Wrap(err, "failed to get digest recipients")
+		}
+		if len(userIDs) == 0 {
+			return sent, nil
+		}
+
+		for _, userID := range userIDs {
+			afterID = userID
+
+			digest := &models.ActivityDigest{UserID: userID, Month: month}
+			claimed, err := s.DigestRepo.ClaimActivityDigest(ctx, digest)
+			if err != nil {
+				return sent, apperr.Wrap(err, "failed to claim activity digest")
+			}
+			if !claimed {
+				continue
+			}
+
+			if err := s.sendDigest(ctx, userID, month, from, to); err != nil {
+				helpers.Logger.Errorf("failed to send activity digest to user %d: %v", userID, err)
+				if err := s.DigestRepo.DeleteActivityDigest(ctx, digest); err != nil {
+					return sent, apperr.Wrap(err, "failed to release activity digest")
+				}
+				continue
+			}
+			sent++
+		}
+	}
+}
+
+func (s *ActivityDigestService) sendDigest(ctx context.Context, userID int, month string, from, to time.Time) error {
+	histories, err := s.DigestRepo.GetLoginHistoriesBetween(ctx, userID, from, to)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get login histories")
+	}
+
+	summary := models.ActivitySummary{Month: month}
+	for _, history := range histories {
+		if !history.Success {
+			summary.FailedLogins++
+			continue
+		}
+		summary.SuccessfulLogins++
+		if history.UserAgent != "" && !slices.Contains(summary.Devices, history.UserAgent) {
+			summary.Devices = append(summary.Devices, history.UserAgent)
+		}
+		if history.IPAddress != "" && !slices.Contains(summary.IPAddresses, history.IPAddress) {
+			summary.IPAddresses = append(summary.IPAddresses, history.IPAddress)
+		}
+	}
+
+	// nothing to report, the claim still stands so the month isn't retried
+	if len(histories) == 0 {
+		return nil
+	}
+	return s.Notifications.SendActivityDigest(ctx, userID, summary)
+}
+
+func (s *ActivityDigestService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := s.SendDigests(ctx, time.Now())
+		if err != nil {
+			log.Error("failed on activity digest: ", err)
+		} else if sent > 0 {
+			log.Info("activity digests sent: ", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
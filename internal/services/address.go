@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAddressNotFound   = apperr.New(apperr.NotFound, "address not found")
+	ErrTooManyAddresses  = apperr.New(apperr.Invalid, "too many addresses, remove one first")
+	ErrInvalidPostalCode = apperr.New(apperr.Invalid, "postal code doesn't match the country's format")
+)
+
+// AddressService manages users' postal addresses. Other services read them,
+// the primary one first, over the User gRPC service for KYC and card
+// issuance.
+type AddressService struct {
+	AddressRepo interfaces.IAddressRepository
+	UserRepo    interfaces.IUserReader
+	AuditRepo   interfaces.IAuditRepository
+
+	// MaxPerUser caps a user's addresses, 0 doesn't.
+	MaxPerUser int
+}
+
+func (s *AddressService) GetAddresses(ctx context.Context, userID int) ([]models.UserAddress, error) {
+	addresses, err := s.AddressRepo.GetUserAddresses(ctx, userID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get addresses")
+	}
+	return addresses, nil
+}
+
+// GetUserAddresses is GetAddresses for other services, failing for unknown
+// users rather than listing nothing.
+func (s *AddressService) GetUserAddresses(ctx context.Context, userID int) ([]models.UserAddress, error) {
+	if _, err := s.UserRepo.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, apperr.Wrap(err, "failed to get user")
+	}
+	return s.GetAddresses(ctx, userID)
+}
+
+func (s *AddressService) AddAddress(ctx context.Context, userID int, req models.AddressRequest) (models.UserAddress, error) {
+	address, err := newUserAddress(userID, req)
+	if err != nil {
+		return address, err
+	}
+
+	if s.MaxPerUser > 0 {
+		addresses, err := s.AddressRepo.GetUserAddresses(ctx, userID)
+		if err != nil {
+			return address, apperr.Wrap(err, "failed to get addresses")
+		}
+		if len(addresses) >= s.MaxPerUser {
+			return address, ErrTooManyAddresses
+		}
+	}
+
+	if err := s.AddressRepo.InsertUserAddress(ctx, &address); err != nil {
+		return address, apperr.Wrap(err, "failed to insert address")
+	}
+
+	s.audit(ctx, userID, constants.AuditActionAddressAdded, address)
+	return address, nil
+}
+
+// UpdateAddress replaces the address, it stays primary if it was.
+func (s *AddressService) UpdateAddress(ctx context.Context, userID, id int, req models.AddressRequest) (models.UserAddress, error) {
+	address, err := s.getAddress(ctx, userID, id)
+	if err != nil {
+		return address, err
+	}
+
+	updated, err := newUserAddress(userID, req)
+	if err != nil {
+		return address, err
+	}
+	updated.ID, updated.IsPrimary, updated.CreatedAt = address.ID, address.IsPrimary, address.CreatedAt
+
+	found, err := s.AddressRepo.UpdateUserAddress(ctx, &updated)
+	if err != nil {
+		return address, apperr.Wrap(err, "failed to update address")
+	}
+	if !found {
+		return address, ErrAddressNotFound
+	}
+
+	s.audit(ctx, userID, constants.AuditActionAddressUpdated, updated)
+	return updated, nil
+}
+
+func (s *AddressService) SetPrimaryAddress(ctx context.Context, userID, id int) error {
+	address, err := s.getAddress(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if address.IsPrimary {
+		return nil
+	}
+
+	found, err := s.AddressRepo.SetPrimaryUserAddress(ctx, userID, id)
+	if err != nil {
+		return apperr.Wrap(err, "failed to set primary address")
+	}
+	if !found {
+		return ErrAddressNotFound
+	}
+
+	s.audit(ctx, userID, constants.AuditActionAddressPrimary, address)
+	return nil
+}
+
+func (s *AddressService) DeleteAddress(ctx context.Context, userID, id int) error {
+	address, err := s.getAddress(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.AddressRepo.DeleteUserAddress(ctx, userID, id)
+	if err != nil {
+		return apperr.Wrap(err, "failed to delete address")
+	}
+	if !deleted {
+		return ErrAddressNotFound
+	}
+
+	s.audit(ctx, userID, constants.AuditActionAddressRemoved, address)
+	return nil
+}
+
+func (s *AddressService) getAddress(ctx context.Context, userID, id int) (models.UserAddress, error) {
+	address, err := s.AddressRepo.GetUserAddress(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return address, ErrAddressNotFound
+		}
+		return address, apperr.Wrap(err, "failed to get address")
+	}
+	return address, nil
+}
+
+func newUserAddress(userID int, req models.AddressRequest) (models.UserAddress, error) {
+	postalCode, err := helpers.NormalizePostalCode(req.Country, req.PostalCode)
+	if err != nil {
+		return models.UserAddress{}, ErrInvalidPostalCode
+	}
+
+	return models.UserAddress{
+		UserID:     userID,
+		Type:       req.Type,
+		Line1:      req.Line1,
+		Line2:      req.Line2,
+		City:       req.City,
+		Region:     req.Region,
+		PostalCode: postalCode,
+		Country:    req.Country,
+		IsPrimary:  req.IsPrimary,
+	}, nil
+}
+
+// audit failures are logged rather than returned, the address is already
+// changed. Only the type and country are recorded, the rest is PII.
+func (s *AddressService) audit(ctx context.Context, userID int, action string, address models.UserAddress) {
+	details, err := json.Marshal(map[string]any{"address_id": address.ID, "type": address.Type, "country": address.Country})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        models.UserActor(userID),
+			Action:       action,
+			TargetUserID: userID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
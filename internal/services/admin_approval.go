@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+)
+
+var (
+	ErrApprovalNotPending = apperr.New(apperr.Conflict, "admin approval is no longer pending")
+	ErrSelfApproval       = apperr.New(apperr.Forbidden, "admin approval must be reviewed by a different admin")
+	ErrComplianceApproval = apperr.New(apperr.Forbidden, "admin approval must be approved by a compliance officer")
+)
+
+type AdminApprovalService struct {
+	AdminRepo   interfaces.IAdminRepository
+	UserRepo    interfaces.IUserReader
+	SessionRepo interfaces.ISessionRepository
+	AuditRepo   interfaces.IAuditRepository
+	TokenCache  interfaces.ITokenCacheRepository
+	// Recovery executes recover_account approvals.
+	Recovery interfaces.IAccountRecoveryService
+	// Purges executes purge_user approvals.
+	Purges interfaces.IUserPurgeService
+}
+
+func (s *AdminApprovalService) RequestApproval(ctx context.Context, requestedBy int, req models.AdminApprovalRequest) (models.AdminApproval, error) {
+	return s.requestApproval(ctx, models.UserActor(requestedBy), requestedBy, req)
+}
+
+// RequestApprovalAs requests an approval on behalf of actor, for back office
+// callers of the gRPC admin API. A caller authenticated by client
+// certificate isn't a user, its approvals have no requested_by and any admin
+// can review them, the audit trail names the certificate.
+func (s *AdminApprovalService) RequestApprovalAs(ctx context.Context, actor string, req models.AdminApprovalRequest) (models.AdminApproval, error) {
+	requestedBy, _ := models.ActorUserID(actor)
+	return s.requestApproval(ctx, actor, requestedBy, req)
+}
+
+func (s *AdminApprovalService) requestApproval(ctx context.Context, actor string, requestedBy int, req models.AdminApprovalRequest) (models.AdminApproval, error) {
+	if _, err := s.UserRepo.GetUserByID(ctx, req.TargetUserID); err != nil {
+		return models.AdminApproval{}, apperr.Wrap(err, "failed to get target user")
+	}
+	if req.Action == constants.AdminActionRecoverAccount {
+		payload := models.RecoverAccountPayload{}
+		if err := json.Unmarshal(req.Payload, &payload); err != nil {
+			return models.AdminApproval{}, apperr.WrapAs(apperr.Invalid, err, "invalid recover_account payload")
+		}
+		if err := s.Recovery.CheckTicket(ctx, payload.TicketID, req.TargetUserID); err != nil {
+			return models.AdminApproval{}, apperr.Wrap(err, "failed to check recovery ticket")
+		}
+	}
+	if req.Action == constants.AdminActionPurgeUser {
+		if err := s.Purges.CheckPurge(ctx, req.TargetUserID); err != nil {
+			return models.AdminApproval{}, err
+		}
+	}
+
+	approval := models.AdminApproval{
+		Action:       req.Action,
+		TargetUserID: req.TargetUserID,
+		Payload:      string(req.Payload),
+		Reason:       req.Reason,
+		Status:       constants.ApprovalStatusPending,
+		RequestedBy:  requestedBy,
+	}
+	if err := s.AdminRepo.InsertAdminApproval(ctx, &approval); err != nil {
+		return approval, apperr.Wrap(err, "failed to insert admin approval")
+	}
+
+	s.audit(ctx, actor, constants.AuditActionApprovalRequested, approval)
+	return approval, nil
+}
+
+func (s *AdminApprovalService) GetApprovals(ctx context.Context, req models.AdminApprovalListRequest) (pagination.Page[models.AdminApproval], error) {
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.AdminApproval]{}, err
+	}
+
+	limit := req.GetLimit()
+	approvals, err := s.AdminRepo.GetAdminApprovals(ctx, req.Status, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.AdminApproval]{}, apperr.Wrap(err, "failed to get admin approvals")
+	}
+
+	return pagination.NewPage(approvals, limit, func(approval models.AdminApproval) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: approval.CreatedAt, ID: approval.ID}
+	}), nil
+}
+
+// Approve claims the approval for reviewedBy and then executes the action.
+// An action that fails to execute leaves the approval failed, it has to be
+// requested again rather than retried by approving twice.
+func (s *AdminApprovalService) Approve(ctx context.Context, approvalID, reviewedBy int, note string) (models.AdminApproval, error) {
+	if err := s.checkApprover(ctx, approvalID, reviewedBy); err != nil {
+		return models.AdminApproval{}, err
+	}
+
+	approval, err := s.review(ctx, approvalID, reviewedBy, note, constants.ApprovalStatusApproved)
+	if err != nil {
+		return approval, err
+	}
+
+	if err := s.execute(ctx, approval); err != nil {
+		approval.Status = constants.ApprovalStatusFailed
+		if updateErr := s.AdminRepo.UpdateAdminApprovalStatus(ctx, approval.ID, approval.Status); updateErr != nil {
+			helpers.Logger.Error("failed to mark admin approval failed: ", updateErr)
+		}
+		s.audit(ctx, models.UserActor(reviewedBy), constants.AuditActionApprovalFailed, approval)
+		return approval, apperr.Wrapf(err, "failed to execute %s", approval.Action)
+	}
+
+	s.audit(ctx, models.UserActor(reviewedBy), constants.AuditActionApprovalApproved, approval)
+	return approval, nil
+}
+
+func (s *AdminApprovalService) Reject(ctx context.Context, approvalID, reviewedBy int, note string) (models.AdminApproval, error) {
+	approval, err := s.review(ctx, approvalID, reviewedBy, note, constants.ApprovalStatusRejected)
+	if err != nil {
+		return approval, err
+	}
+
+	s.audit(ctx, models.UserActor(reviewedBy), constants.AuditActionApprovalRejected, approval)
+	return approval, nil
+}
+
+// checkApprover refuses purge_user approvals from anyone but a compliance
+// officer, the second pair of eyes on an irreversible delete.
+func (s *AdminApprovalService) checkApprover(ctx context.Context, approvalID, reviewedBy int) error {
+	approval, err := s.AdminRepo.GetAdminApprovalByID(ctx, approvalID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get admin approval")
+	}
+	if approval.Action != constants.AdminActionPurgeUser {
+		return nil
+	}
+
+	roles, err := s.AdminRepo.GetUserRoles(ctx, reviewedBy)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get user roles")
+	}
+	if !slices.Contains(roles, constants.RoleCompliance) {
+		return ErrComplianceApproval
+	}
+	return nil
+}
+
+func (s *AdminApprovalService) review(ctx context.Context, approvalID, reviewedBy int, note, status string) (models.AdminApproval, error) {
+	approval, err := s.AdminRepo.GetAdminApprovalByID(ctx, approvalID)
+	if err != nil {
+		return approval, apperr.Wrap(err, "failed to get admin approval")
+	}
+	if approval.Status != constants.ApprovalStatusPending {
+		return approval, ErrApprovalNotPending
+	}
+	if approval.RequestedBy == reviewedBy {
+		return approval, ErrSelfApproval
+	}
+
+	now := time.Now()
+	approval.Status = status
+	approval.ReviewedBy = reviewedBy
+	approval.ReviewNote = note
+	approval.ReviewedAt = &now
+
+	won, err := s.AdminRepo.ReviewAdminApproval(ctx, &approval)
+	if err != nil {
+		return approval, apperr.Wrap(err, "failed to review admin approval")
+	}
+	if !won {
+		return approval, ErrApprovalNotPending
+	}
+	return approval, nil
+}
+
+func (s *AdminApprovalService) execute(ctx context.Context, approval models.AdminApproval) error {
+	switch approval.Action {
+	case constants.AdminActionBanUser:
+		// approved bans apply whatever changed since they were requested
+		if _, err := s.AdminRepo.UpdateUserStatus(ctx, approval.TargetUserID, 0, constants.UserStatusBanned); err != nil {
+			return err
+		}
+		_, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, approval.TargetUserID)
+		return err
+	case constants.AdminActionGrantRole:
+		payload := models.GrantRolePayload{}
+		if err := json.Unmarshal([]byte(approval.Payload), &payload); err != nil {
+			return err
+		}
+		return s.AdminRepo.InsertUserRole(ctx, &models.UserRole{UserID: approval.TargetUserID, Role: payload.Role})
+	case constants.AdminActionRecoverAccount:
+		payload := models.RecoverAccountPayload{}
+		if err := json.Unmarshal([]byte(approval.Payload), &payload); err != nil {
+			return err
+		}
+		return s.Recovery.IssueReset(ctx, payload.TicketID, approval.TargetUserID, approval.ReviewedBy)
+	case constants.AdminActionPurgeUser:
+		return s.Purges.SchedulePurge(ctx, approval)
+	default:
+		return apperr.Newf(apperr.Invalid, "unknown admin action %q", approval.Action)
+	}
+}
+
+// audit failures are logged rather than returned, the action itself has
+// already been recorded on the approval row.
+func (s *AdminApprovalService) audit(ctx context.Context, actor, action string, approval models.AdminApproval) {
+	details, err := json.Marshal(approval)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        actor,
+			Action:       action,
+			TargetUserID: approval.TargetUserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
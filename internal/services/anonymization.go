@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultAnonymizationBatchSize stands in for a BatchSize that isn't
+// positive, fetching batches of none would never finish.
+const defaultAnonymizationBatchSize = 100
+
+type AnonymizationService struct {
+	AnonymizationRepo interfaces.IAnonymizationRepository
+	AuditRepo         interfaces.IAuditRepository
+	EventPublisher    interfaces.IEventPublisher
+	GracePeriod       time.Duration
+	BatchSize         int
+	Interval          time.Duration
+}
+
+// AnonymizeDeletedUsers irreversibly anonymizes users soft-deleted more than
+// GracePeriod ago, auditing each one and emitting user.anonymized so other
+// services can scrub their copies.
+func (s *AnonymizationService) AnonymizeDeletedUsers(ctx context.Context, now time.Time) (int, error) {
+	var total int
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultAnonymizationBatchSize
+	}
+
+	for {
+		userIDs, err := s.AnonymizationRepo.GetUserIDsToAnonymize(ctx, now.Add(-s.GracePeriod), batchSize)
+		if err != nil {
+			return total, apperr.Wrap(err, "failed to get users to anonymize")
+		}
+
+		for _, userID := range userIDs {
+			anonymized, err := s.AnonymizationRepo.AnonymizeUser(ctx, userID, now)
+			if err != nil {
+				return total, apperr.Wrapf(err, "failed to anonymize user %d", userID)
+			}
+			if !anonymized {
+				continue
+			}
+			total++
+
+			err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+				Actor:        constants.AuditActorSystem,
+				Action:       constants.AuditActionUserAnonymized,
+				TargetUserID: userID,
+			})
+			if err != nil {
+				helpers.Logger.Error("failed to insert audit event: ", err)
+			}
+
+			err = s.EventPublisher.Publish(ctx, constants.EventUserAnonymized, &events.UserAnonymized{
+				UserId:     int32(userID),
+				OccurredAt: timestamppb.New(now),
+			})
+			if err != nil {
+				helpers.Logger.Error("failed to publish user anonymized event: ", err)
+			}
+		}
+
+		if len(userIDs) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *AnonymizationService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		anonymized, err := s.AnonymizeDeletedUsers(ctx, time.Now())
+		if err != nil {
+			log.Error("failed on user anonymization: ", err)
+		} else {
+			log.Info("deleted users anonymized: ", anonymized)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+)
+
+var ErrAuditExportTooLarge = apperr.New(apperr.Invalid, "too many audit events to export, narrow the filters")
+
+// auditExportBatchSize is how many events an export reads at a time.
+const auditExportBatchSize = 500
+
+var auditExportHeader = []string{"id", "created_at", "actor", "action", "target_user_id", "details", "ticket_ref"}
+
+// AuditService lets compliance query the audit_events table, page by page
+// or as a CSV export. Exports are audited themselves.
+type AuditService struct {
+	AuditRepo interfaces.IAuditRepository
+
+	// ExportMaxRows caps an export, which is built in memory.
+	ExportMaxRows int
+}
+
+func (s *AuditService) GetAuditEvents(ctx context.Context, req models.AuditEventListRequest) (pagination.Page[models.AuditEvent], error) {
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.AuditEvent]{}, err
+	}
+
+	limit := req.GetLimit()
+	events, err := s.AuditRepo.GetAuditEvents(ctx, req.AuditEventFilter, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.AuditEvent]{}, apperr.Wrap(err, "failed to get audit events")
+	}
+
+	return pagination.NewPage(events, limit, auditEventCursor), nil
+}
+
+// ExportAuditEvents returns every event matching filter as CSV, newest first.
+func (s *AuditService) ExportAuditEvents(ctx context.Context, requestedBy int, filter models.AuditEventFilter) ([]byte, error) {
+	count, err := s.AuditRepo.CountAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to count audit events")
+	}
+	if count > int64(s.ExportMaxRows) {
+		return nil, ErrAuditExportTooLarge
+	}
+
+	body := bytes.Buffer{}
+	writer := csv.NewWriter(&body)
+	if err := writer.Write(auditExportHeader); err != nil {
+		return nil, apperr.Wrap(err, "failed to write audit export")
+	}
+
+	var cursor *pagination.Cursor
+	for {
+		events, err := s.AuditRepo.GetAuditEvents(ctx, filter, cursor, auditExportBatchSize)
+		if err != nil {
+			return nil, apperr.Wrap(err, "failed to get audit events")
+		}
+
+		page := pagination.NewPage(events, auditExportBatchSize, auditEventCursor)
+		for _, event := range page.Items {
+			target := ""
+			if event.TargetUserID != 0 {
+				target = strconv.Itoa(event.TargetUserID)
+			}
+			record := []string{strconv.Itoa(event.ID), event.CreatedAt.UTC().Format(time.RFC3339), event.Actor, event.Action, target, event.Details, event.TicketRef}
+			if err := writer.Write(record); err != nil {
+				return nil, apperr.Wrap(err, "failed to write audit export")
+			}
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		last := auditEventCursor(page.Items[len(page.Items)-1])
+		cursor = &last
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, apperr.Wrap(err, "failed to write audit export")
+	}
+
+	s.audit(ctx, requestedBy, filter, count)
+	return body.Bytes(), nil
+}
+
+func auditEventCursor(event models.AuditEvent) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: event.CreatedAt, ID: event.ID}
+}
+
+// audit failures are logged rather than returned, the export is done.
+func (s *AuditService) audit(ctx context.Context, requestedBy int, filter models.AuditEventFilter, rows int64) {
+	details, err := json.Marshal(map[string]any{"filter": filter, "rows": rows})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:   models.UserActor(requestedBy),
+			Action:  constants.AuditActionAuditExported,
+			Details: string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
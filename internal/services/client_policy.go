@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+)
+
+// clientTokenPolicy looks up the registered client and builds the policy its
+// tokens are issued under. An empty clientID gets the default policy.
+func clientTokenPolicy(ctx context.Context, clientRepo interfaces.IClientRepository, clientID, requestedScope string) (helpers.TokenPolicy, error) {
+	if clientID == "" {
+		return helpers.TokenPolicy{}, nil
+	}
+
+	client, err := clientRepo.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return helpers.TokenPolicy{}, apperr.Wrapf(err, "failed to get client %q", clientID)
+	}
+
+	return helpers.TokenPolicy{
+		ClientID: client.ClientID,
+		Scope:    grantedScope(client.AllowedScopes(), requestedScope),
+		Audience: client.Audience,
+		TTL: map[string]time.Duration{
+			"token":         time.Duration(client.AccessTokenTTLSeconds) * time.Second,
+			"refresh_token": time.Duration(client.RefreshTokenTTLSeconds) * time.Second,
+		},
+		Claims: client.ClaimSet(),
+	}, nil
+}
+
+// grantedScope keeps the requested scopes that are allowed, or grants all
+// of them when none are requested.
+func grantedScope(allowed []string, requestedScope string) string {
+	if strings.TrimSpace(requestedScope) == "" {
+		return strings.Join(allowed, " ")
+	}
+
+	granted := []string{}
+	for _, scope := range strings.Fields(requestedScope) {
+		if slices.Contains(allowed, scope) && !slices.Contains(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	return strings.Join(granted, " ")
+}
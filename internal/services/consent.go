@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+type ConsentService struct {
+	ConsentRepo interfaces.IConsentRepository
+}
+
+// GetConsents returns the user's current choice per consent type. Types the
+// user never answered are absent, which callers must treat as not granted.
+// It doubles as the consent snapshot included in user data exports.
+func (s *ConsentService) GetConsents(ctx context.Context, userID int) ([]models.UserConsent, error) {
+	consents, err := s.ConsentRepo.GetLatestUserConsents(ctx, userID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get user consents")
+	}
+	return consents, nil
+}
+
+// UpdateConsents records only the items that differ from the current choice
+// or version, so resubmitting the same form doesn't grow the history.
+func (s *ConsentService) UpdateConsents(ctx context.Context, userID int, req models.UpdateConsentsRequest) ([]models.UserConsent, error) {
+	current, err := s.GetConsents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentByType := map[string]models.UserConsent{}
+	for _, consent := range current {
+		currentByType[consent.ConsentType] = consent
+	}
+
+	changes := []models.UserConsent{}
+	for _, item := range req.Consents {
+		existing, ok := currentByType[item.Type]
+		if ok && existing.Granted == *item.Granted && existing.Version == item.Version {
+			continue
+		}
+		changes = append(changes, models.UserConsent{
+			UserID:      userID,
+			ConsentType: item.Type,
+			Granted:     *item.Granted,
+			Version:     item.Version,
+			IPAddress:   req.IPAddress,
+			UserAgent:   req.UserAgent,
+		})
+	}
+
+	if len(changes) > 0 {
+		if err := s.ConsentRepo.InsertUserConsents(ctx, changes); err != nil {
+			return nil, apperr.Wrap(err, "failed to insert user consents")
+		}
+	}
+
+	return s.GetConsents(ctx, userID)
+}
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDeadLetterNotFound      = apperr.New(apperr.NotFound, "dead letter not found")
+	ErrDeadLetterResolved      = apperr.New(apperr.Conflict, "dead letter is already replayed or discarded")
+	ErrInvalidDeadLetter       = apperr.New(apperr.Invalid, "payload doesn't match the topic's schema")
+	ErrInvalidDeadLetterStatus = apperr.New(apperr.Invalid, "unknown dead letter status")
+	ErrDeadLetterReplayFailed  = apperr.New(apperr.Upstream, "failed to replay dead letter, it stays pending")
+)
+
+// DeadLetterService keeps the events that failed for good, so none is lost:
+// the ones this service couldn't publish and the ones it consumed and gave
+// up on. Admins look into them, fix their payload if need be, and replay or
+// discard them. A replayed event is published again, or added to its stream
+// again for the consumers to handle.
+type DeadLetterService struct {
+	DeadLetterRepo interfaces.IDeadLetterRepository
+	// Publisher and Consumer send replays. Publisher must not be a
+	// DeadLetterPublisher, a failed replay stays the same dead letter.
+	Publisher interfaces.IEventPublisher
+	Consumer  interfaces.IEventConsumer
+	AuditRepo interfaces.IAuditRepository
+}
+
+// RecordPublished keeps an event that couldn't be published.
+func (s *DeadLetterService) RecordPublished(ctx context.Context, topic string, message []byte, reason string) error {
+	return s.record(ctx, &models.DeadLetter{
+		Direction: constants.DeadLetterDirectionPublish,
+		Topic:     topic,
+		Payload:   message,
+		Error:     reason,
+	})
+}
+
+// RecordConsumed keeps a consumed event that couldn't be handled.
+func (s *DeadLetterService) RecordConsumed(ctx context.Context, topic, messageID string, payload []byte, reason string) error {
+	return s.record(ctx, &models.DeadLetter{
+		Direction: constants.DeadLetterDirectionConsume,
+		Topic:     topic,
+		MessageID: messageID,
+		Payload:   payload,
+		Error:     reason,
+	})
+}
+
+func (s *DeadLetterService) record(ctx context.Context, deadLetter *models.DeadLetter) error {
+	// a payload that isn't JSON is kept as a JSON string, the column is
+	// served as JSON
+	if !json.Valid(deadLetter.Payload) {
+		quoted, err := json.Marshal(string(deadLetter.Payload))
+		if err != nil {
+			return apperr.Wrap(err, "failed to quote dead letter payload")
+		}
+		deadLetter.Payload = quoted
+	}
+
+	deadLetter.Status = constants.DeadLetterStatusPending
+	if err := s.DeadLetterRepo.InsertDeadLetter(ctx, deadLetter); err != nil {
+		return apperr.Wrap(err, "failed to insert dead letter")
+	}
+	helpers.DeadLetters.WithLabelValues(deadLetter.Direction, deadLetter.Topic).Inc()
+	helpers.Logger.Warnf("failed to %s %s event, dead lettered as %d: %s", deadLetter.Direction, deadLetter.Topic, deadLetter.ID, deadLetter.Error)
+	return nil
+}
+
+func (s *DeadLetterService) GetDeadLetters(ctx context.Context, req models.DeadLetterListRequest) (pagination.Page[models.DeadLetter], error) {
+	switch req.Status {
+	case "", constants.DeadLetterStatusPending, constants.DeadLetterStatusReplayed, constants.DeadLetterStatusDiscarded:
+	default:
+		return pagination.Page[models.DeadLetter]{}, ErrInvalidDeadLetterStatus
+	}
+
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.DeadLetter]{}, err
+	}
+
+	limit := req.GetLimit()
+	deadLetters, err := s.DeadLetterRepo.GetDeadLetters(ctx, req.Status, req.Topic, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.DeadLetter]{}, apperr.Wrap(err, "failed to get dead letters")
+	}
+
+	return pagination.NewPage(deadLetters, limit, func(deadLetter models.DeadLetter) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: deadLetter.CreatedAt, ID: deadLetter.ID}
+	}), nil
+}
+
+func (s *DeadLetterService) GetDeadLetter(ctx context.Context, deadLetterID int) (models.DeadLetter, error) {
+	deadLetter, err := s.DeadLetterRepo.GetDeadLetterByID(ctx, deadLetterID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return deadLetter, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return deadLetter, apperr.Wrap(err, "failed to get dead letter")
+	}
+	return deadLetter, nil
+}
+
+// UpdateDeadLetter fixes the payload of a pending dead letter. Payloads of
+// published events must still match the topic's schema.
+func (s *DeadLetterService) UpdateDeadLetter(ctx context.Context, actor string, deadLetterID int, req models.DeadLetterUpdateRequest) (models.DeadLetter, error) {
+	deadLetter, err := s.GetDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		return deadLetter, err
+	}
+	if deadLetter.Status != constants.DeadLetterStatusPending {
+		return deadLetter, ErrDeadLetterResolved
+	}
+
+	if !json.Valid(req.Payload) {
+		return deadLetter, ErrInvalidDeadLetter
+	}
+	if deadLetter.Direction == constants.DeadLetterDirectionPublish {
+		if _, err := events.Unmarshal(deadLetter.Topic, req.Payload); err != nil {
+			return deadLetter, apperr.Wrapf(ErrInvalidDeadLetter, "%v", err)
+		}
+	}
+
+	previous := deadLetter.Payload
+	deadLetter.Payload = req.Payload
+	deadLetter.Note = req.Note
+	updated, err := s.DeadLetterRepo.UpdateDeadLetter(ctx, &deadLetter, constants.DeadLetterStatusPending)
+	if err != nil {
+		return deadLetter, apperr.Wrap(err, "failed to update dead letter")
+	}
+	if !updated {
+		return deadLetter, ErrDeadLetterResolved
+	}
+
+	s.audit(ctx, actor, constants.AuditActionDeadLetterUpdated, map[string]any{
+		"dead_letter_id":   deadLetter.ID,
+		"topic":            deadLetter.Topic,
+		"previous_payload": previous,
+		"note":             req.Note,
+	})
+	return deadLetter, nil
+}
+
+// ReplayDeadLetter sends a pending dead letter again. It is claimed first, so
+// concurrent replays send it once. A failed send puts it back to pending with
+// the error.
+func (s *DeadLetterService) ReplayDeadLetter(ctx context.Context, actor string, deadLetterID int) (models.DeadLetter, error) {
+	deadLetter, err := s.GetDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		return deadLetter, err
+	}
+	if deadLetter.Status != constants.DeadLetterStatusPending {
+		return deadLetter, ErrDeadLetterResolved
+	}
+
+	now := time.Now()
+	deadLetter.Status = constants.DeadLetterStatusReplayed
+	deadLetter.ResolvedBy = actor
+	deadLetter.ResolvedAt = &now
+	claimed, err := s.DeadLetterRepo.UpdateDeadLetter(ctx, &deadLetter, constants.DeadLetterStatusPending)
+	if err != nil {
+		return deadLetter, apperr.Wrap(err, "failed to claim dead letter")
+	}
+	if !claimed {
+		return deadLetter, ErrDeadLetterResolved
+	}
+
+	if sendErr := s.send(ctx, deadLetter); sendErr != nil {
+		deadLetter.Status = constants.DeadLetterStatusPending
+		deadLetter.ResolvedBy = ""
+		deadLetter.ResolvedAt = nil
+		deadLetter.Replays++
+		deadLetter.Error = sendErr.Error()
+		if _, err := s.DeadLetterRepo.UpdateDeadLetter(ctx, &deadLetter, constants.DeadLetterStatusReplayed); err != nil {
+			helpers.Logger.Errorf("failed to release dead letter %d: %v", deadLetter.ID, err)
+		}
+		return deadLetter, apperr.Wrapf(ErrDeadLetterReplayFailed, "%v", sendErr)
+	}
+
+	s.audit(ctx, actor, constants.AuditActionDeadLetterReplayed, map[string]any{
+		"dead_letter_id": deadLetter.ID,
+		"direction":      deadLetter.Direction,
+		"topic":          deadLetter.Topic,
+	})
+	return deadLetter, nil
+}
+
+func (s *DeadLetterService) send(ctx context.Context, deadLetter models.DeadLetter) error {
+	payload := []byte(deadLetter.Payload)
+	// payloads that weren't JSON were kept quoted
+	var raw string
+	if json.Unmarshal(payload, &raw) == nil {
+		payload = []byte(raw)
+	}
+
+	if deadLetter.Direction == constants.DeadLetterDirectionPublish {
+		return s.Publisher.PublishMessage(ctx, deadLetter.Topic, payload)
+	}
+	return s.Consumer.Redeliver(ctx, deadLetter.Topic, payload)
+}
+
+// DiscardDeadLetter gives up on a pending dead letter for good.
+func (s *DeadLetterService) DiscardDeadLetter(ctx context.Context, actor string, deadLetterID int, req models.DeadLetterDiscardRequest) (models.DeadLetter, error) {
+	deadLetter, err := s.GetDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		return deadLetter, err
+	}
+	if deadLetter.Status != constants.DeadLetterStatusPending {
+		return deadLetter, ErrDeadLetterResolved
+	}
+
+	now := time.Now()
+	deadLetter.Status = constants.DeadLetterStatusDiscarded
+	deadLetter.ResolvedBy = actor
+	deadLetter.ResolvedAt = &now
+	deadLetter.Note = req.Note
+	discarded, err := s.DeadLetterRepo.UpdateDeadLetter(ctx, &deadLetter, constants.DeadLetterStatusPending)
+	if err != nil {
+		return deadLetter, apperr.Wrap(err, "failed to discard dead letter")
+	}
+	if !discarded {
+		return deadLetter, ErrDeadLetterResolved
+	}
+
+	s.audit(ctx, actor, constants.AuditActionDeadLetterDiscarded, map[string]any{
+		"dead_letter_id": deadLetter.ID,
+		"direction":      deadLetter.Direction,
+		"topic":          deadLetter.Topic,
+		"note":           req.Note,
+	})
+	return deadLetter, nil
+}
+
+// audit failures are logged rather than returned, the dead letter is already
+// changed.
+func (s *DeadLetterService) audit(ctx context.Context, actor, action string, detail any) {
+	details, err := json.Marshal(detail)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:   actor,
+			Action:  action,
+			Details: string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
+
+// DeadLetterPublisher publishes events and keeps the ones that fail as dead
+// letters, so a redis outage doesn't lose them. Those aren't returned as
+// errors, they are published once replayed.
+type DeadLetterPublisher struct {
+	Publisher   interfaces.IEventPublisher
+	DeadLetters interfaces.IDeadLetterService
+}
+
+func (p *DeadLetterPublisher) Publish(ctx context.Context, topic string, event proto.Message) error {
+	message, err := events.Marshal(topic, event)
+	if err != nil {
+		return apperr.Wrap(err, "failed to marshal event")
+	}
+	return p.PublishMessage(ctx, topic, message)
+}
+
+func (p *DeadLetterPublisher) PublishMessage(ctx context.Context, topic string, message []byte) error {
+	err := p.Publisher.PublishMessage(ctx, topic, message)
+	if err == nil {
+		return nil
+	}
+
+	if recordErr := p.DeadLetters.RecordPublished(ctx, topic, message, err.Error()); recordErr != nil {
+		return errors.Join(err, recordErr)
+	}
+	return nil
+}
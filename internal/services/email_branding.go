@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+// emailBrand is the brand of one email and the client it belongs to, empty
+// for the default brand.
+type emailBrand struct {
+	models.Brand
+	ClientID string
+	// From overrides the mailer's sender when set.
+	From string
+}
+
+// EmailBranding resolves the brand of a user's emails when they are sent, so
+// users of white-label clients get emails in their client's brand. A nil
+// EmailBranding sends every email unbranded with the built-in templates.
+type EmailBranding struct {
+	// ClientRepo is optional, without it every email gets the default brand.
+	ClientRepo interfaces.IClientRepository
+	Default    models.Brand
+
+	// templates are the clients' own versions of templates, by client id.
+	templates map[string]map[string]map[string]emailTemplate
+}
+
+// LoadTemplates parses the clients' templates from dir/<client_id>, laid out
+// like the built-in ones in templates/email. A client's template replaces the
+// built-in one of the same name, so it must be one of them and render with
+// the same data.
+func (b *EmailBranding) LoadTemplates(dir string) error {
+	clients, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	fsys := os.DirFS(dir)
+	b.templates = map[string]map[string]map[string]emailTemplate{}
+	for _, client := range clients {
+		if !client.IsDir() {
+			continue
+		}
+		templates, err := parseEmailTemplates(fsys, client.Name())
+		if err != nil {
+			return fmt.Errorf("client %s: %w", client.Name(), err)
+		}
+
+		for name, byLocale := range templates {
+			sample, ok := emailTemplateSamples[name]
+			if !ok {
+				return fmt.Errorf("client %s: no built-in email template %s", client.Name(), name)
+			}
+			for locale := range byLocale {
+				if _, err := renderEmailFrom(templates, name, locale, sample(b.Default)); err != nil {
+					return fmt.Errorf("client %s: email template %s/%s: %w", client.Name(), locale, name, err)
+				}
+			}
+		}
+		b.templates[client.Name()] = templates
+	}
+	return nil
+}
+
+// resolve returns the brand of clientID's users. Lookup failures are logged
+// and fall back to the default brand, so security emails still go out.
+func (b *EmailBranding) resolve(ctx context.Context, clientID string) emailBrand {
+	if b == nil {
+		return emailBrand{}
+	}
+
+	brand := emailBrand{Brand: b.Default}
+	if clientID == "" || b.ClientRepo == nil {
+		return brand
+	}
+
+	client, err := b.ClientRepo.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		helpers.Logger.Errorf("failed to get client %q, sending the default brand: %v", clientID, err)
+		return brand
+	}
+
+	brand.ClientID = client.ClientID
+	brand.From = client.MailFrom
+	if client.BrandName != "" {
+		brand.Name = client.BrandName
+	}
+	if client.SupportURL != "" {
+		brand.SupportURL = client.SupportURL
+	}
+	return brand
+}
+
+// render renders the brand's client's version of the template, or the
+// built-in one when the client has none.
+func (b *EmailBranding) render(brand emailBrand, name, locale string, data any) (models.RenderedEmail, error) {
+	if b != nil {
+		if templates, ok := b.templates[brand.ClientID]; ok {
+			if _, ok := templates[name]; ok {
+				return renderEmailFrom(templates, name, locale, data)
+			}
+		}
+	}
+	return renderEmail(name, locale, data)
+}
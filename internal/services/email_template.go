@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/models"
+)
+
+var ErrEmailTemplateNotFound = apperr.New(apperr.NotFound, "email template not found")
+
+//go:embed all:templates/email
+var emailTemplateFiles embed.FS
+
+// emailTemplates are parsed at startup from templates/email/<locale>. Each
+// <name>.txt defines the subject in a "subject" block followed by the text
+// body, <name>.html next to it is the HTML body. Files starting with _ are
+// partials shared by the templates of their locale, embedding them takes the
+// all: prefix.
+var emailTemplates = mustParseEmailTemplates(emailTemplateFiles, "templates/email")
+
+type emailTemplate struct {
+	Version string
+	Text    *texttemplate.Template
+	HTML    *htmltemplate.Template
+}
+
+func mustParseEmailTemplates(fsys fs.FS, root string) map[string]map[string]emailTemplate {
+	templates, err := parseEmailTemplates(fsys, root)
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
+
+// parseEmailTemplates returns the templates by name, then locale.
+func parseEmailTemplates(fsys fs.FS, root string) (map[string]map[string]emailTemplate, error) {
+	locales, err := fs.ReadDir(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := map[string]map[string]emailTemplate{}
+	for _, locale := range locales {
+		dir := path.Join(root, locale.Name())
+		partials, err := fs.Glob(fsys, path.Join(dir, "_*"))
+		if err != nil {
+			return nil, err
+		}
+		pages, err := fs.Glob(fsys, path.Join(dir, "[^_]*.txt"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, page := range pages {
+			name := strings.TrimSuffix(path.Base(page), ".txt")
+			tmpl, err := parseEmailTemplate(fsys, strings.TrimSuffix(page, ".txt"), partials)
+			if err != nil {
+				return nil, fmt.Errorf("email template %s/%s: %w", locale.Name(), name, err)
+			}
+			if templates[name] == nil {
+				templates[name] = map[string]emailTemplate{}
+			}
+			templates[name][locale.Name()] = tmpl
+		}
+	}
+
+	for name, byLocale := range templates {
+		if _, ok := byLocale[constants.LocaleEnglish]; !ok {
+			return nil, fmt.Errorf("email template %s has no %s version to fall back to", name, constants.LocaleEnglish)
+		}
+	}
+	return templates, nil
+}
+
+// parseEmailTemplate parses page.txt and page.html with the partials. The
+// version hashes every file read, so it changes with any of them.
+func parseEmailTemplate(fsys fs.FS, page string, partials []string) (emailTemplate, error) {
+	textFiles := []string{page + ".txt"}
+	htmlFiles := []string{page + ".html"}
+	for _, partial := range partials {
+		switch path.Ext(partial) {
+		case ".txt":
+			textFiles = append(textFiles, partial)
+		case ".html":
+			htmlFiles = append(htmlFiles, partial)
+		}
+	}
+
+	hash := sha256.New()
+	for _, file := range append(textFiles, htmlFiles...) {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return emailTemplate{}, err
+		}
+		hash.Write(data)
+	}
+
+	text, err := texttemplate.New(path.Base(textFiles[0])).Option("missingkey=error").ParseFS(fsys, textFiles...)
+	if err != nil {
+		return emailTemplate{}, err
+	}
+	if text.Lookup("subject") == nil {
+		return emailTemplate{}, fmt.Errorf("%s has no subject block", textFiles[0])
+	}
+	html, err := htmltemplate.New(path.Base(htmlFiles[0])).Option("missingkey=error").ParseFS(fsys, htmlFiles...)
+	if err != nil {
+		return emailTemplate{}, err
+	}
+
+	return emailTemplate{
+		Version: hex.EncodeToString(hash.Sum(nil))[:12],
+		Text:    text,
+		HTML:    html,
+	}, nil
+}
+
+// renderEmail renders the built-in template in locale, or in English when it
+// isn't translated to locale.
+func renderEmail(name, locale string, data any) (models.RenderedEmail, error) {
+	return renderEmailFrom(emailTemplates, name, locale, data)
+}
+
+func renderEmailFrom(templates map[string]map[string]emailTemplate, name, locale string, data any) (models.RenderedEmail, error) {
+	byLocale, ok := templates[name]
+	if !ok {
+		return models.RenderedEmail{}, apperr.Wrapf(ErrEmailTemplateNotFound, "template %q", name)
+	}
+	tmpl, ok := byLocale[locale]
+	if !ok {
+		locale = constants.LocaleEnglish
+		tmpl = byLocale[locale]
+	}
+
+	subject := strings.Builder{}
+	if err := tmpl.Text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return models.RenderedEmail{}, apperr.Wrap(err, "failed to render email subject")
+	}
+	text := strings.Builder{}
+	if err := tmpl.Text.Execute(&text, data); err != nil {
+		return models.RenderedEmail{}, apperr.Wrap(err, "failed to render email text")
+	}
+	html := strings.Builder{}
+	if err := tmpl.HTML.Execute(&html, data); err != nil {
+		return models.RenderedEmail{}, apperr.Wrap(err, "failed to render email html")
+	}
+
+	return models.RenderedEmail{
+		Template: name,
+		Locale:   locale,
+		Version:  tmpl.Version,
+		Subject:  strings.TrimSpace(subject.String()),
+		Text:     text.String(),
+		HTML:     html.String(),
+	}, nil
+}
+
+// emailTemplateSamples are rendered by previews, one per template with the
+// data it is sent with in brand.
+var emailTemplateSamples = map[string]func(brand models.Brand) any{
+	constants.EmailTemplateVerification: func(brand models.Brand) any {
+		return models.EmailLink{
+			Username:         "jdoe",
+			URL:              "https://example.com/verify?token=sample",
+			ExpiresInMinutes: 60,
+			Brand:            brand,
+		}
+	},
+	constants.EmailTemplatePasswordReset: func(brand models.Brand) any {
+		return models.EmailLink{
+			Username:         "jdoe",
+			URL:              "https://example.com/reset-password?token=sample",
+			ExpiresInMinutes: 30,
+			Brand:            brand,
+		}
+	},
+	constants.NotificationEventPasswordChanged:  sampleSecurityEmail(constants.NotificationEventPasswordChanged),
+	constants.NotificationEventNewDeviceLogin:   sampleSecurityEmail(constants.NotificationEventNewDeviceLogin),
+	constants.NotificationEventNewCountryLogin:  sampleSecurityEmail(constants.NotificationEventNewCountryLogin),
+	constants.NotificationEventTwoFactorChanged: sampleSecurityEmail(constants.NotificationEventTwoFactorChanged),
+	constants.NotificationEventEmailChanged: func(brand models.Brand) any {
+		email := sampleSecurityEmail(constants.NotificationEventEmailChanged)(brand).(securityEmail)
+		email.RevertURL = "https://example.com/revert-email-change?token=sample"
+		email.RevertExpiresInHours = 72
+		return email
+	},
+	constants.EmailTemplateSecuritySummary: func(brand models.Brand) any {
+		last := sampleSecurityEmail(constants.NotificationEventNewDeviceLogin)(brand).(securityEmail).SecurityEvent
+		return securitySummaryEmail{
+			Events: []models.SecurityEventSummary{
+				{Event: constants.NotificationEventNewDeviceLogin, Count: 4, Last: last},
+				{Event: constants.NotificationEventPasswordChanged, Count: 1, Last: models.SecurityEvent{OccurredAt: last.OccurredAt}},
+			},
+			Username: "jdoe",
+			Brand:    brand,
+		}
+	},
+	constants.NotificationEventActivityDigest: func(brand models.Brand) any {
+		return activityDigestEmail{
+			ActivitySummary: models.ActivitySummary{
+				Month:            "2026-01",
+				SuccessfulLogins: 12,
+				FailedLogins:     1,
+				Devices:          []string{"Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X)"},
+				IPAddresses:      []string{"203.0.113.7"},
+			},
+			Username:       "jdoe",
+			UnsubscribeURL: "https://example.com/user/v1/notification-preferences/unsubscribe?token=sample",
+			Brand:          brand,
+		}
+	},
+}
+
+func sampleSecurityEmail(event string) func(brand models.Brand) any {
+	return func(brand models.Brand) any {
+		return securityEmail{
+			SecurityEvent: models.SecurityEvent{
+				Event:      event,
+				IPAddress:  "203.0.113.7",
+				UserAgent:  "Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X)",
+				Country:    "ID",
+				City:       "Jakarta",
+				OccurredAt: time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC),
+			},
+			Username:       "jdoe",
+			UnsubscribeURL: "https://example.com/user/v1/notification-preferences/unsubscribe?token=sample",
+			Brand:          brand,
+		}
+	}
+}
+
+// EmailTemplateService lets admins review the email templates, rendered with
+// sample data, before a change goes out.
+type EmailTemplateService struct {
+	Branding *EmailBranding
+	// DefaultLocale is previewed when no locale is asked for.
+	DefaultLocale string
+}
+
+func (s *EmailTemplateService) GetTemplates(ctx context.Context) []models.EmailTemplate {
+	templates := []models.EmailTemplate{}
+	for name, byLocale := range emailTemplates {
+		template := models.EmailTemplate{Name: name, Locales: []models.EmailTemplateLocale{}}
+		for locale, tmpl := range byLocale {
+			template.Locales = append(template.Locales, models.EmailTemplateLocale{Locale: locale, Version: tmpl.Version})
+		}
+		slices.SortFunc(template.Locales, func(a, b models.EmailTemplateLocale) int { return strings.Compare(a.Locale, b.Locale) })
+		templates = append(templates, template)
+	}
+	slices.SortFunc(templates, func(a, b models.EmailTemplate) int { return strings.Compare(a.Name, b.Name) })
+	return templates
+}
+
+// Preview renders the template with sample data, in the brand of clientID's
+// users when it is set. A locale it isn't translated to renders the English
+// version, the response says which.
+func (s *EmailTemplateService) Preview(ctx context.Context, name, locale, clientID string) (models.RenderedEmail, error) {
+	sample, ok := emailTemplateSamples[name]
+	if !ok {
+		return models.RenderedEmail{}, apperr.Wrapf(ErrEmailTemplateNotFound, "template %q", name)
+	}
+	if locale == "" {
+		locale = s.DefaultLocale
+	}
+	brand := s.Branding.resolve(ctx, clientID)
+	return s.Branding.render(brand, name, locale, sample(brand.Brand))
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrEntitlementNotGranted = apperr.New(apperr.NotFound, "feature is not granted to the user, KYC entitlements follow the KYC status")
+
+// entitlementFeatures must match the oneof of models.EntitlementRequest.
+var entitlementFeatures = []string{constants.FeatureInternationalTransfer, constants.FeatureCrypto}
+
+// EntitlementService decides which product features a user may use: the
+// ones an admin granted plus the ones their KYC status brings. They are
+// served to other services by token validation, an "entitlements" claim in
+// tokens and the Entitlement gRPC service.
+type EntitlementService struct {
+	EntitlementRepo interfaces.IEntitlementRepository
+	UserRepo        interfaces.IUserReader
+	AuditRepo       interfaces.IAuditRepository
+	UserClaims      interfaces.IUserClaimsService
+
+	// ByKycStatus are the features each KYC status brings.
+	ByKycStatus map[string][]string
+}
+
+// ParseEntitlementsByKycStatus parses ENTITLEMENTS_BY_KYC_STATUS, e.g.
+// "verified=international_transfer crypto,pending=crypto".
+func ParseEntitlementsByKycStatus(value string) (map[string][]string, error) {
+	entries, err := helpers.ParseStaticClaims(value)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus := map[string][]string{}
+	for status, features := range entries {
+		if !slices.Contains([]string{constants.KycStatusUnverified, constants.KycStatusPending, constants.KycStatusVerified, constants.KycStatusRejected}, status) {
+			return nil, fmt.Errorf("unknown kyc status %q", status)
+		}
+		for _, feature := range strings.Fields(features.(string)) {
+			if !slices.Contains(entitlementFeatures, feature) {
+				return nil, fmt.Errorf("unknown feature %q for kyc status %s", feature, status)
+			}
+			byStatus[status] = append(byStatus[status], feature)
+		}
+	}
+	return byStatus, nil
+}
+
+// GetEntitlements lists the user's entitlements with where they come from.
+func (s *EntitlementService) GetEntitlements(ctx context.Context, userID int) ([]models.Entitlement, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := s.EntitlementRepo.GetUserEntitlements(ctx, userID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get user entitlements")
+	}
+
+	entitlements := make([]models.Entitlement, 0, len(grants))
+	for _, grant := range grants {
+		entitlements = append(entitlements, models.Entitlement{
+			Feature:   grant.Feature,
+			Source:    constants.EntitlementSourceGrant,
+			GrantedBy: grant.GrantedBy,
+			Reason:    grant.Reason,
+			GrantedAt: &grant.CreatedAt,
+		})
+	}
+	for _, feature := range s.ByKycStatus[user.KycStatus] {
+		if !slices.ContainsFunc(entitlements, func(e models.Entitlement) bool { return e.Feature == feature }) {
+			entitlements = append(entitlements, models.Entitlement{Feature: feature, Source: constants.EntitlementSourceKyc})
+		}
+	}
+
+	slices.SortFunc(entitlements, func(a, b models.Entitlement) int { return strings.Compare(a.Feature, b.Feature) })
+	return entitlements, nil
+}
+
+// GetFeatures returns the features user is entitled to, sorted.
+func (s *EntitlementService) GetFeatures(ctx context.Context, user models.User) ([]string, error) {
+	grants, err := s.EntitlementRepo.GetUserEntitlements(ctx, user.ID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get user entitlements")
+	}
+
+	features := slices.Clone(s.ByKycStatus[user.KycStatus])
+	for _, grant := range grants {
+		features = append(features, grant.Feature)
+	}
+	slices.Sort(features)
+	return slices.Compact(features), nil
+}
+
+func (s *EntitlementService) GetUserFeatures(ctx context.Context, userID int) ([]string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetFeatures(ctx, user)
+}
+
+// GrantEntitlement is a no-op when the user already has the grant.
+func (s *EntitlementService) GrantEntitlement(ctx context.Context, actor string, userID int, req models.EntitlementRequest) error {
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return err
+	}
+
+	granted, err := s.EntitlementRepo.InsertUserEntitlement(ctx, &models.UserEntitlement{UserID: userID, Feature: req.Feature, GrantedBy: actor, Reason: req.Reason})
+	if err != nil {
+		return apperr.Wrap(err, "failed to insert user entitlement")
+	}
+	if !granted {
+		return nil
+	}
+
+	s.audit(ctx, actor, constants.AuditActionEntitlementGranted, userID, req)
+	return s.UserClaims.InvalidateUserClaims(ctx, userID, []string{constants.ClaimEntitlements})
+}
+
+// RevokeEntitlement removes an admin's grant. The user keeps the feature if
+// their KYC status brings it.
+func (s *EntitlementService) RevokeEntitlement(ctx context.Context, actor string, userID int, req models.EntitlementRequest) error {
+	revoked, err := s.EntitlementRepo.DeleteUserEntitlement(ctx, userID, req.Feature)
+	if err != nil {
+		return apperr.Wrap(err, "failed to delete user entitlement")
+	}
+	if !revoked {
+		return ErrEntitlementNotGranted
+	}
+
+	s.audit(ctx, actor, constants.AuditActionEntitlementRevoked, userID, req)
+	return s.UserClaims.InvalidateUserClaims(ctx, userID, []string{constants.ClaimEntitlements})
+}
+
+// EnrichClaims adds the user's entitlements to the tokens issued to them, as
+// a helpers.ClaimsEnricher.
+func (s *EntitlementService) EnrichClaims(ctx context.Context, claims *helpers.ClaimToken) (map[string]any, error) {
+	features, err := s.GetUserFeatures(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(features) == 0 {
+		return nil, nil
+	}
+	return map[string]any{constants.ClaimEntitlements: features}, nil
+}
+
+func (s *EntitlementService) getUser(ctx context.Context, userID int) (models.User, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return user, ErrUserNotFound
+		}
+		return user, apperr.Wrap(err, "failed to get user")
+	}
+	return user, nil
+}
+
+// audit failures are logged rather than returned, the entitlement is already
+// changed.
+func (s *EntitlementService) audit(ctx context.Context, actor, action string, userID int, req models.EntitlementRequest) {
+	details, err := json.Marshal(map[string]any{"feature": req.Feature, "reason": req.Reason})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        actor,
+			Action:       action,
+			TargetUserID: userID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
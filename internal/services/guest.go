@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+var (
+	ErrNotGuest      = apperr.New(apperr.Forbidden, "user is not a guest")
+	ErrUsernameTaken = apperr.New(apperr.Conflict, "username already taken")
+)
+
+// GuestService lets the wallet app be tried before registering. A guest is a
+// users row of type guest without credentials, its tokens are bound to the
+// device that created it. Upgrading keeps the user id, so whatever the guest
+// did carries over to the full account.
+type GuestService struct {
+	UserRepo               interfaces.IUserRepository
+	SessionRepo            interfaces.ISessionRepository
+	WalletProvisioningRepo interfaces.IWalletProvisioningRepository
+	AuditRepo              interfaces.IAuditRepository
+	TokenCache             interfaces.ITokenCacheRepository
+	RefreshTokenRepo       interfaces.IRefreshTokenRepository
+	PasswordHasher         interfaces.IPasswordHasher
+	// ReservedUsernames is optional, nil reserves no usernames.
+	ReservedUsernames interfaces.IReservedUsernameService
+}
+
+func (s *GuestService) CreateGuest(ctx context.Context, req models.GuestRequest) (models.LoginResponse, error) {
+	suffix, err := randomString(6, hex.EncodeToString)
+	if err != nil {
+		return models.LoginResponse{}, apperr.Wrap(err, "failed to generate guest username")
+	}
+
+	user := models.User{
+		Username: "guest-" + suffix,
+		Status:   constants.UserStatusActive,
+		Type:     constants.UserTypeGuest,
+	}
+	err = s.WalletProvisioningRepo.InsertUserWithWalletProvisioning(ctx, &user, &models.WalletProvisioning{
+		Status:        constants.WalletStatusProvisioning,
+		NextAttemptAt: time.Now(),
+	})
+	if err != nil {
+		return models.LoginResponse{}, apperr.Wrap(err, "failed to insert guest")
+	}
+
+	origin := models.TokenOrigin{IPAddress: req.IPAddress, UserAgent: req.UserAgent}
+	return newUserSession(ctx, s.SessionRepo, s.RefreshTokenRepo, user, helpers.TokenPolicy{DeviceID: req.DeviceID}, origin)
+}
+
+// UpgradeGuest sets the guest's credentials and profile and turns it into a
+// human account. The device-bound guest sessions are ended and replaced by a
+// regular login session.
+func (s *GuestService) UpgradeGuest(ctx context.Context, userID int, req models.UpgradeGuestRequest) (models.LoginResponse, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.LoginResponse{}, apperr.Wrap(err, "failed to get user")
+	}
+	if user.Type != constants.UserTypeGuest {
+		return models.LoginResponse{}, ErrNotGuest
+	}
+
+	if existing, err := s.UserRepo.GetUserByUsername(ctx, req.Username); err == nil && existing.ID != userID {
+		return models.LoginResponse{}, ErrUsernameTaken
+	}
+	if s.ReservedUsernames != nil {
+		if err := s.ReservedUsernames.CheckUsername(ctx, req.Username); err != nil {
+			return models.LoginResponse{}, err
+		}
+	}
+
+	password, err := s.PasswordHasher.HashPassword(ctx, req.Password)
+	if err != nil {
+		return models.LoginResponse{}, apperr.Wrap(err, "failed to hash password")
+	}
+
+	update := models.User{
+		Username:    req.Username,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		FullName:    req.FullName,
+		Address:     req.Address,
+		Dob:         req.Dob,
+		Password:    password,
+		Type:        constants.UserTypeHuman,
+	}
+	applied, err := s.UserRepo.UpdateUser(ctx, userID, user.Version, update)
+	if err != nil {
+		return models.LoginResponse{}, apperr.Wrap(err, "failed to update user")
+	}
+	if !applied {
+		return models.LoginResponse{}, ErrUserVersionConflict
+	}
+	helpers.RegistrationsTotal.WithLabelValues(constants.RegistrationChannelGuestUpgrade).Inc()
+
+	if _, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, userID); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	s.audit(ctx, userID, user.Username, req.Username)
+
+	update.ID = userID
+
+	origin := models.TokenOrigin{IPAddress: req.IPAddress, UserAgent: req.UserAgent}
+	return newUserSession(ctx, s.SessionRepo, s.RefreshTokenRepo, update, helpers.TokenPolicy{}, origin)
+}
+
+// audit failures are logged rather than returned, like for admin approvals.
+func (s *GuestService) audit(ctx context.Context, userID int, guestUsername, username string) {
+	details, err := json.Marshal(map[string]string{
+		"guest_username": guestUsername,
+		"username":       username,
+	})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        models.UserActor(userID),
+			Action:       constants.AuditActionGuestUpgraded,
+			TargetUserID: userID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
@@ -1,11 +1,79 @@
 package services
 
-import "ewallet-ums/internal/interfaces"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
 
 type Healthcheck struct {
 	HealthcheckRepository interfaces.IHealthcheckRepo
+	ExternalWallet        interfaces.IWallet
+
+	// Timeout bounds each dependency check.
+	Timeout time.Duration
 }
 
 func (s *Healthcheck) HealthcheckServices() (string, error) {
 	return "service healthy", nil
 }
+
+// Check pings every dependency at once. The database and redis are required,
+// without the wallet service only wallet provisioning stalls, so the service
+// is degraded rather than down.
+func (s *Healthcheck) Check(ctx context.Context) models.HealthReport {
+	checks := []struct {
+		name     string
+		required bool
+		ping     func(ctx context.Context) error
+	}{
+		{constants.DependencyDatabase, true, s.HealthcheckRepository.PingDB},
+		{constants.DependencyRedis, true, s.HealthcheckRepository.PingRedis},
+		{constants.DependencyWallet, false, s.ExternalWallet.Ping},
+	}
+
+	report := models.HealthReport{
+		Status:       constants.HealthStatusUp,
+		Dependencies: make([]models.DependencyHealth, len(checks)),
+	}
+
+	wg := sync.WaitGroup{}
+	for i, check := range checks {
+		wg.Go(func() {
+			checkCtx, cancel := context.WithTimeout(ctx, s.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.ping(checkCtx)
+
+			dependency := models.DependencyHealth{
+				Name:      check.name,
+				Status:    constants.HealthStatusUp,
+				LatencyMS: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				dependency.Status = constants.HealthStatusDown
+				dependency.Error = err.Error()
+			}
+			report.Dependencies[i] = dependency
+		})
+	}
+	wg.Wait()
+
+	for i, check := range checks {
+		if report.Dependencies[i].Status == constants.HealthStatusUp {
+			continue
+		}
+		if check.required {
+			report.Status = constants.HealthStatusDown
+		} else if report.Status == constants.HealthStatusUp {
+			report.Status = constants.HealthStatusDegraded
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrInboxEventInProgress = apperr.New(apperr.Conflict, "event is being applied by another consumer")
+
+// InboxService applies the events other services publish about our users.
+// Each event is recorded in the inbox once applied, so a redelivery is
+// acknowledged without applying it twice.
+type InboxService struct {
+	InboxRepo              interfaces.IInboxRepository
+	UserAdmin              interfaces.IUserAdminService
+	WalletProvisioningRepo interfaces.IWalletProvisioningRepository
+	AuditRepo              interfaces.IAuditRepository
+	Consumer               interfaces.IEventConsumer
+	// DeadLetters is optional, without it events that can't be applied are
+	// dropped.
+	DeadLetters interfaces.IDeadLetterService
+	// ClaimTimeout is how long an event claimed by a consumer that stopped
+	// waits before another one applies it.
+	ClaimTimeout time.Duration
+}
+
+func (s *InboxService) Run(ctx context.Context) {
+	err := s.Consumer.Consume(ctx, []string{constants.EventWalletClosed, constants.EventFraudFlagged}, s.HandleEvent)
+	if err != nil && ctx.Err() == nil {
+		helpers.Logger.Error("failed on event consumer: ", err)
+	}
+}
+
+// HandleEvent applies an event unless it was applied before. Payloads that
+// can't be applied are not retried: malformed ones and unknown topics are
+// dead lettered, events about an unknown user are logged and dropped.
+func (s *InboxService) HandleEvent(ctx context.Context, topic string, payload []byte) error {
+	log := helpers.Logger
+
+	var (
+		eventID string
+		apply   func(ctx context.Context) error
+	)
+	switch topic {
+	case constants.EventWalletClosed:
+		event := models.WalletClosedEvent{}
+		if err := decodeInboxEvent(payload, &event, event.Validate); err != nil {
+			return s.deadLetter(ctx, topic, payload, fmt.Sprintf("invalid event: %v", err))
+		}
+		eventID = event.EventID
+		apply = func(ctx context.Context) error { return s.closeWallet(ctx, event) }
+	case constants.EventFraudFlagged:
+		event := models.FraudFlaggedEvent{}
+		if err := decodeInboxEvent(payload, &event, event.Validate); err != nil {
+			return s.deadLetter(ctx, topic, payload, fmt.Sprintf("invalid event: %v", err))
+		}
+		eventID = event.EventID
+		apply = func(ctx context.Context) error {
+			_, err := s.UserAdmin.SuspendUser(ctx, models.EventActor(topic), event.UserID, 0, event.Reason)
+			return err
+		}
+	default:
+		return s.deadLetter(ctx, topic, payload, "unknown topic")
+	}
+
+	now := time.Now()
+	inbox := &models.InboxEvent{Topic: topic, EventID: eventID, ClaimedAt: now}
+	claimed, err := s.InboxRepo.ClaimInboxEvent(ctx, inbox, now.Add(-s.ClaimTimeout))
+	if err != nil {
+		return apperr.Wrap(err, "failed to claim inbox event")
+	}
+	if !claimed {
+		if inbox.ProcessedAt != nil {
+			log.Infof("skipping %s event %s, it was already applied", topic, eventID)
+			return nil
+		}
+		return apperr.Wrapf(ErrInboxEventInProgress, "%s event %s", topic, eventID)
+	}
+
+	err = apply(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) || apperr.Is(err, apperr.NotFound) {
+		log.Warnf("%s event %s is about an unknown user or wallet, skipping: %v", topic, eventID, err)
+		err = nil
+	}
+	if err != nil {
+		if releaseErr := s.InboxRepo.DeleteInboxEvent(ctx, inbox); releaseErr != nil {
+			log.Error("failed to release inbox event: ", releaseErr)
+		}
+		return apperr.Wrapf(err, "failed to apply %s event %s", topic, eventID)
+	}
+
+	if err := s.InboxRepo.MarkInboxEventProcessed(ctx, inbox, time.Now()); err != nil {
+		return apperr.Wrap(err, "failed to mark inbox event processed")
+	}
+	return nil
+}
+
+// deadLetter keeps an event that can't be applied, it stays pending when
+// that fails.
+func (s *InboxService) deadLetter(ctx context.Context, topic string, payload []byte, reason string) error {
+	if s.DeadLetters == nil {
+		helpers.Logger.Errorf("dropping %s event: %s", topic, reason)
+		return nil
+	}
+	return s.DeadLetters.RecordConsumed(ctx, topic, "", payload, reason)
+}
+
+func decodeInboxEvent(payload []byte, event any, validate func() error) error {
+	if err := json.Unmarshal(payload, event); err != nil {
+		return err
+	}
+	return validate()
+}
+
+// closeWallet marks the user's wallet closed. An event about a wallet the
+// user no longer has is ignored.
+func (s *InboxService) closeWallet(ctx context.Context, event models.WalletClosedEvent) error {
+	provisioning, err := s.WalletProvisioningRepo.GetWalletProvisioningByUserID(ctx, event.UserID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get wallet provisioning")
+	}
+	if event.WalletID != 0 && provisioning.WalletID != event.WalletID {
+		helpers.Logger.Warnf("ignoring %s event for wallet %d, user %d has wallet %d", constants.EventWalletClosed, event.WalletID, event.UserID, provisioning.WalletID)
+		return nil
+	}
+
+	provisioning.Status = constants.WalletStatusClosed
+	if err := s.WalletProvisioningRepo.UpdateWalletProvisioning(ctx, &provisioning); err != nil {
+		return apperr.Wrap(err, "failed to update wallet provisioning")
+	}
+
+	// audit failures are logged rather than returned, the wallet is already
+	// marked closed
+	details, err := json.Marshal(map[string]any{"wallet_id": provisioning.WalletID, "reason": event.Reason})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        models.EventActor(constants.EventWalletClosed),
+			Action:       constants.AuditActionWalletClosed,
+			TargetUserID: event.UserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+type IntrospectionService struct {
+	UserRepo    interfaces.IUserReader
+	SessionRepo interfaces.ISessionRepository
+	TokenCache  interfaces.ITokenCacheRepository
+}
+
+// Introspect reports whether token is a live access or refresh token. Per
+// RFC 7662 the caller only learns active=false for anything else, the reason
+// is logged here; an error means the answer could not be determined.
+func (s *IntrospectionService) Introspect(ctx context.Context, token, tokenTypeHint string) (models.IntrospectionResponse, error) {
+	var (
+		inactive = models.IntrospectionResponse{}
+		log      = helpers.Logger
+	)
+
+	claimToken, err := helpers.ValidateToken(ctx, token)
+	if err != nil {
+		log.Info("introspected invalid token: ", err)
+		return inactive, nil
+	}
+
+	tokenType := constants.TokenTypeAccess
+	if claimToken.Act == nil {
+		tokenType, err = s.sessionTokenType(ctx, token, tokenTypeHint)
+		if err != nil {
+			log.Info("introspected token without session: ", err)
+			return inactive, nil
+		}
+	} else if err := delegatedSessionActive(ctx, s.SessionRepo, s.TokenCache, token, claimToken); err != nil {
+		log.Info("introspected delegated token without subject session: ", err)
+		return inactive, nil
+	}
+
+	if tokenType == constants.TokenTypeAccess {
+		revoked, err := s.TokenCache.IsTokenRevoked(ctx, token, claimToken.ID)
+		if err != nil {
+			return inactive, apperr.Wrap(err, "failed to check token revocation")
+		}
+		if revoked {
+			log.Info("introspected revoked token for user: ", claimToken.UserID)
+			return inactive, nil
+		}
+	}
+
+	user, err := s.UserRepo.GetUserByID(ctx, claimToken.UserID)
+	if err != nil {
+		log.Info("introspected token of missing user: ", err)
+		return inactive, nil
+	}
+	if user.Status == constants.UserStatusBanned {
+		log.Info("introspected token of banned user: ", user.ID)
+		return inactive, nil
+	}
+
+	return models.IntrospectionResponse{
+		Active:              true,
+		Scope:               claimToken.Scope,
+		ClientID:            claimToken.ClientID,
+		Username:            user.Username,
+		ProfileCompleteness: user.CalculateProfileCompleteness(),
+		TokenType:           tokenType,
+		Exp:                 claimToken.ExpiresAt.Unix(),
+		Iat:                 claimToken.IssuedAt.Unix(),
+		Sub:                 strconv.Itoa(user.ID),
+		Aud:                 claimToken.Audience,
+		Iss:                 claimToken.Issuer,
+		Ext:                 claimToken.Ext,
+	}, nil
+}
+
+// sessionTokenType finds the session the token belongs to, trying the hinted
+// type first, and returns which of the session's tokens it is.
+func (s *IntrospectionService) sessionTokenType(ctx context.Context, token, tokenTypeHint string) (string, error) {
+	tokenTypes := []string{constants.TokenTypeAccess, constants.TokenTypeRefresh}
+	if tokenTypeHint == constants.TokenTypeRefresh {
+		tokenTypes = []string{constants.TokenTypeRefresh, constants.TokenTypeAccess}
+	}
+
+	var err error
+	for _, tokenType := range tokenTypes {
+		if tokenType == constants.TokenTypeAccess {
+			_, err = s.SessionRepo.GetUserSessionByToken(ctx, token)
+		} else {
+			_, err = s.SessionRepo.GetUserSessionByRefreshToken(ctx, token)
+		}
+		if err == nil {
+			return tokenType, nil
+		}
+	}
+
+	return "", apperr.Wrap(err, "failed to get user session")
+}
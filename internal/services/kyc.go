@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrKycFieldsMissing = apperr.New(apperr.Invalid, "fields required in the user's jurisdiction are missing")
+
+var kycFields = []string{constants.KycFieldOccupation, constants.KycFieldEmployer, constants.KycFieldSourceOfFunds, constants.KycFieldNextOfKin}
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// KycService keeps the extended KYC data of users, checked against the
+// fields their jurisdiction requires. The jurisdiction is the country of the
+// user's primary address.
+type KycService struct {
+	KycRepo     interfaces.IKycRepository
+	AddressRepo interfaces.IAddressRepository
+	UserRepo    interfaces.IUserReader
+	AuditRepo   interfaces.IAuditRepository
+
+	// RequiredFields are the fields each jurisdiction requires, by country
+	// or constants.KycJurisdictionDefault.
+	RequiredFields map[string][]string
+}
+
+// ParseKycRequiredFields parses KYC_REQUIRED_FIELDS, e.g.
+// "default=occupation,ID=occupation employer source_of_funds next_of_kin".
+func ParseKycRequiredFields(value string) (map[string][]string, error) {
+	entries, err := helpers.ParseStaticClaims(value)
+	if err != nil {
+		return nil, err
+	}
+
+	byJurisdiction := map[string][]string{}
+	for jurisdiction, fields := range entries {
+		if jurisdiction != constants.KycJurisdictionDefault && !countryCode.MatchString(jurisdiction) {
+			return nil, fmt.Errorf("jurisdiction %q is neither a country code nor %s", jurisdiction, constants.KycJurisdictionDefault)
+		}
+		byJurisdiction[jurisdiction] = []string{}
+		for _, field := range strings.Fields(fields.(string)) {
+			if !slices.Contains(kycFields, field) {
+				return nil, fmt.Errorf("unknown kyc field %q for jurisdiction %s", field, jurisdiction)
+			}
+			byJurisdiction[jurisdiction] = append(byJurisdiction[jurisdiction], field)
+		}
+	}
+	return byJurisdiction, nil
+}
+
+func (s *KycService) GetKycProfile(ctx context.Context, userID int) (models.KycProfileResponse, error) {
+	profile, err := s.KycRepo.GetKycProfile(ctx, userID)
+	if err != nil {
+		return models.KycProfileResponse{}, apperr.Wrap(err, "failed to get kyc profile")
+	}
+	return s.check(ctx, profile, userID)
+}
+
+// GetUserKycProfile is GetKycProfile for admins, failing for unknown users.
+func (s *KycService) GetUserKycProfile(ctx context.Context, userID int) (models.KycProfileResponse, error) {
+	if _, err := s.UserRepo.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.KycProfileResponse{}, ErrUserNotFound
+		}
+		return models.KycProfileResponse{}, apperr.Wrap(err, "failed to get user")
+	}
+	return s.GetKycProfile(ctx, userID)
+}
+
+// UpdateKycProfile replaces the user's profile, refusing one without every
+// field their jurisdiction requires.
+func (s *KycService) UpdateKycProfile(ctx context.Context, userID int, req models.KycProfileRequest) (models.KycProfileResponse, error) {
+	profile := models.KycProfile{
+		UserID:              userID,
+		EmploymentStatus:    req.EmploymentStatus,
+		Occupation:          strings.TrimSpace(req.Occupation),
+		EmployerName:        strings.TrimSpace(req.EmployerName),
+		SourceOfFunds:       req.SourceOfFunds,
+		SourceOfFundsDetail: strings.TrimSpace(req.SourceOfFundsDetail),
+	}
+	if req.NextOfKin != nil {
+		phoneNumber, err := helpers.NormalizePhoneNumber(req.NextOfKin.PhoneNumber)
+		if err != nil {
+			return models.KycProfileResponse{}, apperr.WrapAs(apperr.Invalid, err, "invalid next of kin phone number")
+		}
+		profile.NextOfKin = models.NextOfKin{FullName: strings.TrimSpace(req.NextOfKin.FullName), Relationship: req.NextOfKin.Relationship, PhoneNumber: phoneNumber}
+	}
+
+	resp, err := s.check(ctx, profile, userID)
+	if err != nil {
+		return resp, err
+	}
+	if len(resp.Missing) > 0 {
+		return resp, apperr.Wrapf(ErrKycFieldsMissing, "missing %s for %s", strings.Join(resp.Missing, ", "), resp.Jurisdiction)
+	}
+
+	if err := s.KycRepo.UpsertKycProfile(ctx, &profile); err != nil {
+		return resp, apperr.Wrap(err, "failed to save kyc profile")
+	}
+
+	s.audit(ctx, userID, resp.Jurisdiction, profile)
+	resp.KycProfile = profile
+	return resp, nil
+}
+
+// check fills in the jurisdiction of userID and the fields it requires that
+// profile lacks.
+func (s *KycService) check(ctx context.Context, profile models.KycProfile, userID int) (models.KycProfileResponse, error) {
+	resp := models.KycProfileResponse{KycProfile: profile, Jurisdiction: constants.KycJurisdictionDefault, Missing: []string{}}
+
+	addresses, err := s.AddressRepo.GetUserAddresses(ctx, userID)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to get addresses")
+	}
+	if len(addresses) > 0 && addresses[0].IsPrimary {
+		if _, ok := s.RequiredFields[addresses[0].Country]; ok {
+			resp.Jurisdiction = addresses[0].Country
+		}
+	}
+
+	for _, field := range s.RequiredFields[resp.Jurisdiction] {
+		if !kycFieldFilled(profile, field) {
+			resp.Missing = append(resp.Missing, field)
+		}
+	}
+	return resp, nil
+}
+
+func kycFieldFilled(profile models.KycProfile, field string) bool {
+	switch field {
+	case constants.KycFieldOccupation:
+		return profile.Occupation != ""
+	case constants.KycFieldEmployer:
+		// only asked of users who have an employer
+		employed := profile.EmploymentStatus == constants.EmploymentStatusEmployed || profile.EmploymentStatus == constants.EmploymentStatusSelfEmployed
+		return !employed || profile.EmployerName != ""
+	case constants.KycFieldSourceOfFunds:
+		return profile.SourceOfFunds != ""
+	case constants.KycFieldNextOfKin:
+		return profile.NextOfKin.FullName != ""
+	}
+	return false
+}
+
+// audit failures are logged rather than returned, the profile is already
+// saved. Only which fields were filled in is recorded, not their values.
+func (s *KycService) audit(ctx context.Context, userID int, jurisdiction string, profile models.KycProfile) {
+	filled := []string{}
+	for _, field := range kycFields {
+		// employer counts as filled for users without one
+		if field == constants.KycFieldEmployer && profile.EmployerName == "" {
+			continue
+		}
+		if kycFieldFilled(profile, field) {
+			filled = append(filled, field)
+		}
+	}
+
+	details, err := json.Marshal(map[string]any{"jurisdiction": jurisdiction, "fields": filled})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        models.UserActor(userID),
+			Action:       constants.AuditActionKycProfileUpdated,
+			TargetUserID: userID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
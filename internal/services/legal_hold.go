@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+var ErrLegalHoldLifted = apperr.New(apperr.Conflict, "legal hold is already lifted")
+
+type LegalHoldService struct {
+	LegalHoldRepo interfaces.ILegalHoldRepository
+	UserRepo      interfaces.IUserReader
+	AuditRepo     interfaces.IAuditRepository
+}
+
+func (s *LegalHoldService) PlaceHold(ctx context.Context, userID, placedBy int, reason string) (models.LegalHold, error) {
+	if _, err := s.UserRepo.GetUserByID(ctx, userID); err != nil {
+		return models.LegalHold{}, apperr.Wrap(err, "failed to get user")
+	}
+
+	hold := models.LegalHold{UserID: userID, Reason: reason, PlacedBy: placedBy}
+	if err := s.LegalHoldRepo.InsertLegalHold(ctx, &hold); err != nil {
+		return hold, apperr.Wrap(err, "failed to insert legal hold")
+	}
+
+	s.audit(ctx, placedBy, constants.AuditActionLegalHoldPlaced, hold)
+	return hold, nil
+}
+
+func (s *LegalHoldService) LiftHold(ctx context.Context, holdID, liftedBy int, reason string) (models.LegalHold, error) {
+	hold, err := s.LegalHoldRepo.GetLegalHoldByID(ctx, holdID)
+	if err != nil {
+		return hold, apperr.Wrap(err, "failed to get legal hold")
+	}
+	if hold.LiftedAt != nil {
+		return hold, ErrLegalHoldLifted
+	}
+
+	now := time.Now()
+	hold.LiftReason = reason
+	hold.LiftedBy = liftedBy
+	hold.LiftedAt = &now
+
+	lifted, err := s.LegalHoldRepo.LiftLegalHold(ctx, &hold)
+	if err != nil {
+		return hold, apperr.Wrap(err, "failed to lift legal hold")
+	}
+	if !lifted {
+		return hold, ErrLegalHoldLifted
+	}
+
+	s.audit(ctx, liftedBy, constants.AuditActionLegalHoldLifted, hold)
+	return hold, nil
+}
+
+func (s *LegalHoldService) GetHolds(ctx context.Context, userID int) ([]models.LegalHold, error) {
+	holds, err := s.LegalHoldRepo.GetLegalHoldsByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get legal holds")
+	}
+	return holds, nil
+}
+
+func (s *LegalHoldService) audit(ctx context.Context, actorID int, action string, hold models.LegalHold) {
+	details, err := json.Marshal(hold)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        models.UserActor(actorID),
+			Action:       action,
+			TargetUserID: hold.UserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
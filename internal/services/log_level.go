@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+var ErrUnknownLogLevel = apperr.New(apperr.Invalid, "unknown log level")
+
+// LogLevelService changes the log level of the instance serving the request,
+// other instances keep theirs.
+type LogLevelService struct {
+	AuditRepo interfaces.IAuditRepository
+}
+
+func (s *LogLevelService) GetLogLevel(ctx context.Context) models.LogLevelResponse {
+	return models.LogLevelResponse{Level: helpers.LogLevel()}
+}
+
+func (s *LogLevelService) SetLogLevel(ctx context.Context, actor, level string) (models.LogLevelResponse, error) {
+	previous := helpers.LogLevel()
+	if err := helpers.SetLogLevel(level); err != nil {
+		return models.LogLevelResponse{Level: previous}, ErrUnknownLogLevel
+	}
+
+	// audit failures are logged rather than returned, like for admin approvals.
+	details, err := json.Marshal(map[string]string{"from": previous, "to": helpers.LogLevel()})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:   actor,
+			Action:  constants.AuditActionLogLevelChanged,
+			Details: string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+
+	return models.LogLevelResponse{Level: helpers.LogLevel()}, nil
+}
@@ -2,61 +2,353 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"crypto/rand"
+	"errors"
 	"time"
 
+	"ewallet-ums/constants"
 	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
 	"ewallet-ums/internal/interfaces"
 	"ewallet-ums/internal/models"
 
-	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+// ErrPasswordChangeRequired is returned when the password was right but the
+// user must set a new one, by logging in again with new_password.
+var ErrPasswordChangeRequired = apperr.New(apperr.Forbidden, "password change required")
+
 type LoginService struct {
-	UserRepo interfaces.IUserRepository
+	UserRepo         interfaces.IUserRepository
+	SessionRepo      interfaces.ISessionRepository
+	ClientRepo       interfaces.IClientRepository
+	LoginHistoryRepo interfaces.ILoginHistoryRepository
+	RefreshTokenRepo interfaces.IRefreshTokenRepository
+	PasswordHasher   interfaces.IPasswordHasher
+	// Notifications is optional, nil sends no security emails.
+	Notifications interfaces.INotificationService
+	// Velocity is optional, nil doesn't track failed login rates.
+	Velocity interfaces.ILoginVelocityService
+	// AccountDeletion cancels the deletion of pending_deletion users who
+	// log in with cancel_deletion.
+	AccountDeletion interfaces.IAccountDeletionService
+	// GeoIP is optional, nil only knows the country the edge proxy sends.
+	GeoIP interfaces.IGeoIP
+	// ActiveUsers is optional, nil doesn't count active users.
+	ActiveUsers interfaces.IActiveUsersRepository
+	// OTP is optional, nil only accepts passwords.
+	OTP interfaces.IOTPService
+	// TrustedDevices is optional, nil never remembers a device. Remembered
+	// devices skip the OTP for TrustedDeviceTTL.
+	TrustedDevices   interfaces.ITrustedDeviceRepository
+	TrustedDeviceTTL time.Duration
 }
 
 func (s *LoginService) Login(ctx context.Context, req models.LoginRequest) (models.LoginResponse, error) {
 	resp := models.LoginResponse{}
-	now := time.Now()
+	s.locate(ctx, &req)
 
-	userDetail, err := s.UserRepo.GetUserByUsername(ctx, req.Username)
+	userDetail, err := getUserByIdentifier(ctx, s.UserRepo, req.GetIdentifier())
 	if err != nil {
-		return resp, fmt.Errorf("failed to get user by username, %v", err)
+		s.recordLoginHistory(ctx, req, 0, false)
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, models.ErrAmbiguousIdentifier) {
+			return resp, apperr.WrapAs(apperr.Unauthorized, err, "failed to get user by username")
+		}
+		return resp, apperr.Wrap(err, "failed to get user by username")
+	}
+
+	if err := s.authenticate(ctx, req, userDetail); err != nil {
+		s.recordLoginHistory(ctx, req, userDetail.ID, false)
+		return resp, err
+	}
+
+	if userDetail.Status == constants.UserStatusBanned {
+		s.recordLoginHistory(ctx, req, userDetail.ID, false)
+		return resp, apperr.Newf(apperr.Forbidden, "user %d is banned", userDetail.ID)
+	}
+
+	// service accounts get tokens through the client_credentials grant only,
+	// guests have no password until they upgrade
+	if userDetail.Type == constants.UserTypeService || userDetail.Type == constants.UserTypeGuest {
+		s.recordLoginHistory(ctx, req, userDetail.ID, false)
+		return resp, apperr.Newf(apperr.Forbidden, "user %d is a %s account", userDetail.ID, userDetail.Type)
+	}
+
+	if userDetail.Status == constants.UserStatusPendingDeletion {
+		if !req.CancelDeletion {
+			return resp, ErrAccountPendingDeletion
+		}
+		if err := s.AccountDeletion.CancelDeletion(ctx, userDetail.ID); err != nil {
+			return resp, err
+		}
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(userDetail.Password), []byte(req.Password)); err != nil {
-		return resp, fmt.Errorf("incorrect password, %v", err)
+	if userDetail.PasswordChangeRequired {
+		if req.NewPassword == "" {
+			return resp, ErrPasswordChangeRequired
+		}
+
+		password, err := s.PasswordHasher.HashPassword(ctx, req.NewPassword)
+		if err != nil {
+			return resp, apperr.Wrap(err, "failed to hash password")
+		}
+		if err := s.UserRepo.UpdateUserPassword(ctx, userDetail.ID, password, false); err != nil {
+			return resp, apperr.Wrap(err, "failed to update password")
+		}
 	}
 
-	token, err := helpers.GenerateToken(ctx, userDetail.ID, userDetail.Username, userDetail.FullName, "token", userDetail.Email, now)
+	policy, err := clientTokenPolicy(ctx, s.ClientRepo, req.ClientID, req.Scope)
 	if err != nil {
-		return resp, fmt.Errorf("failed to generate token, %v", err)
+		s.recordLoginHistory(ctx, req, userDetail.ID, false)
+		return resp, err
+	}
+
+	origin := models.TokenOrigin{IPAddress: req.IPAddress, UserAgent: req.UserAgent, Country: req.Country, City: req.City}
+	resp, err = newUserSession(ctx, s.SessionRepo, s.RefreshTokenRepo, userDetail, policy, origin)
+	if err != nil {
+		return resp, err
+	}
+
+	if req.RememberDevice && req.OTP != "" {
+		s.rememberDevice(ctx, req, userDetail.ID, &resp)
+	}
+
+	s.notifyUnusualLogin(ctx, req, userDetail.ID)
+	s.recordLoginHistory(ctx, req, userDetail.ID, true)
+	recordActiveUser(ctx, s.ActiveUsers, userDetail.ID)
+	return resp, nil
+}
+
+// authenticate checks the password, or for passwordless logins the one-time
+// code texted to the user's phone number or the cookie of a device trusted
+// after an earlier code. Passwordless users have no password to match until
+// they set one.
+func (s *LoginService) authenticate(ctx context.Context, req models.LoginRequest, user models.User) error {
+	if req.OTP == "" && req.Password == "" && req.DeviceToken != "" {
+		return s.verifyTrustedDevice(ctx, req.DeviceToken, user)
+	}
+	if req.OTP == "" {
+		if err := s.PasswordHasher.ComparePassword(ctx, user.Password, req.Password); err != nil {
+			return apperr.WrapAs(apperr.Unauthorized, err, "incorrect password")
+		}
+		return nil
+	}
+
+	// a code is the only factor, so it never stands in for a password
+	if s.OTP == nil || user.PhoneNumber == "" || user.Password != "" {
+		return apperr.Newf(apperr.Unauthorized, "user %d can't log in with a code", user.ID)
+	}
+	return s.OTP.VerifyOTP(ctx, constants.OTPPurposeLogin, user.PhoneNumber, req.OTP)
+}
+
+// verifyTrustedDevice accepts the device in place of a code, so only from
+// passwordless users. It never stands in for a password.
+func (s *LoginService) verifyTrustedDevice(ctx context.Context, deviceToken string, user models.User) error {
+	if s.TrustedDevices == nil || s.OTP == nil || user.PhoneNumber == "" || user.Password != "" {
+		return apperr.Newf(apperr.Unauthorized, "user %d can't log in with a device", user.ID)
 	}
 
-	refreshToken, err := helpers.GenerateToken(ctx, userDetail.ID, userDetail.Username, userDetail.FullName, "refresh_token", userDetail.Email, now)
+	trusted, err := s.TrustedDevices.UseTrustedDevice(ctx, user.ID, helpers.HashToken(deviceToken), time.Now())
 	if err != nil {
-		return resp, fmt.Errorf("failed to generate refresh token, %v", err)
+		return apperr.Wrap(err, "failed to check trusted device")
+	}
+	if !trusted {
+		return apperr.Newf(apperr.Unauthorized, "device isn't trusted for user %d", user.ID)
+	}
+	return nil
+}
+
+// rememberDevice trusts the browser that just logged in with a code. The
+// login stands when that fails, the user only gets asked for a code again.
+func (s *LoginService) rememberDevice(ctx context.Context, req models.LoginRequest, userID int, resp *models.LoginResponse) {
+	if s.TrustedDevices == nil {
+		return
+	}
+
+	token := rand.Text()
+	device := &models.TrustedDevice{
+		UserID:    userID,
+		TokenHash: helpers.HashToken(token),
+		UserAgent: req.UserAgent,
+		ExpiresAt: time.Now().Add(s.TrustedDeviceTTL),
+	}
+	if err := s.TrustedDevices.InsertTrustedDevice(ctx, device); err != nil {
+		helpers.Logger.Error("failed to insert trusted device: ", err)
+		return
+	}
+	resp.DeviceToken = token
+	resp.DeviceTokenExpiresAt = device.ExpiresAt
+}
+
+// locate fills in where the login comes from. The edge proxy's country wins
+// over the lookup; failed lookups are logged and leave the location unknown.
+func (s *LoginService) locate(ctx context.Context, req *models.LoginRequest) {
+	if s.GeoIP == nil || req.IPAddress == "" {
+		return
+	}
+
+	location, err := s.GeoIP.Lookup(ctx, req.IPAddress)
+	if err != nil {
+		helpers.Logger.Error("failed to locate login: ", err)
+		return
+	}
+	if req.Country == "" {
+		req.Country = location.Country
+	}
+	if req.Country == location.Country {
+		req.City = location.City
+	}
+}
+
+// notifyUnusualLogin emails the user when they log in from a country none of
+// their earlier located logins came from, or else with a user agent none of
+// their earlier logins used. The very first login is not reported, and
+// neither is the first located one.
+func (s *LoginService) notifyUnusualLogin(ctx context.Context, req models.LoginRequest, userID int) {
+	if s.Notifications == nil {
+		return
+	}
+
+	event := ""
+	if req.Country != "" {
+		newCountry, err := s.isNewCountry(ctx, userID, req.Country)
+		if err != nil {
+			helpers.Logger.Error("failed to check login history: ", err)
+			return
+		}
+		if newCountry {
+			event = constants.NotificationEventNewCountryLogin
+		}
+	}
+
+	if event == "" && req.UserAgent != "" {
+		seen, err := s.LoginHistoryRepo.HasSuccessfulLogin(ctx, userID, req.UserAgent)
+		if err != nil {
+			helpers.Logger.Error("failed to check login history: ", err)
+			return
+		}
+		if !seen {
+			event = constants.NotificationEventNewDeviceLogin
+		}
+	}
+	if event == "" {
+		return
+	}
+
+	returning, err := s.LoginHistoryRepo.HasSuccessfulLogin(ctx, userID, "")
+	if err != nil {
+		helpers.Logger.Error("failed to check login history: ", err)
+		return
+	}
+	if !returning {
+		return
+	}
+
+	s.Notifications.NotifySecurityEvent(ctx, userID, models.SecurityEvent{
+		Event:     event,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		Country:   req.Country,
+		City:      req.City,
+	})
+}
+
+// isNewCountry reports whether the user has located logins, none of them
+// from country. Logins from before locating have no country and don't count.
+func (s *LoginService) isNewCountry(ctx context.Context, userID int, country string) (bool, error) {
+	seen, err := s.LoginHistoryRepo.HasSuccessfulLoginFromCountry(ctx, userID, country)
+	if err != nil || seen {
+		return false, err
+	}
+	return s.LoginHistoryRepo.HasSuccessfulLoginFromCountry(ctx, userID, "")
+}
+
+// getUserByIdentifier resolves a username, email or phone number. Normalizing
+// only succeeds for the kind the identifier looks like.
+func getUserByIdentifier(ctx context.Context, userRepo interfaces.IUserReader, identifier string) (models.User, error) {
+	email, _ := helpers.NormalizeEmail(identifier)
+	phoneNumber, _ := helpers.NormalizePhoneNumber(identifier)
+	return userRepo.GetUserByIdentifier(ctx, identifier, email, phoneNumber)
+}
+
+// newUserSession issues a token pair for user and stores it as a session. The
+// refresh token becomes the root of a new refresh token family.
+func newUserSession(ctx context.Context, sessionRepo interfaces.ISessionRepository, refreshTokenRepo interfaces.IRefreshTokenRepository, user models.User, policy helpers.TokenPolicy, origin models.TokenOrigin) (models.LoginResponse, error) {
+	resp := models.LoginResponse{}
+	now := time.Now()
+	policy.Guest = user.Type == constants.UserTypeGuest
+
+	token, err := helpers.GenerateTokenWithPolicy(ctx, user.ID, user.Username, user.FullName, "token", user.Email, policy, now)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to generate token")
+	}
+
+	refreshToken, err := helpers.GenerateTokenWithPolicy(ctx, user.ID, user.Username, user.FullName, "refresh_token", user.Email, policy, now)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to generate refresh token")
 	}
 
 	userSession := &models.UserSession{
-		UserID:              userDetail.ID,
+		UserID:              user.ID,
 		Token:               token,
+		TokenID:             helpers.TokenID(ctx, token),
 		RefreshToken:        refreshToken,
-		TokenExpired:        now.Add(helpers.MapTypeToken["token"]),
-		RefreshTokenExpired: now.Add(helpers.MapTypeToken["refresh_token"]),
+		TokenExpired:        now.Add(policy.TTLFor("token")),
+		RefreshTokenExpired: now.Add(policy.TTLFor("refresh_token")),
+		IPAddress:           origin.IPAddress,
+		UserAgent:           origin.UserAgent,
+		Country:             origin.Country,
+		City:                origin.City,
 	}
-	err = s.UserRepo.InsertNewUserSession(ctx, userSession)
+	err = sessionRepo.InsertNewUserSession(ctx, userSession)
 	if err != nil {
-		return resp, fmt.Errorf("failed to insert new session, %v", err)
+		return resp, apperr.Wrap(err, "failed to insert new session")
 	}
 
-	resp.UserID = userDetail.ID
-	resp.Username = userDetail.Username
-	resp.FullName = userDetail.FullName
-	resp.Email = userDetail.Email
+	err = refreshTokenRepo.StartRefreshTokenFamily(ctx, userSession.ID, &models.RefreshToken{
+		FamilyID:  rand.Text(),
+		UserID:    user.ID,
+		TokenHash: helpers.HashToken(refreshToken),
+		TokenID:   helpers.TokenID(ctx, refreshToken),
+		IPAddress: origin.IPAddress,
+		UserAgent: origin.UserAgent,
+		ExpiresAt: userSession.RefreshTokenExpired,
+	})
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to start refresh token family")
+	}
+
+	resp.UserID = user.ID
+	resp.Username = user.Username
+	resp.FullName = user.FullName
+	resp.Email = user.Email
 	resp.Token = token
+	resp.TokenExpiresAt = userSession.TokenExpired.Truncate(time.Second)
 	resp.RefreshToken = refreshToken
+	resp.RefreshTokenExpiresAt = userSession.RefreshTokenExpired.Truncate(time.Second)
+	resp.RequestSigningKey = helpers.RequestSigningKey(userSession.TokenID)
+	resp.Scope = policy.Scope
+	resp.ProfileCompleteness = user.CalculateProfileCompleteness()
 
 	return resp, nil
 }
+
+func (s *LoginService) recordLoginHistory(ctx context.Context, req models.LoginRequest, userID int, success bool) {
+	err := s.LoginHistoryRepo.InsertLoginHistory(ctx, &models.LoginHistory{
+		UserID:    userID,
+		Username:  req.GetIdentifier(),
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		Country:   req.Country,
+		City:      req.City,
+		Success:   success,
+	})
+	if err != nil {
+		helpers.Logger.Error("failed to insert login history: ", err)
+	}
+
+	if !success && s.Velocity != nil {
+		s.Velocity.RecordFailure(ctx, req.LoginSources())
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+)
+
+type LoginHistoryService struct {
+	LoginHistoryRepo interfaces.ILoginHistoryRepository
+}
+
+func (s *LoginHistoryService) GetLoginHistories(ctx context.Context, userID int, req pagination.Request) (pagination.Page[models.LoginHistory], error) {
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.LoginHistory]{}, err
+	}
+
+	limit := req.GetLimit()
+	histories, err := s.LoginHistoryRepo.GetLoginHistoriesByUserID(ctx, userID, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.LoginHistory]{}, apperr.Wrap(err, "failed to get login histories")
+	}
+
+	return pagination.NewPage(histories, limit, func(history models.LoginHistory) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: history.CreatedAt, ID: history.ID}
+	}), nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+// LoginVelocityService watches the failed login rate per IP, ASN and country
+// and alerts operators when a source crosses its threshold within Window,
+// once per source and window.
+type LoginVelocityService struct {
+	VelocityRepo interfaces.ILoginVelocityRepository
+	Alerter      interfaces.IAlerter
+
+	Window time.Duration
+	// Thresholds are the failures per Window that trigger an alert, by
+	// source kind. Kinds without a threshold are not tracked.
+	Thresholds map[string]int
+}
+
+// RecordFailure counts a failed login against each of its sources. It never
+// fails the login, errors are logged.
+func (s *LoginVelocityService) RecordFailure(ctx context.Context, sources []models.LoginSource) {
+	now := time.Now()
+
+	for _, source := range sources {
+		threshold := s.Thresholds[source.Kind]
+		if threshold <= 0 {
+			continue
+		}
+
+		failures, err := s.VelocityRepo.RecordLoginFailure(ctx, source.String(), now, s.Window)
+		if err != nil {
+			helpers.Logger.Error("failed to record login failure: ", err)
+			continue
+		}
+		if failures < int64(threshold) {
+			continue
+		}
+
+		first, err := s.VelocityRepo.MarkLoginVelocityAlerted(ctx, source.String(), s.Window)
+		if err != nil {
+			helpers.Logger.Error("failed to mark login velocity alert: ", err)
+			continue
+		}
+		if first {
+			s.alert(ctx, models.HotLoginSource{LoginSource: source, Failures: failures, Threshold: threshold, Breached: true})
+		}
+	}
+}
+
+// GetHotSources lists the sources with failed logins in the current window,
+// most failures first.
+func (s *LoginVelocityService) GetHotSources(ctx context.Context) ([]models.HotLoginSource, error) {
+	counts, err := s.VelocityRepo.GetLoginFailureCounts(ctx, time.Now(), s.Window)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get login failure counts")
+	}
+
+	sources := make([]models.HotLoginSource, 0, len(counts))
+	for key, failures := range counts {
+		kind, value, _ := strings.Cut(key, ":")
+		threshold := s.Thresholds[kind]
+		sources = append(sources, models.HotLoginSource{
+			LoginSource: models.LoginSource{Kind: kind, Value: value},
+			Failures:    failures,
+			Threshold:   threshold,
+			Breached:    threshold > 0 && failures >= int64(threshold),
+		})
+	}
+
+	slices.SortFunc(sources, func(a, b models.HotLoginSource) int {
+		if c := cmp.Compare(b.Failures, a.Failures); c != 0 {
+			return c
+		}
+		return strings.Compare(a.String(), b.String())
+	})
+	return sources, nil
+}
+
+// alert is sent in the background so the failing login isn't slowed down.
+func (s *LoginVelocityService) alert(ctx context.Context, source models.HotLoginSource) {
+	alert := external.Alert{
+		Title: "Login failure spike",
+		Text:  fmt.Sprintf("%d failed logins from %s %s within %s", source.Failures, source.Kind, source.Value, s.Window),
+		Fields: map[string]string{
+			"kind":      source.Kind,
+			"value":     source.Value,
+			"failures":  strconv.FormatInt(source.Failures, 10),
+			"threshold": strconv.Itoa(source.Threshold),
+		},
+	}
+
+	go func() {
+		if err := s.Alerter.Alert(context.WithoutCancel(ctx), alert); err != nil {
+			helpers.Logger.Error("failed to send login velocity alert: ", err)
+		}
+	}()
+}
@@ -2,14 +2,42 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"ewallet-ums/internal/apperr"
 	"ewallet-ums/internal/interfaces"
 )
 
 type LogoutService struct {
-	UserRepo interfaces.IUserRepository
+	SessionRepo      interfaces.ISessionRepository
+	RefreshTokenRepo interfaces.IRefreshTokenRepository
+	TokenCache       interfaces.ITokenCacheRepository
 }
 
+// Logout ends the session of token and revokes its refresh token along with
+// the refresh token's rotation family, so neither keeps working once the
+// session row is gone.
 func (s *LogoutService) Logout(ctx context.Context, token string) error {
-	return s.UserRepo.DeleteUserSession(ctx, token)
+	session, err := s.SessionRepo.GetUserSessionByToken(ctx, token)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get user session")
+	}
+
+	if err := s.SessionRepo.DeleteUserSession(ctx, token); err != nil {
+		return err
+	}
+
+	if err := s.TokenCache.RevokeToken(ctx, token, session.TokenExpired); err != nil {
+		return apperr.Wrap(err, "failed to revoke cached token")
+	}
+	if err := s.TokenCache.RevokeToken(ctx, session.RefreshToken, session.RefreshTokenExpired); err != nil {
+		return apperr.Wrap(err, "failed to revoke cached refresh token")
+	}
+	if session.FamilyID != "" {
+		if err := s.RefreshTokenRepo.RevokeRefreshTokenFamily(ctx, session.FamilyID, time.Now()); err != nil {
+			return apperr.Wrap(err, "failed to revoke refresh token family")
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+var ErrInvalidUnsubscribeToken = apperr.New(apperr.Invalid, "invalid unsubscribe token")
+
+// securityEmail is the data of security event emails.
+type securityEmail struct {
+	models.SecurityEvent
+	Username       string
+	UnsubscribeURL string
+	Brand          models.Brand
+}
+
+// securitySummaryEmail is the data of the summary of held back security
+// emails.
+type securitySummaryEmail struct {
+	Events   []models.SecurityEventSummary
+	Username string
+	Brand    models.Brand
+}
+
+type activityDigestEmail struct {
+	models.ActivitySummary
+	Username       string
+	UnsubscribeURL string
+	Brand          models.Brand
+}
+
+type notificationEvent struct {
+	Event   string
+	Default bool
+	// Mandatory events can't be turned off, so someone who took over a
+	// session can't silence the notices of taking over the account.
+	Mandatory bool
+}
+
+// notificationEvents lists every event with whether it is on for users who
+// never changed it.
+var notificationEvents = []notificationEvent{
+	{constants.NotificationEventPasswordChanged, true, true},
+	{constants.NotificationEventNewDeviceLogin, true, false},
+	{constants.NotificationEventNewCountryLogin, true, false},
+	{constants.NotificationEventTwoFactorChanged, true, false},
+	{constants.NotificationEventEmailChanged, true, true},
+	{constants.NotificationEventActivityDigest, false, false},
+}
+
+func isMandatoryEvent(event string) bool {
+	return slices.ContainsFunc(notificationEvents, func(e notificationEvent) bool { return e.Event == event && e.Mandatory })
+}
+
+// NotificationService emails users about security events on their account
+// and, if they opted in, their monthly activity, unless they turned the
+// event off. Security emails during the user's quiet hours, or repeating one
+// sent within ThrottleWindow, are held back and sent later as one summary by
+// NotificationSummaryService.
+type NotificationService struct {
+	NotificationRepo interfaces.INotificationRepository
+	UserRepo         interfaces.IUserReader
+	Mailer           interfaces.IMailer
+	Branding         *EmailBranding
+	// Throttle is optional, without it only quiet hours hold emails back.
+	Throttle       interfaces.INotificationThrottleRepository
+	ThrottleWindow time.Duration
+
+	// BaseURL is the public address unsubscribe links point at.
+	BaseURL string
+	// DefaultLocale is used for users who haven't picked a locale.
+	DefaultLocale string
+}
+
+// GetPreferences returns every event with its setting, including the
+// defaults for events the user never changed. Mandatory events are always
+// on.
+func (s *NotificationService) GetPreferences(ctx context.Context, userID int) ([]models.NotificationPreference, error) {
+	stored, err := s.NotificationRepo.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get notification preferences")
+	}
+
+	byEvent := map[string]models.NotificationPreference{}
+	for _, preference := range stored {
+		byEvent[preference.Event] = preference
+	}
+
+	preferences := []models.NotificationPreference{}
+	for _, event := range notificationEvents {
+		preference, ok := byEvent[event.Event]
+		if !ok || event.Mandatory {
+			preference = models.NotificationPreference{UserID: userID, Event: event.Event, Enabled: event.Default}
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, nil
+}
+
+// Unsubscribe turns off the event named by an email's unsubscribe token.
+func (s *NotificationService) Unsubscribe(ctx context.Context, token string) error {
+	userID, event, err := helpers.VerifyUnsubscribeToken(token)
+	if err != nil {
+		return ErrInvalidUnsubscribeToken
+	}
+	if !slices.ContainsFunc(notificationEvents, func(e notificationEvent) bool { return e.Event == event && !e.Mandatory }) {
+		return ErrInvalidUnsubscribeToken
+	}
+
+	err = s.NotificationRepo.UpsertNotificationPreferences(ctx, []models.NotificationPreference{{UserID: userID, Event: event}})
+	if err != nil {
+		return apperr.Wrap(err, "failed to update notification preferences")
+	}
+	return nil
+}
+
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID int, req models.UpdateNotificationPreferencesRequest) ([]models.NotificationPreference, error) {
+	preferences := make([]models.NotificationPreference, 0, len(req.Preferences))
+	for _, item := range req.Preferences {
+		preferences = append(preferences, models.NotificationPreference{
+			UserID:  userID,
+			Event:   item.Event,
+			Enabled: *item.Enabled,
+		})
+	}
+
+	if err := s.NotificationRepo.UpsertNotificationPreferences(ctx, preferences); err != nil {
+		return nil, apperr.Wrap(err, "failed to update notification preferences")
+	}
+	return s.GetPreferences(ctx, userID)
+}
+
+// GetQuietHours returns the user's quiet hours, nil when they have none.
+func (s *NotificationService) GetQuietHours(ctx context.Context, userID int) (*models.NotificationQuietHours, error) {
+	quietHours, err := s.NotificationRepo.GetQuietHours(ctx, userID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get quiet hours")
+	}
+	if quietHours.ID == 0 {
+		return nil, nil
+	}
+	return &quietHours, nil
+}
+
+func (s *NotificationService) UpdateQuietHours(ctx context.Context, userID int, req models.UpdateQuietHoursRequest) (*models.NotificationQuietHours, error) {
+	err := s.NotificationRepo.UpsertQuietHours(ctx, &models.NotificationQuietHours{
+		UserID:   userID,
+		Start:    req.Start,
+		End:      req.End,
+		TimeZone: req.TimeZone,
+	})
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to update quiet hours")
+	}
+	return s.GetQuietHours(ctx, userID)
+}
+
+// DeleteQuietHours turns quiet hours off. Emails already held back are still
+// sent when they are due.
+func (s *NotificationService) DeleteQuietHours(ctx context.Context, userID int) error {
+	if err := s.NotificationRepo.DeleteQuietHours(ctx, userID); err != nil {
+		return apperr.Wrap(err, "failed to delete quiet hours")
+	}
+	return nil
+}
+
+// NotifySecurityEvent sends the email in the background so the request that
+// triggered it doesn't wait on the mail relay. Failures are only logged.
+func (s *NotificationService) NotifySecurityEvent(ctx context.Context, userID int, event models.SecurityEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	go func() {
+		if err := s.SendSecurityEmail(context.WithoutCancel(ctx), userID, event); err != nil {
+			helpers.Logger.Errorf("failed to send %s notification to user %d: %v", event.Event, userID, err)
+		}
+	}()
+}
+
+// SendSecurityEmail renders and sends the email for event, or holds it back
+// for the summary. It is a no-op when the user turned the event off or has no
+// email address.
+func (s *NotificationService) SendSecurityEmail(ctx context.Context, userID int, event models.SecurityEvent) error {
+	known := slices.ContainsFunc(notificationEvents, func(e notificationEvent) bool { return e.Event == event.Event })
+	if !known || event.Event == constants.NotificationEventActivityDigest {
+		return apperr.Newf(apperr.Invalid, "unknown notification event %q", event.Event)
+	}
+
+	user, enabled, err := s.recipient(ctx, userID, event.Event)
+	if err != nil || !enabled {
+		return err
+	}
+
+	// email changes go to the old address, a later summary to the new one
+	// would miss its owner
+	if event.Event != constants.NotificationEventEmailChanged {
+		held, err := s.holdBack(ctx, userID, event)
+		if err != nil || held {
+			return err
+		}
+	}
+
+	to := user.Email
+	if event.Event == constants.NotificationEventEmailChanged {
+		to = event.PreviousEmail
+	}
+
+	brand := s.Branding.resolve(ctx, user.ClientID)
+	unsubscribeURL := s.unsubscribeURL(userID, event.Event)
+	return s.send(ctx, brand, to, event.Event, s.locale(user), unsubscribeURL, securityEmail{event, user.Username, unsubscribeURL, brand.Brand})
+}
+
+// holdBack queues event for the summary when the user is in their quiet
+// hours or was sent the same event within the throttle window, and reports
+// whether it did.
+func (s *NotificationService) holdBack(ctx context.Context, userID int, event models.SecurityEvent) (bool, error) {
+	now := time.Now()
+	quietHours, err := s.NotificationRepo.GetQuietHours(ctx, userID)
+	if err != nil {
+		return false, apperr.Wrap(err, "failed to get quiet hours")
+	}
+
+	sendAfter, quiet := quietHours.Until(now)
+	if !quiet {
+		if s.Throttle == nil || s.ThrottleWindow <= 0 {
+			return false, nil
+		}
+		claimed, err := s.Throttle.ClaimNotification(ctx, userID, event.Event, s.ThrottleWindow)
+		if err != nil {
+			// a duplicate is better than a missing security email
+			helpers.Logger.Error("failed to throttle notification: ", err)
+			return false, nil
+		}
+		if claimed {
+			return false, nil
+		}
+		sendAfter = now.Add(s.ThrottleWindow)
+	}
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+	err = s.NotificationRepo.InsertPendingNotification(ctx, &models.PendingNotification{
+		UserID:     userID,
+		Event:      event.Event,
+		IPAddress:  event.IPAddress,
+		UserAgent:  event.UserAgent,
+		Country:    event.Country,
+		City:       event.City,
+		OccurredAt: event.OccurredAt,
+		SendAfter:  sendAfter,
+	})
+	if err != nil {
+		return false, apperr.Wrap(err, "failed to hold notification back")
+	}
+	return true, nil
+}
+
+// SendSecuritySummary emails the user the held back notifications, each
+// event once with how often it happened. Events the user turned off since
+// are left out, and a single held notification is sent as itself.
+func (s *NotificationService) SendSecuritySummary(ctx context.Context, userID int, pending []models.PendingNotification) error {
+	preferences, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+	enabled := map[string]bool{}
+	for _, preference := range preferences {
+		enabled[preference.Event] = preference.Enabled
+	}
+
+	summaries := []models.SecurityEventSummary{}
+	byEvent := map[string]int{}
+	for _, notification := range pending {
+		if !enabled[notification.Event] {
+			continue
+		}
+		i, ok := byEvent[notification.Event]
+		if !ok {
+			i = len(summaries)
+			byEvent[notification.Event] = i
+			summaries = append(summaries, models.SecurityEventSummary{Event: notification.Event})
+		}
+		summaries[i].Count++
+		if notification.OccurredAt.After(summaries[i].Last.OccurredAt) {
+			summaries[i].Last = notification.SecurityEvent()
+		}
+	}
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get user")
+	}
+	brand := s.Branding.resolve(ctx, user.ClientID)
+
+	if len(summaries) == 1 && summaries[0].Count == 1 {
+		event := summaries[0].Last
+		unsubscribeURL := s.unsubscribeURL(userID, event.Event)
+		return s.send(ctx, brand, user.Email, event.Event, s.locale(user), unsubscribeURL, securityEmail{event, user.Username, unsubscribeURL, brand.Brand})
+	}
+	return s.send(ctx, brand, user.Email, constants.EmailTemplateSecuritySummary, s.locale(user), "", securitySummaryEmail{summaries, user.Username, brand.Brand})
+}
+
+// SendActivityDigest emails the user's monthly activity if they opted in.
+func (s *NotificationService) SendActivityDigest(ctx context.Context, userID int, summary models.ActivitySummary) error {
+	user, enabled, err := s.recipient(ctx, userID, constants.NotificationEventActivityDigest)
+	if err != nil || !enabled {
+		return err
+	}
+
+	brand := s.Branding.resolve(ctx, user.ClientID)
+	unsubscribeURL := s.unsubscribeURL(userID, constants.NotificationEventActivityDigest)
+	return s.send(ctx, brand, user.Email, constants.NotificationEventActivityDigest, s.locale(user), unsubscribeURL, activityDigestEmail{summary, user.Username, unsubscribeURL, brand.Brand})
+}
+
+// SendPasswordResetLink emails a password reset link to an address that
+// isn't the user's yet, e.g. the contact email of a manual recovery. It
+// can't be turned off.
+func (s *NotificationService) SendPasswordResetLink(ctx context.Context, userID int, to string, link models.EmailLink) error {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get user")
+	}
+
+	brand := s.Branding.resolve(ctx, user.ClientID)
+	link.Username = user.Username
+	link.Brand = brand.Brand
+	return s.send(ctx, brand, to, constants.EmailTemplatePasswordReset, s.locale(user), "", link)
+}
+
+// recipient loads the user and whether they want emails for event, which
+// they always do for mandatory events.
+func (s *NotificationService) recipient(ctx context.Context, userID int, event string) (models.User, bool, error) {
+	if !isMandatoryEvent(event) {
+		preferences, err := s.GetPreferences(ctx, userID)
+		if err != nil {
+			return models.User{}, false, err
+		}
+		for _, preference := range preferences {
+			if preference.Event == event && !preference.Enabled {
+				return models.User{}, false, nil
+			}
+		}
+	}
+
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return user, false, apperr.Wrap(err, "failed to get user")
+	}
+	return user, true, nil
+}
+
+func (s *NotificationService) locale(user models.User) string {
+	if user.Locale != "" {
+		return user.Locale
+	}
+	return s.DefaultLocale
+}
+
+func (s *NotificationService) send(ctx context.Context, brand emailBrand, to, template, locale, unsubscribeURL string, data any) error {
+	if to == "" {
+		return nil
+	}
+
+	email, err := s.Branding.render(brand, template, locale, data)
+	if err != nil {
+		return apperr.Wrap(err, "failed to render notification")
+	}
+
+	err = s.Mailer.Send(ctx, external.Mail{
+		From:           brand.From,
+		To:             to,
+		Subject:        email.Subject,
+		Body:           email.Text,
+		HTMLBody:       email.HTML,
+		Template:       email.Template + "/" + email.Locale + "/" + email.Version,
+		UnsubscribeURL: unsubscribeURL,
+	})
+	if err != nil {
+		return apperr.WrapAs(apperr.Upstream, err, "failed to send notification")
+	}
+	return nil
+}
+
+// unsubscribeURL is empty for mandatory events, there is nothing to
+// unsubscribe from.
+func (s *NotificationService) unsubscribeURL(userID int, event string) string {
+	if isMandatoryEvent(event) {
+		return ""
+	}
+	return s.BaseURL + "/user/v1/notification-preferences/unsubscribe?token=" + helpers.SignUnsubscribeToken(userID, event)
+}
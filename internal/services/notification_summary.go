@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+)
+
+// NotificationSummaryService sends the security emails NotificationService
+// held back, one summary per user once the earliest is due. Users still in
+// their quiet hours are deferred to the end of them. Failed sends stay
+// pending and are retried on the next run.
+type NotificationSummaryService struct {
+	NotificationRepo interfaces.INotificationRepository
+	Notifications    interfaces.INotificationService
+
+	BatchSize int
+	Interval  time.Duration
+}
+
+// SendSummaries sends the summaries due by now and returns how many users
+// got one.
+func (s *NotificationSummaryService) SendSummaries(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	for afterID := 0; ; {
+		userIDs, err := s.NotificationRepo.GetDuePendingNotificationUsers(ctx, now, afterID, s.BatchSize)
+		if err != nil {
+			return sent, apperr.Wrap(err, "failed to get pending notification users")
+		}
+		if len(userIDs) == 0 {
+			return sent, nil
+		}
+
+		for _, userID := range userIDs {
+			afterID = userID
+
+			quietHours, err := s.NotificationRepo.GetQuietHours(ctx, userID)
+			if err != nil {
+				return sent, apperr.Wrap(err, "failed to get quiet hours")
+			}
+			if until, quiet := quietHours.Until(now); quiet {
+				if err := s.NotificationRepo.DeferPendingNotifications(ctx, userID, until); err != nil {
+					return sent, apperr.Wrap(err, "failed to defer pending notifications")
+				}
+				continue
+			}
+
+			pending, err := s.NotificationRepo.GetPendingNotifications(ctx, userID)
+			if err != nil {
+				return sent, apperr.Wrap(err, "failed to get pending notifications")
+			}
+			if err := s.Notifications.SendSecuritySummary(ctx, userID, pending); err != nil {
+				helpers.Logger.Errorf("failed to send notification summary to user %d: %v", userID, err)
+				continue
+			}
+
+			ids := make([]int, 0, len(pending))
+			for _, notification := range pending {
+				ids = append(ids, notification.ID)
+			}
+			if err := s.NotificationRepo.DeletePendingNotifications(ctx, ids); err != nil {
+				return sent, apperr.Wrap(err, "failed to delete pending notifications")
+			}
+			sent++
+		}
+	}
+}
+
+func (s *NotificationSummaryService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := s.SendSummaries(ctx, time.Now())
+		if err != nil {
+			log.Error("failed on notification summary: ", err)
+		} else if sent > 0 {
+			log.Info("notification summaries sent: ", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidOTP           = apperr.New(apperr.Unauthorized, "invalid or expired code")
+	ErrOTPTooSoon           = apperr.New(apperr.RateLimited, "a code was sent moments ago, wait before asking for another")
+	ErrPhoneNumberTaken     = apperr.New(apperr.Conflict, "phone number already registered")
+	ErrPasswordlessDisabled = apperr.New(apperr.Forbidden, "passwordless accounts are not enabled")
+)
+
+// OTPService texts one-time codes to phone numbers, for passwordless users
+// in phone-only markets: they register with a code sent to their number and
+// log in with one, until they set a password. Codes are single use and
+// expire after TTL or MaxAttempts wrong guesses.
+type OTPService struct {
+	OTPRepo interfaces.IOTPRepository
+	// SMS is optional, without it no codes are sent and passwordless
+	// registration is disabled.
+	SMS              interfaces.ISMSSender
+	UserRepo         interfaces.IUserReader
+	Register         interfaces.IRegisterService
+	SessionRepo      interfaces.ISessionRepository
+	RefreshTokenRepo interfaces.IRefreshTokenRepository
+
+	TTL time.Duration
+	// ResendInterval is how long a phone number waits between codes.
+	ResendInterval time.Duration
+	MaxAttempts    int
+}
+
+// RequestOTP texts a code for req.Purpose. Numbers that can't use it, a
+// registered one for register, an unknown one for login or recovery and one
+// whose user has a password for login, get no code and the same answer, so
+// the endpoint doesn't tell which numbers are registered.
+func (s *OTPService) RequestOTP(ctx context.Context, req models.OTPRequest) error {
+	if s.SMS == nil {
+		return ErrPasswordlessDisabled
+	}
+
+	user, err := s.UserRepo.GetUserByIdentifier(ctx, "", "", req.PhoneNumber)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperr.Wrap(err, "failed to get user by phone number")
+	}
+	registered := err == nil
+	if req.Purpose == constants.OTPPurposeRegister && registered {
+		return nil
+	}
+	if req.Purpose != constants.OTPPurposeRegister && (!registered || user.Type != constants.UserTypeHuman) {
+		return nil
+	}
+	// only passwordless users log in with a code
+	if req.Purpose == constants.OTPPurposeLogin && user.Password != "" {
+		return nil
+	}
+
+	phoneLookup := helpers.PIILookup(req.PhoneNumber)
+	if s.ResendInterval > 0 {
+		allowed, err := s.OTPRepo.MarkOTPSent(ctx, phoneLookup, s.ResendInterval)
+		if err != nil {
+			return apperr.Wrap(err, "failed to throttle code")
+		}
+		if !allowed {
+			return ErrOTPTooSoon
+		}
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return apperr.Wrap(err, "failed to generate code")
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	if err := s.OTPRepo.SaveOTP(ctx, req.Purpose, phoneLookup, otpHash(phoneLookup, code), s.TTL); err != nil {
+		return apperr.Wrap(err, "failed to save code")
+	}
+
+	text := fmt.Sprintf("Your code is %s. It expires in %d minutes, never share it.", code, max(1, int(s.TTL/time.Minute)))
+	if err := s.SMS.SendSMS(ctx, external.SMS{To: req.PhoneNumber, Text: text}); err != nil {
+		return apperr.WrapAs(apperr.Upstream, err, "failed to send code")
+	}
+	return nil
+}
+
+// VerifyOTP spends the phone number's code for purpose when code matches.
+func (s *OTPService) VerifyOTP(ctx context.Context, purpose, phoneNumber, code string) error {
+	phoneLookup := helpers.PIILookup(phoneNumber)
+	codeHash, attempts, err := s.OTPRepo.AttemptOTP(ctx, purpose, phoneLookup)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get code")
+	}
+	if codeHash == "" {
+		return ErrInvalidOTP
+	}
+
+	if attempts > s.MaxAttempts || !hmac.Equal([]byte(codeHash), []byte(otpHash(phoneLookup, code))) {
+		if attempts >= s.MaxAttempts {
+			if err := s.OTPRepo.DeleteOTP(ctx, purpose, phoneLookup); err != nil {
+				helpers.Logger.Error("failed to delete code: ", err)
+			}
+		}
+		return ErrInvalidOTP
+	}
+
+	if err := s.OTPRepo.DeleteOTP(ctx, purpose, phoneLookup); err != nil {
+		return apperr.Wrap(err, "failed to spend code")
+	}
+	return nil
+}
+
+// RegisterWithPhone registers a passwordless user once their code checks
+// out, and logs them in.
+func (s *OTPService) RegisterWithPhone(ctx context.Context, req models.PhoneRegisterRequest) (models.LoginResponse, error) {
+	if s.SMS == nil {
+		return models.LoginResponse{}, ErrPasswordlessDisabled
+	}
+
+	if _, err := s.UserRepo.GetUserByIdentifier(ctx, "", "", req.PhoneNumber); err == nil {
+		return models.LoginResponse{}, ErrPhoneNumberTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.LoginResponse{}, apperr.Wrap(err, "failed to get user by phone number")
+	}
+	if _, err := s.UserRepo.GetUserByUsername(ctx, req.Username); err == nil {
+		return models.LoginResponse{}, ErrUsernameTaken
+	}
+
+	if err := s.VerifyOTP(ctx, constants.OTPPurposeRegister, req.PhoneNumber, req.OTP); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	user := &models.User{
+		Username:    req.Username,
+		PhoneNumber: req.PhoneNumber,
+		FullName:    req.FullName,
+		Locale:      req.Locale,
+		ClientID:    req.ClientID,
+		Attribution: req.Attribution,
+	}
+	if _, err := s.Register.Register(ctx, user); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	origin := models.TokenOrigin{IPAddress: req.IPAddress, UserAgent: req.UserAgent}
+	return newUserSession(ctx, s.SessionRepo, s.RefreshTokenRepo, *user, helpers.TokenPolicy{}, origin)
+}
+
+// otpHash is a keyed hash of the code, so the stored hashes of six digit
+// codes can't be reversed by trying them all.
+func otpHash(phoneLookup, code string) string {
+	return helpers.PIILookup(phoneLookup + ":" + code)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+// defaultPIIRotationBatchSize stands in for a BatchSize that isn't positive,
+// fetching batches of none would never finish.
+const defaultPIIRotationBatchSize = 500
+
+type PIIRotationService struct {
+	PIIRepo   interfaces.IPIIRepository
+	Keyring   *helpers.PIIKeyring
+	BatchSize int
+}
+
+// Rotate optionally creates a fresh data key and re-wraps old data keys
+// under the active master key, then re-encrypts every user row not yet
+// under the active data key. With reindex every row is rewritten, e.g. to
+// recompute the lookups after PII_LOOKUP_KEY changed. It is safe to re-run
+// after an interruption.
+func (s *PIIRotationService) Rotate(ctx context.Context, newDataKey, rewrap, reindex bool) (int, error) {
+	log := helpers.Logger
+
+	if s.Keyring == nil {
+		return 0, apperr.New(apperr.Internal, "pii encryption is not configured")
+	}
+
+	if newDataKey {
+		id, err := s.Keyring.RotateDataKey(ctx)
+		if err != nil {
+			return 0, apperr.Wrap(err, "failed to rotate data key")
+		}
+		log.Info("pii data key rotated, active key: ", id)
+	}
+
+	if rewrap {
+		rewrapped, err := s.Keyring.RewrapDataKeys(ctx)
+		if err != nil {
+			return 0, apperr.Wrap(err, "failed to rewrap data keys")
+		}
+		log.Info("pii data keys rewrapped: ", rewrapped)
+	}
+
+	prefix, err := s.Keyring.ActivePrefix(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPIIRotationBatchSize
+	}
+
+	var total, afterID int
+	for {
+		var users []models.User
+		if reindex {
+			users, err = s.PIIRepo.GetUsersAfter(ctx, afterID, batchSize)
+		} else {
+			users, err = s.PIIRepo.GetUsersWithStalePII(ctx, prefix, afterID, batchSize)
+		}
+		if err != nil {
+			return total, apperr.Wrap(err, "failed to get users to re-encrypt")
+		}
+
+		for i := range users {
+			if err := s.PIIRepo.ReencryptUserPII(ctx, &users[i]); err != nil {
+				return total, apperr.Wrapf(err, "failed to re-encrypt user %d", users[i].ID)
+			}
+			afterID = users[i].ID
+		}
+		total += len(users)
+
+		if len(users) < batchSize {
+			return total, nil
+		}
+	}
+}
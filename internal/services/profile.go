@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUserVersionConflict is returned when the user changed between being
+	// read and updated, or since the version the client sent.
+	ErrUserVersionConflict = apperr.New(apperr.Conflict, "user was changed by another update")
+	// ErrInvalidEmailChangeRevert covers unknown, used and expired undo links
+	// alike.
+	ErrInvalidEmailChangeRevert = apperr.New(apperr.Unauthorized, "invalid or expired email change undo link")
+	ErrIncorrectCode            = apperr.New(apperr.Forbidden, "incorrect or expired code")
+)
+
+type ProfileService struct {
+	UserRepo          interfaces.IUserRepository
+	UserClaimsService interfaces.IUserClaimsService
+	// Notifications is optional, nil sends no security emails.
+	Notifications interfaces.INotificationService
+
+	// EmailChangeRepo is optional, without it email_changed notices carry no
+	// undo link. Undoing a change logs the user out with SessionRepo and
+	// TokenCache.
+	EmailChangeRepo interfaces.IEmailChangeRepository
+	SessionRepo     interfaces.ISessionRepository
+	TokenCache      interfaces.ITokenCacheRepository
+	AuditRepo       interfaces.IAuditRepository
+	// RevertURL is the page undo links point at, the token is appended.
+	RevertURL string
+	RevertTTL time.Duration
+
+	PasswordHasher interfaces.IPasswordHasher
+	// OTP confirms email changes of passwordless users.
+	OTP interfaces.IOTPService
+	// TrustedDevices is optional, new passwords and undone email changes
+	// also forget the user's trusted devices with it.
+	TrustedDevices interfaces.ITrustedDeviceRepository
+}
+
+func (s *ProfileService) GetProfile(ctx context.Context, userID int) (models.User, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return user, apperr.Wrap(err, "failed to get user")
+	}
+
+	user.Password = ""
+	user.ProfileCompleteness = user.CalculateProfileCompleteness()
+	return user, nil
+}
+
+// UpdateProfile returns ErrUserVersionConflict along with the latest profile
+// when the user changed since req.Version, or since it was read here. A new
+// email takes the current password, or a login code for passwordless users,
+// so a stolen session can't move the account's recovery address.
+func (s *ProfileService) UpdateProfile(ctx context.Context, userID int, req models.UpdateProfileRequest) (models.User, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return user, apperr.Wrap(err, "failed to get user")
+	}
+	if req.Email != "" && req.Email != user.Email {
+		if err := reauthenticate(ctx, s.PasswordHasher, s.OTP, user, req.CurrentPassword, req.OTP); err != nil {
+			return user, err
+		}
+	}
+
+	version := user.Version
+	if req.Version != 0 {
+		version = req.Version
+	}
+
+	update := models.User{
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		FullName:    req.FullName,
+		Address:     req.Address,
+		Dob:         req.Dob,
+		Locale:      req.Locale,
+	}
+	applied, err := s.UserRepo.UpdateUser(ctx, userID, version, update)
+	if err != nil {
+		return user, apperr.Wrap(err, "failed to update user")
+	}
+	if !applied {
+		latest, err := s.GetProfile(ctx, userID)
+		if err != nil {
+			return latest, err
+		}
+		return latest, ErrUserVersionConflict
+	}
+
+	// only fields carried in token claims need the caches invalidated
+	changedClaims := []string{}
+	if req.Email != "" && req.Email != user.Email {
+		changedClaims = append(changedClaims, "email")
+	}
+	if req.FullName != "" && req.FullName != user.FullName {
+		changedClaims = append(changedClaims, "full_name")
+	}
+
+	updated, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return updated, err
+	}
+	if updated.ProfileCompleteness != user.CalculateProfileCompleteness() {
+		changedClaims = append(changedClaims, "profile_completeness")
+	}
+
+	if len(changedClaims) > 0 {
+		if err := s.UserClaimsService.InvalidateUserClaims(ctx, userID, changedClaims); err != nil {
+			return updated, err
+		}
+	}
+
+	if s.Notifications != nil && user.Email != "" && updated.Email != user.Email {
+		event := models.SecurityEvent{
+			Event:         constants.NotificationEventEmailChanged,
+			PreviousEmail: user.Email,
+		}
+		s.issueRevert(ctx, userID, user.Email, updated.Email, &event)
+		s.Notifications.NotifySecurityEvent(ctx, userID, event)
+	}
+
+	return updated, nil
+}
+
+// issueRevert adds an undo link to the email_changed notice. Failures are
+// logged, the notice still goes out without one.
+func (s *ProfileService) issueRevert(ctx context.Context, userID int, previousEmail, newEmail string, event *models.SecurityEvent) {
+	if s.EmailChangeRepo == nil {
+		return
+	}
+
+	token := rand.Text()
+	err := s.EmailChangeRepo.InsertEmailChangeRevert(ctx, &models.EmailChangeRevert{
+		UserID:        userID,
+		PreviousEmail: previousEmail,
+		NewEmail:      newEmail,
+		TokenHash:     helpers.HashToken(token),
+		ExpiresAt:     time.Now().Add(s.RevertTTL),
+	})
+	if err != nil {
+		helpers.Logger.Errorf("failed to insert email change revert of user %d: %v", userID, err)
+		return
+	}
+
+	event.RevertURL = s.RevertURL + "?token=" + token
+	event.RevertExpiresInHours = int(s.RevertTTL.Hours())
+}
+
+// RevertEmailChange redeems an undo link: it restores the previous email,
+// replaces the password and logs the user out everywhere, whoever changed
+// the email may know the password and hold a session. The user's other undo
+// links stop working.
+func (s *ProfileService) RevertEmailChange(ctx context.Context, req models.EmailChangeRevertRequest) (models.EmailChangeRevertResponse, error) {
+	resp := models.EmailChangeRevertResponse{}
+	if s.EmailChangeRepo == nil {
+		return resp, ErrInvalidEmailChangeRevert
+	}
+
+	revert, err := s.EmailChangeRepo.GetEmailChangeRevertByToken(ctx, helpers.HashToken(req.Token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return resp, ErrInvalidEmailChangeRevert
+	}
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to get email change revert")
+	}
+	now := time.Now()
+	if revert.RevertedAt != nil || !now.Before(revert.ExpiresAt) {
+		return resp, ErrInvalidEmailChangeRevert
+	}
+
+	user, err := s.UserRepo.GetUserByID(ctx, revert.UserID)
+	if err != nil || user.Status == constants.UserStatusBanned {
+		return resp, ErrInvalidEmailChangeRevert
+	}
+
+	password, err := s.PasswordHasher.HashPassword(ctx, req.NewPassword)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to hash password")
+	}
+
+	// the link is spent along with the account change, so it works only once
+	// and a failed update leaves it usable
+	reverted, err := s.EmailChangeRepo.RevertEmailChange(ctx, &revert, user.Version, password, now)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to revert email change")
+	}
+	if !reverted {
+		if latest, err := s.EmailChangeRepo.GetEmailChangeRevertByToken(ctx, revert.TokenHash); err == nil && latest.RevertedAt == nil && now.Before(latest.ExpiresAt) {
+			return resp, ErrUserVersionConflict
+		}
+		return resp, ErrInvalidEmailChangeRevert
+	}
+
+	if user.Email != revert.PreviousEmail {
+		if err := s.UserClaimsService.InvalidateUserClaims(ctx, user.ID, []string{"email"}); err != nil {
+			return resp, err
+		}
+	}
+
+	resp.SessionsRevoked, err = revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, user.ID)
+	if err != nil {
+		return resp, err
+	}
+	if err := forgetTrustedDevices(ctx, s.TrustedDevices, user.ID); err != nil {
+		return resp, err
+	}
+
+	s.audit(ctx, user.ID, constants.AuditActionEmailChangeReverted, map[string]any{
+		"revert_id":        revert.ID,
+		"sessions_revoked": resp.SessionsRevoked,
+	})
+	if s.Notifications != nil {
+		s.Notifications.NotifySecurityEvent(ctx, user.ID, models.SecurityEvent{Event: constants.NotificationEventPasswordChanged})
+	}
+	return resp, nil
+}
+
+// SetPassword changes the user's password, or sets the first one of a
+// passwordless user, who can then log in with either. Changing a password
+// takes the current one.
+func (s *ProfileService) SetPassword(ctx context.Context, userID int, req models.SetPasswordRequest) error {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get user")
+	}
+	if user.Type != constants.UserTypeHuman {
+		return apperr.Newf(apperr.Forbidden, "user %d is a %s account", user.ID, user.Type)
+	}
+
+	first := user.Password == ""
+	if !first {
+		if err := s.PasswordHasher.ComparePassword(ctx, user.Password, req.CurrentPassword); err != nil {
+			return ErrIncorrectPassword
+		}
+	}
+
+	password, err := s.PasswordHasher.HashPassword(ctx, req.NewPassword)
+	if err != nil {
+		return apperr.Wrap(err, "failed to hash password")
+	}
+	if err := s.UserRepo.UpdateUserPassword(ctx, user.ID, password, false); err != nil {
+		return apperr.Wrap(err, "failed to update password")
+	}
+	if err := forgetTrustedDevices(ctx, s.TrustedDevices, user.ID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, user.ID, constants.AuditActionPasswordSet, map[string]any{"first_password": first})
+	if s.Notifications != nil {
+		s.Notifications.NotifySecurityEvent(ctx, user.ID, models.SecurityEvent{Event: constants.NotificationEventPasswordChanged})
+	}
+	return nil
+}
+
+// audit failures are logged rather than returned, the change is made.
+func (s *ProfileService) audit(ctx context.Context, userID int, action string, detail any) {
+	details, err := json.Marshal(detail)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        models.UserActor(userID),
+			Action:       action,
+			TargetUserID: userID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
+
+// reauthenticate confirms a sensitive change with the user's password, or
+// for passwordless users with a login code texted to their phone number.
+func reauthenticate(ctx context.Context, hasher interfaces.IPasswordHasher, otp interfaces.IOTPService, user models.User, password, code string) error {
+	if user.Password != "" {
+		if err := hasher.ComparePassword(ctx, user.Password, password); err != nil {
+			return ErrIncorrectPassword
+		}
+		return nil
+	}
+
+	if otp == nil || user.PhoneNumber == "" || code == "" {
+		return ErrIncorrectCode
+	}
+	if err := otp.VerifyOTP(ctx, constants.OTPPurposeLogin, user.PhoneNumber, code); err != nil {
+		if errors.Is(err, ErrInvalidOTP) {
+			return ErrIncorrectCode
+		}
+		return err
+	}
+	return nil
+}
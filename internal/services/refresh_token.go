@@ -2,31 +2,197 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"crypto/rand"
+	"encoding/json"
 	"time"
 
+	"ewallet-ums/constants"
 	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
 	"ewallet-ums/internal/interfaces"
 	"ewallet-ums/internal/models"
 )
 
+var (
+	ErrInvalidRefreshToken = apperr.New(apperr.Unauthorized, "refresh token is not active")
+	ErrRefreshTokenReused  = apperr.New(apperr.Unauthorized, "refresh token was already used")
+)
+
+// RefreshTokenService rotates refresh tokens: every refresh returns a new
+// refresh token and retires the one presented. The tokens descending from
+// one login form a family (see models.RefreshToken), and presenting a
+// retired token again revokes the whole family, ending the session for
+// both whoever stole the token and the legitimate client.
 type RefreshTokenService struct {
-	UserRepo interfaces.IUserRepository
+	SessionRepo      interfaces.ISessionRepository
+	ClientRepo       interfaces.IClientRepository
+	RefreshTokenRepo interfaces.IRefreshTokenRepository
+	AuditRepo        interfaces.IAuditRepository
+	TokenCache       interfaces.ITokenCacheRepository
+	// ActiveUsers is optional, nil doesn't count active users.
+	ActiveUsers interfaces.IActiveUsersRepository
 }
 
-func (s *RefreshTokenService) RefreshToken(ctx context.Context, refreshToken string, tokenClaim helpers.ClaimToken) (models.RefreshTokenResponse, error) {
+func (s *RefreshTokenService) RefreshToken(ctx context.Context, refreshToken string, tokenClaim helpers.ClaimToken, origin models.TokenOrigin) (models.RefreshTokenResponse, error) {
 	resp := models.RefreshTokenResponse{}
+	now := time.Now()
+
+	parent, err := s.RefreshTokenRepo.GetRefreshTokenByHash(ctx, helpers.HashToken(refreshToken))
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to get refresh token")
+	}
+	if parent.ID == 0 {
+		if parent, err = s.adoptSession(ctx, refreshToken); err != nil {
+			return resp, err
+		}
+	}
+
+	if parent.RevokedAt != nil {
+		return resp, ErrInvalidRefreshToken
+	}
+	if parent.UsedAt != nil {
+		s.revokeReusedFamily(ctx, parent, origin, now)
+		return resp, ErrRefreshTokenReused
+	}
+
+	session, err := s.RefreshTokenRepo.GetUserSessionByFamilyID(ctx, parent.FamilyID)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to get user session")
+	}
+	if session.ID == 0 {
+		return resp, ErrInvalidRefreshToken
+	}
+
+	// re-read the client so policy changes apply from the next refresh, but
+	// never widen the scope granted at login
+	policy, err := clientTokenPolicy(ctx, s.ClientRepo, tokenClaim.ClientID, tokenClaim.Scope)
+	if err != nil {
+		return resp, err
+	}
+	if tokenClaim.Scope == "" {
+		policy.Scope = ""
+	}
+	policy.DeviceID = tokenClaim.DeviceID
+	policy.Guest = tokenClaim.Guest
+
+	token, err := helpers.GenerateTokenWithPolicy(ctx, tokenClaim.UserID, tokenClaim.Username, tokenClaim.FullName, "token", tokenClaim.Email, policy, now)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to generate new token")
+	}
+	tokenExpired := now.Add(policy.TTLFor("token"))
+
+	// rotated refresh tokens keep the expiry of the login, so rotating
+	// doesn't extend the session
+	policy.TTL = map[string]time.Duration{"refresh_token": session.RefreshTokenExpired.Sub(now)}
+	newRefreshToken, err := helpers.GenerateTokenWithPolicy(ctx, tokenClaim.UserID, tokenClaim.Username, tokenClaim.FullName, "refresh_token", tokenClaim.Email, policy, now)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to generate new refresh token")
+	}
 
-	token, err := helpers.GenerateToken(ctx, tokenClaim.UserID, tokenClaim.Username, tokenClaim.FullName, "refresh_token", tokenClaim.Email, time.Now())
+	child := &models.RefreshToken{
+		FamilyID:  parent.FamilyID,
+		ParentID:  &parent.ID,
+		UserID:    parent.UserID,
+		TokenHash: helpers.HashToken(newRefreshToken),
+		TokenID:   helpers.TokenID(ctx, newRefreshToken),
+		IPAddress: origin.IPAddress,
+		UserAgent: origin.UserAgent,
+		ExpiresAt: session.RefreshTokenExpired,
+	}
+	oldToken := session
+	session.Token = token
+	session.TokenID = helpers.TokenID(ctx, token)
+	session.TokenExpired = tokenExpired
+	session.RefreshToken = newRefreshToken
+	rotated, err := s.RefreshTokenRepo.RotateRefreshToken(ctx, parent, child, session, now)
 	if err != nil {
-		return resp, fmt.Errorf("failed to generate new token %v", err)
+		return resp, apperr.Wrap(err, "failed to rotate refresh token")
+	}
+	// a concurrent refresh with the same token got there first
+	if !rotated {
+		s.revokeReusedFamily(ctx, parent, origin, now)
+		return resp, ErrRefreshTokenReused
 	}
 
-	err = s.UserRepo.UpdateTokenByRefreshToken(ctx, token, refreshToken)
+	err = s.TokenCache.RevokeToken(ctx, oldToken.Token, oldToken.TokenExpired)
 	if err != nil {
-		return resp, fmt.Errorf("failed to update new token %v", err)
+		return resp, apperr.Wrap(err, "failed to revoke old token")
 	}
 
 	resp.Token = token
+	resp.TokenExpiresAt = tokenExpired.Truncate(time.Second)
+	resp.RefreshToken = newRefreshToken
+	resp.RefreshTokenExpiresAt = session.RefreshTokenExpired.Truncate(time.Second)
+	resp.RequestSigningKey = helpers.RequestSigningKey(session.TokenID)
+	recordActiveUser(ctx, s.ActiveUsers, parent.UserID)
 	return resp, nil
 }
+
+// adoptSession starts a family for a session created before refresh tokens
+// were tracked, with refreshToken as its root.
+func (s *RefreshTokenService) adoptSession(ctx context.Context, refreshToken string) (models.RefreshToken, error) {
+	session, err := s.SessionRepo.GetUserSessionByRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return models.RefreshToken{}, ErrInvalidRefreshToken
+	}
+
+	root := models.RefreshToken{
+		FamilyID:  session.FamilyID,
+		UserID:    session.UserID,
+		TokenHash: helpers.HashToken(refreshToken),
+		TokenID:   helpers.TokenID(ctx, refreshToken),
+		ExpiresAt: session.RefreshTokenExpired,
+	}
+	if root.FamilyID == "" {
+		root.FamilyID = rand.Text()
+	}
+	if err := s.RefreshTokenRepo.StartRefreshTokenFamily(ctx, session.ID, &root); err != nil {
+		return root, apperr.Wrap(err, "failed to start refresh token family")
+	}
+	return root, nil
+}
+
+// revokeReusedFamily ends the session of a family whose retired token was
+// presented again and records the family's history for investigation.
+// Failures are logged, the refresh is rejected either way.
+func (s *RefreshTokenService) revokeReusedFamily(ctx context.Context, reused models.RefreshToken, origin models.TokenOrigin, now time.Time) {
+	log := helpers.Logger
+
+	session, err := s.RefreshTokenRepo.GetUserSessionByFamilyID(ctx, reused.FamilyID)
+	if err != nil {
+		log.Error("failed to get user session: ", err)
+	} else if session.ID != 0 {
+		if err := s.SessionRepo.DeleteUserSession(ctx, session.Token); err != nil {
+			log.Error("failed to delete session: ", err)
+		}
+		if err := s.TokenCache.RevokeToken(ctx, session.Token, session.TokenExpired); err != nil {
+			log.Error("failed to revoke token: ", err)
+		}
+	}
+
+	if err := s.RefreshTokenRepo.RevokeRefreshTokenFamily(ctx, reused.FamilyID, now); err != nil {
+		log.Error("failed to revoke refresh token family: ", err)
+	}
+
+	family, err := s.RefreshTokenRepo.GetRefreshTokenFamily(ctx, reused.FamilyID)
+	if err != nil {
+		log.Error("failed to get refresh token family: ", err)
+	}
+	details, err := json.Marshal(map[string]any{
+		"family_id":       reused.FamilyID,
+		"reused_token_id": reused.ID,
+		"ip_address":      origin.IPAddress,
+		"user_agent":      origin.UserAgent,
+		"family":          family,
+	})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:   models.UserActor(reused.UserID),
+			Action:  constants.AuditActionRefreshTokenReuse,
+			Details: string(details),
+		})
+	}
+	if err != nil {
+		log.Error("failed to insert audit event: ", err)
+	}
+}
@@ -2,36 +2,89 @@ package services
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
 	"ewallet-ums/internal/interfaces"
 	"ewallet-ums/internal/models"
 
-	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
+var ErrUnknownClient = apperr.New(apperr.Invalid, "unknown client")
+
 type RegisterService struct {
-	UserRepo       interfaces.IUserRepository
-	ExternalWallet interfaces.IWallet
+	WalletProvisioningRepo interfaces.IWalletProvisioningRepository
+	ClientRepo             interfaces.IClientRepository
+	PasswordHasher         interfaces.IPasswordHasher
+	EventPublisher         interfaces.IEventPublisher
+	// ReservedUsernames is optional, nil reserves no usernames.
+	ReservedUsernames interfaces.IReservedUsernameService
 }
 
 func (s *RegisterService) Register(ctx context.Context, request *models.User) (any, error) {
-	hashPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
-	if err != nil {
-		return nil, err
+	// the client brands the user's emails, so it must be a registered one
+	if request.ClientID != "" {
+		if _, err := s.ClientRepo.GetClientByClientID(ctx, request.ClientID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrUnknownClient
+			}
+			return nil, apperr.Wrap(err, "failed to get client")
+		}
+	}
+
+	if s.ReservedUsernames != nil {
+		if err := s.ReservedUsernames.CheckUsername(ctx, request.Username); err != nil {
+			return nil, err
+		}
 	}
-	request.Password = string(hashPassword)
 
-	err = s.UserRepo.InsertNewUser(ctx, request)
+	// passwordless users have none until they set one, see OTPService
+	if request.Password != "" {
+		hashPassword, err := s.PasswordHasher.HashPassword(ctx, request.Password)
+		if err != nil {
+			return nil, err
+		}
+		request.Password = hashPassword
+	}
+
+	// the wallet is created by the background worker so registration
+	// latency doesn't depend on the wallet service
+	err := s.WalletProvisioningRepo.InsertUserWithWalletProvisioning(ctx, request, &models.WalletProvisioning{
+		Status:        constants.WalletStatusProvisioning,
+		NextAttemptAt: time.Now(),
+	})
 	if err != nil {
 		return nil, err
 	}
+	helpers.RegistrationsTotal.WithLabelValues(registrationChannel(request.Attribution.Platform)).Inc()
 
-	_, err = s.ExternalWallet.CreateWallet(ctx, request.ID)
+	attribution := request.Attribution
+	err = s.EventPublisher.Publish(ctx, constants.EventUserRegistered, &events.UserRegistered{
+		UserId:      int32(request.ID),
+		UtmSource:   attribution.Source,
+		UtmMedium:   attribution.Medium,
+		UtmCampaign: attribution.Campaign,
+		UtmTerm:     attribution.Term,
+		UtmContent:  attribution.Content,
+		Platform:    attribution.Platform,
+		OccurredAt:  timestamppb.New(request.CreatedAt),
+	})
 	if err != nil {
-		return nil, err
+		// the user is registered either way, analytics may miss the signup
+		helpers.Logger.Error("failed to publish user registered event: ", err)
 	}
 
-	resp := request
+	resp := models.RegisterResponse{
+		User:         *request,
+		WalletStatus: constants.WalletStatusProvisioning,
+	}
 	resp.Password = ""
+	resp.ProfileCompleteness = request.CalculateProfileCompleteness()
 	return resp, nil
 }
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUsernameReserved         = apperr.New(apperr.Conflict, "username is reserved")
+	ErrUsernameAlreadyReserved  = apperr.New(apperr.Conflict, "username is already reserved")
+	ErrReservedUsernameNotFound = apperr.New(apperr.NotFound, "username is not reserved")
+	ErrReservedUsernameClaimed  = apperr.New(apperr.Conflict, "reserved username is already claimed")
+)
+
+// ReservedUsernameService keeps brand, system and executive usernames from
+// being taken at registration or guest upgrade. Admins manage the
+// reservations and claim one for its owner once support verified them.
+type ReservedUsernameService struct {
+	ReservedUsernameRepo interfaces.IReservedUsernameRepository
+	UserRepo             interfaces.IUserReader
+	AuditRepo            interfaces.IAuditRepository
+	UserClaims           interfaces.IUserClaimsService
+}
+
+// CheckUsername returns ErrUsernameReserved when username, in any case, is
+// reserved.
+func (s *ReservedUsernameService) CheckUsername(ctx context.Context, username string) error {
+	_, err := s.ReservedUsernameRepo.GetReservedUsername(ctx, strings.ToLower(username))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return apperr.Wrap(err, "failed to get reserved username")
+	}
+	return ErrUsernameReserved
+}
+
+func (s *ReservedUsernameService) GetReservedUsernames(ctx context.Context, req models.ReservedUsernameListRequest) (pagination.Page[models.ReservedUsername], error) {
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.ReservedUsername]{}, err
+	}
+
+	limit := req.GetLimit()
+	reservations, err := s.ReservedUsernameRepo.GetReservedUsernames(ctx, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.ReservedUsername]{}, apperr.Wrap(err, "failed to get reserved usernames")
+	}
+
+	return pagination.NewPage(reservations, limit, func(reservation models.ReservedUsername) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: reservation.CreatedAt, ID: reservation.ID}
+	}), nil
+}
+
+// ReserveUsername reserves the username for new users only, a user who
+// already has it keeps it.
+func (s *ReservedUsernameService) ReserveUsername(ctx context.Context, actor string, req models.ReservedUsernameRequest) (models.ReservedUsername, error) {
+	reservation := models.ReservedUsername{
+		Username:  strings.ToLower(req.Username),
+		Category:  req.Category,
+		Note:      req.Note,
+		CreatedBy: actor,
+	}
+	reserved, err := s.ReservedUsernameRepo.InsertReservedUsername(ctx, &reservation)
+	if err != nil {
+		return reservation, apperr.Wrap(err, "failed to insert reserved username")
+	}
+	if !reserved {
+		return reservation, ErrUsernameAlreadyReserved
+	}
+
+	s.audit(ctx, actor, constants.AuditActionUsernameReserved, 0, map[string]any{
+		"username": reservation.Username,
+		"category": reservation.Category,
+	})
+	return reservation, nil
+}
+
+func (s *ReservedUsernameService) DeleteReservedUsername(ctx context.Context, actor, username string) error {
+	username = strings.ToLower(username)
+	deleted, err := s.ReservedUsernameRepo.DeleteReservedUsername(ctx, username)
+	if err != nil {
+		return apperr.Wrap(err, "failed to delete reserved username")
+	}
+	if !deleted {
+		return ErrReservedUsernameNotFound
+	}
+
+	s.audit(ctx, actor, constants.AuditActionUsernameReleased, 0, map[string]any{"username": username})
+	return nil
+}
+
+// ClaimReservedUsername makes the reserved username the user's. A
+// reservation is claimed once, later owners need it deleted and reserved
+// again.
+func (s *ReservedUsernameService) ClaimReservedUsername(ctx context.Context, actor, username string, req models.ClaimReservedUsernameRequest) (models.ReservedUsername, error) {
+	reservation, err := s.ReservedUsernameRepo.GetReservedUsername(ctx, strings.ToLower(username))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return reservation, ErrReservedUsernameNotFound
+	}
+	if err != nil {
+		return reservation, apperr.Wrap(err, "failed to get reserved username")
+	}
+	if reservation.ClaimedBy != 0 {
+		return reservation, ErrReservedUsernameClaimed
+	}
+
+	user, err := s.UserRepo.GetUserByID(ctx, req.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return reservation, ErrUserNotFound
+	}
+	if err != nil {
+		return reservation, apperr.Wrap(err, "failed to get user")
+	}
+
+	// reserving doesn't take the username from a user who already had it
+	if existing, err := s.UserRepo.GetUserByUsername(ctx, reservation.Username); err == nil && existing.ID != user.ID {
+		return reservation, ErrUsernameTaken
+	}
+
+	now := time.Now()
+	claimed, err := s.ReservedUsernameRepo.ClaimReservedUsername(ctx, &reservation, user.ID, user.Version, now)
+	if err != nil {
+		return reservation, apperr.Wrap(err, "failed to claim reserved username")
+	}
+	if !claimed {
+		return reservation, ErrUserVersionConflict
+	}
+	reservation.ClaimedBy = user.ID
+	reservation.ClaimedAt = &now
+
+	s.audit(ctx, actor, constants.AuditActionUsernameClaimed, user.ID, map[string]any{
+		"username":          reservation.Username,
+		"previous_username": user.Username,
+		"reason":            req.Reason,
+	})
+	if err := s.UserClaims.InvalidateUserClaims(ctx, user.ID, []string{"username"}); err != nil {
+		return reservation, err
+	}
+	return reservation, nil
+}
+
+// audit failures are logged rather than returned, the reservation is already
+// changed.
+func (s *ReservedUsernameService) audit(ctx context.Context, actor, action string, targetUserID int, detail any) {
+	details, err := json.Marshal(detail)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        actor,
+			Action:       action,
+			TargetUserID: targetUserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
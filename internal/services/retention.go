@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+// defaultRetentionBatchSize stands in for a BatchSize that isn't positive,
+// purging batches of none would never finish.
+const defaultRetentionBatchSize = 1000
+
+type RetentionService struct {
+	RetentionRepo interfaces.IRetentionRepository
+	AuditRepo     interfaces.IAuditRepository
+	Rules         []models.RetentionRule
+	BatchSize     int
+	Interval      time.Duration
+	DryRun        bool
+}
+
+// Enforce purges rows past each rule's retention, or only counts them when
+// dryRun is set. Every rule run is recorded in the audit log, dry runs
+// included, so the purge history can be reconstructed for compliance.
+func (s *RetentionService) Enforce(ctx context.Context, now time.Time, dryRun bool) ([]models.RetentionResult, error) {
+	results := []models.RetentionResult{}
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultRetentionBatchSize
+	}
+
+	for _, rule := range s.Rules {
+		// a zero retention disables the rule
+		if rule.Retention <= 0 {
+			continue
+		}
+
+		result := models.RetentionResult{Rule: rule.Name, Cutoff: now.Add(-rule.Retention), DryRun: dryRun}
+		if dryRun {
+			count, err := s.RetentionRepo.CountExpired(ctx, rule.Name, result.Cutoff)
+			if err != nil {
+				return results, apperr.Wrapf(err, "failed to count %s rows", rule.Name)
+			}
+			result.Rows = count
+		} else {
+			for {
+				purged, err := s.RetentionRepo.PurgeExpired(ctx, rule.Name, result.Cutoff, batchSize)
+				result.Rows += purged
+				if err != nil {
+					// record the partial purge before bailing out
+					s.audit(ctx, result)
+					return results, apperr.Wrapf(err, "failed to purge %s rows", rule.Name)
+				}
+				if purged < int64(batchSize) {
+					break
+				}
+			}
+		}
+
+		if err := s.audit(ctx, result); err != nil {
+			return results, apperr.Wrapf(err, "failed to audit %s purge", rule.Name)
+		}
+		helpers.RetentionPurgedRows.WithLabelValues(rule.Name, fmt.Sprint(dryRun)).Add(float64(result.Rows))
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *RetentionService) audit(ctx context.Context, result models.RetentionResult) error {
+	details, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+		Actor:   constants.AuditActorSystem,
+		Action:  constants.AuditActionRetentionPurge,
+		Details: string(details),
+	})
+}
+
+func (s *RetentionService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		results, err := s.Enforce(ctx, time.Now(), s.DryRun)
+		if err != nil {
+			log.Error("failed on retention enforcement: ", err)
+		}
+		for _, result := range results {
+			log.Infof("retention rule %s: %d rows before %s (dry run: %t)", result.Rule, result.Rows, result.Cutoff.Format(time.RFC3339), result.DryRun)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrScreeningReviewNotFound      = apperr.New(apperr.NotFound, "screening review not found")
+	ErrScreeningReviewResolved      = apperr.New(apperr.Conflict, "screening review is already resolved")
+	ErrInvalidScreeningReviewStatus = apperr.New(apperr.Invalid, "unknown screening review status")
+)
+
+// ScreeningService re-screens active, KYC verified users against PEP and
+// sanctions lists every Cadence. Hits go to a review queue for compliance,
+// who are alerted, and stay there until cleared or confirmed.
+type ScreeningService struct {
+	ScreeningRepo interfaces.IScreeningRepository
+	AddressRepo   interfaces.IAddressRepository
+	AuditRepo     interfaces.IAuditRepository
+	// Provider is optional, without it nobody is screened and the queue
+	// only holds earlier hits.
+	Provider interfaces.IScreeningProvider
+	Alerter  interfaces.IAlerter
+
+	Cadence   time.Duration
+	BatchSize int
+	Interval  time.Duration
+}
+
+// Screen screens the users due by now and returns how many new reviews it
+// raised. Users whose screening fails stay due and are retried on the next
+// run.
+func (s *ScreeningService) Screen(ctx context.Context, now time.Time) (int, error) {
+	if s.Provider == nil {
+		return 0, nil
+	}
+
+	reviews := []models.ScreeningReview{}
+	screened, failed := 0, 0
+	for afterID := 0; ; {
+		users, err := s.ScreeningRepo.GetUsersDueForScreening(ctx, now.Add(-s.Cadence), afterID, s.BatchSize)
+		if err != nil {
+			return len(reviews), apperr.Wrap(err, "failed to get users due for screening")
+		}
+		if len(users) == 0 {
+			break
+		}
+
+		for _, user := range users {
+			afterID = user.ID
+
+			review, err := s.screenUser(ctx, user, now)
+			if err != nil {
+				helpers.Logger.Errorf("failed to screen user %d: %v", user.ID, err)
+				failed++
+				continue
+			}
+			screened++
+			if review != nil {
+				reviews = append(reviews, *review)
+			}
+		}
+	}
+
+	if len(reviews) > 0 {
+		s.alert(ctx, reviews)
+	}
+	if failed > 0 {
+		return len(reviews), fmt.Errorf("failed to screen %d of %d users", failed, screened+failed)
+	}
+	return len(reviews), nil
+}
+
+// screenUser returns the review raised for user, nil when they are clear or
+// their matches were already reviewed.
+func (s *ScreeningService) screenUser(ctx context.Context, user models.User, now time.Time) (*models.ScreeningReview, error) {
+	subject := external.ScreeningSubject{Reference: strconv.Itoa(user.ID), FullName: user.FullName, Dob: user.Dob}
+	if len(subject.Dob) > len(time.DateOnly) {
+		subject.Dob = subject.Dob[:len(time.DateOnly)]
+	}
+	addresses, err := s.AddressRepo.GetUserAddresses(ctx, user.ID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get addresses")
+	}
+	if len(addresses) > 0 && addresses[0].IsPrimary {
+		subject.Country = addresses[0].Country
+	}
+
+	matches, err := s.Provider.Screen(ctx, subject)
+	if err != nil {
+		return nil, apperr.WrapAs(apperr.Upstream, err, "failed to screen user")
+	}
+
+	var review *models.ScreeningReview
+	if len(matches) > 0 {
+		review, err = s.raiseReview(ctx, user.ID, matches)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.ScreeningRepo.MarkUserScreened(ctx, user.ID, now); err != nil {
+		return nil, apperr.Wrap(err, "failed to mark user screened")
+	}
+	return review, nil
+}
+
+func (s *ScreeningService) raiseReview(ctx context.Context, userID int, matches []external.ScreeningMatch) (*models.ScreeningReview, error) {
+	ids, lists := []string{}, []string{}
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+		lists = append(lists, match.List)
+	}
+	slices.Sort(ids)
+	slices.Sort(lists)
+	key := sha256.Sum256([]byte(strings.Join(ids, "\n")))
+
+	payload, err := json.Marshal(matches)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to marshal screening matches")
+	}
+
+	review := &models.ScreeningReview{
+		UserID:   userID,
+		Matches:  payload,
+		Lists:    strings.Join(slices.Compact(lists), ","),
+		MatchKey: hex.EncodeToString(key[:]),
+		Status:   constants.ScreeningReviewStatusPending,
+	}
+	raised, err := s.ScreeningRepo.InsertScreeningReview(ctx, review)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to insert screening review")
+	}
+	if !raised {
+		return nil, nil
+	}
+	return review, nil
+}
+
+// alert failures are logged, the reviews are queued either way.
+func (s *ScreeningService) alert(ctx context.Context, reviews []models.ScreeningReview) {
+	reviewIDs, userIDs := []string{}, []string{}
+	for _, review := range reviews {
+		reviewIDs = append(reviewIDs, strconv.Itoa(review.ID))
+		userIDs = append(userIDs, strconv.Itoa(review.UserID))
+	}
+
+	err := s.Alerter.Alert(ctx, external.Alert{
+		Title: "Screening hits",
+		Text:  fmt.Sprintf("%d users matched PEP or sanctions lists on re-screening, review them in the screening review queue.", len(reviews)),
+		Fields: map[string]string{
+			"review_ids": strings.Join(reviewIDs, ","),
+			"user_ids":   strings.Join(userIDs, ","),
+		},
+	})
+	if err != nil {
+		helpers.Logger.Error("failed to send screening alert: ", err)
+	}
+}
+
+func (s *ScreeningService) GetScreeningReviews(ctx context.Context, req models.ScreeningReviewListRequest) (pagination.Page[models.ScreeningReview], error) {
+	switch req.Status {
+	case "", constants.ScreeningReviewStatusPending, constants.ScreeningReviewStatusCleared, constants.ScreeningReviewStatusConfirmed:
+	default:
+		return pagination.Page[models.ScreeningReview]{}, ErrInvalidScreeningReviewStatus
+	}
+
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.ScreeningReview]{}, err
+	}
+
+	limit := req.GetLimit()
+	reviews, err := s.ScreeningRepo.GetScreeningReviews(ctx, req.Status, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.ScreeningReview]{}, apperr.Wrap(err, "failed to get screening reviews")
+	}
+
+	return pagination.NewPage(reviews, limit, func(review models.ScreeningReview) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: review.CreatedAt, ID: review.ID}
+	}), nil
+}
+
+func (s *ScreeningService) GetScreeningReview(ctx context.Context, reviewID int) (models.ScreeningReview, error) {
+	review, err := s.ScreeningRepo.GetScreeningReviewByID(ctx, reviewID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return review, ErrScreeningReviewNotFound
+	}
+	if err != nil {
+		return review, apperr.Wrap(err, "failed to get screening review")
+	}
+	return review, nil
+}
+
+func (s *ScreeningService) ResolveScreeningReview(ctx context.Context, actor string, reviewID int, req models.ScreeningReviewResolveRequest) (models.ScreeningReview, error) {
+	review, err := s.GetScreeningReview(ctx, reviewID)
+	if err != nil {
+		return review, err
+	}
+	if review.Status != constants.ScreeningReviewStatusPending {
+		return review, ErrScreeningReviewResolved
+	}
+
+	now := time.Now()
+	review.Status = req.Decision
+	review.ResolvedBy = actor
+	review.Note = req.Note
+	review.ResolvedAt = &now
+	resolved, err := s.ScreeningRepo.ResolveScreeningReview(ctx, &review)
+	if err != nil {
+		return review, apperr.Wrap(err, "failed to resolve screening review")
+	}
+	if !resolved {
+		return review, ErrScreeningReviewResolved
+	}
+
+	s.audit(ctx, actor, review)
+	return review, nil
+}
+
+func (s *ScreeningService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		raised, err := s.Screen(ctx, time.Now())
+		if err != nil {
+			log.Error("failed on screening: ", err)
+		}
+		if raised > 0 {
+			log.Warn("screening reviews raised: ", raised)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// audit failures are logged rather than returned, the review is already
+// resolved.
+func (s *ScreeningService) audit(ctx context.Context, actor string, review models.ScreeningReview) {
+	details, err := json.Marshal(map[string]any{
+		"review_id": review.ID,
+		"decision":  review.Status,
+		"lists":     review.Lists,
+		"note":      review.Note,
+	})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        actor,
+			Action:       constants.AuditActionScreeningReviewResolved,
+			TargetUserID: review.UserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+var (
+	ErrIncorrectPassword = apperr.New(apperr.Forbidden, "incorrect password")
+	// ErrRecoveryFailed covers unknown users, users without questions and
+	// wrong answers alike, so recovery can't be used to probe accounts.
+	ErrRecoveryFailed = apperr.New(apperr.Unauthorized, "account recovery failed")
+	ErrRecoveryLocked = apperr.New(apperr.RateLimited, "account recovery is locked, try again later")
+)
+
+// SecurityQuestionService manages security questions and the account
+// recovery they allow when a user can't reach their email. Recovery also
+// takes a code texted to the user's phone number. Failed recoveries are
+// counted per user with the caller guard and lock recovery for LockDuration
+// once FailureLimit is reached, or NoPhoneFailureLimit for users without a
+// phone number, who recover with their answers alone.
+type SecurityQuestionService struct {
+	SecurityQuestionRepo interfaces.ISecurityQuestionRepository
+	UserRepo             interfaces.IUserRepository
+	SessionRepo          interfaces.ISessionRepository
+	AuditRepo            interfaces.IAuditRepository
+	TokenCache           interfaces.ITokenCacheRepository
+	CallerGuard          interfaces.ICallerGuardRepository
+	PasswordHasher       interfaces.IPasswordHasher
+	OTP                  interfaces.IOTPService
+	// Notifications is optional, nil sends no security emails.
+	Notifications interfaces.INotificationService
+	// TrustedDevices is optional, recoveries also forget the user's trusted
+	// devices with it.
+	TrustedDevices interfaces.ITrustedDeviceRepository
+
+	FailureLimit        int
+	NoPhoneFailureLimit int
+	FailureWindow       time.Duration
+	LockDuration        time.Duration
+}
+
+func (s *SecurityQuestionService) GetSecurityQuestions(ctx context.Context, userID int) ([]models.SecurityQuestion, error) {
+	questions, err := s.SecurityQuestionRepo.GetSecurityQuestions(ctx, userID)
+	if err != nil {
+		return questions, apperr.Wrap(err, "failed to get security questions")
+	}
+	return questions, nil
+}
+
+func (s *SecurityQuestionService) SetSecurityQuestions(ctx context.Context, userID int, req models.SetSecurityQuestionsRequest) ([]models.SecurityQuestion, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get user")
+	}
+	if err := reauthenticate(ctx, s.PasswordHasher, s.OTP, user, req.Password, req.OTP); err != nil {
+		return nil, err
+	}
+
+	questions := make([]models.SecurityQuestion, 0, len(req.Questions))
+	for _, item := range req.Questions {
+		answerHash, err := s.PasswordHasher.HashPassword(ctx, helpers.NormalizeSecurityAnswer(item.Answer))
+		if err != nil {
+			return nil, apperr.Wrap(err, "failed to hash security answer")
+		}
+		questions = append(questions, models.SecurityQuestion{
+			UserID:     userID,
+			Question:   item.Question,
+			AnswerHash: answerHash,
+		})
+	}
+
+	if err := s.SecurityQuestionRepo.ReplaceSecurityQuestions(ctx, userID, questions); err != nil {
+		return nil, apperr.Wrap(err, "failed to save security questions")
+	}
+
+	s.audit(ctx, userID, constants.AuditActionSecurityQuestionsSet, map[string]int{"questions": len(questions)})
+	return questions, nil
+}
+
+// decoyQuestions are shown for identifiers without questions of their own.
+var decoyQuestions = []string{
+	"What was the name of your first pet?",
+	"In which city were you born?",
+	"What was the name of your first school?",
+	"What is your mother's maiden name?",
+	"What was the make of your first car?",
+	"What street did you grow up on?",
+}
+
+// GetRecoveryQuestions returns the questions to answer for recovering the
+// account named by identifier. Unknown users, and users without questions,
+// get decoys picked from the identifier's keyed hash, the same ones on
+// every call, so the answer doesn't tell which accounts exist.
+func (s *SecurityQuestionService) GetRecoveryQuestions(ctx context.Context, identifier string) ([]models.RecoveryQuestion, error) {
+	var questions []models.SecurityQuestion
+	user, err := getUserByIdentifier(ctx, s.UserRepo, identifier)
+	if err == nil && user.Type == constants.UserTypeHuman {
+		if questions, err = s.GetSecurityQuestions(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(questions) == 0 {
+		return decoyRecoveryQuestions(identifier), nil
+	}
+	items := make([]models.RecoveryQuestion, 0, len(questions))
+	for i, question := range questions {
+		items = append(items, models.RecoveryQuestion{ID: i + 1, Question: question.Question})
+	}
+	return items, nil
+}
+
+func decoyRecoveryQuestions(identifier string) []models.RecoveryQuestion {
+	seed, _ := hex.DecodeString(helpers.PIILookup("recovery-decoy:" + strings.ToLower(strings.TrimSpace(identifier))))
+	pool := slices.Clone(decoyQuestions)
+
+	// two or three questions, like most users set
+	count := 2 + int(seed[0]%2)
+	items := make([]models.RecoveryQuestion, 0, count)
+	for i := range count {
+		pick := int(seed[i+1]) % len(pool)
+		items = append(items, models.RecoveryQuestion{ID: i + 1, Question: pool[pick]})
+		pool = slices.Delete(pool, pick, pick+1)
+	}
+	return items
+}
+
+// RecoverWithSecurityQuestions sets a new password once every question is
+// answered correctly and logs the user out everywhere.
+func (s *SecurityQuestionService) RecoverWithSecurityQuestions(ctx context.Context, req models.SecurityQuestionRecoveryRequest) error {
+	user, err := getUserByIdentifier(ctx, s.UserRepo, req.Identifier)
+	if err != nil || user.Type != constants.UserTypeHuman || user.Status == constants.UserStatusBanned {
+		return ErrRecoveryFailed
+	}
+
+	guardKey := "recovery:" + strconv.Itoa(user.ID)
+	locked, err := s.CallerGuard.IsBanned(ctx, guardKey)
+	if err != nil {
+		return apperr.Wrap(err, "failed to check recovery lock")
+	}
+	if locked {
+		return ErrRecoveryLocked
+	}
+
+	questions, err := s.SecurityQuestionRepo.GetSecurityQuestions(ctx, user.ID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get security questions")
+	}
+
+	if !s.answersMatch(ctx, questions, req.Answers) || !s.otpMatches(ctx, user, req.OTP) {
+		failures, err := s.CallerGuard.RecordFailure(ctx, guardKey, s.FailureWindow)
+		if err != nil {
+			helpers.Logger.Error("failed to record recovery failure: ", err)
+		} else if failures >= int64(s.failureLimit(user)) {
+			if err := s.CallerGuard.Ban(ctx, guardKey, s.LockDuration); err != nil {
+				helpers.Logger.Error("failed to lock recovery: ", err)
+			}
+		}
+		return ErrRecoveryFailed
+	}
+
+	password, err := s.PasswordHasher.HashPassword(ctx, req.NewPassword)
+	if err != nil {
+		return apperr.Wrap(err, "failed to hash password")
+	}
+	applied, err := s.UserRepo.UpdateUser(ctx, user.ID, user.Version, models.User{Password: password})
+	if err != nil {
+		return apperr.Wrap(err, "failed to update password")
+	}
+	if !applied {
+		return ErrUserVersionConflict
+	}
+
+	revoked, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, user.ID)
+	if err != nil {
+		return err
+	}
+	if err := forgetTrustedDevices(ctx, s.TrustedDevices, user.ID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, user.ID, constants.AuditActionUserRecovered, map[string]any{"factor": "security_questions", "sessions_revoked": revoked})
+	if s.Notifications != nil {
+		s.Notifications.NotifySecurityEvent(ctx, user.ID, models.SecurityEvent{Event: constants.NotificationEventPasswordChanged})
+	}
+	return nil
+}
+
+// answersMatch requires an answer to every one of the user's questions, by
+// their position as in GetRecoveryQuestions. A user without questions can't
+// recover this way.
+func (s *SecurityQuestionService) answersMatch(ctx context.Context, questions []models.SecurityQuestion, answers []models.SecurityAnswerItem) bool {
+	if len(questions) == 0 || len(answers) != len(questions) {
+		return false
+	}
+
+	byID := make(map[int]string, len(answers))
+	for _, answer := range answers {
+		byID[answer.QuestionID] = answer.Answer
+	}
+
+	for i, question := range questions {
+		answer, ok := byID[i+1]
+		if !ok {
+			return false
+		}
+		if err := s.PasswordHasher.ComparePassword(ctx, question.AnswerHash, helpers.NormalizeSecurityAnswer(answer)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// otpMatches spends the recovery code texted to the user's phone number.
+// Users without one have no code to send, failureLimit locks them out
+// sooner instead.
+func (s *SecurityQuestionService) otpMatches(ctx context.Context, user models.User, code string) bool {
+	if user.PhoneNumber == "" {
+		return true
+	}
+	if err := s.OTP.VerifyOTP(ctx, constants.OTPPurposeRecovery, user.PhoneNumber, code); err != nil {
+		if !errors.Is(err, ErrInvalidOTP) {
+			helpers.Logger.Error("failed to verify recovery code: ", err)
+		}
+		return false
+	}
+	return true
+}
+
+func (s *SecurityQuestionService) failureLimit(user models.User) int {
+	if user.PhoneNumber == "" {
+		return s.NoPhoneFailureLimit
+	}
+	return s.FailureLimit
+}
+
+// audit failures are logged rather than returned, like for admin approvals.
+func (s *SecurityQuestionService) audit(ctx context.Context, userID int, action string, detail any) {
+	details, err := json.Marshal(detail)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        models.UserActor(userID),
+			Action:       action,
+			TargetUserID: userID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
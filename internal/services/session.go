@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+)
+
+type SessionService struct {
+	SessionRepo interfaces.ISessionRepository
+}
+
+func (s *SessionService) GetSessions(ctx context.Context, userID int, req pagination.Request) (pagination.Page[models.SessionItem], error) {
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.SessionItem]{}, err
+	}
+
+	limit := req.GetLimit()
+	sessions, err := s.SessionRepo.GetUserSessionsByUserID(ctx, userID, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.SessionItem]{}, apperr.Wrap(err, "failed to get user sessions")
+	}
+
+	items := make([]models.SessionItem, 0, len(sessions))
+	for _, session := range sessions {
+		items = append(items, models.SessionItem{
+			ID:                  session.ID,
+			CreatedAt:           session.CreatedAt,
+			TokenExpired:        session.TokenExpired,
+			RefreshTokenExpired: session.RefreshTokenExpired,
+			IPAddress:           session.IPAddress,
+			UserAgent:           session.UserAgent,
+			Country:             session.Country,
+			City:                session.City,
+		})
+	}
+
+	return pagination.NewPage(items, limit, func(item models.SessionItem) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}), nil
+}
+
+// forgetTrustedDevices drops the user's trusted devices along with their
+// sessions, so a browser someone else trusted doesn't outlive a recovery. A
+// nil repository has none to forget.
+func forgetTrustedDevices(ctx context.Context, trustedDevices interfaces.ITrustedDeviceRepository, userID int) error {
+	if trustedDevices == nil {
+		return nil
+	}
+	if err := trustedDevices.DeleteTrustedDevices(ctx, userID); err != nil {
+		return apperr.Wrap(err, "failed to delete trusted devices")
+	}
+	return nil
+}
+
+// revokeUserSessions logs a user out everywhere, e.g. when they are banned,
+// and returns how many sessions were ended.
+func revokeUserSessions(ctx context.Context, sessionRepo interfaces.ISessionRepository, tokenCache interfaces.ITokenCacheRepository, userID int) (int, error) {
+	sessions, err := sessionRepo.GetActiveUserSessions(ctx, userID, time.Now())
+	if err != nil {
+		return 0, apperr.Wrap(err, "failed to get active sessions")
+	}
+
+	for i, session := range sessions {
+		if err := sessionRepo.DeleteUserSession(ctx, session.Token); err != nil {
+			return i, apperr.Wrap(err, "failed to delete session")
+		}
+		if err := tokenCache.RevokeToken(ctx, session.Token, session.TokenExpired); err != nil {
+			return i, apperr.Wrap(err, "failed to revoke token")
+		}
+	}
+	return len(sessions), nil
+}
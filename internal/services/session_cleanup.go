@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+)
+
+// defaultSessionCleanupBatchSize stands in for a BatchSize that isn't
+// positive, deleting batches of none would never finish.
+const defaultSessionCleanupBatchSize = 1000
+
+// SessionCleanupService deletes expired refresh tokens and reports the
+// number of active sessions. Expired sessions themselves are kept for the
+// user_session retention rule, which purges and audits them.
+type SessionCleanupService struct {
+	SessionRepo      interfaces.ISessionRepository
+	RefreshTokenRepo interfaces.IRefreshTokenRepository
+	BatchSize        int
+	Interval         time.Duration
+}
+
+func (s *SessionCleanupService) CleanupExpiredSessions(ctx context.Context) (int64, error) {
+	var total int64
+	now := time.Now()
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultSessionCleanupBatchSize
+	}
+
+	// delete in chunks so a large backlog doesn't hold one long lock on the table
+	for {
+		deleted, err := s.RefreshTokenRepo.DeleteExpiredRefreshTokens(ctx, now, batchSize)
+		if err != nil {
+			return total, apperr.Wrap(err, "failed to delete expired refresh tokens")
+		}
+		total += deleted
+
+		if deleted < int64(batchSize) {
+			break
+		}
+	}
+
+	active, err := s.SessionRepo.CountActiveUserSessions(ctx, now)
+	if err != nil {
+		return total, apperr.Wrap(err, "failed to count active sessions")
+	}
+	helpers.SessionsActive.Set(float64(active))
+
+	return total, nil
+}
+
+func (s *SessionCleanupService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.CleanupExpiredSessions(ctx)
+		if err != nil {
+			log.Error("failed on session cleanup: ", err)
+		} else {
+			log.Info("expired refresh tokens deleted: ", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+// StatsService serves the admin dashboard's aggregates. The daily series
+// are read from the daily_stats table the worker refreshes, active sessions
+// are counted live on their index. Each refresh also sets the
+// active_users_24h and kyc_pending_total gauges, so a scrape never queries.
+type StatsService struct {
+	StatsRepo   interfaces.IStatsRepository
+	SessionRepo interfaces.ISessionRepository
+	// ActiveUsers is optional, nil leaves active_users_24h unset.
+	ActiveUsers interfaces.IActiveUsersRepository
+	// Days are computed on the worker's first run, later runs only redo
+	// today and yesterday, which can still change.
+	Days     int
+	Interval time.Duration
+}
+
+var statsMetrics = []string{constants.StatsMetricRegistrations, constants.StatsMetricFailedLogins}
+
+func (s *StatsService) GetStats(ctx context.Context, days int) (models.AdminStats, error) {
+	now := time.Now()
+	stats := models.AdminStats{Days: days}
+
+	active, err := s.SessionRepo.CountActiveUserSessions(ctx, now)
+	if err != nil {
+		return stats, apperr.Wrap(err, "failed to count active sessions")
+	}
+	stats.ActiveSessions = active
+
+	firstDay := startOfDay(now).AddDate(0, 0, 1-days)
+	rows, err := s.StatsRepo.GetDailyStats(ctx, firstDay.Format(constants.StatsDayFormat))
+	if err != nil {
+		return stats, apperr.Wrap(err, "failed to get daily stats")
+	}
+
+	counts := map[string]map[string]int64{}
+	for _, metric := range statsMetrics {
+		counts[metric] = map[string]int64{}
+	}
+	for _, row := range rows {
+		if counts[row.Metric] != nil {
+			counts[row.Metric][row.Day] = row.Count
+		}
+		if stats.RefreshedAt == nil || row.UpdatedAt.After(*stats.RefreshedAt) {
+			stats.RefreshedAt = &row.UpdatedAt
+		}
+	}
+
+	for day := firstDay; !day.After(now); day = day.AddDate(0, 0, 1) {
+		key := day.Format(constants.StatsDayFormat)
+		stats.Registrations = append(stats.Registrations, models.DailyCount{Day: key, Count: counts[constants.StatsMetricRegistrations][key]})
+		stats.FailedLogins = append(stats.FailedLogins, models.DailyCount{Day: key, Count: counts[constants.StatsMetricFailedLogins][key]})
+	}
+	return stats, nil
+}
+
+// Refresh recomputes the daily stats of the last days up to now.
+func (s *StatsService) Refresh(ctx context.Context, now time.Time, days int) error {
+	since := startOfDay(now).AddDate(0, 0, 1-days)
+
+	registrations, err := s.StatsRepo.CountRegistrationsByDay(ctx, since)
+	if err != nil {
+		return apperr.Wrap(err, "failed to count registrations")
+	}
+	failedLogins, err := s.StatsRepo.CountFailedLoginsByDay(ctx, since)
+	if err != nil {
+		return apperr.Wrap(err, "failed to count failed logins")
+	}
+
+	// days without any are stored too, a count that dropped to zero, e.g.
+	// after a retention purge, must not keep its old value
+	stats := []models.DailyStat{}
+	for day := since; !day.After(now); day = day.AddDate(0, 0, 1) {
+		key := day.Format(constants.StatsDayFormat)
+		stats = append(stats,
+			models.DailyStat{Day: key, Metric: constants.StatsMetricRegistrations, Count: registrations[key]},
+			models.DailyStat{Day: key, Metric: constants.StatsMetricFailedLogins, Count: failedLogins[key]},
+		)
+	}
+	if err := s.StatsRepo.UpsertDailyStats(ctx, stats); err != nil {
+		return apperr.Wrap(err, "failed to save daily stats")
+	}
+	return s.refreshGauges(ctx, now)
+}
+
+func (s *StatsService) refreshGauges(ctx context.Context, now time.Time) error {
+	if s.ActiveUsers != nil {
+		active, err := s.ActiveUsers.CountActiveUsers(ctx, now, 24)
+		if err != nil {
+			return apperr.Wrap(err, "failed to count active users")
+		}
+		helpers.ActiveUsers24h.Set(float64(active))
+	}
+
+	pending, err := s.StatsRepo.CountUsersByKycStatus(ctx, constants.KycStatusPending)
+	if err != nil {
+		return apperr.Wrap(err, "failed to count pending kyc")
+	}
+	helpers.KycPending.Set(float64(pending))
+	return nil
+}
+
+// recordActiveUser counts the user towards active_users_24h. Failures are
+// logged, the user is let in either way.
+func recordActiveUser(ctx context.Context, activeUsers interfaces.IActiveUsersRepository, userID int) {
+	if activeUsers == nil {
+		return
+	}
+	if err := activeUsers.RecordActiveUser(ctx, userID, time.Now()); err != nil {
+		helpers.Logger.Error("failed to record active user: ", err)
+	}
+}
+
+// registrationChannel is the registrations_total channel of a user who
+// registered from platform.
+func registrationChannel(platform string) string {
+	if platform == "" {
+		return constants.RegistrationChannelUnknown
+	}
+	return platform
+}
+
+func (s *StatsService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	days := s.Days
+	for {
+		if err := s.Refresh(ctx, time.Now(), days); err != nil {
+			log.Error("failed on stats refresh: ", err)
+		} else {
+			days = 2
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
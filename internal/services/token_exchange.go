@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+var (
+	ErrInvalidSubjectToken = apperr.New(apperr.Invalid, "subject token is not a valid access token")
+	ErrExchangeNotAllowed  = apperr.New(apperr.Forbidden, "client may not exchange tokens for this audience")
+)
+
+type TokenExchangeService struct {
+	TokenValidationService interfaces.ITokenValidationService
+	AuditRepo              interfaces.IAuditRepository
+
+	// Policy lists the audiences each client may exchange user tokens for.
+	Policy map[string][]string
+	TTL    time.Duration
+}
+
+// Exchange trades a user's access token for a short-lived token restricted
+// to req.Audience, naming clientID as the actor. Delegated tokens can't be
+// exchanged again.
+func (s *TokenExchangeService) Exchange(ctx context.Context, clientID string, req models.TokenExchangeRequest) (models.OAuthTokenResponse, error) {
+	resp := models.OAuthTokenResponse{}
+
+	if req.SubjectTokenType != constants.TokenTypeURIAccess {
+		return resp, ErrInvalidSubjectToken
+	}
+	if req.RequestedTokenType != "" && req.RequestedTokenType != constants.TokenTypeURIAccess {
+		return resp, ErrExchangeNotAllowed
+	}
+	if !slices.Contains(s.Policy[clientID], req.Audience) {
+		return resp, ErrExchangeNotAllowed
+	}
+
+	subject, err := s.TokenValidationService.TokenValidation(ctx, req.SubjectToken)
+	if err != nil {
+		helpers.Logger.Info("failed to validate subject token: ", err)
+		return resp, ErrInvalidSubjectToken
+	}
+	if subject.Act != nil {
+		return resp, ErrInvalidSubjectToken
+	}
+
+	now := time.Now()
+	token, expiresAt, err := helpers.GenerateDelegatedToken(ctx, subject, req.Audience, clientID, s.TTL, now)
+	if err != nil {
+		return resp, apperr.Wrap(err, "failed to generate delegated token")
+	}
+
+	details, _ := json.Marshal(map[string]string{"audience": req.Audience})
+	event := &models.AuditEvent{
+		Actor:        models.ClientActor(clientID),
+		Action:       constants.AuditActionTokenExchanged,
+		TargetUserID: subject.UserID,
+		Details:      string(details),
+	}
+	if err := s.AuditRepo.InsertAuditEvent(ctx, event); err != nil {
+		helpers.Logger.Warn("failed to audit token exchange: ", err)
+	}
+
+	resp.AccessToken = token
+	resp.IssuedTokenType = constants.TokenTypeURIAccess
+	resp.TokenType = "Bearer"
+	resp.ExpiresIn = int64(expiresAt.Sub(now).Seconds())
+	return resp, nil
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+)
+
+var (
+	ErrTokenNotFound        = apperr.New(apperr.NotFound, "no session holds this token")
+	ErrRevokedTokenNotFound = apperr.New(apperr.NotFound, "token is not on the revocation list")
+)
+
+// TokenRevocationService revokes tokens in the database and the revocation
+// list, for incident response through the admin API or the token command.
+type TokenRevocationService struct {
+	SessionRepo      interfaces.ISessionRepository
+	RefreshTokenRepo interfaces.IRefreshTokenRepository
+	AuditRepo        interfaces.IAuditRepository
+	TokenCache       interfaces.ITokenCacheRepository
+	// TrustedDevices is optional, RevokeUserTokens also forgets the user's
+	// trusted devices with it.
+	TrustedDevices interfaces.ITrustedDeviceRepository
+}
+
+// RevokeUserTokens ends every session of the user and revokes all of their
+// refresh tokens, returning how many sessions were ended.
+func (s *TokenRevocationService) RevokeUserTokens(ctx context.Context, actor string, userID int, reason string) (int, error) {
+	revoked, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, userID)
+	if err == nil {
+		if err = s.RefreshTokenRepo.RevokeUserRefreshTokens(ctx, userID, time.Now()); err != nil {
+			err = apperr.Wrap(err, "failed to revoke refresh tokens")
+		}
+	}
+	if err == nil {
+		err = forgetTrustedDevices(ctx, s.TrustedDevices, userID)
+	}
+
+	s.audit(ctx, actor, constants.AuditActionTokenRevoked, userID, map[string]any{"reason": reason, "sessions_revoked": revoked})
+	return revoked, err
+}
+
+// RevokeTokenID ends the session holding the access or refresh token with
+// the jti tokenID, along with the token's whole refresh token family.
+func (s *TokenRevocationService) RevokeTokenID(ctx context.Context, actor, tokenID, reason string) (int, error) {
+	session, err := s.RefreshTokenRepo.GetUserSessionByTokenID(ctx, tokenID)
+	if err != nil {
+		return 0, apperr.Wrap(err, "failed to get user session")
+	}
+
+	familyID := session.FamilyID
+	if session.ID == 0 {
+		refreshToken, err := s.RefreshTokenRepo.GetRefreshTokenByTokenID(ctx, tokenID)
+		if err != nil {
+			return 0, apperr.Wrap(err, "failed to get refresh token")
+		}
+		if refreshToken.ID == 0 {
+			return 0, ErrTokenNotFound
+		}
+
+		familyID = refreshToken.FamilyID
+		if session, err = s.RefreshTokenRepo.GetUserSessionByFamilyID(ctx, familyID); err != nil {
+			return 0, apperr.Wrap(err, "failed to get user session")
+		}
+		// the family's session already ended, only the family is left
+		if session.ID == 0 {
+			session.UserID = refreshToken.UserID
+		}
+	}
+
+	revoked := 0
+	if session.ID != 0 {
+		if err := s.SessionRepo.DeleteUserSession(ctx, session.Token); err != nil {
+			return 0, apperr.Wrap(err, "failed to delete session")
+		}
+		if err := s.TokenCache.RevokeToken(ctx, session.Token, session.TokenExpired); err != nil {
+			return 0, apperr.Wrap(err, "failed to revoke token")
+		}
+		revoked = 1
+	}
+	if familyID != "" {
+		if err := s.RefreshTokenRepo.RevokeRefreshTokenFamily(ctx, familyID, time.Now()); err != nil {
+			return revoked, apperr.Wrap(err, "failed to revoke refresh token family")
+		}
+	}
+
+	s.audit(ctx, actor, constants.AuditActionTokenRevoked, session.UserID, map[string]any{"reason": reason, "token_id": tokenID, "sessions_revoked": revoked})
+	return revoked, nil
+}
+
+// RevokeTokens revokes by req's user or jti. A jti no session holds is put on
+// the revocation list by itself when req has a TTL, for tokens checked
+// against the list only, like delegated ones or all tokens under stateless
+// validation.
+func (s *TokenRevocationService) RevokeTokens(ctx context.Context, actor string, req models.RevokeTokensRequest) (int, error) {
+	if req.UserID != 0 {
+		return s.RevokeUserTokens(ctx, actor, req.UserID, req.Reason)
+	}
+
+	revoked, err := s.RevokeTokenID(ctx, actor, req.TokenID, req.Reason)
+	if !errors.Is(err, ErrTokenNotFound) || req.TTLSeconds == 0 {
+		return revoked, err
+	}
+
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	if err := s.TokenCache.RevokeTokenID(ctx, req.TokenID, time.Now().Add(ttl)); err != nil {
+		return 0, apperr.Wrap(err, "failed to revoke token id")
+	}
+	s.audit(ctx, actor, constants.AuditActionTokenRevoked, 0, map[string]any{"reason": req.Reason, "token_id": req.TokenID, "ttl_seconds": req.TTLSeconds})
+	return 0, nil
+}
+
+func (s *TokenRevocationService) GetRevokedTokens(ctx context.Context, req models.RevokedTokenListRequest) (pagination.Page[models.RevokedToken], error) {
+	tokens, next, err := s.TokenCache.GetRevokedTokens(ctx, req.Cursor, req.GetLimit())
+	if err != nil {
+		return pagination.Page[models.RevokedToken]{}, apperr.Wrap(err, "failed to get revoked tokens")
+	}
+
+	page := pagination.Page[models.RevokedToken]{Items: tokens}
+	if next != 0 {
+		page.NextCursor = strconv.FormatUint(next, 10)
+	}
+	return page, nil
+}
+
+// DeleteRevokedToken takes an entry off the revocation list, e.g. one added
+// by mistake. Sessions ended by the revocation stay ended, so this only
+// brings back tokens that are validated against the list alone.
+func (s *TokenRevocationService) DeleteRevokedToken(ctx context.Context, actor, key string) error {
+	deleted, err := s.TokenCache.DeleteRevokedToken(ctx, key)
+	if err != nil {
+		return apperr.Wrap(err, "failed to delete revoked token")
+	}
+	if !deleted {
+		return ErrRevokedTokenNotFound
+	}
+
+	s.audit(ctx, actor, constants.AuditActionTokenUnrevoked, 0, map[string]any{"key": key})
+	return nil
+}
+
+// audit failures are logged rather than returned, the revocation list is
+// already changed.
+func (s *TokenRevocationService) audit(ctx context.Context, actor, action string, userID int, details map[string]any) {
+	payload, err := json.Marshal(details)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        actor,
+			Action:       action,
+			TargetUserID: userID,
+			Details:      string(payload),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
@@ -2,28 +2,159 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"slices"
 
+	"ewallet-ums/constants"
 	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
 	"ewallet-ums/internal/interfaces"
 )
 
 type TokenValidationService struct {
-	UserRepo interfaces.IUserRepository
+	UserRepo    interfaces.IUserReader
+	SessionRepo interfaces.ISessionRepository
+	TokenCache  interfaces.ITokenCacheRepository
+	// Entitlements is optional, without it tokens validate with none.
+	Entitlements interfaces.IEntitlementService
+
+	// Stateless skips the user_sessions and users lookups and trusts the
+	// signature plus the revocation list, profile claims are the token's. It
+	// follows config reloads, nil is stateful.
+	Stateless *helpers.Reloadable[bool]
+}
+
+// TokenValidationForAudience validates the token for a service that names
+// its audience, tokens issued for another audience are rejected. An empty
+// audience only accepts tokens TokenValidation does, but delegated ones.
+func (s *TokenValidationService) TokenValidationForAudience(ctx context.Context, token, audience string) (*helpers.ClaimToken, error) {
+	claimToken, err := s.TokenValidation(ctx, token)
+	if err != nil {
+		return claimToken, err
+	}
+	// delegated tokens are only good for the audience they were exchanged for
+	if (audience != "" || claimToken.Act != nil) && !slices.Contains(claimToken.Audience, audience) {
+		helpers.EmitTokenValidationFailed(ctx, helpers.TokenFailureWrongAudience, claimToken)
+		return claimToken, apperr.New(apperr.Unauthorized, "token not issued for this audience")
+	}
+	return claimToken, nil
 }
 
 func (s *TokenValidationService) TokenValidation(ctx context.Context, token string) (*helpers.ClaimToken, error) {
-	var claimToken *helpers.ClaimToken
+	claimToken, err := s.TokenCache.GetTokenClaim(ctx, token)
+	if err == nil {
+		return claimToken, nil
+	}
 
-	claimToken, err := helpers.ValidateToken(ctx, token)
+	claimToken, err = helpers.ValidateToken(ctx, token)
 	if err != nil {
-		return claimToken, fmt.Errorf("failed to validate token: %v", err)
+		helpers.EmitTokenValidationFailed(ctx, helpers.TokenFailureInvalid, nil)
+		return claimToken, apperr.Wrap(err, "failed to validate token")
+	}
+	if claimToken.Type != "" && !claimToken.IsAccessToken() {
+		helpers.EmitTokenValidationFailed(ctx, helpers.TokenFailureWrongType, claimToken)
+		return claimToken, apperr.New(apperr.Unauthorized, "not an access token")
 	}
 
-	_, err = s.UserRepo.GetUserSessionByToken(ctx, token)
-	if err != nil {
-		return claimToken, fmt.Errorf("failed to get user session: %v", err)
+	// delegated tokens from token exchange have no session of their own,
+	// they live as long as the session of the token they were exchanged for.
+	// Untyped tokens predate the typ claim, only their session tells an
+	// access token from a refresh token.
+	stateless := s.Stateless != nil && s.Stateless.Get() && claimToken.Type != ""
+	if claimToken.Act != nil {
+		if err := delegatedSessionActive(ctx, s.SessionRepo, s.TokenCache, token, claimToken); err != nil {
+			return claimToken, err
+		}
+	} else if stateless {
+		revoked, err := s.TokenCache.IsTokenRevoked(ctx, token, claimToken.ID)
+		if err != nil {
+			return claimToken, apperr.Wrap(err, "failed to check token revocation")
+		}
+		if revoked {
+			helpers.EmitTokenValidationFailed(ctx, helpers.TokenFailureRevoked, claimToken)
+			return claimToken, apperr.New(apperr.Unauthorized, "token has been revoked")
+		}
+	} else {
+		_, err = s.SessionRepo.GetUserSessionByToken(ctx, token)
+		if err != nil {
+			helpers.EmitTokenValidationFailed(ctx, helpers.TokenFailureNoSession, claimToken)
+			return claimToken, apperr.Wrap(err, "failed to get user session")
+		}
+	}
+
+	if stateless && claimToken.Act == nil {
+		// no database read, the profile claims are the ones signed at issue
+		claimToken.Entitlements = signedEntitlements(claimToken)
+	} else if err := s.fillProfileClaims(ctx, claimToken); err != nil {
+		return claimToken, err
+	}
+
+	// delegated tokens aren't cached, so they end with the subject's session
+	// rather than with the cache entry
+	if claimToken.Act == nil {
+		if err := s.TokenCache.SetTokenClaim(ctx, token, claimToken); err != nil {
+			helpers.Logger.Warn("failed to cache token validation: ", err)
+		}
 	}
 
 	return claimToken, nil
 }
+
+// fillProfileClaims serves current profile data rather than what was signed
+// into the token, so claim changes show up as soon as the cache entry is
+// evicted.
+func (s *TokenValidationService) fillProfileClaims(ctx context.Context, claimToken *helpers.ClaimToken) error {
+	user, err := s.UserRepo.GetUserByID(ctx, claimToken.UserID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get user")
+	}
+	claimToken.Username = user.Username
+	claimToken.FullName = user.FullName
+	claimToken.Email = user.Email
+	claimToken.ProfileCompleteness = user.CalculateProfileCompleteness()
+	claimToken.Guest = user.Type == constants.UserTypeGuest
+	claimToken.Service = user.Type == constants.UserTypeService
+	if s.Entitlements != nil {
+		if claimToken.Entitlements, err = s.Entitlements.GetFeatures(ctx, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// signedEntitlements are the entitlements the token carries in Ext, see
+// EntitlementService.EnrichClaims.
+func signedEntitlements(claimToken *helpers.ClaimToken) []string {
+	values, _ := claimToken.Ext[constants.ClaimEntitlements].([]any)
+	var entitlements []string
+	for _, value := range values {
+		if entitlement, ok := value.(string); ok {
+			entitlements = append(entitlements, entitlement)
+		}
+	}
+	return entitlements
+}
+
+// delegatedSessionActive checks a delegated token is not revoked and the
+// session of the token it was exchanged for still holds that token, which
+// logout, force logout and refresh all end.
+func delegatedSessionActive(ctx context.Context, sessionRepo interfaces.ISessionRepository, tokenCache interfaces.ITokenCacheRepository, token string, claimToken *helpers.ClaimToken) error {
+	revoked, err := tokenCache.IsTokenRevoked(ctx, token, claimToken.ID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to check token revocation")
+	}
+	if revoked {
+		helpers.EmitTokenValidationFailed(ctx, helpers.TokenFailureRevoked, claimToken)
+		return apperr.New(apperr.Unauthorized, "token has been revoked")
+	}
+
+	if claimToken.SubjectTokenID == "" {
+		helpers.EmitTokenValidationFailed(ctx, helpers.TokenFailureNoSession, claimToken)
+		return apperr.New(apperr.Unauthorized, "delegated token without subject session")
+	}
+	session, err := sessionRepo.GetUserSessionByTokenID(ctx, claimToken.SubjectTokenID)
+	if err != nil || session.UserID != claimToken.UserID {
+		helpers.EmitTokenValidationFailed(ctx, helpers.TokenFailureNoSession, claimToken)
+		return apperr.New(apperr.Unauthorized, "subject session has ended")
+	}
+	return nil
+}
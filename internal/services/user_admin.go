@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownRole  = apperr.New(apperr.Invalid, "unknown role")
+	ErrUserNotFound = apperr.New(apperr.NotFound, "user not found")
+)
+
+// UserAdminService backs the UserAdminService gRPC API used by the back
+// office. Like the HTTP admin API, suspensions and role grants only take
+// effect once a second admin approves them, see AdminApprovalService.
+type UserAdminService struct {
+	AdminRepo   interfaces.IAdminRepository
+	UserRepo    interfaces.IUserRepository
+	SessionRepo interfaces.ISessionRepository
+	AuditRepo   interfaces.IAuditRepository
+	TokenCache  interfaces.ITokenCacheRepository
+	// Approvals holds requested suspensions and role grants for review.
+	Approvals interfaces.IAdminApprovalService
+	// PasswordHasher is only needed by CreateAdmin.
+	PasswordHasher interfaces.IPasswordHasher
+	// TrustedDevices is optional, ForceLogout also forgets the user's
+	// trusted devices with it.
+	TrustedDevices interfaces.ITrustedDeviceRepository
+}
+
+// GetUsers lists users newest first for the admin API.
+func (s *UserAdminService) GetUsers(ctx context.Context, req models.AdminUserListRequest) (pagination.Page[models.AdminUserSummary], error) {
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return pagination.Page[models.AdminUserSummary]{}, err
+	}
+
+	limit := req.GetLimit()
+	users, err := s.AdminRepo.GetUsers(ctx, req.AdminUserFilter, cursor, limit)
+	if err != nil {
+		return pagination.Page[models.AdminUserSummary]{}, apperr.Wrap(err, "failed to get users")
+	}
+
+	summaries := make([]models.AdminUserSummary, 0, len(users))
+	for _, user := range users {
+		summaries = append(summaries, models.AdminUserSummary{
+			ID:        user.ID,
+			Username:  user.Username,
+			FullName:  user.FullName,
+			Status:    user.Status,
+			Type:      user.Type,
+			KycStatus: user.KycStatus,
+			Version:   user.Version,
+			CreatedAt: user.CreatedAt,
+		})
+	}
+	return pagination.NewPage(summaries, limit, func(user models.AdminUserSummary) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: user.CreatedAt, ID: user.ID}
+	}), nil
+}
+
+// SuspendUser bans the user and ends their sessions straight away, for
+// automated suspensions such as fraud flags from another service. A
+// non-zero version must still be the user's, otherwise
+// ErrUserVersionConflict says which one is.
+func (s *UserAdminService) SuspendUser(ctx context.Context, actor string, userID, version int, reason string) (int, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return 0, apperr.Wrap(err, "failed to get user")
+	}
+
+	applied, err := s.AdminRepo.UpdateUserStatus(ctx, userID, version, constants.UserStatusBanned)
+	if err != nil {
+		return 0, apperr.Wrap(err, "failed to update user status")
+	}
+	if !applied {
+		if latest, err := s.UserRepo.GetUserByID(ctx, userID); err == nil {
+			user = latest
+		}
+		return 0, apperr.Wrapf(ErrUserVersionConflict, "latest version is %d", user.Version)
+	}
+
+	revoked, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, userID)
+	s.audit(ctx, actor, constants.AuditActionUserSuspended, userID, map[string]any{"reason": reason, "sessions_revoked": revoked})
+	return revoked, err
+}
+
+// RequestSuspension requests a ban_user approval, the user is banned and
+// logged out once another admin approves it. A non-zero version must still
+// be the user's when requested.
+func (s *UserAdminService) RequestSuspension(ctx context.Context, actor string, userID, version int, reason string) (models.AdminApproval, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.AdminApproval{}, apperr.Wrap(err, "failed to get user")
+	}
+	if version != 0 && user.Version != version {
+		return models.AdminApproval{}, apperr.Wrapf(ErrUserVersionConflict, "latest version is %d", user.Version)
+	}
+
+	return s.Approvals.RequestApprovalAs(ctx, actor, models.AdminApprovalRequest{
+		Action:       constants.AdminActionBanUser,
+		TargetUserID: userID,
+		Reason:       reason,
+	})
+}
+
+// RequestRole requests a grant_role approval, the role is granted once
+// another admin approves it.
+func (s *UserAdminService) RequestRole(ctx context.Context, actor string, userID int, role, reason string) (models.AdminApproval, error) {
+	if !slices.Contains([]string{constants.RoleAdmin, constants.RoleCompliance, constants.RolePIIReader}, role) {
+		return models.AdminApproval{}, ErrUnknownRole
+	}
+
+	payload, err := json.Marshal(models.GrantRolePayload{Role: role})
+	if err != nil {
+		return models.AdminApproval{}, err
+	}
+	return s.Approvals.RequestApprovalAs(ctx, actor, models.AdminApprovalRequest{
+		Action:       constants.AdminActionGrantRole,
+		TargetUserID: userID,
+		Payload:      payload,
+		Reason:       reason,
+	})
+}
+
+// ForceLogout ends every session of the user and revokes their access
+// tokens, e.g. when the account is reported compromised.
+func (s *UserAdminService) ForceLogout(ctx context.Context, actor string, userID int, reason string) (int, error) {
+	if _, err := s.UserRepo.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrUserNotFound
+		}
+		return 0, apperr.Wrap(err, "failed to get user")
+	}
+
+	revoked, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, userID)
+	if err == nil {
+		err = forgetTrustedDevices(ctx, s.TrustedDevices, userID)
+	}
+	s.audit(ctx, actor, constants.AuditActionUserForceLogout, userID, map[string]any{"reason": reason, "sessions_revoked": revoked})
+	return revoked, err
+}
+
+// CreateAdmin creates a user with the admin role and a generated password,
+// which is returned once and must be changed at the first login. It
+// bootstraps the first admin, who can then grant roles through approvals.
+func (s *UserAdminService) CreateAdmin(ctx context.Context, actor string, req models.CreateAdminRequest) (models.User, string, error) {
+	if _, err := s.UserRepo.GetUserByUsername(ctx, req.Username); err == nil {
+		return models.User{}, "", ErrUsernameTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.User{}, "", apperr.Wrap(err, "failed to get user")
+	}
+
+	email, err := helpers.NormalizeEmail(req.Email)
+	if err != nil {
+		return models.User{}, "", err
+	}
+	phoneNumber := ""
+	if req.PhoneNumber != "" {
+		if phoneNumber, err = helpers.NormalizePhoneNumber(req.PhoneNumber); err != nil {
+			return models.User{}, "", err
+		}
+	}
+
+	password := rand.Text()
+	hashed, err := s.PasswordHasher.HashPassword(ctx, password)
+	if err != nil {
+		return models.User{}, "", apperr.Wrap(err, "failed to hash password")
+	}
+
+	user := models.User{
+		Username:               req.Username,
+		Email:                  email,
+		PhoneNumber:            phoneNumber,
+		Password:               hashed,
+		PasswordChangeRequired: true,
+	}
+	if err := s.UserRepo.InsertNewUser(ctx, &user); err != nil {
+		return models.User{}, "", apperr.Wrap(err, "failed to insert user")
+	}
+	if err := s.AdminRepo.InsertUserRole(ctx, &models.UserRole{UserID: user.ID, Role: constants.RoleAdmin}); err != nil {
+		return models.User{}, "", apperr.Wrap(err, "failed to insert user role")
+	}
+
+	s.audit(ctx, actor, constants.AuditActionUserRoleAssigned, user.ID, map[string]any{"reason": "admin created", "role": constants.RoleAdmin})
+	return user, password, nil
+}
+
+// audit failures are logged rather than returned, the action has already
+// taken effect.
+func (s *UserAdminService) audit(ctx context.Context, actor, action string, userID int, details map[string]any) {
+	payload, err := json.Marshal(details)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        actor,
+			Action:       action,
+			TargetUserID: userID,
+			Details:      string(payload),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
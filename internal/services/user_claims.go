@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type UserClaimsService struct {
+	SessionRepo    interfaces.ISessionRepository
+	TokenCache     interfaces.ITokenCacheRepository
+	EventPublisher interfaces.IEventPublisher
+}
+
+// InvalidateUserClaims evicts every cached validation of the user's live
+// tokens and tells downstream services that the claims changed.
+func (s *UserClaimsService) InvalidateUserClaims(ctx context.Context, userID int, changedFields []string) error {
+	now := time.Now()
+
+	sessions, err := s.SessionRepo.GetActiveUserSessions(ctx, userID, now)
+	if err != nil {
+		return apperr.Wrap(err, "failed to get active sessions")
+	}
+
+	for _, session := range sessions {
+		if err := s.TokenCache.EvictToken(ctx, session.Token); err != nil {
+			return apperr.Wrap(err, "failed to evict cached token")
+		}
+	}
+
+	err = s.EventPublisher.Publish(ctx, constants.EventUserClaimsChanged, &events.UserClaimsChanged{
+		UserId:        int32(userID),
+		ChangedFields: changedFields,
+		OccurredAt:    timestamppb.New(now),
+	})
+	if err != nil {
+		return apperr.WrapAs(apperr.Upstream, err, "failed to publish claims changed event")
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// ErrUserExportNotFound is also returned for another admin's export, whose
+// columns were chosen by that admin's permissions.
+var ErrUserExportNotFound = apperr.New(apperr.NotFound, "user export not found")
+
+type userExportColumn struct {
+	Name  string
+	PII   bool
+	Node  parquet.Node
+	Value func(models.User) any
+}
+
+// userExportColumns are the export columns in CSV order. PII columns are only
+// written for requesters with the pii_reader role.
+var userExportColumns = []userExportColumn{
+	{Name: "id", Node: parquet.Int(64), Value: func(u models.User) any { return int64(u.ID) }},
+	{Name: "external_id", Node: parquet.Optional(parquet.String()), Value: func(u models.User) any {
+		if u.ExternalID == nil {
+			return nil
+		}
+		return *u.ExternalID
+	}},
+	{Name: "username", Node: parquet.String(), Value: func(u models.User) any { return u.Username }},
+	{Name: "full_name", PII: true, Node: parquet.String(), Value: func(u models.User) any { return u.FullName }},
+	{Name: "email", PII: true, Node: parquet.String(), Value: func(u models.User) any { return u.Email }},
+	{Name: "phone_number", PII: true, Node: parquet.String(), Value: func(u models.User) any { return u.PhoneNumber }},
+	{Name: "address", PII: true, Node: parquet.String(), Value: func(u models.User) any { return u.Address }},
+	{Name: "dob", PII: true, Node: parquet.String(), Value: func(u models.User) any { return u.Dob }},
+	{Name: "status", Node: parquet.String(), Value: func(u models.User) any { return u.Status }},
+	{Name: "kyc_status", Node: parquet.String(), Value: func(u models.User) any { return u.KycStatus }},
+	{Name: "profile_completeness", Node: parquet.Int(64), Value: func(u models.User) any { return int64(u.CalculateProfileCompleteness()) }},
+	{Name: "created_at", Node: parquet.Timestamp(parquet.Millisecond), Value: func(u models.User) any { return u.CreatedAt }},
+}
+
+// UserExportService builds back-office user reports in the background and
+// hands them out through short-lived signed object storage URLs.
+type UserExportService struct {
+	UserExportRepo interfaces.IUserExportRepository
+	AdminRepo      interfaces.IAdminRepository
+	AuditRepo      interfaces.IAuditRepository
+	ObjectStorage  interfaces.IObjectStorage
+
+	BatchSize int
+	Interval  time.Duration
+	// Timeout is how long a running export may take before another worker
+	// picks it up again.
+	Timeout time.Duration
+	URLTTL  time.Duration
+}
+
+func (s *UserExportService) RequestExport(ctx context.Context, requestedBy int, req models.UserExportRequest) (models.UserExport, error) {
+	roles, err := s.AdminRepo.GetUserRoles(ctx, requestedBy)
+	if err != nil {
+		return models.UserExport{}, apperr.Wrap(err, "failed to get user roles")
+	}
+
+	export := models.UserExport{
+		Format:      req.Format,
+		Status:      constants.UserExportStatusPending,
+		UserStatus:  req.UserStatus,
+		KycStatus:   req.KycStatus,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		IncludePII:  slices.Contains(roles, constants.RolePIIReader),
+		RequestedBy: requestedBy,
+	}
+	if err := s.UserExportRepo.InsertUserExport(ctx, &export); err != nil {
+		return export, apperr.Wrap(err, "failed to insert user export")
+	}
+
+	s.audit(ctx, constants.AuditActionUserExportRequested, export)
+	return export, nil
+}
+
+func (s *UserExportService) GetExport(ctx context.Context, exportID, requestedBy int) (models.UserExport, error) {
+	export, err := s.UserExportRepo.GetUserExportByID(ctx, exportID)
+	if err != nil {
+		return export, apperr.Wrap(err, "failed to get user export")
+	}
+	if export.RequestedBy != requestedBy {
+		return models.UserExport{}, ErrUserExportNotFound
+	}
+
+	if export.Status == constants.UserExportStatusCompleted {
+		if export.DownloadURL, err = s.ObjectStorage.PresignGetURL(export.ObjectKey, s.URLTTL); err != nil {
+			return export, apperr.WrapAs(apperr.Upstream, err, "failed to sign download url")
+		}
+	}
+	return export, nil
+}
+
+func (s *UserExportService) ProcessPendingExports(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	// exports are heavy, a few per tick is plenty
+	exports, err := s.UserExportRepo.GetPendingUserExports(ctx, now.Add(-s.Timeout), 5)
+	if err != nil {
+		return 0, apperr.Wrap(err, "failed to get pending user exports")
+	}
+
+	processed := 0
+	for i := range exports {
+		export := &exports[i]
+
+		claimed, err := s.UserExportRepo.ClaimUserExport(ctx, export, now)
+		if err != nil {
+			return processed, apperr.Wrap(err, "failed to claim user export")
+		}
+		if !claimed {
+			continue
+		}
+
+		export.Status = constants.UserExportStatusCompleted
+		if err := s.export(ctx, export); err != nil {
+			export.Status = constants.UserExportStatusFailed
+			export.Error = err.Error()
+		}
+		finishedAt := time.Now()
+		export.FinishedAt = &finishedAt
+
+		if err := s.UserExportRepo.UpdateUserExport(ctx, export); err != nil {
+			return processed, apperr.Wrap(err, "failed to update user export")
+		}
+		s.audit(ctx, constants.AuditActionUserExportCompleted, *export)
+		processed++
+	}
+
+	return processed, nil
+}
+
+// export writes the matching users to a temporary file, then uploads it.
+func (s *UserExportService) export(ctx context.Context, export *models.UserExport) error {
+	columns := []userExportColumn{}
+	for _, column := range userExportColumns {
+		if !column.PII || export.IncludePII {
+			columns = append(columns, column)
+		}
+	}
+
+	file, err := os.CreateTemp("", "user-export-*")
+	if err != nil {
+		return apperr.Wrap(err, "failed to create export file")
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	writer, contentType := newUserExportWriter(export.Format, file, columns)
+
+	export.RowCount = 0
+	for afterID := 0; ; {
+		users, err := s.UserExportRepo.GetUsersForExport(ctx, *export, afterID, s.BatchSize)
+		if err != nil {
+			return apperr.Wrap(err, "failed to get users")
+		}
+		if len(users) == 0 {
+			break
+		}
+
+		for _, user := range users {
+			if err := writer.Write(user); err != nil {
+				return apperr.Wrap(err, "failed to write export row")
+			}
+		}
+		export.RowCount += len(users)
+		afterID = users[len(users)-1].ID
+	}
+
+	if err := writer.Close(); err != nil {
+		return apperr.Wrap(err, "failed to finish export file")
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return apperr.Wrap(err, "failed to size export file")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return apperr.Wrap(err, "failed to rewind export file")
+	}
+
+	export.ObjectKey = fmt.Sprintf("user-exports/%d/users-%d.%s", export.ID, export.ID, export.Format)
+	if err := s.ObjectStorage.PutObject(ctx, export.ObjectKey, contentType, file, size); err != nil {
+		return apperr.WrapAs(apperr.Upstream, err, "failed to upload export")
+	}
+	return nil
+}
+
+func (s *UserExportService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := s.ProcessPendingExports(ctx)
+		if err != nil {
+			log.Error("failed on user export: ", err)
+		} else if processed > 0 {
+			log.Info("user exports processed: ", processed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// audit failures are logged rather than returned, like for admin approvals.
+func (s *UserExportService) audit(ctx context.Context, action string, export models.UserExport) {
+	details, err := json.Marshal(export)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:   models.UserActor(export.RequestedBy),
+			Action:  action,
+			Details: string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
+
+type userExportWriter interface {
+	Write(user models.User) error
+	Close() error
+}
+
+func newUserExportWriter(format string, w io.Writer, columns []userExportColumn) (userExportWriter, string) {
+	if format == constants.UserExportFormatParquet {
+		group := parquet.Group{}
+		for _, column := range columns {
+			group[column.Name] = column.Node
+		}
+		return &parquetUserExportWriter{
+			writer:  parquet.NewGenericWriter[map[string]any](w, parquet.NewSchema("user", group)),
+			columns: columns,
+		}, "application/vnd.apache.parquet"
+	}
+
+	return &csvUserExportWriter{writer: csv.NewWriter(w), columns: columns}, "text/csv"
+}
+
+type csvUserExportWriter struct {
+	writer        *csv.Writer
+	columns       []userExportColumn
+	headerWritten bool
+}
+
+func (c *csvUserExportWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+
+	header := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		header[i] = column.Name
+	}
+	return c.writer.Write(header)
+}
+
+func (c *csvUserExportWriter) Write(user models.User) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		switch value := column.Value(user).(type) {
+		case nil:
+		case int64:
+			record[i] = strconv.FormatInt(value, 10)
+		case time.Time:
+			record[i] = value.UTC().Format(time.RFC3339)
+		default:
+			record[i] = fmt.Sprint(value)
+		}
+	}
+	return c.writer.Write(record)
+}
+
+func (c *csvUserExportWriter) Close() error {
+	// an empty export still gets its header
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type parquetUserExportWriter struct {
+	writer  *parquet.GenericWriter[map[string]any]
+	columns []userExportColumn
+}
+
+func (p *parquetUserExportWriter) Write(user models.User) error {
+	row := make(map[string]any, len(p.columns))
+	for _, column := range p.columns {
+		row[column.Name] = column.Value(user)
+	}
+	_, err := p.writer.Write([]map[string]any{row})
+	return err
+}
+
+func (p *parquetUserExportWriter) Close() error {
+	return p.writer.Close()
+}
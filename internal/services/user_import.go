@@ -0,0 +1,327 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// maxUserImportErrors bounds the row errors kept on an import, FailedRows
+// still counts every rejected row.
+const maxUserImportErrors = 1000
+
+var errUserImportRowSkipped = errors.New("user already imported")
+
+// userImportColumns maps CSV header names to UserImportRow fields.
+var userImportColumns = map[string]func(*models.UserImportRow) *string{
+	"external_id":   func(r *models.UserImportRow) *string { return &r.ExternalID },
+	"username":      func(r *models.UserImportRow) *string { return &r.Username },
+	"email":         func(r *models.UserImportRow) *string { return &r.Email },
+	"phone_number":  func(r *models.UserImportRow) *string { return &r.PhoneNumber },
+	"full_name":     func(r *models.UserImportRow) *string { return &r.FullName },
+	"address":       func(r *models.UserImportRow) *string { return &r.Address },
+	"dob":           func(r *models.UserImportRow) *string { return &r.Dob },
+	"password":      func(r *models.UserImportRow) *string { return &r.Password },
+	"password_hash": func(r *models.UserImportRow) *string { return &r.PasswordHash },
+}
+
+// UserImportService imports users from a legacy system. Rows are keyed on
+// their external id, so re-running the same file skips users created by an
+// earlier run instead of duplicating them.
+type UserImportService struct {
+	UserImportRepo interfaces.IUserImportRepository
+	AuditRepo      interfaces.IAuditRepository
+	PasswordHasher interfaces.IPasswordHasher
+
+	// ProgressEvery is how many rows are processed between progress updates.
+	ProgressEvery int
+}
+
+func (s *UserImportService) CreateImport(ctx context.Context, actor, format string, dryRun bool) (models.UserImport, error) {
+	job := models.UserImport{
+		Format: format,
+		DryRun: dryRun,
+		Status: constants.UserImportStatusRunning,
+		Actor:  actor,
+	}
+	if err := s.UserImportRepo.InsertUserImport(ctx, &job); err != nil {
+		return job, apperr.Wrap(err, "failed to insert user import")
+	}
+	return job, nil
+}
+
+// StartImport runs the import in the background and returns straight away,
+// progress is polled with GetImport.
+func (s *UserImportService) StartImport(ctx context.Context, actor, format string, dryRun bool, data []byte) (models.UserImport, error) {
+	job, err := s.CreateImport(ctx, actor, format, dryRun)
+	if err != nil {
+		return job, err
+	}
+
+	running := job
+	go func() {
+		if err := s.RunImport(context.WithoutCancel(ctx), &running, bytes.NewReader(data)); err != nil {
+			helpers.Logger.Error("failed to run user import: ", err)
+		}
+	}()
+
+	return job, nil
+}
+
+func (s *UserImportService) GetImport(ctx context.Context, importID int) (models.UserImport, error) {
+	job, err := s.UserImportRepo.GetUserImportByID(ctx, importID)
+	if err != nil {
+		return job, apperr.Wrap(err, "failed to get user import")
+	}
+	return job, nil
+}
+
+// RunImport processes every row of r and records the outcome on job. Invalid
+// rows are reported and skipped, only an unreadable input fails the import.
+// In a dry run nothing is written and CreatedRows counts the rows that would
+// have been created.
+func (s *UserImportService) RunImport(ctx context.Context, job *models.UserImport, r io.Reader) error {
+	seenExternalIDs := map[string]bool{}
+	seenUsernames := map[string]bool{}
+
+	err := readUserImportRows(job.Format, r, func(line int, row models.UserImportRow, rowErr error) error {
+		if rowErr == nil {
+			rowErr = s.importRow(ctx, job.DryRun, row, seenExternalIDs, seenUsernames)
+		}
+
+		job.ProcessedRows++
+		switch {
+		case rowErr == nil:
+			job.CreatedRows++
+		case errors.Is(rowErr, errUserImportRowSkipped):
+			job.SkippedRows++
+		default:
+			job.FailedRows++
+			if len(job.Errors) < maxUserImportErrors {
+				job.Errors = append(job.Errors, models.UserImportRowError{Row: line, ExternalID: row.ExternalID, Error: rowErr.Error()})
+			}
+		}
+
+		if s.ProgressEvery > 0 && job.ProcessedRows%s.ProgressEvery == 0 {
+			helpers.Logger.Infof("user import %d: %d rows processed, %d created, %d skipped, %d failed", job.ID, job.ProcessedRows, job.CreatedRows, job.SkippedRows, job.FailedRows)
+			if err := s.UserImportRepo.UpdateUserImport(ctx, job); err != nil {
+				return apperr.Wrap(err, "failed to update user import")
+			}
+		}
+		return nil
+	})
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = constants.UserImportStatusCompleted
+	if err != nil {
+		job.Status = constants.UserImportStatusFailed
+		job.Errors = append(job.Errors, models.UserImportRowError{Error: err.Error()})
+	}
+
+	if updateErr := s.UserImportRepo.UpdateUserImport(ctx, job); updateErr != nil {
+		return apperr.Wrap(updateErr, "failed to update user import")
+	}
+	s.audit(ctx, job)
+
+	helpers.Logger.Infof("user import %d %s: %d rows processed, %d created, %d skipped, %d failed", job.ID, job.Status, job.ProcessedRows, job.CreatedRows, job.SkippedRows, job.FailedRows)
+	return err
+}
+
+func (s *UserImportService) importRow(ctx context.Context, dryRun bool, row models.UserImportRow, seenExternalIDs, seenUsernames map[string]bool) error {
+	if err := row.Validate(); err != nil {
+		return err
+	}
+
+	email, err := helpers.NormalizeEmail(row.Email)
+	if err != nil {
+		return err
+	}
+	phoneNumber, err := helpers.NormalizePhoneNumber(row.PhoneNumber)
+	if err != nil {
+		return err
+	}
+
+	if seenExternalIDs[row.ExternalID] {
+		return errors.New("duplicate external_id in file")
+	}
+	if seenUsernames[row.Username] {
+		return errors.New("duplicate username in file")
+	}
+
+	existingID, err := s.UserImportRepo.GetUserIDByExternalID(ctx, row.ExternalID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to look up external id")
+	}
+	if existingID != 0 {
+		seenExternalIDs[row.ExternalID] = true
+		return errUserImportRowSkipped
+	}
+
+	taken, err := s.UserImportRepo.UsernameExists(ctx, row.Username)
+	if err != nil {
+		return apperr.Wrap(err, "failed to look up username")
+	}
+	if taken {
+		return errors.New("username already taken")
+	}
+
+	password := row.PasswordHash
+	if password != "" {
+		if _, err := bcrypt.Cost([]byte(password)); err != nil {
+			return errors.New("password_hash is not a bcrypt hash")
+		}
+	} else if !dryRun {
+		if password, err = s.PasswordHasher.HashPassword(ctx, row.Password); err != nil {
+			return apperr.Wrap(err, "failed to hash password")
+		}
+	}
+
+	seenExternalIDs[row.ExternalID] = true
+	seenUsernames[row.Username] = true
+	if dryRun {
+		return nil
+	}
+
+	externalID := row.ExternalID
+	user := models.User{
+		Username:    row.Username,
+		Email:       email,
+		PhoneNumber: phoneNumber,
+		FullName:    row.FullName,
+		Address:     row.Address,
+		Dob:         row.Dob,
+		Password:    password,
+		ExternalID:  &externalID,
+	}
+	provisioning := models.WalletProvisioning{
+		Status:        constants.WalletStatusProvisioning,
+		NextAttemptAt: time.Now(),
+	}
+	if err := s.UserImportRepo.InsertImportedUser(ctx, &user, &provisioning); err != nil {
+		return apperr.Wrap(err, "failed to insert user")
+	}
+	helpers.RegistrationsTotal.WithLabelValues(constants.RegistrationChannelImport).Inc()
+	return nil
+}
+
+// audit failures are logged rather than returned, like for admin approvals.
+func (s *UserImportService) audit(ctx context.Context, job *models.UserImport) {
+	summary := *job
+	summary.Errors = nil
+
+	details, err := json.Marshal(summary)
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:   job.Actor,
+			Action:  constants.AuditActionUserImported,
+			Details: string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
+
+// readUserImportRows calls fn for every data row of r. Rows that can't be
+// parsed are passed with a non-nil rowErr, an error returned by fn or from
+// reading r stops the import.
+func readUserImportRows(format string, r io.Reader, fn func(line int, row models.UserImportRow, rowErr error) error) error {
+	switch format {
+	case constants.UserImportFormatCSV:
+		return readUserImportCSV(r, fn)
+	case constants.UserImportFormatNDJSON:
+		return readUserImportNDJSON(r, fn)
+	default:
+		return apperr.Newf(apperr.Invalid, "unknown import format %q", format)
+	}
+}
+
+func readUserImportCSV(r io.Reader, fn func(line int, row models.UserImportRow, rowErr error) error) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return apperr.Wrap(err, "failed to read csv header")
+	}
+
+	fields := make([]func(*models.UserImportRow) *string, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		field, ok := userImportColumns[name]
+		if !ok {
+			return apperr.Newf(apperr.Invalid, "unknown csv column %q", name)
+		}
+		fields[i] = field
+	}
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		row := models.UserImportRow{}
+		var rowErr error
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rowErr = apperr.Wrap(parseErr.Err, "invalid csv row")
+		} else if err != nil {
+			return apperr.Wrap(err, "failed to read csv")
+		} else {
+			for i, value := range record {
+				*fields[i](&row) = strings.TrimSpace(value)
+			}
+		}
+
+		if err := fn(line, row, rowErr); err != nil {
+			return err
+		}
+	}
+}
+
+func readUserImportNDJSON(r io.Reader, fn func(line int, row models.UserImportRow, rowErr error) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		line++
+
+		row := models.UserImportRow{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		var rowErr error
+		if err := decoder.Decode(&row); err != nil {
+			rowErr = apperr.Wrap(err, "invalid json row")
+		}
+
+		if err := fn(line, row, rowErr); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return apperr.Wrap(err, "failed to read ndjson")
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"ewallet-ums/cmd/proto/events"
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var ErrUserUnderLegalHold = apperr.New(apperr.Conflict, "user is under legal hold")
+
+// UserPurgeService hard deletes users on an admin's request once a
+// compliance officer approved it. Approval only queues the purge; the
+// user_purge job deletes the user and their data, ends their sessions and
+// announces it like a deletion followed by anonymization. Users under legal
+// hold can't be purged, a purge queued before a hold waits for it to be
+// lifted.
+type UserPurgeService struct {
+	PurgeRepo      interfaces.IUserPurgeRepository
+	LegalHoldRepo  interfaces.ILegalHoldRepository
+	SessionRepo    interfaces.ISessionRepository
+	AuditRepo      interfaces.IAuditRepository
+	TokenCache     interfaces.ITokenCacheRepository
+	EventPublisher interfaces.IEventPublisher
+
+	BatchSize int
+	Interval  time.Duration
+}
+
+// CheckPurge is called when a purge_user approval is requested, so a purge
+// that can't run isn't put to a second officer.
+func (s *UserPurgeService) CheckPurge(ctx context.Context, userID int) error {
+	held, err := s.LegalHoldRepo.HasActiveLegalHold(ctx, userID)
+	if err != nil {
+		return apperr.Wrap(err, "failed to check legal holds")
+	}
+	if held {
+		return ErrUserUnderLegalHold
+	}
+	return nil
+}
+
+// SchedulePurge executes an approved purge_user action.
+func (s *UserPurgeService) SchedulePurge(ctx context.Context, approval models.AdminApproval) error {
+	if err := s.CheckPurge(ctx, approval.TargetUserID); err != nil {
+		return err
+	}
+
+	purge := models.UserPurge{ApprovalID: approval.ID, UserID: approval.TargetUserID, Status: constants.UserPurgeStatusPending}
+	if err := s.PurgeRepo.InsertUserPurge(ctx, &purge); err != nil {
+		return apperr.Wrap(err, "failed to insert user purge")
+	}
+	return nil
+}
+
+// PurgeUsers runs the pending purges and returns how many users were
+// deleted. A purge that fails stays pending with its error.
+func (s *UserPurgeService) PurgeUsers(ctx context.Context, now time.Time) (int, error) {
+	purged := 0
+	for afterID := 0; ; {
+		purges, err := s.PurgeRepo.GetPendingUserPurges(ctx, afterID, s.BatchSize)
+		if err != nil {
+			return purged, apperr.Wrap(err, "failed to get pending user purges")
+		}
+		if len(purges) == 0 {
+			return purged, nil
+		}
+
+		for _, purge := range purges {
+			afterID = purge.ID
+
+			deleted, err := s.purge(ctx, purge, now)
+			if err != nil {
+				helpers.Logger.Errorf("failed to purge user %d: %v", purge.UserID, err)
+				if err := s.PurgeRepo.RecordUserPurgeFailure(ctx, purge.ID, err.Error()); err != nil {
+					return purged, apperr.Wrap(err, "failed to record user purge failure")
+				}
+				continue
+			}
+			if deleted {
+				purged++
+			}
+		}
+	}
+}
+
+func (s *UserPurgeService) purge(ctx context.Context, purge models.UserPurge, now time.Time) (bool, error) {
+	if err := s.CheckPurge(ctx, purge.UserID); err != nil {
+		return false, err
+	}
+	// tokens are revoked first, the sessions backing them are deleted with
+	// the user
+	if _, err := revokeUserSessions(ctx, s.SessionRepo, s.TokenCache, purge.UserID); err != nil {
+		return false, err
+	}
+
+	user, err := s.PurgeRepo.PurgeUser(ctx, purge, now)
+	if err != nil {
+		return false, apperr.Wrap(err, "failed to purge user")
+	}
+	if user == nil {
+		return false, nil
+	}
+
+	details, err := json.Marshal(map[string]any{"approval_id": purge.ApprovalID})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        constants.AuditActorSystem,
+			Action:       constants.AuditActionUserPurged,
+			TargetUserID: purge.UserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+
+	// a user that wasn't deleted yet still has a wallet to close
+	if !user.DeletedAt.Valid {
+		err := s.EventPublisher.Publish(ctx, constants.EventUserDeleted, &events.UserDeleted{UserId: int32(user.ID), OccurredAt: timestamppb.New(now)})
+		if err != nil {
+			helpers.Logger.Error("failed to publish user deleted event: ", err)
+		}
+	}
+	err = s.EventPublisher.Publish(ctx, constants.EventUserAnonymized, &events.UserAnonymized{UserId: int32(user.ID), OccurredAt: timestamppb.New(now)})
+	if err != nil {
+		helpers.Logger.Error("failed to publish user anonymized event: ", err)
+	}
+	return true, nil
+}
+
+func (s *UserPurgeService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.PurgeUsers(ctx, time.Now())
+		if err != nil {
+			log.Error("failed on user purge: ", err)
+		} else if purged > 0 {
+			log.Info("users purged: ", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
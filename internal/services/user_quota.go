@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+var ErrQuotaExceeded = apperr.New(apperr.RateLimited, "daily quota exceeded, try again after it resets")
+
+// UserQuotaService caps how often each user calls an expensive endpoint per
+// UTC day.
+type UserQuotaService struct {
+	QuotaRepo interfaces.IUserQuotaRepository
+	// Limits are the calls allowed per day by quota name. Quotas left out
+	// or zero are unlimited.
+	Limits map[string]int
+}
+
+// ConsumeQuota counts a call against the user's quota. It returns
+// ErrQuotaExceeded with the usage once the day's limit is used up, and a
+// usage with a zero Limit for unlimited quotas.
+func (s *UserQuotaService) ConsumeQuota(ctx context.Context, userID int, name string, now time.Time) (models.QuotaUsage, error) {
+	limit := s.Limits[name]
+	if limit <= 0 {
+		return models.QuotaUsage{Name: name}, nil
+	}
+
+	day, resetAt := quotaDay(now)
+	count, err := s.QuotaRepo.IncrUserQuota(ctx, name, userID, day, resetAt)
+	if err != nil {
+		return models.QuotaUsage{Name: name, Limit: limit, Remaining: limit, ResetAt: resetAt}, apperr.Wrap(err, "failed to count quota")
+	}
+
+	usage := newQuotaUsage(name, limit, count, resetAt)
+	if count > int64(limit) {
+		return usage, ErrQuotaExceeded
+	}
+	return usage, nil
+}
+
+// GetQuotaUsage returns the user's usage of every limited quota, by name.
+func (s *UserQuotaService) GetQuotaUsage(ctx context.Context, userID int, now time.Time) ([]models.QuotaUsage, error) {
+	names := make([]string, 0, len(s.Limits))
+	for name, limit := range s.Limits {
+		if limit > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	day, resetAt := quotaDay(now)
+	counts, err := s.QuotaRepo.GetUserQuotaCounts(ctx, names, userID, day)
+	if err != nil {
+		return nil, apperr.Wrap(err, "failed to get quota usage")
+	}
+
+	usage := make([]models.QuotaUsage, 0, len(names))
+	for _, name := range names {
+		usage = append(usage, newQuotaUsage(name, s.Limits[name], counts[name], resetAt))
+	}
+	return usage, nil
+}
+
+// quotaDay returns the UTC day of now and when it ends.
+func quotaDay(now time.Time) (time.Time, time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	return day, day.Add(24 * time.Hour)
+}
+
+// newQuotaUsage caps the count at limit, calls refused over it count too.
+func newQuotaUsage(name string, limit int, count int64, resetAt time.Time) models.QuotaUsage {
+	used := int(min(count, int64(limit)))
+	return models.QuotaUsage{Name: name, Limit: limit, Used: used, Remaining: limit - used, ResetAt: resetAt}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+)
+
+type WalletProvisioningService struct {
+	WalletProvisioningRepo interfaces.IWalletProvisioningRepository
+	ExternalWallet         interfaces.IWallet
+	BatchSize              int
+	MaxAttempts            int
+	Interval               time.Duration
+}
+
+func (s *WalletProvisioningService) GetWalletStatus(ctx context.Context, userID int) (models.WalletProvisioning, error) {
+	provisioning, err := s.WalletProvisioningRepo.GetWalletProvisioningByUserID(ctx, userID)
+	if err != nil {
+		return provisioning, apperr.Wrap(err, "failed to get wallet provisioning")
+	}
+	return provisioning, nil
+}
+
+func (s *WalletProvisioningService) ProcessPendingWallets(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	provisionings, err := s.WalletProvisioningRepo.GetPendingWalletProvisionings(ctx, now, s.BatchSize)
+	if err != nil {
+		return 0, apperr.Wrap(err, "failed to get pending wallet provisionings")
+	}
+
+	processed := 0
+	for i := range provisionings {
+		provisioning := &provisionings[i]
+
+		claimed, err := s.WalletProvisioningRepo.ClaimWalletProvisioning(ctx, provisioning, now.Add(s.backoff(provisioning.Attempts+1)))
+		if err != nil {
+			return processed, apperr.Wrap(err, "failed to claim wallet provisioning")
+		}
+		if !claimed {
+			continue
+		}
+
+		s.provision(ctx, provisioning)
+		if err := s.WalletProvisioningRepo.UpdateWalletProvisioning(ctx, provisioning); err != nil {
+			return processed, apperr.Wrap(err, "failed to update wallet provisioning")
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (s *WalletProvisioningService) provision(ctx context.Context, provisioning *models.WalletProvisioning) {
+	provisioning.Attempts++
+
+	wallet, err := s.ExternalWallet.CreateWallet(ctx, provisioning.UserID)
+	if err != nil {
+		provisioning.LastError = err.Error()
+		if provisioning.Attempts >= s.MaxAttempts {
+			provisioning.Status = constants.WalletStatusFailed
+		}
+		return
+	}
+
+	provisioning.Status = constants.WalletStatusCreated
+	provisioning.WalletID = wallet.ID
+	provisioning.LastError = ""
+}
+
+func (s *WalletProvisioningService) backoff(attempt int) time.Duration {
+	delay := s.Interval << min(attempt, 10)
+	return min(delay, time.Hour)
+}
+
+func (s *WalletProvisioningService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := s.ProcessPendingWallets(ctx)
+		if err != nil {
+			log.Error("failed on wallet provisioning: ", err)
+		} else if processed > 0 {
+			log.Info("wallet provisionings processed: ", processed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
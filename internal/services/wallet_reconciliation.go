@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ewallet-ums/constants"
+	"ewallet-ums/external"
+	"ewallet-ums/helpers"
+	"ewallet-ums/internal/apperr"
+	"ewallet-ums/internal/interfaces"
+	"ewallet-ums/internal/models"
+	"ewallet-ums/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+// ErrWalletProvisioningNotStuck is returned when retrying a user whose wallet
+// was created or is still being provisioned within its SLA.
+var ErrWalletProvisioningNotStuck = apperr.New(apperr.Conflict, "wallet provisioning has not failed or passed its sla")
+
+// WalletReconciliationService reports the wallets the provisioning worker
+// gave up on or hasn't created within SLA, and lets ops queue them again.
+type WalletReconciliationService struct {
+	WalletProvisioningRepo interfaces.IWalletProvisioningRepository
+	AuditRepo              interfaces.IAuditRepository
+	Alerter                interfaces.IAlerter
+
+	// SLA is how long a wallet may stay provisioning before it's reported.
+	SLA      time.Duration
+	Interval time.Duration
+}
+
+func (s *WalletReconciliationService) GetReport(ctx context.Context, req pagination.Request) (models.WalletReconciliationReport, error) {
+	report := models.WalletReconciliationReport{}
+
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return report, err
+	}
+
+	createdBefore := time.Now().Add(-s.SLA)
+	counts, err := s.WalletProvisioningRepo.CountStuckWalletProvisionings(ctx, createdBefore)
+	if err != nil {
+		return report, apperr.Wrap(err, "failed to count stuck wallet provisionings")
+	}
+	report.Failed = counts[constants.WalletStatusFailed]
+	report.PendingPastSLA = counts[constants.WalletStatusProvisioning]
+
+	limit := req.GetLimit()
+	provisionings, err := s.WalletProvisioningRepo.GetStuckWalletProvisionings(ctx, createdBefore, cursor, limit)
+	if err != nil {
+		return report, apperr.Wrap(err, "failed to get stuck wallet provisionings")
+	}
+
+	page := pagination.NewPage(provisionings, limit, func(provisioning models.WalletProvisioning) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: provisioning.CreatedAt, ID: provisioning.ID}
+	})
+	report.NextCursor = page.NextCursor
+	report.Items = make([]models.WalletReconciliationItem, 0, len(page.Items))
+	for _, provisioning := range page.Items {
+		report.Items = append(report.Items, models.WalletReconciliationItem{
+			UserID:    provisioning.UserID,
+			Status:    provisioning.Status,
+			Attempts:  provisioning.Attempts,
+			LastError: provisioning.LastError,
+			CreatedAt: provisioning.CreatedAt,
+			UpdatedAt: provisioning.UpdatedAt,
+		})
+	}
+	return report, nil
+}
+
+// RetryProvisioning resets the user's attempts, the provisioning worker
+// picks the wallet up on its next run.
+func (s *WalletReconciliationService) RetryProvisioning(ctx context.Context, actor string, userID int) error {
+	previous, err := s.WalletProvisioningRepo.GetWalletProvisioningByUserID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrWalletProvisioningNotStuck
+	}
+	if err != nil {
+		return apperr.Wrap(err, "failed to get wallet provisioning")
+	}
+
+	now := time.Now()
+	retried, err := s.WalletProvisioningRepo.RetryWalletProvisioning(ctx, userID, now.Add(-s.SLA), now)
+	if err != nil {
+		return apperr.Wrap(err, "failed to retry wallet provisioning")
+	}
+	if !retried {
+		return ErrWalletProvisioningNotStuck
+	}
+
+	s.audit(ctx, actor, previous)
+	return nil
+}
+
+// Reconcile updates the backlog metric and alerts ops while the backlog
+// isn't empty, returning the first page of the report.
+func (s *WalletReconciliationService) Reconcile(ctx context.Context) (models.WalletReconciliationReport, error) {
+	report, err := s.GetReport(ctx, pagination.Request{Limit: 10})
+	if err != nil {
+		return report, err
+	}
+
+	helpers.WalletProvisioningBacklog.WithLabelValues(constants.WalletStatusFailed).Set(float64(report.Failed))
+	helpers.WalletProvisioningBacklog.WithLabelValues(constants.WalletStatusProvisioning).Set(float64(report.PendingPastSLA))
+
+	if report.Failed+report.PendingPastSLA == 0 {
+		return report, nil
+	}
+
+	userIDs := []string{}
+	for _, item := range report.Items {
+		userIDs = append(userIDs, strconv.Itoa(item.UserID))
+	}
+	err = s.Alerter.Alert(ctx, external.Alert{
+		Title: "Wallet provisioning backlog",
+		Text:  fmt.Sprintf("%d wallets failed and %d are pending past the %s SLA, retry them from the admin reconciliation report.", report.Failed, report.PendingPastSLA, s.SLA),
+		Fields: map[string]string{
+			"failed":           strconv.FormatInt(report.Failed, 10),
+			"pending_past_sla": strconv.FormatInt(report.PendingPastSLA, 10),
+			"user_ids":         strings.Join(userIDs, ","),
+		},
+	})
+	if err != nil {
+		return report, apperr.Wrap(err, "failed to send alert")
+	}
+	return report, nil
+}
+
+func (s *WalletReconciliationService) Run(ctx context.Context) {
+	log := helpers.Logger
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.Reconcile(ctx)
+		if err != nil {
+			log.Error("failed on wallet reconciliation: ", err)
+		} else if report.Failed+report.PendingPastSLA > 0 {
+			log.Warnf("wallet provisioning backlog: %d failed, %d pending past sla", report.Failed, report.PendingPastSLA)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// audit failures are logged rather than returned, the retry is already
+// queued.
+func (s *WalletReconciliationService) audit(ctx context.Context, actor string, previous models.WalletProvisioning) {
+	details, err := json.Marshal(map[string]any{
+		"previous_status":   previous.Status,
+		"previous_attempts": previous.Attempts,
+		"last_error":        previous.LastError,
+	})
+	if err == nil {
+		err = s.AuditRepo.InsertAuditEvent(ctx, &models.AuditEvent{
+			Actor:        actor,
+			Action:       constants.AuditActionWalletProvisioningRetried,
+			TargetUserID: previous.UserID,
+			Details:      string(details),
+		})
+	}
+	if err != nil {
+		helpers.Logger.Error("failed to insert audit event: ", err)
+	}
+}
@@ -0,0 +1,89 @@
+//go:build integration || e2e
+
+// Package testenv boots MySQL, Redis and the fake wallet service for the
+// integration and e2e suites and points the global config at them.
+package testenv
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"ewallet-ums/external/walletfake"
+	"ewallet-ums/helpers"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mysql"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+type Env struct {
+	MySQL  *mysql.MySQLContainer
+	Redis  *tcredis.RedisContainer
+	Wallet *walletfake.Server
+}
+
+// Start runs the containers, fills helpers.Env and runs the regular
+// logger, database (including migrations) and redis setup.
+func Start(ctx context.Context, extraEnv map[string]string) (*Env, error) {
+	env := &Env{}
+
+	mysqlC, err := mysql.Run(ctx, "mysql:8.0",
+		mysql.WithDatabase("ewallet_ums"),
+		mysql.WithUsername("ums"),
+		mysql.WithPassword("password"),
+	)
+	env.MySQL = mysqlC
+	if err != nil {
+		env.Stop()
+		return nil, fmt.Errorf("failed to start mysql container: %v", err)
+	}
+
+	redisC, err := tcredis.Run(ctx, "redis:7-alpine")
+	env.Redis = redisC
+	if err != nil {
+		env.Stop()
+		return nil, fmt.Errorf("failed to start redis container: %v", err)
+	}
+
+	mysqlHost, _ := mysqlC.Host(ctx)
+	mysqlPort, _ := mysqlC.MappedPort(ctx, "3306/tcp")
+	redisHost, _ := redisC.Host(ctx)
+	redisPort, _ := redisC.MappedPort(ctx, "6379/tcp")
+
+	env.Wallet = walletfake.New()
+
+	helpers.Env = map[string]string{
+		"APP_NAME":               "ewallet-ums",
+		"APP_SECRET":             "test-secret",
+		"DB_HOST":                mysqlHost,
+		"DB_PORT":                mysqlPort.Port(),
+		"DB_NAME":                "ewallet_ums",
+		"DB_USER":                "ums",
+		"DB_PASSWORD":            "password",
+		"REDIS_HOST":             redisHost,
+		"REDIS_PORT":             redisPort.Port(),
+		"WALLET_HOST":            env.Wallet.URL,
+		"WALLET_ENDPOINT_CREATE": walletfake.CreateEndpoint,
+		"PII_MASTER_KEYS":        "test:" + base64.StdEncoding.EncodeToString(make([]byte, 32)),
+		"PII_ACTIVE_MASTER_KEY":  "test",
+	}
+	for key, val := range extraEnv {
+		helpers.Env[key] = val
+	}
+
+	helpers.SetupLogger()
+	helpers.SetupDatabase()
+	helpers.SetupPII()
+	helpers.SetupRedis()
+
+	return env, nil
+}
+
+func (e *Env) Stop() {
+	if e.Wallet != nil {
+		e.Wallet.Close()
+	}
+	testcontainers.TerminateContainer(e.Redis)
+	testcontainers.TerminateContainer(e.MySQL)
+}
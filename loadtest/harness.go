@@ -0,0 +1,226 @@
+// Package loadtest drives the auth endpoints of a running ewallet-ums
+// instance. The benchmarks and baseline checks live in loadtest_test.go
+// behind the loadtest build tag:
+//
+//	go test -tags loadtest -run Baseline ./loadtest
+//	go test -tags loadtest -bench . -run ^$ ./loadtest
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"ewallet-ums/cmd/proto/tokenvalidation"
+	"ewallet-ums/constants"
+	"ewallet-ums/internal/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+type Config struct {
+	HTTPURL  string
+	GRPCAddr string
+	Username string
+	Password string
+	// ServiceToken is a service account access token, which the gRPC API
+	// requires of its callers.
+	ServiceToken string
+	Requests     int
+	Concurrency  int
+}
+
+func ConfigFromEnv() Config {
+	return Config{
+		HTTPURL:      getEnv("LOADTEST_HTTP_URL", "http://127.0.0.1:8080"),
+		GRPCAddr:     getEnv("LOADTEST_GRPC_ADDR", "127.0.0.1:7000"),
+		Username:     getEnv("LOADTEST_USERNAME", "loadtest_user"),
+		Password:     getEnv("LOADTEST_PASSWORD", "loadtest_password"),
+		ServiceToken: getEnv("LOADTEST_SERVICE_TOKEN", ""),
+		Requests:     getEnvInt("LOADTEST_REQUESTS", 200),
+		Concurrency:  getEnvInt("LOADTEST_CONCURRENCY", 10),
+	}
+}
+
+type Client struct {
+	Config     Config
+	HTTPClient *http.Client
+	Validator  tokenvalidation.TokenValidationClient
+	conn       *grpc.ClientConn
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	conn, err := grpc.NewClient(cfg.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial grpc: %v", err)
+	}
+
+	return &Client{
+		Config:     cfg,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		Validator:  tokenvalidation.NewTokenValidationClient(conn),
+		conn:       conn,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Seed registers the load test user. An error from an already existing
+// user is ignored so the harness can be re-run against the same database.
+func (c *Client) Seed(ctx context.Context) {
+	_ = c.do(ctx, http.MethodPost, "/user/v1/register", "", models.User{
+		Username:    c.Config.Username,
+		Email:       c.Config.Username + "@loadtest.local",
+		PhoneNumber: "080000000000",
+		FullName:    "Load Test User",
+		Password:    c.Config.Password,
+	}, nil)
+}
+
+func (c *Client) Login(ctx context.Context) (models.LoginResponse, error) {
+	resp := models.LoginResponse{}
+	err := c.do(ctx, http.MethodPost, "/user/v1/login", "", models.LoginRequest{
+		Username: c.Config.Username,
+		Password: c.Config.Password,
+	}, &resp)
+	return resp, err
+}
+
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (models.RefreshTokenResponse, error) {
+	resp := models.RefreshTokenResponse{}
+	err := c.do(ctx, http.MethodPut, "/user/v1/refresh-token", refreshToken, nil, &resp)
+	return resp, err
+}
+
+func (c *Client) ValidateToken(ctx context.Context, token string) error {
+	if c.Config.ServiceToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.Config.ServiceToken)
+	}
+	resp, err := c.Validator.ValidateToken(ctx, &tokenvalidation.TokenRequest{Token: token})
+	if err != nil {
+		return err
+	}
+	if resp.GetMessage() != constants.SuccessMessage {
+		return fmt.Errorf("token validation failed: %s", resp.GetMessage())
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path, token string, body, result any) error {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return fmt.Errorf("failed to marshal request: %v", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.Config.HTTPURL+path, &payload)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("got status %d from %s", resp.StatusCode, path)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	envelope := struct {
+		Data any `json:"data"`
+	}{Data: result}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
+
+type Result struct {
+	Latencies []time.Duration
+	Errors    int
+}
+
+// Run calls fn the given number of times spread over concurrency workers
+// and collects the latency of every successful call.
+func Run(requests, concurrency int, fn func() error) Result {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		result Result
+		jobs   = make(chan struct{})
+	)
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				start := time.Now()
+				err := fn()
+				elapsed := time.Since(start)
+
+				mu.Lock()
+				if err != nil {
+					result.Errors++
+				} else {
+					result.Latencies = append(result.Latencies, elapsed)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for range requests {
+		jobs <- struct{}{}
+	}
+	close(jobs)
+	wg.Wait()
+
+	slices.Sort(result.Latencies)
+	return result
+}
+
+func (r Result) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	idx := int(float64(len(r.Latencies)-1) * p / 100)
+	return r.Latencies[idx]
+}
+
+func getEnv(key, val string) string {
+	if result := os.Getenv(key); result != "" {
+		return result
+	}
+	return val
+}
+
+func getEnvInt(key string, val int) int {
+	result, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return val
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+//go:build loadtest
+
+package loadtest
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func newClient(tb testing.TB) (*Client, context.Context) {
+	tb.Helper()
+
+	client, err := NewClient(ConfigFromEnv())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { client.Close() })
+
+	ctx := context.Background()
+	client.Seed(ctx)
+	return client, ctx
+}
+
+func login(tb testing.TB, client *Client, ctx context.Context) (string, string) {
+	tb.Helper()
+
+	resp, err := client.Login(ctx)
+	if err != nil {
+		tb.Fatal("failed to login load test user: ", err)
+	}
+	return resp.Token, resp.RefreshToken
+}
+
+// refreshChains hands out refresh tokens from n separate logins. Refresh
+// tokens are rotated and a reused one revokes its session, so each token is
+// used once and its successor put back.
+func refreshChains(tb testing.TB, client *Client, ctx context.Context, n int) func() error {
+	tb.Helper()
+
+	chains := make(chan string, n)
+	for range n {
+		_, refreshToken := login(tb, client, ctx)
+		chains <- refreshToken
+	}
+
+	return func() error {
+		refreshToken := <-chains
+		resp, err := client.RefreshToken(ctx, refreshToken)
+		if err != nil {
+			// the chain is dead, start another one for the next call
+			if fresh, loginErr := client.Login(ctx); loginErr == nil {
+				resp.RefreshToken = fresh.RefreshToken
+			} else {
+				resp.RefreshToken = refreshToken
+			}
+		}
+		chains <- resp.RefreshToken
+		return err
+	}
+}
+
+func TestLatencyBaseline(t *testing.T) {
+	client, ctx := newClient(t)
+	token, _ := login(t, client, ctx)
+	cfg := client.Config
+	refresh := refreshChains(t, client, ctx, cfg.Concurrency)
+
+	tests := []struct {
+		name string
+		p95  time.Duration
+		call func() error
+	}{
+		{
+			name: "login",
+			p95:  time.Duration(getEnvInt("LOADTEST_P95_LOGIN_MS", 400)) * time.Millisecond,
+			call: func() error {
+				_, err := client.Login(ctx)
+				return err
+			},
+		},
+		// validate before refresh: refreshing revokes the access token
+		{
+			name: "validate_token",
+			p95:  time.Duration(getEnvInt("LOADTEST_P95_VALIDATE_MS", 20)) * time.Millisecond,
+			call: func() error {
+				return client.ValidateToken(ctx, token)
+			},
+		},
+		{
+			name: "refresh_token",
+			p95:  time.Duration(getEnvInt("LOADTEST_P95_REFRESH_MS", 100)) * time.Millisecond,
+			call: refresh,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Run(cfg.Requests, cfg.Concurrency, tt.call)
+			if result.Errors > 0 {
+				t.Errorf("%d of %d requests failed", result.Errors, cfg.Requests)
+			}
+
+			p50, p95, p99 := result.Percentile(50), result.Percentile(95), result.Percentile(99)
+			t.Logf("p50=%s p95=%s p99=%s", p50, p95, p99)
+			if p95 > tt.p95 {
+				t.Errorf("p95 latency %s exceeds baseline %s", p95, tt.p95)
+			}
+		})
+	}
+}
+
+func BenchmarkLogin(b *testing.B) {
+	client, ctx := newClient(b)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.Login(ctx); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRefreshToken(b *testing.B) {
+	client, ctx := newClient(b)
+	refresh := refreshChains(b, client, ctx, runtime.GOMAXPROCS(0))
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := refresh(); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func BenchmarkValidateToken(b *testing.B) {
+	client, ctx := newClient(b)
+	token, _ := login(b, client, ctx)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := client.ValidateToken(ctx, token); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
@@ -1,19 +1,54 @@
 package main
 
 import (
+	"os"
+
 	"ewallet-ums/cmd"
 	"ewallet-ums/helpers"
 )
 
 func main() {
-	// load config
-	helpers.SetupConfig()
+	// load config, e.g. `ewallet-ums serve --config config/staging.env`
+	profile, args := helpers.ParseConfigFlag(os.Args[1:])
+	helpers.SetupConfig(profile)
 
 	// load log
 	helpers.SetupLogger()
 
 	// load database
-	helpers.SetupMySQL()
+	helpers.SetupDatabase()
+
+	// load pii encryption keys
+	helpers.SetupPII()
+
+	// load token claims enrichers
+	helpers.SetupClaimsEnrichers()
+
+	// load experiment rollouts
+	helpers.SetupExperiments()
+
+	// run cli command instead of the servers, e.g. `ewallet-ums seed`
+	if len(args) > 0 && args[0] != "serve" {
+		cmd.RunCommand(args[0], args[1:])
+		return
+	}
+
+	// load redis
+	helpers.SetupRedis()
+
+	// stream token events to a SIEM, when SIEM_URL is set
+	cmd.StartSIEM()
+
+	// start fakes of external services, e.g. `ewallet-ums serve --with-fakes`
+	if len(args) > 1 && cmd.ParseServeFlags(args[1:]).WithFakes {
+		cmd.StartFakes()
+	}
+
+	// refuse to start on a broken database, redis, schema or jwt config
+	cmd.SelfTest()
+
+	// run background jobs
+	go cmd.ServeWorker()
 
 	// run grpc
 	go cmd.ServeGRPC()